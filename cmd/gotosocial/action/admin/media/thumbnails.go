@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// RegenerateThumbnails regenerates thumbnails of all cached
+// attachments, using the current media-thumbnail-* settings.
+var RegenerateThumbnails action.GTSAction = func(ctx context.Context) error {
+	var state state.State
+
+	state.Caches.Init()
+	state.Caches.Start()
+
+	dbService, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %w", err)
+	}
+	state.DB = dbService
+
+	defer func() {
+		errs := gtserror.NewMultiError(1)
+		if err := dbService.Close(); err != nil {
+			errs.Appendf("error stopping database: %w", err)
+		}
+		state.Caches.Stop()
+		if err := errs.Combine(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	//nolint:contextcheck
+	storage, err := gtsstorage.AutoConfig()
+	if err != nil {
+		return fmt.Errorf("error creating storage backend: %w", err)
+	}
+	state.Storage = storage
+
+	//nolint:contextcheck
+	manager := media.NewManager(&state)
+
+	count, err := manager.RegenerateThumbnails(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "regenerated %d thumbnail(s)", count)
+	return nil
+}
@@ -183,7 +183,10 @@ var Start action.GTSAction = func(ctx context.Context) error {
 
 	// Build handlers used in later initializations.
 	mediaManager := media.NewManager(state)
-	oauthServer := oauth.New(ctx, dbService)
+	oauthServer, err := oauth.New(ctx, dbService)
+	if err != nil {
+		return fmt.Errorf("error creating oauth server: %w", err)
+	}
 	typeConverter := typeutils.NewConverter(state)
 	visFilter := visibility.NewFilter(state)
 	spamFilter := spam.NewFilter(state)
@@ -281,8 +284,33 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
 	}
 
+	// Schedule publishing of all existing scheduled statuses.
+	if err := processor.Status().SchedulePublishAll(ctx); err != nil {
+		return fmt.Errorf("error scheduling statuses: %w", err)
+	}
+
+	// Schedule deletion of all existing expiring statuses.
+	if err := processor.Status().ScheduleExpiryAll(ctx); err != nil {
+		return fmt.Errorf("error scheduling status expiries: %w", err)
+	}
+
+	// Schedule federating any statuses still held back by the federation delay.
+	if err := processor.Workers().SchedulePendingCreates(ctx); err != nil {
+		return fmt.Errorf("error scheduling delayed status federation: %w", err)
+	}
+
+	// Schedule regular re-verification of profile field links.
+	if err := processor.Account().ScheduleFieldVerification(); err != nil {
+		return fmt.Errorf("error scheduling field verification: %w", err)
+	}
+
+	// Schedule regular rotation of rotating pins.
+	if err := processor.Status().ScheduleRotatingPins(); err != nil {
+		return fmt.Errorf("error scheduling rotating pins: %w", err)
+	}
+
 	// Initialize metrics.
-	if err := metrics.Initialize(state.DB); err != nil {
+	if err := metrics.Initialize(state); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
@@ -375,7 +403,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		authModule        = api.NewAuth(dbService, processor, idp, routerSession, sessionName) // auth/oauth paths
 		clientModule      = api.NewClient(state, processor)                                    // api client endpoints
 		metricsModule     = api.NewMetrics()                                                   // Metrics endpoints
-		healthModule      = api.NewHealth(dbService.Ready)                                     // Health check endpoints
+		healthModule      = api.NewHealth(dbService.Ready, state.Storage.Ready)                // Health check endpoints
 		fileserverModule  = api.NewFileserver(processor)                                       // fileserver endpoints
 		wellKnownModule   = api.NewWellKnown(processor)                                        // .well-known endpoints
 		nodeInfoModule    = api.NewNodeInfo(processor)                                         // nodeinfo endpoint
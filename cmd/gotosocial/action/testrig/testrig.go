@@ -200,7 +200,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	processor := testrig.NewTestProcessor(state, federator, emailSender, mediaManager)
 
 	// Initialize metrics.
-	if err := metrics.Initialize(state.DB); err != nil {
+	if err := metrics.Initialize(state); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
@@ -282,7 +282,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		authModule        = api.NewAuth(state.DB, processor, idp, routerSession, sessionName) // auth/oauth paths
 		clientModule      = api.NewClient(state, processor)                                   // api client endpoints
 		metricsModule     = api.NewMetrics()                                                  // Metrics endpoints
-		healthModule      = api.NewHealth(state.DB.Ready)                                     // Health check endpoints
+		healthModule      = api.NewHealth(state.DB.Ready, state.Storage.Ready)                // Health check endpoints
 		fileserverModule  = api.NewFileserver(processor)                                      // fileserver endpoints
 		wellKnownModule   = api.NewWellKnown(processor)                                       // .well-known endpoints
 		nodeInfoModule    = api.NewNodeInfo(processor)                                        // nodeinfo endpoint
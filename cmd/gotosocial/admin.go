@@ -217,6 +217,18 @@ func adminCommands() *cobra.Command {
 	config.AddAdminMediaList(adminMediaListEmojisLocalCmd)
 	adminMediaCmd.AddCommand(adminMediaListEmojisLocalCmd)
 
+	adminMediaRegenerateThumbnailsCmd := &cobra.Command{
+		Use:   "regenerate-thumbnails",
+		Short: "regenerate thumbnails of stored attachments using the current media-thumbnail-* settings",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), media.RegenerateThumbnails)
+		},
+	}
+	adminMediaCmd.AddCommand(adminMediaRegenerateThumbnailsCmd)
+
 	/*
 		ADMIN MEDIA PRUNE COMMANDS
 	*/
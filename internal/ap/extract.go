@@ -436,6 +436,27 @@ func ExtractSummary(i WithSummary) string {
 	return ""
 }
 
+// ExtractPronouns extracts the 'pronouns' extension property
+// of the given Accountable, sanitized to plaintext. There's
+// no property for this in our vocab, so it's read from the
+// unknown properties of the type. Returns an empty string
+// if pronouns are not set, or are not a plain string.
+func ExtractPronouns(i Accountable) string {
+	withUnknown, ok := i.(interface {
+		GetUnknownProperties() map[string]interface{}
+	})
+	if !ok {
+		return ""
+	}
+
+	pronouns, ok := withUnknown.GetUnknownProperties()["pronouns"].(string)
+	if !ok {
+		return ""
+	}
+
+	return text.SanitizeToPlaintext(pronouns)
+}
+
 // ExtractFields extracts property/value fields from the given
 // WithAttachment interface. Will return an empty slice if no
 // property/value fields can be found. Attachments that are not
@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type ExtractPronounsTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractPronounsTestSuite) accountableWithPronouns(pronouns string) ap.Accountable {
+	t, _ := suite.jsonToType(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/someone",
+		"pronouns": ` + pronouns + `,
+		"type": "Person"
+	}`)
+
+	return t.(ap.Accountable)
+}
+
+func (suite *ExtractPronounsTestSuite) accountableWithoutPronouns() ap.Accountable {
+	t, _ := suite.jsonToType(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/someone",
+		"type": "Person"
+	}`)
+
+	return t.(ap.Accountable)
+}
+
+func (suite *ExtractPronounsTestSuite) TestExtractPronouns() {
+	for _, test := range []struct {
+		pronouns string
+		expect   string
+	}{
+		{pronouns: `"they/them"`, expect: "they/them"},
+		{pronouns: `"  <em>she/her</em> & they/them "`, expect: "she/her & they/them"},
+		{pronouns: `""`, expect: ""},
+		{pronouns: `["they/them"]`, expect: ""},
+		{pronouns: `{"en": "they/them"}`, expect: ""},
+		{pronouns: `null`, expect: ""},
+	} {
+		accountable := suite.accountableWithPronouns(test.pronouns)
+		suite.Equal(test.expect, ap.ExtractPronouns(accountable), test.pronouns)
+	}
+
+	// Not set at all.
+	suite.Empty(ap.ExtractPronouns(suite.accountableWithoutPronouns()))
+}
+
+func (suite *ExtractPronounsTestSuite) TestSerializePronouns() {
+	accountable := suite.accountableWithPronouns(`"they/them"`)
+
+	// Pronouns should be serialized as-is, and be
+	// defined in the context, as it's not in our vocab.
+	suite.Equal(`{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "pronouns": "https://gotosocial.org/ns#pronouns"
+    }
+  ],
+  "id": "https://example.org/users/someone",
+  "pronouns": "they/them",
+  "type": "Person"
+}`, suite.typeToJson(accountable))
+}
+
+func TestExtractPronounsTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractPronounsTestSuite{})
+}
@@ -510,6 +510,59 @@ func NormalizeOutgoingAlsoKnownAsProp(item WithAlsoKnownAs, rawJSON map[string]i
 	rawJSON["alsoKnownAs"] = []interface{}{alsoKnownAs}
 }
 
+// NormalizeOutgoingFeaturedTagsContext adds a json-ld
+// '@context' definition for the toot:featuredTags property,
+// if it's set on the given raw JSON. This property isn't
+// known to our vocab, so it's not defined automatically.
+//
+// Noop if featuredTags is not set, or there's no '@context'.
+func NormalizeOutgoingFeaturedTagsContext(rawJSON map[string]interface{}) {
+	if _, ok := rawJSON["featuredTags"]; !ok {
+		// No 'featuredTags',
+		// nothing to change.
+		return
+	}
+
+	featuredTagsDef := map[string]interface{}{
+		"featuredTags": map[string]interface{}{
+			"@id":   "http://joinmastodon.org/ns#featuredTags",
+			"@type": "@id",
+		},
+	}
+
+	switch context := rawJSON["@context"].(type) {
+	case []interface{}:
+		rawJSON["@context"] = append(context, featuredTagsDef)
+	case string, map[string]interface{}:
+		rawJSON["@context"] = []interface{}{context, featuredTagsDef}
+	}
+}
+
+// NormalizeOutgoingPronounsContext adds a json-ld
+// '@context' definition for the gts:pronouns property,
+// if it's set on the given raw JSON. This property isn't
+// known to our vocab, so it's not defined automatically.
+//
+// Noop if pronouns is not set, or there's no '@context'.
+func NormalizeOutgoingPronounsContext(rawJSON map[string]interface{}) {
+	if _, ok := rawJSON["pronouns"]; !ok {
+		// No 'pronouns',
+		// nothing to change.
+		return
+	}
+
+	pronounsDef := map[string]interface{}{
+		"pronouns": "https://gotosocial.org/ns#pronouns",
+	}
+
+	switch context := rawJSON["@context"].(type) {
+	case []interface{}:
+		rawJSON["@context"] = append(context, pronounsDef)
+	case string, map[string]interface{}:
+		rawJSON["@context"] = []interface{}{context, pronounsDef}
+	}
+}
+
 // NormalizeOutgoingContentProp normalizes go-fed's funky formatting of content and
 // contentMap properties to a format better understood by other AP implementations.
 //
@@ -36,7 +36,7 @@ import (
 //
 //   - OrderedCollection:       'orderedItems' property will always be made into an array.
 //   - OrderedCollectionPage:   'orderedItems' property will always be made into an array.
-//   - Any Accountable type:    'attachment' property will always be made into an array.
+//   - Any Accountable type:    'attachment' property will always be made into an array; 'featuredTags' and 'pronouns' will be defined in '@context'.
 //   - Any Statusable type:     'attachment' property will always be made into an array; 'content' and 'contentMap' will be normalized.
 //   - Any Activityable type:   any 'object's set on an activity will be custom serialized as above.
 func Serialize(t vocab.Type) (m map[string]interface{}, e error) {
@@ -127,6 +127,11 @@ func serializeAccountable(t vocab.Type, includeContext bool) (map[string]interfa
 	NormalizeOutgoingAttachmentProp(accountable, data)
 	NormalizeOutgoingAlsoKnownAsProp(accountable, data)
 
+	if includeContext {
+		NormalizeOutgoingFeaturedTagsContext(data)
+		NormalizeOutgoingPronounsContext(data)
+	}
+
 	return data, nil
 }
 
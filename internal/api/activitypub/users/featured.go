@@ -95,3 +95,70 @@ func (m *Module) FeaturedCollectionGETHandler(c *gin.Context) {
 
 	apiutil.JSONType(c, http.StatusOK, contentType, resp)
 }
+
+// FeaturedTagsCollectionGETHandler swagger:operation GET /users/{username}/collections/tags s2sFeaturedTagsCollectionGet
+//
+// Get the featured tags collection (hashtags featured on the profile) for a user.
+//
+// The response will contain an ordered collection of Hashtag objects in the `orderedItems` property.
+//
+// HTTP signature is required on the request.
+//
+//	---
+//	tags:
+//	- s2s/federation
+//
+//	produces:
+//	- application/activity+json
+//
+//	parameters:
+//	-
+//		name: username
+//		type: string
+//		description: Account name of the user
+//		in: path
+//		required: true
+//
+//	responses:
+//		'200':
+//			in: body
+//			schema:
+//				"$ref": "#/definitions/swaggerFeaturedTagsCollection"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+func (m *Module) FeaturedTagsCollectionGETHandler(c *gin.Context) {
+	// usernames on our instance are always lowercase
+	requestedUsername := strings.ToLower(c.Param(UsernameKey))
+	if requestedUsername == "" {
+		err := errors.New("no username specified in request")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	contentType, err := apiutil.NegotiateAccept(c, apiutil.ActivityPubOrHTMLHeaders...)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if contentType == string(apiutil.TextHTML) {
+		// This isn't an ActivityPub request;
+		// redirect to the user's profile.
+		c.Redirect(http.StatusSeeOther, "/@"+requestedUsername)
+		return
+	}
+
+	resp, errWithCode := m.processor.Fedi().FeaturedTagsCollectionGet(c.Request.Context(), requestedUsername)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSONType(c, http.StatusOK, contentType, resp)
+}
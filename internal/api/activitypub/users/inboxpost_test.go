@@ -121,6 +121,18 @@ func (suite *InboxPostTestSuite) inboxPost(
 	}
 }
 
+// waitForInbox waits for activities POSTed to inboxes,
+// which are processed asynchronously, to be processed,
+// so that it's safe to assert something *didn't* happen.
+func (suite *InboxPostTestSuite) waitForInbox() {
+	if !testrig.WaitFor(func() bool {
+		return suite.state.Workers.Inbox.Len() == 0 &&
+			suite.state.Workers.Federator.Queue.Len() == 0
+	}) {
+		suite.FailNow("timed out waiting for inbox to drain")
+	}
+}
+
 func (suite *InboxPostTestSuite) newBlock(blockID string, blockingAccount *gtsmodel.Account, blockedAccount *gtsmodel.Account) vocab.ActivityStreamsBlock {
 	block := streams.NewActivityStreamsBlock()
 
@@ -582,6 +594,11 @@ func (suite *InboxPostTestSuite) TestPostFromBlockedAccountToOtherAccount() {
 		suite.signatureCheck,
 	)
 
+	// Wait for any processing of the
+	// activity, before checking that
+	// it didn't create the status.
+	suite.waitForInbox()
+
 	_, err := suite.state.DB.GetStatusByURI(context.Background(), statusURI)
 	suite.ErrorIs(err, db.ErrNoEntries)
 }
@@ -50,12 +50,22 @@ const (
 
 	// OauthTokenPath is the API path to use for granting token requests to users with valid credentials
 	OauthTokenPath = "/token" // #nosec G101 else we get a hardcoded credentials warning
+	// OauthRevokePath is the API path for revoking access or refresh tokens which are no longer needed
+	OauthRevokePath = "/revoke"
+	// OauthIntrospectPath is the API path for introspecting access or refresh tokens
+	OauthIntrospectPath = "/introspect"
+	// OauthRegisterPath is the API path for dynamic client registration
+	OauthRegisterPath = "/register"
+	// OauthPARPath is the API path for pushed authorization requests
+	OauthPARPath = "/par"
 	// OauthAuthorizePath is the API path for authorization requests (eg., authorize this app to act on my behalf as a user)
 	OauthAuthorizePath = "/authorize"
 	// OauthFinalizePath is the API path for completing user registration with additional user details
 	OauthFinalizePath = "/finalize"
 	// OauthOobTokenPath is the path for serving an html representation of an oob token page.
 	OauthOobTokenPath = "/oob" // #nosec G101 else we get a hardcoded credentials warning
+	// OauthJWKSPath is the API path for serving the public keys to validate JWT access tokens with
+	OauthJWKSPath = "/jwks"
 
 	/*
 		params / session keys
@@ -73,6 +83,10 @@ const (
 	sessionClientState   = "client_state"
 	sessionClaims        = "claims"
 	sessionAppID         = "app_id"
+
+	sessionCodeChallenge       = "code_challenge"
+	sessionCodeChallengeMethod = "code_challenge_method"
+	sessionDeviceName          = "device_name"
 )
 
 type Module struct {
@@ -102,10 +116,15 @@ func (m *Module) RouteAuth(attachHandler func(method string, path string, f ...g
 // RouteOauth routes all paths that should have an 'oauth' prefix
 func (m *Module) RouteOauth(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodPost, OauthTokenPath, m.TokenPOSTHandler)
+	attachHandler(http.MethodPost, OauthRevokePath, m.RevokePOSTHandler)
+	attachHandler(http.MethodPost, OauthIntrospectPath, m.IntrospectPOSTHandler)
+	attachHandler(http.MethodPost, OauthRegisterPath, m.RegisterPOSTHandler)
+	attachHandler(http.MethodPost, OauthPARPath, m.PARPOSTHandler)
 	attachHandler(http.MethodGet, OauthAuthorizePath, m.AuthorizeGETHandler)
 	attachHandler(http.MethodPost, OauthAuthorizePath, m.AuthorizePOSTHandler)
 	attachHandler(http.MethodPost, OauthFinalizePath, m.FinalizePOSTHandler)
 	attachHandler(http.MethodGet, OauthOobTokenPath, m.OobHandler)
+	attachHandler(http.MethodGet, OauthJWKSPath, m.JWKSGETHandler)
 }
 
 func (m *Module) clearSession(s sessions.Session) {
@@ -64,8 +64,14 @@ type AuthStandardTestSuite struct {
 }
 
 const (
-	sessionUserID   = "userid"
-	sessionClientID = "client_id"
+	sessionUserID       = "userid"
+	sessionClientID     = "client_id"
+	sessionRedirectURI  = "redirect_uri"
+	sessionResponseType = "response_type"
+	sessionScope        = "scope"
+
+	sessionClientState   = "client_state"
+	sessionCodeChallenge = "code_challenge"
 )
 
 func (suite *AuthStandardTestSuite) SetupSuite() {
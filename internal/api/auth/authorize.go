@@ -18,6 +18,7 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -31,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -56,12 +58,33 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 			return
 		}
 
+		if form.RequestURI != "" {
+			// Client pushed the authorization
+			// parameters to us in advance, use
+			// those instead of any in the request.
+			var errWithCode gtserror.WithCode
+			form, errWithCode = m.loadPushedAuthForm(c, form)
+			if errWithCode != nil {
+				m.clearSession(s)
+				apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+				return
+			}
+		}
+
 		if errWithCode := saveAuthFormToSession(s, form); errWithCode != nil {
 			m.clearSession(s)
 			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 			return
 		}
 
+		// Check the redirect uri now, so the user
+		// isn't asked to sign in for a doomed request.
+		if errWithCode := m.validateRedirectURI(c.Request.Context(), form.ClientID, form.RedirectURI); errWithCode != nil {
+			m.clearSession(s)
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
 		c.Redirect(http.StatusSeeOther, "/auth"+AuthSignInPath)
 		return
 	}
@@ -130,6 +153,12 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := m.validateRedirectURI(c.Request.Context(), clientID, redirect); errWithCode != nil {
+		m.clearSession(s)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	scope, ok := s.Get(sessionScope).(string)
 	if !ok || scope == "" {
 		m.clearSession(s)
@@ -138,6 +167,46 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 		return
 	}
 
+	// Check the requested scope is valid and was registered for
+	// the app, so the user isn't asked to approve an impossible
+	// request (this is also enforced when the token is generated).
+	if err := oauth.ValidateScopes(scope); err != nil {
+		m.clearSession(s)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !oauth.ScopesSubset(scope, app.Scopes) {
+		m.clearSession(s)
+		err := fmt.Errorf("requested scope %q exceeds scopes registered for application: %q", scope, app.Scopes)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if oauth.IsDemoClient(app.ClientID) && !oauth.ScopesSubset(scope, oauth.ScopeRead) {
+		m.clearSession(s)
+		err := fmt.Errorf("requested scope %q exceeds read scopes permitted for demo application", scope)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	// If the user already approved this app for
+	// (at least) this scope, and asked us to remember
+	// that, skip asking them again and go straight to
+	// issuing a code, as if they'd approved it now.
+	consent, err := m.db.GetConsent(c.Request.Context(), user.ID, clientID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		m.clearSession(s)
+		safe := fmt.Sprintf("consent for client %s could not be retrieved", clientID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, safe, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if consent != nil && oauth.ScopesSubset(scope, consent.Scope) {
+		m.AuthorizePOSTHandler(c)
+		return
+	}
+
 	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -168,6 +237,9 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 // AuthorizePOSTHandler should be served as POST at https://example.org/oauth/authorize
 // At this point we assume that the user has A) logged in and B) accepted that the app should act for them,
 // so we should proceed with the authentication flow and generate an oauth token for them if we can.
+//
+// If the user ticked "remember" when accepting, their consent is stored, so that AuthorizeGETHandler
+// can skip straight to here the next time the app asks for authorization with the same (or a narrower) scope.
 func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 	s := sessions.Default(c)
 
@@ -205,6 +277,19 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		clientState = s
 	}
 
+	var codeChallenge, codeChallengeMethod string
+	if s, ok := s.Get(sessionCodeChallenge).(string); ok {
+		codeChallenge = s
+	}
+	if s, ok := s.Get(sessionCodeChallengeMethod).(string); ok {
+		codeChallengeMethod = s
+	}
+
+	var deviceName string
+	if s, ok := s.Get(sessionDeviceName).(string); ok {
+		deviceName = s
+	}
+
 	userID, ok := s.Get(sessionUserID).(string)
 	if !ok {
 		errs = append(errs, fmt.Sprintf("key %s was not found in session", sessionUserID))
@@ -248,6 +333,23 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if c.PostForm("remember") == "true" {
+		// User wants us to remember they approved
+		// this scope, replacing any previous consent.
+		consent := &gtsmodel.Consent{
+			ID:       id.NewULID(),
+			UserID:   userID,
+			ClientID: clientID,
+			Scope:    scope,
+		}
+		if err := m.db.PutConsent(c.Request.Context(), consent); err != nil {
+			m.clearSession(s)
+			safe := fmt.Sprintf("consent for client %s could not be stored", clientID)
+			apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, safe, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+			return
+		}
+	}
+
 	if redirectURI != oauth.OOBURI {
 		// we're done with the session now, so just clear it out
 		m.clearSession(s)
@@ -268,11 +370,67 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		c.Request.Form.Set("state", clientState)
 	}
 
+	if codeChallenge != "" {
+		// Client is using PKCE, pass the challenge
+		// on so that it's stored with the auth code.
+		c.Request.Form.Set(sessionCodeChallenge, codeChallenge)
+		c.Request.Form.Set(sessionCodeChallengeMethod, codeChallengeMethod)
+	}
+
+	if deviceName != "" {
+		c.Request.Form.Set(oauth.DeviceNameKey, deviceName)
+	}
+
 	if errWithCode := m.processor.OAuthHandleAuthorizeRequest(c.Writer, c.Request); errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 	}
 }
 
+// validateRedirectURI checks that the given redirect uri matches
+// one of those registered for the client with the given id.
+func (m *Module) validateRedirectURI(ctx context.Context, clientID string, redirectURI string) gtserror.WithCode {
+	client, err := m.db.GetClientByID(ctx, clientID)
+	if err != nil {
+		safe := fmt.Sprintf("client %s could not be retrieved", clientID)
+		if errors.Is(err, db.ErrNoEntries) {
+			return gtserror.NewErrorBadRequest(err, safe, oauth.HelpfulAdvice)
+		}
+		return gtserror.NewErrorInternalError(err, safe, oauth.HelpfulAdvice)
+	}
+
+	if !oauth.RedirectURIAllowed(client.RedirectURIs, redirectURI) {
+		err := fmt.Errorf("redirect_uri %s is not registered for this client", redirectURI)
+		return gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice)
+	}
+
+	return nil
+}
+
+// loadPushedAuthForm returns the authorization request parameters that
+// were pushed in advance under the request_uri of the given form. To
+// prevent pushed parameters being tampered with or added to in the
+// front channel, the request must not contain any parameters other
+// than request_uri and client_id.
+func (m *Module) loadPushedAuthForm(c *gin.Context, form *apimodel.OAuthAuthorize) (*apimodel.OAuthAuthorize, gtserror.WithCode) {
+	for key := range c.Request.URL.Query() {
+		if key != "request_uri" && key != "client_id" {
+			err := fmt.Errorf("request_uri must not be combined with other authorization parameters, but %s was also set", key)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice)
+		}
+	}
+
+	if form.ClientID == "" {
+		err := errors.New("client_id must be set along with request_uri")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice)
+	}
+
+	return m.processor.OAuthLoadPushedAuthorizeRequest(
+		c.Request.Context(),
+		form.ClientID,
+		form.RequestURI,
+	)
+}
+
 // saveAuthFormToSession checks the given OAuthAuthorize form,
 // and stores the values in the form into the session.
 func saveAuthFormToSession(s sessions.Session, form *apimodel.OAuthAuthorize) gtserror.WithCode {
@@ -309,6 +467,9 @@ func saveAuthFormToSession(s sessions.Session, form *apimodel.OAuthAuthorize) gt
 	s.Set(sessionScope, form.Scope)
 	s.Set(sessionInternalState, uuid.NewString())
 	s.Set(sessionClientState, form.State)
+	s.Set(sessionCodeChallenge, form.CodeChallenge)
+	s.Set(sessionCodeChallengeMethod, form.CodeChallengeMethod)
+	s.Set(sessionDeviceName, form.DeviceName)
 
 	if err := s.Save(); err != nil {
 		err := fmt.Errorf("error saving form values onto session: %s", err)
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -113,6 +115,105 @@ func (suite *AuthAuthorizeTestSuite) TestAccountAuthorizeHandler() {
 	}
 }
 
-func TestAccountUpdateTestSuite(t *testing.T) {
+func (suite *AuthAuthorizeTestSuite) TestAuthorizeUnregisteredRedirectURI() {
+	app := suite.testApplications["application_1"]
+
+	for _, test := range []struct {
+		redirectURI        string
+		expectedStatusCode int
+	}{
+		// Registered, should be sent to sign in.
+		{"http://localhost:8080", http.StatusSeeOther},
+		// Prefix match is not enough.
+		{"http://localhost:8080/some/other/path", http.StatusBadRequest},
+		// Entirely different uri.
+		{"https://evil.example.org/callback", http.StatusBadRequest},
+	} {
+		query := url.Values{
+			"response_type": {"code"},
+			"client_id":     {app.ClientID},
+			"redirect_uri":  {test.redirectURI},
+		}
+
+		ctx, recorder := suite.newContext(http.MethodGet, auth.OauthAuthorizePath+"?"+query.Encode(), nil, "")
+
+		suite.authModule.AuthorizeGETHandler(ctx)
+		suite.Equal(test.expectedStatusCode, recorder.Code, test.redirectURI)
+
+		if test.expectedStatusCode == http.StatusBadRequest {
+			suite.Contains(recorder.Body.String(), "is not registered for this client")
+		}
+	}
+}
+
+func (suite *AuthAuthorizeTestSuite) TestAuthorizeRememberedConsent() {
+	app := suite.testApplications["application_1"]
+	user := suite.testUsers["local_account_1"]
+
+	authorize := func(method string, scope string, body url.Values) *httptest.ResponseRecorder {
+		var contentType string
+		if body != nil {
+			contentType = "application/x-www-form-urlencoded"
+		}
+		ctx, recorder := suite.newContext(method, auth.OauthAuthorizePath, []byte(body.Encode()), contentType)
+
+		testSession := sessions.Default(ctx)
+		testSession.Set(sessionUserID, user.ID)
+		testSession.Set(sessionClientID, app.ClientID)
+		testSession.Set(sessionRedirectURI, app.RedirectURI)
+		testSession.Set(sessionResponseType, "code")
+		testSession.Set(sessionScope, scope)
+		if err := testSession.Save(); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		if method == http.MethodPost {
+			suite.authModule.AuthorizePOSTHandler(ctx)
+		} else {
+			suite.authModule.AuthorizeGETHandler(ctx)
+		}
+
+		// Redirects only set the status
+		// code, so flush it to the recorder.
+		ctx.Writer.WriteHeaderNow()
+		return recorder
+	}
+
+	// Nothing remembered yet, so
+	// user is asked to approve.
+	recorder := authorize(http.MethodGet, "read", nil)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Contains(recorder.Body.String(), "Remember my approval")
+
+	// Approve, and remember it.
+	recorder = authorize(http.MethodPost, "read write", url.Values{"remember": {"true"}})
+	suite.Equal(http.StatusFound, recorder.Code)
+
+	consent, err := suite.db.GetConsent(context.Background(), user.ID, app.ClientID)
+	suite.NoError(err)
+	suite.Equal("read write", consent.Scope)
+
+	// Same or narrower scope is
+	// now issued a code straight away.
+	for _, scope := range []string{"read write", "read"} {
+		recorder = authorize(http.MethodGet, scope, nil)
+		suite.Equal(http.StatusFound, recorder.Code, scope)
+		suite.Contains(recorder.Header().Get("Location"), "code=", scope)
+	}
+
+	// Broader scope has to be approved again.
+	recorder = authorize(http.MethodGet, "read write follow", nil)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Contains(recorder.Body.String(), "Authorize app")
+
+	// Once revoked, user is asked again.
+	err = suite.db.DeleteConsentByID(context.Background(), consent.ID)
+	suite.NoError(err)
+
+	recorder = authorize(http.MethodGet, "read", nil)
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func TestAuthAuthorizeTestSuite(t *testing.T) {
 	suite.Run(t, new(AuthAuthorizeTestSuite))
 }
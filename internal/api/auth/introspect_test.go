@@ -0,0 +1,177 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type IntrospectTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *IntrospectTestSuite) introspect(fields map[string][]string, expectedHTTPStatus int) string {
+	requestBody, w, err := testrig.CreateMultipartFormData("", "", fields)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/introspect", requestBody.Bytes(), w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.IntrospectPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(expectedHTTPStatus, recorder.Code, string(b))
+	return string(b)
+}
+
+func (suite *IntrospectTestSuite) TestIntrospectValidToken() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	b := suite.introspect(map[string][]string{
+		"client_id":     {testClient.ID},
+		"client_secret": {testClient.Secret},
+		"token":         {testToken.Access},
+	}, http.StatusOK)
+
+	introspection := &apimodel.OAuthTokenIntrospection{}
+	if err := json.Unmarshal([]byte(b), introspection); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(introspection.Active)
+	suite.Equal("read write follow push", introspection.Scope)
+	suite.Equal(testClient.ID, introspection.ClientID)
+	suite.Equal("the_mighty_zork", introspection.Username)
+	suite.Equal("Bearer", introspection.TokenType)
+	suite.Equal(testToken.AccessExpiresAt.Unix(), introspection.Exp)
+	suite.Equal(testToken.AccessCreateAt.Unix(), introspection.Iat)
+}
+
+func (suite *IntrospectTestSuite) TestIntrospectExpiredToken() {
+	testClient := suite.testClients["local_account_1"]
+
+	// Put an already-expired token in the db.
+	expiredToken := &gtsmodel.Token{
+		ID:              id.NewULID(),
+		ClientID:        testClient.ID,
+		UserID:          suite.testUsers["local_account_1"].ID,
+		RedirectURI:     "http://localhost:8080",
+		Scope:           "read",
+		Access:          "THISTOKENHASEXPIREDTHISTOKENHASEXPIREDTHISTOKEN",
+		AccessCreateAt:  time.Now().Add(-2 * time.Hour),
+		AccessExpiresAt: time.Now().Add(-1 * time.Hour),
+	}
+	if err := suite.db.PutToken(context.Background(), expiredToken); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	b := suite.introspect(map[string][]string{
+		"client_id":     {testClient.ID},
+		"client_secret": {testClient.Secret},
+		"token":         {expiredToken.Access},
+	}, http.StatusOK)
+
+	suite.Equal(`{"active":false}`, b)
+}
+
+func (suite *IntrospectTestSuite) TestIntrospectRevokedToken() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	// Revoke the token first.
+	if errWithCode := suite.processor.OAuthRevokeToken(
+		context.Background(),
+		testClient.ID,
+		testClient.Secret,
+		testToken.Access,
+		"",
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	b := suite.introspect(map[string][]string{
+		"client_id":     {testClient.ID},
+		"client_secret": {testClient.Secret},
+		"token":         {testToken.Access},
+	}, http.StatusOK)
+
+	suite.Equal(`{"active":false}`, b)
+}
+
+func (suite *IntrospectTestSuite) TestIntrospectOtherClientToken() {
+	testClient := suite.testClients["local_account_1"]
+	otherToken := suite.testTokens["local_account_2"]
+
+	// Token issued to another client
+	// should not be visible to this one.
+	b := suite.introspect(map[string][]string{
+		"client_id":     {testClient.ID},
+		"client_secret": {testClient.Secret},
+		"token":         {otherToken.Access},
+	}, http.StatusOK)
+
+	suite.Equal(`{"active":false}`, b)
+}
+
+func (suite *IntrospectTestSuite) TestIntrospectNoClientSecret() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	b := suite.introspect(map[string][]string{
+		"client_id": {testClient.ID},
+		"token":     {testToken.Access},
+	}, http.StatusBadRequest)
+
+	suite.Equal(`{"error":"invalid_request","error_description":"Bad Request: client_secret was not set in the introspect request form"}`, b)
+}
+
+func (suite *IntrospectTestSuite) TestIntrospectWrongClientSecret() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	suite.introspect(map[string][]string{
+		"client_id":     {testClient.ID},
+		"client_secret": {"wrong secret"},
+		"token":         {testToken.Access},
+	}, http.StatusUnauthorized)
+}
+
+func TestIntrospectTestSuite(t *testing.T) {
+	suite.Run(t, &IntrospectTestSuite{})
+}
@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type parRequestForm struct {
+	apimodel.OAuthAuthorize
+	ClientSecret string `form:"client_secret" json:"client_secret" xml:"client_secret"`
+}
+
+// PARPOSTHandler should be served as a POST at https://example.org/oauth/par
+// The idea here is to allow a client to push the parameters of an authorization
+// request directly to us, rather than through the user's browser, and get back
+// a one-time request_uri to use at the authorize endpoint in their place, as per
+// https://datatracker.ietf.org/doc/html/rfc9126.
+func (m *Module) PARPOSTHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &parRequestForm{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.OAuthErrorHandler(c, gtserror.NewErrorBadRequest(oauth.ErrInvalidRequest, err.Error()))
+		return
+	}
+
+	// Client may authenticate using either
+	// form values or http basic auth.
+	clientID, clientSecret, basic := c.Request.BasicAuth()
+	if !basic {
+		clientID = form.ClientID
+		clientSecret = form.ClientSecret
+	}
+
+	if clientID == "" {
+		const help = "client_id was not set in the pushed authorization request"
+		apiutil.OAuthErrorHandler(c, gtserror.NewErrorBadRequest(oauth.ErrInvalidRequest, help))
+		return
+	}
+
+	pushed, errWithCode := m.processor.OAuthPushAuthorizeRequest(
+		c.Request.Context(),
+		clientID,
+		clientSecret,
+		&form.OAuthAuthorize,
+	)
+	if errWithCode != nil {
+		apiutil.OAuthErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	apiutil.JSON(c, http.StatusCreated, pushed)
+}
@@ -0,0 +1,198 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/auth"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type PARTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *PARTestSuite) push(fields map[string][]string, expectedHTTPStatus int) *apimodel.OAuthPushedAuthorization {
+	requestBody, w, err := testrig.CreateMultipartFormData("", "", fields)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/par", requestBody.Bytes(), w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.PARPOSTHandler(ctx)
+	suite.Equal(expectedHTTPStatus, recorder.Code, recorder.Body.String())
+
+	if expectedHTTPStatus != http.StatusCreated {
+		return nil
+	}
+
+	pushed := &apimodel.OAuthPushedAuthorization{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), pushed); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return pushed
+}
+
+func (suite *PARTestSuite) authorize(query url.Values) (int, sessions.Session, string) {
+	ctx, recorder := suite.newContext(http.MethodGet, auth.OauthAuthorizePath+"?"+query.Encode(), nil, "")
+	suite.authModule.AuthorizeGETHandler(ctx)
+	return recorder.Code, sessions.Default(ctx), recorder.Body.String()
+}
+
+func (suite *PARTestSuite) TestPushAndAuthorize() {
+	testClient := suite.testClients["local_account_1"]
+
+	pushed := suite.push(map[string][]string{
+		"client_id":      {testClient.ID},
+		"client_secret":  {testClient.Secret},
+		"response_type":  {"code"},
+		"redirect_uri":   {"http://localhost:8080"},
+		"scope":          {"read write"},
+		"state":          {"pushed-state"},
+		"code_challenge": {"some-challenge"},
+	}, http.StatusCreated)
+
+	suite.Contains(pushed.RequestURI, oauth.RequestURIPrefix)
+	suite.EqualValues(60, pushed.ExpiresIn)
+
+	query := url.Values{
+		"client_id":   {testClient.ID},
+		"request_uri": {pushed.RequestURI},
+	}
+
+	// Using the request uri should send the user
+	// to sign in, with the pushed params in session.
+	code, session, _ := suite.authorize(query)
+	suite.Equal(http.StatusSeeOther, code)
+	suite.Equal(testClient.ID, session.Get(sessionClientID))
+	suite.Equal("http://localhost:8080", session.Get(sessionRedirectURI))
+	suite.Equal("read write", session.Get(sessionScope))
+	suite.Equal("pushed-state", session.Get(sessionClientState))
+	suite.Equal("some-challenge", session.Get(sessionCodeChallenge))
+
+	// Using it again should not.
+	code, _, body := suite.authorize(query)
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Contains(body, "request_uri is unknown, or has already been used")
+}
+
+func (suite *PARTestSuite) TestAuthorizeRequestURIWithInlineParams() {
+	testClient := suite.testClients["local_account_1"]
+
+	pushed := suite.push(map[string][]string{
+		"client_id":     {testClient.ID},
+		"client_secret": {testClient.Secret},
+		"response_type": {"code"},
+		"redirect_uri":  {"http://localhost:8080"},
+		"scope":         {"read"},
+	}, http.StatusCreated)
+
+	// Trying to sneak in a broader scope
+	// alongside the request uri is refused.
+	code, _, body := suite.authorize(url.Values{
+		"client_id":   {testClient.ID},
+		"request_uri": {pushed.RequestURI},
+		"scope":       {"read write follow"},
+	})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Contains(body, "request_uri must not be combined with other authorization parameters, but scope was also set")
+
+	// As is leaving out the client id.
+	code, _, body = suite.authorize(url.Values{
+		"request_uri": {pushed.RequestURI},
+	})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Contains(body, "client_id must be set along with request_uri")
+}
+
+func (suite *PARTestSuite) TestPushInvalid() {
+	testClient := suite.testClients["local_account_1"]
+
+	for _, test := range []struct {
+		fields             map[string][]string
+		expectedHTTPStatus int
+	}{
+		{
+			// Missing secret.
+			fields: map[string][]string{
+				"client_id":     {testClient.ID},
+				"response_type": {"code"},
+				"redirect_uri":  {"http://localhost:8080"},
+			},
+			expectedHTTPStatus: http.StatusUnauthorized,
+		},
+		{
+			// Wrong secret.
+			fields: map[string][]string{
+				"client_id":     {testClient.ID},
+				"client_secret": {"not-the-secret"},
+				"response_type": {"code"},
+				"redirect_uri":  {"http://localhost:8080"},
+			},
+			expectedHTTPStatus: http.StatusUnauthorized,
+		},
+		{
+			// Unregistered redirect uri.
+			fields: map[string][]string{
+				"client_id":     {testClient.ID},
+				"client_secret": {testClient.Secret},
+				"response_type": {"code"},
+				"redirect_uri":  {"https://evil.example.org/callback"},
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			// Nested request uri.
+			fields: map[string][]string{
+				"client_id":     {testClient.ID},
+				"client_secret": {testClient.Secret},
+				"response_type": {"code"},
+				"redirect_uri":  {"http://localhost:8080"},
+				"request_uri":   {oauth.RequestURIPrefix + "whatever"},
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			// Unsupported response type.
+			fields: map[string][]string{
+				"client_id":     {testClient.ID},
+				"client_secret": {testClient.Secret},
+				"response_type": {"token"},
+				"redirect_uri":  {"http://localhost:8080"},
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
+	} {
+		suite.Nil(suite.push(test.fields, test.expectedHTTPStatus))
+	}
+}
+
+func TestPARTestSuite(t *testing.T) {
+	suite.Run(t, new(PARTestSuite))
+}
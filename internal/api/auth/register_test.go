@@ -0,0 +1,173 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RegisterTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *RegisterTestSuite) register(body string, expectedHTTPStatus int) string {
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/register", []byte(body), "application/json")
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.RegisterPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(expectedHTTPStatus, recorder.Code, string(b))
+	return string(b)
+}
+
+func (suite *RegisterTestSuite) TestRegisterAndAuthorize() {
+	b := suite.register(`{
+  "client_name": "Dynamic App",
+  "client_uri": "https://app.example.org",
+  "redirect_uris": [
+    "https://app.example.org/callback",
+    "https://other.example.org/callback"
+  ],
+  "scope": "read write"
+}`, http.StatusCreated)
+
+	registration := &apimodel.OAuthClientRegistration{}
+	if err := json.Unmarshal([]byte(b), registration); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotEmpty(registration.ClientID)
+	suite.NotEmpty(registration.ClientSecret)
+	suite.NotZero(registration.ClientIDIssuedAt)
+	suite.Zero(registration.ClientSecretExpiresAt)
+	suite.Equal([]string{"code"}, registration.ResponseTypes)
+	suite.Equal("read write", registration.Scope)
+	suite.Equal("Dynamic App", registration.ClientName)
+
+	// The registered client should be able to
+	// complete an authorization code flow,
+	// using either of its redirect uris.
+	const redirectURI = "https://other.example.org/callback"
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/authorize", nil, "")
+	s := sessions.Default(ctx)
+	s.Set(sessionUserID, suite.testUsers["local_account_1"].ID)
+	s.Set(sessionClientID, registration.ClientID)
+	s.Set(sessionRedirectURI, redirectURI)
+	s.Set(sessionResponseType, "code")
+	s.Set(sessionScope, "read")
+	if err := s.Save(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.authModule.AuthorizePOSTHandler(ctx)
+
+	// Redirect only sets the status
+	// code, so flush it to the recorder.
+	ctx.Writer.WriteHeaderNow()
+	suite.Equal(http.StatusFound, recorder.Code)
+
+	location, err := url.Parse(recorder.Header().Get("Location"))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(strings.HasPrefix(location.String(), redirectURI+"?"), location.String())
+
+	code := location.Query().Get("code")
+	suite.NotEmpty(code)
+
+	// Exchange the code for a token.
+	requestBody, w, err := testrig.CreateMultipartFormData("", "", map[string][]string{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {registration.ClientID},
+		"client_secret": {registration.ClientSecret},
+		"redirect_uri":  {redirectURI},
+		"code":          {code},
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx, recorder = suite.newContext(http.MethodPost, "oauth/token", requestBody.Bytes(), w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	token := make(map[string]any)
+	if err := json.Unmarshal(recorder.Body.Bytes(), &token); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(token["access_token"])
+	suite.Equal("read", token["scope"])
+}
+
+func (suite *RegisterTestSuite) TestRegisterLocalhost() {
+	const body = `{"redirect_uris": ["http://localhost:8080/callback"]}`
+
+	// Rejected by default.
+	b := suite.register(body, http.StatusBadRequest)
+	suite.Contains(b, `"error":"invalid_redirect_uri"`)
+
+	// Permitted if explicitly allowed.
+	config.SetAdvancedOAuthRegistrationAllowLocalhost(true)
+	defer config.SetAdvancedOAuthRegistrationAllowLocalhost(false)
+	suite.register(body, http.StatusCreated)
+}
+
+func (suite *RegisterTestSuite) TestRegisterInvalid() {
+	for _, test := range []struct {
+		body          string
+		expectedError string
+	}{
+		{`{}`, "invalid_redirect_uri"},
+		{`{"redirect_uris": ["https://example.org/callback#frag"]}`, "invalid_redirect_uri"},
+		{`{"redirect_uris": ["/callback"]}`, "invalid_redirect_uri"},
+		{`{"redirect_uris": ["https://example.org/callback"], "grant_types": ["implicit"]}`, "invalid_client_metadata"},
+		{`{"redirect_uris": ["https://example.org/callback"], "response_types": ["token"]}`, "invalid_client_metadata"},
+		{`{"redirect_uris": ["https://example.org/callback"], "token_endpoint_auth_method": "private_key_jwt"}`, "invalid_client_metadata"},
+		{`{"redirect_uris": ["https://example.org/callback"], "scope": "read bogus"}`, "invalid_client_metadata"},
+		{`{"redirect_uris": "https://example.org/callback"}`, "invalid_client_metadata"},
+	} {
+		b := suite.register(test.body, http.StatusBadRequest)
+		suite.Contains(b, `"error":"`+test.expectedError+`"`, test.body)
+	}
+}
+
+func TestRegisterTestSuite(t *testing.T) {
+	suite.Run(t, new(RegisterTestSuite))
+}
@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type revokeRequestForm struct {
+	Token         *string `form:"token" json:"token" xml:"token"`
+	TokenTypeHint *string `form:"token_type_hint" json:"token_type_hint" xml:"token_type_hint"`
+	ClientID      *string `form:"client_id" json:"client_id" xml:"client_id"`
+	ClientSecret  *string `form:"client_secret" json:"client_secret" xml:"client_secret"`
+}
+
+// RevokePOSTHandler should be served as a POST at https://example.org/oauth/revoke
+// The idea here is to allow a client to revoke an access or refresh token that it
+// no longer needs, as per https://datatracker.ietf.org/doc/html/rfc7009.
+func (m *Module) RevokePOSTHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	help := []string{}
+
+	form := &revokeRequestForm{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.OAuthErrorHandler(c, gtserror.NewErrorBadRequest(oauth.ErrInvalidRequest, err.Error()))
+		return
+	}
+
+	// Client may authenticate using either
+	// form values or http basic auth.
+	clientID, clientSecret, basic := c.Request.BasicAuth()
+	if !basic {
+		if form.ClientID != nil {
+			clientID = *form.ClientID
+		}
+
+		if form.ClientSecret != nil {
+			clientSecret = *form.ClientSecret
+		}
+	}
+
+	if clientID == "" {
+		help = append(help, "client_id was not set in the revoke request form")
+	}
+
+	var token string
+	if form.Token != nil {
+		token = *form.Token
+	}
+
+	if token == "" {
+		help = append(help, "token was not set in the revoke request form")
+	}
+
+	var tokenTypeHint string
+	if form.TokenTypeHint != nil {
+		tokenTypeHint = *form.TokenTypeHint
+	}
+
+	if len(help) != 0 {
+		apiutil.OAuthErrorHandler(c, gtserror.NewErrorBadRequest(oauth.ErrInvalidRequest, help...))
+		return
+	}
+
+	if errWithCode := m.processor.OAuthRevokeToken(
+		c.Request.Context(),
+		clientID,
+		clientSecret,
+		token,
+		tokenTypeHint,
+	); errWithCode != nil {
+		apiutil.OAuthErrorHandler(c, errWithCode)
+		return
+	}
+
+	// Spec says to respond with 200 and an
+	// empty body, whether or not the token
+	// was actually valid in the first place.
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	apiutil.JSON(c, http.StatusOK, struct{}{})
+}
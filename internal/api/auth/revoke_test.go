@@ -0,0 +1,157 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RevokeTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *RevokeTestSuite) revoke(fields map[string][]string, expectedHTTPStatus int) string {
+	requestBody, w, err := testrig.CreateMultipartFormData("", "", fields)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/revoke", requestBody.Bytes(), w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.RevokePOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(expectedHTTPStatus, recorder.Code, string(b))
+	return string(b)
+}
+
+// validate checks whether the given access token can
+// still be used to authenticate against the client API.
+func (suite *RevokeTestSuite) validate(access string) error {
+	req := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/accounts/verify_credentials", nil)
+	req.Header.Set("Authorization", "Bearer "+access)
+	_, err := suite.processor.OAuthValidateBearerToken(req)
+	return err
+}
+
+func (suite *RevokeTestSuite) TestRevokeAccessToken() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	// Token should work before revocation.
+	suite.NoError(suite.validate(testToken.Access))
+
+	suite.revoke(map[string][]string{
+		"client_id":       {testClient.ID},
+		"client_secret":   {testClient.Secret},
+		"token":           {testToken.Access},
+		"token_type_hint": {"access_token"},
+	}, http.StatusOK)
+
+	// Token should no longer work.
+	suite.Error(suite.validate(testToken.Access))
+
+	// And should be gone from the db.
+	_, err := suite.db.GetTokenByAccess(context.Background(), testToken.Access)
+	suite.Error(err)
+}
+
+func (suite *RevokeTestSuite) TestRevokeAccessTokenNoHint() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	suite.revoke(map[string][]string{
+		"client_id":     {testClient.ID},
+		"client_secret": {testClient.Secret},
+		"token":         {testToken.Access},
+	}, http.StatusOK)
+
+	suite.Error(suite.validate(testToken.Access))
+}
+
+func (suite *RevokeTestSuite) TestRevokeUnknownToken() {
+	testClient := suite.testClients["local_account_1"]
+
+	// Unknown token should
+	// still give 200 OK.
+	suite.revoke(map[string][]string{
+		"client_id":     {testClient.ID},
+		"client_secret": {testClient.Secret},
+		"token":         {"this-token-does-not-exist"},
+	}, http.StatusOK)
+}
+
+func (suite *RevokeTestSuite) TestRevokeWrongClientSecret() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	suite.revoke(map[string][]string{
+		"client_id":     {testClient.ID},
+		"client_secret": {"wrong secret"},
+		"token":         {testToken.Access},
+	}, http.StatusUnauthorized)
+
+	// Token should still work.
+	suite.NoError(suite.validate(testToken.Access))
+}
+
+func (suite *RevokeTestSuite) TestRevokeMissingClientSecret() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	suite.revoke(map[string][]string{
+		"client_id": {testClient.ID},
+		"token":     {testToken.Access},
+	}, http.StatusUnauthorized)
+
+	// Token should still work.
+	suite.NoError(suite.validate(testToken.Access))
+}
+
+func (suite *RevokeTestSuite) TestRevokeUnsupportedHint() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	b := suite.revoke(map[string][]string{
+		"client_id":       {testClient.ID},
+		"client_secret":   {testClient.Secret},
+		"token":           {testToken.Access},
+		"token_type_hint": {"id_token"},
+	}, http.StatusBadRequest)
+
+	suite.Equal(`{"error":"unsupported_token_type","error_description":"Bad Request: token_type_hint id_token not supported, must be one of access_token, refresh_token"}`, b)
+}
+
+func TestRevokeTestSuite(t *testing.T) {
+	suite.Run(t, &RevokeTestSuite{})
+}
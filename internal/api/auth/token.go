@@ -35,6 +35,9 @@ type tokenRequestForm struct {
 	ClientID     *string `form:"client_id" json:"client_id" xml:"client_id"`
 	ClientSecret *string `form:"client_secret" json:"client_secret" xml:"client_secret"`
 	Scope        *string `form:"scope" json:"scope" xml:"scope"`
+	CodeVerifier *string `form:"code_verifier" json:"code_verifier" xml:"code_verifier"`
+	RefreshToken *string `form:"refresh_token" json:"refresh_token" xml:"refresh_token"`
+	DeviceName   *string `form:"device_name" json:"device_name" xml:"device_name"`
 }
 
 // TokenPOSTHandler should be served as a POST at https://example.org/oauth/token
@@ -60,7 +63,7 @@ func (m *Module) TokenPOSTHandler(c *gin.Context) {
 		grantType = *form.GrantType
 		c.Request.Form.Set("grant_type", grantType)
 	} else {
-		help = append(help, "grant_type was not set in the token request form, but must be set to authorization_code or client_credentials")
+		help = append(help, "grant_type was not set in the token request form, but must be set to authorization_code, client_credentials, or refresh_token")
 	}
 
 	if form.ClientID != nil {
@@ -71,13 +74,19 @@ func (m *Module) TokenPOSTHandler(c *gin.Context) {
 
 	if form.ClientSecret != nil {
 		c.Request.Form.Set("client_secret", *form.ClientSecret)
-	} else {
+	} else if form.CodeVerifier == nil || grantType != "authorization_code" {
+		// Public clients using PKCE may omit the
+		// client secret, since the code verifier
+		// proves they're the ones who requested
+		// the authorization code in the first place.
 		help = append(help, "client_secret was not set in the token request form")
 	}
 
 	if form.RedirectURI != nil {
 		c.Request.Form.Set("redirect_uri", *form.RedirectURI)
-	} else {
+	} else if grantType != "refresh_token" {
+		// Redirect URI isn't used when
+		// refreshing, so it may be omitted.
 		help = append(help, "redirect_uri was not set in the token request form")
 	}
 
@@ -97,6 +106,28 @@ func (m *Module) TokenPOSTHandler(c *gin.Context) {
 		c.Request.Form.Set("scope", *form.Scope)
 	}
 
+	if form.CodeVerifier != nil {
+		if grantType != "authorization_code" {
+			help = append(help, "a code_verifier was provided in the token request form, but grant_type was not set to authorization_code")
+		} else {
+			c.Request.Form.Set("code_verifier", *form.CodeVerifier)
+		}
+	}
+
+	if form.RefreshToken != nil {
+		if grantType != "refresh_token" {
+			help = append(help, "a refresh_token was provided in the token request form, but grant_type was not set to refresh_token")
+		} else {
+			c.Request.Form.Set("refresh_token", *form.RefreshToken)
+		}
+	} else if grantType == "refresh_token" {
+		help = append(help, "refresh_token was not set in the token request form, but must be set since grant_type is refresh_token")
+	}
+
+	if form.DeviceName != nil {
+		c.Request.Form.Set(oauth.DeviceNameKey, *form.DeviceName)
+	}
+
 	if len(help) != 0 {
 		apiutil.OAuthErrorHandler(c, gtserror.NewErrorBadRequest(oauth.ErrInvalidRequest, help...))
 		return
@@ -22,13 +22,17 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/timelines"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -50,7 +54,7 @@ func (suite *TokenTestSuite) TestPOSTTokenEmptyForm() {
 	b, err := ioutil.ReadAll(result.Body)
 	suite.NoError(err)
 
-	suite.Equal(`{"error":"invalid_request","error_description":"Bad Request: grant_type was not set in the token request form, but must be set to authorization_code or client_credentials: client_id was not set in the token request form: client_secret was not set in the token request form: redirect_uri was not set in the token request form"}`, string(b))
+	suite.Equal(`{"error":"invalid_request","error_description":"Bad Request: grant_type was not set in the token request form, but must be set to authorization_code, client_credentials, or refresh_token: client_id was not set in the token request form: client_secret was not set in the token request form: redirect_uri was not set in the token request form"}`, string(b))
 }
 
 func (suite *TokenTestSuite) TestRetrieveClientCredentialsOK() {
@@ -96,6 +100,12 @@ func (suite *TokenTestSuite) TestRetrieveClientCredentialsOK() {
 	err = suite.db.GetWhere(context.Background(), []db.Where{{Key: "access", Value: t.AccessToken}}, dbToken)
 	suite.NoError(err)
 	suite.NotNil(dbToken)
+
+	// App-only token should be bound
+	// to the client, not to a user.
+	suite.Equal(testClient.ID, dbToken.ClientID)
+	suite.Empty(dbToken.UserID)
+	suite.Equal("read", dbToken.Scope)
 }
 
 func (suite *TokenTestSuite) TestRetrieveAuthorizationCodeOK() {
@@ -144,6 +154,220 @@ func (suite *TokenTestSuite) TestRetrieveAuthorizationCodeOK() {
 	suite.NotNil(dbToken)
 }
 
+func (suite *TokenTestSuite) exchangeCode(code string, deviceName string) *gtsmodel.Token {
+	testClient := suite.testClients["local_account_1"]
+
+	fields := map[string][]string{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {testClient.ID},
+		"client_secret": {testClient.Secret},
+		"redirect_uri":  {"http://localhost:8080"},
+		"code":          {code},
+	}
+	if deviceName != "" {
+		fields["device_name"] = []string{deviceName}
+	}
+
+	requestBody, w, err := testrig.CreateMultipartFormData("", "", fields)
+	if err != nil {
+		panic(err)
+	}
+	bodyBytes := requestBody.Bytes()
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", bodyBytes, w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	t := &apimodel.Token{}
+	if err := json.NewDecoder(recorder.Body).Decode(t); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return suite.getDBToken(t.AccessToken)
+}
+
+func (suite *TokenTestSuite) TestRetrieveAuthorizationCodeDeviceName() {
+	testUserAuthorizationToken := suite.testTokens["local_account_1_user_authorization_token"]
+
+	dbToken := suite.exchangeCode(testUserAuthorizationToken.Code, "  Firefox on\nLinux  ")
+	suite.Equal("Firefox onLinux", dbToken.DeviceName)
+}
+
+func (suite *TokenTestSuite) TestRetrieveAuthorizationCodeDeviceNameFromAuthorize() {
+	testUserAuthorizationToken := suite.testTokens["local_account_1_user_authorization_token"]
+
+	// Device name given when
+	// requesting authorization.
+	testUserAuthorizationToken.DeviceName = "Phone"
+	if err := suite.db.UpdateToken(
+		context.Background(),
+		testUserAuthorizationToken,
+		"device_name",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbToken := suite.exchangeCode(testUserAuthorizationToken.Code, "")
+	suite.Equal("Phone", dbToken.DeviceName)
+}
+
+func (suite *TokenTestSuite) putPKCEAuthorizationToken(challenge string, method string) *gtsmodel.Token {
+	testClient := suite.testClients["local_account_1"]
+	testUserAuthorizationToken := suite.testTokens["local_account_1_user_authorization_token"]
+
+	token := &gtsmodel.Token{
+		ID:                  "01J2N3KBN4HZHT4X2VNRSQG1F2",
+		ClientID:            testClient.ID,
+		UserID:              testUserAuthorizationToken.UserID,
+		RedirectURI:         "http://localhost:8080",
+		Scope:               "read write follow push",
+		Code:                "ZWRIZGNMYTATZTFKOS0ZZJC1LWFKMJUTMDRKNDNKMDQYNMJM",
+		CodeChallenge:       challenge,
+		CodeChallengeMethod: method,
+		CodeCreateAt:        time.Now(),
+		CodeExpiresAt:       time.Now().Add(10 * time.Minute),
+	}
+	if err := suite.db.Put(context.Background(), token); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return token
+}
+
+func (suite *TokenTestSuite) TestRetrieveAuthorizationCodePKCES256OK() {
+	testClient := suite.testClients["local_account_1"]
+
+	// Verifier + challenge example taken from RFC 7636, Appendix B.
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	token := suite.putPKCEAuthorizationToken(challenge, "S256")
+
+	// No client secret: the
+	// code verifier replaces it.
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		"", "",
+		map[string][]string{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {testClient.ID},
+			"redirect_uri":  {"http://localhost:8080"},
+			"code":          {token.Code},
+			"code_verifier": {verifier},
+		})
+	if err != nil {
+		panic(err)
+	}
+	bodyBytes := requestBody.Bytes()
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", bodyBytes, w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	t := &apimodel.Token{}
+	err = json.Unmarshal(b, t)
+	suite.NoError(err)
+
+	suite.Equal("Bearer", t.TokenType)
+	suite.NotEmpty(t.AccessToken)
+}
+
+func (suite *TokenTestSuite) TestRetrieveAuthorizationCodePKCEPlainOK() {
+	testClient := suite.testClients["local_account_1"]
+
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	token := suite.putPKCEAuthorizationToken(verifier, "plain")
+
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		"", "",
+		map[string][]string{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {testClient.ID},
+			"client_secret": {testClient.Secret},
+			"redirect_uri":  {"http://localhost:8080"},
+			"code":          {token.Code},
+			"code_verifier": {verifier},
+		})
+	if err != nil {
+		panic(err)
+	}
+	bodyBytes := requestBody.Bytes()
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", bodyBytes, w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *TokenTestSuite) TestRetrieveAuthorizationCodePKCEWrongVerifier() {
+	testClient := suite.testClients["local_account_1"]
+
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	token := suite.putPKCEAuthorizationToken(challenge, "S256")
+
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		"", "",
+		map[string][]string{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {testClient.ID},
+			"redirect_uri":  {"http://localhost:8080"},
+			"code":          {token.Code},
+			"code_verifier": {"not-the-right-verifier-not-the-right-verifier"},
+		})
+	if err != nil {
+		panic(err)
+	}
+	bodyBytes := requestBody.Bytes()
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", bodyBytes, w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *TokenTestSuite) TestRetrieveAuthorizationCodePKCENoVerifier() {
+	testClient := suite.testClients["local_account_1"]
+
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	token := suite.putPKCEAuthorizationToken(challenge, "S256")
+
+	// Client secret is set, but since a challenge
+	// was registered, a verifier is still required.
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		"", "",
+		map[string][]string{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {testClient.ID},
+			"client_secret": {testClient.Secret},
+			"redirect_uri":  {"http://localhost:8080"},
+			"code":          {token.Code},
+		})
+	if err != nil {
+		panic(err)
+	}
+	bodyBytes := requestBody.Bytes()
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", bodyBytes, w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
 func (suite *TokenTestSuite) TestRetrieveAuthorizationCodeNoCode() {
 	testClient := suite.testClients["local_account_1"]
 
@@ -209,6 +433,330 @@ func (suite *TokenTestSuite) TestRetrieveAuthorizationCodeWrongGrantType() {
 	suite.Equal(`{"error":"invalid_request","error_description":"Bad Request: a code was provided in the token request form, but grant_type was not set to authorization_code"}`, string(b))
 }
 
+func (suite *TokenTestSuite) putRefreshableToken() *gtsmodel.Token {
+	testClient := suite.testClients["local_account_1"]
+	testUserAuthorizationToken := suite.testTokens["local_account_1_user_authorization_token"]
+
+	token := &gtsmodel.Token{
+		ID:              "01J2N3KBN4HZHT4X2VNRSQG1F3",
+		ClientID:        testClient.ID,
+		UserID:          testUserAuthorizationToken.UserID,
+		RedirectURI:     "http://localhost:8080",
+		Scope:           "read write",
+		Access:          "NTHKODE1OWETMZQ5MY0ZMDAWLTG5NZATOGRLMDYXYJGYZDM2",
+		AccessCreateAt:  time.Now(),
+		Refresh:         "MZM3NDA0MJETYZC4ZS0ZNDDILTK4OTITYJEZYTI0YTEYNWVK",
+		RefreshCreateAt: time.Now(),
+		FamilyID:        "01J2N3KBN4HZHT4X2VNRSQG1F3",
+	}
+	if err := suite.db.PutToken(context.Background(), token); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return token
+}
+
+func (suite *TokenTestSuite) refresh(refreshToken string) (*apimodel.Token, int) {
+	testClient := suite.testClients["local_account_1"]
+
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		"", "",
+		map[string][]string{
+			"grant_type":    {"refresh_token"},
+			"client_id":     {testClient.ID},
+			"client_secret": {testClient.Secret},
+			"refresh_token": {refreshToken},
+		})
+	if err != nil {
+		panic(err)
+	}
+	bodyBytes := requestBody.Bytes()
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", bodyBytes, w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	if recorder.Code != http.StatusOK {
+		return nil, recorder.Code
+	}
+
+	t := &apimodel.Token{}
+	if err := json.Unmarshal(b, t); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return t, recorder.Code
+}
+
+func (suite *TokenTestSuite) TestRefreshTokenRotation() {
+	token := suite.putRefreshableToken()
+
+	t, code := suite.refresh(token.Refresh)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("Bearer", t.TokenType)
+	suite.NotEmpty(t.AccessToken)
+	suite.NotEmpty(t.RefreshToken)
+	suite.NotEqual(token.Access, t.AccessToken)
+	suite.NotEqual(token.Refresh, t.RefreshToken)
+	suite.Equal("read write", t.Scope)
+
+	// Old access token should no longer work.
+	_, err := suite.db.GetTokenByAccess(context.Background(), token.Access)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Old refresh token should be marked as consumed.
+	oldToken, err := suite.db.GetTokenByRefresh(context.Background(), token.Refresh)
+	suite.NoError(err)
+	suite.False(oldToken.RefreshConsumedAt.IsZero())
+
+	// New token should be in the same family.
+	newToken, err := suite.db.GetTokenByAccess(context.Background(), t.AccessToken)
+	suite.NoError(err)
+	suite.Equal(t.RefreshToken, newToken.Refresh)
+	suite.Equal(token.FamilyID, newToken.FamilyID)
+	suite.True(newToken.RefreshConsumedAt.IsZero())
+
+	// And the new refresh token
+	// should be usable in turn.
+	t2, code := suite.refresh(t.RefreshToken)
+	suite.Equal(http.StatusOK, code)
+	suite.NotEqual(t.RefreshToken, t2.RefreshToken)
+}
+
+func (suite *TokenTestSuite) TestRefreshTokenKeepsDeviceName() {
+	token := suite.putRefreshableToken()
+
+	token.DeviceName = "Phone"
+	if err := suite.db.UpdateToken(context.Background(), token, "device_name"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, code := suite.refresh(token.Refresh)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("Phone", suite.getDBToken(t.AccessToken).DeviceName)
+}
+
+func (suite *TokenTestSuite) TestRefreshTokenReuseRevokesFamily() {
+	token := suite.putRefreshableToken()
+
+	t, code := suite.refresh(token.Refresh)
+	suite.Equal(http.StatusOK, code)
+
+	// Presenting the consumed
+	// refresh token again fails...
+	_, code = suite.refresh(token.Refresh)
+	suite.Equal(http.StatusBadRequest, code)
+
+	// ...and revokes the token
+	// that was refreshed from it.
+	_, err := suite.db.GetTokenByAccess(context.Background(), t.AccessToken)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, code = suite.refresh(t.RefreshToken)
+	suite.Equal(http.StatusBadRequest, code)
+}
+
+func (suite *TokenTestSuite) TestRefreshTokenWiderScope() {
+	token := suite.putRefreshableToken()
+
+	testClient := suite.testClients["local_account_1"]
+
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		"", "",
+		map[string][]string{
+			"grant_type":    {"refresh_token"},
+			"client_id":     {testClient.ID},
+			"client_secret": {testClient.Secret},
+			"refresh_token": {token.Refresh},
+			"scope":         {"read write follow"},
+		})
+	if err != nil {
+		panic(err)
+	}
+	bodyBytes := requestBody.Bytes()
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", bodyBytes, w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+
+	// Refresh token should not have been consumed.
+	dbToken, err := suite.db.GetTokenByRefresh(context.Background(), token.Refresh)
+	suite.NoError(err)
+	suite.True(dbToken.RefreshConsumedAt.IsZero())
+}
+
+func (suite *TokenTestSuite) clientCredentials(secret string, scope string) (*apimodel.Token, int) {
+	testClient := suite.testClients["local_account_1"]
+
+	fields := map[string][]string{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {testClient.ID},
+		"client_secret": {secret},
+		"redirect_uri":  {"http://localhost:8080"},
+	}
+	if scope != "" {
+		fields["scope"] = []string{scope}
+	}
+
+	requestBody, w, err := testrig.CreateMultipartFormData("", "", fields)
+	if err != nil {
+		panic(err)
+	}
+	bodyBytes := requestBody.Bytes()
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", bodyBytes, w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	if recorder.Code != http.StatusOK {
+		return nil, recorder.Code
+	}
+
+	t := &apimodel.Token{}
+	if err := json.NewDecoder(recorder.Body).Decode(t); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return t, recorder.Code
+}
+
+func (suite *TokenTestSuite) TestClientCredentialsWrongSecret() {
+	_, code := suite.clientCredentials("not the right secret", "")
+	suite.Equal(http.StatusUnauthorized, code)
+}
+
+func (suite *TokenTestSuite) TestClientCredentialsWriteScope() {
+	// Write scopes are user-level,
+	// so can't be granted to an app.
+	_, code := suite.clientCredentials(suite.testClients["local_account_1"].Secret, "read write")
+	suite.Equal(http.StatusBadRequest, code)
+}
+
+func (suite *TokenTestSuite) TestClientCredentialsConfiguredScopes() {
+	testClient := suite.testClients["local_account_1"]
+
+	// Restrict this client's app-only
+	// tokens to reading accounts only.
+	testClient.ClientCredentialsScopes = "read:accounts"
+	if err := suite.db.UpdateByID(
+		context.Background(),
+		testClient,
+		testClient.ID,
+		"client_credentials_scopes",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, code := suite.clientCredentials(testClient.Secret, "read")
+	suite.Equal(http.StatusBadRequest, code)
+
+	t, code := suite.clientCredentials(testClient.Secret, "read:accounts")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("read:accounts", t.Scope)
+}
+
+func (suite *TokenTestSuite) setTokenLifetimes(access time.Duration, refresh time.Duration) {
+	testClient := suite.testClients["local_account_1"]
+
+	testClient.AccessTokenTTL = access
+	testClient.RefreshTokenTTL = refresh
+	if err := suite.db.UpdateByID(
+		context.Background(),
+		testClient,
+		testClient.ID,
+		"access_token_ttl",
+		"refresh_token_ttl",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *TokenTestSuite) getDBToken(access string) *gtsmodel.Token {
+	dbToken := &gtsmodel.Token{}
+	if err := suite.db.GetWhere(
+		context.Background(),
+		[]db.Where{{Key: "access", Value: access}},
+		dbToken,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return dbToken
+}
+
+func (suite *TokenTestSuite) TestClientCredentialsDefaultLifetime() {
+	t, code := suite.clientCredentials(suite.testClients["local_account_1"].Secret, "read")
+	suite.Equal(http.StatusOK, code)
+
+	dbToken := suite.getDBToken(t.AccessToken)
+	suite.WithinDuration(time.Now().Add(2*time.Hour), dbToken.AccessExpiresAt, 1*time.Minute)
+}
+
+func (suite *TokenTestSuite) TestClientCredentialsConfiguredLifetime() {
+	suite.setTokenLifetimes(10*time.Minute, 0)
+
+	t, code := suite.clientCredentials(suite.testClients["local_account_1"].Secret, "read")
+	suite.Equal(http.StatusOK, code)
+
+	dbToken := suite.getDBToken(t.AccessToken)
+	suite.WithinDuration(time.Now().Add(10*time.Minute), dbToken.AccessExpiresAt, 1*time.Minute)
+}
+
+func (suite *TokenTestSuite) TestRefreshTokenConfiguredLifetimes() {
+	suite.setTokenLifetimes(time.Hour, 30*24*time.Hour)
+	token := suite.putRefreshableToken()
+
+	t, code := suite.refresh(token.Refresh)
+	suite.Equal(http.StatusOK, code)
+
+	dbToken := suite.getDBToken(t.AccessToken)
+	suite.WithinDuration(time.Now().Add(time.Hour), dbToken.AccessExpiresAt, 1*time.Minute)
+	suite.WithinDuration(time.Now().Add(30*24*time.Hour), dbToken.RefreshExpiresAt, 1*time.Minute)
+}
+
+func (suite *TokenTestSuite) TestRefreshTokenDefaultLifetimes() {
+	token := suite.putRefreshableToken()
+
+	t, code := suite.refresh(token.Refresh)
+	suite.Equal(http.StatusOK, code)
+
+	dbToken := suite.getDBToken(t.AccessToken)
+	suite.Zero(dbToken.AccessExpiresAt)
+	suite.Zero(dbToken.RefreshExpiresAt)
+}
+
+func (suite *TokenTestSuite) TestClientCredentialsCannotReadHomeTimeline() {
+	t, code := suite.clientCredentials(suite.testClients["local_account_1"].Secret, "read")
+	suite.Equal(http.StatusOK, code)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/timelines/home", nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Header.Set("Authorization", "Bearer "+t.AccessToken)
+
+	// Token itself is valid, and identifies
+	// the app, but not any user or account.
+	middleware.TokenCheck(suite.db, suite.processor.OAuthValidateBearerToken)(ctx)
+	authed, err := oauth.Authed(ctx, true, true, false, false)
+	suite.NoError(err)
+	suite.Nil(authed.User)
+	suite.Nil(authed.Account)
+
+	timelines.New(suite.processor).HomeTimelineGETHandler(ctx)
+	suite.Equal(http.StatusUnauthorized, recorder.Code)
+}
+
 func TestTokenTestSuite(t *testing.T) {
 	suite.Run(t, &TokenTestSuite{})
 }
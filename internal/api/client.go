@@ -18,8 +18,6 @@
 package api
 
 import (
-	"time"
-
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
@@ -47,6 +45,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timelines"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
@@ -94,6 +93,8 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	apiGroup.Use(m...)
 	apiGroup.Use(
 		middleware.TokenCheck(c.db, c.processor.OAuthValidateBearerToken),
+		middleware.ScopeCheck(),
+		middleware.ReadOnlyCheck(c.db.GetTokenByAccess),
 		middleware.CacheControl(middleware.CacheControlConfig{
 			// Never cache client api responses.
 			Directives: []string{"no-store"},
@@ -159,7 +160,7 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 		reports:        reports.New(p),
 		search:         search.New(p),
 		statuses:       statuses.New(p),
-		streaming:      streaming.New(p, time.Second*30, 4096),
+		streaming:      streaming.New(p, config.GetAdvancedStreamingPingInterval(), 4096),
 		timelines:      timelines.New(p),
 		user:           user.New(p),
 	}
@@ -40,6 +40,7 @@ const (
 
 	BlockPath         = BasePathWithID + "/block"
 	DeletePath        = BasePath + "/delete"
+	FeaturedTagsPath  = BasePathWithID + "/featured_tags"
 	FollowersPath     = BasePathWithID + "/followers"
 	FollowingPath     = BasePathWithID + "/following"
 	FollowPath        = BasePathWithID + "/follow"
@@ -116,6 +117,9 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	// account lists
 	attachHandler(http.MethodGet, ListsPath, m.AccountListsGETHandler)
 
+	// get featured tags of an account
+	attachHandler(http.MethodGet, FeaturedTagsPath, m.AccountFeaturedTagsGETHandler)
+
 	// account note
 	attachHandler(http.MethodPost, NotePath, m.AccountNotePOSTHandler)
 
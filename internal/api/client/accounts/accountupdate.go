@@ -73,6 +73,12 @@ import (
 //		type: string
 //		allowEmptyValue: true
 //	-
+//		name: pronouns
+//		in: formData
+//		description: Pronouns to show on this account's profile, as plain text. Use empty string to unset.
+//		type: string
+//		allowEmptyValue: true
+//	-
 //		name: avatar
 //		in: formData
 //		description: Avatar of the user.
@@ -93,11 +99,27 @@ import (
 //		description: Default post privacy for authored statuses.
 //		type: string
 //	-
+//		name: source[reply_privacy]
+//		in: formData
+//		description: |-
+//			Default post privacy for authored replies, when no visibility is given.
+//			Replies get the privacy of the post they reply to, but never wider than this.
+//			Use an empty string to unset, so that replies get the default post privacy.
+//		type: string
+//	-
 //		name: source[sensitive]
 //		in: formData
 //		description: Mark authored statuses as sensitive by default.
 //		type: boolean
 //	-
+//		name: source[default_media_sensitive]
+//		in: formData
+//		description: |-
+//			Mark authored statuses that have media attached as sensitive by default, so that their
+//			media is hidden, without marking every status as sensitive. Only applies when sensitive
+//			is not set when creating a status.
+//		type: boolean
+//	-
 //		name: source[language]
 //		in: formData
 //		description: Default language to use for authored statuses (ISO 6391).
@@ -105,9 +127,121 @@ import (
 //	-
 //		name: source[status_content_type]
 //		in: formData
-//		description: Default content type to use for authored statuses (text/plain or text/markdown).
+//		description: Default content type to use for authored statuses (text/plain or text/markdown). Must be one of the content types allowed by the instance.
+//		type: string
+//	-
+//		name: source[bio_content_type]
+//		in: formData
+//		description: |-
+//			Content type in which the note / bio is written (text/plain or text/markdown).
+//			Must be one of the content types allowed by the instance.
+//			Empty string to unset, in which case the note uses source[status_content_type].
+//		type: string
+//	-
+//		name: source[default_content_warning]
+//		in: formData
+//		description: |-
+//			Default content warning / spoiler text to pre-populate authored statuses with.
+//			Only applied by clients when composing; statuses that set spoiler_text are unaffected.
+//			Use an empty string to unset.
+//		type: string
+//	-
+//		name: source[default_post_expiry]
+//		in: formData
+//		description: |-
+//			Number of seconds after which authored statuses are automatically deleted.
+//			Can be overridden per status with expires_in. Use 0 to unset.
+//		type: integer
+//	-
+//		name: source[auto_approve_follows_after]
+//		in: formData
+//		description: |-
+//			If the account is locked, automatically approve follow requests from accounts
+//			known to this instance for longer than this number of seconds, and queue the rest
+//			for review. Use 0 to review all follow requests.
+//		type: integer
+//	-
+//		name: source[review_local_follows]
+//		in: formData
+//		description: |-
+//			Review follow requests from accounts on this instance as well, rather than
+//			automatically approving them, when source[auto_approve_follows_after] is set.
+//		type: boolean
+//	-
+//		name: source[away_enabled]
+//		in: formData
+//		description: |-
+//			Turn away mode on or off. While away, the web profile shows source[away_message],
+//			and mentions may be answered automatically (see source[away_auto_reply]).
+//		type: boolean
+//	-
+//		name: source[away_message]
+//		in: formData
+//		description: Message to show (and send as an auto-reply, if enabled) while away. Max 500 characters.
 //		type: string
 //	-
+//		name: source[away_starts_at]
+//		in: formData
+//		description: ISO 8601 timestamp from which away mode applies. Use empty string to start immediately.
+//		type: string
+//	-
+//		name: source[away_ends_at]
+//		in: formData
+//		description: ISO 8601 timestamp until which away mode applies. Use empty string for no end.
+//		type: string
+//	-
+//		name: source[away_auto_reply]
+//		in: formData
+//		description: |-
+//			While away, automatically reply to mentions with source[away_message]. Each account
+//			gets at most one auto-reply per day, and bot accounts never get one.
+//		type: boolean
+//	-
+//		name: source[require_media_descriptions]
+//		in: formData
+//		description: |-
+//			Reject new statuses that have media attached without a description (alt text).
+//			This has no effect if the instance already requires descriptions on all media.
+//		type: boolean
+//	-
+//		name: source[detect_language]
+//		in: formData
+//		description: |-
+//			Detect the language of new statuses posted without a language from their content,
+//			falling back to source[language] if it can't be detected with confidence.
+//			This has no effect if the instance already detects the language of all statuses.
+//		type: boolean
+//	-
+//		name: source[local_only]
+//		in: formData
+//		description: |-
+//			Make new statuses local-only by default, ie., never federated to other instances.
+//			This can be overridden per status with the `federated` field. Direct statuses are always federated.
+//		type: boolean
+//	-
+//		name: source[continue_thread]
+//		in: formData
+//		description: |-
+//			Make new statuses posted without in_reply_to_id reply to your own most recent status by default, continuing a thread.
+//			This can be overridden per status with the `continue_thread` field.
+//		type: boolean
+//	-
+//		name: source[rotating_pin_status_ids][]
+//		in: formData
+//		description: |-
+//			IDs of up to 10 of your own statuses to take turns being pinned to your profile, in order.
+//			Statuses that are deleted later on are skipped. Only used if source[rotating_pin_interval] is set.
+//		type: array
+//		items:
+//			type: string
+//	-
+//		name: source[rotating_pin_interval]
+//		in: formData
+//		description: |-
+//			Number of seconds each status in source[rotating_pin_status_ids] stays pinned before
+//			the next one takes its place. Must be at least an hour. Use 0 to turn rotation off.
+//		type: integer
+//	-
 //		name: theme
 //		in: formData
 //		description: >-
@@ -133,6 +267,11 @@ import (
 //		description: Hide the account's following/followers collections.
 //		type: boolean
 //	-
+//		name: hide_web_profile
+//		in: formData
+//		description: Hide the account's web profile, web statuses, and RSS feed from visitors who aren't logged in as a follower.
+//		type: boolean
+//	-
 //		name: fields_attributes[0][name]
 //		in: formData
 //		description: Name of 1st profile field to be added to this account's profile.
@@ -314,18 +453,38 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 			form.Bot == nil &&
 			form.DisplayName == nil &&
 			form.Note == nil &&
+			form.Pronouns == nil &&
 			form.Avatar == nil &&
 			form.Header == nil &&
 			form.Locked == nil &&
 			form.Source.Privacy == nil &&
+			form.Source.ReplyPrivacy == nil &&
 			form.Source.Sensitive == nil &&
+			form.Source.DefaultMediaSensitive == nil &&
 			form.Source.Language == nil &&
 			form.Source.StatusContentType == nil &&
+			form.Source.BioContentType == nil &&
+			form.Source.DefaultContentWarning == nil &&
+			form.Source.DefaultPostExpiry == nil &&
+			form.Source.AutoApproveFollowsAfter == nil &&
+			form.Source.ReviewLocalFollows == nil &&
+			form.Source.AwayEnabled == nil &&
+			form.Source.AwayMessage == nil &&
+			form.Source.AwayStartsAt == nil &&
+			form.Source.AwayEndsAt == nil &&
+			form.Source.AwayAutoReply == nil &&
+			form.Source.RequireMediaDescriptions == nil &&
+			form.Source.DetectLanguage == nil &&
+			form.Source.LocalOnly == nil &&
+			form.Source.ContinueThread == nil &&
+			form.Source.RotatingPinStatusIDs == nil &&
+			form.Source.RotatingPinInterval == nil &&
 			form.FieldsAttributes == nil &&
 			form.Theme == nil &&
 			form.CustomCSS == nil &&
 			form.EnableRSS == nil &&
-			form.HideCollections == nil) {
+			form.HideCollections == nil &&
+			form.HideWebProfile == nil) {
 		return nil, errors.New("empty form submitted")
 	}
 
@@ -28,37 +28,41 @@ import (
 )
 
 const (
-	BasePath                = "/v1/admin"
-	EmojiPath               = BasePath + "/custom_emojis"
-	EmojiPathWithID         = EmojiPath + "/:" + apiutil.IDKey
-	EmojiCategoriesPath     = EmojiPath + "/categories"
-	DomainBlocksPath        = BasePath + "/domain_blocks"
-	DomainBlocksPathWithID  = DomainBlocksPath + "/:" + apiutil.IDKey
-	DomainAllowsPath        = BasePath + "/domain_allows"
-	DomainAllowsPathWithID  = DomainAllowsPath + "/:" + apiutil.IDKey
-	DomainKeysExpirePath    = BasePath + "/domain_keys_expire"
-	HeaderAllowsPath        = BasePath + "/header_allows"
-	HeaderAllowsPathWithID  = HeaderAllowsPath + "/:" + apiutil.IDKey
-	HeaderBlocksPath        = BasePath + "/header_blocks"
-	HeaderBlocksPathWithID  = HeaderBlocksPath + "/:" + apiutil.IDKey
-	AccountsV1Path          = BasePath + "/accounts"
-	AccountsV2Path          = "/v2/admin/accounts"
-	AccountsPathWithID      = AccountsV1Path + "/:" + apiutil.IDKey
-	AccountsActionPath      = AccountsPathWithID + "/action"
-	AccountsApprovePath     = AccountsPathWithID + "/approve"
-	AccountsRejectPath      = AccountsPathWithID + "/reject"
-	MediaCleanupPath        = BasePath + "/media_cleanup"
-	MediaRefetchPath        = BasePath + "/media_refetch"
-	ReportsPath             = BasePath + "/reports"
-	ReportsPathWithID       = ReportsPath + "/:" + apiutil.IDKey
-	ReportsResolvePath      = ReportsPathWithID + "/resolve"
-	EmailPath               = BasePath + "/email"
-	EmailTestPath           = EmailPath + "/test"
-	InstanceRulesPath       = BasePath + "/instance/rules"
-	InstanceRulesPathWithID = InstanceRulesPath + "/:" + apiutil.IDKey
-	DebugPath               = BasePath + "/debug"
-	DebugAPUrlPath          = DebugPath + "/apurl"
-	DebugClearCachesPath    = DebugPath + "/caches/clear"
+	BasePath                   = "/v1/admin"
+	EmojiPath                  = BasePath + "/custom_emojis"
+	EmojiPathWithID            = EmojiPath + "/:" + apiutil.IDKey
+	EmojiCategoriesPath        = EmojiPath + "/categories"
+	DomainBlocksPath           = BasePath + "/domain_blocks"
+	DomainBlocksPathWithID     = DomainBlocksPath + "/:" + apiutil.IDKey
+	DomainAllowsPath           = BasePath + "/domain_allows"
+	DomainAllowsPathWithID     = DomainAllowsPath + "/:" + apiutil.IDKey
+	DomainKeysExpirePath       = BasePath + "/domain_keys_expire"
+	DomainSensitivesPath       = BasePath + "/domain_sensitives"
+	DomainSensitivesPathWithID = DomainSensitivesPath + "/:" + apiutil.IDKey
+	HeaderAllowsPath           = BasePath + "/header_allows"
+	HeaderAllowsPathWithID     = HeaderAllowsPath + "/:" + apiutil.IDKey
+	HeaderBlocksPath           = BasePath + "/header_blocks"
+	HeaderBlocksPathWithID     = HeaderBlocksPath + "/:" + apiutil.IDKey
+	AccountsV1Path             = BasePath + "/accounts"
+	AccountsV2Path             = "/v2/admin/accounts"
+	AccountsPathWithID         = AccountsV1Path + "/:" + apiutil.IDKey
+	AccountsActionPath         = AccountsPathWithID + "/action"
+	AccountsApprovePath        = AccountsPathWithID + "/approve"
+	AccountsRejectPath         = AccountsPathWithID + "/reject"
+	AccountsTokensPath         = AccountsPathWithID + "/tokens"
+	AccountsTokensRevokePath   = AccountsTokensPath + "/revoke"
+	MediaCleanupPath           = BasePath + "/media_cleanup"
+	MediaRefetchPath           = BasePath + "/media_refetch"
+	ReportsPath                = BasePath + "/reports"
+	ReportsPathWithID          = ReportsPath + "/:" + apiutil.IDKey
+	ReportsResolvePath         = ReportsPathWithID + "/resolve"
+	EmailPath                  = BasePath + "/email"
+	EmailTestPath              = EmailPath + "/test"
+	InstanceRulesPath          = BasePath + "/instance/rules"
+	InstanceRulesPathWithID    = InstanceRulesPath + "/:" + apiutil.IDKey
+	DebugPath                  = BasePath + "/debug"
+	DebugAPUrlPath             = DebugPath + "/apurl"
+	DebugClearCachesPath       = DebugPath + "/caches/clear"
 
 	FilterQueryKey        = "filter"
 	MaxShortcodeDomainKey = "max_shortcode_domain"
@@ -99,6 +103,12 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, DomainAllowsPathWithID, m.DomainAllowGETHandler)
 	attachHandler(http.MethodDelete, DomainAllowsPathWithID, m.DomainAllowDELETEHandler)
 
+	// domain sensitive stuff
+	attachHandler(http.MethodPost, DomainSensitivesPath, m.DomainSensitivePOSTHandler)
+	attachHandler(http.MethodGet, DomainSensitivesPath, m.DomainSensitivesGETHandler)
+	attachHandler(http.MethodGet, DomainSensitivesPathWithID, m.DomainSensitiveGETHandler)
+	attachHandler(http.MethodDelete, DomainSensitivesPathWithID, m.DomainSensitiveDELETEHandler)
+
 	// header filtering administration routes
 	attachHandler(http.MethodGet, HeaderAllowsPathWithID, m.HeaderFilterAllowGET)
 	attachHandler(http.MethodGet, HeaderBlocksPathWithID, m.HeaderFilterBlockGET)
@@ -119,6 +129,8 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
 	attachHandler(http.MethodPost, AccountsApprovePath, m.AccountApprovePOSTHandler)
 	attachHandler(http.MethodPost, AccountsRejectPath, m.AccountRejectPOSTHandler)
+	attachHandler(http.MethodGet, AccountsTokensPath, m.AccountTokensGETHandler)
+	attachHandler(http.MethodPost, AccountsTokensRevokePath, m.AccountTokensRevokePOSTHandler)
 
 	// media stuff
 	attachHandler(http.MethodPost, MediaCleanupPath, m.MediaCleanupPOSTHandler)
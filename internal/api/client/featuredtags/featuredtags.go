@@ -25,7 +25,12 @@ import (
 )
 
 const (
+	// IDKey is the key to use for retrieving featured tag ID in requests
+	IDKey = "id"
+	// BasePath is the base path for serving the featured tags API, minus the 'api' prefix
 	BasePath = "/v1/featured_tags"
+	// BasePathWithID is just the base path with the ID key in it.
+	BasePathWithID = BasePath + "/:" + IDKey
 )
 
 type Module struct {
@@ -40,4 +45,6 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.FeaturedTagsGETHandler)
+	attachHandler(http.MethodPost, BasePath, m.FeaturedTagCreatePOSTHandler)
+	attachHandler(http.MethodDelete, BasePathWithID, m.FeaturedTagDELETEHandler)
 }
@@ -29,6 +29,12 @@ const (
 	BasePath = "/v1/filters"
 	// BasePathWithID is the base path with the ID key in it, for operations on an existing filter.
 	BasePathWithID = BasePath + "/:" + apiutil.IDKey
+	// ImportPath is the path for importing filters in bulk.
+	ImportPath = BasePath + "/import"
+
+	// ImportMaxFilters is the max number of
+	// filters that can be imported at once.
+	ImportMaxFilters = 500
 )
 
 // Module implements APIs for client-side aka "v1" filtering.
@@ -45,6 +51,7 @@ func New(processor *processing.Processor) *Module {
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.FiltersGETHandler)
 	attachHandler(http.MethodPost, BasePath, m.FilterPOSTHandler)
+	attachHandler(http.MethodPost, ImportPath, m.FiltersImportPOSTHandler)
 	attachHandler(http.MethodGet, BasePathWithID, m.FilterGETHandler)
 	attachHandler(http.MethodPut, BasePathWithID, m.FilterPUTHandler)
 	attachHandler(http.MethodDelete, BasePathWithID, m.FilterDELETEHandler)
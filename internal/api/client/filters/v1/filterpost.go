@@ -88,7 +88,7 @@ import (
 //		name: irreversible
 //		in: formData
 //		description: |-
-//			Should matching entities be removed from the user's timelines/views, instead of hidden? Not supported yet.
+//			Should matching entities be removed from the user's timelines/views, instead of hidden?
 //
 //			Sample: false
 //		type: boolean
@@ -18,6 +18,7 @@
 package v1_test
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -30,6 +31,7 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/superseriousbusiness/gotosocial/testrig"
@@ -200,6 +202,34 @@ func (suite *FiltersTestSuite) TestPostFilterMinimal() {
 	suite.checkStreamed(homeStream, true, "", stream.EventTypeFiltersChanged)
 }
 
+func (suite *FiltersTestSuite) TestPostFilterIrreversible() {
+	homeStream := suite.openHomeStream(suite.testAccounts["local_account_1"])
+
+	phrase := "GNU/Linux"
+	filterContext := []string{"home"}
+	irreversible := true
+	filter, err := suite.postFilter(&phrase, &filterContext, &irreversible, nil, nil, nil, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(phrase, filter.Phrase)
+	suite.True(filter.Irreversible)
+
+	// The equivalent v2 filter should use the "hide" action.
+	dbFilterKeyword, err := suite.db.GetFilterKeywordByID(context.Background(), filter.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	dbFilter, err := suite.db.GetFilterByID(context.Background(), dbFilterKeyword.FilterID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.FilterActionHide, dbFilter.Action)
+
+	suite.checkStreamed(homeStream, true, "", stream.EventTypeFiltersChanged)
+}
+
 func (suite *FiltersTestSuite) TestPostFilterEmptyPhrase() {
 	phrase := ""
 	context := []string{"home"}
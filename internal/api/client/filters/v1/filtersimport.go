@@ -0,0 +1,371 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// FiltersImportPOSTHandler swagger:operation POST /api/v1/filters/import filtersV1Import
+//
+// Create v1 filters in bulk, eg. from a list of filters exported from another server.
+//
+// The request body can be either:
+//
+// - A JSON array of v1 filters, as returned by GET /api/v1/filters.
+//
+// - A JSON array of v2 filters, as returned by GET /api/v2/filters, or a document created by GET /api/v2/filters/export. Each keyword of these becomes a v1 filter.
+//
+// - A CSV file (content type `text/csv`) with a header row, and the columns `phrase`, `context` (contexts separated by spaces), `whole_word`, `irreversible`, and `expires_at`. Only `phrase` and `context` are required.
+//
+// Each filter is validated individually. Filters with the same phrase and context as an existing filter,
+// or an earlier filter in the import, are skipped. Invalid filters are rejected, rather than aborting the
+// whole import. Filters which have already expired are rejected.
+//
+// At most 500 filters can be imported in one request.
+//
+//	---
+//	tags:
+//	- filters
+//
+//	consumes:
+//	- application/json
+//	- text/csv
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: filters
+//		in: body
+//		required: true
+//		description: Filters to import.
+//		schema:
+//			type: array
+//			items:
+//				"$ref": "#/definitions/filterV1"
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:filters
+//
+//	responses:
+//		'200':
+//			name: result
+//			description: Outcome of the import.
+//			schema:
+//				"$ref": "#/definitions/filterImportResultV1"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden to moved accounts
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) FiltersImportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	var items []importItem
+	if c.ContentType() == apiutil.TextCSV {
+		items, err = parseImportCSV(c.Request.Body)
+	} else {
+		items, err = parseImportJSON(c.Request.Body)
+	}
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if len(items) > ImportMaxFilters {
+		err := fmt.Errorf("at most %d filters can be imported at once, provided %d", ImportMaxFilters, len(items))
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	now := time.Now()
+	form := &apimodel.FilterImportRequestV1{
+		Filters: make([]apimodel.FilterImportEntryV1, len(items)),
+	}
+	for i, item := range items {
+		entry := &form.Filters[i]
+		entry.Index = item.index
+		entry.Phrase = item.phrase
+		if item.err != nil {
+			entry.Error = item.err.Error()
+			continue
+		}
+
+		filter, err := item.toForm(now)
+		if err == nil {
+			err = validateNormalizeCreateUpdateFilter(filter)
+		}
+		if err != nil {
+			entry.Error = err.Error()
+			continue
+		}
+
+		entry.Filter = filter
+	}
+
+	result, errWithCode := m.processor.FiltersV1().Import(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, result)
+}
+
+// importItem is a single v1 filter
+// parsed from an import document.
+type importItem struct {
+	index        int
+	phrase       string
+	context      []apimodel.FilterContext
+	wholeWord    *bool
+	irreversible *bool
+	expiresAt    *string
+
+	// set if the filter could
+	// not be parsed at all.
+	err error
+}
+
+// toForm converts the import item to params for creating a v1 filter.
+func (i *importItem) toForm(now time.Time) (*apimodel.FilterCreateUpdateRequestV1, error) {
+	form := &apimodel.FilterCreateUpdateRequestV1{
+		Phrase:       i.phrase,
+		Context:      i.context,
+		WholeWord:    i.wholeWord,
+		Irreversible: i.irreversible,
+	}
+
+	if i.expiresAt != nil && *i.expiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, *i.expiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse expires_at: %w", err)
+		}
+
+		expiresIn := int(math.Ceil(expiresAt.Sub(now).Seconds()))
+		if expiresIn <= 0 {
+			return nil, errors.New("filter has already expired")
+		}
+		form.ExpiresIn = &expiresIn
+	}
+
+	return form, nil
+}
+
+// importFilterJSON covers the fields of both
+// v1 and v2 filters that are used by imports.
+type importFilterJSON struct {
+	// v1 only.
+	Phrase       string `json:"phrase"`
+	WholeWord    *bool  `json:"whole_word"`
+	Irreversible *bool  `json:"irreversible"`
+
+	// v2 only.
+	FilterAction apimodel.FilterAction `json:"filter_action"`
+	Keywords     []struct {
+		Keyword   string `json:"keyword"`
+		WholeWord *bool  `json:"whole_word"`
+	} `json:"keywords"`
+
+	// Both.
+	Context   []apimodel.FilterContext `json:"context"`
+	ExpiresAt *string                  `json:"expires_at"`
+}
+
+// parseImportJSON parses v1 filters from a JSON array of v1 or
+// v2 filters, or a v2 filter export document. Filters that can't
+// be parsed are returned with an error, so they can be reported.
+func parseImportJSON(r io.Reader) ([]importItem, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var raws []json.RawMessage
+	if b = bytes.TrimSpace(b); len(b) > 0 && b[0] == '{' {
+		// Filter export document.
+		var doc struct {
+			Filters []json.RawMessage `json:"filters"`
+		}
+		if err := json.Unmarshal(b, &doc); err != nil {
+			return nil, fmt.Errorf("could not parse filter export document: %w", err)
+		}
+		raws = doc.Filters
+	} else if err := json.Unmarshal(b, &raws); err != nil {
+		return nil, fmt.Errorf("could not parse filters: %w", err)
+	}
+
+	items := make([]importItem, 0, len(raws))
+	for i, raw := range raws {
+		var filter importFilterJSON
+		if err := json.Unmarshal(raw, &filter); err != nil {
+			items = append(items, importItem{
+				index: i,
+				err:   fmt.Errorf("malformed filter: %w", err),
+			})
+			continue
+		}
+
+		if filter.Keywords == nil {
+			// v1 filter.
+			items = append(items, importItem{
+				index:        i,
+				phrase:       filter.Phrase,
+				context:      filter.Context,
+				wholeWord:    filter.WholeWord,
+				irreversible: filter.Irreversible,
+				expiresAt:    filter.ExpiresAt,
+			})
+			continue
+		}
+
+		// v2 filter, one v1 filter per keyword.
+		irreversible := filter.FilterAction == apimodel.FilterActionHide
+		for _, keyword := range filter.Keywords {
+			items = append(items, importItem{
+				index:        i,
+				phrase:       keyword.Keyword,
+				context:      filter.Context,
+				wholeWord:    keyword.WholeWord,
+				irreversible: &irreversible,
+				expiresAt:    filter.ExpiresAt,
+			})
+		}
+	}
+
+	return items, nil
+}
+
+// parseImportCSV parses v1 filters from a CSV file with a header row.
+// Filters that can't be parsed are returned with an error, so they
+// can be reported.
+func parseImportCSV(r io.Reader) ([]importItem, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"phrase", "context"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV header is missing required column %s", name)
+		}
+	}
+
+	var items []importItem
+	for i := 0; ; i++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		item := importItem{index: i}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			item.err = fmt.Errorf("malformed filter: %w", err)
+			items = append(items, item)
+			continue
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		item.phrase = field("phrase")
+		for _, context := range strings.Fields(field("context")) {
+			item.context = append(item.context, apimodel.FilterContext(context))
+		}
+		if expiresAt := field("expires_at"); expiresAt != "" {
+			item.expiresAt = &expiresAt
+		}
+		item.wholeWord, err = parseImportBool(field("whole_word"))
+		if err == nil {
+			item.irreversible, err = parseImportBool(field("irreversible"))
+		}
+		if err != nil {
+			item.err = err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// parseImportBool parses an optional CSV boolean.
+func parseImportBool(s string) (*bool, error) {
+	if s == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s as boolean", s)
+	}
+	return &b, nil
+}
@@ -0,0 +1,181 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	filtersV1 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v1"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+func (suite *FiltersTestSuite) importFilters(body string, contentType string, expectedHTTPStatus int) *apimodel.FilterImportResultV1 {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	// create the request
+	ctx.Request = httptest.NewRequest(http.MethodPost, config.GetProtocol()+"://"+config.GetHost()+"/api/"+filtersV1.ImportPath, strings.NewReader(body))
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Header.Set("content-type", contentType)
+
+	// trigger the handler
+	suite.filtersModule.FiltersImportPOSTHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Equal(expectedHTTPStatus, recorder.Code, string(b)) || expectedHTTPStatus != http.StatusOK {
+		return nil
+	}
+
+	resp := &apimodel.FilterImportResultV1{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return resp
+}
+
+func (suite *FiltersTestSuite) TestImportFiltersV1() {
+	homeStream := suite.openHomeStream(suite.testAccounts["local_account_1"])
+
+	result := suite.importFilters(`[
+		{"phrase": "GNU/Linux", "context": ["home"], "whole_word": true, "expires_at": "2999-01-01T00:00:00Z"},
+		{"phrase": "fnord", "context": ["public", "home"]},
+		{"phrase": "GNU/Linux", "context": ["home", "home"]},
+		{"phrase": "ratio", "context": ["nowhere"]},
+		{"phrase": "ancient history", "context": ["home"], "expires_at": "2001-01-01T00:00:00Z"},
+		"not a filter"
+	]`, "application/json", http.StatusOK)
+	if result == nil {
+		suite.FailNow("")
+	}
+
+	if suite.Len(result.Imported, 1) {
+		suite.Equal("GNU/Linux", result.Imported[0].Phrase)
+		suite.True(result.Imported[0].WholeWord)
+		suite.NotNil(result.Imported[0].ExpiresAt)
+	}
+
+	// Same phrase and context as an existing
+	// filter, and an earlier filter in the import.
+	suite.Equal([]apimodel.FilterImportEntryResultV1{
+		{Index: 1, Phrase: "fnord", Error: "filter with this phrase and context already exists"},
+		{Index: 2, Phrase: "GNU/Linux", Error: "filter with this phrase and context already exists"},
+	}, result.Skipped)
+
+	if suite.Len(result.Rejected, 3) {
+		suite.Equal(3, result.Rejected[0].Index)
+		suite.Equal(4, result.Rejected[1].Index)
+		suite.Equal("filter has already expired", result.Rejected[1].Error)
+		suite.Equal(5, result.Rejected[2].Index)
+		suite.Empty(result.Rejected[2].Phrase)
+	}
+
+	// One filters changed event for the whole import.
+	suite.checkStreamed(homeStream, true, "", stream.EventTypeFiltersChanged)
+}
+
+func (suite *FiltersTestSuite) TestImportFiltersV2Export() {
+	result := suite.importFilters(`{
+		"exported_at": "2024-01-01T00:00:00Z",
+		"filters": [
+			{
+				"title": "birds",
+				"context": ["home", "thread"],
+				"filter_action": "hide",
+				"keywords": [
+					{"keyword": "goose", "whole_word": true},
+					{"keyword": "duck", "whole_word": false}
+				]
+			}
+		]
+	}`, "application/json", http.StatusOK)
+	if result == nil {
+		suite.FailNow("")
+	}
+
+	suite.Empty(result.Skipped)
+	suite.Empty(result.Rejected)
+	if suite.Len(result.Imported, 2) {
+		suite.Equal("goose", result.Imported[0].Phrase)
+		suite.True(result.Imported[0].WholeWord)
+		suite.True(result.Imported[0].Irreversible)
+		suite.Equal("duck", result.Imported[1].Phrase)
+		suite.False(result.Imported[1].WholeWord)
+		suite.True(result.Imported[1].Irreversible)
+	}
+}
+
+func (suite *FiltersTestSuite) TestImportFiltersCSV() {
+	result := suite.importFilters(
+		"phrase,context,whole_word,irreversible\n"+
+			"kale,home public,true,false\n"+
+			"fnord,home public,,\n"+
+			"spinach,home,maybe,\n",
+		"text/csv",
+		http.StatusOK,
+	)
+	if result == nil {
+		suite.FailNow("")
+	}
+
+	if suite.Len(result.Imported, 1) {
+		suite.Equal("kale", result.Imported[0].Phrase)
+		suite.True(result.Imported[0].WholeWord)
+		suite.False(result.Imported[0].Irreversible)
+	}
+	if suite.Len(result.Skipped, 1) {
+		suite.Equal(1, result.Skipped[0].Index)
+	}
+	if suite.Len(result.Rejected, 1) {
+		suite.Equal(2, result.Rejected[0].Index)
+		suite.Equal("could not parse maybe as boolean", result.Rejected[0].Error)
+	}
+}
+
+func (suite *FiltersTestSuite) TestImportFiltersCSVMissingColumn() {
+	suite.importFilters("phrase\nkale\n", "text/csv", http.StatusBadRequest)
+}
+
+func (suite *FiltersTestSuite) TestImportFiltersTooMany() {
+	filters := make([]string, filtersV1.ImportMaxFilters+1)
+	for i := range filters {
+		filters[i] = `{"phrase": "kale", "context": ["home"]}`
+	}
+	suite.importFilters("["+strings.Join(filters, ",")+"]", "application/json", http.StatusBadRequest)
+}
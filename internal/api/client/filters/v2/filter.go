@@ -44,6 +44,20 @@ const (
 	StatusPath = BasePath + "/statuses"
 	// StatusPathWithStatusID is the path for operations on an existing filter status.
 	StatusPathWithStatusID = StatusPath + "/:" + apiutil.IDKey
+
+	// ExportPath is the path for exporting all filters.
+	ExportPath = BasePath + "/export"
+	// ImportPath is the path for importing filters from an export.
+	ImportPath = BasePath + "/import"
+	// ExportMastodonPath is the path for exporting all filters in Mastodon format.
+	ExportMastodonPath = ExportPath + "/mastodon"
+	// ImportMastodonPath is the path for importing filters in Mastodon format.
+	ImportMastodonPath = ImportPath + "/mastodon"
+
+	// ImportModeKey is the query key for the filter import mode.
+	ImportModeKey = "mode"
+	// ImportExpiryKey is the query key for how to treat filter expiry on import.
+	ImportExpiryKey = "expiry"
 )
 
 // Module implements APIs for client-side aka "v1" filtering.
@@ -65,6 +79,11 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPut, BasePathWithID, m.FilterPUTHandler)
 	attachHandler(http.MethodDelete, BasePathWithID, m.FilterDELETEHandler)
 
+	attachHandler(http.MethodGet, ExportPath, m.FiltersExportGETHandler)
+	attachHandler(http.MethodPost, ImportPath, m.FiltersImportPOSTHandler)
+	attachHandler(http.MethodGet, ExportMastodonPath, m.FiltersExportMastodonGETHandler)
+	attachHandler(http.MethodPost, ImportMastodonPath, m.FiltersImportMastodonPOSTHandler)
+
 	attachHandler(http.MethodGet, FilterKeywordsPathWithID, m.FilterKeywordsGETHandler)
 	attachHandler(http.MethodPost, FilterKeywordsPathWithID, m.FilterKeywordPOSTHandler)
 
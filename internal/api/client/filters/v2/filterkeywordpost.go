@@ -62,7 +62,7 @@ import (
 //			Sample: fnord
 //		type: string
 //		minLength: 1
-//		maxLength: 40
+//		maxLength: 200
 //	-
 //		name: whole_word
 //		in: formData
@@ -72,6 +72,16 @@ import (
 //			Sample: true
 //		type: boolean
 //		default: false
+//	-
+//		name: regex
+//		in: formData
+//		description: |-
+//			Should the keyword be matched as a case-insensitive regular expression (RE2 syntax), instead of as a literal phrase?
+//			Only allowed if the instance has enabled regex filters. Literal keywords may be up to 40 characters, regex keywords up to 200.
+//
+//			Sample: false
+//		type: boolean
+//		default: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -143,11 +153,12 @@ func (m *Module) FilterKeywordPOSTHandler(c *gin.Context) {
 }
 
 func validateNormalizeCreateUpdateFilterKeyword(form *apimodel.FilterKeywordCreateUpdateRequest) error {
-	if err := validate.FilterKeyword(form.Keyword); err != nil {
+	form.WholeWord = util.Ptr(util.PtrValueOr(form.WholeWord, false))
+	form.Regex = util.Ptr(util.PtrValueOr(form.Regex, false))
+
+	if err := validate.FilterKeywordOrRegex(form.Keyword, *form.Regex); err != nil {
 		return err
 	}
 
-	form.WholeWord = util.Ptr(util.PtrValueOr(form.WholeWord, false))
-
 	return nil
 }
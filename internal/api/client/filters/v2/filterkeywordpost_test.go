@@ -159,6 +159,52 @@ func (suite *FiltersTestSuite) TestPostFilterKeywordMinimal() {
 	suite.checkStreamed(homeStream, true, "", stream.EventTypeFiltersChanged)
 }
 
+func (suite *FiltersTestSuite) TestPostFilterKeywordRegexJSON() {
+	homeStream := suite.openHomeStream(suite.testAccounts["local_account_1"])
+
+	filterID := suite.testFilters["local_account_1_filter_1"].ID
+	requestJson := `{
+		"keyword": "fnord(s|ing)?",
+		"regex": true
+	}`
+	filterKeyword, err := suite.postFilterKeyword(filterID, nil, nil, &requestJson, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("fnord(s|ing)?", filterKeyword.Keyword)
+	suite.True(filterKeyword.Regex)
+
+	suite.checkStreamed(homeStream, true, "", stream.EventTypeFiltersChanged)
+}
+
+func (suite *FiltersTestSuite) TestPostFilterKeywordInvalidRegexJSON() {
+	filterID := suite.testFilters["local_account_1_filter_1"].ID
+	requestJson := `{
+		"keyword": "(fnord",
+		"regex": true
+	}`
+	_, err := suite.postFilterKeyword(filterID, nil, nil, &requestJson, http.StatusUnprocessableEntity, "{\"error\":\"Unprocessable Entity: filter keyword is not a valid regular expression: error parsing regexp: missing closing ): `(fnord`\"}")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *FiltersTestSuite) TestPostFilterKeywordRegexDisabledJSON() {
+	config.SetInstanceAllowRegexFilters(false)
+	defer config.SetInstanceAllowRegexFilters(true)
+
+	filterID := suite.testFilters["local_account_1_filter_1"].ID
+	requestJson := `{
+		"keyword": "fnord(s|ing)?",
+		"regex": true
+	}`
+	_, err := suite.postFilterKeyword(filterID, nil, nil, &requestJson, http.StatusUnprocessableEntity, `{"error":"Unprocessable Entity: regular expression filter keywords are not enabled on this instance"}`)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
 func (suite *FiltersTestSuite) TestPostFilterKeywordEmptyKeyword() {
 	filterID := suite.testFilters["local_account_1_filter_1"].ID
 	keyword := ""
@@ -60,7 +60,7 @@ import (
 //			Sample: fnord
 //		type: string
 //		minLength: 1
-//		maxLength: 40
+//		maxLength: 200
 //	-
 //		name: whole_word
 //		in: formData
@@ -69,6 +69,16 @@ import (
 //
 //			Sample: true
 //		type: boolean
+//	-
+//		name: regex
+//		in: formData
+//		description: |-
+//			Should the keyword be matched as a case-insensitive regular expression (RE2 syntax), instead of as a literal phrase?
+//			Only allowed if the instance has enabled regex filters. Literal keywords may be up to 40 characters, regex keywords up to 200.
+//
+//			Sample: false
+//		type: boolean
+//		default: false
 //
 //	security:
 //	- OAuth2 Bearer:
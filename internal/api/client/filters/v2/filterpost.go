@@ -99,8 +99,23 @@ import (
 //		enum:
 //			- warn
 //			- hide
+//			- blur
+//			- notify
 //		default: warn
 //	-
+//		name: filter_mode
+//		in: formData
+//		description: |-
+//			Whether the filter applies its action to statuses that match it (block),
+//			or hides all statuses that don't match it in its contexts (allow).
+//
+//			Sample: block
+//		type: string
+//		enum:
+//			- block
+//			- allow
+//		default: block
+//	-
 //		name: keywords_attributes[][keyword]
 //		in: formData
 //		type: array
@@ -117,6 +132,14 @@ import (
 //		description: Should each keyword consider word boundaries?
 //		collectionFormat: multi
 //	-
+//		name: keywords_attributes[][regex]
+//		in: formData
+//		type: array
+//		items:
+//			type: boolean
+//		description: Should each keyword be matched as a regular expression? Only allowed if the instance has enabled regex filters.
+//		collectionFormat: multi
+//	-
 //		name: statuses_attributes[][status_id]
 //		in: formData
 //		type: array
@@ -124,6 +147,51 @@ import (
 //			type: string
 //		description: Statuses to be added to the filter.
 //		collectionFormat: multi
+//	-
+//		name: accounts[]
+//		in: formData
+//		type: array
+//		items:
+//			type: string
+//		description: IDs of accounts whose statuses (and boosts of whose statuses) the filter should match.
+//		collectionFormat: multi
+//	-
+//		name: domains[]
+//		in: formData
+//		type: array
+//		items:
+//			type: string
+//		description: Domains of accounts whose statuses (and boosts of whose statuses) the filter should match.
+//		collectionFormat: multi
+//	-
+//		name: include_subdomains
+//		in: formData
+//		type: boolean
+//		description: Whether the given domains should also match their subdomains.
+//		default: false
+//	-
+//		name: active_days[]
+//		in: formData
+//		type: array
+//		items:
+//			type: integer
+//		description: Days of the week on which the filter should apply, from 0 (Sunday) to 6 (Saturday). If omitted, applies every day.
+//		collectionFormat: multi
+//	-
+//		name: active_from
+//		in: formData
+//		type: string
+//		description: Time of day (HH:MM) from which the filter should apply. Must be given together with active_until.
+//	-
+//		name: active_until
+//		in: formData
+//		type: string
+//		description: Time of day (HH:MM) until which the filter should apply. If earlier than active_from, the window runs past midnight.
+//	-
+//		name: active_timezone
+//		in: formData
+//		type: string
+//		description: IANA time zone in which active days and times are interpreted. Defaults to UTC.
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -196,6 +264,10 @@ func validateNormalizeCreateFilter(form *apimodel.FilterCreateRequestV2) error {
 	if err := validate.FilterAction(action); err != nil {
 		return err
 	}
+	mode := util.PtrValueOr(form.FilterMode, apimodel.FilterModeBlock)
+	if err := validate.FilterMode(mode); err != nil {
+		return err
+	}
 	if err := validate.FilterContexts(form.Context); err != nil {
 		return err
 	}
@@ -210,6 +282,9 @@ func validateNormalizeCreateFilter(form *apimodel.FilterCreateRequestV2) error {
 			if i < len(form.KeywordsAttributesWholeWord) {
 				formKeyword.WholeWord = &form.KeywordsAttributesWholeWord[i]
 			}
+			if i < len(form.KeywordsAttributesRegex) {
+				formKeyword.Regex = &form.KeywordsAttributesRegex[i]
+			}
 			form.Keywords = append(form.Keywords, formKeyword)
 		}
 	}
@@ -226,6 +301,7 @@ func validateNormalizeCreateFilter(form *apimodel.FilterCreateRequestV2) error {
 
 	// Apply defaults for missing fields.
 	form.FilterAction = util.Ptr(action)
+	form.FilterMode = util.Ptr(mode)
 
 	// Normalize filter expiry if necessary.
 	// If we parsed this as JSON, expires_in
@@ -250,10 +326,12 @@ func validateNormalizeCreateFilter(form *apimodel.FilterCreateRequestV2) error {
 
 	// Normalize and validate new keywords and statuses.
 	for i, formKeyword := range form.Keywords {
-		if err := validate.FilterKeyword(formKeyword.Keyword); err != nil {
+		regex := util.PtrValueOr(formKeyword.Regex, false)
+		if err := validate.FilterKeywordOrRegex(formKeyword.Keyword, regex); err != nil {
 			return err
 		}
 		form.Keywords[i].WholeWord = util.Ptr(util.PtrValueOr(formKeyword.WholeWord, false))
+		form.Keywords[i].Regex = &regex
 	}
 	for _, formStatus := range form.Statuses {
 		if err := validate.ULID(formStatus.StatusID, "status_id"); err != nil {
@@ -261,5 +339,28 @@ func validateNormalizeCreateFilter(form *apimodel.FilterCreateRequestV2) error {
 		}
 	}
 
+	// Normalize and validate targeted accounts and domains.
+	if err := validate.FilterAccounts(form.Accounts); err != nil {
+		return err
+	}
+	form.Accounts = util.Deduplicate(form.Accounts)
+
+	domains, err := validate.FilterDomains(form.Domains)
+	if err != nil {
+		return err
+	}
+	form.Domains = domains
+	form.IncludeSubdomains = util.Ptr(util.PtrValueOr(form.IncludeSubdomains, false))
+
+	// Validate active window.
+	if _, _, err := validate.FilterActiveWindow(
+		form.ActiveDays,
+		form.ActiveFrom,
+		form.ActiveUntil,
+		form.ActiveTimezone,
+	); err != nil {
+		return err
+	}
+
 	return nil
 }
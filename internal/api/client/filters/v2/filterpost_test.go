@@ -264,6 +264,73 @@ func (suite *FiltersTestSuite) TestPostFilterMinimal() {
 	suite.checkStreamed(homeStream, true, "", stream.EventTypeFiltersChanged)
 }
 
+func (suite *FiltersTestSuite) TestPostFilterAccountsDomainsJSON() {
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	requestJson := `{
+		"title": "Some people",
+		"context": ["home", "public"],
+		"filter_action": "hide",
+		"accounts": ["` + targetAccount.ID + `"],
+		"domains": ["Example.org", "example.org."],
+		"include_subdomains": true
+	}`
+	filter, err := suite.postFilter(nil, nil, nil, nil, nil, nil, nil, &requestJson, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal([]string{targetAccount.ID}, filter.Accounts)
+	suite.Equal([]string{"example.org"}, filter.Domains)
+	suite.True(filter.IncludeSubdomains)
+	suite.Empty(filter.Keywords)
+}
+
+func (suite *FiltersTestSuite) TestPostFilterActiveWindowJSON() {
+	requestJson := `{
+		"title": "Work hours",
+		"context": ["home"],
+		"keywords_attributes": [{"keyword": "deadline"}],
+		"active_days": [1, 2, 3, 4, 5],
+		"active_from": "09:00",
+		"active_until": "17:30",
+		"active_timezone": "Europe/Amsterdam"
+	}`
+	filter, err := suite.postFilter(nil, nil, nil, nil, nil, nil, nil, &requestJson, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal([]int{1, 2, 3, 4, 5}, filter.ActiveDays)
+	suite.Equal("09:00", filter.ActiveFrom)
+	suite.Equal("17:30", filter.ActiveUntil)
+	suite.Equal("Europe/Amsterdam", filter.ActiveTimezone)
+}
+
+func (suite *FiltersTestSuite) TestPostFilterInvalidActiveWindowJSON() {
+	requestJson := `{
+		"title": "Work hours",
+		"context": ["home"],
+		"active_from": "09:00"
+	}`
+	_, err := suite.postFilter(nil, nil, nil, nil, nil, nil, nil, &requestJson, http.StatusUnprocessableEntity, `{"error":"Unprocessable Entity: filter active_from and active_until must be provided together"}`)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *FiltersTestSuite) TestPostFilterUnknownAccountJSON() {
+	requestJson := `{
+		"title": "Some people",
+		"context": ["home"],
+		"accounts": ["01HZ55WWWP82WYP2Z1CZ0T4KQJ"]
+	}`
+	_, err := suite.postFilter(nil, nil, nil, nil, nil, nil, nil, &requestJson, http.StatusUnprocessableEntity, `{"error":"Unprocessable Entity: account 01HZ55WWWP82WYP2Z1CZ0T4KQJ not found"}`)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
 func (suite *FiltersTestSuite) TestPostFilterEmptyTitle() {
 	title := ""
 	context := []string{"home"}
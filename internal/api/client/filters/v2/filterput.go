@@ -85,6 +85,14 @@ import (
 //		description: Should each keyword consider word boundaries?
 //		collectionFormat: multi
 //	-
+//		name: keywords_attributes[][regex]
+//		in: formData
+//		type: array
+//		items:
+//			type: boolean
+//		description: Should each keyword be matched as a regular expression? Only allowed if the instance has enabled regex filters.
+//		collectionFormat: multi
+//	-
 //		name: statuses_attributes[][status_id]
 //		in: formData
 //		type: array
@@ -121,6 +129,62 @@ import (
 //
 //			Sample: 86400
 //		type: number
+//	-
+//		name: filter_mode
+//		in: formData
+//		description: |-
+//			Whether the filter applies its action to statuses that match it (block),
+//			or hides all statuses that don't match it in its contexts (allow).
+//
+//			Sample: block
+//		type: string
+//		enum:
+//			- block
+//			- allow
+//	-
+//		name: accounts[]
+//		in: formData
+//		type: array
+//		items:
+//			type: string
+//		description: IDs of accounts whose statuses (and boosts of whose statuses) the filter should match. If provided, replaces the existing accounts.
+//		collectionFormat: multi
+//	-
+//		name: domains[]
+//		in: formData
+//		type: array
+//		items:
+//			type: string
+//		description: Domains of accounts whose statuses (and boosts of whose statuses) the filter should match. If provided, replaces the existing domains.
+//		collectionFormat: multi
+//	-
+//		name: include_subdomains
+//		in: formData
+//		type: boolean
+//		description: Whether the given domains should also match their subdomains.
+//	-
+//		name: active_days[]
+//		in: formData
+//		type: array
+//		items:
+//			type: integer
+//		description: Days of the week on which the filter should apply, from 0 (Sunday) to 6 (Saturday). If any active window parameter is provided, replaces the existing window.
+//		collectionFormat: multi
+//	-
+//		name: active_from
+//		in: formData
+//		type: string
+//		description: Time of day (HH:MM) from which the filter should apply. Must be given together with active_until.
+//	-
+//		name: active_until
+//		in: formData
+//		type: string
+//		description: Time of day (HH:MM) until which the filter should apply. If earlier than active_from, the window runs past midnight.
+//	-
+//		name: active_timezone
+//		in: formData
+//		type: string
+//		description: IANA time zone in which active days and times are interpreted. Defaults to UTC.
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -202,6 +266,11 @@ func validateNormalizeUpdateFilter(form *apimodel.FilterUpdateRequestV2) error {
 			return err
 		}
 	}
+	if form.FilterMode != nil {
+		if err := validate.FilterMode(*form.FilterMode); err != nil {
+			return err
+		}
+	}
 	if form.Context != nil {
 		if err := validate.FilterContexts(*form.Context); err != nil {
 			return err
@@ -214,6 +283,7 @@ func validateNormalizeUpdateFilter(form *apimodel.FilterUpdateRequestV2) error {
 		len(form.KeywordsAttributesID),
 		len(form.KeywordsAttributesKeyword),
 		len(form.KeywordsAttributesWholeWord),
+		len(form.KeywordsAttributesRegex),
 		len(form.KeywordsAttributesDestroy),
 	)
 	if numFormKeywords > 0 {
@@ -229,6 +299,9 @@ func validateNormalizeUpdateFilter(form *apimodel.FilterUpdateRequestV2) error {
 			if i < len(form.KeywordsAttributesWholeWord) {
 				formKeyword.WholeWord = &form.KeywordsAttributesWholeWord[i]
 			}
+			if i < len(form.KeywordsAttributesRegex) {
+				formKeyword.Regex = &form.KeywordsAttributesRegex[i]
+			}
 			if i < len(form.KeywordsAttributesDestroy) {
 				formKeyword.Destroy = &form.KeywordsAttributesDestroy[i]
 			}
@@ -283,8 +356,11 @@ func validateNormalizeUpdateFilter(form *apimodel.FilterUpdateRequestV2) error {
 
 	// Normalize and validate updates.
 	for i, formKeyword := range form.Keywords {
-		if formKeyword.Keyword != nil {
-			if err := validate.FilterKeyword(*formKeyword.Keyword); err != nil {
+		// Keywords being updated without saying whether they're a
+		// regex depend on the stored keyword, so are validated later.
+		if formKeyword.Keyword != nil && (formKeyword.ID == nil || formKeyword.Regex != nil) {
+			regex := util.PtrValueOr(formKeyword.Regex, false)
+			if err := validate.FilterKeywordOrRegex(*formKeyword.Keyword, regex); err != nil {
 				return err
 			}
 		}
@@ -318,5 +394,36 @@ func validateNormalizeUpdateFilter(form *apimodel.FilterUpdateRequestV2) error {
 		}
 	}
 
+	// Normalize and validate targeted accounts and domains.
+	if form.Accounts != nil {
+		if err := validate.FilterAccounts(*form.Accounts); err != nil {
+			return err
+		}
+		form.Accounts = util.Ptr(util.Deduplicate(*form.Accounts))
+	}
+	if form.Domains != nil {
+		domains, err := validate.FilterDomains(*form.Domains)
+		if err != nil {
+			return err
+		}
+		form.Domains = &domains
+	}
+
+	// Validate active window, which is replaced
+	// as a whole if any part of it is provided.
+	if form.ActiveDays != nil ||
+		form.ActiveFrom != nil ||
+		form.ActiveUntil != nil ||
+		form.ActiveTimezone != nil {
+		if _, _, err := validate.FilterActiveWindow(
+			util.PtrValueOr(form.ActiveDays, nil),
+			util.PtrValueOr(form.ActiveFrom, ""),
+			util.PtrValueOr(form.ActiveUntil, ""),
+			util.PtrValueOr(form.ActiveTimezone, ""),
+		); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
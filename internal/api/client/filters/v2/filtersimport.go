@@ -0,0 +1,140 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// FiltersImportPOSTHandler swagger:operation POST /api/v2/filters/import filtersV2Import
+//
+// Import filters from a document created by GET /api/v2/filters/export.
+//
+// Each filter is validated individually; malformed or invalid filters are
+// skipped, and the reason reported, rather than aborting the whole import.
+//
+//	---
+//	tags:
+//	- filters
+//
+//	consumes:
+//	- application/json
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: export
+//		in: body
+//		required: true
+//		description: Filter export document.
+//		schema:
+//			"$ref": "#/definitions/filterExport"
+//	-
+//		name: mode
+//		in: query
+//		description: |-
+//			How to treat existing filters of the account.
+//
+//			merge: keep existing filters, and add imported filters alongside them.
+//
+//			replace: delete existing filters before adding imported filters.
+//			Existing filters are only deleted if at least one imported filter is valid.
+//		type: string
+//		enum:
+//			- merge
+//			- replace
+//		default: merge
+//	-
+//		name: expiry
+//		in: query
+//		description: |-
+//			How to treat filter expiry times.
+//
+//			relative: imported filters expire the same amount of time after import
+//			as they would have expired after export.
+//
+//			absolute: imported filters expire at the exported expiry time.
+//		type: string
+//		enum:
+//			- relative
+//			- absolute
+//		default: relative
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:filters
+//
+//	responses:
+//		'200':
+//			name: result
+//			description: Outcome of the import for each filter.
+//			schema:
+//				"$ref": "#/definitions/filterImportResult"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden to moved accounts
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable content
+//		'500':
+//			description: internal server error
+func (m *Module) FiltersImportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.FilterImportRequest{}
+	if err := c.ShouldBindJSON(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+	form.Mode = c.DefaultQuery(ImportModeKey, apimodel.FilterImportModeMerge)
+	form.Expiry = c.DefaultQuery(ImportExpiryKey, apimodel.FilterImportExpiryRelative)
+
+	result, errWithCode := m.processor.FiltersV2().Import(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, result)
+}
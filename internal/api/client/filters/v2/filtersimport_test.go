@@ -0,0 +1,250 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	filtersV2 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v2"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+func (suite *FiltersTestSuite) importFilters(
+	accountFixtureName string,
+	requestJson string,
+	mode string,
+	expiry string,
+	expectedHTTPStatus int,
+	expectedBody string,
+) (*apimodel.FilterImportResult, error) {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountFixtureName])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountFixtureName]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountFixtureName])
+
+	// create the request
+	query := url.Values{}
+	if mode != "" {
+		query.Set(filtersV2.ImportModeKey, mode)
+	}
+	if expiry != "" {
+		query.Set(filtersV2.ImportExpiryKey, expiry)
+	}
+	ctx.Request = httptest.NewRequest(http.MethodPost, config.GetProtocol()+"://"+config.GetHost()+"/api/"+filtersV2.ImportPath+"?"+query.Encode(), strings.NewReader(requestJson))
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Header.Set("content-type", "application/json")
+
+	// trigger the handler
+	suite.filtersModule.FiltersImportPOSTHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := gtserror.NewMultiError(2)
+
+	// check code + body
+	if resultCode := recorder.Code; expectedHTTPStatus != resultCode {
+		errs.Appendf("expected %d got %d", expectedHTTPStatus, resultCode)
+		if expectedBody == "" {
+			return nil, errs.Combine()
+		}
+	}
+
+	// if we got an expected body, return early
+	if expectedBody != "" {
+		if string(b) != expectedBody {
+			errs.Appendf("expected %s got %s", expectedBody, string(b))
+		}
+		return nil, errs.Combine()
+	}
+
+	resp := &apimodel.FilterImportResult{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (suite *FiltersTestSuite) TestImportFiltersMerge() {
+	requestJson := `{
+		"exported_at": "2024-01-01T00:00:00.000Z",
+		"filters": [
+			{
+				"title": "cats",
+				"context": ["home", "thread"],
+				"filter_action": "hide",
+				"expires_at": null,
+				"keywords": [{"keyword": "meow", "whole_word": true}]
+			},
+			{
+				"title": "",
+				"context": ["home"]
+			},
+			"not a filter at all",
+			{
+				"title": "no contexts",
+				"context": []
+			},
+			{
+				"title": "gamer words",
+				"context": ["home"]
+			}
+		]
+	}`
+
+	result, err := suite.importFilters("local_account_2", requestJson, "", "", http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(1, result.Imported)
+	suite.Equal(4, result.Failed)
+	if !suite.Len(result.Filters, 5) {
+		suite.FailNow("")
+	}
+
+	suite.Equal("cats", result.Filters[0].Title)
+	suite.NotEmpty(result.Filters[0].ID)
+	suite.Empty(result.Filters[0].Error)
+
+	suite.Contains(result.Filters[1].Error, "filter title must be provided")
+	suite.Contains(result.Filters[2].Error, "malformed filter")
+	suite.Contains(result.Filters[3].Error, "at least one filter context is required")
+
+	// Existing filter was kept, so title conflicts.
+	suite.Equal("duplicate title or keyword", result.Filters[4].Error)
+
+	// Imported filter should now exist.
+	filter, err := suite.db.GetFilterByID(context.Background(), result.Filters[0].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(suite.testAccounts["local_account_2"].ID, filter.AccountID)
+	suite.True(*filter.ContextHome)
+	suite.True(*filter.ContextThread)
+	suite.Nil(filter.ContextPublic)
+	if suite.Len(filter.Keywords, 1) {
+		suite.Equal("meow", filter.Keywords[0].Keyword)
+		suite.True(*filter.Keywords[0].WholeWord)
+	}
+}
+
+func (suite *FiltersTestSuite) TestImportFiltersReplace() {
+	requestJson := `{
+		"exported_at": "2024-01-01T00:00:00.000Z",
+		"filters": [
+			{
+				"title": "gamer words",
+				"context": ["public"],
+				"keywords": [{"keyword": "pog", "whole_word": false}]
+			}
+		]
+	}`
+
+	result, err := suite.importFilters("local_account_2", requestJson, apimodel.FilterImportModeReplace, "", http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Existing filter with the same title was
+	// deleted, so the import doesn't conflict.
+	suite.Equal(1, result.Imported)
+	suite.Equal(0, result.Failed)
+
+	filters, err := suite.db.GetFiltersForAccountID(context.Background(), suite.testAccounts["local_account_2"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(filters, 1) {
+		suite.Equal(result.Filters[0].ID, filters[0].ID)
+	}
+}
+
+func (suite *FiltersTestSuite) TestImportFiltersExpiry() {
+	exportedAt := time.Now().Add(-time.Hour)
+	expiresAt := exportedAt.Add(2 * time.Hour)
+	requestJson := `{
+		"exported_at": "` + util.FormatISO8601(exportedAt) + `",
+		"filters": [
+			{
+				"title": "expiring",
+				"context": ["home"],
+				"expires_at": "` + util.FormatISO8601(expiresAt) + `"
+			}
+		]
+	}`
+
+	// Relative: should expire ~2h from now.
+	result, err := suite.importFilters("local_account_2", requestJson, "", apimodel.FilterImportExpiryRelative, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if !suite.Equal(1, result.Imported) {
+		suite.FailNow("")
+	}
+
+	filter, err := suite.db.GetFilterByID(context.Background(), result.Filters[0].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.WithinDuration(time.Now().Add(2*time.Hour), filter.ExpiresAt, time.Minute)
+
+	// Absolute: should expire ~1h from now.
+	result, err = suite.importFilters("local_account_2", requestJson, apimodel.FilterImportModeReplace, apimodel.FilterImportExpiryAbsolute, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if !suite.Equal(1, result.Imported) {
+		suite.FailNow("")
+	}
+
+	filter, err = suite.db.GetFilterByID(context.Background(), result.Filters[0].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.WithinDuration(expiresAt, filter.ExpiresAt, time.Second)
+}
+
+func (suite *FiltersTestSuite) TestImportFiltersInvalidMode() {
+	_, err := suite.importFilters("local_account_2", `{"filters": []}`, "overwrite", "", http.StatusBadRequest, `{"error":"Bad Request: filter import mode 'overwrite' was not recognized, valid options are 'merge', 'replace'"}`)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
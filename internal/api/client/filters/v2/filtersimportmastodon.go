@@ -0,0 +1,131 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// FiltersImportMastodonPOSTHandler swagger:operation POST /api/v2/filters/import/mastodon filtersV2ImportMastodon
+//
+// Import filters in the representation used by Mastodon's v2 filters API.
+//
+// Accepts the output of GET /api/v2/filters/export/mastodon, or of Mastodon's GET /api/v2/filters.
+// Each filter is validated individually; malformed or invalid filters are skipped, and the reason
+// reported, rather than aborting the whole import.
+//
+// Fields that are not recognized are dropped and reported as unmapped, as are filtered statuses and
+// target accounts that don't exist on this instance. Expiry times are imported as-is.
+//
+//	---
+//	tags:
+//	- filters
+//
+//	consumes:
+//	- application/json
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: filters
+//		in: body
+//		required: true
+//		description: Filters in Mastodon representation.
+//		schema:
+//			type: array
+//			items:
+//				"$ref": "#/definitions/filterMastodon"
+//	-
+//		name: mode
+//		in: query
+//		description: |-
+//			How to treat existing filters of the account.
+//
+//			merge: keep existing filters, and add imported filters alongside them.
+//
+//			replace: delete existing filters before adding imported filters.
+//			Existing filters are only deleted if at least one imported filter is valid.
+//		type: string
+//		enum:
+//			- merge
+//			- replace
+//		default: merge
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:filters
+//
+//	responses:
+//		'200':
+//			name: result
+//			description: Outcome of the import for each filter.
+//			schema:
+//				"$ref": "#/definitions/filterImportResult"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden to moved accounts
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) FiltersImportMastodonPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Kept raw so that malformed
+	// entries can be skipped individually.
+	var filters []json.RawMessage
+	if err := c.ShouldBindJSON(&filters); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+	mode := c.DefaultQuery(ImportModeKey, apimodel.FilterImportModeMerge)
+
+	result, errWithCode := m.processor.FiltersV2().ImportMastodon(c.Request.Context(), authed.Account, mode, filters)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, result)
+}
@@ -0,0 +1,332 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+
+	filtersV2 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v2"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+func (suite *FiltersTestSuite) exportFiltersMastodon(
+	accountFixtureName string,
+	expectedHTTPStatus int,
+) ([]byte, []apimodel.FilterMastodon, error) {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountFixtureName])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountFixtureName]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountFixtureName])
+
+	// create the request
+	ctx.Request = httptest.NewRequest(http.MethodGet, config.GetProtocol()+"://"+config.GetHost()+"/api/"+filtersV2.ExportMastodonPath, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	// trigger the handler
+	suite.filtersModule.FiltersExportMastodonGETHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// check code
+	if resultCode := recorder.Code; expectedHTTPStatus != resultCode {
+		errs := gtserror.NewMultiError(1)
+		errs.Appendf("expected %d got %d", expectedHTTPStatus, resultCode)
+		return nil, nil, errs.Combine()
+	}
+
+	resp := []apimodel.FilterMastodon{}
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, nil, err
+	}
+
+	return b, resp, nil
+}
+
+func (suite *FiltersTestSuite) importFiltersMastodon(
+	accountFixtureName string,
+	requestJson string,
+	mode string,
+	expectedHTTPStatus int,
+	expectedBody string,
+) (*apimodel.FilterImportResult, error) {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountFixtureName])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountFixtureName]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountFixtureName])
+
+	// create the request
+	query := url.Values{}
+	if mode != "" {
+		query.Set(filtersV2.ImportModeKey, mode)
+	}
+	ctx.Request = httptest.NewRequest(http.MethodPost, config.GetProtocol()+"://"+config.GetHost()+"/api/"+filtersV2.ImportMastodonPath+"?"+query.Encode(), strings.NewReader(requestJson))
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Header.Set("content-type", "application/json")
+
+	// trigger the handler
+	suite.filtersModule.FiltersImportMastodonPOSTHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := gtserror.NewMultiError(2)
+
+	// check code + body
+	if resultCode := recorder.Code; expectedHTTPStatus != resultCode {
+		errs.Appendf("expected %d got %d", expectedHTTPStatus, resultCode)
+		if expectedBody == "" {
+			return nil, errs.Combine()
+		}
+	}
+
+	// if we got an expected body, return early
+	if expectedBody != "" {
+		if string(b) != expectedBody {
+			errs.Appendf("expected %s got %s", expectedBody, string(b))
+		}
+		return nil, errs.Combine()
+	}
+
+	resp := &apimodel.FilterImportResult{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (suite *FiltersTestSuite) TestExportFiltersMastodon() {
+	_, filters, err := suite.exportFiltersMastodon("local_account_1", http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(filters, 4) {
+		suite.FailNow("")
+	}
+
+	// Filtered statuses are exported
+	// the way Mastodon represents them.
+	filter := filters[2]
+	suite.Equal("puppies", filter.Title)
+	suite.Equal([]apimodel.FilterMastodonStatus{
+		{
+			ID:       suite.testFilterStatuses["local_account_1_filter_3_status_1"].ID,
+			StatusID: suite.testFilterStatuses["local_account_1_filter_3_status_1"].StatusID,
+		},
+	}, filter.Statuses)
+
+	// Fixture filters only use fields that
+	// Mastodon supports, so have no extensions.
+	for _, filter := range filters {
+		suite.Nil(filter.GoToSocial)
+		suite.NotNil(filter.Keywords)
+		suite.NotNil(filter.Statuses)
+	}
+}
+
+func (suite *FiltersTestSuite) TestImportFiltersMastodon() {
+	requestJson := `[
+		{
+			"id": "1234",
+			"title": "cats",
+			"context": ["home", "thread"],
+			"filter_action": "warn",
+			"expires_at": null,
+			"keywords": [{"id": "5678", "keyword": "meow", "whole_word": true, "shiny": true}],
+			"statuses": [
+				{"id": "9012", "status_id": "01F8MHAAY43M6RJ473VQFCVH37"},
+				{"id": "3456", "status_id": "110000000000000000"}
+			],
+			"mastodon_only_field": "whatever",
+			"gotosocial": {
+				"filter_action": "notify",
+				"filter_mode": "allow",
+				"accounts": ["01F8MH5ZK5VRH73AKHQM6Y9VNX", "01HJR5QB6A1SQNJGTEZ3HVG0ZM"],
+				"domains": ["example.org"],
+				"include_subdomains": true
+			}
+		},
+		{
+			"title": "no contexts",
+			"context": [],
+			"keywords": [],
+			"statuses": []
+		}
+	]`
+
+	result, err := suite.importFiltersMastodon("local_account_2", requestJson, "", http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(1, result.Imported)
+	suite.Equal(1, result.Failed)
+	if !suite.Len(result.Filters, 2) {
+		suite.FailNow("")
+	}
+
+	suite.Equal("cats", result.Filters[0].Title)
+	suite.NotEmpty(result.Filters[0].ID)
+	suite.Empty(result.Filters[0].Error)
+	suite.Equal([]string{
+		"mastodon_only_field",
+		"keywords[0].shiny",
+		"statuses[1]: status 110000000000000000 not found",
+		"gotosocial.accounts[1]: account 01HJR5QB6A1SQNJGTEZ3HVG0ZM not found",
+	}, result.Filters[0].Unmapped)
+
+	suite.Contains(result.Filters[1].Error, "at least one filter context is required")
+
+	// Imported filter should now exist,
+	// with the GoToSocial extension fields.
+	filter, err := suite.db.GetFilterByID(context.Background(), result.Filters[0].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(suite.testAccounts["local_account_2"].ID, filter.AccountID)
+	suite.Equal(gtsmodel.FilterActionNotify, filter.Action)
+	suite.Equal(gtsmodel.FilterModeAllow, filter.Mode)
+	suite.Equal([]string{"01F8MH5ZK5VRH73AKHQM6Y9VNX"}, filter.TargetAccountIDs)
+	suite.Equal([]string{"example.org"}, filter.TargetDomains)
+	suite.True(*filter.TargetSubdomains)
+	if suite.Len(filter.Keywords, 1) {
+		suite.Equal("meow", filter.Keywords[0].Keyword)
+	}
+	if suite.Len(filter.Statuses, 1) {
+		suite.Equal("01F8MHAAY43M6RJ473VQFCVH37", filter.Statuses[0].StatusID)
+	}
+}
+
+func (suite *FiltersTestSuite) TestImportFiltersMastodonNotArray() {
+	_, err := suite.importFiltersMastodon("local_account_2", `{"filters": []}`, "", http.StatusBadRequest, "")
+	suite.NoError(err)
+}
+
+func (suite *FiltersTestSuite) TestFiltersMastodonRoundTrip() {
+	// Give the account a filter that uses
+	// fields Mastodon doesn't support.
+	requestJson := `[
+		{
+			"title": "quiet hours",
+			"context": ["home", "public"],
+			"filter_action": "warn",
+			"expires_at": "2099-01-01T00:00:00.000Z",
+			"keywords": [{"keyword": "^work", "whole_word": false, "regex": true}],
+			"statuses": [],
+			"gotosocial": {
+				"filter_action": "notify",
+				"accounts": ["01F8MH5ZK5VRH73AKHQM6Y9VNX"],
+				"active_days": [1, 2, 3, 4, 5],
+				"active_from": "22:00",
+				"active_until": "07:00",
+				"active_timezone": "Europe/Amsterdam"
+			}
+		}
+	]`
+	result, err := suite.importFiltersMastodon("local_account_1", requestJson, "", http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, result.Imported)
+
+	exportJson, before, err := suite.exportFiltersMastodon("local_account_1", http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if !suite.Len(before, 5) {
+		suite.FailNow("")
+	}
+
+	// Replace the account's filters with the export.
+	result, err = suite.importFiltersMastodon("local_account_1", string(exportJson), apimodel.FilterImportModeReplace, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(len(before), result.Imported)
+	suite.Equal(0, result.Failed)
+	for _, entryResult := range result.Filters {
+		suite.Empty(entryResult.Unmapped)
+	}
+
+	_, after, err := suite.exportFiltersMastodon("local_account_1", http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Apart from newly generated IDs,
+	// nothing should have changed.
+	suite.Equal(normalizeMastodonFilters(before), normalizeMastodonFilters(after))
+}
+
+// normalizeMastodonFilters clears the IDs of the given filters,
+// their keywords, and their statuses, and sorts them by content,
+// so that they can be compared across an import. (IDs generated
+// within the same millisecond don't sort in creation order.)
+func normalizeMastodonFilters(filters []apimodel.FilterMastodon) []apimodel.FilterMastodon {
+	for i := range filters {
+		filters[i].ID = ""
+		for j := range filters[i].Keywords {
+			filters[i].Keywords[j].ID = ""
+		}
+		slices.SortFunc(filters[i].Keywords, func(a, b apimodel.FilterMastodonKeyword) int {
+			return strings.Compare(a.Keyword, b.Keyword)
+		})
+		for j := range filters[i].Statuses {
+			filters[i].Statuses[j].ID = ""
+		}
+		slices.SortFunc(filters[i].Statuses, func(a, b apimodel.FilterMastodonStatus) int {
+			return strings.Compare(a.StatusID, b.StatusID)
+		})
+	}
+	slices.SortFunc(filters, func(a, b apimodel.FilterMastodon) int {
+		return strings.Compare(a.Title, b.Title)
+	})
+	return filters
+}
@@ -95,10 +95,19 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
     "statuses": {
       "max_characters": 5000,
       "max_media_attachments": 6,
+      "allow_mixed_media": true,
       "characters_reserved_per_url": 25,
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "circle",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -216,10 +225,19 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
     "statuses": {
       "max_characters": 5000,
       "max_media_attachments": 6,
+      "allow_mixed_media": true,
       "characters_reserved_per_url": 25,
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "circle",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -337,10 +355,19 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
     "statuses": {
       "max_characters": 5000,
       "max_media_attachments": 6,
+      "allow_mixed_media": true,
       "characters_reserved_per_url": 25,
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "circle",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -509,10 +536,19 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
     "statuses": {
       "max_characters": 5000,
       "max_media_attachments": 6,
+      "allow_mixed_media": true,
       "characters_reserved_per_url": 25,
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "circle",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -652,10 +688,19 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
     "statuses": {
       "max_characters": 5000,
       "max_media_attachments": 6,
+      "allow_mixed_media": true,
       "characters_reserved_per_url": 25,
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "circle",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -810,10 +855,19 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
     "statuses": {
       "max_characters": 5000,
       "max_media_attachments": 6,
+      "allow_mixed_media": true,
       "characters_reserved_per_url": 25,
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "circle",
+        "direct"
       ]
     },
     "media_attachments": {
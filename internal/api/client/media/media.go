@@ -29,6 +29,8 @@ const (
 	IDKey            = "id"                                    // IDKey is the key for media attachment IDs
 	BasePath         = "/:" + apiutil.APIVersionKey + "/media" // BasePath is the base API path for making media requests through v1 or v2 of the api (for mastodon API compatibility)
 	AttachmentWithID = BasePath + "/:" + IDKey                 // BasePathWithID corresponds to a media attachment with the given ID
+	UploadsPath      = BasePath + "/uploads"                   // UploadsPath is for getting presigned URLs to upload media directly to storage
+	FinalizePath     = UploadsPath + "/finalize"               // FinalizePath is for creating media attachments from direct uploads
 )
 
 type Module struct {
@@ -45,4 +47,6 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, BasePath, m.MediaCreatePOSTHandler)
 	attachHandler(http.MethodGet, AttachmentWithID, m.MediaGETHandler)
 	attachHandler(http.MethodPut, AttachmentWithID, m.MediaPUTHandler)
+	attachHandler(http.MethodPost, UploadsPath, m.MediaUploadPOSTHandler)
+	attachHandler(http.MethodPost, FinalizePath, m.MediaUploadFinalizePOSTHandler)
 }
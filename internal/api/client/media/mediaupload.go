@@ -0,0 +1,243 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// MediaUploadPOSTHandler swagger:operation POST /api/{api_version}/media/uploads mediaUploadCreate
+//
+// Get a presigned URL to upload a media file directly to storage.
+//
+// The file should then be uploaded to the returned URL with a PUT request, before the URL
+// expires, after which the upload can be turned into a media attachment by calling
+// `/api/{api_version}/media/uploads/finalize` with the returned key.
+//
+// This is only available on instances using S3 storage without proxying; otherwise, a
+// 404 is returned, and media should be uploaded via `/api/{api_version}/media` instead.
+//
+//	---
+//	tags:
+//	- media
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: api_version
+//		type: string
+//		in: path
+//		description: Version of the API to use. Must be either `v1` or `v2`.
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'200':
+//			description: The presigned upload URL.
+//			schema:
+//				"$ref": "#/definitions/attachmentUpload"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: direct uploads not supported by this instance
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadPOSTHandler(c *gin.Context) {
+	if _, errWithCode := apiutil.ParseAPIVersion(
+		c.Param(apiutil.APIVersionKey),
+		[]string{apiutil.APIv1, apiutil.APIv2}...,
+	); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	upload, errWithCode := m.processor.Media().CreateUpload(c.Request.Context(), authed.Account)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, upload)
+}
+
+// MediaUploadFinalizePOSTHandler swagger:operation POST /api/{api_version}/media/uploads/finalize mediaUploadFinalize
+//
+// Create a new media attachment from a file uploaded directly to storage.
+//
+// The file must have been uploaded to a presigned URL obtained from `/api/{api_version}/media/uploads`.
+// It is processed in the same way as a file uploaded via `/api/{api_version}/media`, and is removed
+// from its upload location afterwards, so each upload can only be finalized once.
+//
+//	---
+//	tags:
+//	- media
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: api_version
+//		type: string
+//		in: path
+//		description: Version of the API to use. Must be either `v1` or `v2`.
+//		required: true
+//	-
+//		name: key
+//		in: formData
+//		description: Key of the uploaded file, as returned along with the presigned upload URL.
+//		type: string
+//		required: true
+//	-
+//		name: description
+//		in: formData
+//		description: >-
+//			Image or media description to use as alt-text on the attachment.
+//			This is very useful for users of screenreaders!
+//			May or may not be required, depending on your instance settings.
+//		type: string
+//	-
+//		name: focus
+//		in: formData
+//		description: >-
+//			Focus of the media file.
+//			If present, it should be in the form of two comma-separated floats between -1 and 1.
+//			For example: `-0.5,0.25`.
+//		type: string
+//		default: "0,0"
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'200':
+//			description: The newly-created media attachment.
+//			schema:
+//				"$ref": "#/definitions/attachment"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: upload not found
+//		'422':
+//			description: unprocessable
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadFinalizePOSTHandler(c *gin.Context) {
+	apiVersion, errWithCode := apiutil.ParseAPIVersion(
+		c.Param(apiutil.APIVersionKey),
+		[]string{apiutil.APIv1, apiutil.APIv2}...,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.AttachmentUploadFinalizeRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if err := validateFinalizeUpload(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	apiAttachment, errWithCode := m.processor.Media().FinalizeUpload(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if apiVersion == apiutil.APIv2 {
+		// As for regular uploads, see MediaCreatePOSTHandler.
+		apiAttachment.URL = nil
+	}
+
+	apiutil.JSON(c, http.StatusOK, apiAttachment)
+}
+
+func validateFinalizeUpload(form *apimodel.AttachmentUploadFinalizeRequest) error {
+	if form.Key == "" {
+		return errors.New("no key given")
+	}
+
+	minDescriptionChars := config.GetMediaDescriptionMinChars()
+	maxDescriptionChars := config.GetMediaDescriptionMaxChars()
+
+	if length := len([]rune(form.Description)); length > maxDescriptionChars {
+		return fmt.Errorf("image description length must be between %d and %d characters (inclusive), but provided image description was %d chars", minDescriptionChars, maxDescriptionChars, length)
+	}
+
+	return nil
+}
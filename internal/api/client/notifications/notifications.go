@@ -38,10 +38,14 @@ const (
 	TypesKey = "types[]"
 	// ExcludeTypesKey names an array param specifying notification types to exclude.
 	ExcludeTypesKey = "exclude_types[]"
-	MaxIDKey        = "max_id"
-	LimitKey        = "limit"
-	SinceIDKey      = "since_id"
-	MinIDKey        = "min_id"
+	// AccountIDKey names a param specifying an account to include notifications from.
+	AccountIDKey = "account_id"
+	// ExcludeAccountsKey names an array param specifying accounts to exclude notifications from.
+	ExcludeAccountsKey = "exclude_accounts[]"
+	MaxIDKey           = "max_id"
+	LimitKey           = "limit"
+	SinceIDKey         = "since_id"
+	MinIDKey           = "min_id"
 )
 
 type Module struct {
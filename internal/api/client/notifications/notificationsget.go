@@ -93,6 +93,7 @@ import (
 //				- poll
 //				- status
 //				- admin.sign_up
+//				- filter_match
 //		description: Types of notifications to include. If not provided, all notification types will be included.
 //		in: query
 //		required: false
@@ -110,9 +111,26 @@ import (
 //				- poll
 //				- status
 //				- admin.sign_up
+//				- filter_match
 //		description: Types of notifications to exclude.
 //		in: query
 //		required: false
+//	-
+//		name: account_id
+//		type: string
+//		description: >-
+//			Return only notifications received from the account with the given ID.
+//			Unknown account IDs will match no notifications.
+//		in: query
+//		required: false
+//	-
+//		name: exclude_accounts[]
+//		type: array
+//		items:
+//			type: string
+//		description: IDs of accounts whose notifications should be excluded.
+//		in: query
+//		required: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -173,6 +191,8 @@ func (m *Module) NotificationsGETHandler(c *gin.Context) {
 		limit,
 		c.QueryArray(TypesKey),
 		c.QueryArray(ExcludeTypesKey),
+		c.Query(AccountIDKey),
+		c.QueryArray(ExcludeAccountsKey),
 	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -38,6 +38,7 @@ import (
 //		 "posting:default:visibility": "public",
 //		 "posting:default:sensitive": false,
 //		 "posting:default:language": "en",
+//		 "posting:default:content_warning": "",
 //		 "reading:expand:media": "default",
 //		 "reading:expand:spoilers": false,
 //		 "reading:autoplay:gifs": false
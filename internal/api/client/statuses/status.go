@@ -70,6 +70,12 @@ const (
 
 	// SourcePath is used for fetching source of a post.
 	SourcePath = BasePathWithID + "/source"
+
+	// AudiencePreviewPath is used for previewing the delivery audience of a draft post.
+	AudiencePreviewPath = BasePath + "/audience_preview"
+
+	// ScheduleBatchPath is used for scheduling multiple posts at once.
+	ScheduleBatchPath = BasePath + "/schedule_batch"
 )
 
 type Module struct {
@@ -87,6 +93,8 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, BasePath, m.StatusCreatePOSTHandler)
 	attachHandler(http.MethodGet, BasePathWithID, m.StatusGETHandler)
 	attachHandler(http.MethodDelete, BasePathWithID, m.StatusDELETEHandler)
+	attachHandler(http.MethodPost, AudiencePreviewPath, m.StatusAudiencePOSTHandler)
+	attachHandler(http.MethodPost, ScheduleBatchPath, m.StatusScheduleBatchPOSTHandler)
 
 	// fave stuff
 	attachHandler(http.MethodPost, FavouritePath, m.StatusFavePOSTHandler)
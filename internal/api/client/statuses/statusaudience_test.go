@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusAudienceTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusAudienceTestSuite) previewAudience(accountKey string, form url.Values) string {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(
+		http.MethodPost,
+		"http://localhost:8080/api"+statuses.AudiencePreviewPath,
+		strings.NewReader(form.Encode()),
+	)
+	request.Header.Set("content-type", "application/x-www-form-urlencoded")
+	request.Header.Set("accept", "application/json")
+	ctx, _ := testrig.CreateGinTestContext(recorder, request)
+
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+
+	suite.statusModule.StatusAudiencePOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if result.StatusCode != http.StatusOK {
+		suite.FailNow("", "unexpected http code %d: %s", result.StatusCode, string(b))
+	}
+
+	dst := new(bytes.Buffer)
+	if err := json.Indent(dst, b, "", "  "); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return dst.String()
+}
+
+func (suite *StatusAudienceTestSuite) TestAudiencePreviewMention() {
+	// Local mentions aren't delivered,
+	// so only the remote one should count.
+	resp := suite.previewAudience("local_account_1", url.Values{
+		"status":     {"hello @foss_satan@fossbros-anonymous.io and @1happyturtle"},
+		"visibility": {"public"},
+	})
+
+	suite.Equal(`{
+  "visibility": "public",
+  "federated": true,
+  "accounts": 1,
+  "inboxes": 1,
+  "domains": 1,
+  "excluded": 0,
+  "inboxes_sample": [
+    "http://fossbros-anonymous.io/inbox"
+  ],
+  "domains_sample": [
+    "fossbros-anonymous.io"
+  ]
+}`, resp)
+}
+
+func (suite *StatusAudienceTestSuite) TestAudiencePreviewBlocked() {
+	// local_account_2 blocks foss_satan,
+	// so they should be excluded.
+	resp := suite.previewAudience("local_account_2", url.Values{
+		"status":     {"hello @foss_satan@fossbros-anonymous.io"},
+		"visibility": {"direct"},
+	})
+
+	suite.Equal(`{
+  "visibility": "direct",
+  "federated": true,
+  "accounts": 0,
+  "inboxes": 0,
+  "domains": 0,
+  "excluded": 1,
+  "inboxes_sample": [],
+  "domains_sample": []
+}`, resp)
+}
+
+func (suite *StatusAudienceTestSuite) TestAudiencePreviewLocalOnly() {
+	resp := suite.previewAudience("local_account_1", url.Values{
+		"status":     {"hello @foss_satan@fossbros-anonymous.io"},
+		"visibility": {"unlisted"},
+		"federated":  {"false"},
+	})
+
+	suite.Equal(`{
+  "visibility": "unlisted",
+  "federated": false,
+  "accounts": 0,
+  "inboxes": 0,
+  "domains": 0,
+  "excluded": 0,
+  "inboxes_sample": [],
+  "domains_sample": []
+}`, resp)
+}
+
+func TestStatusAudienceTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusAudienceTestSuite))
+}
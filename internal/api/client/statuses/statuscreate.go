@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
@@ -50,6 +51,16 @@ import (
 //
 //	parameters:
 //	-
+//		name: Idempotency-Key
+//		description: |-
+//			Optional key to prevent duplicate statuses from being created when retrying a request.
+//			Retries with the same key (from the same account) within an hour return the originally
+//			created status, rather than creating a new one. Reusing a key for a request with a
+//			different payload results in 422 Unprocessable Entity, and retrying a request whose
+//			status has since been deleted results in 409 Conflict.
+//		type: string
+//		in: header
+//	-
 //		name: status
 //		x-go-name: Status
 //		description: |-
@@ -111,9 +122,21 @@ import (
 //		type: string
 //		in: formData
 //	-
+//		name: continue_thread
+//		x-go-name: ContinueThread
+//		description: |-
+//			Reply to your own most recent (non-direct) status, continuing a thread, if in_reply_to_id is not set.
+//			If that status can't be replied to, or there isn't one, the status starts a new thread instead.
+//			Defaults to the account's source[continue_thread] setting.
+//		type: boolean
+//		in: formData
+//	-
 //		name: sensitive
 //		x-go-name: Sensitive
-//		description: Status and attached media should be marked as sensitive.
+//		description: |-
+//			Status and attached media should be marked as sensitive.
+//			If not set, statuses with media attached are marked as sensitive if the
+//			account's source[default_media_sensitive] setting is on.
 //		type: boolean
 //		in: formData
 //	-
@@ -134,6 +157,7 @@ import (
 //			- unlisted
 //			- private
 //			- mutuals_only
+//			- circle
 //			- direct
 //		in: formData
 //	-
@@ -156,16 +180,38 @@ import (
 //	-
 //		name: content_type
 //		x-go-name: ContentType
-//		description: Content type to use when parsing this status.
+//		description: Content type to use when parsing this status. Must be one of the content types allowed by the instance, see `configuration.statuses.supported_mime_types` of the instance info.
 //		type: string
 //		enum:
 //			- text/plain
 //			- text/markdown
 //		in: formData
 //	-
+//		name: expires_in
+//		x-go-name: ExpiresIn
+//		description: |-
+//			Number of seconds after which this status should be automatically deleted.
+//			Overrides the account's default post expiry. Use 0 for a status that won't expire.
+//		type: integer
+//		in: formData
+//	-
+//		name: audience_ids[]
+//		x-go-name: AudienceIDs
+//		description: |-
+//			IDs of the followers to address a status with circle visibility to.
+//			Required for, and only allowed with, circle visibility.
+//			The status won't be visible to anyone else, even if they're mentioned.
+//		type: array
+//		items:
+//			type: string
+//		in: formData
+//	-
 //		name: federated
 //		x-go-name: Federated
-//		description: This status will be federated beyond the local timeline(s).
+//		description: |-
+//			This status will be federated beyond the local timeline(s).
+//			Set to false to make the status local-only. Defaults to false if the account's source[local_only] is set.
+//			Direct statuses are always federated, and replies to local-only statuses are always local-only.
 //		in: formData
 //		type: boolean
 //	-
@@ -209,6 +255,10 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'409':
+//			description: Idempotency-Key already used for a status which has since been deleted
+//		'422':
+//			description: Idempotency-Key already used for a different request
 //		'500':
 //			description: internal server error
 func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
@@ -243,6 +293,10 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 	// }
 	// form.Status += "\n\nsent from " + user + "'s iphone\n"
 
+	// Retries of a request with the same
+	// Idempotency-Key won't create duplicates.
+	form.IdempotencyKey = c.GetHeader("Idempotency-Key")
+
 	if err := validateNormalizeCreateStatus(form); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -266,8 +320,12 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 // for disallowed combinations of attachments and
 // overlength inputs.
 //
-// Side effect: normalizes the post's language tag.
+// Side effects: normalizes the post's language tag,
+// and strips tracking params from links in the post
+// (if enabled), before the post's length is checked.
 func validateNormalizeCreateStatus(form *apimodel.AdvancedStatusCreateForm) error {
+	form.Status = text.StripTrackingParams(form.Status)
+
 	hasStatus := form.Status != ""
 	hasMedia := len(form.MediaIDs) != 0
 	hasPoll := form.Poll != nil
@@ -304,6 +362,20 @@ func validateNormalizeCreateStatus(form *apimodel.AdvancedStatusCreateForm) erro
 		form.Language = language
 	}
 
+	if form.ExpiresIn != nil && *form.ExpiresIn != 0 {
+		if err := validate.StatusExpiry("expires_in", *form.ExpiresIn); err != nil {
+			return err
+		}
+	}
+
+	if form.Visibility == apimodel.VisibilityCircle {
+		if len(form.AudienceIDs) == 0 {
+			return errors.New("audience_ids must be provided for circle visibility")
+		}
+	} else if len(form.AudienceIDs) != 0 {
+		return errors.New("audience_ids can only be provided for circle visibility")
+	}
+
 	return nil
 }
 
@@ -503,6 +503,103 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollJSON() {
 	})
 }
 
+func (suite *StatusCreateTestSuite) postNewStatusIdempotent(idempotencyKey string, form url.Values) (*apimodel.Status, int) {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", statuses.BasePath), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Header.Set("Idempotency-Key", idempotencyKey)
+	ctx.Request.Form = form
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	if recorder.Code != http.StatusOK {
+		return nil, recorder.Code
+	}
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusReply := &apimodel.Status{}
+	if err := json.Unmarshal(b, statusReply); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return statusReply, recorder.Code
+}
+
+func (suite *StatusCreateTestSuite) TestPostNewStatusIdempotent() {
+	form := url.Values{
+		"status":     {"posting from a train, hope this doesn't go through twice"},
+		"visibility": {string(apimodel.VisibilityPublic)},
+	}
+
+	// Post the status.
+	status1, code := suite.postNewStatusIdempotent("some-idempotency-key", form)
+	suite.Equal(http.StatusOK, code)
+
+	// Retry the same request with the
+	// same key, we should get the same
+	// status back instead of a new one.
+	status2, code := suite.postNewStatusIdempotent("some-idempotency-key", form)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(status1.ID, status2.ID)
+
+	// Post the same request with a different
+	// key, this should create a new status.
+	status3, code := suite.postNewStatusIdempotent("another-idempotency-key", form)
+	suite.Equal(http.StatusOK, code)
+	suite.NotEqual(status1.ID, status3.ID)
+}
+
+func (suite *StatusCreateTestSuite) TestPostNewStatusIdempotentDifferentPayload() {
+	// Post the status.
+	_, code := suite.postNewStatusIdempotent("some-idempotency-key", url.Values{
+		"status":     {"first version of my status"},
+		"visibility": {string(apimodel.VisibilityPublic)},
+	})
+	suite.Equal(http.StatusOK, code)
+
+	// Reuse the key with a different payload,
+	// this should be rejected as a client bug.
+	_, code = suite.postNewStatusIdempotent("some-idempotency-key", url.Values{
+		"status":     {"second version of my status"},
+		"visibility": {string(apimodel.VisibilityPublic)},
+	})
+	suite.Equal(http.StatusUnprocessableEntity, code)
+}
+
+func (suite *StatusCreateTestSuite) TestPostNewStatusIdempotentDeleted() {
+	form := url.Values{
+		"status":     {"oops, didn't mean to post this"},
+		"visibility": {string(apimodel.VisibilityPublic)},
+	}
+
+	// Post the status.
+	status, code := suite.postNewStatusIdempotent("some-idempotency-key", form)
+	suite.Equal(http.StatusOK, code)
+
+	// Delete it again.
+	if err := suite.db.DeleteStatusByID(context.Background(), status.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// A late retry of the request shouldn't
+	// bring the deleted status back again.
+	_, code = suite.postNewStatusIdempotent("some-idempotency-key", form)
+	suite.Equal(http.StatusConflict, code)
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
@@ -0,0 +1,176 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// scheduleBatchMax is the maximum number of
+// statuses that can be scheduled in one request.
+const scheduleBatchMax = 50
+
+// StatusScheduleBatchPOSTHandler swagger:operation POST /api/v1/statuses/schedule_batch statusScheduleBatch
+//
+// Schedule multiple statuses to be published later.
+//
+// The request body must be JSON, of the form `{"statuses": [ ... ]}`, where each
+// entry takes the same parameters as status creation, and must have `scheduled_at`
+// set to an ISO 8601 datetime. Scheduled times must be in the future, and within the
+// instance's configured scheduling horizon. At most 50 statuses can be given at once.
+//
+// Each status is validated and scheduled independently, so a problem with one status
+// doesn't prevent the others from being scheduled. The response contains one result
+// per status, in the same order as the request, with either the scheduled status or
+// the reason it couldn't be scheduled.
+//
+//	---
+//	tags:
+//	- statuses
+//
+//	consumes:
+//	- application/json
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:statuses
+//
+//	responses:
+//		'200':
+//			description: "Results of scheduling each status."
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/scheduledStatusBatchResult"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) StatusScheduleBatchPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.ScheduledStatusBatchRequest{}
+	if err := c.ShouldBindJSON(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	switch l := len(form.Statuses); {
+	case l == 0:
+		const text = "no statuses provided"
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(errors.New(text), text), m.processor.InstanceGetV1)
+		return
+
+	case l > scheduleBatchMax:
+		text := fmt.Sprintf("too many statuses provided, %d provided but limit is %d", l, scheduleBatchMax)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(errors.New(text), text), m.processor.InstanceGetV1)
+		return
+	}
+
+	results := make([]apimodel.ScheduledStatusBatchResult, len(form.Statuses))
+	for i, statusForm := range form.Statuses {
+		results[i].Index = i
+
+		scheduled, errWithCode := m.scheduleStatus(c, authed, statusForm)
+		if errWithCode != nil {
+			results[i].Error = helpText(errWithCode)
+			continue
+		}
+
+		results[i].ScheduledStatus = scheduled
+	}
+
+	apiutil.JSON(c, http.StatusOK, results)
+}
+
+// helpText returns the safe text of the given error
+// without its leading status text, eg., "Bad Request: ",
+// as results carry no status code for this to describe.
+func helpText(errWithCode gtserror.WithCode) string {
+	prefix := http.StatusText(errWithCode.Code()) + ": "
+	return strings.TrimPrefix(errWithCode.Safe(), prefix)
+}
+
+// scheduleStatus validates and schedules
+// one status of a batch scheduling request.
+func (m *Module) scheduleStatus(
+	c *gin.Context,
+	authed *oauth.Auth,
+	form *apimodel.AdvancedStatusCreateForm,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	if form == nil {
+		const text = "status must be an object"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if form.ScheduledAt == "" {
+		const text = "scheduled_at must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	scheduledAt, err := time.Parse(time.RFC3339, form.ScheduledAt)
+	if err != nil {
+		text := fmt.Sprintf("could not parse scheduled_at %s as ISO 8601 datetime", form.ScheduledAt)
+		return nil, gtserror.NewErrorBadRequest(err, text)
+	}
+
+	if err := validateNormalizeCreateStatus(form); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return m.processor.Status().ScheduledCreate(
+		c.Request.Context(),
+		authed.Account,
+		authed.Application,
+		form,
+		scheduledAt,
+	)
+}
@@ -0,0 +1,129 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusScheduleBatchTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusScheduleBatchTestSuite) scheduleBatch(body string, expectedCode int) []byte {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(
+		http.MethodPost,
+		"http://localhost:8080/api"+statuses.ScheduleBatchPath,
+		strings.NewReader(body),
+	)
+	request.Header.Set("content-type", "application/json")
+	request.Header.Set("accept", "application/json")
+	ctx, _ := testrig.CreateGinTestContext(recorder, request)
+
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+
+	suite.statusModule.StatusScheduleBatchPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if result.StatusCode != expectedCode {
+		suite.FailNow("", "unexpected http code %d: %s", result.StatusCode, string(b))
+	}
+
+	return b
+}
+
+func (suite *StatusScheduleBatchTestSuite) TestScheduleBatchPartial() {
+	future := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	past := time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+	beyond := time.Now().Add(365 * 24 * time.Hour).UTC().Format(time.RFC3339)
+
+	b := suite.scheduleBatch(`{"statuses":[
+		{"status":"hello tomorrow","visibility":"unlisted","scheduled_at":"`+future+`"},
+		{"status":"hello yesterday","scheduled_at":"`+past+`"},
+		{"status":"hello next year","scheduled_at":"`+beyond+`"},
+		{"status":"hello whenever"}
+	]}`, http.StatusOK)
+
+	results := []apimodel.ScheduledStatusBatchResult{}
+	if err := json.Unmarshal(b, &results); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(results, 4)
+
+	// First status should be scheduled.
+	suite.Equal(0, results[0].Index)
+	suite.Empty(results[0].Error)
+	suite.NotNil(results[0].ScheduledStatus)
+	suite.Equal("hello tomorrow", results[0].ScheduledStatus.Params.Text)
+	suite.Equal("unlisted", results[0].ScheduledStatus.Params.Visibility)
+	suite.Equal(suite.testApplications["application_1"].ID, results[0].ScheduledStatus.Params.ApplicationID)
+
+	// The rest should have failed.
+	suite.Nil(results[1].ScheduledStatus)
+	suite.Equal("scheduled_at must be in the future", results[1].Error)
+	suite.Nil(results[2].ScheduledStatus)
+	suite.Equal("scheduled_at must be no more than 2160h0m0s in the future", results[2].Error)
+	suite.Nil(results[3].ScheduledStatus)
+	suite.Equal("scheduled_at must be set", results[3].Error)
+
+	// Only the first should be stored.
+	scheduled, err := suite.db.GetAllScheduledStatuses(context.Background())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(scheduled, 1)
+	suite.Equal(results[0].ScheduledStatus.ID, scheduled[0].ID)
+}
+
+func (suite *StatusScheduleBatchTestSuite) TestScheduleBatchTooMany() {
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	item := `{"status":"spam","scheduled_at":"` + future + `"}`
+	items := strings.Repeat(item+",", 50) + item
+
+	b := suite.scheduleBatch(`{"statuses":[`+items+`]}`, http.StatusBadRequest)
+	suite.Equal(`{"error":"Bad Request: too many statuses provided, 51 provided but limit is 50"}`, string(b))
+}
+
+func TestStatusScheduleBatchTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusScheduleBatchTestSuite))
+}
@@ -19,6 +19,8 @@ package streaming
 
 import (
 	"context"
+	"errors"
+	"net"
 	"slices"
 	"time"
 
@@ -44,9 +46,13 @@ import (
 //
 // As long as the connection is open, various message types will be streamed into it.
 //
-// GoToSocial will ping the connection every 30 seconds to check whether the client is still receiving.
+// GoToSocial will ping the connection when it's idle to check whether the client is still receiving, by default every 30 seconds (this is configurable by the instance admin).
 //
-// If the ping fails, or something else goes wrong during transmission, then the connection will be dropped, and the client will be expected to start it again.
+// If the ping fails, the client doesn't respond to pings within twice the ping interval, or something else goes wrong during transmission, then the connection will be dropped, and the client will be expected to start it again.
+//
+// If the client doesn't read messages as fast as they're sent, eg., because it's on a very slow network, then depending on instance configuration either the connection is closed with code `1008`, or the oldest queued status updates and notifications are dropped to make room for new messages.
+//
+// Each message has an `id`. When starting it again, the client can pass the `id` of the last message it received as `last_event_id` (or in the `Last-Event-ID` header), to first receive any messages it missed in the meantime. If these are no longer available (messages are kept for a few minutes), a single `reset` event is sent instead, and the client should refetch whatever it's displaying.
 //
 //	---
 //	tags:
@@ -80,6 +86,7 @@ import (
 //			`hashtag:local`: receive local updates for a given hashtag.
 //			`list`: receive updates for a certain list of accounts.
 //			`direct`: receive updates for direct messages.
+//			`conversation`: receive new replies and edits within the thread of a certain status.
 //		in: query
 //		required: true
 //	-
@@ -96,6 +103,82 @@ import (
 //			Name of the tag to subscribe to.
 //			Only used if stream type is 'hashtag' or 'hashtag:local'.
 //		in: query
+//	-
+//		name: status
+//		type: string
+//		description: |-
+//			ID of a status in the thread to subscribe to.
+//			Only used if stream type is 'conversation'.
+//		in: query
+//	-
+//		name: filter_ids[]
+//		type: array
+//		items:
+//			type: string
+//		description: |-
+//			IDs of filters owned by the requesting account to apply to list streams of this connection.
+//			Statuses matching any of these filters will not be sent on list streams at all, regardless
+//			of the context and action configured for the filters. The filters are updated if changed.
+//		in: query
+//	-
+//		name: last_event_id
+//		type: string
+//		description: |-
+//			ID of the last message received on a previous connection, to resume from.
+//			Can also be provided in the `Last-Event-ID` header.
+//		in: query
+//	-
+//		name: notification_events
+//		type: string
+//		description: |-
+//			How to send notification events on this connection.
+//
+//			`combined`: send all notifications as `notification` events.
+//			`granular`: append the notification type to the event, eg., `notification.mention`,
+//			`notification.follow`, `notification.favourite`, so clients can handle them selectively.
+//		in: query
+//		enum:
+//		- combined
+//		- granular
+//		default: combined
+//	-
+//		name: delete_events
+//		type: string
+//		description: |-
+//			How to send delete events on this connection.
+//
+//			`plain`: the payload of `delete` events is just the ID of the deleted status.
+//			`detailed`: the payload of `delete` events is a JSON object with the `id` of the deleted status,
+//			and a `reason` for the deletion, which is one of `author` (deleted by its author), `moderation`
+//			(removed by moderation), `block` (removed because of a block) or `filtered` (filtered out with
+//			`retroactive_filters`). The `reason` field is omitted if the reason is not known.
+//		in: query
+//		enum:
+//		- plain
+//		- detailed
+//		default: plain
+//	-
+//		name: retroactive_filters
+//		type: boolean
+//		description: |-
+//			Re-apply `filter_ids[]` to statuses recently sent on this connection when the account's filters change.
+//			For each status that's now filtered out, a `delete` event is sent, so the client can remove it. To avoid
+//			a flood of events on busy connections, only statuses from the last few minutes are checked, and at most
+//			20 `delete` events are sent per filter change.
+//		in: query
+//		default: false
+//	-
+//		name: notification_batch_window
+//		type: integer
+//		description: |-
+//			Window in milliseconds within which to batch favourite and boost notifications of the same status.
+//			The first such notification is held back for the window, and any more of the same type about the
+//			same status received in the meantime are sent together with it as one `notification_group` event,
+//			instead of one `notification` event each. 0 turns batching off. Values over 10000 are treated as 10000.
+//		in: query
+//		default: 0
+//		minimum: 0
+//		maximum: 10000
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -118,20 +201,29 @@ import (
 //							- hashtag:local
 //							- list
 //							- direct
+//							- conversation
+//					id:
+//						description: ID of the message, to resume from with `last_event_id`.
+//						type: string
 //					event:
 //						description: |-
 //							The type of event being received.
 //
 //							`update`: a new status has been received.
 //							`notification`: a new notification has been received.
-//							`delete`: a status has been deleted.
+//							`notification.{type}`: a new notification of the given type has been received, if `notification_events` is `granular`.
+//							`notification_group`: several notifications of the same type about the same status have been received, if `notification_batch_window` is set.
+//							`delete`: a status has been deleted, or filtered out with `retroactive_filters`.
 //							`filters_changed`: filters (including keywords and statuses) have changed.
+//							`reset`: the stream could not be resumed from `last_event_id`.
 //						type: string
 //						enum:
 //						- update
 //						- notification
+//						- notification_group
 //						- delete
 //						- filters_changed
+//						- reset
 //					payload:
 //						description: |-
 //							The payload of the streamed message.
@@ -140,15 +232,19 @@ import (
 //							If present, it should be parsed as a string.
 //
 //							If `event` = `update`, then the payload will be a JSON string of a status.
-//							If `event` = `notification`, then the payload will be a JSON string of a notification.
-//							If `event` = `delete`, then the payload will be a status ID.
+//							If `event` = `notification` (or `notification.{type}`), then the payload will be a JSON string of a notification.
+//							If `event` = `notification_group`, then the payload will be a JSON string of a notification group.
+//							If `event` = `delete`, then the payload will be a status ID, or a JSON string of an object with `id` and `reason`, if `delete_events` is `detailed`.
 //							If `event` = `filters_changed`, then there is no payload.
+//							If `event` = `reset`, then there is no payload.
 //						type: string
 //						example: "{\"id\":\"01FC3TZ5CFG6H65GCKCJRKA669\",\"created_at\":\"2021-08-02T16:25:52Z\",\"sensitive\":false,\"spoiler_text\":\"\",\"visibility\":\"public\",\"language\":\"en\",\"uri\":\"https://gts.superseriousbusiness.org/users/dumpsterqueer/statuses/01FC3TZ5CFG6H65GCKCJRKA669\",\"url\":\"https://gts.superseriousbusiness.org/@dumpsterqueer/statuses/01FC3TZ5CFG6H65GCKCJRKA669\",\"replies_count\":0,\"reblogs_count\":0,\"favourites_count\":0,\"favourited\":false,\"reblogged\":false,\"muted\":false,\"bookmarked\":fals…//gts.superseriousbusiness.org/fileserver/01JNN207W98SGG3CBJ76R5MVDN/header/original/019036W043D8FXPJKSKCX7G965.png\",\"header_static\":\"https://gts.superseriousbusiness.org/fileserver/01JNN207W98SGG3CBJ76R5MVDN/header/small/019036W043D8FXPJKSKCX7G965.png\",\"followers_count\":33,\"following_count\":28,\"statuses_count\":126,\"last_status_at\":\"2021-08-02T16:25:52Z\",\"emojis\":[],\"fields\":[]},\"media_attachments\":[],\"mentions\":[],\"tags\":[],\"emojis\":[],\"card\":null,\"poll\":null,\"text\":\"a\"}"
 //		'401':
 //			description: unauthorized
 //		'400':
 //			description: bad request
+//		'404':
+//			description: filter not found
 func (m *Module) StreamGETHandler(c *gin.Context) {
 	var (
 		account     *gtsmodel.Account
@@ -204,20 +300,83 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 		streamType += ":" + list
 	} else if tag := c.Query(StreamTagKey); tag != "" {
 		streamType += ":" + tag
+	} else if status := c.Query(StreamStatusKey); status != "" {
+		streamType += ":" + status
+	}
+
+	// Check how notification events should be sent.
+	var granular bool
+	switch events := c.Query(NotificationEventsKey); events {
+	case "", "combined":
+		// Default.
+	case "granular":
+		granular = true
+	default:
+		const text = "notification_events must be one of: combined, granular"
+		errWithCode := gtserror.NewErrorBadRequest(errors.New(text), text)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Check how delete events should be sent.
+	var detailed bool
+	switch events := c.Query(DeleteEventsKey); events {
+	case "", "plain":
+		// Default.
+	case "detailed":
+		detailed = true
+	default:
+		const text = "delete_events must be one of: plain, detailed"
+		errWithCode := gtserror.NewErrorBadRequest(errors.New(text), text)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Check whether filters should be re-applied
+	// to already-sent statuses when they change.
+	retroactive, errWithCode := apiutil.ParseRetroactiveFilters(c.Query(apiutil.RetroactiveFiltersKey), false)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Check whether (and for how long) notifications
+	// of the same status should be batched together.
+	batchWindow, errWithCode := apiutil.ParseNotificationBatchWindow(
+		c.Query(apiutil.NotificationBatchWindowKey),
+		0,
+		int(streampkg.MaxNotificationBatchWindow/time.Millisecond),
+		0,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Get the ID of the last message received, if resuming.
+	lastEventID := c.Query(LastEventIDQueryKey)
+	if lastEventID == "" {
+		lastEventID = c.GetHeader(LastEventIDHeader)
 	}
 
 	// Open a stream with the processor; this lets processor
 	// functions pass messages into a channel, which we can
 	// then read from and put into a websockets connection.
-	stream, errWithCode := m.processor.Stream().Open(
-		c.Request.Context(), // this ctx is only used for logging
+	stream, errWithCode := m.processor.Stream().Resume(
+		c.Request.Context(), // this ctx is only used for logging / resolving filters
 		account,
 		streamType,
+		lastEventID,
+		c.QueryArray(StreamFilterKey)...,
 	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
+	stream.SetGranularNotifications(granular)
+	stream.SetDetailedDeletes(detailed)
+	stream.SetRetroactiveFilter(retroactive)
+	stream.SetNotificationBatchWindow(time.Duration(batchWindow) * time.Millisecond)
 
 	l := log.
 		WithContext(c.Request.Context()).
@@ -253,6 +412,16 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *streampkg.Stream) {
 	l.Info("opened websocket connection")
 
+	// Clients that don't respond to our pings
+	// (or send anything else) within twice the
+	// ping interval are considered gone. Each
+	// pong received extends the read deadline.
+	timeout := 2 * m.dTicker
+	_ = wsConn.SetReadDeadline(time.Now().Add(timeout))
+	wsConn.SetPongHandler(func(string) error {
+		return wsConn.SetReadDeadline(time.Now().Add(timeout))
+	})
+
 	// Create new async context with cancel.
 	ctx, cncl := context.WithCancel(context.Background())
 
@@ -278,6 +447,21 @@ func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *stre
 	// straightaway.
 	stream.Close()
 
+	if stream.TooSlow() {
+		// Let the client know why we're
+		// hanging up, so it can reconnect
+		// and resume from its last event.
+		l.Info("client not reading websocket messages fast enough")
+		msg := websocket.FormatCloseMessage(
+			websocket.ClosePolicyViolation,
+			"client not reading fast enough",
+		)
+		deadline := time.Now().Add(m.dTicker)
+		if err := wsConn.WriteControl(websocket.CloseMessage, msg, deadline); err != nil {
+			l.Debugf("error writing websocket close: %v", err)
+		}
+	}
+
 	// Tidy up underlying websocket connection.
 	if err := wsConn.Close(); err != nil {
 		l.Errorf("error closing websocket connection: %v", err)
@@ -315,7 +499,12 @@ func (m *Module) readFromWSConn(
 				websocket.CloseGoingAway,
 				websocket.CloseNoStatusReceived,
 			}...) {
-				l.Errorf("error during websocket read: %v", err)
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					l.Info("client did not respond to websocket ping in time")
+				} else {
+					l.Errorf("error during websocket read: %v", err)
+				}
 			}
 
 			// The connection is gone; no
@@ -323,6 +512,10 @@ func (m *Module) readFromWSConn(
 			break
 		}
 
+		// Client is clearly still
+		// there, extend read deadline.
+		_ = wsConn.SetReadDeadline(time.Now().Add(2 * m.dTicker))
+
 		// Messages *from* the WS connection are infrequent
 		// and usually interesting, so log this at info.
 		l.Infof("received websocket message: %+v", msg)
@@ -385,11 +578,16 @@ func (m *Module) writeToWSConn(
 			l.Trace("writing websocket ping")
 
 			// Wrapped context time-out, send a keep-alive "ping".
-			if err := wsConn.WriteControl(websocket.PingMessage, nil, time.Time{}); err != nil {
+			deadline := time.Now().Add(ping)
+			if err := wsConn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
 				l.Debugf("error writing websocket ping: %v", err)
-				break
+				return
 			}
 
+			// Nothing else
+			// to write yet.
+			continue
+
 		case !ok:
 			// Stream was
 			// closed.
@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package streaming
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	streampkg "github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+// serveWS starts a test server which hands websocket connections
+// to the given module, and returns a client connection to it.
+func serveWS(t *testing.T, m *Module) *websocket.Conn {
+	var streams streampkg.Streams
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wsConn, err := m.wsUpgrade.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("error upgrading: %v", err)
+			return
+		}
+		l := log.WithField("test", t.Name())
+		go m.handleWSConn(&l, wsConn, streams.Open("some_account", streampkg.TimelineHome))
+	}))
+	t.Cleanup(srv.Close)
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+	conn, rsp, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("error dialing: %v", err)
+	}
+	rsp.Body.Close()
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestPingIdleConnection(t *testing.T) {
+	m := New(nil, 50*time.Millisecond, 4096)
+	conn := serveWS(t, m)
+
+	var pings atomic.Int32
+	conn.SetPingHandler(func(data string) error {
+		pings.Add(1)
+
+		// Reply with pong, as the default handler would.
+		deadline := time.Now().Add(time.Second)
+		return conn.WriteControl(websocket.PongMessage, []byte(data), deadline)
+	})
+
+	// Read from the connection for several ping
+	// intervals; any data message is unexpected.
+	_ = conn.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
+	_, msg, err := conn.ReadMessage()
+	if err == nil {
+		t.Fatalf("expected no messages on idle connection, got %q", msg)
+	}
+
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("expected read timeout, connection was closed instead: %v", err)
+	}
+
+	if n := pings.Load(); n < 2 {
+		t.Fatalf("expected at least 2 pings, got %d", n)
+	}
+}
+
+func TestPingUnresponsiveClient(t *testing.T) {
+	m := New(nil, 50*time.Millisecond, 4096)
+	conn := serveWS(t, m)
+
+	// Swallow pings without replying.
+	conn.SetPingHandler(func(string) error { return nil })
+
+	// The server should give up on us after twice
+	// the ping interval and close the connection,
+	// well before our own read deadline is hit.
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err := conn.ReadMessage()
+	if err == nil {
+		t.Fatal("expected error reading from closed connection")
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		t.Fatalf("expected connection to be closed by server: %v", err)
+	}
+}
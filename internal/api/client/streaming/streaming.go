@@ -27,12 +27,18 @@ import (
 )
 
 const (
-	BasePath            = "/v1/streaming"          // path for the streaming api, minus the 'api' prefix
-	StreamQueryKey      = "stream"                 // type of stream being requested
-	StreamListKey       = "list"                   // id of list being requested
-	StreamTagKey        = "tag"                    // name of tag being requested
-	AccessTokenQueryKey = "access_token"           // oauth access token
-	AccessTokenHeader   = "Sec-Websocket-Protocol" //nolint:gosec
+	BasePath              = "/v1/streaming"          // path for the streaming api, minus the 'api' prefix
+	StreamQueryKey        = "stream"                 // type of stream being requested
+	StreamListKey         = "list"                   // id of list being requested
+	StreamTagKey          = "tag"                    // name of tag being requested
+	StreamStatusKey       = "status"                 // id of status whose conversation is being requested
+	StreamFilterKey       = "filter_ids[]"           // ids of filters to apply to list streams
+	LastEventIDQueryKey   = "last_event_id"          // id of last message received, to resume from
+	LastEventIDHeader     = "Last-Event-ID"          // id of last message received, to resume from
+	NotificationEventsKey = "notification_events"    // whether to send granular or combined notification events
+	DeleteEventsKey       = "delete_events"          // whether to send plain or detailed delete events
+	AccessTokenQueryKey   = "access_token"           // oauth access token
+	AccessTokenHeader     = "Sec-Websocket-Protocol" //nolint:gosec
 )
 
 type Module struct {
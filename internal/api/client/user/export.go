@@ -0,0 +1,215 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ExportPOSTHandler swagger:operation POST /api/v1/user/export exportCreate
+//
+// Start an export of your profile, statuses, and media, as a downloadable zip archive.
+//
+// The archive is built in the background; poll `GET /api/v1/user/export` for progress,
+// and once its state is `done`, download it from `GET /api/v1/user/export/archive`.
+//
+// The archive contains `account.json` (your profile), `statuses/[page].json`
+// (your statuses, newest first, in pages), and `media/[id].[ext]` (your avatar,
+// header, and status media, named by media attachment ID).
+//
+// Starting a new export replaces the previous one. An account can make
+// one successful export per 24 hours, and archives are kept for 7 days.
+//
+//	---
+//	tags:
+//	- user
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'202':
+//			description: Export started.
+//			schema:
+//				"$ref": "#/definitions/accountExport"
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: conflict (an export is already in progress)
+//		'422':
+//			description: unprocessable (an export was already made in the last 24 hours)
+//		'500':
+//			description: internal server error
+func (m *Module) ExportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	export, errWithCode := m.processor.User().ExportCreate(c.Request.Context(), authed.Account)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusAccepted, export)
+}
+
+// ExportGETHandler swagger:operation GET /api/v1/user/export exportGet
+//
+// Get the progress of your most recent export.
+//
+//	---
+//	tags:
+//	- user
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: The most recent export.
+//			schema:
+//				"$ref": "#/definitions/accountExport"
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found (no export has been started)
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) ExportGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	export, errWithCode := m.processor.User().ExportGet(c.Request.Context(), authed.Account)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, export)
+}
+
+// ExportArchiveGETHandler swagger:operation GET /api/v1/user/export/archive exportArchiveGet
+//
+// Download the archive of your most recent export, once it's done.
+//
+// Supports range requests, so that interrupted downloads of large archives
+// can be resumed. If media is stored in S3, this may redirect to the archive.
+//
+//	---
+//	tags:
+//	- user
+//
+//	produces:
+//	- application/zip
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: The export archive.
+//		'206':
+//			description: Partial content of the export archive.
+//		'302':
+//			description: Redirect to the export archive in storage.
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found (no finished export, or archive expired)
+//		'416':
+//			description: range not satisfiable
+//		'500':
+//			description: internal server error
+func (m *Module) ExportArchiveGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	content, errWithCode := m.processor.User().ExportArchive(c.Request.Context(), authed.Account)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if content.URL != nil {
+		// Archive is in non-proxied
+		// S3, so redirect straight to it.
+		c.Header("Cache-Control", "private, no-store")
+		c.Redirect(http.StatusFound, content.URL.String())
+		return
+	}
+
+	defer func() {
+		// Close content when we're done, catch errors.
+		if err := content.Content.Close(); err != nil {
+			log.Errorf(c.Request.Context(), "error closing export archive: %v", err)
+		}
+	}()
+
+	c.Header("Content-Disposition", `attachment; filename="export.zip"`)
+	c.Header("Cache-Control", "private, no-store")
+
+	if rs, ok := content.Content.(io.ReadSeeker); ok {
+		// Serve with support for range
+		// requests, to allow resuming.
+		c.Header("Content-Type", content.ContentType)
+		http.ServeContent(c.Writer, c.Request, "", content.ContentUpdated, rs)
+		return
+	}
+
+	c.Header("Last-Modified", content.ContentUpdated.UTC().Format(http.TimeFormat))
+	c.DataFromReader(http.StatusOK, content.ContentLength, content.ContentType, content.Content, nil)
+}
@@ -31,6 +31,21 @@ const (
 	PasswordChangePath = BasePath + "/password_change"
 	// EmailChangePath is the path for POSTing an email address change request.
 	EmailChangePath = BasePath + "/email_change"
+	// ExportPath is the path for starting and checking on account exports.
+	ExportPath = BasePath + "/export"
+	// ExportArchivePath is the path for downloading account export archives.
+	ExportArchivePath = ExportPath + "/archive"
+	// ConsentsPath is the path for listing remembered OAuth consents.
+	ConsentsPath = BasePath + "/consents"
+	// ConsentPath is the path for revoking one remembered OAuth consent.
+	ConsentPath = ConsentsPath + "/:" + IDKey
+	// SessionsPath is the path for listing sessions.
+	SessionsPath = BasePath + "/sessions"
+	// SessionPath is the path for revoking one session.
+	SessionPath = SessionsPath + "/:" + IDKey
+
+	// IDKey is the key for IDs in request paths.
+	IDKey = "id"
 )
 
 type Module struct {
@@ -47,4 +62,11 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, BasePath, m.UserGETHandler)
 	attachHandler(http.MethodPost, PasswordChangePath, m.PasswordChangePOSTHandler)
 	attachHandler(http.MethodPost, EmailChangePath, m.EmailChangePOSTHandler)
+	attachHandler(http.MethodPost, ExportPath, m.ExportPOSTHandler)
+	attachHandler(http.MethodGet, ExportPath, m.ExportGETHandler)
+	attachHandler(http.MethodGet, ExportArchivePath, m.ExportArchiveGETHandler)
+	attachHandler(http.MethodGet, ConsentsPath, m.ConsentsGETHandler)
+	attachHandler(http.MethodDelete, ConsentPath, m.ConsentDELETEHandler)
+	attachHandler(http.MethodGet, SessionsPath, m.SessionsGETHandler)
+	attachHandler(http.MethodDelete, SessionPath, m.SessionDELETEHandler)
 }
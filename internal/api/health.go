@@ -44,8 +44,8 @@ func (mt *Health) Route(r *router.Router, m ...gin.HandlerFunc) {
 	mt.health.Route(healthGroup.Handle)
 }
 
-func NewHealth(readyF func(context.Context) error) *Health {
+func NewHealth(readyFs ...func(context.Context) error) *Health {
 	return &Health{
-		health: health.New(readyF),
+		health: health.New(readyFs...),
 	}
 }
@@ -30,12 +30,14 @@ const (
 )
 
 type Module struct {
-	readyF func(context.Context) error
+	readyFs []func(context.Context) error
 }
 
-func New(readyF func(context.Context) error) *Module {
+// New returns a new health module, which is
+// ready when all of the given funcs return nil.
+func New(readyFs ...func(context.Context) error) *Module {
 	return &Module{
-		readyF: readyF,
+		readyFs: readyFs,
 	}
 }
 
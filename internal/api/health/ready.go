@@ -26,20 +26,23 @@ import (
 )
 
 func (m *Module) ready(c *gin.Context) {
-	if err := m.readyF(c.Request.Context()); err != nil {
-		// Set error on the gin context so
-		// it's logged by the logging middleware.
-		errWithCode := gtserror.NewErrorInternalError(err)
-		c.Error(errWithCode) //nolint:errcheck
-		c.Status(http.StatusInternalServerError)
-	} else {
-		c.Status(http.StatusOK)
+	for _, readyF := range m.readyFs {
+		if err := readyF(c.Request.Context()); err != nil {
+			// Set error on the gin context so
+			// it's logged by the logging middleware.
+			errWithCode := gtserror.NewErrorInternalError(err)
+			c.Error(errWithCode) //nolint:errcheck
+			c.Status(http.StatusInternalServerError)
+			return
+		}
 	}
+
+	c.Status(http.StatusOK)
 }
 
 // ReadyGETRequest swagger:operation GET /readyz readyGet
 //
-// Returns code 200 with no body if GoToSocial is "ready", ie., able to connect to the database backend and do a simple SELECT.
+// Returns code 200 with no body if GoToSocial is "ready", ie., able to connect to the database backend and do a simple SELECT, and to reach the storage backend (and, for S3, find its bucket).
 //
 // If GtS is not ready, 500 Internal Error will be returned, and an error will be logged (but not returned to the caller, to avoid leaking internals).
 //
@@ -58,7 +61,7 @@ func (m *Module) ReadyGETRequest(c *gin.Context) {
 
 // ReadyHEADRequest swagger:operation HEAD /readyz readyHead
 //
-// Returns code 200 with no body if GoToSocial is "ready", ie., able to connect to the database backend and do a simple SELECT.
+// Returns code 200 with no body if GoToSocial is "ready", ie., able to connect to the database backend and do a simple SELECT, and to reach the storage backend (and, for S3, find its bucket).
 //
 // If GtS is not ready, 500 Internal Error will be returned, and an error will be logged (but not returned to the caller, to avoid leaking internals).
 //
@@ -52,6 +52,9 @@ type Account struct {
 	CreatedAt string `json:"created_at"`
 	// Bio/description of this account.
 	Note string `json:"note"`
+	// Pronouns set by this account, as plain text.
+	// example: they/them
+	Pronouns string `json:"pronouns,omitempty"`
 	// Web location of the account's profile page.
 	// example: https://example.org/@some_user
 	URL string `json:"url"`
@@ -98,6 +101,13 @@ type Account struct {
 	// Account has opted to hide their followers/following collections.
 	// Key/value omitted if false.
 	HideCollections bool `json:"hide_collections,omitempty"`
+	// Account has opted to hide their web profile from
+	// visitors who aren't logged in as one of their followers.
+	// Key/value omitted if false.
+	HideWebProfile bool `json:"hide_web_profile,omitempty"`
+	// Message set by the account to let others know they're away.
+	// Key/value omitted if the account isn't currently away.
+	AwayMessage string `json:"away_message,omitempty"`
 	// Role of the account on this instance.
 	// Key/value omitted for remote accounts.
 	Role *AccountRole `json:"role,omitempty"`
@@ -166,6 +176,9 @@ type UpdateCredentialsRequest struct {
 	DisplayName *string `form:"display_name" json:"display_name"`
 	// Bio/description of this account.
 	Note *string `form:"note" json:"note"`
+	// Pronouns to show on this account's profile, as plain text.
+	// Use empty string to unset.
+	Pronouns *string `form:"pronouns" json:"pronouns"`
 	// Avatar image encoded using multipart/form-data.
 	Avatar *multipart.FileHeader `form:"avatar" json:"-"`
 	// Header image encoded using multipart/form-data
@@ -188,6 +201,9 @@ type UpdateCredentialsRequest struct {
 	EnableRSS *bool `form:"enable_rss" json:"enable_rss"`
 	// Hide this account's following/followers collections.
 	HideCollections *bool `form:"hide_collections" json:"hide_collections"`
+	// Hide this account's web profile, web statuses, and RSS
+	// feed from visitors who aren't logged in as a follower.
+	HideWebProfile *bool `form:"hide_web_profile" json:"hide_web_profile"`
 }
 
 // UpdateSource is to be used specifically in an UpdateCredentialsRequest.
@@ -196,12 +212,62 @@ type UpdateCredentialsRequest struct {
 type UpdateSource struct {
 	// Default post privacy for authored statuses.
 	Privacy *string `form:"privacy" json:"privacy"`
+	// Default post privacy for replies, as that of the replied-to
+	// post but never wider than this. Empty string to unset.
+	ReplyPrivacy *string `form:"reply_privacy" json:"reply_privacy"`
 	// Mark authored statuses as sensitive by default.
 	Sensitive *bool `form:"sensitive" json:"sensitive"`
+	// Mark authored statuses with media attached as sensitive by default.
+	DefaultMediaSensitive *bool `form:"default_media_sensitive" json:"default_media_sensitive"`
 	// Default language to use for authored statuses. (ISO 6391)
 	Language *string `form:"language" json:"language"`
 	// Default format for authored statuses (text/plain or text/markdown).
 	StatusContentType *string `form:"status_content_type" json:"status_content_type"`
+	// Format of the account's note / bio (text/plain or text/markdown).
+	// Empty string to unset, and use StatusContentType.
+	BioContentType *string `form:"bio_content_type" json:"bio_content_type"`
+	// Default content warning / spoiler text for authored statuses.
+	// Use empty string to unset.
+	DefaultContentWarning *string `form:"default_content_warning" json:"default_content_warning"`
+	// Number of seconds after which authored statuses are
+	// automatically deleted. Use 0 to unset.
+	DefaultPostExpiry *int `form:"default_post_expiry" json:"default_post_expiry"`
+	// If the account is locked, automatically approve follow
+	// requests from accounts known to this instance for longer
+	// than this number of seconds. Use 0 to review all requests.
+	AutoApproveFollowsAfter *int `form:"auto_approve_follows_after" json:"auto_approve_follows_after"`
+	// Review follow requests from local accounts as well, rather than
+	// automatically approving them, if auto_approve_follows_after is set.
+	ReviewLocalFollows *bool `form:"review_local_follows" json:"review_local_follows"`
+	// Turn away mode on or off.
+	AwayEnabled *bool `form:"away_enabled" json:"away_enabled"`
+	// Message to show (and send as an auto-reply, if enabled) while away.
+	AwayMessage *string `form:"away_message" json:"away_message"`
+	// ISO 8601 timestamp from which away mode applies.
+	// Use empty string to start immediately.
+	AwayStartsAt *string `form:"away_starts_at" json:"away_starts_at"`
+	// ISO 8601 timestamp until which away mode applies.
+	// Use empty string for no end.
+	AwayEndsAt *string `form:"away_ends_at" json:"away_ends_at"`
+	// Automatically reply to mentions with the away message while away.
+	AwayAutoReply *bool `form:"away_auto_reply" json:"away_auto_reply"`
+	// Reject statuses with media that lacks a description (alt text).
+	RequireMediaDescriptions *bool `form:"require_media_descriptions" json:"require_media_descriptions"`
+	// Detect the language of authored statuses posted
+	// without one, before falling back to the default.
+	DetectLanguage *bool `form:"detect_language" json:"detect_language"`
+	// Make authored statuses local-only (not
+	// federated) by default, if not set explicitly.
+	LocalOnly *bool `form:"local_only" json:"local_only"`
+	// Continue the account's thread by default when posting
+	// statuses without in_reply_to_id, by replying to its
+	// most recent status.
+	ContinueThread *bool `form:"continue_thread" json:"continue_thread"`
+	// IDs of statuses to take turns being pinned to the profile, in order.
+	RotatingPinStatusIDs *[]string `form:"rotating_pin_status_ids[]" json:"rotating_pin_status_ids"`
+	// Number of seconds each rotating status stays pinned
+	// before the next one takes over. Use 0 to turn off.
+	RotatingPinInterval *int `form:"rotating_pin_interval" json:"rotating_pin_interval"`
 }
 
 // UpdateField is to be used specifically in an UpdateCredentialsRequest.
@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AccountExport represents an export of an account's
+// profile, statuses, and media, as a downloadable archive.
+//
+// swagger:model accountExport
+//
+// ---
+// tags:
+// - user
+type AccountExport struct {
+	// The ID of the export.
+	ID string `json:"id"`
+	// State of the export.
+	// Enum:
+	//	- pending
+	//	- running
+	//	- done
+	//	- failed
+	State string `json:"state"`
+	// When the export was requested (ISO 8601 Datetime).
+	CreatedAt string `json:"created_at"`
+	// When the export finished, successfully or not (ISO 8601 Datetime).
+	// Null if the export has not finished yet.
+	CompletedAt *string `json:"completed_at"`
+	// When the export archive will be removed from storage (ISO 8601 Datetime).
+	// Null if the export has not finished successfully.
+	ExpiresAt *string `json:"expires_at"`
+	// Number of statuses to export.
+	StatusesTotal int `json:"statuses_total"`
+	// Number of statuses exported so far.
+	StatusesDone int `json:"statuses_done"`
+	// Number of media files exported so far.
+	MediaDone int `json:"media_done"`
+	// Size of the export archive in bytes. 0 if not finished yet.
+	Size int64 `json:"size"`
+	// URL at which the export archive can be downloaded.
+	// Only set once the export has finished successfully.
+	ArchiveURL string `json:"archive_url,omitempty"`
+	// Reason the export failed, if applicable.
+	Error string `json:"error,omitempty"`
+}
@@ -63,8 +63,30 @@ type ApplicationCreateRequest struct {
 	//
 	// in: formData
 	Scopes string `form:"scopes" json:"scopes" xml:"scopes"`
+	// Space separated list of scopes that may be granted to app-only tokens obtained
+	// using the `client_credentials` grant. Only `read` and its sub-scopes are permitted,
+	// and they must be within the scopes of the application.
+	//
+	// If not provided, defaults to `read`.
+	//
+	// in: formData
+	ClientCredentialsScopes string `form:"client_credentials_scopes" json:"client_credentials_scopes" xml:"client_credentials_scopes"`
 	// A URL to the web page of the app (optional).
 	//
 	// in: formData
 	Website string `form:"website" json:"website" xml:"website"`
+	// Number of seconds after which access tokens issued to this application expire.
+	//
+	// If not provided, or 0, access tokens obtained by authorizing a user don't expire,
+	// and app-only tokens obtained using the `client_credentials` grant expire after 2 hours.
+	//
+	// in: formData
+	AccessTokenLifetime int `form:"access_token_lifetime" json:"access_token_lifetime" xml:"access_token_lifetime"`
+	// Number of seconds after which refresh tokens issued to this application expire.
+	// Must be at least `access_token_lifetime`, which must then also be set.
+	//
+	// If not provided, or 0, refresh tokens don't expire.
+	//
+	// in: formData
+	RefreshTokenLifetime int `form:"refresh_token_lifetime" json:"refresh_token_lifetime" xml:"refresh_token_lifetime"`
 }
@@ -35,6 +35,36 @@ type AttachmentRequest struct {
 	Focus string `form:"focus"`
 }
 
+// AttachmentUploadFinalizeRequest models parameters for creating a media
+// attachment from a file uploaded directly to storage via a presigned URL.
+//
+// swagger:ignore
+type AttachmentUploadFinalizeRequest struct {
+	// Storage key of the uploaded file, as returned
+	// along with the presigned upload URL.
+	Key string `form:"key" json:"key" xml:"key" binding:"required"`
+	// Description of the media file. Optional.
+	// This will be used as alt-text for users of screenreaders etc.
+	Description string `form:"description" json:"description" xml:"description"`
+	// Focus of the media file. Optional.
+	// If present, it should be in the form of two comma-separated floats between -1 and 1.
+	Focus string `form:"focus" json:"focus" xml:"focus"`
+}
+
+// AttachmentUpload models a presigned URL at which a
+// client can upload a media file directly to storage.
+//
+// swagger:model attachmentUpload
+type AttachmentUpload struct {
+	// Storage key of the file, to be given when
+	// finalizing the upload once it's complete.
+	Key string `json:"key"`
+	// Presigned URL to upload the file to, using a PUT request.
+	URL string `json:"url"`
+	// When the presigned URL expires (ISO 8601 Datetime).
+	ExpiresAt string `json:"expires_at"`
+}
+
 // AttachmentUpdateRequest models an update request for an attachment.
 //
 // swagger:ignore
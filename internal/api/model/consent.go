@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Consent represents an application that the user approved
+// to act on their behalf, and asked to remember approval for,
+// so that it can be authorized again without asking them.
+//
+// swagger:model consent
+//
+// ---
+// tags:
+// - user
+type Consent struct {
+	// The ID of the consent.
+	ID string `json:"id"`
+	// The application consented to.
+	Application *Application `json:"application"`
+	// OAuth scopes consented to, separated by spaces.
+	// If the application asks for a broader scope
+	// than this, the user is asked to approve it again.
+	Scope string `json:"scope"`
+	// When consent was first remembered (ISO 8601 Datetime).
+	CreatedAt string `json:"created_at"`
+	// When consent was last remembered (ISO 8601 Datetime).
+	UpdatedAt string `json:"updated_at"`
+}
@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// DomainSensitive represents a rule that media from
+// accounts on one domain (and its subdomains) should
+// always be shown as sensitive to users of this instance.
+//
+// swagger:model domainSensitive
+type DomainSensitive struct {
+	// The ID of the domain sensitive entry.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// The hostname of the domain.
+	// example: example.org
+	Domain string `json:"domain"`
+
+	// Private comment for this entry, visible to this instance's admins only.
+	// example: lots of untagged gore
+	PrivateComment string `json:"private_comment,omitempty"`
+
+	// ID of the account that created this entry.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+
+	// Time at which this entry was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+}
+
+// DomainSensitiveRequest is the form submitted as a POST to create a new domain sensitive entry.
+//
+// swagger:parameters domainSensitiveCreate
+type DomainSensitiveRequest struct {
+	// The hostname of the domain whose media should be shown as sensitive.
+	// required: true
+	// in: formData
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+
+	// Private comment for this entry, visible to this instance's admins only.
+	// in: formData
+	PrivateComment string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+}
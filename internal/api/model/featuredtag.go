@@ -18,15 +18,29 @@
 package model
 
 // FeaturedTag represents a hashtag that is featured on a profile.
+//
+// swagger:model featuredTag
 type FeaturedTag struct {
 	// The internal ID of the featured tag in the database.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
 	ID string `json:"id"`
-	// The name of the hashtag being featured.
+	// The name of the hashtag being featured, without the # prefix.
+	// example: gotosocial
 	Name string `json:"name"`
-	// A link to all statuses by a user that contain this hashtag.
+	// A link to the web page of this hashtag.
+	// example: https://example.org/tags/gotosocial
 	URL string `json:"url"`
-	// The number of authored statuses containing this hashtag.
+	// The number of public statuses by the account containing this hashtag.
 	StatusesCount int `json:"statuses_count"`
-	// The timestamp of the last authored status containing this hashtag. (ISO 8601 Datetime)
-	LastStatusAt string `json:"last_status_at"`
+	// The timestamp of the last public status by the account containing this hashtag. (ISO 8601 Datetime)
+	// Null if the account has not used this hashtag.
+	LastStatusAt *string `json:"last_status_at"`
+}
+
+// FeaturedTagCreateRequest models a request to feature a hashtag on the requester's profile.
+//
+// swagger:ignore
+type FeaturedTagCreateRequest struct {
+	// The hashtag to be featured, with or without the # prefix.
+	Name string `form:"name" json:"name" xml:"name"`
 }
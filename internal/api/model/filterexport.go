@@ -0,0 +1,315 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import "encoding/json"
+
+// FilterExport is a portable document containing all of an account's v2 filters,
+// suitable for importing into another account, possibly on another instance.
+//
+// Filtered statuses are not included, since status IDs are specific to one instance.
+//
+// swagger:model filterExport
+//
+// ---
+// tags:
+// - filters
+type FilterExport struct {
+	// When the filters were exported (ISO 8601 Datetime).
+	// Used to recompute filter expiry relative to import time.
+	//
+	// Example: 2024-02-01T02:57:49.000Z
+	ExportedAt string `json:"exported_at"`
+	// The exported filters.
+	Filters []FilterExportEntry `json:"filters"`
+}
+
+// FilterExportEntry is a single filter in a filter export document.
+//
+// swagger:model filterExportEntry
+//
+// ---
+// tags:
+// - filters
+type FilterExportEntry struct {
+	// The name of the filter.
+	//
+	// Example: Linux Words
+	Title string `json:"title"`
+	// The contexts in which the filter should be applied.
+	//
+	// Example: ["home", "public"]
+	Context []FilterContext `json:"context"`
+	// When the filter should no longer be applied. Null if the filter does not expire.
+	//
+	// Example: 2024-02-01T02:57:49Z
+	ExpiresAt *string `json:"expires_at"`
+	// The action to be taken when a status matches this filter.
+	// Enum:
+	//	- warn
+	//	- hide
+	//	- blur
+	//	- notify
+	FilterAction FilterAction `json:"filter_action"`
+	// Whether the filter applies its action to statuses that match it (block),
+	// or hides all statuses that don't match it in its contexts (allow).
+	// Enum:
+	//	- block
+	//	- allow
+	FilterMode FilterMode `json:"filter_mode"`
+	// The keywords grouped under this filter.
+	Keywords []FilterExportKeyword `json:"keywords"`
+	// Domains of accounts whose statuses this filter matches. Omitted if empty.
+	// (Accounts matched by ID are not exported, as IDs are instance-specific.)
+	//
+	// Example: ["example.org"]
+	Domains []string `json:"domains,omitempty"`
+	// Whether the filter's domains also match their subdomains. Omitted if false.
+	IncludeSubdomains bool `json:"include_subdomains,omitempty"`
+	// Days of the week on which the filter applies, from 0 (Sunday) to 6 (Saturday).
+	// Omitted if the filter applies every day.
+	ActiveDays []int `json:"active_days,omitempty"`
+	// Time of day (HH:MM) from which the filter applies. Omitted if the filter applies all day.
+	ActiveFrom string `json:"active_from,omitempty"`
+	// Time of day (HH:MM) until which the filter applies. Omitted if the filter applies all day.
+	ActiveUntil string `json:"active_until,omitempty"`
+	// IANA time zone in which the active days and times are interpreted. Omitted if UTC.
+	ActiveTimezone string `json:"active_timezone,omitempty"`
+}
+
+// FilterExportKeyword is a single keyword of a filter in a filter export document.
+//
+// swagger:model filterExportKeyword
+//
+// ---
+// tags:
+// - filters
+type FilterExportKeyword struct {
+	// The text to be filtered.
+	//
+	// Example: fnord
+	Keyword string `json:"keyword"`
+	// Should the filter keyword consider word boundaries?
+	//
+	// Example: true
+	WholeWord bool `json:"whole_word"`
+	// Is the keyword a regular expression rather than a literal phrase?
+	//
+	// Example: false
+	Regex bool `json:"regex,omitempty"`
+}
+
+// FilterImportRequest captures params for importing a filter export document.
+//
+// swagger:ignore
+type FilterImportRequest struct {
+	// When the filters were exported (ISO 8601 Datetime).
+	ExportedAt string `json:"exported_at"`
+	// The filters to import. Kept raw so that
+	// malformed entries can be skipped individually.
+	Filters []json.RawMessage `json:"filters"`
+
+	// How to treat the account's existing filters;
+	// one of FilterImportModeMerge or FilterImportModeReplace.
+	Mode string `json:"-"`
+	// How to treat filter expiry; one of
+	// FilterImportExpiryRelative or FilterImportExpiryAbsolute.
+	Expiry string `json:"-"`
+}
+
+const (
+	// FilterImportModeMerge keeps existing filters,
+	// adding imported filters alongside them.
+	FilterImportModeMerge = "merge"
+	// FilterImportModeReplace deletes existing
+	// filters before adding imported filters.
+	FilterImportModeReplace = "replace"
+
+	// FilterImportExpiryRelative recomputes expiry so that imported
+	// filters expire the same time after import as they would have
+	// expired after export.
+	FilterImportExpiryRelative = "relative"
+	// FilterImportExpiryAbsolute preserves exported expiry times as-is.
+	FilterImportExpiryAbsolute = "absolute"
+)
+
+// FilterImportResult reports the outcome of importing a filter export document.
+//
+// swagger:model filterImportResult
+//
+// ---
+// tags:
+// - filters
+type FilterImportResult struct {
+	// Number of filters successfully imported.
+	Imported int `json:"imported"`
+	// Number of filters that could not be imported.
+	Failed int `json:"failed"`
+	// Outcome for each filter in the document, in document order.
+	Filters []FilterImportEntryResult `json:"filters"`
+}
+
+// FilterImportEntryResult reports the outcome of importing a single filter.
+//
+// swagger:model filterImportEntryResult
+//
+// ---
+// tags:
+// - filters
+type FilterImportEntryResult struct {
+	// Index of the filter in the imported document.
+	Index int `json:"index"`
+	// The name of the filter, if it could be parsed.
+	Title string `json:"title,omitempty"`
+	// ID of the newly created filter. Only set on success.
+	ID string `json:"id,omitempty"`
+	// Reason the filter could not be imported. Only set on failure.
+	Error string `json:"error,omitempty"`
+	// Fields of the filter that were not recognized, or that refer to
+	// accounts or statuses not found on this instance, and so were dropped.
+	// Only set for imports in Mastodon format.
+	Unmapped []string `json:"unmapped,omitempty"`
+}
+
+// FilterMastodon is a single filter in the representation used by Mastodon's
+// v2 filters API, as returned by Mastodon's GET /api/v2/filters.
+//
+// Fields that have no Mastodon equivalent are nested under the gotosocial
+// key, which Mastodon ignores, so that filters survive a round trip through
+// GoToSocial intact.
+//
+// swagger:model filterMastodon
+//
+// ---
+// tags:
+// - filters
+type FilterMastodon struct {
+	// The ID of the filter on the exporting instance. Ignored on import.
+	//
+	// Example: 01HN26VM6KZTW1ANNRVSBMA461
+	ID string `json:"id,omitempty"`
+	// The name of the filter.
+	//
+	// Example: Linux Words
+	Title string `json:"title"`
+	// The contexts in which the filter should be applied.
+	//
+	// Example: ["home", "public"]
+	Context []FilterContext `json:"context"`
+	// When the filter should no longer be applied. Null if the filter does not expire.
+	//
+	// Example: 2024-02-01T02:57:49Z
+	ExpiresAt *string `json:"expires_at"`
+	// The action to be taken when a status matches this filter.
+	// GoToSocial's notify action is exported as warn, which Mastodon understands.
+	// Enum:
+	//	- warn
+	//	- hide
+	//	- blur
+	FilterAction FilterAction `json:"filter_action"`
+	// The keywords grouped under this filter.
+	Keywords []FilterMastodonKeyword `json:"keywords"`
+	// The statuses grouped under this filter.
+	Statuses []FilterMastodonStatus `json:"statuses"`
+	// GoToSocial-specific filter fields. Omitted if all have default values.
+	GoToSocial *FilterMastodonExtensions `json:"gotosocial,omitempty"`
+}
+
+// FilterMastodonKeyword is a single keyword of a filter in Mastodon representation.
+//
+// swagger:model filterMastodonKeyword
+//
+// ---
+// tags:
+// - filters
+type FilterMastodonKeyword struct {
+	// The ID of the keyword on the exporting instance. Ignored on import.
+	//
+	// Example: 01HN277FSPQAWXZXK92QPPYF79
+	ID string `json:"id,omitempty"`
+	// The text to be filtered.
+	//
+	// Example: fnord
+	Keyword string `json:"keyword"`
+	// Should the filter keyword consider word boundaries?
+	//
+	// Example: true
+	WholeWord bool `json:"whole_word"`
+	// Is the keyword a regular expression rather than a literal phrase?
+	// GoToSocial extension, ignored by Mastodon. Omitted if false.
+	//
+	// Example: false
+	Regex bool `json:"regex,omitempty"`
+}
+
+// FilterMastodonStatus is a single status of a filter in Mastodon representation.
+//
+// swagger:model filterMastodonStatus
+//
+// ---
+// tags:
+// - filters
+type FilterMastodonStatus struct {
+	// The ID of the filter status entry on the exporting instance. Ignored on import.
+	//
+	// Example: 01HQXGMQ3QFXRT4GX9WNQ8KC0X
+	ID string `json:"id,omitempty"`
+	// The ID of the filtered status.
+	//
+	// Example: 01HEN2QRFA8H3C6QPN7RD4KSR6
+	StatusID string `json:"status_id"`
+}
+
+// FilterMastodonExtensions contains the fields of a filter
+// that cannot be represented in Mastodon's filter entity.
+//
+// swagger:model filterMastodonExtensions
+//
+// ---
+// tags:
+// - filters
+type FilterMastodonExtensions struct {
+	// The action to be taken when a status matches this filter,
+	// if it has no Mastodon equivalent. Omitted otherwise.
+	// Enum:
+	//	- notify
+	FilterAction FilterAction `json:"filter_action,omitempty"`
+	// Whether the filter applies its action to statuses that match it (block),
+	// or hides all statuses that don't match it in its contexts (allow).
+	// Omitted if block.
+	// Enum:
+	//	- allow
+	FilterMode FilterMode `json:"filter_mode,omitempty"`
+	// IDs of accounts whose statuses this filter matches. Omitted if empty.
+	Accounts []string `json:"accounts,omitempty"`
+	// Domains of accounts whose statuses this filter matches. Omitted if empty.
+	//
+	// Example: ["example.org"]
+	Domains []string `json:"domains,omitempty"`
+	// Whether the filter's domains also match their subdomains. Omitted if false.
+	IncludeSubdomains bool `json:"include_subdomains,omitempty"`
+	// Days of the week on which the filter applies, from 0 (Sunday) to 6 (Saturday).
+	// Omitted if the filter applies every day.
+	ActiveDays []int `json:"active_days,omitempty"`
+	// Time of day (HH:MM) from which the filter applies. Omitted if the filter applies all day.
+	ActiveFrom string `json:"active_from,omitempty"`
+	// Time of day (HH:MM) until which the filter applies. Omitted if the filter applies all day.
+	ActiveUntil string `json:"active_until,omitempty"`
+	// IANA time zone in which the active days and times are interpreted. Omitted if UTC.
+	ActiveTimezone string `json:"active_timezone,omitempty"`
+}
@@ -97,3 +97,63 @@ type FilterCreateUpdateRequestV1 struct {
 	// Example: 86400
 	ExpiresInI interface{} `json:"expires_in"`
 }
+
+// FilterImportRequestV1 captures the filters of a bulk v1 filter import,
+// after parsing and validation.
+//
+// swagger:ignore
+type FilterImportRequestV1 struct {
+	Filters []FilterImportEntryV1
+}
+
+// FilterImportEntryV1 is a single filter of a bulk v1 filter import.
+//
+// swagger:ignore
+type FilterImportEntryV1 struct {
+	// Index of the filter in the imported document.
+	Index int
+	// Validated params for creating the filter.
+	// Nil if the filter was rejected.
+	Filter *FilterCreateUpdateRequestV1
+	// The text to be filtered, if it could be parsed.
+	Phrase string
+	// Reason the filter was rejected.
+	Error string
+}
+
+// FilterImportResultV1 reports the outcome of a bulk v1 filter import.
+//
+// swagger:model filterImportResultV1
+//
+// ---
+// tags:
+// - filters
+type FilterImportResultV1 struct {
+	// Filters that were created.
+	Imported []*FilterV1 `json:"imported"`
+	// Filters that were not created because an
+	// identical filter (same phrase and context)
+	// already exists, or occurs earlier in the import.
+	Skipped []FilterImportEntryResultV1 `json:"skipped"`
+	// Filters that were not created because they're invalid.
+	Rejected []FilterImportEntryResultV1 `json:"rejected"`
+}
+
+// FilterImportEntryResultV1 reports why a single filter of a
+// bulk v1 filter import was skipped or rejected.
+//
+// swagger:model filterImportEntryResultV1
+//
+// ---
+// tags:
+// - filters
+type FilterImportEntryResultV1 struct {
+	// Index of the filter in the imported document.
+	// Filters of the v2 shape with several keywords
+	// have one entry per keyword, with the same index.
+	Index int `json:"index"`
+	// The text to be filtered, if it could be parsed.
+	Phrase string `json:"phrase,omitempty"`
+	// Reason the filter was skipped or rejected.
+	Error string `json:"error"`
+}
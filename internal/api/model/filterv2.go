@@ -52,11 +52,47 @@ type FilterV2 struct {
 	// Enum:
 	//	- warn
 	//	- hide
+	//	- blur
+	//	- notify
 	FilterAction FilterAction `json:"filter_action"`
+	// Whether the filter applies its action to statuses that match it (block),
+	// or hides all statuses that don't match it in its contexts (allow).
+	// Enum:
+	//	- block
+	//	- allow
+	FilterMode FilterMode `json:"filter_mode"`
 	// The keywords grouped under this filter.
 	Keywords []FilterKeyword `json:"keywords"`
 	// The statuses grouped under this filter.
 	Statuses []FilterStatus `json:"statuses"`
+	// IDs of accounts whose statuses this filter matches. Omitted if empty.
+	Accounts []string `json:"accounts,omitempty"`
+	// Domains of accounts whose statuses this filter matches. Omitted if empty.
+	//
+	// Example: ["example.org"]
+	Domains []string `json:"domains,omitempty"`
+	// Whether the filter's domains also match their subdomains. Omitted if false.
+	IncludeSubdomains bool `json:"include_subdomains,omitempty"`
+	// Days of the week on which the filter applies, from 0 (Sunday) to 6 (Saturday).
+	// Omitted if the filter applies every day.
+	//
+	// Example: [1, 2, 3, 4, 5]
+	ActiveDays []int `json:"active_days,omitempty"`
+	// Time of day (HH:MM) from which the filter applies on active days.
+	// Omitted if the filter applies all day.
+	//
+	// Example: 09:00
+	ActiveFrom string `json:"active_from,omitempty"`
+	// Time of day (HH:MM) until which the filter applies. If earlier than active_from,
+	// the window runs past midnight. Omitted if the filter applies all day.
+	//
+	// Example: 17:00
+	ActiveUntil string `json:"active_until,omitempty"`
+	// IANA time zone in which the active days and times are interpreted.
+	// Omitted if UTC.
+	//
+	// Example: Europe/Amsterdam
+	ActiveTimezone string `json:"active_timezone,omitempty"`
 }
 
 // FilterAction is the action to apply to statuses matching a filter.
@@ -69,6 +105,23 @@ const (
 	FilterActionWarn FilterAction = "warn"
 	// FilterActionHide filters will remove this status from API results.
 	FilterActionHide FilterAction = "hide"
+	// FilterActionBlur filters will include this status in API results with its media marked sensitive.
+	FilterActionBlur FilterAction = "blur"
+	// FilterActionNotify filters will include this status in API results as normal, and notify the user about it.
+	FilterActionNotify FilterAction = "notify"
+)
+
+// FilterMode is the mode of a filter, determining
+// whether it applies to matching or non-matching statuses.
+type FilterMode string
+
+const (
+	// FilterModeNone filters should not exist, except internally, for partially constructed or invalid filters.
+	FilterModeNone FilterMode = ""
+	// FilterModeBlock filters apply their action to statuses that match them.
+	FilterModeBlock FilterMode = "block"
+	// FilterModeAllow filters hide statuses that don't match them, or any other allow filter, in the same context.
+	FilterModeAllow FilterMode = "allow"
 )
 
 // FilterKeyword represents text to filter within a v2 filter.
@@ -89,6 +142,11 @@ type FilterKeyword struct {
 	//
 	// Example: true
 	WholeWord bool `json:"whole_word"`
+	// Is the keyword a regular expression rather than a literal phrase?
+	// GoToSocial extension; only present if true.
+	//
+	// Example: false
+	Regex bool `json:"regex,omitempty"`
 }
 
 // FilterStatus represents a single status to filter within a v2 filter.
@@ -126,8 +184,17 @@ type FilterCreateRequestV2 struct {
 	// Enum:
 	//	- warn
 	//	- hide
+	//	- blur
+	//	- notify
 	// Example: warn
 	FilterAction *FilterAction `form:"filter_action" json:"filter_action" xml:"filter_action"`
+	// Whether the filter applies its action to statuses that match it (block),
+	// or hides all statuses that don't match it in its contexts (allow). If omitted, defaults to block.
+	// Enum:
+	//	- block
+	//	- allow
+	// Example: block
+	FilterMode *FilterMode `form:"filter_mode" json:"filter_mode" xml:"filter_mode"`
 
 	// Number of seconds from now that the filter should expire. If omitted, filter never expires.
 	ExpiresIn *int `json:"-" form:"expires_in" xml:"expires_in"`
@@ -142,11 +209,29 @@ type FilterCreateRequestV2 struct {
 	KeywordsAttributesKeyword []string `form:"keywords_attributes[][keyword]" json:"-" xml:"-"`
 	// Form data version of Keywords[].WholeWord.
 	KeywordsAttributesWholeWord []bool `form:"keywords_attributes[][whole_word]" json:"-" xml:"-"`
+	// Form data version of Keywords[].Regex.
+	KeywordsAttributesRegex []bool `form:"keywords_attributes[][regex]" json:"-" xml:"-"`
 
 	// Statuses to be added to the newly created filter.
 	Statuses []FilterStatusCreateRequest `form:"-" json:"statuses_attributes" xml:"statuses_attributes"`
 	// Form data version of Statuses[].StatusID.
 	StatusesAttributesStatusID []string `form:"statuses_attributes[][status_id]" json:"-" xml:"-"`
+
+	// IDs of accounts whose statuses the filter should match.
+	Accounts []string `form:"accounts[]" json:"accounts" xml:"accounts"`
+	// Domains of accounts whose statuses the filter should match.
+	Domains []string `form:"domains[]" json:"domains" xml:"domains"`
+	// Whether domains should also match their subdomains. If omitted, defaults to false.
+	IncludeSubdomains *bool `form:"include_subdomains" json:"include_subdomains" xml:"include_subdomains"`
+
+	// Days of the week (0 = Sunday) on which the filter should apply. If omitted, applies every day.
+	ActiveDays []int `form:"active_days[]" json:"active_days" xml:"active_days"`
+	// Time of day (HH:MM) from which the filter should apply. Must be provided with ActiveUntil.
+	ActiveFrom string `form:"active_from" json:"active_from" xml:"active_from"`
+	// Time of day (HH:MM) until which the filter should apply. Must be provided with ActiveFrom.
+	ActiveUntil string `form:"active_until" json:"active_until" xml:"active_until"`
+	// IANA time zone for ActiveDays, ActiveFrom and ActiveUntil. If omitted, defaults to UTC.
+	ActiveTimezone string `form:"active_timezone" json:"active_timezone" xml:"active_timezone"`
 }
 
 // FilterKeywordCreateUpdateRequest captures params for creating or updating a filter keyword while creating a v2 filter or as a standalone operation.
@@ -162,6 +247,11 @@ type FilterKeywordCreateUpdateRequest struct {
 	//
 	// Example: true
 	WholeWord *bool `form:"whole_word" json:"whole_word" xml:"whole_word"`
+	// Should the keyword be matched as a regular expression?
+	// Only allowed if the instance permits regex filters.
+	//
+	// Example: false
+	Regex *bool `form:"regex" json:"regex" xml:"regex"`
 }
 
 // FilterStatusCreateRequest captures params for a status while creating a v2 filter or filter status.
@@ -191,8 +281,17 @@ type FilterUpdateRequestV2 struct {
 	// Enum:
 	//	- warn
 	//	- hide
+	//	- blur
+	//	- notify
 	// Example: warn
 	FilterAction *FilterAction `form:"filter_action" json:"filter_action" xml:"filter_action"`
+	// Whether the filter applies its action to statuses that match it (block),
+	// or hides all statuses that don't match it in its contexts (allow).
+	// Enum:
+	//	- block
+	//	- allow
+	// Example: block
+	FilterMode *FilterMode `form:"filter_mode" json:"filter_mode" xml:"filter_mode"`
 
 	// Number of seconds from now that the filter should expire. If omitted, filter never expires.
 	ExpiresIn *int `json:"-" form:"expires_in" xml:"expires_in"`
@@ -209,6 +308,8 @@ type FilterUpdateRequestV2 struct {
 	KeywordsAttributesKeyword []string `form:"keywords_attributes[][keyword]" json:"-" xml:"-"`
 	// Form data version of Keywords[].WholeWord.
 	KeywordsAttributesWholeWord []bool `form:"keywords_attributes[][whole_word]" json:"-" xml:"-"`
+	// Form data version of Keywords[].Regex.
+	KeywordsAttributesRegex []bool `form:"keywords_attributes[][regex]" json:"-" xml:"-"`
 	// Form data version of Keywords[].Destroy.
 	KeywordsAttributesDestroy []bool `form:"keywords_attributes[][_destroy]" json:"-" xml:"-"`
 
@@ -220,6 +321,25 @@ type FilterUpdateRequestV2 struct {
 	StatusesAttributesStatusID []string `form:"statuses_attributes[][status_id]" json:"-" xml:"-"`
 	// Form data version of Statuses[].Destroy.
 	StatusesAttributesDestroy []bool `form:"statuses_attributes[][_destroy]" json:"-" xml:"-"`
+
+	// IDs of accounts whose statuses the filter should match.
+	// If provided, replaces the filter's existing accounts.
+	Accounts *[]string `form:"accounts[]" json:"accounts" xml:"accounts"`
+	// Domains of accounts whose statuses the filter should match.
+	// If provided, replaces the filter's existing domains.
+	Domains *[]string `form:"domains[]" json:"domains" xml:"domains"`
+	// Whether domains should also match their subdomains.
+	IncludeSubdomains *bool `form:"include_subdomains" json:"include_subdomains" xml:"include_subdomains"`
+
+	// Days of the week (0 = Sunday) on which the filter should apply.
+	// If any active window field is provided, replaces the filter's existing window.
+	ActiveDays *[]int `form:"active_days[]" json:"active_days" xml:"active_days"`
+	// Time of day (HH:MM) from which the filter should apply.
+	ActiveFrom *string `form:"active_from" json:"active_from" xml:"active_from"`
+	// Time of day (HH:MM) until which the filter should apply.
+	ActiveUntil *string `form:"active_until" json:"active_until" xml:"active_until"`
+	// IANA time zone for ActiveDays, ActiveFrom and ActiveUntil.
+	ActiveTimezone *string `form:"active_timezone" json:"active_timezone" xml:"active_timezone"`
 }
 
 // FilterKeywordCreateUpdateDeleteRequest captures params for creating, updating, or deleting a keyword while updating a v2 filter.
@@ -238,6 +358,11 @@ type FilterKeywordCreateUpdateDeleteRequest struct {
 	//
 	// Example: true
 	WholeWord *bool `json:"whole_word" xml:"whole_word"`
+	// Should the keyword be matched as a regular expression?
+	// Only allowed if the instance permits regex filters.
+	//
+	// Example: false
+	Regex *bool `json:"regex" xml:"regex"`
 	// Remove this filter keyword. Requires an ID.
 	Destroy *bool `json:"_destroy" xml:"_destroy"`
 }
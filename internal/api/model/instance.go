@@ -71,6 +71,11 @@ type InstanceConfigurationStatuses struct {
 	//
 	// example: 4
 	MaxMediaAttachments int `json:"max_media_attachments"`
+	// Whether media of different types (eg., image + video)
+	// can be attached to the same status on this instance.
+	//
+	// example: true
+	AllowMixedMedia bool `json:"allow_mixed_media"`
 	// Amount of characters clients should assume a url takes up.
 	//
 	// example: 25
@@ -79,6 +84,10 @@ type InstanceConfigurationStatuses struct {
 	//
 	// example: ["text/plain","text/markdown"]
 	SupportedMimeTypes []string `json:"supported_mime_types,omitempty"`
+	// List of visibilities that it's possible to use for statuses on this instance.
+	//
+	// example: ["public","unlisted","private","mutuals_only","circle","direct"]
+	SupportedVisibilities []string `json:"supported_visibilities,omitempty"`
 }
 
 // InstanceConfigurationMediaAttachments models instance media attachment config parameters.
@@ -34,6 +34,7 @@ type Notification struct {
 	// 	poll = A poll you have voted in or created has ended. `status` will be set. `account` will be set.
 	// 	status = Someone you enabled notifications for has posted a status. `status` will be set. `account` will be set.
 	// 	admin.sign_up = Someone has signed up for a new account on the instance. `account` will be set.
+	// 	filter_match = A status matching one of your filters with the notify action has reached your home timeline. `status` will be set. `account` will be set.
 	Type string `json:"type"`
 	// The timestamp of the notification (ISO 8601 Datetime)
 	CreatedAt string `json:"created_at"`
@@ -46,6 +47,30 @@ type Notification struct {
 	Status *Status `json:"status,omitempty"`
 }
 
+// NotificationGroup represents several notifications of the same
+// type about the same status, eg., a burst of favourites, which were
+// streamed to the user together as one event. See the streaming API.
+//
+// swagger:model notificationGroup
+type NotificationGroup struct {
+	// Key identifying the group: the type of the
+	// notifications, and the ID of their status.
+	GroupKey string `json:"group_key"`
+	// Number of notifications in the group.
+	NotificationsCount int `json:"notifications_count"`
+	// The type of the notifications in the group: favourite or reblog.
+	Type string `json:"type"`
+	// ID of the most recent notification in the group.
+	MostRecentNotificationID string `json:"most_recent_notification_id"`
+	// The timestamp of the most recent notification in the group (ISO 8601 Datetime).
+	LatestNotificationAt string `json:"latest_notification_at"`
+	// The accounts that performed the actions,
+	// most recent first. At most 8 are included.
+	SampleAccounts []*Account `json:"sample_accounts"`
+	// Status that was the object of the notifications.
+	Status *Status `json:"status"`
+}
+
 /*
 	The below functions are added onto the apimodel notification so that it satisfies
 	the Timelineable interface in internal/timeline.
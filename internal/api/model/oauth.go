@@ -36,4 +36,138 @@ type OAuthAuthorize struct {
 	// The authorization server must return the unmodified state value back to the application.
 	// See https://www.oauth.com/oauth2-servers/authorization/the-authorization-request/
 	State string `form:"state" json:"state"`
+	// PKCE code challenge derived from the client's code verifier (RFC 7636).
+	// Optional; if set, the code verifier must be presented when exchanging the code for a token.
+	CodeChallenge string `form:"code_challenge" json:"code_challenge"`
+	// Method used to derive the code challenge: either plain or S256. Defaults to plain if not set.
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method"`
+	// Human-readable name of the device or app being authorized, eg., "Firefox on Linux".
+	// Optional; if set, it's stored with the token so the user can recognize it among their sessions.
+	DeviceName string `form:"device_name" json:"device_name"`
+	// Request URI returned from a pushed authorization request (RFC 9126).
+	// If set, the authorization parameters are taken from the pushed request
+	// instead, and client_id must be the only other parameter given.
+	RequestURI string `form:"request_uri" json:"request_uri"`
+}
+
+// OAuthClientRegistrationRequest represents a dynamic client
+// registration request sent to https://example.org/oauth/register,
+// containing the client's metadata, as per RFC 7591.
+//
+// swagger:ignore
+type OAuthClientRegistrationRequest struct {
+	// Redirect URIs to register for the client.
+	RedirectURIs []string `json:"redirect_uris"`
+	// OAuth grant types the client will use. Defaults to authorization_code.
+	GrantTypes []string `json:"grant_types"`
+	// OAuth response types the client will use. Defaults to code.
+	ResponseTypes []string `json:"response_types"`
+	// Authentication method the client will use at the token
+	// endpoint. Either client_secret_post (default) or none.
+	TokenEndpointAuthMethod string `json:"token_endpoint_auth_method"`
+	// Human-readable name of the client.
+	ClientName string `json:"client_name"`
+	// URL of the web page of the client.
+	ClientURI string `json:"client_uri"`
+	// Space-separated list of scopes the client may request. Defaults to read.
+	Scope string `json:"scope"`
+}
+
+// OAuthClientRegistration represents the response to a
+// successful dynamic client registration request, ie.,
+// the registered client's information and metadata.
+//
+// swagger:model oauthClientRegistration
+type OAuthClientRegistration struct {
+	// Client ID of the registered client.
+	ClientID string `json:"client_id"`
+	// Client secret of the registered client.
+	ClientSecret string `json:"client_secret"`
+	// Unix timestamp (seconds) of when the client ID was issued.
+	ClientIDIssuedAt int64 `json:"client_id_issued_at"`
+	// Unix timestamp (seconds) of when the client secret
+	// expires, or 0 if it does not expire (which it doesn't).
+	ClientSecretExpiresAt int64 `json:"client_secret_expires_at"`
+	// Redirect URIs registered for the client.
+	RedirectURIs []string `json:"redirect_uris"`
+	// OAuth response types the client may use.
+	ResponseTypes []string `json:"response_types"`
+	// Human-readable name of the client.
+	ClientName string `json:"client_name,omitempty"`
+	// URL of the web page of the client.
+	ClientURI string `json:"client_uri,omitempty"`
+	// Space-separated list of scopes the client may request.
+	Scope string `json:"scope"`
+}
+
+// OAuthPushedAuthorization represents the response to a pushed
+// authorization request made to https://example.org/oauth/par,
+// as per RFC 9126.
+//
+// swagger:model oauthPushedAuthorization
+type OAuthPushedAuthorization struct {
+	// Request URI to pass to the authorize
+	// endpoint, along with the client ID.
+	RequestURI string `json:"request_uri"`
+	// Number of seconds for which the request URI can be used.
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// OAuthTokenIntrospection represents the response to a token introspection
+// request made to https://example.org/oauth/introspect, as per RFC 7662.
+//
+// If the token is not active (ie., it's unknown, revoked, expired, or not
+// visible to the requesting client), then only Active will be set.
+type OAuthTokenIntrospection struct {
+	// Whether or not the token is currently active.
+	Active bool `json:"active"`
+	// Space-separated list of scopes associated with the token.
+	Scope string `json:"scope,omitempty"`
+	// Client ID of the client the token was issued to.
+	ClientID string `json:"client_id,omitempty"`
+	// Username of the account the token was issued on behalf of,
+	// if any. Not set for tokens issued via client credentials.
+	Username string `json:"username,omitempty"`
+	// Type of the token, always "Bearer".
+	TokenType string `json:"token_type,omitempty"`
+	// Unix timestamp (seconds) of when the token expires.
+	// Not set if the token does not expire.
+	Exp int64 `json:"exp,omitempty"`
+	// Unix timestamp (seconds) of when the token was issued.
+	Iat int64 `json:"iat,omitempty"`
+}
+
+// JWKS represents a JSON Web Key Set, as per RFC 7517, containing
+// the public keys to validate JWT access tokens issued by this
+// instance with. Served at https://example.org/oauth/jwks.
+//
+// swagger:model jwks
+type JWKS struct {
+	// Public keys in the set.
+	Keys []JWK `json:"keys"`
+}
+
+// JWK represents a single public JSON Web Key, as per RFC 7517.
+//
+// swagger:model jwk
+type JWK struct {
+	// Key type: RSA, EC or OKP.
+	Kty string `json:"kty"`
+	// Intended use of the key, always "sig".
+	Use string `json:"use"`
+	// Algorithm the key signs tokens with, eg., RS256 or ES256.
+	Alg string `json:"alg"`
+	// ID of the key, set in the "kid" header of tokens signed with
+	// it. This is the key's RFC 7638 thumbprint.
+	Kid string `json:"kid"`
+	// RSA modulus, base64url-encoded. RSA keys only.
+	N string `json:"n,omitempty"`
+	// RSA public exponent, base64url-encoded. RSA keys only.
+	E string `json:"e,omitempty"`
+	// Curve of the key, eg., P-256 or Ed25519. EC and OKP keys only.
+	Crv string `json:"crv,omitempty"`
+	// X coordinate (EC) or public key (OKP), base64url-encoded.
+	X string `json:"x,omitempty"`
+	// Y coordinate, base64url-encoded. EC keys only.
+	Y string `json:"y,omitempty"`
 }
@@ -29,6 +29,8 @@ type Preferences struct {
 	PostingDefaultSensitive bool `json:"posting:default:sensitive"`
 	// Default language for new posts. (ISO 639-1 language two-letter code), or null
 	PostingDefaultLanguage string `json:"posting:default:language,omitempty"`
+	// Default content warning / spoiler text for new posts, or empty string if not set.
+	PostingDefaultContentWarning string `json:"posting:default:content_warning"`
 	// Whether media attachments should be automatically displayed or blurred/hidden.
 	// 	default = Hide media marked as sensitive
 	// 	show_all = Always show all media by default, regardless of sensitivity
@@ -18,6 +18,8 @@
 package model
 
 // ScheduledStatus represents a status that will be published at a future scheduled date.
+//
+// swagger:model scheduledStatus
 type ScheduledStatus struct {
 	ID               string        `json:"id"`
 	ScheduledAt      string        `json:"scheduled_at"`
@@ -26,6 +28,8 @@ type ScheduledStatus struct {
 }
 
 // StatusParams represents parameters for a scheduled status.
+//
+// swagger:model statusParams
 type StatusParams struct {
 	Text          string   `json:"text"`
 	InReplyToID   string   `json:"in_reply_to_id,omitempty"`
@@ -36,3 +40,27 @@ type StatusParams struct {
 	ScheduledAt   string   `json:"scheduled_at,omitempty"`
 	ApplicationID string   `json:"application_id"`
 }
+
+// ScheduledStatusBatchRequest models a request
+// to schedule multiple statuses at once.
+//
+// swagger:ignore
+type ScheduledStatusBatchRequest struct {
+	// Statuses to schedule. Each takes the same
+	// parameters as status creation, and must
+	// have scheduled_at set.
+	Statuses []*AdvancedStatusCreateForm `json:"statuses"`
+}
+
+// ScheduledStatusBatchResult represents the result of
+// scheduling one status of a batch scheduling request.
+//
+// swagger:model scheduledStatusBatchResult
+type ScheduledStatusBatchResult struct {
+	// Index of the status in the request's statuses array.
+	Index int `json:"index"`
+	// The scheduled status, if scheduling succeeded.
+	ScheduledStatus *ScheduledStatus `json:"scheduled_status,omitempty"`
+	// Reason scheduling failed, if it did.
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,46 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Session represents an access token held by the user,
+// ie., a device or app that's logged in to their account.
+//
+// swagger:model session
+//
+// ---
+// tags:
+// - user
+type Session struct {
+	// The ID of the session.
+	ID string `json:"id"`
+	// The application the session belongs to.
+	Application *Application `json:"application"`
+	// Human-readable name of the device or app, as given
+	// by the application when it was authorized.
+	// Key/value omitted if not given.
+	DeviceName string `json:"device_name,omitempty"`
+	// OAuth scopes of the session, separated by spaces.
+	Scope string `json:"scope"`
+	// When the session's token was issued (ISO 8601 Datetime).
+	CreatedAt string `json:"created_at"`
+	// Roughly when the session was last used (ISO 8601 Datetime).
+	// Key/value omitted if the session was never used.
+	LastUsedAt string `json:"last_used_at,omitempty"`
+	// Whether this is the session used to make this request.
+	Current bool `json:"current"`
+}
@@ -26,12 +26,73 @@ type Source struct {
 	//    private = Followers-only post
 	//    direct = Direct post
 	Privacy Visibility `json:"privacy"`
+	// Default privacy for replies: that of the replied-to
+	// post, but never wider than this. Empty if not set,
+	// in which case replies use the default privacy.
+	ReplyPrivacy Visibility `json:"reply_privacy"`
 	// Whether new statuses should be marked sensitive by default.
 	Sensitive bool `json:"sensitive"`
+	// Whether new statuses with media attached should be marked
+	// sensitive by default, so that their media is hidden.
+	DefaultMediaSensitive bool `json:"default_media_sensitive"`
 	// The default posting language for new statuses.
 	Language string `json:"language"`
 	// The default posting content type for new statuses.
 	StatusContentType string `json:"status_content_type"`
+	// The content type in which the account's note / bio is
+	// written. Empty if not set, in which case the note uses
+	// the status content type.
+	BioContentType string `json:"bio_content_type"`
+	// The default content warning / spoiler text for new statuses.
+	// Clients should pre-populate the spoiler text of new statuses
+	// with this, if set. Empty string means no default.
+	DefaultContentWarning string `json:"default_content_warning"`
+	// Number of seconds after which new statuses are
+	// automatically deleted. 0 means no default expiry.
+	DefaultPostExpiry int `json:"default_post_expiry"`
+	// If the account is locked, follow requests from accounts known
+	// to this instance for longer than this number of seconds are
+	// automatically approved. 0 means all requests are reviewed.
+	AutoApproveFollowsAfter int `json:"auto_approve_follows_after"`
+	// Whether follow requests from local accounts are reviewed
+	// as well, rather than automatically approved, when
+	// auto_approve_follows_after is set.
+	ReviewLocalFollows bool `json:"review_local_follows"`
+	// Whether away mode is turned on.
+	AwayEnabled bool `json:"away_enabled"`
+	// Message shown (and sent as an auto-reply, if
+	// enabled) while away. Empty if not set.
+	AwayMessage string `json:"away_message"`
+	// ISO 8601 timestamp from which away mode applies.
+	// Omitted if away mode starts immediately.
+	AwayStartsAt string `json:"away_starts_at,omitempty"`
+	// ISO 8601 timestamp until which away mode applies.
+	// Omitted if away mode has no end.
+	AwayEndsAt string `json:"away_ends_at,omitempty"`
+	// Whether mentions are automatically
+	// replied to with the away message.
+	AwayAutoReply bool `json:"away_auto_reply"`
+	// Whether statuses with media that lacks a description (alt text)
+	// are rejected, even if the instance doesn't require descriptions.
+	RequireMediaDescriptions bool `json:"require_media_descriptions"`
+	// Whether the language of new statuses posted
+	// without one is detected from their content,
+	// before falling back to the default language.
+	DetectLanguage bool `json:"detect_language"`
+	// Whether new statuses are local-only (not
+	// federated) by default, if not set explicitly.
+	LocalOnly bool `json:"local_only"`
+	// Whether new statuses posted without in_reply_to_id
+	// continue the account's thread by default, by
+	// replying to its most recent status.
+	ContinueThread bool `json:"continue_thread"`
+	// IDs of statuses that take turns being pinned
+	// to the profile, in order. Omitted if not set.
+	RotatingPinStatusIDs []string `json:"rotating_pin_status_ids,omitempty"`
+	// Number of seconds each status in rotating_pin_status_ids
+	// stays pinned before the next one takes over. 0 means
+	// rotation is turned off.
+	RotatingPinInterval int `json:"rotating_pin_interval"`
 	// Profile bio.
 	Note string `json:"note"`
 	// Metadata about the account.
@@ -46,6 +46,9 @@ type Status struct {
 	// Visibility of this status.
 	// example: unlisted
 	Visibility Visibility `json:"visibility"`
+	// IDs of the accounts in the chosen audience of a status with
+	// circle visibility (shown as private). Only shown to the author.
+	AudienceIDs []string `json:"audience_ids,omitempty"`
 	// Primary language of this status (ISO 639 Part 1 two-letter language code).
 	// Will be null if language is not known.
 	// example: en
@@ -178,8 +181,13 @@ type StatusCreateRequest struct {
 	Poll *PollRequest `form:"poll" json:"poll" xml:"poll"`
 	// ID of the status being replied to, if status is a reply.
 	InReplyToID string `form:"in_reply_to_id" json:"in_reply_to_id" xml:"in_reply_to_id"`
+	// Reply to the account's own most recent status, if in_reply_to_id
+	// is not set. Defaults to the account's continue_thread setting.
+	ContinueThread *bool `form:"continue_thread" json:"continue_thread" xml:"continue_thread"`
 	// Status and attached media should be marked as sensitive.
-	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
+	// If not set, statuses with media attached are marked as sensitive
+	// if the account's default_media_sensitive setting is on.
+	Sensitive *bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
 	// Text to be shown as a warning or subject before the actual content.
 	// Statuses are generally collapsed behind this field.
 	SpoilerText string `form:"spoiler_text" json:"spoiler_text" xml:"spoiler_text"`
@@ -193,6 +201,12 @@ type StatusCreateRequest struct {
 	Language string `form:"language" json:"language" xml:"language"`
 	// Content type to use when parsing this status.
 	ContentType StatusContentType `form:"content_type" json:"content_type" xml:"content_type"`
+	// Number of seconds after which this status should be automatically deleted.
+	// Overrides the account's default post expiry; 0 means the status won't expire.
+	ExpiresIn *int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+	// IDs of the followers to address a status with circle visibility to.
+	// Required for, and only allowed with, circle visibility.
+	AudienceIDs []string `form:"audience_ids[]" json:"audience_ids" xml:"audience_ids"`
 }
 
 // Visibility models the visibility of a status.
@@ -210,6 +224,8 @@ const (
 	VisibilityPrivate Visibility = "private"
 	// VisibilityMutualsOnly is visible only to mutual followers of the account that posted the status.
 	VisibilityMutualsOnly Visibility = "mutuals_only"
+	// VisibilityCircle is visible only to a chosen audience of followers of the account that posted the status.
+	VisibilityCircle Visibility = "circle"
 	// VisibilityDirect is visible only to accounts tagged in the status. It is equivalent to a direct message.
 	VisibilityDirect Visibility = "direct"
 )
@@ -221,6 +237,10 @@ const (
 type AdvancedStatusCreateForm struct {
 	StatusCreateRequest
 	AdvancedVisibilityFlagsForm
+
+	// IdempotencyKey is the value of the
+	// request's Idempotency-Key header, if set.
+	IdempotencyKey string `form:"-" json:"-" xml:"-"`
 }
 
 // AdvancedVisibilityFlagsForm allows a few more advanced flags to be set on new statuses, in addition
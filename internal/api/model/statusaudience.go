@@ -0,0 +1,48 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// StatusAudience represents the resolved federated delivery
+// audience of a draft status, ie., which remote accounts,
+// inboxes and domains the status would be delivered to if
+// it was posted now.
+//
+// swagger:model statusAudience
+type StatusAudience struct {
+	// Visibility the status would be posted with.
+	// example: private
+	Visibility Visibility `json:"visibility"`
+	// Whether the status would be federated at all.
+	// If false, the status would be local-only, and
+	// all other counts will be zero.
+	Federated bool `json:"federated"`
+	// Number of remote accounts the status would be delivered to.
+	Accounts int `json:"accounts"`
+	// Number of distinct inboxes the status would be delivered to.
+	Inboxes int `json:"inboxes"`
+	// Number of distinct domains the status would be delivered to.
+	Domains int `json:"domains"`
+	// Number of remote accounts that would otherwise be addressed,
+	// but which are excluded from delivery due to a block, domain
+	// block, or suspension.
+	Excluded int `json:"excluded"`
+	// Sample of the inboxes the status would be delivered to, sorted alphabetically.
+	InboxesSample []string `json:"inboxes_sample"`
+	// Sample of the domains the status would be delivered to, sorted alphabetically.
+	DomainsSample []string `json:"domains_sample"`
+}
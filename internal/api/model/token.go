@@ -32,4 +32,7 @@ type Token struct {
 	// When the OAuth token was generated (UNIX timestamp seconds).
 	// example: 1627644520
 	CreatedAt int64 `json:"created_at"`
+	// Refresh token which can be exchanged for a new access token
+	// and refresh token. Each refresh token can only be used once.
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
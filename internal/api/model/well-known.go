@@ -28,7 +28,7 @@ import "encoding/xml"
 type WellKnownResponse struct {
 	Subject string   `json:"subject,omitempty"`
 	Aliases []string `json:"aliases,omitempty"`
-	Links   []Link   `json:"links,omitempty"`
+	Links   []Link   `json:"links"`
 }
 
 // Link represents one 'link' in a slice of links returned from a lookup request.
@@ -89,12 +89,13 @@ type NodeInfoUsers struct {
 	Total int `json:"total"`
 }
 
-// HostMeta represents a hostmeta document.
+// HostMeta represents a hostmeta document, which can
+// be serialized as either XRD (XML) or JRD (JSON).
 // See: https://www.rfc-editor.org/rfc/rfc6415.html#section-3
 //
 // swagger:model hostmeta
 type HostMeta struct {
-	XMLName xml.Name `xml:"XRD"`
-	XMLNS   string   `xml:"xmlns,attr"`
-	Link    []Link   `xml:"Link"`
+	XMLName xml.Name `json:"-" xml:"XRD"`
+	XMLNS   string   `json:"-" xml:"xmlns,attr"`
+	Link    []Link   `json:"links" xml:"Link"`
 }
@@ -83,9 +83,16 @@ var ActivityPubHeaders = []string{
 	AppActivityJSON,
 }
 
+// HostMetaHeaders is a slice of offers that prefers the XRD
+// representation of host-meta, but will serve the JRD (JSON)
+// representation to callers that ask for it.
+//
+// https://www.rfc-editor.org/rfc/rfc6415.html#section-6.1
 var HostMetaHeaders = []string{
 	AppXMLXRD,
 	AppXML,
+	AppJRDJSON,
+	AppJSON,
 }
 
 // NegotiateAccept takes the *gin.Context from an incoming request, and a
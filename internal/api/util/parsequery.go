@@ -59,6 +59,11 @@ const (
 	SearchResolveKey           = "resolve"
 	SearchTypeKey              = "type"
 
+	/* Streaming keys */
+
+	RetroactiveFiltersKey      = "retroactive_filters"
+	NotificationBatchWindowKey = "notification_batch_window"
+
 	/* Tag keys */
 
 	TagNameKey = "tag_name"
@@ -154,6 +159,14 @@ func ParseSearchResolve(value string, defaultValue bool) (bool, gtserror.WithCod
 	return parseBool(value, defaultValue, SearchResolveKey)
 }
 
+func ParseRetroactiveFilters(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, RetroactiveFiltersKey)
+}
+
+func ParseNotificationBatchWindow(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, NotificationBatchWindowKey)
+}
+
 func ParseDomainPermissionExport(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, DomainPermissionExportKey)
 }
@@ -29,6 +29,9 @@ import (
 //
 // Returns a compliant hostmeta response to web host metadata queries.
 //
+// The XRD (XML) representation is returned by default, or the
+// JRD (JSON) representation if the Accept header prefers it.
+//
 // See: https://www.rfc-editor.org/rfc/rfc6415.html
 //
 //	---
@@ -36,26 +39,85 @@ import (
 //	- .well-known
 //
 //	produces:
-//	- application/xrd+xml"
+//	- application/xrd+xml
+//	- application/jrd+json
 //
 //	responses:
 //		'200':
 //			schema:
 //				"$ref": "#/definitions/hostmeta"
+//		'406':
+//			description: not acceptable
 func (m *Module) HostMetaGETHandler(c *gin.Context) {
-	if _, err := apiutil.NegotiateAccept(c, apiutil.HostMetaHeaders...); err != nil {
+	contentType, err := apiutil.NegotiateAccept(c, apiutil.HostMetaHeaders...)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Representation depends
+	// on the Accept header.
+	c.Writer.Header().Add("Vary", "Accept")
+
+	hostMeta := m.processor.Fedi().HostMetaGet()
+
+	switch contentType {
+	case apiutil.AppJRDJSON, apiutil.AppJSON:
+		// Encode JSON HTTP response.
+		apiutil.EncodeJSONResponse(
+			c.Writer,
+			c.Request,
+			http.StatusOK,
+			contentType,
+			hostMeta,
+		)
+	default:
+		// Encode XML HTTP response.
+		apiutil.EncodeXMLResponse(
+			c.Writer,
+			c.Request,
+			http.StatusOK,
+			HostMetaContentType,
+			hostMeta,
+		)
+	}
+}
+
+// HostMetaJSONGETHandler swagger:operation GET /.well-known/host-meta.json hostMetaJSONGet
+//
+// Returns the JRD (JSON) representation of the hostmeta document.
+//
+// See: https://www.rfc-editor.org/rfc/rfc6415.html#section-6.2
+//
+//	---
+//	tags:
+//	- .well-known
+//
+//	produces:
+//	- application/jrd+json
+//	- application/json
+//
+//	responses:
+//		'200':
+//			schema:
+//				"$ref": "#/definitions/hostmeta"
+//		'406':
+//			description: not acceptable
+func (m *Module) HostMetaJSONGETHandler(c *gin.Context) {
+	contentType, err := apiutil.NegotiateAccept(c, apiutil.WebfingerJSONAcceptHeaders...)
+	if err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
 	}
 
 	hostMeta := m.processor.Fedi().HostMetaGet()
 
-	// Encode XML HTTP response.
-	apiutil.EncodeXMLResponse(
+	// Encode JSON HTTP response.
+	apiutil.EncodeJSONResponse(
 		c.Writer,
 		c.Request,
 		http.StatusOK,
-		HostMetaContentType,
+		contentType,
 		hostMeta,
 	)
 }
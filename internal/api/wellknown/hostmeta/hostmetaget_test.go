@@ -0,0 +1,158 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hostmeta_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/api/wellknown/hostmeta"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type HostMetaGetTestSuite struct {
+	suite.Suite
+	db        db.DB
+	state     state.State
+	storage   *storage.Driver
+	processor *processing.Processor
+
+	hostMetaModule *hostmeta.Module
+}
+
+func (suite *HostMetaGetTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+
+	testrig.InitTestLog()
+	testrig.InitTestConfig()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+
+	testrig.StartTimelines(
+		&suite.state,
+		visibility.NewFilter(&suite.state),
+		typeutils.NewConverter(&suite.state),
+	)
+
+	suite.storage = testrig.NewInMemoryStorage()
+	suite.state.Storage = suite.storage
+	mediaManager := testrig.NewTestMediaManager(&suite.state)
+	federator := testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../../../testrig/media")), mediaManager)
+	emailSender := testrig.NewEmailSender("../../../../web/template/", nil)
+	suite.processor = testrig.NewTestProcessor(&suite.state, federator, emailSender, mediaManager)
+	suite.hostMetaModule = hostmeta.New(suite.processor)
+}
+
+func (suite *HostMetaGetTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StopWorkers(&suite.state)
+}
+
+// hostMeta calls the given handler with the given Accept header
+// (if any), and returns the status code, content type, and body.
+func (suite *HostMetaGetTestSuite) hostMeta(
+	handler gin.HandlerFunc,
+	requestPath string,
+	accept string,
+) (int, string, string) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, requestPath, nil)
+	if accept != "" {
+		ctx.Request.Header.Set("accept", accept)
+	}
+
+	handler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return result.StatusCode, result.Header.Get("content-type"), string(b)
+}
+
+const (
+	expectedHostMetaXML = `<?xml version="1.0" encoding="UTF-8"?>
+<XRD xmlns="http://docs.oasis-open.org/ns/xri/xrd-1.0"><Link rel="lrdd" type="application/xrd+xml" template="http://localhost:8080/.well-known/webfinger?resource={uri}"></Link></XRD>`
+	expectedHostMetaJSON = `{"links":[{"rel":"lrdd","type":"application/xrd+xml","template":"http://localhost:8080/.well-known/webfinger?resource={uri}"}]}`
+)
+
+func (suite *HostMetaGetTestSuite) TestHostMetaDefaultsToXML() {
+	code, contentType, body := suite.hostMeta(suite.hostMetaModule.HostMetaGETHandler, "/.well-known/host-meta", "")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(hostmeta.HostMetaContentType, contentType)
+	suite.Equal(expectedHostMetaXML, body)
+}
+
+func (suite *HostMetaGetTestSuite) TestHostMetaXML() {
+	for _, accept := range []string{
+		apiutil.AppXMLXRD,
+		apiutil.AppXML,
+		"*/*",
+	} {
+		code, contentType, body := suite.hostMeta(suite.hostMetaModule.HostMetaGETHandler, "/.well-known/host-meta", accept)
+		suite.Equal(http.StatusOK, code, accept)
+		suite.Equal(hostmeta.HostMetaContentType, contentType, accept)
+		suite.Equal(expectedHostMetaXML, body, accept)
+	}
+}
+
+func (suite *HostMetaGetTestSuite) TestHostMetaJSONByAccept() {
+	for _, accept := range []string{
+		apiutil.AppJRDJSON,
+		apiutil.AppJSON,
+	} {
+		code, contentType, body := suite.hostMeta(suite.hostMetaModule.HostMetaGETHandler, "/.well-known/host-meta", accept)
+		suite.Equal(http.StatusOK, code, accept)
+		suite.Equal(accept, contentType, accept)
+		suite.Equal(expectedHostMetaJSON, body, accept)
+	}
+}
+
+func (suite *HostMetaGetTestSuite) TestHostMetaJSONPath() {
+	code, contentType, body := suite.hostMeta(suite.hostMetaModule.HostMetaJSONGETHandler, "/.well-known/host-meta.json", "")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(hostmeta.HostMetaJSONContentType, contentType)
+	suite.Equal(expectedHostMetaJSON, body)
+}
+
+func (suite *HostMetaGetTestSuite) TestHostMetaJSONPathNotAcceptable() {
+	code, _, _ := suite.hostMeta(suite.hostMetaModule.HostMetaJSONGETHandler, "/.well-known/host-meta.json", apiutil.AppXMLXRD)
+	suite.Equal(http.StatusNotAcceptable, code)
+}
+
+func TestHostMetaGetTestSuite(t *testing.T) {
+	suite.Run(t, new(HostMetaGetTestSuite))
+}
@@ -21,6 +21,9 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -32,14 +35,26 @@ const (
 
 type Module struct {
 	processor *processing.Processor
+
+	// rateLimit limits webfinger requests per remote
+	// IP, separately from the general rate limit, as
+	// webfinger is unauthenticated and hit by remotes
+	// in bursts whenever they look up local accounts.
+	rateLimit gin.HandlerFunc
 }
 
 func New(processor *processing.Processor) *Module {
 	return &Module{
 		processor: processor,
+		rateLimit: middleware.TokenBucketRateLimit(
+			config.GetAdvancedWebfingerRateLimitRequests(),
+			config.GetAdvancedWebfingerRateLimitBurst(),
+			config.GetAdvancedRateLimitExceptions(),
+			metrics.WebfingerThrottled,
+		),
 	}
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, WebfingerBasePath, m.WebfingerGETRequest)
+	attachHandler(http.MethodGet, WebfingerBasePath, m.rateLimit, m.WebfingerGETRequest)
 }
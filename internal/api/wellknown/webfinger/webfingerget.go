@@ -18,11 +18,20 @@
 package webfinger
 
 import (
+	"bytes"
+	// nolint:gosec
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -41,6 +50,12 @@ import (
 //
 // ```
 //
+// Links can be limited to only certain link relations by passing one or more `rel` query parameters.
+//
+// The instance actor, which signs requests made on behalf of the instance itself, can also be looked up with the bare domain of the instance as resource, eg. `https://goblin.technology` or `acct:goblin.technology`.
+//
+// Responses include `ETag` and `Last-Modified` headers, and conditional requests using `If-None-Match` or `If-Modified-Since` get an empty `304 Not Modified` response if the account hasn't changed since.
+//
 // See: https://webfinger.net/
 //
 //	---
@@ -50,10 +65,28 @@ import (
 //	produces:
 //	- application/jrd+json
 //
+//	parameters:
+//	-
+//		name: resource
+//		type: string
+//		description: The resource to look up, eg. `acct:tobi@goblin.technology`, or the bare domain of the instance for the instance actor.
+//		in: query
+//		required: true
+//	-
+//		name: rel
+//		type: array
+//		items:
+//			type: string
+//		collectionFormat: multi
+//		description: Only include links with the given link relation(s) in the response.
+//		in: query
+//
 //	responses:
 //		'200':
 //			schema:
 //				"$ref": "#/definitions/wellKnownResponse"
+//		'304':
+//			description: Not modified since the version given in If-None-Match or If-Modified-Since.
 func (m *Module) WebfingerGETRequest(c *gin.Context) {
 	if _, err := apiutil.NegotiateAccept(c, apiutil.WebfingerJSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
@@ -67,11 +100,22 @@ func (m *Module) WebfingerGETRequest(c *gin.Context) {
 		return
 	}
 
-	requestedUsername, requestedHost, err := util.ExtractWebfingerParts(resourceQuery)
-	if err != nil {
-		err := fmt.Errorf("bad webfinger request with resource query %s: %w", resourceQuery, err)
-		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
-		return
+	var (
+		requestedUsername string
+		requestedHost     string
+		err               error
+	)
+	if host, ok := instanceResource(resourceQuery); ok {
+		// Bare domain, look up the instance actor,
+		// whose username is the host of the instance.
+		requestedUsername, requestedHost = config.GetHost(), host
+	} else {
+		requestedUsername, requestedHost, err = util.ExtractWebfingerParts(resourceQuery)
+		if err != nil {
+			err := fmt.Errorf("bad webfinger request with resource query %s: %w", resourceQuery, err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
 	}
 
 	if requestedHost != config.GetHost() && requestedHost != config.GetAccountDomain() {
@@ -80,18 +124,140 @@ func (m *Module) WebfingerGETRequest(c *gin.Context) {
 		return
 	}
 
-	resp, errWithCode := m.processor.Fedi().WebfingerGet(c.Request.Context(), requestedUsername)
+	// Clients may request only certain link
+	// relations, by giving one or more rel.
+	rels := c.QueryArray("rel")
+
+	resp, lastModified, errWithCode := m.processor.Fedi().WebfingerGet(c.Request.Context(), requestedUsername, rels)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
-	// Encode JSON HTTP response.
-	apiutil.EncodeJSONResponse(
+	// Encode the response up front,
+	// so we can derive the ETag from it.
+	b, err := encodeJRD(resp)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Set 'ETag' and 'Last-Modified' headers no matter
+	// what, as caller may want to cache these even if
+	// we return 304 below. 'Cache-Control' is set by the
+	// .well-known group middleware.
+	eTag := generateETag(b)
+	c.Header("ETag", eTag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c.Request, eTag, lastModified) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+
+	apiutil.WriteResponseBytes(
 		c.Writer,
 		c.Request,
 		http.StatusOK,
 		apiutil.AppJRDJSON,
-		resp,
+		b,
 	)
 }
+
+// encodeJRD encodes the given webfinger response
+// the same way as apiutil.EncodeJSONResponse does.
+func encodeJRD(resp *apimodel.WellKnownResponse) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(resp); err != nil {
+		return nil, gtserror.Newf("error encoding webfinger response: %w", err)
+	}
+
+	// Drop new-line added by encoder.
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
+// generateETag returns a strong ETag for the given
+// encoded response body, which changes whenever
+// anything in the response does.
+func generateETag(b []byte) string {
+	// nolint:gosec
+	sum := sha1.Sum(b)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// notModified returns whether the request is conditional, and the
+// caller already has the latest version of the response, as per
+// https://www.rfc-editor.org/rfc/rfc9110#section-13.2.2. If-None-Match
+// takes precedence over If-Modified-Since where both are given.
+func notModified(r *http.Request, eTag string, lastModified time.Time) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, tag := range strings.Split(ifNoneMatch, ",") {
+			// Weak comparison, as per RFC.
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == eTag {
+				return true
+			}
+		}
+		return false
+	}
+
+	ifModifiedSince := r.Header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		// Invalid dates are ignored.
+		return false
+	}
+
+	// HTTP dates only have second precision.
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// instanceResource returns the host of the given
+// webfinger resource if it's a bare domain, like
+// "https://example.org" or "acct:example.org",
+// which is taken to refer to the instance actor.
+// The instance actor's own subject, of the form
+// "acct:example.org@example.org", is also accepted,
+// as its username (the host) isn't a valid username.
+func instanceResource(resource string) (string, bool) {
+	u, err := url.Parse(resource)
+	if err != nil {
+		return "", false
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		if u.Host == "" || u.User != nil ||
+			(u.Path != "" && u.Path != "/") ||
+			u.RawQuery != "" || u.Fragment != "" {
+			return "", false
+		}
+		return u.Host, true
+
+	case "acct":
+		if u.Opaque == "" {
+			return "", false
+		}
+
+		user, host, found := strings.Cut(u.Opaque, "@")
+		if !found {
+			return u.Opaque, true
+		}
+
+		if user == "" || user != host {
+			return "", false
+		}
+		return host, true
+	}
+
+	return "", false
+}
@@ -27,6 +27,8 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -78,6 +80,25 @@ func (suite *WebfingerGetTestSuite) finger(requestPath string) string {
 	return dst.String()
 }
 
+// fingerConditional fingers the given path with the
+// given request headers set, returning the response.
+func (suite *WebfingerGetTestSuite) fingerConditional(requestPath string, headers map[string]string) *http.Response {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, requestPath, nil)
+	ctx.Request.Header.Set("accept", "application/jrd+json")
+	for k, v := range headers {
+		ctx.Request.Header.Set(k, v)
+	}
+
+	suite.webfingerModule.WebfingerGETRequest(ctx)
+
+	// Flush any status written
+	// by AbortWithStatus.
+	ctx.Writer.WriteHeaderNow()
+	return recorder.Result()
+}
+
 func (suite *WebfingerGetTestSuite) funkifyAccountDomain(host string, accountDomain string) *gtsmodel.Account {
 	// Reset suite structs + config
 	// to new host + account domain.
@@ -150,6 +171,42 @@ func (suite *WebfingerGetTestSuite) TestFingerUser() {
 }`, resp)
 }
 
+func (suite *WebfingerGetTestSuite) TestFingerUserPreviousUsernames() {
+	targetAccount := new(gtsmodel.Account)
+	*targetAccount = *suite.testAccounts["local_account_1"]
+
+	// Give the account a couple of old handles.
+	targetAccount.PreviousUsernames = []string{"zork", "the_mini_zork"}
+	if err := suite.db.UpdateAccount(context.Background(), targetAccount, "previous_usernames"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	requestPath := fmt.Sprintf("/%s?resource=acct:%s@%s", webfinger.WebfingerBasePath, targetAccount.Username, config.GetHost())
+
+	resp := suite.finger(requestPath)
+	suite.Equal(`{
+  "subject": "acct:the_mighty_zork@localhost:8080",
+  "aliases": [
+    "http://localhost:8080/users/the_mighty_zork",
+    "http://localhost:8080/@the_mighty_zork",
+    "acct:zork@localhost:8080",
+    "acct:the_mini_zork@localhost:8080"
+  ],
+  "links": [
+    {
+      "rel": "http://webfinger.net/rel/profile-page",
+      "type": "text/html",
+      "href": "http://localhost:8080/@the_mighty_zork"
+    },
+    {
+      "rel": "self",
+      "type": "application/activity+json",
+      "href": "http://localhost:8080/users/the_mighty_zork"
+    }
+  ]
+}`, resp)
+}
+
 func (suite *WebfingerGetTestSuite) TestFingerUserActorURI() {
 	targetAccount := suite.testAccounts["local_account_1"]
 	host := config.GetHost()
@@ -269,6 +326,218 @@ func (suite *WebfingerGetTestSuite) TestFingerUserWithoutAcct() {
 }`, resp)
 }
 
+func (suite *WebfingerGetTestSuite) TestFingerUserCached() {
+	targetAccount := suite.testAccounts["local_account_1"]
+	cache := &suite.state.Caches.WebfingerResponse
+
+	// Counts carry over from other tests
+	// in the suite, so compare against
+	// those from before we start.
+	misses, hits := cache.Misses(), cache.Hits()
+
+	// Finger the account with different case + host
+	// variants, these should all share a cache entry.
+	for _, resource := range []string{
+		"acct:" + targetAccount.Username + "@" + config.GetHost(),
+		"acct:" + strings.ToUpper(targetAccount.Username) + "@" + config.GetHost(),
+		targetAccount.Username + "@" + config.GetAccountDomain(),
+	} {
+		resp := suite.finger("/" + webfinger.WebfingerBasePath + "?resource=" + resource)
+		suite.Contains(resp, `"subject": "acct:the_mighty_zork@localhost:8080"`)
+	}
+
+	suite.EqualValues(1, cache.Misses()-misses)
+	suite.EqualValues(2, cache.Hits()-hits)
+
+	// Rename the account; the cached
+	// response should be invalidated.
+	renamed := new(gtsmodel.Account)
+	*renamed = *targetAccount
+	renamed.Username = "the_mightier_zork"
+	if err := suite.db.UpdateAccount(context.Background(), renamed, "username"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	resp := suite.finger("/" + webfinger.WebfingerBasePath + "?resource=acct:" + renamed.Username + "@" + config.GetHost())
+	suite.Contains(resp, `"subject": "acct:the_mightier_zork@localhost:8080"`)
+
+	_, _, ok := cache.Get("acct:the_mighty_zork@" + config.GetAccountDomain())
+	suite.False(ok)
+}
+
+func (suite *WebfingerGetTestSuite) TestFingerUserConditional() {
+	targetAccount := suite.testAccounts["local_account_1"]
+	requestPath := "/" + webfinger.WebfingerBasePath + "?resource=acct:" + targetAccount.Username + "@" + config.GetHost()
+
+	// Plain request gets the full response
+	// with ETag and Last-Modified headers.
+	result := suite.fingerConditional(requestPath, nil)
+	defer result.Body.Close()
+	suite.Equal(http.StatusOK, result.StatusCode)
+	eTag := result.Header.Get("ETag")
+	lastModified := result.Header.Get("Last-Modified")
+	suite.NotEmpty(eTag)
+	suite.Equal(targetAccount.UpdatedAt.UTC().Format(http.TimeFormat), lastModified)
+
+	// Matching ETag gets 304 with no body.
+	result = suite.fingerConditional(requestPath, map[string]string{"If-None-Match": eTag})
+	defer result.Body.Close()
+	suite.Equal(http.StatusNotModified, result.StatusCode)
+	suite.Equal(eTag, result.Header.Get("ETag"))
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(b)
+
+	// As does an unchanged modification time.
+	result = suite.fingerConditional(requestPath, map[string]string{"If-Modified-Since": lastModified})
+	defer result.Body.Close()
+	suite.Equal(http.StatusNotModified, result.StatusCode)
+
+	// A different ETag gets the full response,
+	// and takes precedence over If-Modified-Since.
+	result = suite.fingerConditional(requestPath, map[string]string{
+		"If-None-Match":     `"nope"`,
+		"If-Modified-Since": lastModified,
+	})
+	defer result.Body.Close()
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	// Update the account in a way
+	// that changes the response.
+	updated := new(gtsmodel.Account)
+	*updated = *targetAccount
+	updated.URL = "http://localhost:8080/@the_mighty_zork_updated"
+	if err := suite.db.UpdateAccount(context.Background(), updated, "url"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Old ETag now gets the full
+	// response, with a new ETag.
+	result = suite.fingerConditional(requestPath, map[string]string{"If-None-Match": eTag})
+	defer result.Body.Close()
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.NotEmpty(result.Header.Get("ETag"))
+	suite.NotEqual(eTag, result.Header.Get("ETag"))
+	b, err = io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Contains(string(b), updated.URL)
+}
+
+func (suite *WebfingerGetTestSuite) TestFingerUserRel() {
+	targetAccount := suite.testAccounts["local_account_1"]
+	requestPath := fmt.Sprintf("/%s?resource=acct:%s@%s&rel=self", webfinger.WebfingerBasePath, targetAccount.Username, config.GetHost())
+
+	resp := suite.finger(requestPath)
+	suite.Equal(`{
+  "subject": "acct:the_mighty_zork@localhost:8080",
+  "aliases": [
+    "http://localhost:8080/users/the_mighty_zork",
+    "http://localhost:8080/@the_mighty_zork"
+  ],
+  "links": [
+    {
+      "rel": "self",
+      "type": "application/activity+json",
+      "href": "http://localhost:8080/users/the_mighty_zork"
+    }
+  ]
+}`, resp)
+
+	// Filtering shouldn't have touched
+	// the cached response, so a request
+	// without rel should get all links.
+	requestPath = fmt.Sprintf("/%s?resource=acct:%s@%s", webfinger.WebfingerBasePath, targetAccount.Username, config.GetHost())
+	resp = suite.finger(requestPath)
+	suite.Contains(resp, `"rel": "http://webfinger.net/rel/profile-page"`)
+	suite.Contains(resp, `"rel": "self"`)
+}
+
+func (suite *WebfingerGetTestSuite) TestFingerUserMultipleRels() {
+	targetAccount := suite.testAccounts["local_account_1"]
+	requestPath := fmt.Sprintf("/%s?resource=acct:%s@%s&rel=self&rel=%s&rel=http://ostatus.org/schema/1.0/subscribe", webfinger.WebfingerBasePath, targetAccount.Username, config.GetHost(), url.QueryEscape("http://webfinger.net/rel/profile-page"))
+
+	resp := suite.finger(requestPath)
+	suite.Equal(`{
+  "subject": "acct:the_mighty_zork@localhost:8080",
+  "aliases": [
+    "http://localhost:8080/users/the_mighty_zork",
+    "http://localhost:8080/@the_mighty_zork"
+  ],
+  "links": [
+    {
+      "rel": "http://webfinger.net/rel/profile-page",
+      "type": "text/html",
+      "href": "http://localhost:8080/@the_mighty_zork"
+    },
+    {
+      "rel": "self",
+      "type": "application/activity+json",
+      "href": "http://localhost:8080/users/the_mighty_zork"
+    }
+  ]
+}`, resp)
+}
+
+func (suite *WebfingerGetTestSuite) TestFingerUserUnknownRel() {
+	targetAccount := suite.testAccounts["local_account_1"]
+	requestPath := fmt.Sprintf("/%s?resource=acct:%s@%s&rel=http://ostatus.org/schema/1.0/subscribe", webfinger.WebfingerBasePath, targetAccount.Username, config.GetHost())
+
+	resp := suite.finger(requestPath)
+	suite.Equal(`{
+  "subject": "acct:the_mighty_zork@localhost:8080",
+  "aliases": [
+    "http://localhost:8080/users/the_mighty_zork",
+    "http://localhost:8080/@the_mighty_zork"
+  ],
+  "links": []
+}`, resp)
+}
+
+func (suite *WebfingerGetTestSuite) TestFingerInstanceActor() {
+	host := config.GetHost()
+
+	for _, resource := range []string{
+		"https://" + host,
+		"https://" + host + "/",
+		"acct:" + host,
+		"acct:" + host + "@" + host,
+	} {
+		resource := resource
+		suite.Run(resource, func() {
+			requestPath := fmt.Sprintf("/%s?resource=%s&rel=self", webfinger.WebfingerBasePath, resource)
+			resp := suite.finger(requestPath)
+			suite.Equal(`{
+  "subject": "acct:localhost:8080@localhost:8080",
+  "aliases": [
+    "http://localhost:8080/users/localhost:8080",
+    "http://localhost:8080/@localhost:8080"
+  ],
+  "links": [
+    {
+      "rel": "self",
+      "type": "application/activity+json",
+      "href": "http://localhost:8080/users/localhost:8080"
+    }
+  ]
+}`, resp)
+		})
+	}
+}
+
+func (suite *WebfingerGetTestSuite) TestFingerOtherDomain() {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/"+webfinger.WebfingerBasePath+"?resource=https://example.org", nil)
+	ctx.Request.Header.Set("accept", "application/jrd+json")
+
+	suite.webfingerModule.WebfingerGETRequest(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
 func TestWebfingerGetTestSuite(t *testing.T) {
 	suite.Run(t, new(WebfingerGetTestSuite))
 }
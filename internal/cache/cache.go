@@ -20,6 +20,7 @@ package cache
 import (
 	"time"
 
+	"codeberg.org/gruf/go-cache/v3/ttl"
 	"github.com/superseriousbusiness/gotosocial/internal/cache/headerfilter"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -42,6 +43,16 @@ type Caches struct {
 	// cache. (used by the visibility filter).
 	Visibility VisibilityCache
 
+	// StatusIdempotency provides access to the cache of
+	// status creation Idempotency-Key results, keyed by
+	// account ID + key. (used by the status processor).
+	StatusIdempotency *ttl.Cache[string, Idempotency] // TTL=1hr, sweep=5min
+
+	// WebfingerResponse provides access to the cache of
+	// webfinger responses served for local accounts, keyed
+	// by normalized acct: URI. (used by the fedi processor).
+	WebfingerResponse WebfingerResponseCache // TTL=configured, sweep=1min
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -62,6 +73,7 @@ func (c *Caches) Init() {
 	c.initClient()
 	c.initDomainAllow()
 	c.initDomainBlock()
+	c.initDomainSensitive()
 	c.initEmoji()
 	c.initEmojiCategory()
 	c.initFilter()
@@ -98,6 +110,8 @@ func (c *Caches) Init() {
 	c.initUserMuteIDs()
 	c.initWebfinger()
 	c.initVisibility()
+	c.initStatusIdempotency()
+	c.initWebfingerResponse()
 }
 
 // Start will start any caches that require a background
@@ -108,6 +122,14 @@ func (c *Caches) Start() {
 	tryUntil("starting webfinger cache", 5, func() bool {
 		return c.GTS.Webfinger.Start(5 * time.Minute)
 	})
+
+	tryUntil("starting status idempotency cache", 5, func() bool {
+		return c.StatusIdempotency.Start(5 * time.Minute)
+	})
+
+	tryUntil("starting webfinger response cache", 5, func() bool {
+		return c.WebfingerResponse.Start(time.Minute)
+	})
 }
 
 // Stop will stop any caches that require a background
@@ -116,6 +138,8 @@ func (c *Caches) Stop() {
 	log.Infof(nil, "stop: %p", c)
 
 	tryUntil("stopping webfinger cache", 5, c.GTS.Webfinger.Stop)
+	tryUntil("stopping status idempotency cache", 5, c.StatusIdempotency.Stop)
+	tryUntil("stopping webfinger response cache", 5, c.WebfingerResponse.Stop)
 }
 
 // Sweep will sweep all the available caches to ensure none
@@ -62,6 +62,9 @@ type GTSCaches struct {
 	// DomainBlock provides access to the domain block database cache.
 	DomainBlock *domain.Cache
 
+	// DomainSensitive provides access to the domain sensitive database cache.
+	DomainSensitive *domain.Cache
+
 	// Emoji provides access to the gtsmodel Emoji database cache.
 	Emoji StructCache[*gtsmodel.Emoji]
 
@@ -431,6 +434,10 @@ func (c *Caches) initDomainBlock() {
 	c.GTS.DomainBlock = new(domain.Cache)
 }
 
+func (c *Caches) initDomainSensitive() {
+	c.GTS.DomainSensitive = new(domain.Cache)
+}
+
 func (c *Caches) initEmoji() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1092,6 +1099,7 @@ func (c *Caches) initStatus() {
 		s2.Attachments = nil
 		s2.Tags = nil
 		s2.Mentions = nil
+		s2.Audience = nil
 		s2.Emojis = nil
 		s2.CreatedWithApplication = nil
 
@@ -0,0 +1,60 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3/ttl"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// idempotencyCacheMax is the max number
+	// of idempotency keys to keep cached.
+	idempotencyCacheMax = 10000
+
+	// idempotencyCacheTTL is how long idempotency keys
+	// are remembered for, matching Mastodon's behaviour.
+	idempotencyCacheTTL = time.Hour
+)
+
+// Idempotency is the result of a request made with a
+// client-provided Idempotency-Key header, cached so
+// retries of the request can return the same result.
+type Idempotency struct {
+	// ResultID is the ID of the item
+	// created by the original request.
+	ResultID string
+
+	// PayloadHash is a hash of the original request
+	// payload, used to detect reuse of a key with a
+	// different payload (most likely a client bug).
+	PayloadHash string
+}
+
+func (c *Caches) initStatusIdempotency() {
+	log.Infof(nil, "cache size = %d", idempotencyCacheMax)
+
+	c.StatusIdempotency = new(ttl.Cache[string, Idempotency])
+	c.StatusIdempotency.Init(
+		0,
+		idempotencyCacheMax,
+		idempotencyCacheTTL,
+	)
+}
@@ -58,6 +58,10 @@ func (c *Caches) OnInvalidateAccount(account *gtsmodel.Account) {
 	// Invalidate this account's Move(s).
 	c.GTS.Move.Invalidate("OriginURI", account.URI)
 	c.GTS.Move.Invalidate("TargetURI", account.URI)
+
+	// Invalidate served webfinger response, in
+	// case the username or domain has changed.
+	c.WebfingerResponse.Invalidate(account.ID)
 }
 
 func (c *Caches) OnInvalidateApplication(app *gtsmodel.Application) {
@@ -223,6 +223,7 @@ func sizeofAccount() uintptr {
 		DisplayName:             exampleUsername,
 		Note:                    exampleText,
 		NoteRaw:                 exampleText,
+		Pronouns:                exampleUsername,
 		Memorial:                func() *bool { ok := false; return &ok }(),
 		CreatedAt:               exampleTime,
 		UpdatedAt:               exampleTime,
@@ -259,16 +260,30 @@ func sizeofAccountNote() uintptr {
 
 func sizeofAccountSettings() uintptr {
 	return uintptr(size.Of(&gtsmodel.AccountSettings{
-		AccountID:         exampleID,
-		CreatedAt:         exampleTime,
-		UpdatedAt:         exampleTime,
-		Privacy:           gtsmodel.VisibilityFollowersOnly,
-		Sensitive:         util.Ptr(true),
-		Language:          "fr",
-		StatusContentType: "text/plain",
-		CustomCSS:         exampleText,
-		EnableRSS:         util.Ptr(true),
-		HideCollections:   util.Ptr(false),
+		AccountID:                exampleID,
+		CreatedAt:                exampleTime,
+		UpdatedAt:                exampleTime,
+		Privacy:                  gtsmodel.VisibilityFollowersOnly,
+		Sensitive:                util.Ptr(true),
+		DefaultMediaSensitive:    util.Ptr(true),
+		Language:                 "fr",
+		DetectLanguage:           util.Ptr(true),
+		LocalOnly:                util.Ptr(true),
+		ContinueThread:           util.Ptr(true),
+		StatusContentType:        "text/plain",
+		BioContentType:           "text/plain",
+		CustomCSS:                exampleText,
+		EnableRSS:                util.Ptr(true),
+		HideCollections:          util.Ptr(false),
+		HideWebProfile:           util.Ptr(false),
+		AwayEnabled:              util.Ptr(true),
+		AwayMessage:              exampleTextSmall,
+		AwayStartsAt:             exampleTime,
+		AwayEndsAt:               exampleTime,
+		AwayAutoReply:            util.Ptr(true),
+		RequireMediaDescriptions: util.Ptr(true),
+		RotatingPinStatusIDs:     []string{exampleID, exampleID, exampleID},
+		RotatingPinInterval:      24 * time.Hour,
 	}))
 }
 
@@ -310,12 +325,12 @@ func sizeofBlock() uintptr {
 
 func sizeofClient() uintptr {
 	return uintptr(size.Of(&gtsmodel.Client{
-		ID:        exampleID,
-		CreatedAt: exampleTime,
-		UpdatedAt: exampleTime,
-		Secret:    exampleID,
-		Domain:    exampleURI,
-		UserID:    exampleID,
+		ID:           exampleID,
+		CreatedAt:    exampleTime,
+		UpdatedAt:    exampleTime,
+		Secret:       exampleID,
+		RedirectURIs: []string{exampleURI},
+		UserID:       exampleID,
 	}))
 }
 
@@ -360,6 +375,7 @@ func sizeofFilter() uintptr {
 		AccountID: exampleID,
 		Title:     exampleTextSmall,
 		Action:    gtsmodel.FilterActionHide,
+		Mode:      gtsmodel.FilterModeBlock,
 	}))
 }
 
@@ -664,6 +680,11 @@ func sizeofToken() uintptr {
 		Refresh:             "", // TODO: clients don't really support this very well yet
 		RefreshCreateAt:     exampleTime,
 		RefreshExpiresAt:    exampleTime,
+		RefreshConsumedAt:   exampleTime,
+		FamilyID:            exampleID,
+		ReadOnly:            util.Ptr(false),
+		DeviceName:          "Firefox on Linux",
+		LastUsedAt:          exampleTime,
 	}))
 }
 
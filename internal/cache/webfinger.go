@@ -0,0 +1,173 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3/ttl"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// WebfingerResponseCache caches webfinger responses served
+// for local accounts, keyed by normalized acct: URI. Cache
+// hits and misses are counted so that operators can tune
+// the TTL. A zero TTL disables the cache entirely.
+type WebfingerResponseCache struct {
+	cache *ttl.Cache[string, webfingerResponse]
+
+	// keys maps account IDs to the
+	// key of their cached response,
+	// for invalidation by account.
+	keys   map[string]string
+	keysMu sync.Mutex
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+type webfingerResponse struct {
+	accountID    string
+	response     *apimodel.WellKnownResponse
+	lastModified time.Time
+}
+
+func (c *Caches) initWebfingerResponse() {
+	ttl := config.GetCacheWebfingerResponseTTL()
+	max := config.GetCacheWebfingerResponseMax()
+
+	log.Infof(nil, "cache size = %d, ttl = %s", max, ttl)
+
+	c.WebfingerResponse.init(ttl, max)
+}
+
+func (c *WebfingerResponseCache) init(t time.Duration, max int) {
+	c.keys = make(map[string]string)
+
+	if t <= 0 || max <= 0 {
+		// Disabled.
+		c.cache = nil
+		return
+	}
+
+	c.cache = new(ttl.Cache[string, webfingerResponse])
+	c.cache.Init(0, max, t)
+
+	// Drop account key mapping when
+	// response is evicted / invalidated.
+	drop := func(key string, v webfingerResponse) {
+		c.keysMu.Lock()
+		if c.keys[v.accountID] == key {
+			delete(c.keys, v.accountID)
+		}
+		c.keysMu.Unlock()
+	}
+	c.cache.SetEvictionCallback(drop)
+	c.cache.SetInvalidateCallback(drop)
+}
+
+// Start starts the cache sweeping routine, if enabled.
+func (c *WebfingerResponseCache) Start(freq time.Duration) bool {
+	if c.cache == nil {
+		return true
+	}
+	return c.cache.Start(freq)
+}
+
+// Stop stops the cache sweeping routine, if enabled.
+func (c *WebfingerResponseCache) Stop() bool {
+	if c.cache == nil {
+		return true
+	}
+	return c.cache.Stop()
+}
+
+// Get returns the cached webfinger response, and the
+// time its account was last modified, under the given
+// normalized acct: URI key, counting it as a cache hit
+// or miss.
+func (c *WebfingerResponseCache) Get(key string) (*apimodel.WellKnownResponse, time.Time, bool) {
+	if c.cache == nil {
+		return nil, time.Time{}, false
+	}
+
+	v, ok := c.cache.Get(key)
+	if !ok {
+		c.misses.Add(1)
+		return nil, time.Time{}, false
+	}
+
+	c.hits.Add(1)
+	return v.response, v.lastModified, true
+}
+
+// Set caches the given webfinger response for account
+// with ID, last modified at the given time, under the
+// given normalized acct: URI key.
+func (c *WebfingerResponseCache) Set(key string, accountID string, response *apimodel.WellKnownResponse, lastModified time.Time) {
+	if c.cache == nil {
+		return
+	}
+
+	c.keysMu.Lock()
+	oldKey, ok := c.keys[accountID]
+	c.keys[accountID] = key
+	c.keysMu.Unlock()
+
+	if ok && oldKey != key {
+		// Account changed, drop the old
+		// response (eg., under old username).
+		c.cache.Invalidate(oldKey)
+	}
+
+	c.cache.Set(key, webfingerResponse{
+		accountID:    accountID,
+		response:     response,
+		lastModified: lastModified,
+	})
+}
+
+// Invalidate drops any cached webfinger
+// response for the account with given ID.
+func (c *WebfingerResponseCache) Invalidate(accountID string) {
+	if c.cache == nil {
+		return
+	}
+
+	c.keysMu.Lock()
+	key, ok := c.keys[accountID]
+	c.keysMu.Unlock()
+
+	if ok {
+		c.cache.Invalidate(key)
+	}
+}
+
+// Hits returns the number of cache hits so far.
+func (c *WebfingerResponseCache) Hits() uint64 {
+	return c.hits.Load()
+}
+
+// Misses returns the number of cache misses so far.
+func (c *WebfingerResponseCache) Misses() uint64 {
+	return c.misses.Load()
+}
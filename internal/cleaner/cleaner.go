@@ -31,12 +31,17 @@ import (
 
 const (
 	selectLimit = 50
+
+	// notificationExpiryEvery is how often
+	// to run the read notification expiry job.
+	notificationExpiryEvery = time.Hour
 )
 
 type Cleaner struct {
-	state *state.State
-	emoji Emoji
-	media Media
+	state        *state.State
+	emoji        Emoji
+	media        Media
+	notification Notification
 }
 
 func New(state *state.State) *Cleaner {
@@ -44,6 +49,7 @@ func New(state *state.State) *Cleaner {
 	c.state = state
 	c.emoji.Cleaner = c
 	c.media.Cleaner = c
+	c.notification.Cleaner = c
 	return c
 }
 
@@ -57,6 +63,11 @@ func (c *Cleaner) Media() *Media {
 	return &c.media
 }
 
+// Notification returns the notification set of cleaner utilities.
+func (c *Cleaner) Notification() *Notification {
+	return &c.notification
+}
+
 // haveFiles returns whether all of the provided files exist within current storage.
 func (c *Cleaner) haveFiles(ctx context.Context, files ...string) (bool, error) {
 	for _, file := range files {
@@ -169,5 +180,41 @@ func (c *Cleaner) ScheduleJobs() error {
 		panic("failed to schedule @mediacleanup")
 	}
 
+	var (
+		notifMaxAge   = config.GetNotificationsReadMaxAge()
+		notifMaxCount = config.GetNotificationsReadMaxCount()
+	)
+
+	if notifMaxAge <= 0 && notifMaxCount <= 0 {
+		// Read notification
+		// expiry is disabled.
+		return nil
+	}
+
+	notifFn := func(ctx context.Context, start time.Time) {
+		log.Info(ctx, "starting read notification expiry")
+		c.Notification().All(ctx,
+			notifMaxAge,
+			notifMaxCount,
+			config.GetNotificationsExpiryExemptTypes(),
+		)
+		log.Infof(ctx, "finished read notification expiry after %s", time.Since(start))
+	}
+
+	log.Infof(nil,
+		"scheduling read notification expiry to run every %s",
+		notificationExpiryEvery,
+	)
+
+	// Schedule read notification expiry to run regularly.
+	if !c.state.Workers.Scheduler.AddRecurring(
+		"@notificationexpiry",
+		now.Add(notificationExpiryEvery),
+		notificationExpiryEvery,
+		notifFn,
+	) {
+		panic("failed to schedule @notificationexpiry")
+	}
+
 	return nil
 }
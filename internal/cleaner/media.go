@@ -20,8 +20,11 @@ package cleaner
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
+	"github.com/oklog/ulid"
+
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -30,9 +33,14 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
+// staleUploadAge is the age after which direct uploads
+// to storage that were never finalized are removed.
+const staleUploadAge = 24 * time.Hour
+
 // Media encompasses a set of
 // media cleanup / admin utils.
 type Media struct{ *Cleaner }
@@ -95,6 +103,24 @@ func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
 
 	// All media files in storage will have path fitting: {$account}/{$type}/{$size}/{$id}.{$ext}
 	if err := m.state.Storage.WalkKeys(ctx, func(path string) error {
+		if strings.HasPrefix(path, storage.UploadsPrefix) {
+			// Direct uploads are removed once finalized,
+			// so any old ones were abandoned by clients.
+			if isStaleUpload(path) {
+				files = append(files, path)
+			}
+			return nil
+		}
+
+		if strings.HasPrefix(path, storage.ExportsPrefix) {
+			// Exports are replaced by newer exports,
+			// so only need removing once they're old.
+			if isStaleExport(path) {
+				files = append(files, path)
+			}
+			return nil
+		}
+
 		// Check for our expected fileserver path format.
 		if !regexes.FilePath.MatchString(path) {
 			log.Warn(ctx, "unexpected storage item: %s", path)
@@ -121,6 +147,39 @@ func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
 	return m.removeFiles(ctx, files...)
 }
 
+// isStaleUpload returns whether the direct upload at given storage
+// path was created long enough ago that it should have been either
+// finalized or abandoned by now. See storage.Driver{}.PresignedUpload().
+func isStaleUpload(path string) bool {
+	// Upload keys end in a ULID
+	// generated when presigning.
+	name := path[strings.LastIndexByte(path, '/')+1:]
+	uploadID, err := ulid.Parse(name)
+	if err != nil {
+		return false
+	}
+
+	createdAt := ulid.Time(uploadID.Time())
+	return time.Since(createdAt) > staleUploadAge
+}
+
+// isStaleExport returns whether the account export archive at
+// given storage path was created long enough ago that it should
+// no longer be kept around. See storage.ExportKey().
+func isStaleExport(path string) bool {
+	// Export keys end in a ULID
+	// generated when exporting.
+	name := path[strings.LastIndexByte(path, '/')+1:]
+	name = strings.TrimSuffix(name, ".zip")
+	exportID, err := ulid.Parse(name)
+	if err != nil {
+		return false
+	}
+
+	createdAt := ulid.Time(exportID.Time())
+	return time.Since(createdAt) > storage.ExportRetention
+}
+
 // PruneUnused will delete all unused media attachments from the database and storage driver.
 // Media is marked as unused if not attached to any status, account or account is suspended.
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
@@ -374,6 +433,17 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 		}
 	}
 
+	// Check whether media belongs to a not yet published scheduled status.
+	scheduled, err := m.getRelatedScheduledStatus(ctx, media)
+	if err != nil {
+		return false, err
+	}
+
+	if scheduled != nil {
+		l.Debug("skipping as attached to scheduled status")
+		return false, nil
+	}
+
 	// Media totally unused, delete it.
 	l.Debug("deleting unused media")
 	return true, m.delete(ctx, media)
@@ -542,6 +612,24 @@ func (m *Media) getRelatedStatus(ctx context.Context, media *gtsmodel.MediaAttac
 	return status, false, nil
 }
 
+func (m *Media) getRelatedScheduledStatus(ctx context.Context, media *gtsmodel.MediaAttachment) (*gtsmodel.ScheduledStatus, error) {
+	if media.ScheduledStatusID == "" {
+		// no related scheduled status.
+		return nil, nil
+	}
+
+	// Load the scheduled status related to this media.
+	scheduled, err := m.state.DB.GetScheduledStatusByID(
+		gtscontext.SetBarebones(ctx),
+		media.ScheduledStatusID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error fetching scheduled status by id %s: %w", media.ScheduledStatusID, err)
+	}
+
+	return scheduled, nil
+}
+
 func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) error {
 	if gtscontext.DryRun(ctx) {
 		// Dry run, do nothing.
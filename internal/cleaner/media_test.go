@@ -31,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
@@ -292,6 +293,84 @@ func (suite *MediaTestSuite) TearDownTest() {
 // 	suite.ErrorIs(err, db.ErrNoEntries)
 // }
 
+func (suite *MediaTestSuite) TestPruneOrphanedStaleUploads() {
+	ctx := context.Background()
+
+	accountID := suite.testAccounts["local_account_1"].ID
+
+	// One upload from two days ago, one from just now.
+	staleID, err := id.NewULIDFromTime(time.Now().Add(-48 * time.Hour))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	staleKey := storage.UploadKeyPrefix(accountID) + staleID
+	freshKey := storage.UploadKeyPrefix(accountID) + id.NewULID()
+
+	for _, key := range []string{staleKey, freshKey} {
+		if _, err := suite.storage.Put(ctx, key, []byte("some data")); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	totalPruned, err := suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+	suite.Equal(1, totalPruned)
+
+	// Only the stale upload should be gone.
+	hasStale, err := suite.storage.Has(ctx, staleKey)
+	suite.NoError(err)
+	suite.False(hasStale)
+
+	hasFresh, err := suite.storage.Has(ctx, freshKey)
+	suite.NoError(err)
+	suite.True(hasFresh)
+}
+
+func (suite *MediaTestSuite) TestPruneUnusedScheduled() {
+	ctx := context.Background()
+
+	testAccount := suite.testAccounts["local_account_1"]
+	testAttachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	// Attach the otherwise
+	// unused media to a
+	// pending scheduled status.
+	scheduled := &gtsmodel.ScheduledStatus{
+		ID:            id.NewULID(),
+		AccountID:     testAccount.ID,
+		ApplicationID: "01F8MGY43H3N2C8EWPR2FPYEXG",
+		ScheduledAt:   time.Now().Add(24 * time.Hour),
+		Params:        `{"media_ids":["` + testAttachment.ID + `"]}`,
+	}
+	if err := suite.db.PutScheduledStatus(ctx, scheduled); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	testAttachment.ScheduledStatusID = scheduled.ID
+	if err := suite.db.UpdateAttachment(ctx, testAttachment, "scheduled_status_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.cleaner.Media().PruneUnused(ctx)
+	suite.NoError(err)
+
+	// Media should have been kept.
+	_, err = suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+
+	// Once the scheduled status is gone,
+	// the media should be pruned as unused.
+	if err := suite.db.DeleteScheduledStatusByID(ctx, scheduled.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.cleaner.Media().PruneUnused(ctx)
+	suite.NoError(err)
+
+	_, err = suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func (suite *MediaTestSuite) TestUncacheRemote() {
 	ctx := context.Background()
 
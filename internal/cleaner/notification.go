@@ -0,0 +1,186 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// notificationExpiryBatch is the maximum number of
+	// notifications deleted per account per expiry policy
+	// in one run, so no one account can hog the job; any
+	// remainder will be handled in the next run.
+	notificationExpiryBatch = 500
+
+	// notificationExpiryPause is how long to pause
+	// after deleting notifications for an account
+	// before moving on, to pace database writes.
+	notificationExpiryPause = 100 * time.Millisecond
+)
+
+// Notification encompasses a set of
+// notification cleanup / admin utils.
+type Notification struct{ *Cleaner }
+
+// All will execute all cleaner.Notification utilities synchronously, including output logging.
+func (n *Notification) All(ctx context.Context, maxAge time.Duration, maxCount int, exemptTypes []string) {
+	n.LogExpireRead(ctx, maxAge, maxCount, exemptTypes)
+}
+
+// LogExpireRead performs Notification.ExpireRead(...), logging the start and outcome.
+func (n *Notification) LogExpireRead(ctx context.Context, maxAge time.Duration, maxCount int, exemptTypes []string) {
+	log.Infof(ctx, "start max age: %s, max count: %d", maxAge, maxCount)
+	if n, err := n.ExpireRead(ctx, maxAge, maxCount, exemptTypes); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deleted: %d", n)
+	}
+}
+
+// ExpireRead deletes read notifications targeting local accounts that are
+// older than maxAge, or beyond the newest maxCount read notifications of
+// each account. A zero value for either disables that policy. Notifications
+// of exemptTypes are never deleted.
+//
+// A notification is considered read if it's older than the account's
+// notifications marker. Unread notifications (and the notification the
+// marker points to) are never deleted, so read markers are unaffected.
+//
+// Accounts are handled one at a time, with at most notificationExpiryBatch
+// deletions per policy per account in a single run, so that accounts with
+// huge numbers of notifications don't starve the rest.
+func (n *Notification) ExpireRead(ctx context.Context, maxAge time.Duration, maxCount int, exemptTypes []string) (int, error) {
+	if maxAge <= 0 && maxCount <= 0 {
+		// Nothing to do.
+		return 0, nil
+	}
+
+	users, err := n.state.DB.GetAllUsers(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, gtserror.Newf("error getting users: %w", err)
+	}
+
+	var total int
+
+	for _, user := range users {
+		deleted, err := n.expireRead(ctx,
+			user.AccountID,
+			maxAge,
+			maxCount,
+			exemptTypes,
+		)
+		if err != nil {
+			return total, err
+		}
+
+		if deleted == 0 {
+			continue
+		}
+
+		// Update
+		// count.
+		total += deleted
+
+		// Pace deletions
+		// between accounts.
+		select {
+		case <-ctx.Done():
+			return total, ctx.Err()
+		case <-time.After(notificationExpiryPause):
+		}
+	}
+
+	return total, nil
+}
+
+func (n *Notification) expireRead(
+	ctx context.Context,
+	accountID string,
+	maxAge time.Duration,
+	maxCount int,
+	exemptTypes []string,
+) (int, error) {
+	// Get the account's notifications read marker.
+	marker, err := n.state.DB.GetMarker(ctx, accountID, gtsmodel.MarkerNameNotifications)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, gtserror.Newf("error getting notifications marker for account %s: %w", accountID, err)
+	}
+
+	if marker == nil || marker.LastReadID == "" {
+		// No notifications
+		// read yet, skip.
+		return 0, nil
+	}
+
+	// Only notifications older than
+	// the marker are known to be read.
+	readMaxID := marker.LastReadID
+
+	var total int
+
+	if maxAge > 0 {
+		// Only delete read notifications older than max age.
+		maxID, err := id.NewULIDFromTime(time.Now().Add(-maxAge))
+		if err != nil {
+			return total, gtserror.Newf("error generating max id: %w", err)
+		}
+
+		if readMaxID < maxID {
+			maxID = readMaxID
+		}
+
+		deleted, err := n.state.DB.DeleteOldNotifications(ctx,
+			accountID,
+			maxID,
+			0,
+			exemptTypes,
+			notificationExpiryBatch,
+		)
+		if err != nil {
+			return total, gtserror.Newf("error deleting notifications for account %s: %w", accountID, err)
+		}
+		total += deleted
+	}
+
+	if maxCount > 0 {
+		// Delete read notifications beyond
+		// the newest max count for account.
+		deleted, err := n.state.DB.DeleteOldNotifications(ctx,
+			accountID,
+			readMaxID,
+			maxCount,
+			exemptTypes,
+			notificationExpiryBatch,
+		)
+		if err != nil {
+			return total, gtserror.Newf("error deleting notifications for account %s: %w", accountID, err)
+		}
+		total += deleted
+	}
+
+	return total, nil
+}
@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+func (suite *CleanerTestSuite) TestNotificationExpireRead() {
+	ctx := context.Background()
+
+	// Local account 1 has read up to their
+	// "local_account_1_like" notification.
+	var (
+		target = "01F8MH1H7YV1Z7D2C8K2730QBF"
+		origin = "01F8MH17FWEB39HZJ76B6VXSKF"
+		marker = testrig.NewTestNotifications()["local_account_1_like"]
+	)
+
+	// Add some older, read notifications.
+	older := []*gtsmodel.Notification{
+		{
+			ID:               "01F8MH0000000000000000000A",
+			NotificationType: gtsmodel.NotificationFave,
+			CreatedAt:        testrig.TimeMustParse("2021-01-01T00:00:00Z"),
+			TargetAccountID:  target,
+			OriginAccountID:  origin,
+			StatusID:         "01F8MHAMCHF6Y650WCRSCP4WMY",
+			Read:             util.Ptr(false),
+		},
+		{
+			ID:               "01F8MH0000000000000000000B",
+			NotificationType: gtsmodel.NotificationMention,
+			CreatedAt:        testrig.TimeMustParse("2021-01-02T00:00:00Z"),
+			TargetAccountID:  target,
+			OriginAccountID:  origin,
+			StatusID:         "01F8MHAMCHF6Y650WCRSCP4WMY",
+			Read:             util.Ptr(false),
+		},
+		{
+			ID:               "01F8MH0000000000000000000C",
+			NotificationType: gtsmodel.NotificationFollow,
+			CreatedAt:        testrig.TimeMustParse("2021-01-03T00:00:00Z"),
+			TargetAccountID:  target,
+			OriginAccountID:  origin,
+			Read:             util.Ptr(false),
+		},
+	}
+	for _, notif := range older {
+		if err := suite.state.DB.PutNotification(ctx, notif); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Keep the newest read notification,
+	// and never expire follow notifications.
+	deleted, err := suite.cleaner.Notification().ExpireRead(ctx,
+		0, 1, []string{string(gtsmodel.NotificationFollow)},
+	)
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+
+	// The oldest fave should be gone.
+	_, err = suite.state.DB.GetNotificationByID(ctx, older[0].ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// All the others should remain.
+	for _, id := range []string{older[1].ID, older[2].ID, marker.ID} {
+		_, err := suite.state.DB.GetNotificationByID(ctx, id)
+		suite.NoError(err)
+	}
+
+	// Expiring by age should now get rid of the
+	// mention, but leave the marker notification.
+	deleted, err = suite.cleaner.Notification().ExpireRead(ctx,
+		24*time.Hour, 0, []string{string(gtsmodel.NotificationFollow)},
+	)
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+
+	_, err = suite.state.DB.GetNotificationByID(ctx, older[1].ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, err = suite.state.DB.GetNotificationByID(ctx, marker.ID)
+	suite.NoError(err)
+}
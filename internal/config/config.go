@@ -79,6 +79,7 @@ type Configuration struct {
 
 	InstanceFederationMode         string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
 	InstanceFederationSpamFilter   bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
+	InstanceFederationDelay        time.Duration      `name:"instance-federation-delay" usage:"Delay federating out newly created statuses by this long, giving authors a window to fix typos without generating edits. 0 to disable."`
 	InstanceExposePeers            bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
 	InstanceExposeSuspended        bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
 	InstanceExposeSuspendedWeb     bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
@@ -86,6 +87,7 @@ type Configuration struct {
 	InstanceDeliverToSharedInboxes bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
 	InstanceInjectMastodonVersion  bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
 	InstanceLanguages              language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+	InstanceAllowRegexFilters      bool               `name:"instance-allow-regex-filters" usage:"Allow users to create filter keywords that are matched as regular expressions."`
 
 	AccountsRegistrationOpen bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
 	AccountsReasonRequired   bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
@@ -96,25 +98,56 @@ type Configuration struct {
 	MediaVideoMaxSize        bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos in bytes"`
 	MediaDescriptionMinChars int           `name:"media-description-min-chars" usage:"Min required chars for an image description"`
 	MediaDescriptionMaxChars int           `name:"media-description-max-chars" usage:"Max permitted chars for an image description"`
+	MediaDescriptionRequired bool          `name:"media-description-required" usage:"Reject statuses from local accounts that contain media without a description (alt text)"`
 	MediaRemoteCacheDays     int           `name:"media-remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
 	MediaEmojiLocalMaxSize   bytesize.Size `name:"media-emoji-local-max-size" usage:"Max size in bytes of emojis uploaded to this instance via the admin API."`
 	MediaEmojiRemoteMaxSize  bytesize.Size `name:"media-emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
 	MediaCleanupFrom         string        `name:"media-cleanup-from" usage:"Time of day from which to start running media cleanup/prune jobs. Should be in the format 'hh:mm:ss', eg., '15:04:05'."`
 	MediaCleanupEvery        time.Duration `name:"media-cleanup-every" usage:"Period to elapse between cleanups, starting from media-cleanup-at."`
+	MediaThumbnailMaxSize    int           `name:"media-thumbnail-max-size" usage:"Max width and height in pixels of generated image thumbnails, between 64 and 2048."`
+	MediaThumbnailQuality    int           `name:"media-thumbnail-quality" usage:"JPEG quality of generated image thumbnails, between 10 (smallest files) and 100 (best quality)."`
 
-	StorageBackend       string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
-	StorageLocalBasePath string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
-	StorageS3Endpoint    string `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
-	StorageS3AccessKey   string `name:"storage-s3-access-key" usage:"S3 Access Key"`
-	StorageS3SecretKey   string `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
-	StorageS3UseSSL      bool   `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
-	StorageS3BucketName  string `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
-	StorageS3Proxy       bool   `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
+	StorageBackend                 string        `name:"storage-backend" usage:"Storage backend to use for media attachments"`
+	StorageLocalBasePath           string        `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
+	StorageS3Endpoint              string        `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
+	StorageS3AccessKey             string        `name:"storage-s3-access-key" usage:"S3 Access Key"`
+	StorageS3SecretKey             string        `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
+	StorageS3UseSSL                bool          `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
+	StorageS3BucketName            string        `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
+	StorageS3Proxy                 bool          `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
+	StorageS3StorageClass          string        `name:"storage-s3-storage-class" usage:"S3 storage class to upload media with (e.g. 'STANDARD_IA'). If empty, the bucket's default is used."`
+	StorageS3StorageClassEmojis    string        `name:"storage-s3-storage-class-emojis" usage:"S3 storage class to upload emojis with. If empty, storage-s3-storage-class is used."`
+	StorageS3PresignedUploadExpiry time.Duration `name:"storage-s3-presigned-upload-expiry" usage:"How long presigned URLs for uploading media directly to S3 remain valid for."`
+	StorageS3PartSize              bytesize.Size `name:"storage-s3-part-size" usage:"Size of parts to use for multipart uploads to S3. Must be between 5MiB and 5GiB. If 0, the S3 client's default is used."`
+	StorageS3NumThreads            int           `name:"storage-s3-num-threads" usage:"Number of parts of a multipart upload to S3 to upload in parallel. If 0, the S3 client's default is used."`
+	StorageS3ConcurrentStreamParts bool          `name:"storage-s3-concurrent-stream-parts" usage:"Upload parts of media of unknown size to S3 in parallel, using storage-s3-num-threads buffers of storage-s3-part-size each. Faster, at the cost of memory."`
+	StorageS3SSE                   string        `name:"storage-s3-sse" usage:"Server-side encryption to store media in S3 with: 'sse-s3', 'sse-kms', or 'sse-c'. If empty, the bucket's default is used."`
+	StorageS3SSEKMSKeyID           string        `name:"storage-s3-sse-kms-key-id" usage:"ID of the KMS key to encrypt media with, if storage-s3-sse is 'sse-kms'. If empty, the provider's default key is used."`
+	StorageS3SSECKey               string        `name:"storage-s3-sse-c-key" usage:"Base64-encoded 256-bit key to encrypt media with, if storage-s3-sse is 'sse-c'. Needed to read media back, so must not be lost or changed."`
+	StorageS3ObjectTags            []string      `name:"storage-s3-object-tags" usage:"Tags to apply to media uploaded to S3, for use in lifecycle rules. Each is either 'media-type', 'media-size' or 'account-id', to tag media with that key and the corresponding value, or 'key=value' to tag all media with a fixed value."`
 
-	StatusesMaxChars           int `name:"statuses-max-chars" usage:"Max permitted characters for posted statuses, including content warning"`
-	StatusesPollMaxOptions     int `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
-	StatusesPollOptionMaxChars int `name:"statuses-poll-option-max-chars" usage:"Max amount of characters for a poll option"`
-	StatusesMediaMaxFiles      int `name:"statuses-media-max-files" usage:"Maximum number of media files/attachments per status"`
+	StatusesMaxChars           int  `name:"statuses-max-chars" usage:"Max permitted characters for posted statuses, including content warning"`
+	StatusesPollMaxOptions     int  `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
+	StatusesPollOptionMaxChars int  `name:"statuses-poll-option-max-chars" usage:"Max amount of characters for a poll option"`
+	StatusesMediaMaxFiles      int  `name:"statuses-media-max-files" usage:"Maximum number of media files/attachments per status"`
+	StatusesMediaAllowMixed    bool `name:"statuses-media-allow-mixed" usage:"Allow attaching media of different types (eg., image + video) to the same status"`
+
+	StatusesStripTrackingParams       bool     `name:"statuses-strip-tracking-params" usage:"Strip tracking query parameters from links in new statuses"`
+	StatusesTrackingParams            []string `name:"statuses-tracking-params" usage:"Query parameters to strip from links when statuses-strip-tracking-params is true. A trailing * matches any parameter with that prefix"`
+	StatusesTrackingParamsSkipDomains []string `name:"statuses-tracking-params-skip-domains" usage:"Domains (and their subdomains) for which links are left untouched when stripping tracking parameters"`
+
+	StatusesScheduledMaxHorizon time.Duration `name:"statuses-scheduled-max-horizon" usage:"How far in the future statuses can be scheduled to be published"`
+
+	StatusesExpiryMin time.Duration `name:"statuses-expiry-min" usage:"Minimum duration after which statuses may be set to be automatically deleted"`
+	StatusesExpiryMax time.Duration `name:"statuses-expiry-max" usage:"Maximum duration after which statuses may be set to be automatically deleted"`
+
+	StatusesLanguageDetection bool `name:"statuses-language-detection" usage:"Detect the language of new statuses posted without a language, instead of using the account's default language straight away"`
+
+	StatusesContentTypes []string `name:"statuses-content-types" usage:"Content types that statuses may be posted in. The first is used when neither the status nor the account's settings specify one. Options: [text/plain, text/markdown]"`
+
+	NotificationsReadMaxAge        time.Duration `name:"notifications-read-max-age" usage:"Automatically delete read notifications older than this. 0 to disable."`
+	NotificationsReadMaxCount      int           `name:"notifications-read-max-count" usage:"Automatically delete read notifications beyond this many per account, oldest first. 0 to disable."`
+	NotificationsExpiryExemptTypes []string      `name:"notifications-expiry-exempt-types" usage:"Types of notification to never automatically delete, eg., follow"`
 
 	LetsEncryptEnabled      bool   `name:"letsencrypt-enabled" usage:"Enable letsencrypt TLS certs for this server. If set to true, then cert dir also needs to be set (or take the default)."`
 	LetsEncryptPort         int    `name:"letsencrypt-port" usage:"Port to listen on for letsencrypt certificate challenges. Must not be the same as the GtS webserver/API port."`
@@ -156,14 +189,27 @@ type Configuration struct {
 	SyslogProtocol string `name:"syslog-protocol" usage:"Protocol to use when directing logs to syslog. Leave empty to connect to local syslog."`
 	SyslogAddress  string `name:"syslog-address" usage:"Address:port to send syslog logs to. Leave empty to connect to local syslog."`
 
-	AdvancedCookiesSamesite      string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
-	AdvancedRateLimitRequests    int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
-	AdvancedRateLimitExceptions  []string      `name:"advanced-rate-limit-exceptions" usage:"Slice of CIDRs to exclude from rate limit restrictions."`
-	AdvancedThrottlingMultiplier int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
-	AdvancedThrottlingRetryAfter time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
-	AdvancedSenderMultiplier     int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
-	AdvancedCSPExtraURIs         []string      `name:"advanced-csp-extra-uris" usage:"Additional URIs to allow when building content-security-policy for media + images."`
-	AdvancedHeaderFilterMode     string        `name:"advanced-header-filter-mode" usage:"Set incoming request header filtering mode."`
+	AdvancedCookiesSamesite                 string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
+	AdvancedRateLimitRequests               int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
+	AdvancedRateLimitExceptions             []string      `name:"advanced-rate-limit-exceptions" usage:"Slice of CIDRs to exclude from rate limit restrictions."`
+	AdvancedWebfingerRateLimitRequests      int           `name:"advanced-webfinger-rate-limit-requests" usage:"Sustained amount of webfinger requests to permit per minute from a single remote IP. 0 or less turns webfinger rate limiting off."`
+	AdvancedWebfingerRateLimitBurst         int           `name:"advanced-webfinger-rate-limit-burst" usage:"Amount of webfinger requests a single remote IP may make in a burst before being held to the sustained rate."`
+	AdvancedThrottlingMultiplier            int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
+	AdvancedThrottlingRetryAfter            time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
+	AdvancedSenderMultiplier                int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
+	AdvancedCSPExtraURIs                    []string      `name:"advanced-csp-extra-uris" usage:"Additional URIs to allow when building content-security-policy for media + images."`
+	AdvancedHeaderFilterMode                string        `name:"advanced-header-filter-mode" usage:"Set incoming request header filtering mode."`
+	AdvancedSignedFetchExempt               []string      `name:"advanced-signed-fetch-exempt" usage:"Slice of domains and/or CIDRs permitted to fetch ActivityPub objects without an http signature."`
+	AdvancedOAuthRegistrationAllowLocalhost bool          `name:"advanced-oauth-registration-allow-localhost" usage:"Allow OAuth clients registered via dynamic client registration to use localhost / loopback redirect URIs."`
+	AdvancedOAuthLoopbackRedirectAnyPort    bool          `name:"advanced-oauth-loopback-redirect-any-port" usage:"Let OAuth redirect URIs registered with a loopback IP address (eg., http://127.0.0.1/callback) be used with any port, as native apps require (RFC 8252). All other redirect URIs must match exactly."`
+	AdvancedOAuthMaxTokensPerClient         int           `name:"advanced-oauth-max-tokens-per-client" usage:"Maximum number of active access tokens a user may hold for a single OAuth client. When a new token would exceed this, the oldest is revoked. 0 or less means no limit."`
+	AdvancedOAuthDemoClientIDs              []string      `name:"advanced-oauth-demo-client-ids" usage:"Client IDs of OAuth clients whose tokens are read-only, eg., for public demos. These clients may only request read scopes, and any write request made with their tokens is rejected."`
+	AdvancedOAuthAccessTokenFormat          string        `name:"advanced-oauth-access-token-format" usage:"Format of issued OAuth access tokens: 'opaque' (random strings) or 'jwt' (signed JSON Web Tokens, verifiable with the keys served at /oauth/jwks)."`
+	AdvancedOAuthJWTSigningKeyPath          string        `name:"advanced-oauth-jwt-signing-key-path" usage:"Path to a PEM-encoded RSA, ECDSA or Ed25519 private key to sign JWT access tokens with. Required if advanced-oauth-access-token-format is 'jwt'."`
+	AdvancedOAuthJWTLifetime                time.Duration `name:"advanced-oauth-jwt-lifetime" usage:"Expiry (exp claim) of JWT access tokens that don't otherwise expire. After this, other services validating the JWT will reject it, but GoToSocial will keep accepting it until it's revoked."`
+	AdvancedStreamingPingInterval           time.Duration `name:"advanced-streaming-ping-interval" usage:"Interval at which to send keep-alive pings on idle streaming connections. Clients not responding within twice this interval are disconnected."`
+	AdvancedStreamingQueueSize              int           `name:"advanced-streaming-queue-size" usage:"Maximum number of events queued for sending on each streaming connection. 0 or less means the default."`
+	AdvancedStreamingSlowConsumer           string        `name:"advanced-streaming-slow-consumer" usage:"What to do when a streaming connection's queue is full because the client isn't reading fast enough: 'disconnect' or 'drop-oldest'."`
 
 	// HTTPClient configuration vars.
 	HTTPClient HTTPClientConfiguration `name:"http-client"`
@@ -237,6 +283,8 @@ type CacheConfiguration struct {
 	UserMuteIDsMemRatio       float64       `name:"user-mute-ids-mem-ratio"`
 	WebfingerMemRatio         float64       `name:"webfinger-mem-ratio"`
 	VisibilityMemRatio        float64       `name:"visibility-mem-ratio"`
+	WebfingerResponseTTL      time.Duration `name:"webfinger-response-ttl"`
+	WebfingerResponseMax      int           `name:"webfinger-response-max"`
 }
 
 // MarshalMap will marshal current Configuration into a map structure (useful for JSON/TOML/YAML).
@@ -17,6 +17,8 @@
 
 package config
 
+import "time"
+
 const (
 	// Instance federation mode determines how this
 	// instance federates with others (if at all).
@@ -29,4 +31,22 @@ const (
 	RequestHeaderFilterModeAllow    = "allow"
 	RequestHeaderFilterModeBlock    = "block"
 	RequestHeaderFilterModeDisabled = ""
+
+	// Streaming slow consumer policy determines what
+	// happens when a client doesn't read its stream
+	// fast enough and the per-connection queue fills.
+	StreamingSlowConsumerDisconnect = "disconnect"
+	StreamingSlowConsumerDropOldest = "drop-oldest"
+	StreamingSlowConsumerDefault    = StreamingSlowConsumerDisconnect
+
+	// OAuth access token format determines whether
+	// issued access tokens are random strings, or
+	// signed JWTs that other services can validate.
+	OAuthAccessTokenFormatOpaque  = "opaque"
+	OAuthAccessTokenFormatJWT     = "jwt"
+	OAuthAccessTokenFormatDefault = OAuthAccessTokenFormatOpaque
+
+	// InstanceFederationDelayMax is the maximum
+	// permitted value of instance-federation-delay.
+	InstanceFederationDelayMax = 5 * time.Minute
 )
@@ -60,11 +60,13 @@ var Defaults = Configuration{
 
 	InstanceFederationMode:         InstanceFederationModeDefault,
 	InstanceFederationSpamFilter:   false,
+	InstanceFederationDelay:        0,
 	InstanceExposePeers:            false,
 	InstanceExposeSuspended:        false,
 	InstanceExposeSuspendedWeb:     false,
 	InstanceDeliverToSharedInboxes: true,
 	InstanceLanguages:              make(language.Languages, 0),
+	InstanceAllowRegexFilters:      false,
 
 	AccountsRegistrationOpen: false,
 	AccountsReasonRequired:   true,
@@ -74,22 +76,57 @@ var Defaults = Configuration{
 	MediaImageMaxSize:        10 * bytesize.MiB,
 	MediaVideoMaxSize:        40 * bytesize.MiB,
 	MediaDescriptionMinChars: 0,
+	MediaDescriptionRequired: false,
 	MediaDescriptionMaxChars: 1500,
 	MediaRemoteCacheDays:     7,
 	MediaEmojiLocalMaxSize:   50 * bytesize.KiB,
 	MediaEmojiRemoteMaxSize:  100 * bytesize.KiB,
 	MediaCleanupFrom:         "00:00",        // Midnight.
 	MediaCleanupEvery:        24 * time.Hour, // 1/day.
+	MediaThumbnailMaxSize:    512,
+	MediaThumbnailQuality:    70,
 
-	StorageBackend:       "local",
-	StorageLocalBasePath: "/gotosocial/storage",
-	StorageS3UseSSL:      true,
-	StorageS3Proxy:       false,
+	StorageBackend:                 "local",
+	StorageLocalBasePath:           "/gotosocial/storage",
+	StorageS3UseSSL:                true,
+	StorageS3Proxy:                 false,
+	StorageS3PresignedUploadExpiry: 10 * time.Minute,
+	StorageS3ObjectTags:            []string{},
 
 	StatusesMaxChars:           5000,
 	StatusesPollMaxOptions:     6,
 	StatusesPollOptionMaxChars: 50,
 	StatusesMediaMaxFiles:      6,
+	StatusesMediaAllowMixed:    true,
+
+	StatusesStripTrackingParams: false,
+	StatusesTrackingParams: []string{
+		"utm_*",
+		"fbclid",
+		"gclid",
+		"dclid",
+		"msclkid",
+		"mc_cid",
+		"mc_eid",
+		"igshid",
+		"yclid",
+		"_hsenc",
+		"_hsmi",
+	},
+	StatusesTrackingParamsSkipDomains: []string{},
+
+	StatusesScheduledMaxHorizon: 90 * 24 * time.Hour, // 90 days
+
+	StatusesExpiryMin: 5 * time.Minute,
+	StatusesExpiryMax: 365 * 24 * time.Hour, // 1 year
+
+	StatusesLanguageDetection: false,
+
+	StatusesContentTypes: []string{"text/plain", "text/markdown"},
+
+	NotificationsReadMaxAge:        0, // disabled.
+	NotificationsReadMaxCount:      0, // disabled.
+	NotificationsExpiryExemptTypes: []string{},
 
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         80,
@@ -127,14 +164,27 @@ var Defaults = Configuration{
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
 
-	AdvancedCookiesSamesite:      "lax",
-	AdvancedRateLimitRequests:    300, // 1 per second per 5 minutes
-	AdvancedRateLimitExceptions:  []string{},
-	AdvancedThrottlingMultiplier: 8, // 8 open requests per CPU
-	AdvancedThrottlingRetryAfter: time.Second * 30,
-	AdvancedSenderMultiplier:     2, // 2 senders per CPU
-	AdvancedCSPExtraURIs:         []string{},
-	AdvancedHeaderFilterMode:     RequestHeaderFilterModeDisabled,
+	AdvancedCookiesSamesite:                 "lax",
+	AdvancedRateLimitRequests:               300, // 1 per second per 5 minutes
+	AdvancedRateLimitExceptions:             []string{},
+	AdvancedWebfingerRateLimitRequests:      60,  // 1 per second sustained
+	AdvancedWebfingerRateLimitBurst:         300, // allow big instances to catch up
+	AdvancedThrottlingMultiplier:            8,   // 8 open requests per CPU
+	AdvancedThrottlingRetryAfter:            time.Second * 30,
+	AdvancedSenderMultiplier:                2, // 2 senders per CPU
+	AdvancedCSPExtraURIs:                    []string{},
+	AdvancedHeaderFilterMode:                RequestHeaderFilterModeDisabled,
+	AdvancedSignedFetchExempt:               []string{},
+	AdvancedOAuthRegistrationAllowLocalhost: false,
+	AdvancedOAuthLoopbackRedirectAnyPort:    true,
+	AdvancedOAuthMaxTokensPerClient:         50,
+	AdvancedOAuthDemoClientIDs:              []string{},
+	AdvancedOAuthAccessTokenFormat:          OAuthAccessTokenFormatDefault,
+	AdvancedOAuthJWTSigningKeyPath:          "",
+	AdvancedOAuthJWTLifetime:                24 * time.Hour,
+	AdvancedStreamingPingInterval:           30 * time.Second,
+	AdvancedStreamingQueueSize:              50,
+	AdvancedStreamingSlowConsumer:           StreamingSlowConsumerDefault,
 
 	Cache: CacheConfiguration{
 		// Rough memory target that the total
@@ -201,6 +251,8 @@ var Defaults = Configuration{
 		UserMuteIDsMemRatio:       3,
 		WebfingerMemRatio:         0.1,
 		VisibilityMemRatio:        2,
+		WebfingerResponseTTL:      10 * time.Minute,
+		WebfingerResponseMax:      1000,
 	},
 
 	HTTPClient: HTTPClientConfiguration{
@@ -85,11 +85,13 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		// Instance
 		cmd.Flags().String(InstanceFederationModeFlag(), cfg.InstanceFederationMode, fieldtag("InstanceFederationMode", "usage"))
 		cmd.Flags().Bool(InstanceFederationSpamFilterFlag(), cfg.InstanceFederationSpamFilter, fieldtag("InstanceFederationSpamFilter", "usage"))
+		cmd.Flags().Duration(InstanceFederationDelayFlag(), cfg.InstanceFederationDelay, fieldtag("InstanceFederationDelay", "usage"))
 		cmd.Flags().Bool(InstanceExposePeersFlag(), cfg.InstanceExposePeers, fieldtag("InstanceExposePeers", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedFlag(), cfg.InstanceExposeSuspended, fieldtag("InstanceExposeSuspended", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedWebFlag(), cfg.InstanceExposeSuspendedWeb, fieldtag("InstanceExposeSuspendedWeb", "usage"))
 		cmd.Flags().Bool(InstanceDeliverToSharedInboxesFlag(), cfg.InstanceDeliverToSharedInboxes, fieldtag("InstanceDeliverToSharedInboxes", "usage"))
 		cmd.Flags().StringSlice(InstanceLanguagesFlag(), cfg.InstanceLanguages.TagStrs(), fieldtag("InstanceLanguages", "usage"))
+		cmd.Flags().Bool(InstanceAllowRegexFiltersFlag(), cfg.InstanceAllowRegexFilters, fieldtag("InstanceAllowRegexFilters", "usage"))
 
 		// Accounts
 		cmd.Flags().Bool(AccountsRegistrationOpenFlag(), cfg.AccountsRegistrationOpen, fieldtag("AccountsRegistrationOpen", "usage"))
@@ -101,11 +103,14 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Uint64(MediaVideoMaxSizeFlag(), uint64(cfg.MediaVideoMaxSize), fieldtag("MediaVideoMaxSize", "usage"))
 		cmd.Flags().Int(MediaDescriptionMinCharsFlag(), cfg.MediaDescriptionMinChars, fieldtag("MediaDescriptionMinChars", "usage"))
 		cmd.Flags().Int(MediaDescriptionMaxCharsFlag(), cfg.MediaDescriptionMaxChars, fieldtag("MediaDescriptionMaxChars", "usage"))
+		cmd.Flags().Bool(MediaDescriptionRequiredFlag(), cfg.MediaDescriptionRequired, fieldtag("MediaDescriptionRequired", "usage"))
 		cmd.Flags().Int(MediaRemoteCacheDaysFlag(), cfg.MediaRemoteCacheDays, fieldtag("MediaRemoteCacheDays", "usage"))
 		cmd.Flags().Uint64(MediaEmojiLocalMaxSizeFlag(), uint64(cfg.MediaEmojiLocalMaxSize), fieldtag("MediaEmojiLocalMaxSize", "usage"))
 		cmd.Flags().Uint64(MediaEmojiRemoteMaxSizeFlag(), uint64(cfg.MediaEmojiRemoteMaxSize), fieldtag("MediaEmojiRemoteMaxSize", "usage"))
 		cmd.Flags().String(MediaCleanupFromFlag(), cfg.MediaCleanupFrom, fieldtag("MediaCleanupFrom", "usage"))
 		cmd.Flags().Duration(MediaCleanupEveryFlag(), cfg.MediaCleanupEvery, fieldtag("MediaCleanupEvery", "usage"))
+		cmd.Flags().Int(MediaThumbnailMaxSizeFlag(), cfg.MediaThumbnailMaxSize, fieldtag("MediaThumbnailMaxSize", "usage"))
+		cmd.Flags().Int(MediaThumbnailQualityFlag(), cfg.MediaThumbnailQuality, fieldtag("MediaThumbnailQuality", "usage"))
 
 		// Storage
 		cmd.Flags().String(StorageBackendFlag(), cfg.StorageBackend, fieldtag("StorageBackend", "usage"))
@@ -116,6 +121,20 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Int(StatusesPollMaxOptionsFlag(), cfg.StatusesPollMaxOptions, fieldtag("StatusesPollMaxOptions", "usage"))
 		cmd.Flags().Int(StatusesPollOptionMaxCharsFlag(), cfg.StatusesPollOptionMaxChars, fieldtag("StatusesPollOptionMaxChars", "usage"))
 		cmd.Flags().Int(StatusesMediaMaxFilesFlag(), cfg.StatusesMediaMaxFiles, fieldtag("StatusesMediaMaxFiles", "usage"))
+		cmd.Flags().Bool(StatusesMediaAllowMixedFlag(), cfg.StatusesMediaAllowMixed, fieldtag("StatusesMediaAllowMixed", "usage"))
+		cmd.Flags().Bool(StatusesStripTrackingParamsFlag(), cfg.StatusesStripTrackingParams, fieldtag("StatusesStripTrackingParams", "usage"))
+		cmd.Flags().StringSlice(StatusesTrackingParamsFlag(), cfg.StatusesTrackingParams, fieldtag("StatusesTrackingParams", "usage"))
+		cmd.Flags().StringSlice(StatusesTrackingParamsSkipDomainsFlag(), cfg.StatusesTrackingParamsSkipDomains, fieldtag("StatusesTrackingParamsSkipDomains", "usage"))
+		cmd.Flags().Duration(StatusesScheduledMaxHorizonFlag(), cfg.StatusesScheduledMaxHorizon, fieldtag("StatusesScheduledMaxHorizon", "usage"))
+		cmd.Flags().Duration(StatusesExpiryMinFlag(), cfg.StatusesExpiryMin, fieldtag("StatusesExpiryMin", "usage"))
+		cmd.Flags().Duration(StatusesExpiryMaxFlag(), cfg.StatusesExpiryMax, fieldtag("StatusesExpiryMax", "usage"))
+		cmd.Flags().Bool(StatusesLanguageDetectionFlag(), cfg.StatusesLanguageDetection, fieldtag("StatusesLanguageDetection", "usage"))
+		cmd.Flags().StringSlice(StatusesContentTypesFlag(), cfg.StatusesContentTypes, fieldtag("StatusesContentTypes", "usage"))
+
+		// Notifications
+		cmd.Flags().Duration(NotificationsReadMaxAgeFlag(), cfg.NotificationsReadMaxAge, fieldtag("NotificationsReadMaxAge", "usage"))
+		cmd.Flags().Int(NotificationsReadMaxCountFlag(), cfg.NotificationsReadMaxCount, fieldtag("NotificationsReadMaxCount", "usage"))
+		cmd.Flags().StringSlice(NotificationsExpiryExemptTypesFlag(), cfg.NotificationsExpiryExemptTypes, fieldtag("NotificationsExpiryExemptTypes", "usage"))
 
 		// LetsEncrypt
 		cmd.Flags().Bool(LetsEncryptEnabledFlag(), cfg.LetsEncryptEnabled, fieldtag("LetsEncryptEnabled", "usage"))
@@ -153,11 +172,24 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().String(AdvancedCookiesSamesiteFlag(), cfg.AdvancedCookiesSamesite, fieldtag("AdvancedCookiesSamesite", "usage"))
 		cmd.Flags().Int(AdvancedRateLimitRequestsFlag(), cfg.AdvancedRateLimitRequests, fieldtag("AdvancedRateLimitRequests", "usage"))
 		cmd.Flags().StringSlice(AdvancedRateLimitExceptionsFlag(), cfg.AdvancedRateLimitExceptions, fieldtag("AdvancedRateLimitExceptions", "usage"))
+		cmd.Flags().Int(AdvancedWebfingerRateLimitRequestsFlag(), cfg.AdvancedWebfingerRateLimitRequests, fieldtag("AdvancedWebfingerRateLimitRequests", "usage"))
+		cmd.Flags().Int(AdvancedWebfingerRateLimitBurstFlag(), cfg.AdvancedWebfingerRateLimitBurst, fieldtag("AdvancedWebfingerRateLimitBurst", "usage"))
 		cmd.Flags().Int(AdvancedThrottlingMultiplierFlag(), cfg.AdvancedThrottlingMultiplier, fieldtag("AdvancedThrottlingMultiplier", "usage"))
 		cmd.Flags().Duration(AdvancedThrottlingRetryAfterFlag(), cfg.AdvancedThrottlingRetryAfter, fieldtag("AdvancedThrottlingRetryAfter", "usage"))
 		cmd.Flags().Int(AdvancedSenderMultiplierFlag(), cfg.AdvancedSenderMultiplier, fieldtag("AdvancedSenderMultiplier", "usage"))
 		cmd.Flags().StringSlice(AdvancedCSPExtraURIsFlag(), cfg.AdvancedCSPExtraURIs, fieldtag("AdvancedCSPExtraURIs", "usage"))
 		cmd.Flags().String(AdvancedHeaderFilterModeFlag(), cfg.AdvancedHeaderFilterMode, fieldtag("AdvancedHeaderFilterMode", "usage"))
+		cmd.Flags().StringSlice(AdvancedSignedFetchExemptFlag(), cfg.AdvancedSignedFetchExempt, fieldtag("AdvancedSignedFetchExempt", "usage"))
+		cmd.Flags().Bool(AdvancedOAuthRegistrationAllowLocalhostFlag(), cfg.AdvancedOAuthRegistrationAllowLocalhost, fieldtag("AdvancedOAuthRegistrationAllowLocalhost", "usage"))
+		cmd.Flags().Bool(AdvancedOAuthLoopbackRedirectAnyPortFlag(), cfg.AdvancedOAuthLoopbackRedirectAnyPort, fieldtag("AdvancedOAuthLoopbackRedirectAnyPort", "usage"))
+		cmd.Flags().Int(AdvancedOAuthMaxTokensPerClientFlag(), cfg.AdvancedOAuthMaxTokensPerClient, fieldtag("AdvancedOAuthMaxTokensPerClient", "usage"))
+		cmd.Flags().StringSlice(AdvancedOAuthDemoClientIDsFlag(), cfg.AdvancedOAuthDemoClientIDs, fieldtag("AdvancedOAuthDemoClientIDs", "usage"))
+		cmd.Flags().String(AdvancedOAuthAccessTokenFormatFlag(), cfg.AdvancedOAuthAccessTokenFormat, fieldtag("AdvancedOAuthAccessTokenFormat", "usage"))
+		cmd.Flags().String(AdvancedOAuthJWTSigningKeyPathFlag(), cfg.AdvancedOAuthJWTSigningKeyPath, fieldtag("AdvancedOAuthJWTSigningKeyPath", "usage"))
+		cmd.Flags().Duration(AdvancedOAuthJWTLifetimeFlag(), cfg.AdvancedOAuthJWTLifetime, fieldtag("AdvancedOAuthJWTLifetime", "usage"))
+		cmd.Flags().Duration(AdvancedStreamingPingIntervalFlag(), cfg.AdvancedStreamingPingInterval, fieldtag("AdvancedStreamingPingInterval", "usage"))
+		cmd.Flags().Int(AdvancedStreamingQueueSizeFlag(), cfg.AdvancedStreamingQueueSize, fieldtag("AdvancedStreamingQueueSize", "usage"))
+		cmd.Flags().String(AdvancedStreamingSlowConsumerFlag(), cfg.AdvancedStreamingSlowConsumer, fieldtag("AdvancedStreamingSlowConsumer", "usage"))
 
 		cmd.Flags().String(RequestIDHeaderFlag(), cfg.RequestIDHeader, fieldtag("RequestIDHeader", "usage"))
 	})
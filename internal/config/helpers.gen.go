@@ -800,6 +800,31 @@ func GetInstanceFederationSpamFilter() bool { return global.GetInstanceFederatio
 // SetInstanceFederationSpamFilter safely sets the value for global configuration 'InstanceFederationSpamFilter' field
 func SetInstanceFederationSpamFilter(v bool) { global.SetInstanceFederationSpamFilter(v) }
 
+// GetInstanceFederationDelay safely fetches the Configuration value for state's 'InstanceFederationDelay' field
+func (st *ConfigState) GetInstanceFederationDelay() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationDelay
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationDelay safely sets the Configuration value for state's 'InstanceFederationDelay' field
+func (st *ConfigState) SetInstanceFederationDelay(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationDelay = v
+	st.reloadToViper()
+}
+
+// InstanceFederationDelayFlag returns the flag name for the 'InstanceFederationDelay' field
+func InstanceFederationDelayFlag() string { return "instance-federation-delay" }
+
+// GetInstanceFederationDelay safely fetches the value for global configuration 'InstanceFederationDelay' field
+func GetInstanceFederationDelay() time.Duration { return global.GetInstanceFederationDelay() }
+
+// SetInstanceFederationDelay safely sets the value for global configuration 'InstanceFederationDelay' field
+func SetInstanceFederationDelay(v time.Duration) { global.SetInstanceFederationDelay(v) }
+
 // GetInstanceExposePeers safely fetches the Configuration value for state's 'InstanceExposePeers' field
 func (st *ConfigState) GetInstanceExposePeers() (v bool) {
 	st.mutex.RLock()
@@ -975,6 +1000,31 @@ func GetInstanceLanguages() language.Languages { return global.GetInstanceLangua
 // SetInstanceLanguages safely sets the value for global configuration 'InstanceLanguages' field
 func SetInstanceLanguages(v language.Languages) { global.SetInstanceLanguages(v) }
 
+// GetInstanceAllowRegexFilters safely fetches the Configuration value for state's 'InstanceAllowRegexFilters' field
+func (st *ConfigState) GetInstanceAllowRegexFilters() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceAllowRegexFilters
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceAllowRegexFilters safely sets the Configuration value for state's 'InstanceAllowRegexFilters' field
+func (st *ConfigState) SetInstanceAllowRegexFilters(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceAllowRegexFilters = v
+	st.reloadToViper()
+}
+
+// InstanceAllowRegexFiltersFlag returns the flag name for the 'InstanceAllowRegexFilters' field
+func InstanceAllowRegexFiltersFlag() string { return "instance-allow-regex-filters" }
+
+// GetInstanceAllowRegexFilters safely fetches the value for global configuration 'InstanceAllowRegexFilters' field
+func GetInstanceAllowRegexFilters() bool { return global.GetInstanceAllowRegexFilters() }
+
+// SetInstanceAllowRegexFilters safely sets the value for global configuration 'InstanceAllowRegexFilters' field
+func SetInstanceAllowRegexFilters(v bool) { global.SetInstanceAllowRegexFilters(v) }
+
 // GetAccountsRegistrationOpen safely fetches the Configuration value for state's 'AccountsRegistrationOpen' field
 func (st *ConfigState) GetAccountsRegistrationOpen() (v bool) {
 	st.mutex.RLock()
@@ -1175,6 +1225,31 @@ func GetMediaDescriptionMaxChars() int { return global.GetMediaDescriptionMaxCha
 // SetMediaDescriptionMaxChars safely sets the value for global configuration 'MediaDescriptionMaxChars' field
 func SetMediaDescriptionMaxChars(v int) { global.SetMediaDescriptionMaxChars(v) }
 
+// GetMediaDescriptionRequired safely fetches the Configuration value for state's 'MediaDescriptionRequired' field
+func (st *ConfigState) GetMediaDescriptionRequired() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaDescriptionRequired
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaDescriptionRequired safely sets the Configuration value for state's 'MediaDescriptionRequired' field
+func (st *ConfigState) SetMediaDescriptionRequired(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaDescriptionRequired = v
+	st.reloadToViper()
+}
+
+// MediaDescriptionRequiredFlag returns the flag name for the 'MediaDescriptionRequired' field
+func MediaDescriptionRequiredFlag() string { return "media-description-required" }
+
+// GetMediaDescriptionRequired safely fetches the value for global configuration 'MediaDescriptionRequired' field
+func GetMediaDescriptionRequired() bool { return global.GetMediaDescriptionRequired() }
+
+// SetMediaDescriptionRequired safely sets the value for global configuration 'MediaDescriptionRequired' field
+func SetMediaDescriptionRequired(v bool) { global.SetMediaDescriptionRequired(v) }
+
 // GetMediaRemoteCacheDays safely fetches the Configuration value for state's 'MediaRemoteCacheDays' field
 func (st *ConfigState) GetMediaRemoteCacheDays() (v int) {
 	st.mutex.RLock()
@@ -1300,6 +1375,56 @@ func GetMediaCleanupEvery() time.Duration { return global.GetMediaCleanupEvery()
 // SetMediaCleanupEvery safely sets the value for global configuration 'MediaCleanupEvery' field
 func SetMediaCleanupEvery(v time.Duration) { global.SetMediaCleanupEvery(v) }
 
+// GetMediaThumbnailMaxSize safely fetches the Configuration value for state's 'MediaThumbnailMaxSize' field
+func (st *ConfigState) GetMediaThumbnailMaxSize() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaThumbnailMaxSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaThumbnailMaxSize safely sets the Configuration value for state's 'MediaThumbnailMaxSize' field
+func (st *ConfigState) SetMediaThumbnailMaxSize(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaThumbnailMaxSize = v
+	st.reloadToViper()
+}
+
+// MediaThumbnailMaxSizeFlag returns the flag name for the 'MediaThumbnailMaxSize' field
+func MediaThumbnailMaxSizeFlag() string { return "media-thumbnail-max-size" }
+
+// GetMediaThumbnailMaxSize safely fetches the value for global configuration 'MediaThumbnailMaxSize' field
+func GetMediaThumbnailMaxSize() int { return global.GetMediaThumbnailMaxSize() }
+
+// SetMediaThumbnailMaxSize safely sets the value for global configuration 'MediaThumbnailMaxSize' field
+func SetMediaThumbnailMaxSize(v int) { global.SetMediaThumbnailMaxSize(v) }
+
+// GetMediaThumbnailQuality safely fetches the Configuration value for state's 'MediaThumbnailQuality' field
+func (st *ConfigState) GetMediaThumbnailQuality() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaThumbnailQuality
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaThumbnailQuality safely sets the Configuration value for state's 'MediaThumbnailQuality' field
+func (st *ConfigState) SetMediaThumbnailQuality(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaThumbnailQuality = v
+	st.reloadToViper()
+}
+
+// MediaThumbnailQualityFlag returns the flag name for the 'MediaThumbnailQuality' field
+func MediaThumbnailQualityFlag() string { return "media-thumbnail-quality" }
+
+// GetMediaThumbnailQuality safely fetches the value for global configuration 'MediaThumbnailQuality' field
+func GetMediaThumbnailQuality() int { return global.GetMediaThumbnailQuality() }
+
+// SetMediaThumbnailQuality safely sets the value for global configuration 'MediaThumbnailQuality' field
+func SetMediaThumbnailQuality(v int) { global.SetMediaThumbnailQuality(v) }
+
 // GetStorageBackend safely fetches the Configuration value for state's 'StorageBackend' field
 func (st *ConfigState) GetStorageBackend() (v string) {
 	st.mutex.RLock()
@@ -1494,111 +1619,665 @@ func (st *ConfigState) SetStorageS3Proxy(v bool) {
 // StorageS3ProxyFlag returns the flag name for the 'StorageS3Proxy' field
 func StorageS3ProxyFlag() string { return "storage-s3-proxy" }
 
-// GetStorageS3Proxy safely fetches the value for global configuration 'StorageS3Proxy' field
-func GetStorageS3Proxy() bool { return global.GetStorageS3Proxy() }
+// GetStorageS3Proxy safely fetches the value for global configuration 'StorageS3Proxy' field
+func GetStorageS3Proxy() bool { return global.GetStorageS3Proxy() }
+
+// SetStorageS3Proxy safely sets the value for global configuration 'StorageS3Proxy' field
+func SetStorageS3Proxy(v bool) { global.SetStorageS3Proxy(v) }
+
+// GetStorageS3StorageClass safely fetches the Configuration value for state's 'StorageS3StorageClass' field
+func (st *ConfigState) GetStorageS3StorageClass() (v string) {
+	st.mutex.RLock()
+	v = st.config.StorageS3StorageClass
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3StorageClass safely sets the Configuration value for state's 'StorageS3StorageClass' field
+func (st *ConfigState) SetStorageS3StorageClass(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3StorageClass = v
+	st.reloadToViper()
+}
+
+// StorageS3StorageClassFlag returns the flag name for the 'StorageS3StorageClass' field
+func StorageS3StorageClassFlag() string { return "storage-s3-storage-class" }
+
+// GetStorageS3StorageClass safely fetches the value for global configuration 'StorageS3StorageClass' field
+func GetStorageS3StorageClass() string { return global.GetStorageS3StorageClass() }
+
+// SetStorageS3StorageClass safely sets the value for global configuration 'StorageS3StorageClass' field
+func SetStorageS3StorageClass(v string) { global.SetStorageS3StorageClass(v) }
+
+// GetStorageS3StorageClassEmojis safely fetches the Configuration value for state's 'StorageS3StorageClassEmojis' field
+func (st *ConfigState) GetStorageS3StorageClassEmojis() (v string) {
+	st.mutex.RLock()
+	v = st.config.StorageS3StorageClassEmojis
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3StorageClassEmojis safely sets the Configuration value for state's 'StorageS3StorageClassEmojis' field
+func (st *ConfigState) SetStorageS3StorageClassEmojis(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3StorageClassEmojis = v
+	st.reloadToViper()
+}
+
+// StorageS3StorageClassEmojisFlag returns the flag name for the 'StorageS3StorageClassEmojis' field
+func StorageS3StorageClassEmojisFlag() string { return "storage-s3-storage-class-emojis" }
+
+// GetStorageS3StorageClassEmojis safely fetches the value for global configuration 'StorageS3StorageClassEmojis' field
+func GetStorageS3StorageClassEmojis() string { return global.GetStorageS3StorageClassEmojis() }
+
+// SetStorageS3StorageClassEmojis safely sets the value for global configuration 'StorageS3StorageClassEmojis' field
+func SetStorageS3StorageClassEmojis(v string) { global.SetStorageS3StorageClassEmojis(v) }
+
+// GetStorageS3PresignedUploadExpiry safely fetches the Configuration value for state's 'StorageS3PresignedUploadExpiry' field
+func (st *ConfigState) GetStorageS3PresignedUploadExpiry() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.StorageS3PresignedUploadExpiry
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3PresignedUploadExpiry safely sets the Configuration value for state's 'StorageS3PresignedUploadExpiry' field
+func (st *ConfigState) SetStorageS3PresignedUploadExpiry(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3PresignedUploadExpiry = v
+	st.reloadToViper()
+}
+
+// StorageS3PresignedUploadExpiryFlag returns the flag name for the 'StorageS3PresignedUploadExpiry' field
+func StorageS3PresignedUploadExpiryFlag() string { return "storage-s3-presigned-upload-expiry" }
+
+// GetStorageS3PresignedUploadExpiry safely fetches the value for global configuration 'StorageS3PresignedUploadExpiry' field
+func GetStorageS3PresignedUploadExpiry() time.Duration {
+	return global.GetStorageS3PresignedUploadExpiry()
+}
+
+// SetStorageS3PresignedUploadExpiry safely sets the value for global configuration 'StorageS3PresignedUploadExpiry' field
+func SetStorageS3PresignedUploadExpiry(v time.Duration) { global.SetStorageS3PresignedUploadExpiry(v) }
+
+// GetStorageS3PartSize safely fetches the Configuration value for state's 'StorageS3PartSize' field
+func (st *ConfigState) GetStorageS3PartSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.StorageS3PartSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3PartSize safely sets the Configuration value for state's 'StorageS3PartSize' field
+func (st *ConfigState) SetStorageS3PartSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3PartSize = v
+	st.reloadToViper()
+}
+
+// StorageS3PartSizeFlag returns the flag name for the 'StorageS3PartSize' field
+func StorageS3PartSizeFlag() string { return "storage-s3-part-size" }
+
+// GetStorageS3PartSize safely fetches the value for global configuration 'StorageS3PartSize' field
+func GetStorageS3PartSize() bytesize.Size { return global.GetStorageS3PartSize() }
+
+// SetStorageS3PartSize safely sets the value for global configuration 'StorageS3PartSize' field
+func SetStorageS3PartSize(v bytesize.Size) { global.SetStorageS3PartSize(v) }
+
+// GetStorageS3NumThreads safely fetches the Configuration value for state's 'StorageS3NumThreads' field
+func (st *ConfigState) GetStorageS3NumThreads() (v int) {
+	st.mutex.RLock()
+	v = st.config.StorageS3NumThreads
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3NumThreads safely sets the Configuration value for state's 'StorageS3NumThreads' field
+func (st *ConfigState) SetStorageS3NumThreads(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3NumThreads = v
+	st.reloadToViper()
+}
+
+// StorageS3NumThreadsFlag returns the flag name for the 'StorageS3NumThreads' field
+func StorageS3NumThreadsFlag() string { return "storage-s3-num-threads" }
+
+// GetStorageS3NumThreads safely fetches the value for global configuration 'StorageS3NumThreads' field
+func GetStorageS3NumThreads() int { return global.GetStorageS3NumThreads() }
+
+// SetStorageS3NumThreads safely sets the value for global configuration 'StorageS3NumThreads' field
+func SetStorageS3NumThreads(v int) { global.SetStorageS3NumThreads(v) }
+
+// GetStorageS3ConcurrentStreamParts safely fetches the Configuration value for state's 'StorageS3ConcurrentStreamParts' field
+func (st *ConfigState) GetStorageS3ConcurrentStreamParts() (v bool) {
+	st.mutex.RLock()
+	v = st.config.StorageS3ConcurrentStreamParts
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3ConcurrentStreamParts safely sets the Configuration value for state's 'StorageS3ConcurrentStreamParts' field
+func (st *ConfigState) SetStorageS3ConcurrentStreamParts(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3ConcurrentStreamParts = v
+	st.reloadToViper()
+}
+
+// StorageS3ConcurrentStreamPartsFlag returns the flag name for the 'StorageS3ConcurrentStreamParts' field
+func StorageS3ConcurrentStreamPartsFlag() string { return "storage-s3-concurrent-stream-parts" }
+
+// GetStorageS3ConcurrentStreamParts safely fetches the value for global configuration 'StorageS3ConcurrentStreamParts' field
+func GetStorageS3ConcurrentStreamParts() bool { return global.GetStorageS3ConcurrentStreamParts() }
+
+// SetStorageS3ConcurrentStreamParts safely sets the value for global configuration 'StorageS3ConcurrentStreamParts' field
+func SetStorageS3ConcurrentStreamParts(v bool) { global.SetStorageS3ConcurrentStreamParts(v) }
+
+// GetStorageS3SSE safely fetches the Configuration value for state's 'StorageS3SSE' field
+func (st *ConfigState) GetStorageS3SSE() (v string) {
+	st.mutex.RLock()
+	v = st.config.StorageS3SSE
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3SSE safely sets the Configuration value for state's 'StorageS3SSE' field
+func (st *ConfigState) SetStorageS3SSE(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3SSE = v
+	st.reloadToViper()
+}
+
+// StorageS3SSEFlag returns the flag name for the 'StorageS3SSE' field
+func StorageS3SSEFlag() string { return "storage-s3-sse" }
+
+// GetStorageS3SSE safely fetches the value for global configuration 'StorageS3SSE' field
+func GetStorageS3SSE() string { return global.GetStorageS3SSE() }
+
+// SetStorageS3SSE safely sets the value for global configuration 'StorageS3SSE' field
+func SetStorageS3SSE(v string) { global.SetStorageS3SSE(v) }
+
+// GetStorageS3SSEKMSKeyID safely fetches the Configuration value for state's 'StorageS3SSEKMSKeyID' field
+func (st *ConfigState) GetStorageS3SSEKMSKeyID() (v string) {
+	st.mutex.RLock()
+	v = st.config.StorageS3SSEKMSKeyID
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3SSEKMSKeyID safely sets the Configuration value for state's 'StorageS3SSEKMSKeyID' field
+func (st *ConfigState) SetStorageS3SSEKMSKeyID(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3SSEKMSKeyID = v
+	st.reloadToViper()
+}
+
+// StorageS3SSEKMSKeyIDFlag returns the flag name for the 'StorageS3SSEKMSKeyID' field
+func StorageS3SSEKMSKeyIDFlag() string { return "storage-s3-sse-kms-key-id" }
+
+// GetStorageS3SSEKMSKeyID safely fetches the value for global configuration 'StorageS3SSEKMSKeyID' field
+func GetStorageS3SSEKMSKeyID() string { return global.GetStorageS3SSEKMSKeyID() }
+
+// SetStorageS3SSEKMSKeyID safely sets the value for global configuration 'StorageS3SSEKMSKeyID' field
+func SetStorageS3SSEKMSKeyID(v string) { global.SetStorageS3SSEKMSKeyID(v) }
+
+// GetStorageS3SSECKey safely fetches the Configuration value for state's 'StorageS3SSECKey' field
+func (st *ConfigState) GetStorageS3SSECKey() (v string) {
+	st.mutex.RLock()
+	v = st.config.StorageS3SSECKey
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3SSECKey safely sets the Configuration value for state's 'StorageS3SSECKey' field
+func (st *ConfigState) SetStorageS3SSECKey(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3SSECKey = v
+	st.reloadToViper()
+}
+
+// StorageS3SSECKeyFlag returns the flag name for the 'StorageS3SSECKey' field
+func StorageS3SSECKeyFlag() string { return "storage-s3-sse-c-key" }
+
+// GetStorageS3SSECKey safely fetches the value for global configuration 'StorageS3SSECKey' field
+func GetStorageS3SSECKey() string { return global.GetStorageS3SSECKey() }
+
+// SetStorageS3SSECKey safely sets the value for global configuration 'StorageS3SSECKey' field
+func SetStorageS3SSECKey(v string) { global.SetStorageS3SSECKey(v) }
+
+// GetStorageS3ObjectTags safely fetches the Configuration value for state's 'StorageS3ObjectTags' field
+func (st *ConfigState) GetStorageS3ObjectTags() (v []string) {
+	st.mutex.RLock()
+	v = st.config.StorageS3ObjectTags
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3ObjectTags safely sets the Configuration value for state's 'StorageS3ObjectTags' field
+func (st *ConfigState) SetStorageS3ObjectTags(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3ObjectTags = v
+	st.reloadToViper()
+}
+
+// StorageS3ObjectTagsFlag returns the flag name for the 'StorageS3ObjectTags' field
+func StorageS3ObjectTagsFlag() string { return "storage-s3-object-tags" }
+
+// GetStorageS3ObjectTags safely fetches the value for global configuration 'StorageS3ObjectTags' field
+func GetStorageS3ObjectTags() []string { return global.GetStorageS3ObjectTags() }
+
+// SetStorageS3ObjectTags safely sets the value for global configuration 'StorageS3ObjectTags' field
+func SetStorageS3ObjectTags(v []string) { global.SetStorageS3ObjectTags(v) }
+
+// GetStatusesMaxChars safely fetches the Configuration value for state's 'StatusesMaxChars' field
+func (st *ConfigState) GetStatusesMaxChars() (v int) {
+	st.mutex.RLock()
+	v = st.config.StatusesMaxChars
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesMaxChars safely sets the Configuration value for state's 'StatusesMaxChars' field
+func (st *ConfigState) SetStatusesMaxChars(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesMaxChars = v
+	st.reloadToViper()
+}
+
+// StatusesMaxCharsFlag returns the flag name for the 'StatusesMaxChars' field
+func StatusesMaxCharsFlag() string { return "statuses-max-chars" }
+
+// GetStatusesMaxChars safely fetches the value for global configuration 'StatusesMaxChars' field
+func GetStatusesMaxChars() int { return global.GetStatusesMaxChars() }
+
+// SetStatusesMaxChars safely sets the value for global configuration 'StatusesMaxChars' field
+func SetStatusesMaxChars(v int) { global.SetStatusesMaxChars(v) }
+
+// GetStatusesPollMaxOptions safely fetches the Configuration value for state's 'StatusesPollMaxOptions' field
+func (st *ConfigState) GetStatusesPollMaxOptions() (v int) {
+	st.mutex.RLock()
+	v = st.config.StatusesPollMaxOptions
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesPollMaxOptions safely sets the Configuration value for state's 'StatusesPollMaxOptions' field
+func (st *ConfigState) SetStatusesPollMaxOptions(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesPollMaxOptions = v
+	st.reloadToViper()
+}
+
+// StatusesPollMaxOptionsFlag returns the flag name for the 'StatusesPollMaxOptions' field
+func StatusesPollMaxOptionsFlag() string { return "statuses-poll-max-options" }
+
+// GetStatusesPollMaxOptions safely fetches the value for global configuration 'StatusesPollMaxOptions' field
+func GetStatusesPollMaxOptions() int { return global.GetStatusesPollMaxOptions() }
+
+// SetStatusesPollMaxOptions safely sets the value for global configuration 'StatusesPollMaxOptions' field
+func SetStatusesPollMaxOptions(v int) { global.SetStatusesPollMaxOptions(v) }
+
+// GetStatusesPollOptionMaxChars safely fetches the Configuration value for state's 'StatusesPollOptionMaxChars' field
+func (st *ConfigState) GetStatusesPollOptionMaxChars() (v int) {
+	st.mutex.RLock()
+	v = st.config.StatusesPollOptionMaxChars
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesPollOptionMaxChars safely sets the Configuration value for state's 'StatusesPollOptionMaxChars' field
+func (st *ConfigState) SetStatusesPollOptionMaxChars(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesPollOptionMaxChars = v
+	st.reloadToViper()
+}
+
+// StatusesPollOptionMaxCharsFlag returns the flag name for the 'StatusesPollOptionMaxChars' field
+func StatusesPollOptionMaxCharsFlag() string { return "statuses-poll-option-max-chars" }
+
+// GetStatusesPollOptionMaxChars safely fetches the value for global configuration 'StatusesPollOptionMaxChars' field
+func GetStatusesPollOptionMaxChars() int { return global.GetStatusesPollOptionMaxChars() }
+
+// SetStatusesPollOptionMaxChars safely sets the value for global configuration 'StatusesPollOptionMaxChars' field
+func SetStatusesPollOptionMaxChars(v int) { global.SetStatusesPollOptionMaxChars(v) }
+
+// GetStatusesMediaMaxFiles safely fetches the Configuration value for state's 'StatusesMediaMaxFiles' field
+func (st *ConfigState) GetStatusesMediaMaxFiles() (v int) {
+	st.mutex.RLock()
+	v = st.config.StatusesMediaMaxFiles
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesMediaMaxFiles safely sets the Configuration value for state's 'StatusesMediaMaxFiles' field
+func (st *ConfigState) SetStatusesMediaMaxFiles(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesMediaMaxFiles = v
+	st.reloadToViper()
+}
+
+// StatusesMediaMaxFilesFlag returns the flag name for the 'StatusesMediaMaxFiles' field
+func StatusesMediaMaxFilesFlag() string { return "statuses-media-max-files" }
+
+// GetStatusesMediaMaxFiles safely fetches the value for global configuration 'StatusesMediaMaxFiles' field
+func GetStatusesMediaMaxFiles() int { return global.GetStatusesMediaMaxFiles() }
+
+// SetStatusesMediaMaxFiles safely sets the value for global configuration 'StatusesMediaMaxFiles' field
+func SetStatusesMediaMaxFiles(v int) { global.SetStatusesMediaMaxFiles(v) }
+
+// GetStatusesMediaAllowMixed safely fetches the Configuration value for state's 'StatusesMediaAllowMixed' field
+func (st *ConfigState) GetStatusesMediaAllowMixed() (v bool) {
+	st.mutex.RLock()
+	v = st.config.StatusesMediaAllowMixed
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesMediaAllowMixed safely sets the Configuration value for state's 'StatusesMediaAllowMixed' field
+func (st *ConfigState) SetStatusesMediaAllowMixed(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesMediaAllowMixed = v
+	st.reloadToViper()
+}
+
+// StatusesMediaAllowMixedFlag returns the flag name for the 'StatusesMediaAllowMixed' field
+func StatusesMediaAllowMixedFlag() string { return "statuses-media-allow-mixed" }
+
+// GetStatusesMediaAllowMixed safely fetches the value for global configuration 'StatusesMediaAllowMixed' field
+func GetStatusesMediaAllowMixed() bool { return global.GetStatusesMediaAllowMixed() }
+
+// SetStatusesMediaAllowMixed safely sets the value for global configuration 'StatusesMediaAllowMixed' field
+func SetStatusesMediaAllowMixed(v bool) { global.SetStatusesMediaAllowMixed(v) }
+
+// GetStatusesStripTrackingParams safely fetches the Configuration value for state's 'StatusesStripTrackingParams' field
+func (st *ConfigState) GetStatusesStripTrackingParams() (v bool) {
+	st.mutex.RLock()
+	v = st.config.StatusesStripTrackingParams
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesStripTrackingParams safely sets the Configuration value for state's 'StatusesStripTrackingParams' field
+func (st *ConfigState) SetStatusesStripTrackingParams(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesStripTrackingParams = v
+	st.reloadToViper()
+}
+
+// StatusesStripTrackingParamsFlag returns the flag name for the 'StatusesStripTrackingParams' field
+func StatusesStripTrackingParamsFlag() string { return "statuses-strip-tracking-params" }
+
+// GetStatusesStripTrackingParams safely fetches the value for global configuration 'StatusesStripTrackingParams' field
+func GetStatusesStripTrackingParams() bool { return global.GetStatusesStripTrackingParams() }
+
+// SetStatusesStripTrackingParams safely sets the value for global configuration 'StatusesStripTrackingParams' field
+func SetStatusesStripTrackingParams(v bool) { global.SetStatusesStripTrackingParams(v) }
+
+// GetStatusesTrackingParams safely fetches the Configuration value for state's 'StatusesTrackingParams' field
+func (st *ConfigState) GetStatusesTrackingParams() (v []string) {
+	st.mutex.RLock()
+	v = st.config.StatusesTrackingParams
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesTrackingParams safely sets the Configuration value for state's 'StatusesTrackingParams' field
+func (st *ConfigState) SetStatusesTrackingParams(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesTrackingParams = v
+	st.reloadToViper()
+}
+
+// StatusesTrackingParamsFlag returns the flag name for the 'StatusesTrackingParams' field
+func StatusesTrackingParamsFlag() string { return "statuses-tracking-params" }
+
+// GetStatusesTrackingParams safely fetches the value for global configuration 'StatusesTrackingParams' field
+func GetStatusesTrackingParams() []string { return global.GetStatusesTrackingParams() }
+
+// SetStatusesTrackingParams safely sets the value for global configuration 'StatusesTrackingParams' field
+func SetStatusesTrackingParams(v []string) { global.SetStatusesTrackingParams(v) }
+
+// GetStatusesTrackingParamsSkipDomains safely fetches the Configuration value for state's 'StatusesTrackingParamsSkipDomains' field
+func (st *ConfigState) GetStatusesTrackingParamsSkipDomains() (v []string) {
+	st.mutex.RLock()
+	v = st.config.StatusesTrackingParamsSkipDomains
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesTrackingParamsSkipDomains safely sets the Configuration value for state's 'StatusesTrackingParamsSkipDomains' field
+func (st *ConfigState) SetStatusesTrackingParamsSkipDomains(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesTrackingParamsSkipDomains = v
+	st.reloadToViper()
+}
+
+// StatusesTrackingParamsSkipDomainsFlag returns the flag name for the 'StatusesTrackingParamsSkipDomains' field
+func StatusesTrackingParamsSkipDomainsFlag() string { return "statuses-tracking-params-skip-domains" }
+
+// GetStatusesTrackingParamsSkipDomains safely fetches the value for global configuration 'StatusesTrackingParamsSkipDomains' field
+func GetStatusesTrackingParamsSkipDomains() []string {
+	return global.GetStatusesTrackingParamsSkipDomains()
+}
+
+// SetStatusesTrackingParamsSkipDomains safely sets the value for global configuration 'StatusesTrackingParamsSkipDomains' field
+func SetStatusesTrackingParamsSkipDomains(v []string) { global.SetStatusesTrackingParamsSkipDomains(v) }
+
+// GetStatusesScheduledMaxHorizon safely fetches the Configuration value for state's 'StatusesScheduledMaxHorizon' field
+func (st *ConfigState) GetStatusesScheduledMaxHorizon() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.StatusesScheduledMaxHorizon
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesScheduledMaxHorizon safely sets the Configuration value for state's 'StatusesScheduledMaxHorizon' field
+func (st *ConfigState) SetStatusesScheduledMaxHorizon(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesScheduledMaxHorizon = v
+	st.reloadToViper()
+}
+
+// StatusesScheduledMaxHorizonFlag returns the flag name for the 'StatusesScheduledMaxHorizon' field
+func StatusesScheduledMaxHorizonFlag() string { return "statuses-scheduled-max-horizon" }
+
+// GetStatusesScheduledMaxHorizon safely fetches the value for global configuration 'StatusesScheduledMaxHorizon' field
+func GetStatusesScheduledMaxHorizon() time.Duration { return global.GetStatusesScheduledMaxHorizon() }
+
+// SetStatusesScheduledMaxHorizon safely sets the value for global configuration 'StatusesScheduledMaxHorizon' field
+func SetStatusesScheduledMaxHorizon(v time.Duration) { global.SetStatusesScheduledMaxHorizon(v) }
+
+// GetStatusesExpiryMin safely fetches the Configuration value for state's 'StatusesExpiryMin' field
+func (st *ConfigState) GetStatusesExpiryMin() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.StatusesExpiryMin
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesExpiryMin safely sets the Configuration value for state's 'StatusesExpiryMin' field
+func (st *ConfigState) SetStatusesExpiryMin(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesExpiryMin = v
+	st.reloadToViper()
+}
+
+// StatusesExpiryMinFlag returns the flag name for the 'StatusesExpiryMin' field
+func StatusesExpiryMinFlag() string { return "statuses-expiry-min" }
+
+// GetStatusesExpiryMin safely fetches the value for global configuration 'StatusesExpiryMin' field
+func GetStatusesExpiryMin() time.Duration { return global.GetStatusesExpiryMin() }
+
+// SetStatusesExpiryMin safely sets the value for global configuration 'StatusesExpiryMin' field
+func SetStatusesExpiryMin(v time.Duration) { global.SetStatusesExpiryMin(v) }
+
+// GetStatusesExpiryMax safely fetches the Configuration value for state's 'StatusesExpiryMax' field
+func (st *ConfigState) GetStatusesExpiryMax() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.StatusesExpiryMax
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesExpiryMax safely sets the Configuration value for state's 'StatusesExpiryMax' field
+func (st *ConfigState) SetStatusesExpiryMax(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesExpiryMax = v
+	st.reloadToViper()
+}
+
+// StatusesExpiryMaxFlag returns the flag name for the 'StatusesExpiryMax' field
+func StatusesExpiryMaxFlag() string { return "statuses-expiry-max" }
+
+// GetStatusesExpiryMax safely fetches the value for global configuration 'StatusesExpiryMax' field
+func GetStatusesExpiryMax() time.Duration { return global.GetStatusesExpiryMax() }
+
+// SetStatusesExpiryMax safely sets the value for global configuration 'StatusesExpiryMax' field
+func SetStatusesExpiryMax(v time.Duration) { global.SetStatusesExpiryMax(v) }
+
+// GetStatusesLanguageDetection safely fetches the Configuration value for state's 'StatusesLanguageDetection' field
+func (st *ConfigState) GetStatusesLanguageDetection() (v bool) {
+	st.mutex.RLock()
+	v = st.config.StatusesLanguageDetection
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesLanguageDetection safely sets the Configuration value for state's 'StatusesLanguageDetection' field
+func (st *ConfigState) SetStatusesLanguageDetection(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesLanguageDetection = v
+	st.reloadToViper()
+}
+
+// StatusesLanguageDetectionFlag returns the flag name for the 'StatusesLanguageDetection' field
+func StatusesLanguageDetectionFlag() string { return "statuses-language-detection" }
 
-// SetStorageS3Proxy safely sets the value for global configuration 'StorageS3Proxy' field
-func SetStorageS3Proxy(v bool) { global.SetStorageS3Proxy(v) }
+// GetStatusesLanguageDetection safely fetches the value for global configuration 'StatusesLanguageDetection' field
+func GetStatusesLanguageDetection() bool { return global.GetStatusesLanguageDetection() }
 
-// GetStatusesMaxChars safely fetches the Configuration value for state's 'StatusesMaxChars' field
-func (st *ConfigState) GetStatusesMaxChars() (v int) {
+// SetStatusesLanguageDetection safely sets the value for global configuration 'StatusesLanguageDetection' field
+func SetStatusesLanguageDetection(v bool) { global.SetStatusesLanguageDetection(v) }
+
+// GetStatusesContentTypes safely fetches the Configuration value for state's 'StatusesContentTypes' field
+func (st *ConfigState) GetStatusesContentTypes() (v []string) {
 	st.mutex.RLock()
-	v = st.config.StatusesMaxChars
+	v = st.config.StatusesContentTypes
 	st.mutex.RUnlock()
 	return
 }
 
-// SetStatusesMaxChars safely sets the Configuration value for state's 'StatusesMaxChars' field
-func (st *ConfigState) SetStatusesMaxChars(v int) {
+// SetStatusesContentTypes safely sets the Configuration value for state's 'StatusesContentTypes' field
+func (st *ConfigState) SetStatusesContentTypes(v []string) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.StatusesMaxChars = v
+	st.config.StatusesContentTypes = v
 	st.reloadToViper()
 }
 
-// StatusesMaxCharsFlag returns the flag name for the 'StatusesMaxChars' field
-func StatusesMaxCharsFlag() string { return "statuses-max-chars" }
+// StatusesContentTypesFlag returns the flag name for the 'StatusesContentTypes' field
+func StatusesContentTypesFlag() string { return "statuses-content-types" }
 
-// GetStatusesMaxChars safely fetches the value for global configuration 'StatusesMaxChars' field
-func GetStatusesMaxChars() int { return global.GetStatusesMaxChars() }
+// GetStatusesContentTypes safely fetches the value for global configuration 'StatusesContentTypes' field
+func GetStatusesContentTypes() []string { return global.GetStatusesContentTypes() }
 
-// SetStatusesMaxChars safely sets the value for global configuration 'StatusesMaxChars' field
-func SetStatusesMaxChars(v int) { global.SetStatusesMaxChars(v) }
+// SetStatusesContentTypes safely sets the value for global configuration 'StatusesContentTypes' field
+func SetStatusesContentTypes(v []string) { global.SetStatusesContentTypes(v) }
 
-// GetStatusesPollMaxOptions safely fetches the Configuration value for state's 'StatusesPollMaxOptions' field
-func (st *ConfigState) GetStatusesPollMaxOptions() (v int) {
+// GetNotificationsReadMaxAge safely fetches the Configuration value for state's 'NotificationsReadMaxAge' field
+func (st *ConfigState) GetNotificationsReadMaxAge() (v time.Duration) {
 	st.mutex.RLock()
-	v = st.config.StatusesPollMaxOptions
+	v = st.config.NotificationsReadMaxAge
 	st.mutex.RUnlock()
 	return
 }
 
-// SetStatusesPollMaxOptions safely sets the Configuration value for state's 'StatusesPollMaxOptions' field
-func (st *ConfigState) SetStatusesPollMaxOptions(v int) {
+// SetNotificationsReadMaxAge safely sets the Configuration value for state's 'NotificationsReadMaxAge' field
+func (st *ConfigState) SetNotificationsReadMaxAge(v time.Duration) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.StatusesPollMaxOptions = v
+	st.config.NotificationsReadMaxAge = v
 	st.reloadToViper()
 }
 
-// StatusesPollMaxOptionsFlag returns the flag name for the 'StatusesPollMaxOptions' field
-func StatusesPollMaxOptionsFlag() string { return "statuses-poll-max-options" }
+// NotificationsReadMaxAgeFlag returns the flag name for the 'NotificationsReadMaxAge' field
+func NotificationsReadMaxAgeFlag() string { return "notifications-read-max-age" }
 
-// GetStatusesPollMaxOptions safely fetches the value for global configuration 'StatusesPollMaxOptions' field
-func GetStatusesPollMaxOptions() int { return global.GetStatusesPollMaxOptions() }
+// GetNotificationsReadMaxAge safely fetches the value for global configuration 'NotificationsReadMaxAge' field
+func GetNotificationsReadMaxAge() time.Duration { return global.GetNotificationsReadMaxAge() }
 
-// SetStatusesPollMaxOptions safely sets the value for global configuration 'StatusesPollMaxOptions' field
-func SetStatusesPollMaxOptions(v int) { global.SetStatusesPollMaxOptions(v) }
+// SetNotificationsReadMaxAge safely sets the value for global configuration 'NotificationsReadMaxAge' field
+func SetNotificationsReadMaxAge(v time.Duration) { global.SetNotificationsReadMaxAge(v) }
 
-// GetStatusesPollOptionMaxChars safely fetches the Configuration value for state's 'StatusesPollOptionMaxChars' field
-func (st *ConfigState) GetStatusesPollOptionMaxChars() (v int) {
+// GetNotificationsReadMaxCount safely fetches the Configuration value for state's 'NotificationsReadMaxCount' field
+func (st *ConfigState) GetNotificationsReadMaxCount() (v int) {
 	st.mutex.RLock()
-	v = st.config.StatusesPollOptionMaxChars
+	v = st.config.NotificationsReadMaxCount
 	st.mutex.RUnlock()
 	return
 }
 
-// SetStatusesPollOptionMaxChars safely sets the Configuration value for state's 'StatusesPollOptionMaxChars' field
-func (st *ConfigState) SetStatusesPollOptionMaxChars(v int) {
+// SetNotificationsReadMaxCount safely sets the Configuration value for state's 'NotificationsReadMaxCount' field
+func (st *ConfigState) SetNotificationsReadMaxCount(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.StatusesPollOptionMaxChars = v
+	st.config.NotificationsReadMaxCount = v
 	st.reloadToViper()
 }
 
-// StatusesPollOptionMaxCharsFlag returns the flag name for the 'StatusesPollOptionMaxChars' field
-func StatusesPollOptionMaxCharsFlag() string { return "statuses-poll-option-max-chars" }
+// NotificationsReadMaxCountFlag returns the flag name for the 'NotificationsReadMaxCount' field
+func NotificationsReadMaxCountFlag() string { return "notifications-read-max-count" }
 
-// GetStatusesPollOptionMaxChars safely fetches the value for global configuration 'StatusesPollOptionMaxChars' field
-func GetStatusesPollOptionMaxChars() int { return global.GetStatusesPollOptionMaxChars() }
+// GetNotificationsReadMaxCount safely fetches the value for global configuration 'NotificationsReadMaxCount' field
+func GetNotificationsReadMaxCount() int { return global.GetNotificationsReadMaxCount() }
 
-// SetStatusesPollOptionMaxChars safely sets the value for global configuration 'StatusesPollOptionMaxChars' field
-func SetStatusesPollOptionMaxChars(v int) { global.SetStatusesPollOptionMaxChars(v) }
+// SetNotificationsReadMaxCount safely sets the value for global configuration 'NotificationsReadMaxCount' field
+func SetNotificationsReadMaxCount(v int) { global.SetNotificationsReadMaxCount(v) }
 
-// GetStatusesMediaMaxFiles safely fetches the Configuration value for state's 'StatusesMediaMaxFiles' field
-func (st *ConfigState) GetStatusesMediaMaxFiles() (v int) {
+// GetNotificationsExpiryExemptTypes safely fetches the Configuration value for state's 'NotificationsExpiryExemptTypes' field
+func (st *ConfigState) GetNotificationsExpiryExemptTypes() (v []string) {
 	st.mutex.RLock()
-	v = st.config.StatusesMediaMaxFiles
+	v = st.config.NotificationsExpiryExemptTypes
 	st.mutex.RUnlock()
 	return
 }
 
-// SetStatusesMediaMaxFiles safely sets the Configuration value for state's 'StatusesMediaMaxFiles' field
-func (st *ConfigState) SetStatusesMediaMaxFiles(v int) {
+// SetNotificationsExpiryExemptTypes safely sets the Configuration value for state's 'NotificationsExpiryExemptTypes' field
+func (st *ConfigState) SetNotificationsExpiryExemptTypes(v []string) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.StatusesMediaMaxFiles = v
+	st.config.NotificationsExpiryExemptTypes = v
 	st.reloadToViper()
 }
 
-// StatusesMediaMaxFilesFlag returns the flag name for the 'StatusesMediaMaxFiles' field
-func StatusesMediaMaxFilesFlag() string { return "statuses-media-max-files" }
+// NotificationsExpiryExemptTypesFlag returns the flag name for the 'NotificationsExpiryExemptTypes' field
+func NotificationsExpiryExemptTypesFlag() string { return "notifications-expiry-exempt-types" }
 
-// GetStatusesMediaMaxFiles safely fetches the value for global configuration 'StatusesMediaMaxFiles' field
-func GetStatusesMediaMaxFiles() int { return global.GetStatusesMediaMaxFiles() }
+// GetNotificationsExpiryExemptTypes safely fetches the value for global configuration 'NotificationsExpiryExemptTypes' field
+func GetNotificationsExpiryExemptTypes() []string { return global.GetNotificationsExpiryExemptTypes() }
 
-// SetStatusesMediaMaxFiles safely sets the value for global configuration 'StatusesMediaMaxFiles' field
-func SetStatusesMediaMaxFiles(v int) { global.SetStatusesMediaMaxFiles(v) }
+// SetNotificationsExpiryExemptTypes safely sets the value for global configuration 'NotificationsExpiryExemptTypes' field
+func SetNotificationsExpiryExemptTypes(v []string) { global.SetNotificationsExpiryExemptTypes(v) }
 
 // GetLetsEncryptEnabled safely fetches the Configuration value for state's 'LetsEncryptEnabled' field
 func (st *ConfigState) GetLetsEncryptEnabled() (v bool) {
@@ -2500,6 +3179,58 @@ func GetAdvancedRateLimitExceptions() []string { return global.GetAdvancedRateLi
 // SetAdvancedRateLimitExceptions safely sets the value for global configuration 'AdvancedRateLimitExceptions' field
 func SetAdvancedRateLimitExceptions(v []string) { global.SetAdvancedRateLimitExceptions(v) }
 
+// GetAdvancedWebfingerRateLimitRequests safely fetches the Configuration value for state's 'AdvancedWebfingerRateLimitRequests' field
+func (st *ConfigState) GetAdvancedWebfingerRateLimitRequests() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedWebfingerRateLimitRequests
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedWebfingerRateLimitRequests safely sets the Configuration value for state's 'AdvancedWebfingerRateLimitRequests' field
+func (st *ConfigState) SetAdvancedWebfingerRateLimitRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedWebfingerRateLimitRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedWebfingerRateLimitRequestsFlag returns the flag name for the 'AdvancedWebfingerRateLimitRequests' field
+func AdvancedWebfingerRateLimitRequestsFlag() string { return "advanced-webfinger-rate-limit-requests" }
+
+// GetAdvancedWebfingerRateLimitRequests safely fetches the value for global configuration 'AdvancedWebfingerRateLimitRequests' field
+func GetAdvancedWebfingerRateLimitRequests() int {
+	return global.GetAdvancedWebfingerRateLimitRequests()
+}
+
+// SetAdvancedWebfingerRateLimitRequests safely sets the value for global configuration 'AdvancedWebfingerRateLimitRequests' field
+func SetAdvancedWebfingerRateLimitRequests(v int) { global.SetAdvancedWebfingerRateLimitRequests(v) }
+
+// GetAdvancedWebfingerRateLimitBurst safely fetches the Configuration value for state's 'AdvancedWebfingerRateLimitBurst' field
+func (st *ConfigState) GetAdvancedWebfingerRateLimitBurst() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedWebfingerRateLimitBurst
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedWebfingerRateLimitBurst safely sets the Configuration value for state's 'AdvancedWebfingerRateLimitBurst' field
+func (st *ConfigState) SetAdvancedWebfingerRateLimitBurst(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedWebfingerRateLimitBurst = v
+	st.reloadToViper()
+}
+
+// AdvancedWebfingerRateLimitBurstFlag returns the flag name for the 'AdvancedWebfingerRateLimitBurst' field
+func AdvancedWebfingerRateLimitBurstFlag() string { return "advanced-webfinger-rate-limit-burst" }
+
+// GetAdvancedWebfingerRateLimitBurst safely fetches the value for global configuration 'AdvancedWebfingerRateLimitBurst' field
+func GetAdvancedWebfingerRateLimitBurst() int { return global.GetAdvancedWebfingerRateLimitBurst() }
+
+// SetAdvancedWebfingerRateLimitBurst safely sets the value for global configuration 'AdvancedWebfingerRateLimitBurst' field
+func SetAdvancedWebfingerRateLimitBurst(v int) { global.SetAdvancedWebfingerRateLimitBurst(v) }
+
 // GetAdvancedThrottlingMultiplier safely fetches the Configuration value for state's 'AdvancedThrottlingMultiplier' field
 func (st *ConfigState) GetAdvancedThrottlingMultiplier() (v int) {
 	st.mutex.RLock()
@@ -2625,6 +3356,295 @@ func GetAdvancedHeaderFilterMode() string { return global.GetAdvancedHeaderFilte
 // SetAdvancedHeaderFilterMode safely sets the value for global configuration 'AdvancedHeaderFilterMode' field
 func SetAdvancedHeaderFilterMode(v string) { global.SetAdvancedHeaderFilterMode(v) }
 
+// GetAdvancedSignedFetchExempt safely fetches the Configuration value for state's 'AdvancedSignedFetchExempt' field
+func (st *ConfigState) GetAdvancedSignedFetchExempt() (v []string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedSignedFetchExempt
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedSignedFetchExempt safely sets the Configuration value for state's 'AdvancedSignedFetchExempt' field
+func (st *ConfigState) SetAdvancedSignedFetchExempt(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedSignedFetchExempt = v
+	st.reloadToViper()
+}
+
+// AdvancedSignedFetchExemptFlag returns the flag name for the 'AdvancedSignedFetchExempt' field
+func AdvancedSignedFetchExemptFlag() string { return "advanced-signed-fetch-exempt" }
+
+// GetAdvancedSignedFetchExempt safely fetches the value for global configuration 'AdvancedSignedFetchExempt' field
+func GetAdvancedSignedFetchExempt() []string { return global.GetAdvancedSignedFetchExempt() }
+
+// SetAdvancedSignedFetchExempt safely sets the value for global configuration 'AdvancedSignedFetchExempt' field
+func SetAdvancedSignedFetchExempt(v []string) { global.SetAdvancedSignedFetchExempt(v) }
+
+// GetAdvancedOAuthRegistrationAllowLocalhost safely fetches the Configuration value for state's 'AdvancedOAuthRegistrationAllowLocalhost' field
+func (st *ConfigState) GetAdvancedOAuthRegistrationAllowLocalhost() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AdvancedOAuthRegistrationAllowLocalhost
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedOAuthRegistrationAllowLocalhost safely sets the Configuration value for state's 'AdvancedOAuthRegistrationAllowLocalhost' field
+func (st *ConfigState) SetAdvancedOAuthRegistrationAllowLocalhost(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedOAuthRegistrationAllowLocalhost = v
+	st.reloadToViper()
+}
+
+// AdvancedOAuthRegistrationAllowLocalhostFlag returns the flag name for the 'AdvancedOAuthRegistrationAllowLocalhost' field
+func AdvancedOAuthRegistrationAllowLocalhostFlag() string {
+	return "advanced-oauth-registration-allow-localhost"
+}
+
+// GetAdvancedOAuthRegistrationAllowLocalhost safely fetches the value for global configuration 'AdvancedOAuthRegistrationAllowLocalhost' field
+func GetAdvancedOAuthRegistrationAllowLocalhost() bool {
+	return global.GetAdvancedOAuthRegistrationAllowLocalhost()
+}
+
+// SetAdvancedOAuthRegistrationAllowLocalhost safely sets the value for global configuration 'AdvancedOAuthRegistrationAllowLocalhost' field
+func SetAdvancedOAuthRegistrationAllowLocalhost(v bool) {
+	global.SetAdvancedOAuthRegistrationAllowLocalhost(v)
+}
+
+// GetAdvancedOAuthLoopbackRedirectAnyPort safely fetches the Configuration value for state's 'AdvancedOAuthLoopbackRedirectAnyPort' field
+func (st *ConfigState) GetAdvancedOAuthLoopbackRedirectAnyPort() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AdvancedOAuthLoopbackRedirectAnyPort
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedOAuthLoopbackRedirectAnyPort safely sets the Configuration value for state's 'AdvancedOAuthLoopbackRedirectAnyPort' field
+func (st *ConfigState) SetAdvancedOAuthLoopbackRedirectAnyPort(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedOAuthLoopbackRedirectAnyPort = v
+	st.reloadToViper()
+}
+
+// AdvancedOAuthLoopbackRedirectAnyPortFlag returns the flag name for the 'AdvancedOAuthLoopbackRedirectAnyPort' field
+func AdvancedOAuthLoopbackRedirectAnyPortFlag() string {
+	return "advanced-oauth-loopback-redirect-any-port"
+}
+
+// GetAdvancedOAuthLoopbackRedirectAnyPort safely fetches the value for global configuration 'AdvancedOAuthLoopbackRedirectAnyPort' field
+func GetAdvancedOAuthLoopbackRedirectAnyPort() bool {
+	return global.GetAdvancedOAuthLoopbackRedirectAnyPort()
+}
+
+// SetAdvancedOAuthLoopbackRedirectAnyPort safely sets the value for global configuration 'AdvancedOAuthLoopbackRedirectAnyPort' field
+func SetAdvancedOAuthLoopbackRedirectAnyPort(v bool) {
+	global.SetAdvancedOAuthLoopbackRedirectAnyPort(v)
+}
+
+// GetAdvancedOAuthMaxTokensPerClient safely fetches the Configuration value for state's 'AdvancedOAuthMaxTokensPerClient' field
+func (st *ConfigState) GetAdvancedOAuthMaxTokensPerClient() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedOAuthMaxTokensPerClient
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedOAuthMaxTokensPerClient safely sets the Configuration value for state's 'AdvancedOAuthMaxTokensPerClient' field
+func (st *ConfigState) SetAdvancedOAuthMaxTokensPerClient(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedOAuthMaxTokensPerClient = v
+	st.reloadToViper()
+}
+
+// AdvancedOAuthMaxTokensPerClientFlag returns the flag name for the 'AdvancedOAuthMaxTokensPerClient' field
+func AdvancedOAuthMaxTokensPerClientFlag() string { return "advanced-oauth-max-tokens-per-client" }
+
+// GetAdvancedOAuthMaxTokensPerClient safely fetches the value for global configuration 'AdvancedOAuthMaxTokensPerClient' field
+func GetAdvancedOAuthMaxTokensPerClient() int { return global.GetAdvancedOAuthMaxTokensPerClient() }
+
+// SetAdvancedOAuthMaxTokensPerClient safely sets the value for global configuration 'AdvancedOAuthMaxTokensPerClient' field
+func SetAdvancedOAuthMaxTokensPerClient(v int) { global.SetAdvancedOAuthMaxTokensPerClient(v) }
+
+// GetAdvancedOAuthDemoClientIDs safely fetches the Configuration value for state's 'AdvancedOAuthDemoClientIDs' field
+func (st *ConfigState) GetAdvancedOAuthDemoClientIDs() (v []string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedOAuthDemoClientIDs
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedOAuthDemoClientIDs safely sets the Configuration value for state's 'AdvancedOAuthDemoClientIDs' field
+func (st *ConfigState) SetAdvancedOAuthDemoClientIDs(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedOAuthDemoClientIDs = v
+	st.reloadToViper()
+}
+
+// AdvancedOAuthDemoClientIDsFlag returns the flag name for the 'AdvancedOAuthDemoClientIDs' field
+func AdvancedOAuthDemoClientIDsFlag() string { return "advanced-oauth-demo-client-ids" }
+
+// GetAdvancedOAuthDemoClientIDs safely fetches the value for global configuration 'AdvancedOAuthDemoClientIDs' field
+func GetAdvancedOAuthDemoClientIDs() []string { return global.GetAdvancedOAuthDemoClientIDs() }
+
+// SetAdvancedOAuthDemoClientIDs safely sets the value for global configuration 'AdvancedOAuthDemoClientIDs' field
+func SetAdvancedOAuthDemoClientIDs(v []string) { global.SetAdvancedOAuthDemoClientIDs(v) }
+
+// GetAdvancedOAuthAccessTokenFormat safely fetches the Configuration value for state's 'AdvancedOAuthAccessTokenFormat' field
+func (st *ConfigState) GetAdvancedOAuthAccessTokenFormat() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedOAuthAccessTokenFormat
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedOAuthAccessTokenFormat safely sets the Configuration value for state's 'AdvancedOAuthAccessTokenFormat' field
+func (st *ConfigState) SetAdvancedOAuthAccessTokenFormat(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedOAuthAccessTokenFormat = v
+	st.reloadToViper()
+}
+
+// AdvancedOAuthAccessTokenFormatFlag returns the flag name for the 'AdvancedOAuthAccessTokenFormat' field
+func AdvancedOAuthAccessTokenFormatFlag() string { return "advanced-oauth-access-token-format" }
+
+// GetAdvancedOAuthAccessTokenFormat safely fetches the value for global configuration 'AdvancedOAuthAccessTokenFormat' field
+func GetAdvancedOAuthAccessTokenFormat() string { return global.GetAdvancedOAuthAccessTokenFormat() }
+
+// SetAdvancedOAuthAccessTokenFormat safely sets the value for global configuration 'AdvancedOAuthAccessTokenFormat' field
+func SetAdvancedOAuthAccessTokenFormat(v string) { global.SetAdvancedOAuthAccessTokenFormat(v) }
+
+// GetAdvancedOAuthJWTSigningKeyPath safely fetches the Configuration value for state's 'AdvancedOAuthJWTSigningKeyPath' field
+func (st *ConfigState) GetAdvancedOAuthJWTSigningKeyPath() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedOAuthJWTSigningKeyPath
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedOAuthJWTSigningKeyPath safely sets the Configuration value for state's 'AdvancedOAuthJWTSigningKeyPath' field
+func (st *ConfigState) SetAdvancedOAuthJWTSigningKeyPath(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedOAuthJWTSigningKeyPath = v
+	st.reloadToViper()
+}
+
+// AdvancedOAuthJWTSigningKeyPathFlag returns the flag name for the 'AdvancedOAuthJWTSigningKeyPath' field
+func AdvancedOAuthJWTSigningKeyPathFlag() string { return "advanced-oauth-jwt-signing-key-path" }
+
+// GetAdvancedOAuthJWTSigningKeyPath safely fetches the value for global configuration 'AdvancedOAuthJWTSigningKeyPath' field
+func GetAdvancedOAuthJWTSigningKeyPath() string { return global.GetAdvancedOAuthJWTSigningKeyPath() }
+
+// SetAdvancedOAuthJWTSigningKeyPath safely sets the value for global configuration 'AdvancedOAuthJWTSigningKeyPath' field
+func SetAdvancedOAuthJWTSigningKeyPath(v string) { global.SetAdvancedOAuthJWTSigningKeyPath(v) }
+
+// GetAdvancedOAuthJWTLifetime safely fetches the Configuration value for state's 'AdvancedOAuthJWTLifetime' field
+func (st *ConfigState) GetAdvancedOAuthJWTLifetime() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AdvancedOAuthJWTLifetime
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedOAuthJWTLifetime safely sets the Configuration value for state's 'AdvancedOAuthJWTLifetime' field
+func (st *ConfigState) SetAdvancedOAuthJWTLifetime(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedOAuthJWTLifetime = v
+	st.reloadToViper()
+}
+
+// AdvancedOAuthJWTLifetimeFlag returns the flag name for the 'AdvancedOAuthJWTLifetime' field
+func AdvancedOAuthJWTLifetimeFlag() string { return "advanced-oauth-jwt-lifetime" }
+
+// GetAdvancedOAuthJWTLifetime safely fetches the value for global configuration 'AdvancedOAuthJWTLifetime' field
+func GetAdvancedOAuthJWTLifetime() time.Duration { return global.GetAdvancedOAuthJWTLifetime() }
+
+// SetAdvancedOAuthJWTLifetime safely sets the value for global configuration 'AdvancedOAuthJWTLifetime' field
+func SetAdvancedOAuthJWTLifetime(v time.Duration) { global.SetAdvancedOAuthJWTLifetime(v) }
+
+// GetAdvancedStreamingPingInterval safely fetches the Configuration value for state's 'AdvancedStreamingPingInterval' field
+func (st *ConfigState) GetAdvancedStreamingPingInterval() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AdvancedStreamingPingInterval
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedStreamingPingInterval safely sets the Configuration value for state's 'AdvancedStreamingPingInterval' field
+func (st *ConfigState) SetAdvancedStreamingPingInterval(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedStreamingPingInterval = v
+	st.reloadToViper()
+}
+
+// AdvancedStreamingPingIntervalFlag returns the flag name for the 'AdvancedStreamingPingInterval' field
+func AdvancedStreamingPingIntervalFlag() string { return "advanced-streaming-ping-interval" }
+
+// GetAdvancedStreamingPingInterval safely fetches the value for global configuration 'AdvancedStreamingPingInterval' field
+func GetAdvancedStreamingPingInterval() time.Duration {
+	return global.GetAdvancedStreamingPingInterval()
+}
+
+// SetAdvancedStreamingPingInterval safely sets the value for global configuration 'AdvancedStreamingPingInterval' field
+func SetAdvancedStreamingPingInterval(v time.Duration) { global.SetAdvancedStreamingPingInterval(v) }
+
+// GetAdvancedStreamingQueueSize safely fetches the Configuration value for state's 'AdvancedStreamingQueueSize' field
+func (st *ConfigState) GetAdvancedStreamingQueueSize() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedStreamingQueueSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedStreamingQueueSize safely sets the Configuration value for state's 'AdvancedStreamingQueueSize' field
+func (st *ConfigState) SetAdvancedStreamingQueueSize(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedStreamingQueueSize = v
+	st.reloadToViper()
+}
+
+// AdvancedStreamingQueueSizeFlag returns the flag name for the 'AdvancedStreamingQueueSize' field
+func AdvancedStreamingQueueSizeFlag() string { return "advanced-streaming-queue-size" }
+
+// GetAdvancedStreamingQueueSize safely fetches the value for global configuration 'AdvancedStreamingQueueSize' field
+func GetAdvancedStreamingQueueSize() int { return global.GetAdvancedStreamingQueueSize() }
+
+// SetAdvancedStreamingQueueSize safely sets the value for global configuration 'AdvancedStreamingQueueSize' field
+func SetAdvancedStreamingQueueSize(v int) { global.SetAdvancedStreamingQueueSize(v) }
+
+// GetAdvancedStreamingSlowConsumer safely fetches the Configuration value for state's 'AdvancedStreamingSlowConsumer' field
+func (st *ConfigState) GetAdvancedStreamingSlowConsumer() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedStreamingSlowConsumer
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedStreamingSlowConsumer safely sets the Configuration value for state's 'AdvancedStreamingSlowConsumer' field
+func (st *ConfigState) SetAdvancedStreamingSlowConsumer(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedStreamingSlowConsumer = v
+	st.reloadToViper()
+}
+
+// AdvancedStreamingSlowConsumerFlag returns the flag name for the 'AdvancedStreamingSlowConsumer' field
+func AdvancedStreamingSlowConsumerFlag() string { return "advanced-streaming-slow-consumer" }
+
+// GetAdvancedStreamingSlowConsumer safely fetches the value for global configuration 'AdvancedStreamingSlowConsumer' field
+func GetAdvancedStreamingSlowConsumer() string { return global.GetAdvancedStreamingSlowConsumer() }
+
+// SetAdvancedStreamingSlowConsumer safely sets the value for global configuration 'AdvancedStreamingSlowConsumer' field
+func SetAdvancedStreamingSlowConsumer(v string) { global.SetAdvancedStreamingSlowConsumer(v) }
+
 // GetHTTPClientAllowIPs safely fetches the Configuration value for state's 'HTTPClient.AllowIPs' field
 func (st *ConfigState) GetHTTPClientAllowIPs() (v []string) {
 	st.mutex.RLock()
@@ -3875,6 +4895,56 @@ func GetCacheVisibilityMemRatio() float64 { return global.GetCacheVisibilityMemR
 // SetCacheVisibilityMemRatio safely sets the value for global configuration 'Cache.VisibilityMemRatio' field
 func SetCacheVisibilityMemRatio(v float64) { global.SetCacheVisibilityMemRatio(v) }
 
+// GetCacheWebfingerResponseTTL safely fetches the Configuration value for state's 'Cache.WebfingerResponseTTL' field
+func (st *ConfigState) GetCacheWebfingerResponseTTL() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.Cache.WebfingerResponseTTL
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheWebfingerResponseTTL safely sets the Configuration value for state's 'Cache.WebfingerResponseTTL' field
+func (st *ConfigState) SetCacheWebfingerResponseTTL(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.WebfingerResponseTTL = v
+	st.reloadToViper()
+}
+
+// CacheWebfingerResponseTTLFlag returns the flag name for the 'Cache.WebfingerResponseTTL' field
+func CacheWebfingerResponseTTLFlag() string { return "cache-webfinger-response-ttl" }
+
+// GetCacheWebfingerResponseTTL safely fetches the value for global configuration 'Cache.WebfingerResponseTTL' field
+func GetCacheWebfingerResponseTTL() time.Duration { return global.GetCacheWebfingerResponseTTL() }
+
+// SetCacheWebfingerResponseTTL safely sets the value for global configuration 'Cache.WebfingerResponseTTL' field
+func SetCacheWebfingerResponseTTL(v time.Duration) { global.SetCacheWebfingerResponseTTL(v) }
+
+// GetCacheWebfingerResponseMax safely fetches the Configuration value for state's 'Cache.WebfingerResponseMax' field
+func (st *ConfigState) GetCacheWebfingerResponseMax() (v int) {
+	st.mutex.RLock()
+	v = st.config.Cache.WebfingerResponseMax
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheWebfingerResponseMax safely sets the Configuration value for state's 'Cache.WebfingerResponseMax' field
+func (st *ConfigState) SetCacheWebfingerResponseMax(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.WebfingerResponseMax = v
+	st.reloadToViper()
+}
+
+// CacheWebfingerResponseMaxFlag returns the flag name for the 'Cache.WebfingerResponseMax' field
+func CacheWebfingerResponseMaxFlag() string { return "cache-webfinger-response-max" }
+
+// GetCacheWebfingerResponseMax safely fetches the value for global configuration 'Cache.WebfingerResponseMax' field
+func GetCacheWebfingerResponseMax() int { return global.GetCacheWebfingerResponseMax() }
+
+// SetCacheWebfingerResponseMax safely sets the value for global configuration 'Cache.WebfingerResponseMax' field
+func SetCacheWebfingerResponseMax(v int) { global.SetCacheWebfingerResponseMax(v) }
+
 // GetAdminAccountUsername safely fetches the Configuration value for state's 'AdminAccountUsername' field
 func (st *ConfigState) GetAdminAccountUsername() (v string) {
 	st.mutex.RLock()
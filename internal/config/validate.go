@@ -18,14 +18,33 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"codeberg.org/gruf/go-bytesize"
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
+// Limits on S3 object tags, see:
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-tagging.html
+const (
+	maxS3ObjectTags     = 10
+	maxS3ObjectTagKey   = 128
+	maxS3ObjectTagValue = 256
+)
+
+// s3ObjectTagChars matches strings containing only
+// the characters permitted in S3 object tags.
+var s3ObjectTagChars = regexp.MustCompile(`^[\p{L}\p{Z}\p{N}+\-=._:/@]*$`)
+
 // Validate validates global config settings.
 func Validate() error {
 	// Gather all validation errors in
@@ -98,6 +117,16 @@ func Validate() error {
 		)
 	}
 
+	// `instance-federation-delay` should
+	// be within sensible bounds, as it
+	// holds back delivery of new statuses.
+	if delay := GetInstanceFederationDelay(); delay < 0 || delay > InstanceFederationDelayMax {
+		errf(
+			"%s must be between 0 and %s, provided value was %s",
+			InstanceFederationDelayFlag(), InstanceFederationDelayMax, delay,
+		)
+	}
+
 	// Parse `instance-languages`, and
 	// set enriched version into config.
 	parsedLangs, err := language.InitLangs(GetInstanceLanguages().TagStrs())
@@ -118,6 +147,183 @@ func Validate() error {
 		errf("%s must be set", WebAssetBaseDirFlag())
 	}
 
+	// S3 storage classes, if set, must
+	// look like a storage class name.
+	for _, sc := range []struct {
+		flag  string
+		value string
+	}{
+		{StorageS3StorageClassFlag(), GetStorageS3StorageClass()},
+		{StorageS3StorageClassEmojisFlag(), GetStorageS3StorageClassEmojis()},
+	} {
+		if sc.value == "" {
+			// Not set.
+			continue
+		}
+
+		if strings.TrimSpace(sc.value) == "" {
+			errf("%s must not be blank when set", sc.flag)
+		} else if strings.ContainsFunc(sc.value, unicode.IsSpace) {
+			errf("%s must not contain whitespace, provided value was %q", sc.flag, sc.value)
+		}
+	}
+
+	// Thumbnails must be big enough to be
+	// useful, and small enough to be smaller
+	// than most of the originals; quality
+	// below 10 is just blocky artifacts.
+	const (
+		minThumbSize    = 64
+		maxThumbSize    = 2048
+		minThumbQuality = 10
+		maxThumbQuality = 100
+	)
+	if size := GetMediaThumbnailMaxSize(); size < minThumbSize || size > maxThumbSize {
+		errf(
+			"%s must be between %d and %d, provided value was %d",
+			MediaThumbnailMaxSizeFlag(), minThumbSize, maxThumbSize, size,
+		)
+	}
+	if quality := GetMediaThumbnailQuality(); quality < minThumbQuality || quality > maxThumbQuality {
+		errf(
+			"%s must be between %d and %d, provided value was %d",
+			MediaThumbnailQualityFlag(), minThumbQuality, maxThumbQuality, quality,
+		)
+	}
+
+	// S3 multipart part size, if set, must be
+	// within the limits allowed by S3 itself.
+	const (
+		minS3PartSize = 5 * bytesize.MiB
+		maxS3PartSize = 5 * bytesize.GiB
+	)
+	if partSize := GetStorageS3PartSize(); partSize != 0 &&
+		(partSize < minS3PartSize || partSize > maxS3PartSize) {
+		errf(
+			"%s must be between %s and %s, provided value was %s",
+			StorageS3PartSizeFlag(), minS3PartSize, maxS3PartSize, partSize,
+		)
+	}
+
+	// S3 multipart upload threads must be
+	// zero (default), or a sensible number.
+	const maxS3NumThreads = 64
+	if numThreads := GetStorageS3NumThreads(); numThreads < 0 || numThreads > maxS3NumThreads {
+		errf(
+			"%s must be between 0 and %d, provided value was %d",
+			StorageS3NumThreadsFlag(), maxS3NumThreads, numThreads,
+		)
+	}
+
+	// Concurrent stream parts are uploaded
+	// with one buffer per thread, so can't
+	// be done with only a single thread.
+	if GetStorageS3ConcurrentStreamParts() && GetStorageS3NumThreads() == 1 {
+		errf(
+			"%s requires %s to be either 0 (default) or greater than 1",
+			StorageS3ConcurrentStreamPartsFlag(), StorageS3NumThreadsFlag(),
+		)
+	}
+
+	// S3 server-side encryption must be a
+	// known mode, with usable key material.
+	switch sse := GetStorageS3SSE(); sse {
+	case "", "sse-s3", "sse-kms":
+		// No key needed, or
+		// default key is fine.
+
+	case "sse-c":
+		if key, err := base64.StdEncoding.DecodeString(GetStorageS3SSECKey()); err != nil || len(key) != 32 {
+			errf(
+				"%s must be a base64-encoded 256-bit key when %s is 'sse-c'",
+				StorageS3SSECKeyFlag(), StorageS3SSEFlag(),
+			)
+		}
+
+		// Presigned URLs can't carry
+		// the key, so must be proxied.
+		if !GetStorageS3Proxy() {
+			errf(
+				"%s 'sse-c' requires %s to be true",
+				StorageS3SSEFlag(), StorageS3ProxyFlag(),
+			)
+		}
+
+	default:
+		errf(
+			"%s must be one of 'sse-s3', 'sse-kms', or 'sse-c' if set, provided value was %q",
+			StorageS3SSEFlag(), sse,
+		)
+	}
+
+	// S3 object tags must be known media tags, or
+	// fixed tags within the limits allowed by S3.
+	objectTags := GetStorageS3ObjectTags()
+	if len(objectTags) > maxS3ObjectTags {
+		errf(
+			"%s must contain at most %d tags, provided value contained %d",
+			StorageS3ObjectTagsFlag(), maxS3ObjectTags, len(objectTags),
+		)
+	}
+	tagKeys := make(map[string]struct{}, len(objectTags))
+	for _, tag := range objectTags {
+		key, value, fixed := strings.Cut(tag, "=")
+		switch {
+		case !fixed && key != "media-type" && key != "media-size" && key != "account-id":
+			errf(
+				"%s entry %q must be one of 'media-type', 'media-size', 'account-id', or 'key=value'",
+				StorageS3ObjectTagsFlag(), tag,
+			)
+			continue
+
+		case key == "" || utf8.RuneCountInString(key) > maxS3ObjectTagKey || !s3ObjectTagChars.MatchString(key):
+			errf(
+				"%s entry %q key must be 1 to %d letters, numbers, spaces, or any of + - = . _ : / @",
+				StorageS3ObjectTagsFlag(), tag, maxS3ObjectTagKey,
+			)
+			continue
+
+		case utf8.RuneCountInString(value) > maxS3ObjectTagValue || !s3ObjectTagChars.MatchString(value):
+			errf(
+				"%s entry %q value must be up to %d letters, numbers, spaces, or any of + - = . _ : / @",
+				StorageS3ObjectTagsFlag(), tag, maxS3ObjectTagValue,
+			)
+			continue
+		}
+
+		if _, ok := tagKeys[key]; ok {
+			errf("%s contains duplicate tag key %q", StorageS3ObjectTagsFlag(), key)
+		}
+		tagKeys[key] = struct{}{}
+	}
+
+	// Status expiry bounds must
+	// make sense with each other.
+	if expiryMin, expiryMax := GetStatusesExpiryMin(), GetStatusesExpiryMax(); expiryMin <= 0 || expiryMax < expiryMin {
+		errf(
+			"%s must be greater than 0, and %s must be greater than or equal to it, provided values were %s and %s",
+			StatusesExpiryMinFlag(), StatusesExpiryMaxFlag(), expiryMin, expiryMax,
+		)
+	}
+
+	// Status content types must be known
+	// ones, and at least one must be allowed.
+	contentTypes := GetStatusesContentTypes()
+	if len(contentTypes) == 0 {
+		errf("%s must contain at least one content type", StatusesContentTypesFlag())
+	}
+	for _, contentType := range contentTypes {
+		switch contentType {
+		case "text/plain", "text/markdown":
+			// Fine.
+		default:
+			errf(
+				"%s entry %q must be one of 'text/plain' or 'text/markdown'",
+				StatusesContentTypesFlag(), contentType,
+			)
+		}
+	}
+
 	// Custom / LE TLS settings.
 	//
 	// Only one of custom certs or LE can be set,
@@ -144,5 +350,73 @@ func Validate() error {
 		)
 	}
 
+	// `advanced-streaming-slow-consumer` should
+	// be "disconnect" or "drop-oldest".
+	switch policy := GetAdvancedStreamingSlowConsumer(); policy {
+	case StreamingSlowConsumerDisconnect, StreamingSlowConsumerDropOldest:
+		// No problem.
+
+	default:
+		errf(
+			"%s must be set to either disconnect or drop-oldest, provided value was %s",
+			AdvancedStreamingSlowConsumerFlag(), policy,
+		)
+	}
+
+	switch format := GetAdvancedOAuthAccessTokenFormat(); format {
+	case OAuthAccessTokenFormatOpaque:
+		// No problem.
+
+	case OAuthAccessTokenFormatJWT:
+		if GetAdvancedOAuthJWTSigningKeyPath() == "" {
+			errf(
+				"%s must be set when %s is jwt",
+				AdvancedOAuthJWTSigningKeyPathFlag(), AdvancedOAuthAccessTokenFormatFlag(),
+			)
+		}
+
+		if GetAdvancedOAuthJWTLifetime() <= 0 {
+			errf(
+				"%s must be greater than 0, provided value was %s",
+				AdvancedOAuthJWTLifetimeFlag(), GetAdvancedOAuthJWTLifetime(),
+			)
+		}
+
+	default:
+		errf(
+			"%s must be set to either opaque or jwt, provided value was %s",
+			AdvancedOAuthAccessTokenFormatFlag(), format,
+		)
+	}
+
+	// `advanced-signed-fetch-exempt` entries
+	// must be either valid CIDRs or domains.
+	for _, exempt := range GetAdvancedSignedFetchExempt() {
+		if prefix, err := netip.ParsePrefix(exempt); err == nil {
+			if prefix.Bits() == 0 {
+				log.Warnf(nil,
+					"%s entry %s would exempt *every* address from http signature checks; it will be ignored",
+					AdvancedSignedFetchExemptFlag(), exempt,
+				)
+			}
+			continue
+		}
+
+		if strings.Contains(exempt, "*") {
+			log.Warnf(nil,
+				"%s entry %s is a wildcard; wildcards are not supported for http signature exemptions as this would be a security risk, it will be ignored",
+				AdvancedSignedFetchExemptFlag(), exempt,
+			)
+			continue
+		}
+
+		if _, ok := dns.IsDomainName(exempt); !ok || strings.ContainsAny(exempt, "/:") {
+			errf(
+				"%s entry %s is neither a valid CIDR nor a valid domain",
+				AdvancedSignedFetchExemptFlag(), exempt,
+			)
+		}
+	}
+
 	return errs.Combine()
 }
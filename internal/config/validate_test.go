@@ -19,7 +19,9 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
+	"codeberg.org/gruf/go-bytesize"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/testrig"
@@ -140,6 +142,181 @@ func (suite *ConfigValidateTestSuite) TestValidateConfigBadProtocolNoHost() {
 	suite.EqualError(err, "host must be set\nprotocol must be set to either http or https, provided value was foo")
 }
 
+func (suite *ConfigValidateTestSuite) TestValidateSignedFetchExemptOK() {
+	testrig.InitTestConfig()
+
+	config.SetAdvancedSignedFetchExempt([]string{
+		"cdn.example.org",
+		"192.0.2.0/24",
+		"2001:db8::/64",
+	})
+
+	err := config.Validate()
+	suite.NoError(err)
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateSignedFetchExemptWildcard() {
+	testrig.InitTestConfig()
+
+	// Wildcards are warned
+	// about, but not fatal.
+	config.SetAdvancedSignedFetchExempt([]string{
+		"*",
+		"*.example.org",
+		"0.0.0.0/0",
+		"::/0",
+	})
+
+	err := config.Validate()
+	suite.NoError(err)
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateSignedFetchExemptInvalid() {
+	testrig.InitTestConfig()
+
+	config.SetAdvancedSignedFetchExempt([]string{
+		"192.0.2.0/99",
+	})
+
+	err := config.Validate()
+	suite.EqualError(err, "advanced-signed-fetch-exempt entry 192.0.2.0/99 is neither a valid CIDR nor a valid domain")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateStorageS3StorageClassOK() {
+	testrig.InitTestConfig()
+
+	config.SetStorageS3StorageClass("STANDARD_IA")
+	config.SetStorageS3StorageClassEmojis("STANDARD")
+
+	err := config.Validate()
+	suite.NoError(err)
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateStorageS3StorageClassInvalid() {
+	testrig.InitTestConfig()
+
+	config.SetStorageS3StorageClass("  ")
+	config.SetStorageS3StorageClassEmojis("STANDARD IA")
+
+	err := config.Validate()
+	suite.EqualError(err, "storage-s3-storage-class must not be blank when set\nstorage-s3-storage-class-emojis must not contain whitespace, provided value was \"STANDARD IA\"")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateStorageS3MultipartOK() {
+	testrig.InitTestConfig()
+
+	config.SetStorageS3PartSize(64 * bytesize.MiB)
+	config.SetStorageS3NumThreads(8)
+
+	err := config.Validate()
+	suite.NoError(err)
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateStorageS3MultipartInvalid() {
+	testrig.InitTestConfig()
+
+	config.SetStorageS3PartSize(1 * bytesize.MiB)
+	config.SetStorageS3NumThreads(-1)
+
+	err := config.Validate()
+	suite.EqualError(err, "storage-s3-part-size must be between 5.00MiB and 5.00GiB, provided value was 1.00MiB\nstorage-s3-num-threads must be between 0 and 64, provided value was -1")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateStorageS3ConcurrentStreamPartsSingleThread() {
+	testrig.InitTestConfig()
+
+	config.SetStorageS3ConcurrentStreamParts(true)
+	config.SetStorageS3NumThreads(1)
+
+	err := config.Validate()
+	suite.EqualError(err, "storage-s3-concurrent-stream-parts requires storage-s3-num-threads to be either 0 (default) or greater than 1")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateStorageS3ObjectTagsOK() {
+	testrig.InitTestConfig()
+
+	config.SetStorageS3ObjectTags([]string{"media-type", "account-id", "instance=gts.example.org", "tier=cold:archive"})
+
+	err := config.Validate()
+	suite.NoError(err)
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateStorageS3ObjectTagsInvalid() {
+	testrig.InitTestConfig()
+
+	config.SetStorageS3ObjectTags([]string{"media-kind", "=empty", "tier=cold!", "media-type", "media-type=attachment"})
+
+	err := config.Validate()
+	suite.EqualError(err, "storage-s3-object-tags entry \"media-kind\" must be one of 'media-type', 'media-size', 'account-id', or 'key=value'\nstorage-s3-object-tags entry \"=empty\" key must be 1 to 128 letters, numbers, spaces, or any of + - = . _ : / @\nstorage-s3-object-tags entry \"tier=cold!\" value must be up to 256 letters, numbers, spaces, or any of + - = . _ : / @\nstorage-s3-object-tags contains duplicate tag key \"media-type\"")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateStorageS3SSEOK() {
+	testrig.InitTestConfig()
+
+	config.SetStorageS3SSE("sse-c")
+	config.SetStorageS3SSECKey("MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=")
+	config.SetStorageS3Proxy(true)
+
+	err := config.Validate()
+	suite.NoError(err)
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateStorageS3SSECInvalid() {
+	testrig.InitTestConfig()
+
+	config.SetStorageS3SSE("sse-c")
+	config.SetStorageS3SSECKey("dG9vIHNob3J0")
+	config.SetStorageS3Proxy(false)
+
+	err := config.Validate()
+	suite.EqualError(err, "storage-s3-sse-c-key must be a base64-encoded 256-bit key when storage-s3-sse is 'sse-c'\nstorage-s3-sse 'sse-c' requires storage-s3-proxy to be true")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateStorageS3SSEUnknown() {
+	testrig.InitTestConfig()
+
+	config.SetStorageS3SSE("aes")
+
+	err := config.Validate()
+	suite.EqualError(err, "storage-s3-sse must be one of 'sse-s3', 'sse-kms', or 'sse-c' if set, provided value was \"aes\"")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateStatusesExpiryBounds() {
+	testrig.InitTestConfig()
+
+	config.SetStatusesExpiryMin(48 * time.Hour)
+	config.SetStatusesExpiryMax(24 * time.Hour)
+
+	err := config.Validate()
+	suite.EqualError(err, "statuses-expiry-min must be greater than 0, and statuses-expiry-max must be greater than or equal to it, provided values were 48h0m0s and 24h0m0s")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateStatusesContentTypes() {
+	testrig.InitTestConfig()
+
+	config.SetStatusesContentTypes([]string{"text/markdown"})
+	suite.NoError(config.Validate())
+
+	config.SetStatusesContentTypes([]string{})
+	suite.EqualError(config.Validate(), "statuses-content-types must contain at least one content type")
+
+	config.SetStatusesContentTypes([]string{"text/plain", "text/html"})
+	suite.EqualError(config.Validate(), "statuses-content-types entry \"text/html\" must be one of 'text/plain' or 'text/markdown'")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateOAuthAccessTokenFormat() {
+	testrig.InitTestConfig()
+
+	config.SetAdvancedOAuthAccessTokenFormat("macaroon")
+	suite.EqualError(config.Validate(), "advanced-oauth-access-token-format must be set to either opaque or jwt, provided value was macaroon")
+
+	config.SetAdvancedOAuthAccessTokenFormat("jwt")
+	suite.EqualError(config.Validate(), "advanced-oauth-jwt-signing-key-path must be set when advanced-oauth-access-token-format is jwt")
+
+	config.SetAdvancedOAuthJWTSigningKeyPath("/gotosocial/jwt.pem")
+	suite.NoError(config.Validate())
+}
+
 func TestConfigValidateTestSuite(t *testing.T) {
 	suite.Run(t, &ConfigValidateTestSuite{})
 }
@@ -85,7 +85,9 @@ type Account interface {
 	// PutAccount puts one account in the database.
 	PutAccount(ctx context.Context, account *gtsmodel.Account) error
 
-	// UpdateAccount updates one account by ID.
+	// UpdateAccount updates one account by ID. If the username of a
+	// local account is changed, the old one is added to its previous
+	// usernames, so it's still advertised as an alias of the account.
 	UpdateAccount(ctx context.Context, account *gtsmodel.Account, columns ...string) error
 
 	// DeleteAccount deletes one account from the database by its ID.
@@ -124,6 +126,17 @@ type Account interface {
 	// In the case of no statuses, this function will return db.ErrNoEntries.
 	GetAccountWebStatuses(ctx context.Context, accountID string, limit int, maxID string) ([]*gtsmodel.Status, error)
 
+	// GetAccountWebStatusesByTag is like GetAccountWebStatuses, but
+	// returns only those web-visible statuses that use the given tag.
+	//
+	// In the case of no statuses, this function will return db.ErrNoEntries.
+	GetAccountWebStatusesByTag(ctx context.Context, accountID string, tagID string, limit int, maxID string) ([]*gtsmodel.Status, error)
+
+	// CountAccountWebStatusesByTag counts the web-visible statuses
+	// of the given account that use the given tag, using the same
+	// criteria as GetAccountWebStatusesByTag.
+	CountAccountWebStatusesByTag(ctx context.Context, accountID string, tagID string) (int, error)
+
 	// SetAccountHeaderOrAvatar sets the header or avatar for the given accountID to the given media attachment.
 	SetAccountHeaderOrAvatar(ctx context.Context, mediaAttachment *gtsmodel.MediaAttachment, accountID string) error
 
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -48,6 +49,9 @@ type Application interface {
 	// GetAllTokens ...
 	GetAllTokens(ctx context.Context) ([]*gtsmodel.Token, error)
 
+	// GetTokenByID fetches the token with the given ID.
+	GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, error)
+
 	// GetTokenByCode ...
 	GetTokenByCode(ctx context.Context, code string) (*gtsmodel.Token, error)
 
@@ -57,9 +61,16 @@ type Application interface {
 	// GetTokenByRefresh ...
 	GetTokenByRefresh(ctx context.Context, refresh string) (*gtsmodel.Token, error)
 
+	// GetTokensByUserID returns the tokens with a (not yet consumed)
+	// access token that the given user holds, for any client, newest first.
+	GetTokensByUserID(ctx context.Context, userID string) ([]*gtsmodel.Token, error)
+
 	// PutToken ...
 	PutToken(ctx context.Context, token *gtsmodel.Token) error
 
+	// UpdateToken updates the given token by columns, or all columns if none are given.
+	UpdateToken(ctx context.Context, token *gtsmodel.Token, columns ...string) error
+
 	// DeleteTokenByID ...
 	DeleteTokenByID(ctx context.Context, id string) error
 
@@ -71,4 +82,48 @@ type Application interface {
 
 	// DeleteTokenByRefresh ...
 	DeleteTokenByRefresh(ctx context.Context, refresh string) error
+
+	// ConsumeTokenRefresh marks the refresh token of the token with the given ID
+	// as consumed, clearing its access token at the same time. Returns false if
+	// the refresh token had already been consumed, or the token doesn't exist.
+	ConsumeTokenRefresh(ctx context.Context, id string) (bool, error)
+
+	// DeleteTokensByFamilyID deletes all tokens with the given family ID.
+	DeleteTokensByFamilyID(ctx context.Context, familyID string) error
+
+	// GetActiveTokens returns the tokens with a (not yet consumed) access
+	// token that the given user holds for the given client, oldest first.
+	GetActiveTokens(ctx context.Context, userID string, clientID string) ([]*gtsmodel.Token, error)
+
+	// DeleteExpiredTokens deletes all tokens with a code, access,
+	// or refresh token that expired before the given time. Tokens
+	// whose access token expired are kept while they still hold a
+	// refresh token, so that the access token can be refreshed.
+	DeleteExpiredTokens(ctx context.Context, now time.Time) error
+
+	// DeleteTokensByUserID deletes all tokens of the given user,
+	// whether code, access or refresh tokens, and returns how
+	// many access tokens (ie., sessions) were deleted this way.
+	DeleteTokensByUserID(ctx context.Context, userID string) (int, error)
+
+	// GetConsent fetches the consent the given user remembered
+	// for the given client, if any.
+	GetConsent(ctx context.Context, userID string, clientID string) (*gtsmodel.Consent, error)
+
+	// GetConsentByID fetches the consent with the given ID.
+	GetConsentByID(ctx context.Context, id string) (*gtsmodel.Consent, error)
+
+	// GetConsentsByUserID fetches all consents the
+	// given user has remembered, newest first.
+	GetConsentsByUserID(ctx context.Context, userID string) ([]*gtsmodel.Consent, error)
+
+	// PutConsent stores the given consent, replacing the scope
+	// of any existing consent for the same user + client.
+	PutConsent(ctx context.Context, consent *gtsmodel.Consent) error
+
+	// DeleteConsentByID deletes the consent with the given ID.
+	DeleteConsentByID(ctx context.Context, id string) error
+
+	// DeleteConsentsByUserID deletes all consents of the given user.
+	DeleteConsentsByUserID(ctx context.Context, userID string) error
 }
@@ -19,6 +19,7 @@ package bundb
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/netip"
@@ -701,6 +702,17 @@ func (a *accountDB) PopulateAccount(ctx context.Context, account *gtsmodel.Accou
 		}
 	}
 
+	if !account.FeaturedTagsPopulated() {
+		// Account featured tags are out-of-date with IDs, repopulate.
+		account.FeaturedTags, err = a.state.DB.GetTags(
+			ctx, // these are already barebones
+			account.FeaturedTagIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating account featured tags: %w", err)
+		}
+	}
+
 	if account.IsLocal() && account.Settings == nil && !account.IsInstance() {
 		// Account settings not set, fetch from db.
 		account.Settings, err = a.state.DB.GetAccountSettings(
@@ -779,6 +791,29 @@ func (a *accountDB) UpdateAccount(ctx context.Context, account *gtsmodel.Account
 				}
 			}
 
+			if account.IsLocal() &&
+				(len(columns) == 0 || slices.Contains(columns, "username")) {
+				// Keep track of the old username of a renamed
+				// local account, so it's still advertised as
+				// an alias of the account over webfinger.
+				var username string
+				if err := tx.NewSelect().
+					Table("accounts").
+					Column("username").
+					Where("? = ?", bun.Ident("id"), account.ID).
+					Scan(ctx, &username); err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return err
+				}
+
+				if username != "" && username != account.Username &&
+					!slices.Contains(account.PreviousUsernames, username) {
+					account.PreviousUsernames = append(account.PreviousUsernames, username)
+					if len(columns) > 0 {
+						columns = append(columns, "previous_usernames")
+					}
+				}
+			}
+
 			// update the account
 			_, err := tx.NewUpdate().
 				Model(account).
@@ -1094,6 +1129,72 @@ func (a *accountDB) GetAccountWebStatuses(ctx context.Context, accountID string,
 	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
 
+func (a *accountDB) GetAccountWebStatusesByTag(ctx context.Context, accountID string, tagID string, limit int, maxID string) ([]*gtsmodel.Status, error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	statusIDs := make([]string, 0, limit)
+
+	q := a.accountWebStatusesByTagQuery(accountID, tagID).
+		// Select only IDs from table
+		Column("status_to_tag.status_id")
+
+	// return only statuses LOWER (ie., older) than maxID
+	if maxID == "" {
+		maxID = id.Highest
+	}
+	q = q.Where("? < ?", bun.Ident("status_to_tag.status_id"), maxID)
+
+	if limit > 0 {
+		// limit amount of statuses returned
+		q = q.Limit(limit)
+	}
+
+	q = q.Order("status_to_tag.status_id DESC")
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	if len(statusIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
+}
+
+func (a *accountDB) CountAccountWebStatusesByTag(ctx context.Context, accountID string, tagID string) (int, error) {
+	return a.accountWebStatusesByTagQuery(accountID, tagID).Count(ctx)
+}
+
+// accountWebStatusesByTagQuery returns a query selecting from status_to_tags,
+// joined with statuses, limited to the given account's web-visible statuses
+// that use the given tag. Callers should add columns, ordering etc as needed.
+func (a *accountDB) accountWebStatusesByTagQuery(accountID string, tagID string) *bun.SelectQuery {
+	return a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		// Join with statuses for filtering.
+		Join(
+			"INNER JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		// This tag only.
+		Where("? = ?", bun.Ident("status_to_tag.tag_id"), tagID).
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		// Don't show replies or boosts.
+		Where("? IS NULL", bun.Ident("status.in_reply_to_uri")).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		// Only Public statuses.
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		// Don't show local-only statuses on the web view.
+		Where("? = ?", bun.Ident("status.federated"), true)
+}
+
 func (a *accountDB) GetAccountSettings(
 	ctx context.Context,
 	accountID string,
@@ -415,6 +415,49 @@ func (suite *AccountTestSuite) TestUpdateAccount() {
 	suite.WithinDuration(time.Now(), noCache.UpdatedAt, 5*time.Second)
 }
 
+func (suite *AccountTestSuite) TestUpdateAccountUsername() {
+	ctx := context.Background()
+
+	testAccount := new(gtsmodel.Account)
+	*testAccount = *suite.testAccounts["local_account_1"]
+	oldUsername := testAccount.Username
+
+	// Rename the account.
+	testAccount.Username = "the_mightier_zork"
+	if err := suite.db.UpdateAccount(ctx, testAccount, "username"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	updated, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("the_mightier_zork", updated.Username)
+	suite.Equal([]string{oldUsername}, updated.PreviousUsernames)
+
+	// Updating other columns leaves previous usernames be.
+	testAccount.DisplayName = "new display name!"
+	if err := suite.db.UpdateAccount(ctx, testAccount); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbService, ok := suite.db.(*bundb.DBService)
+	if !ok {
+		panic("db was not *bundb.DBService")
+	}
+
+	noCache := &gtsmodel.Account{}
+	if err := dbService.DB().
+		NewSelect().
+		Model(noCache).
+		Where("? = ?", bun.Ident("account.id"), testAccount.ID).
+		Scan(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("new display name!", noCache.DisplayName)
+	suite.Equal([]string{oldUsername}, noCache.PreviousUsernames)
+}
+
 func (suite *AccountTestSuite) TestInsertAccountWithDefaults() {
 	key, err := rsa.GenerateKey(rand.Reader, 2048)
 	suite.NoError(err)
@@ -144,8 +144,17 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 
 		// Insert basic settings for new account.
 		account.Settings = &gtsmodel.AccountSettings{
-			AccountID: accountID,
-			Privacy:   gtsmodel.VisibilityDefault,
+			AccountID:                accountID,
+			Privacy:                  gtsmodel.VisibilityDefault,
+			ReviewLocalFollows:       util.Ptr(false),
+			HideWebProfile:           util.Ptr(false),
+			AwayEnabled:              util.Ptr(false),
+			AwayAutoReply:            util.Ptr(false),
+			RequireMediaDescriptions: util.Ptr(false),
+			DefaultMediaSensitive:    util.Ptr(false),
+			DetectLanguage:           util.Ptr(false),
+			LocalOnly:                util.Ptr(false),
+			ContinueThread:           util.Ptr(false),
 		}
 		if err := a.state.DB.PutAccountSettings(ctx, account.Settings); err != nil {
 			return nil, err
@@ -394,9 +403,9 @@ func (a *adminDB) CreateInstanceApplication(ctx context.Context) error {
 	// Model an oauth client
 	// from the application.
 	oc := &gtsmodel.Client{
-		ID:     clientID,
-		Secret: clientSecret,
-		Domain: url,
+		ID:           clientID,
+		Secret:       clientSecret,
+		RedirectURIs: []string{url},
 	}
 
 	// Store it.
@@ -19,6 +19,7 @@ package bundb
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -143,6 +144,51 @@ func (a *applicationDB) GetAllTokens(ctx context.Context) ([]*gtsmodel.Token, er
 		return nil, err
 	}
 
+	return a.getTokensByIDs(ctx, tokenIDs)
+}
+
+func (a *applicationDB) GetActiveTokens(ctx context.Context, userID string, clientID string) ([]*gtsmodel.Token, error) {
+	var tokenIDs []string
+
+	// Select IDs of tokens with an access token
+	// for this user + client, oldest first. Token
+	// IDs are random ULIDs, so can't be used to
+	// order tokens by age; use access creation.
+	if err := a.db.NewSelect().
+		Table("tokens").
+		Column("id").
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Where("? = ?", bun.Ident("client_id"), clientID).
+		Where("? != ''", bun.Ident("access")).
+		OrderExpr("? ASC, ? ASC", bun.Ident("access_create_at"), bun.Ident("id")).
+		Scan(ctx, &tokenIDs); err != nil {
+		return nil, err
+	}
+
+	return a.getTokensByIDs(ctx, tokenIDs)
+}
+
+func (a *applicationDB) GetTokensByUserID(ctx context.Context, userID string) ([]*gtsmodel.Token, error) {
+	var tokenIDs []string
+
+	// Select IDs of tokens with an access token
+	// for this user, newest first. As in
+	// GetActiveTokens, order by access creation,
+	// since token IDs are random ULIDs.
+	if err := a.db.NewSelect().
+		Table("tokens").
+		Column("id").
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Where("? != ''", bun.Ident("access")).
+		OrderExpr("? DESC, ? DESC", bun.Ident("access_create_at"), bun.Ident("id")).
+		Scan(ctx, &tokenIDs); err != nil {
+		return nil, err
+	}
+
+	return a.getTokensByIDs(ctx, tokenIDs)
+}
+
+func (a *applicationDB) getTokensByIDs(ctx context.Context, tokenIDs []string) ([]*gtsmodel.Token, error) {
 	// Load all input token IDs via cache loader callback.
 	tokens, err := a.state.Caches.GTS.Token.LoadIDs("ID",
 		tokenIDs,
@@ -174,6 +220,16 @@ func (a *applicationDB) GetAllTokens(ctx context.Context) ([]*gtsmodel.Token, er
 	return tokens, nil
 }
 
+func (a *applicationDB) GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, error) {
+	return a.getTokenBy(
+		"ID",
+		func(t *gtsmodel.Token) error {
+			return a.db.NewSelect().Model(t).Where("? = ?", bun.Ident("id"), id).Scan(ctx)
+		},
+		id,
+	)
+}
+
 func (a *applicationDB) GetTokenByCode(ctx context.Context, code string) (*gtsmodel.Token, error) {
 	return a.getTokenBy(
 		"Code",
@@ -223,6 +279,24 @@ func (a *applicationDB) PutToken(ctx context.Context, token *gtsmodel.Token) err
 	})
 }
 
+func (a *applicationDB) UpdateToken(ctx context.Context, token *gtsmodel.Token, columns ...string) error {
+	return a.state.Caches.GTS.Token.Store(token, func() error {
+		token.UpdatedAt = time.Now()
+		if len(columns) > 0 {
+			// If we're updating by column,
+			// ensure "updated_at" is included.
+			columns = append(columns, "updated_at")
+		}
+
+		_, err := a.db.NewUpdate().
+			Model(token).
+			Column(columns...).
+			Where("? = ?", bun.Ident("token.id"), token.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
 func (a *applicationDB) DeleteTokenByID(ctx context.Context, id string) error {
 	_, err := a.db.NewDelete().
 		Table("tokens").
@@ -274,3 +348,211 @@ func (a *applicationDB) DeleteTokenByRefresh(ctx context.Context, refresh string
 	a.state.Caches.GTS.Token.Invalidate("Refresh", refresh)
 	return nil
 }
+
+func (a *applicationDB) ConsumeTokenRefresh(ctx context.Context, id string) (bool, error) {
+	// Only update the token if its refresh
+	// token hasn't been consumed yet, so that
+	// concurrent refreshes can't both succeed.
+	res, err := a.db.NewUpdate().
+		Table("tokens").
+		Set("? = ?", bun.Ident("refresh_consumed_at"), time.Now()).
+		Set("? = ''", bun.Ident("access")).
+		Set("? = NULL", bun.Ident("access_create_at")).
+		Set("? = NULL", bun.Ident("access_expires_at")).
+		Where("? = ?", bun.Ident("id"), id).
+		Where("? IS NULL", bun.Ident("refresh_consumed_at")).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// Invalidate token from cache, this
+	// drops it from the access index too.
+	a.state.Caches.GTS.Token.Invalidate("ID", id)
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+func (a *applicationDB) DeleteExpiredTokens(ctx context.Context, now time.Time) error {
+	var tokenIDs []string
+
+	// Select IDs of tokens with any expiry time set
+	// before now, except for expired access tokens
+	// which can still be refreshed.
+	if err := a.db.NewSelect().
+		Table("tokens").
+		Column("id").
+		WhereOr("? < ?", bun.Ident("code_expires_at"), now).
+		WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? < ?", bun.Ident("access_expires_at"), now).
+				WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.
+						WhereOr("? IS NULL", bun.Ident("refresh")).
+						WhereOr("? = ''", bun.Ident("refresh"))
+				})
+		}).
+		WhereOr("? < ?", bun.Ident("refresh_expires_at"), now).
+		Scan(ctx, &tokenIDs); err != nil {
+		return err
+	}
+
+	if len(tokenIDs) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	if _, err := a.db.NewDelete().
+		Table("tokens").
+		Where("? IN (?)", bun.Ident("id"), bun.In(tokenIDs)).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Drop deleted tokens from cache.
+	a.state.Caches.GTS.Token.InvalidateIDs("ID", tokenIDs)
+	return nil
+}
+
+func (a *applicationDB) DeleteTokensByUserID(ctx context.Context, userID string) (int, error) {
+	var tokens []*gtsmodel.Token
+
+	// Select all tokens of this user,
+	// not just those with an access token.
+	if err := a.db.NewSelect().
+		Model(&tokens).
+		Column("id", "access").
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Scan(ctx); err != nil {
+		return 0, err
+	}
+
+	if len(tokens) == 0 {
+		// Nothing to do.
+		return 0, nil
+	}
+
+	var (
+		tokenIDs = make([]string, 0, len(tokens))
+		sessions int
+	)
+	for _, token := range tokens {
+		tokenIDs = append(tokenIDs, token.ID)
+		if token.Access != "" {
+			sessions++
+		}
+	}
+
+	if _, err := a.db.NewDelete().
+		Table("tokens").
+		Where("? IN (?)", bun.Ident("id"), bun.In(tokenIDs)).
+		Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	// Drop deleted tokens from cache.
+	a.state.Caches.GTS.Token.InvalidateIDs("ID", tokenIDs)
+	return sessions, nil
+}
+
+func (a *applicationDB) DeleteTokensByFamilyID(ctx context.Context, familyID string) error {
+	var tokenIDs []string
+
+	// Select IDs of all tokens in this family,
+	// so we can drop each of them from cache.
+	if err := a.db.NewSelect().
+		Table("tokens").
+		Column("id").
+		Where("? = ?", bun.Ident("family_id"), familyID).
+		Scan(ctx, &tokenIDs); err != nil {
+		return err
+	}
+
+	if len(tokenIDs) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	if _, err := a.db.NewDelete().
+		Table("tokens").
+		Where("? IN (?)", bun.Ident("id"), bun.In(tokenIDs)).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Drop deleted tokens from cache.
+	a.state.Caches.GTS.Token.InvalidateIDs("ID", tokenIDs)
+	return nil
+}
+
+func (a *applicationDB) GetConsent(ctx context.Context, userID string, clientID string) (*gtsmodel.Consent, error) {
+	var consent gtsmodel.Consent
+
+	if err := a.db.NewSelect().
+		Model(&consent).
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Where("? = ?", bun.Ident("client_id"), clientID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &consent, nil
+}
+
+func (a *applicationDB) GetConsentByID(ctx context.Context, id string) (*gtsmodel.Consent, error) {
+	var consent gtsmodel.Consent
+
+	if err := a.db.NewSelect().
+		Model(&consent).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &consent, nil
+}
+
+func (a *applicationDB) GetConsentsByUserID(ctx context.Context, userID string) ([]*gtsmodel.Consent, error) {
+	var consents []*gtsmodel.Consent
+
+	if err := a.db.NewSelect().
+		Model(&consents).
+		Where("? = ?", bun.Ident("user_id"), userID).
+		OrderExpr("? DESC", bun.Ident("updated_at")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return consents, nil
+}
+
+func (a *applicationDB) PutConsent(ctx context.Context, consent *gtsmodel.Consent) error {
+	consent.UpdatedAt = time.Now()
+	_, err := a.db.NewInsert().
+		Model(consent).
+		On("CONFLICT (?, ?) DO UPDATE", bun.Ident("user_id"), bun.Ident("client_id")).
+		Set("? = ?, ? = ?", bun.Ident("updated_at"), consent.UpdatedAt, bun.Ident("scope"), consent.Scope).
+		Exec(ctx)
+	return err
+}
+
+func (a *applicationDB) DeleteConsentByID(ctx context.Context, id string) error {
+	_, err := a.db.NewDelete().
+		Table("consents").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (a *applicationDB) DeleteConsentsByUserID(ctx context.Context, userID string) error {
+	_, err := a.db.NewDelete().
+		Table("consents").
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Exec(ctx)
+	return err
+}
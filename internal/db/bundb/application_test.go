@@ -131,6 +131,78 @@ func (suite *ApplicationTestSuite) TestGetAllTokens() {
 	suite.NotEmpty(tokens)
 }
 
+func (suite *ApplicationTestSuite) TestGetActiveTokens() {
+	ctx := context.Background()
+	token := suite.testTokens["local_account_1"]
+
+	tokens, err := suite.db.GetActiveTokens(ctx, token.UserID, token.ClientID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.NotEmpty(tokens) {
+		suite.Equal(token.ID, tokens[0].ID)
+	}
+	for _, t := range tokens {
+		suite.Equal(token.UserID, t.UserID)
+		suite.Equal(token.ClientID, t.ClientID)
+		suite.NotEmpty(t.Access)
+	}
+}
+
+func (suite *ApplicationTestSuite) TestDeleteExpiredTokens() {
+	ctx := context.Background()
+	now := time.Now()
+
+	// Copy an existing token as a
+	// template for the ones below.
+	newToken := func(id string, access string) *gtsmodel.Token {
+		token := new(gtsmodel.Token)
+		*token = *suite.testTokens["local_account_1"]
+		token.ID = id
+		token.Access = access
+		token.Code = ""
+		token.Refresh = ""
+		return token
+	}
+
+	expired := newToken("01J2PK0XQ5B3J6AH0ZJ4V5YJ0B", "expired")
+	expired.AccessExpiresAt = now.Add(-time.Minute)
+
+	notExpired := newToken("01J2PK1D2GW0M6Y6N4JBD7X1VZ", "not-expired")
+	notExpired.AccessExpiresAt = now.Add(time.Hour)
+
+	noExpiry := newToken("01J2PK1T8ZPV4C6D0QFKAZ4Y0S", "no-expiry")
+	noExpiry.AccessExpiresAt = time.Time{}
+
+	// Expired access, but can still be refreshed.
+	refreshable := newToken("01J2PK2B6Q3X8N1W7F5EZ9C4TR", "refreshable")
+	refreshable.AccessExpiresAt = now.Add(-time.Minute)
+	refreshable.Refresh = "refreshable-refresh"
+
+	for _, token := range []*gtsmodel.Token{expired, notExpired, noExpiry, refreshable} {
+		if err := suite.db.PutToken(ctx, token); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	if err := suite.db.DeleteExpiredTokens(ctx, now); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.db.GetTokenByAccess(ctx, expired.Access)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	for _, token := range []*gtsmodel.Token{notExpired, noExpiry, refreshable} {
+		_, err := suite.db.GetTokenByAccess(ctx, token.Access)
+		suite.NoError(err)
+	}
+
+	// Existing test token hasn't expired.
+	_, err = suite.db.GetTokenByAccess(ctx, suite.testTokens["local_account_1"].Access)
+	suite.NoError(err)
+}
+
 func TestApplicationTestSuite(t *testing.T) {
 	suite.Run(t, new(ApplicationTestSuite))
 }
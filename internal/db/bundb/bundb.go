@@ -71,6 +71,7 @@ type DBService struct {
 	db.Relationship
 	db.Report
 	db.Rule
+	db.ScheduledStatus
 	db.Search
 	db.Session
 	db.Status
@@ -247,6 +248,9 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		ScheduledStatus: &scheduledStatusDB{
+			db: db,
+		},
 		Search: &searchDB{
 			db:    db,
 			state: state,
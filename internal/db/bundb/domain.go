@@ -227,6 +227,102 @@ func (d *domainDB) DeleteDomainBlock(ctx context.Context, domain string) error {
 	return nil
 }
 
+func (d *domainDB) CreateDomainSensitive(ctx context.Context, sensitive *gtsmodel.DomainSensitive) error {
+	// Normalize the domain as punycode
+	var err error
+	sensitive.Domain, err = util.Punify(sensitive.Domain)
+	if err != nil {
+		return err
+	}
+
+	// Attempt to store domain sensitive in DB
+	if _, err := d.db.NewInsert().
+		Model(sensitive).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the domain sensitive cache (for later reload)
+	d.state.Caches.GTS.DomainSensitive.Clear()
+
+	return nil
+}
+
+func (d *domainDB) GetDomainSensitive(ctx context.Context, domain string) (*gtsmodel.DomainSensitive, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for easy case, domain referencing *us*
+	if domain == "" || domain == config.GetAccountDomain() ||
+		domain == config.GetHost() {
+		return nil, db.ErrNoEntries
+	}
+
+	var sensitive gtsmodel.DomainSensitive
+
+	// Look for rule matching domain in DB
+	q := d.db.
+		NewSelect().
+		Model(&sensitive).
+		Where("? = ?", bun.Ident("domain_sensitive.domain"), domain)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &sensitive, nil
+}
+
+func (d *domainDB) GetDomainSensitives(ctx context.Context) ([]*gtsmodel.DomainSensitive, error) {
+	sensitives := []*gtsmodel.DomainSensitive{}
+
+	if err := d.db.
+		NewSelect().
+		Model(&sensitives).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return sensitives, nil
+}
+
+func (d *domainDB) GetDomainSensitiveByID(ctx context.Context, id string) (*gtsmodel.DomainSensitive, error) {
+	var sensitive gtsmodel.DomainSensitive
+
+	q := d.db.
+		NewSelect().
+		Model(&sensitive).
+		Where("? = ?", bun.Ident("domain_sensitive.id"), id)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &sensitive, nil
+}
+
+func (d *domainDB) DeleteDomainSensitive(ctx context.Context, domain string) error {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return err
+	}
+
+	// Attempt to delete domain sensitive
+	if _, err := d.db.NewDelete().
+		Model((*gtsmodel.DomainSensitive)(nil)).
+		Where("? = ?", bun.Ident("domain_sensitive.domain"), domain).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the domain sensitive cache (for later reload)
+	d.state.Caches.GTS.DomainSensitive.Clear()
+
+	return nil
+}
+
 func (d *domainDB) IsDomainBlocked(ctx context.Context, domain string) (bool, error) {
 	// Normalize the domain as punycode
 	domain, err := util.Punify(domain)
@@ -328,3 +424,32 @@ func (d *domainDB) AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, e
 	}
 	return false, nil
 }
+
+func (d *domainDB) IsDomainSensitive(ctx context.Context, domain string) (bool, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return false, err
+	}
+
+	// Domain referencing *us* is never forced sensitive.
+	if domain == "" || domain == config.GetAccountDomain() ||
+		domain == config.GetHost() {
+		return false, nil
+	}
+
+	// Check the cache for a domain sensitive rule (hydrating the cache with callback if necessary).
+	return d.state.Caches.GTS.DomainSensitive.Matches(domain, func() ([]string, error) {
+		var domains []string
+
+		// Scan list of all sensitive domains from DB
+		q := d.db.NewSelect().
+			Table("domain_sensitives").
+			Column("domain")
+		if err := q.Scan(ctx, &domains); err != nil {
+			return nil, err
+		}
+
+		return domains, nil
+	})
+}
@@ -274,6 +274,43 @@ func (suite *DomainTestSuite) TestIsOtherDomainBlockedWildcardAndExplicit() {
 	}
 }
 
+func (suite *DomainTestSuite) TestIsDomainSensitive() {
+	ctx := context.Background()
+
+	domainSensitive := &gtsmodel.DomainSensitive{
+		ID:                 "01J1Z8YV0QDXWB5RFS0XYC3M4E",
+		Domain:             "nsfw.example.org",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		CreatedByAccount:   suite.testAccounts["admin_account"],
+	}
+
+	// no entry exists for the given domain yet
+	sensitive, err := suite.db.IsDomainSensitive(ctx, "media.nsfw.example.org")
+	suite.NoError(err)
+	suite.False(sensitive)
+
+	err = suite.db.CreateDomainSensitive(ctx, domainSensitive)
+	suite.NoError(err)
+
+	// subdomains should now match
+	sensitive, err = suite.db.IsDomainSensitive(ctx, "media.nsfw.example.org")
+	suite.NoError(err)
+	suite.True(sensitive)
+
+	// parent domain should not
+	sensitive, err = suite.db.IsDomainSensitive(ctx, "example.org")
+	suite.NoError(err)
+	suite.False(sensitive)
+
+	// remove the entry again
+	err = suite.db.DeleteDomainSensitive(ctx, domainSensitive.Domain)
+	suite.NoError(err)
+
+	sensitive, err = suite.db.IsDomainSensitive(ctx, "media.nsfw.example.org")
+	suite.NoError(err)
+	suite.False(sensitive)
+}
+
 func TestDomainTestSuite(t *testing.T) {
 	suite.Run(t, new(DomainTestSuite))
 }
@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		// Add refresh token rotation
+		// columns to the tokens table.
+		for _, column := range []struct {
+			name string
+			typ  string
+		}{
+			{name: "refresh_consumed_at", typ: "TIMESTAMPTZ"},
+			{name: "family_id", typ: "CHAR(26)"},
+		} {
+			_, err := db.ExecContext(ctx,
+				"ALTER TABLE ? ADD COLUMN ? "+column.typ,
+				bun.Ident("tokens"), bun.Ident(column.name),
+			)
+			if err != nil {
+				e := err.Error()
+				if !(strings.Contains(e, "already exists") ||
+					strings.Contains(e, "duplicate column name") ||
+					strings.Contains(e, "SQLSTATE 42701")) {
+					return err
+				}
+			}
+		}
+
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Existing tokens each
+			// start their own family.
+			if _, err := tx.NewUpdate().
+				Table("tokens").
+				Set("? = ?", bun.Ident("family_id"), bun.Ident("id")).
+				Where("? IS NULL", bun.Ident("family_id")).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Index family IDs so a whole
+			// family can be revoked at once.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("tokens").
+				Index("tokens_family_id_idx").
+				Column("family_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
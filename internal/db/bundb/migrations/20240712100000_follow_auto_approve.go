@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Add follow request auto-approval
+			// columns to account settings table.
+			for _, column := range []struct {
+				name    string
+				colType string
+			}{
+				{"auto_approve_follows_after", "BIGINT"},
+				{"review_local_follows", "BOOLEAN NOT NULL DEFAULT false"},
+			} {
+				_, err := tx.ExecContext(ctx,
+					"ALTER TABLE ? ADD COLUMN ? "+column.colType,
+					bun.Ident("account_settings"), bun.Ident(column.name),
+				)
+				if err != nil {
+					e := err.Error()
+					if !(strings.Contains(e, "already exists") ||
+						strings.Contains(e, "duplicate column name") ||
+						strings.Contains(e, "SQLSTATE 42701")) {
+						return err
+					}
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
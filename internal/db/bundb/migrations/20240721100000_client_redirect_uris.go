@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Clients previously stored their redirect
+			// uri(s) newline-separated in the domain column.
+			exists, err := doesColumnExist(ctx, tx, "clients", "domain")
+			if err != nil {
+				return err
+			}
+
+			if !exists {
+				// Already migrated.
+				return nil
+			}
+
+			// SQLite stores arrays as JSON strings.
+			urisType := "VARCHAR"
+			if tx.Dialect().Name() == dialect.PG {
+				urisType = "VARCHAR ARRAY"
+			}
+
+			if _, err := tx.ExecContext(ctx,
+				"ALTER TABLE ? ADD COLUMN ? "+urisType,
+				bun.Ident("clients"), bun.Ident("redirect_uris"),
+			); err != nil {
+				e := err.Error()
+				if !(strings.Contains(e, "already exists") ||
+					strings.Contains(e, "duplicate column name") ||
+					strings.Contains(e, "SQLSTATE 42701")) {
+					return err
+				}
+			}
+
+			type client struct {
+				bun.BaseModel `bun:"table:clients"`
+
+				ID           string   `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`
+				Domain       string   `bun:",nullzero"`
+				RedirectURIs []string `bun:"redirect_uris,array"`
+			}
+
+			var clients []*client
+			if err := tx.NewSelect().
+				Model(&clients).
+				Column("id", "domain").
+				Scan(ctx); err != nil {
+				return err
+			}
+
+			// Split each client's domain
+			// into its separate redirect uris.
+			for _, c := range clients {
+				for _, uri := range strings.Split(c.Domain, "\n") {
+					uri = strings.TrimSpace(uri)
+					if uri != "" {
+						c.RedirectURIs = append(c.RedirectURIs, uri)
+					}
+				}
+
+				if _, err := tx.NewUpdate().
+					Model(c).
+					Column("redirect_uris").
+					WherePK().
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Domain column is now unused.
+			_, err = tx.NewDropColumn().
+				Table("clients").
+				Column("domain").
+				Exec(ctx)
+			return err
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -202,6 +202,8 @@ func (n *notificationDB) GetAccountNotifications(
 	limit int,
 	types []string,
 	excludeTypes []string,
+	originAccountID string,
+	excludeOriginAccountIDs []string,
 ) ([]*gtsmodel.Notification, error) {
 	// Ensure reasonable
 	if limit < 0 {
@@ -248,6 +250,16 @@ func (n *notificationDB) GetAccountNotifications(
 		q = q.Where("? NOT IN (?)", bun.Ident("notification.notification_type"), bun.In(excludeTypes))
 	}
 
+	if originAccountID != "" {
+		// Include only notifs from requested account.
+		q = q.Where("? = ?", bun.Ident("notification.origin_account_id"), originAccountID)
+	}
+
+	if len(excludeOriginAccountIDs) > 0 {
+		// Filter out notifs from unwanted accounts.
+		q = q.Where("? NOT IN (?)", bun.Ident("notification.origin_account_id"), bun.In(excludeOriginAccountIDs))
+	}
+
 	// Return only notifs for this account.
 	q = q.Where("? = ?", bun.Ident("notification.target_account_id"), accountID)
 
@@ -357,3 +369,52 @@ func (n *notificationDB) DeleteNotificationsForStatus(ctx context.Context, statu
 	n.state.Caches.GTS.Notification.InvalidateIDs("ID", notifIDs)
 	return nil
 }
+
+func (n *notificationDB) DeleteOldNotifications(
+	ctx context.Context,
+	accountID string,
+	maxID string,
+	keep int,
+	excludeTypes []string,
+	limit int,
+) (int, error) {
+	var notifIDs []string
+
+	q := n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notifications"), bun.Ident("notification")).
+		Column("notification.id").
+		Where("? = ?", bun.Ident("notification.target_account_id"), accountID).
+		Where("? < ?", bun.Ident("notification.id"), maxID)
+
+	if len(excludeTypes) > 0 {
+		// Filter out exempt notif types.
+		q = q.Where("? NOT IN (?)", bun.Ident("notification.notification_type"), bun.In(excludeTypes))
+	}
+
+	// Skip the newest 'keep' notifs.
+	if err := q.
+		Order("notification.id DESC").
+		Offset(keep).
+		Limit(limit).
+		Scan(ctx, &notifIDs); err != nil {
+		return 0, err
+	}
+
+	if len(notifIDs) == 0 {
+		return 0, nil
+	}
+
+	// Delete selected notifs from DB.
+	if _, err := n.db.
+		NewDelete().
+		Table("notifications").
+		Where("? IN (?)", bun.Ident("id"), bun.In(notifIDs)).
+		Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	// Invalidate all deleted notifications by IDs.
+	n.state.Caches.GTS.Notification.InvalidateIDs("ID", notifIDs)
+	return len(notifIDs), nil
+}
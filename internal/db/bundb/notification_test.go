@@ -98,6 +98,8 @@ func (suite *NotificationTestSuite) TestGetAccountNotificationsWithSpam() {
 		20,
 		nil,
 		nil,
+		"",
+		nil,
 	)
 	suite.NoError(err)
 	timeTaken := time.Since(before)
@@ -121,6 +123,8 @@ func (suite *NotificationTestSuite) TestGetAccountNotificationsWithoutSpam() {
 		20,
 		nil,
 		nil,
+		"",
+		nil,
 	)
 	suite.NoError(err)
 	timeTaken := time.Since(before)
@@ -132,6 +136,70 @@ func (suite *NotificationTestSuite) TestGetAccountNotificationsWithoutSpam() {
 	}
 }
 
+func (suite *NotificationTestSuite) TestGetAccountNotificationsFilterAccounts() {
+	suite.spamNotifs()
+
+	var (
+		ctx           = gtscontext.SetBarebones(context.Background())
+		testAccount   = suite.testAccounts["local_account_1"]
+		originAccount = suite.testAccounts["admin_account"]
+	)
+
+	// Only notifications from admin.
+	notifications, err := suite.db.GetAccountNotifications(
+		ctx,
+		testAccount.ID,
+		"", "", "", 0,
+		nil, nil,
+		originAccount.ID,
+		nil,
+	)
+	suite.NoError(err)
+	suite.NotEmpty(notifications)
+	for _, n := range notifications {
+		suite.Equal(originAccount.ID, n.OriginAccountID)
+	}
+
+	// Only notifications from admin, of a type admin hasn't sent.
+	notifications, err = suite.db.GetAccountNotifications(
+		ctx,
+		testAccount.ID,
+		"", "", "", 0,
+		[]string{string(gtsmodel.NotificationPoll)}, nil,
+		originAccount.ID,
+		nil,
+	)
+	suite.NoError(err)
+	suite.Empty(notifications)
+
+	// Unknown account matches nothing.
+	notifications, err = suite.db.GetAccountNotifications(
+		ctx,
+		testAccount.ID,
+		"", "", "", 0,
+		nil, nil,
+		"01J0000000000000000000000Z",
+		nil,
+	)
+	suite.NoError(err)
+	suite.Empty(notifications)
+
+	// Exclude notifications from admin.
+	notifications, err = suite.db.GetAccountNotifications(
+		ctx,
+		testAccount.ID,
+		"", "", "", 0,
+		nil, nil,
+		"",
+		[]string{originAccount.ID},
+	)
+	suite.NoError(err)
+	suite.NotEmpty(notifications)
+	for _, n := range notifications {
+		suite.NotEqual(originAccount.ID, n.OriginAccountID)
+	}
+}
+
 func (suite *NotificationTestSuite) TestDeleteNotificationsWithSpam() {
 	suite.spamNotifs()
 	testAccount := suite.testAccounts["local_account_1"]
@@ -146,6 +214,8 @@ func (suite *NotificationTestSuite) TestDeleteNotificationsWithSpam() {
 		20,
 		nil,
 		nil,
+		"",
+		nil,
 	)
 	if err != nil {
 		suite.FailNow(err.Error())
@@ -167,6 +237,8 @@ func (suite *NotificationTestSuite) TestDeleteNotificationsWithSpam() {
 		20,
 		nil,
 		nil,
+		"",
+		nil,
 	)
 	if err != nil {
 		suite.FailNow(err.Error())
@@ -189,6 +261,8 @@ func (suite *NotificationTestSuite) TestDeleteNotificationsWithTwoAccounts() {
 		20,
 		nil,
 		nil,
+		"",
+		nil,
 	)
 	suite.NoError(err)
 	suite.Nil(notifications)
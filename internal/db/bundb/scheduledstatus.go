@@ -0,0 +1,74 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type scheduledStatusDB struct {
+	db *bun.DB
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error) {
+	scheduledStatus := new(gtsmodel.ScheduledStatus)
+
+	if err := s.db.
+		NewSelect().
+		Model(scheduledStatus).
+		Where("? = ?", bun.Ident("scheduled_status.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return scheduledStatus, nil
+}
+
+func (s *scheduledStatusDB) GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, error) {
+	var scheduledStatuses []*gtsmodel.ScheduledStatus
+
+	if err := s.db.
+		NewSelect().
+		Model(&scheduledStatuses).
+		Order("scheduled_status.scheduled_at ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return scheduledStatuses, nil
+}
+
+func (s *scheduledStatusDB) PutScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) error {
+	_, err := s.db.
+		NewInsert().
+		Model(scheduledStatus).
+		Exec(ctx)
+	return err
+}
+
+func (s *scheduledStatusDB) DeleteScheduledStatusByID(ctx context.Context, id string) error {
+	_, err := s.db.
+		NewDelete().
+		Table("scheduled_statuses").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
@@ -181,7 +181,7 @@ func (s *statusDB) getStatus(ctx context.Context, lookup string, dbQuery func(*g
 func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status) error {
 	var (
 		err  error
-		errs = gtserror.NewMultiError(9)
+		errs = gtserror.NewMultiError(10)
 	)
 
 	if status.Account == nil {
@@ -287,6 +287,17 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if !status.AudiencePopulated() {
+		// Status audience is out-of-date with IDs, repopulate.
+		status.Audience, err = s.state.DB.GetAccountsByIDs(
+			gtscontext.SetBarebones(ctx),
+			status.AudienceIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating status audience: %w", err)
+		}
+	}
+
 	if !status.EmojisPopulated() {
 		// Status emojis are out-of-date with IDs, repopulate.
 		status.Emojis, err = s.state.DB.GetEmojisByIDs(
@@ -556,6 +567,43 @@ func (s *statusDB) GetStatusesUsingEmoji(ctx context.Context, emojiID string) ([
 	return s.GetStatusesByIDs(ctx, statusIDs)
 }
 
+func (s *statusDB) GetExpiringStatuses(ctx context.Context) ([]*gtsmodel.Status, error) {
+	var statusIDs []string
+
+	// SELECT all local statuses with an expiry set.
+	if err := s.db.NewSelect().
+		Table("statuses").
+		Column("id").
+		Where("? IS NOT NULL", bun.Ident("expires_at")).
+		Where("? = true", bun.Ident("local")).
+		Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	// Convert status IDs into status objects.
+	return s.GetStatusesByIDs(ctx, statusIDs)
+}
+
+func (s *statusDB) GetLocalFederatedStatusesSince(ctx context.Context, since time.Time) ([]*gtsmodel.Status, error) {
+	var statusIDs []string
+
+	// SELECT all local, federated statuses
+	// created after since, excluding boosts.
+	if err := s.db.NewSelect().
+		Table("statuses").
+		Column("id").
+		Where("? > ?", bun.Ident("created_at"), since).
+		Where("? = true", bun.Ident("local")).
+		Where("? = true", bun.Ident("federated")).
+		Where("? IS NULL", bun.Ident("boost_of_id")).
+		Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	// Convert status IDs into status objects.
+	return s.GetStatusesByIDs(ctx, statusIDs)
+}
+
 func (s *statusDB) GetStatusParents(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.Status, error) {
 	var parents []*gtsmodel.Status
 
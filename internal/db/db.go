@@ -43,6 +43,7 @@ type DB interface {
 	Relationship
 	Report
 	Rule
+	ScheduledStatus
 	Search
 	Session
 	Status
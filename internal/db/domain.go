@@ -60,6 +60,21 @@ type Domain interface {
 	// DeleteDomainBlock deletes an instance-level domain block with the given domain, if it exists.
 	DeleteDomainBlock(ctx context.Context, domain string) error
 
+	// CreateDomainSensitive puts the given instance-level domain sensitive rule into the database.
+	CreateDomainSensitive(ctx context.Context, sensitive *gtsmodel.DomainSensitive) error
+
+	// GetDomainSensitive returns one instance-level domain sensitive rule with the given domain, if it exists.
+	GetDomainSensitive(ctx context.Context, domain string) (*gtsmodel.DomainSensitive, error)
+
+	// GetDomainSensitiveByID returns one instance-level domain sensitive rule with the given id, if it exists.
+	GetDomainSensitiveByID(ctx context.Context, id string) (*gtsmodel.DomainSensitive, error)
+
+	// GetDomainSensitives returns all instance-level domain sensitive rules currently enforced by this instance.
+	GetDomainSensitives(ctx context.Context) ([]*gtsmodel.DomainSensitive, error)
+
+	// DeleteDomainSensitive deletes an instance-level domain sensitive rule with the given domain, if it exists.
+	DeleteDomainSensitive(ctx context.Context, domain string) error
+
 	/*
 		Block/allow checking functions.
 	*/
@@ -78,4 +93,8 @@ type Domain interface {
 	// AreURIsBlocked calls IsURIBlocked for each URI.
 	// Will return true if even one of the given URIs is blocked.
 	AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, error)
+
+	// IsDomainSensitive checks if media from the given domain (or
+	// any of its parent domains) should be forced sensitive.
+	IsDomainSensitive(ctx context.Context, domain string) (bool, error)
 }
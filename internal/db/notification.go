@@ -29,7 +29,9 @@ type Notification interface {
 	//
 	// Returned notifications will be ordered ID descending (ie., highest/newest to lowest/oldest).
 	// If types is empty, *all* notification types will be included.
-	GetAccountNotifications(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, types []string, excludeTypes []string) ([]*gtsmodel.Notification, error)
+	// If originAccountID is set, only notifications from that account will be included,
+	// and notifications from any of excludeOriginAccountIDs will always be excluded.
+	GetAccountNotifications(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, types []string, excludeTypes []string, originAccountID string, excludeOriginAccountIDs []string) ([]*gtsmodel.Notification, error)
 
 	// GetNotificationByID returns one notification according to its id.
 	GetNotificationByID(ctx context.Context, id string) (*gtsmodel.Notification, error)
@@ -70,4 +72,10 @@ type Notification interface {
 	// the given statusID. This function is useful when a status has been deleted,
 	// and so notifications relating to that status must also be deleted.
 	DeleteNotificationsForStatus(ctx context.Context, statusID string) error
+
+	// DeleteOldNotifications deletes up to limit notifications targeting accountID
+	// that are older (ie., have a lower ID) than maxID, excluding notifications of
+	// the given types. The newest 'keep' of these notifications will be left alone.
+	// Returns the number of notifications deleted.
+	DeleteOldNotifications(ctx context.Context, accountID string, maxID string, keep int, excludeTypes []string, limit int) (int, error)
 }
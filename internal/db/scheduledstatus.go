@@ -0,0 +1,40 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// ScheduledStatus contains functions for getting, creating,
+// and deleting statuses scheduled to be published later.
+type ScheduledStatus interface {
+	// GetScheduledStatusByID gets one scheduled status with the given id.
+	GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error)
+
+	// GetAllScheduledStatuses gets all scheduled statuses, of all accounts.
+	GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, error)
+
+	// PutScheduledStatus puts one scheduled status in the database.
+	PutScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) error
+
+	// DeleteScheduledStatusByID deletes one scheduled status with the given id.
+	DeleteScheduledStatusByID(ctx context.Context, id string) error
+}
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -58,6 +59,12 @@ type Status interface {
 	// GetStatusesUsingEmoji fetches all status models using emoji with given ID stored in their 'emojis' column.
 	GetStatusesUsingEmoji(ctx context.Context, emojiID string) ([]*gtsmodel.Status, error)
 
+	// GetExpiringStatuses fetches all local status models with a set `expires_at` column.
+	GetExpiringStatuses(ctx context.Context) ([]*gtsmodel.Status, error)
+
+	// GetLocalFederatedStatusesSince fetches all local, federated status models created after the given time, excluding boosts.
+	GetLocalFederatedStatusesSince(ctx context.Context, since time.Time) ([]*gtsmodel.Status, error)
+
 	// GetStatusReplies returns the *direct* (i.e. in_reply_to_id column) replies to this status ID, ordered DESC by ID.
 	GetStatusReplies(ctx context.Context, statusID string) ([]*gtsmodel.Status, error)
 
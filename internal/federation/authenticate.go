@@ -110,6 +110,12 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 	// this is an unsigned request.
 	verifier := gtscontext.HTTPSignatureVerifier(ctx)
 	if verifier == nil {
+		if exempt := gtscontext.SignedFetchExempt(ctx); exempt != "" {
+			// Unsigned fetch from a domain or CIDR that's
+			// been explicitly exempted by the admin.
+			return f.authenticateSignedFetchExempt(ctx, exempt)
+		}
+
 		err := gtserror.Newf("%w", errUnsigned)
 		errWithCode := gtserror.NewErrorUnauthorized(err, errUnsigned.Error(), "(verifier)")
 		return nil, errWithCode
@@ -237,6 +243,36 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 	return pubKeyAuth, nil
 }
 
+// authenticateSignedFetchExempt returns a PubKeyAuth for
+// an unsigned fetch that has been exempted from signature
+// checks via the AdvancedSignedFetchExempt config setting.
+//
+// Since we don't know who the requester actually is, the
+// returned Owner is our own instance account, meaning any
+// further visibility checks are performed as though from
+// the instance actor: public items are visible, but items
+// requiring a follow or mention (etc) are not.
+func (f *Federator) authenticateSignedFetchExempt(ctx context.Context, exempt string) (*PubKeyAuth, gtserror.WithCode) {
+	instanceAcct, err := f.db.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("error getting instance account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	ownerURI, err := url.Parse(instanceAcct.URI)
+	if err != nil {
+		err := gtserror.Newf("error parsing instance account uri: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	log.Debugf(ctx, "authenticated unsigned fetch as instance account; exempted by %s", exempt)
+
+	return &PubKeyAuth{
+		OwnerURI: ownerURI,
+		Owner:    instanceAcct,
+	}, nil
+}
+
 // derefPubKeyDBOnly tries to dereference the given
 // pubKey using only entries already in the database.
 //
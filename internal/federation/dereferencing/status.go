@@ -619,6 +619,14 @@ func (d *Dereferencer) isPermittedStatus(
 		return onFail()
 	}
 
+	if *status.InReplyTo.Local &&
+		!*status.InReplyTo.Federated {
+		// We do not permit replies to
+		// local-only statuses, which
+		// remotes shouldn't know about.
+		return onFail()
+	}
+
 	// Default to true
 	permitted = true
 
@@ -936,6 +944,14 @@ func (d *Dereferencer) fetchStatusAttachments(
 	existing *gtsmodel.Status,
 	status *gtsmodel.Status,
 ) error {
+	if maxFiles := config.GetStatusesMediaMaxFiles(); len(status.Attachments) > maxFiles {
+		// Rather than rejecting statuses with more attachments
+		// than we allow locally, store them with the excess dropped.
+		log.Infof(ctx, "truncating %d attachments to max %d for status %s",
+			len(status.Attachments), maxFiles, status.URI)
+		status.Attachments = status.Attachments[:maxFiles]
+	}
+
 	// Allocate new slice to take the yet-to-be fetched attachment IDs.
 	status.AttachmentIDs = make([]string, len(status.Attachments))
 
@@ -140,6 +140,7 @@ func (d *Dereferencer) DereferenceStatusAncestors(ctx context.Context, username
 			// and just return since we can go no further.
 			if status.Visibility == gtsmodel.VisibilityFollowersOnly ||
 				status.Visibility == gtsmodel.VisibilityMutualsOnly ||
+				status.Visibility == gtsmodel.VisibilityCircle ||
 				status.Visibility == gtsmodel.VisibilityDirect {
 				return nil
 			}
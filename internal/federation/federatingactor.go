@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"net/url"
 
+	"codeberg.org/gruf/go-cache/v3/simple"
 	errorsv2 "codeberg.org/gruf/go-errors/v2"
 	"codeberg.org/gruf/go-kv"
 	"github.com/superseriousbusiness/activity/pub"
@@ -31,25 +32,44 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
 )
 
+// inboxSeenMax is the maximum number of recently
+// accepted inbox activity IDs to keep for deduplication.
+const inboxSeenMax = 10000
+
 // federatingActor wraps the pub.FederatingActor
 // with some custom GoToSocial-specific logic.
 type federatingActor struct {
 	sideEffectActor pub.DelegateActor
 	wrapped         pub.FederatingActor
+
+	// inbox is the worker pool in which
+	// accepted inbox activities are processed,
+	// serially per actor in order of receipt.
+	inbox *workers.OrderedFnWorkerPool
+
+	// seen contains keys of recently processed
+	// inbox activities, made of the requesting
+	// actor and activity ID (see seenKey), used
+	// to drop duplicate deliveries of an activity.
+	seen *simple.Cache[string, struct{}]
 }
 
 // newFederatingActor returns a federatingActor.
-func newFederatingActor(c pub.CommonBehavior, s2s pub.FederatingProtocol, db pub.Database, clock pub.Clock) pub.FederatingActor {
+func newFederatingActor(c pub.CommonBehavior, s2s pub.FederatingProtocol, db pub.Database, clock pub.Clock, inbox *workers.OrderedFnWorkerPool) pub.FederatingActor {
 	sideEffectActor := pub.NewSideEffectActor(c, s2s, nil, db, clock)
 	sideEffectActor.Serialize = ap.Serialize // hook in our own custom Serialize function
 
 	return &federatingActor{
 		sideEffectActor: sideEffectActor,
 		wrapped:         pub.NewCustomActor(sideEffectActor, false, true, clock),
+		inbox:           inbox,
+		seen:            simple.New[string, struct{}](0, inboxSeenMax),
 	}
 }
 
@@ -62,7 +82,12 @@ func newFederatingActor(c pub.CommonBehavior, s2s pub.FederatingProtocol, db pub
 //   - *ALWAYS* return gtserror.WithCode if there's an issue, to
 //     provide more helpful messages to remote callers.
 //   - Return code 202 instead of 200 on successful POST, to reflect
-//     that we process most side effects asynchronously.
+//     that we process side effects asynchronously: once a request is
+//     authenticated, validated and authorized, the activity is queued
+//     for processing in the inbox worker pool, keyed by actor URI so
+//     that activities from the same actor are processed in order.
+//   - Drop (but still accept) activities recently processed from the same actor.
+//   - Return code 503 if the inbox queue is full, so the remote retries later.
 func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
 	l := log.WithContext(ctx).
 		WithFields([]kv.Field{
@@ -164,15 +189,81 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 		return u
 	}()
 
+	// Perform basic validation of the activity before accepting
+	// it, so that we can still reject the obviously malformed.
+	if err := validateInboxActivity(activity); err != nil {
+		// Log malformed activities to help debug.
+		l = l.WithField("activity", activity)
+		l.Warnf("malformed incoming activity: %v", err)
+
+		const text = "malformed incoming activity"
+		return false, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Process activities from the same actor in order, keyed
+	// by URI of the authenticated requesting account. Fall
+	// back to the request's public key ID if none is set.
+	var actorURI string
+	if requester := gtscontext.RequestingAccount(ctx); requester != nil {
+		actorURI = requester.URI
+	} else if pubKeyID := gtscontext.HTTPSignaturePubKeyID(ctx); pubKeyID != nil {
+		actorURI = pubKeyID.String()
+	}
+
+	// Check whether we've recently processed
+	// this activity from this actor, e.g. a remote
+	// retrying a delivery, or the same activity
+	// delivered to multiple inboxes on this instance.
+	//
+	// TODO: key this by inbox if we ever start
+	// processing activities per receiving inbox.
+	key := seenKey(actorURI, activity)
+	if key != "" && f.seen.Has(key) {
+		l.Debugf("dropping duplicate activity %s", key)
+		return true, nil
+	}
+
 	// At this point we have everything we need, and have verified that
 	// the POST request is authentic (properly signed) and authorized
 	// (permitted to interact with the target inbox).
 	//
-	// Post the activity to the Actor's inbox and trigger side effects .
+	// Queue the activity for posting to the Actor's inbox and triggering
+	// side effects. Detach from the request context's cancellation here,
+	// as it will be cancelled as soon as we write our response, while
+	// keeping values (requesting account etc) that processing relies on.
+	ctx = context.WithoutCancel(ctx)
+	if !f.inbox.Push(actorURI, func(context.Context) {
+		f.processInboxActivity(ctx, inboxID, key, activity)
+	}) {
+		// Too much queued already, from this
+		// actor or in total; have them retry.
+		const text = "inbox busy, try again later"
+		return false, gtserror.NewErrorServiceUnavailable(errors.New(text), text)
+	}
+
+	// Request is now undergoing processing. Caller
+	// of this function will handle writing Accepted.
+	return true, nil
+}
+
+// processInboxActivity posts the given activity to the inbox
+// and triggers side effects, then performs inbox forwarding.
+// Any errors are logged, as the request has already been accepted.
+// The activity is marked as seen under key (if set) once posted.
+func (f *federatingActor) processInboxActivity(ctx context.Context, inboxID *url.URL, key string, activity pub.Activity) {
+	l := log.WithContext(ctx).
+		WithField("inbox", inboxID)
+
+	// Activities from the same actor are processed
+	// serially, so check again in case a duplicate
+	// was queued before the first was processed.
+	if key != "" && f.seen.Has(key) {
+		l.Debugf("dropping duplicate activity %s", key)
+		return
+	}
+
+	// Post the activity to the Actor's inbox and trigger side effects.
 	if err := f.sideEffectActor.PostInbox(ctx, inboxID, activity); err != nil {
-		// Special case: We know it is a bad request if the object or target
-		// props needed to be populated, or we failed parsing activity details.
-		// Send the rejection to the peer.
 		if errors.Is(err, pub.ErrObjectRequired) ||
 			errors.Is(err, pub.ErrTargetRequired) ||
 			gtserror.IsMalformed(err) {
@@ -180,14 +271,18 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 			// Log malformed activities to help debug.
 			l = l.WithField("activity", activity)
 			l.Warnf("malformed incoming activity: %v", err)
-
-			const text = "malformed incoming activity"
-			return false, gtserror.NewErrorBadRequest(errors.New(text), text)
+			return
 		}
 
 		// There's been some real error.
-		err := gtserror.Newf("error calling sideEffectActor.PostInbox: %w", err)
-		return false, gtserror.NewErrorInternalError(err)
+		l.Errorf("error calling sideEffectActor.PostInbox: %v", err)
+		return
+	}
+
+	if key != "" {
+		// Processed OK, so drop any
+		// further deliveries of this.
+		f.seen.Set(key, struct{}{})
 	}
 
 	// Side effects are complete. Now delegate determining whether
@@ -219,10 +314,69 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 			l.Warnf("error calling sideEffectActor.InboxForwarding: %v", err)
 		}
 	}
+}
 
-	// Request is now undergoing processing. Caller
-	// of this function will handle writing Accepted.
-	return true, nil
+// seenKey returns the key under which to track the given
+// activity from the given actor as seen, or "" if either
+// is unset. Keying by actor as well as activity ID means
+// actors can only mark their own activities as seen.
+func seenKey(actorURI string, activity pub.Activity) string {
+	activityID := ap.GetJSONLDId(activity)
+	if actorURI == "" || activityID == nil {
+		return ""
+	}
+	return actorURI + " " + activityID.String()
+}
+
+// validateInboxActivity checks that the given activity
+// has the object and target properties required to
+// process it, mirroring the checks made by the go-fed
+// callbacks, so malformed activities can be rejected
+// before they're accepted for asynchronous processing.
+func validateInboxActivity(activity pub.Activity) error {
+	var needsObject, needsTarget bool
+
+	switch activity.GetTypeName() {
+	case ap.ActivityCreate,
+		ap.ActivityUpdate,
+		ap.ActivityDelete,
+		ap.ActivityFollow,
+		ap.ActivityLike,
+		ap.ActivityUndo,
+		ap.ActivityBlock:
+		needsObject = true
+
+	case ap.ActivityAdd,
+		ap.ActivityRemove:
+		needsObject = true
+		needsTarget = true
+	}
+
+	if needsObject {
+		withObject, ok := activity.(ap.WithObject)
+		if !ok {
+			return pub.ErrObjectRequired
+		}
+
+		op := withObject.GetActivityStreamsObject()
+		if op == nil || op.Len() == 0 {
+			return pub.ErrObjectRequired
+		}
+	}
+
+	if needsTarget {
+		withTarget, ok := activity.(ap.WithTarget)
+		if !ok {
+			return pub.ErrTargetRequired
+		}
+
+		tp := withTarget.GetActivityStreamsTarget()
+		if tp == nil || tp.Len() == 0 {
+			return pub.ErrTargetRequired
+		}
+	}
+
+	return nil
 }
 
 /*
@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/superseriousbusiness/activity/streams"
+)
+
+func TestSeenKey(t *testing.T) {
+	create := streams.NewActivityStreamsCreate()
+
+	// No ID, nothing to key by.
+	if key := seenKey("https://example.org/users/someone", create); key != "" {
+		t.Fatalf("expected empty key, got %q", key)
+	}
+
+	id, err := url.Parse("https://example.org/activities/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(id)
+	create.SetJSONLDId(idProp)
+
+	// No actor, nothing to key by.
+	if key := seenKey("", create); key != "" {
+		t.Fatalf("expected empty key, got %q", key)
+	}
+
+	// The same activity ID sent by different
+	// actors must not share a key, or one
+	// could mark the other's activity seen.
+	key1 := seenKey("https://example.org/users/someone", create)
+	key2 := seenKey("https://example.com/users/someone_else", create)
+	if key1 == "" || key2 == "" || key1 == key2 {
+		t.Fatalf("expected distinct keys, got %q and %q", key1, key2)
+	}
+}
@@ -26,8 +26,9 @@ import (
 
 // StatusBoostable checks if given status is boostable by requester, checking boolean status visibility to requester and ultimately the AP status visibility setting.
 func (f *Filter) StatusBoostable(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
-	if status.Visibility == gtsmodel.VisibilityDirect {
-		log.Trace(ctx, "direct statuses are not boostable")
+	if status.Visibility == gtsmodel.VisibilityDirect ||
+		status.Visibility == gtsmodel.VisibilityCircle {
+		log.Tracef(ctx, "%s statuses are not boostable", status.Visibility)
 		return false, nil
 	}
 
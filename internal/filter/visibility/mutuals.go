@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// StatusMutuals returns the accounts that were mutuals of
+// the given status' author at the time the status was created,
+// ie., the intended audience of a mutuals-only status.
+//
+// Accounts that became mutuals with the author after the status
+// was created are not included, so that changes in relationships
+// don't retroactively expose mutuals-only statuses to them.
+func (f *Filter) StatusMutuals(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.Account, error) {
+	// Get all follows targeting the author.
+	followers, err := f.state.DB.GetAccountFollowers(ctx, status.AccountID, nil)
+	if err != nil {
+		return nil, gtserror.Newf("error getting followers of %s: %w", status.AccountID, err)
+	}
+
+	mutuals := make([]*gtsmodel.Account, 0, len(followers))
+	for _, follower := range followers {
+		if follower.CreatedAt.After(status.CreatedAt) {
+			// Followed the author
+			// after status created.
+			continue
+		}
+
+		// Check author follows back.
+		ok, err := f.followedBefore(ctx,
+			status.AccountID,
+			follower.AccountID,
+			status,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			mutuals = append(mutuals, follower.Account)
+		}
+	}
+
+	return mutuals, nil
+}
+
+// isStatusMutual returns whether the given requester
+// was a mutual of the given status' author at the time
+// the status was created. See StatusMutuals().
+func (f *Filter) isStatusMutual(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	// Check requester follows author.
+	ok, err := f.followedBefore(ctx,
+		requester.ID,
+		status.AccountID,
+		status,
+	)
+	if !ok /* ok = false when err != nil */ {
+		return false, err
+	}
+
+	// Check author follows requester.
+	return f.followedBefore(ctx,
+		status.AccountID,
+		requester.ID,
+		status,
+	)
+}
+
+// followedBefore returns whether source account follows
+// target account, with the follow having been created
+// no later than the given status.
+func (f *Filter) followedBefore(ctx context.Context, sourceID string, targetID string, status *gtsmodel.Status) (bool, error) {
+	follow, err := f.state.DB.GetFollow(
+		gtscontext.SetBarebones(ctx),
+		sourceID,
+		targetID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("error checking follow %s->%s: %w", sourceID, targetID, err)
+	}
+
+	if follow == nil {
+		// Not following.
+		return false, nil
+	}
+
+	return !follow.CreatedAt.After(status.CreatedAt), nil
+}
@@ -111,6 +111,17 @@ func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Accoun
 		return true, nil
 	}
 
+	if status.Visibility == gtsmodel.VisibilityCircle {
+		// Circle statuses are visible only to their
+		// chosen audience, even if they mention others.
+		if !status.InAudience(requester.ID) {
+			log.Trace(ctx, "circle status not visible to requester")
+			return false, nil
+		}
+
+		return true, nil
+	}
+
 	if status.MentionsAccount(requester.ID) {
 		// Status mentions the requesting account.
 		return true, nil
@@ -150,13 +161,15 @@ func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Accoun
 		return true, nil
 
 	case gtsmodel.VisibilityMutualsOnly:
-		// Check mutual following between requester and author.
-		mutuals, err := f.state.DB.IsMutualFollowing(ctx,
-			requester.ID,
-			status.AccountID,
+		// Check requester and author were mutuals
+		// when the status was created; becoming
+		// mutuals later doesn't grant visibility.
+		mutuals, err := f.isStatusMutual(ctx,
+			requester,
+			status,
 		)
 		if err != nil {
-			return false, gtserror.Newf("error checking mutual follow %s<->%s: %w", requester.ID, status.AccountID, err)
+			return false, err
 		}
 
 		if !mutuals {
@@ -20,6 +20,7 @@ package visibility_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -29,6 +30,34 @@ type StatusVisibleTestSuite struct {
 	FilterStandardTestSuite
 }
 
+// putMutualsOnlyStatus stores and returns a new copy of
+// local_account_1's mutuals-only status, created now, so
+// that the standard follows between local_account_1 and
+// local_account_2 were made before it.
+func (suite *StatusVisibleTestSuite) putMutualsOnlyStatus() *gtsmodel.Status {
+	ctx := context.Background()
+
+	status := new(gtsmodel.Status)
+	*status = *suite.testStatuses["local_account_1_status_4"]
+	status.ID = "01J2M9CSY0FQ2WKDE4V1SVA5Z4"
+	status.URI = "http://localhost:8080/users/the_mighty_zork/statuses/" + status.ID
+	status.URL = "http://localhost:8080/@the_mighty_zork/statuses/" + status.ID
+	status.AttachmentIDs = nil
+	status.Attachments = nil
+	status.CreatedAt = time.Now()
+	status.UpdatedAt = status.CreatedAt
+	if err := suite.db.PutStatus(ctx, status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	status, err := suite.db.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
 func (suite *StatusVisibleTestSuite) TestOwnStatusVisible() {
 	testStatus := suite.testStatuses["local_account_1_status_1"]
 	testAccount := suite.testAccounts["local_account_1"]
@@ -98,6 +127,31 @@ func (suite *StatusVisibleTestSuite) TestStatusNotVisibleIfNotMutuals() {
 	suite.False(visible)
 }
 
+func (suite *StatusVisibleTestSuite) TestStatusNotVisibleIfMutualsAfterStatus() {
+	ctx := context.Background()
+
+	testStatus := suite.putMutualsOnlyStatus()
+	testAccount := suite.testAccounts["local_account_2"]
+
+	// Recreate the follow as if it
+	// was only made after the status.
+	follow := new(gtsmodel.Follow)
+	*follow = *suite.testFollows["local_account_2_local_account_1"]
+	err := suite.db.DeleteFollowByID(ctx, follow.ID)
+	suite.NoError(err)
+
+	follow.ID = "01J1KSQ4Z1V7ZW0Y8NGPZW4V8F"
+	follow.CreatedAt = testStatus.CreatedAt.Add(time.Minute)
+	follow.UpdatedAt = follow.CreatedAt
+	err = suite.db.PutFollow(ctx, follow)
+	suite.NoError(err)
+
+	visible, err := suite.filter.StatusVisible(ctx, testAccount, testStatus)
+	suite.NoError(err)
+
+	suite.False(visible)
+}
+
 func (suite *StatusVisibleTestSuite) TestStatusNotVisibleIfNotFollowing() {
 	ctx := context.Background()
 
@@ -116,9 +170,7 @@ func (suite *StatusVisibleTestSuite) TestStatusNotVisibleIfNotFollowing() {
 
 func (suite *StatusVisibleTestSuite) TestStatusNotVisibleIfNotMutualsCached() {
 	ctx := context.Background()
-	testStatusID := suite.testStatuses["local_account_1_status_4"].ID
-	testStatus, err := suite.db.GetStatusByID(ctx, testStatusID)
-	suite.NoError(err)
+	testStatus := suite.putMutualsOnlyStatus()
 	testAccount := suite.testAccounts["local_account_2"]
 
 	// Perform a status visibility check while mutuals, this shsould be true.
@@ -44,6 +44,8 @@ const (
 	httpSigPubKeyIDKey
 	dryRunKey
 	httpClientSignFnKey
+	signedFetchExemptKey
+	moderationKey
 )
 
 // DryRun returns whether the "dryrun" context key has been set. This can be
@@ -60,6 +62,21 @@ func SetDryRun(ctx context.Context) context.Context {
 	return context.WithValue(ctx, dryRunKey, struct{}{})
 }
 
+// Moderation returns whether the "moderation" context key has been set. This
+// can be used to indicate to functions that the operation is being carried out
+// as a side effect of a moderation action, eg., deleting the statuses of an
+// account that's being suspended, rather than at the request of the account.
+func Moderation(ctx context.Context) bool {
+	_, ok := ctx.Value(moderationKey).(struct{})
+	return ok
+}
+
+// SetModeration sets the "moderation" context flag and returns this wrapped context.
+// See Moderation() for further information on the "moderation" context flag.
+func SetModeration(ctx context.Context) context.Context {
+	return context.WithValue(ctx, moderationKey, struct{}{})
+}
+
 // RequestID returns the request ID associated with context. This value will usually
 // be set by the request ID middleware handler, either pulling an existing supplied
 // value from request headers, or generating a unique new entry. This is useful for
@@ -181,6 +198,20 @@ func SetHTTPSignaturePubKeyID(ctx context.Context, pubKeyID *url.URL) context.Co
 	return context.WithValue(ctx, httpSigPubKeyIDKey, pubKeyID)
 }
 
+// SignedFetchExempt returns the configured exemption entry (domain or CIDR)
+// that permitted the current unsigned ActivityPub GET request to proceed
+// without an http signature, or an empty string if the request isn't exempt.
+func SignedFetchExempt(ctx context.Context) string {
+	exempt, _ := ctx.Value(signedFetchExemptKey).(string)
+	return exempt
+}
+
+// SetSignedFetchExempt stores the given exemption entry and returns the wrapped
+// context. See SignedFetchExempt() for further information on the exemption value.
+func SetSignedFetchExempt(ctx context.Context, exempt string) context.Context {
+	return context.WithValue(ctx, signedFetchExemptKey, exempt)
+}
+
 // IsFastFail returns whether the "fastfail" context key has been set. This
 // can be used to indicate to an http client, for example, that the result
 // of an outgoing request is time sensitive and so not to bother with retries.
@@ -39,6 +39,7 @@ type Account struct {
 	FetchedAt               time.Time        `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
 	Username                string           `bun:",nullzero,notnull,unique:usernamedomain"`                     // Username of the account, should just be a string of [a-zA-Z0-9_]. Can be added to domain to create the full username in the form ``[username]@[domain]`` eg., ``user_96@example.org``. Username and domain should be unique *with* each other
 	Domain                  string           `bun:",nullzero,unique:usernamedomain"`                             // Domain of the account, will be null if this is a local account, otherwise something like ``example.org``. Should be unique with username.
+	PreviousUsernames       []string         `bun:"previous_usernames,array"`                                    // Usernames previously used by this (local) account, if it has been renamed. Advertised as acct: aliases via webfinger.
 	AvatarMediaAttachmentID string           `bun:"type:CHAR(26),nullzero"`                                      // Database ID of the media attachment, if present
 	AvatarMediaAttachment   *MediaAttachment `bun:"rel:belongs-to"`                                              // MediaAttachment corresponding to avatarMediaAttachmentID
 	AvatarRemoteURL         string           `bun:",nullzero"`                                                   // For a non-local account, where can the header be fetched?
@@ -52,7 +53,10 @@ type Account struct {
 	FieldsRaw               []*Field         `bun:""`                                                            // The raw (unparsed) content of fields that this account has added to their profile, without conversion to HTML, only available when requester = target
 	Note                    string           `bun:""`                                                            // A note that this account has on their profile (ie., the account's bio/description of themselves)
 	NoteRaw                 string           `bun:""`                                                            // The raw contents of .Note without conversion to HTML, only available when requester = target
+	Pronouns                string           `bun:",nullzero"`                                                   // Plain text pronouns that this account has set on their profile, eg., "they/them".
 	Memorial                *bool            `bun:",default:false"`                                              // Is this a memorial account, ie., has the user passed away?
+	FeaturedTagIDs          []string         `bun:"featured_tags,array"`                                         // Database IDs of hashtags this account has featured on their profile, in the order they were featured.
+	FeaturedTags            []*Tag           `bun:"-"`                                                           // Hashtags corresponding to featuredTagIDs (field not stored in the db).
 	AlsoKnownAsURIs         []string         `bun:"also_known_as_uris,array"`                                    // This account is associated with these account URIs.
 	AlsoKnownAs             []*Account       `bun:"-"`                                                           // This account is associated with these accounts (field not stored in the db).
 	MovedToURI              string           `bun:",nullzero"`                                                   // This account has (or claims to have) moved to this account URI. Even if this field is set the move may not yet have been processed. Check `move` for this.
@@ -136,6 +140,28 @@ func (a *Account) EmojisPopulated() bool {
 	return true
 }
 
+// FeaturedTagsPopulated returns whether featured tags
+// are populated according to current FeaturedTagIDs.
+func (a *Account) FeaturedTagsPopulated() bool {
+	if len(a.FeaturedTagIDs) != len(a.FeaturedTags) {
+		// this is the quickest indicator.
+		return false
+	}
+
+	// Tags must be in same order.
+	for i, id := range a.FeaturedTagIDs {
+		if a.FeaturedTags[i] == nil {
+			log.Warnf(nil, "nil tag in featured tags slice for account %s", a.URI)
+			continue
+		}
+		if a.FeaturedTags[i].ID != id {
+			return false
+		}
+	}
+
+	return true
+}
+
 // AlsoKnownAsPopulated returns whether alsoKnownAs accounts
 // are populated according to current AlsoKnownAsURIs.
 func (a *Account) AlsoKnownAsPopulated() bool {
@@ -187,6 +213,39 @@ func (a *Account) IsMoving() bool {
 	return a.MovedToURI != "" || a.MoveID != ""
 }
 
+// FollowNeedsApproval returns whether a follow request from
+// requester to this (local) account must be manually approved.
+// This is the case if the account is locked, unless its settings
+// auto-approve follow requests from established accounts, and the
+// requester is one: local accounts are established unless the
+// settings say otherwise, while remote accounts are established
+// once known to this instance for longer than the set threshold.
+//
+// Settings of the account are expected to be populated.
+func (a *Account) FollowNeedsApproval(requester *Account) bool {
+	if a.Locked != nil && !*a.Locked {
+		// Unlocked.
+		return false
+	}
+
+	if a.Settings == nil || a.Settings.AutoApproveFollowsAfter <= 0 {
+		// Locked, and all follow
+		// requests must be reviewed.
+		return true
+	}
+
+	if requester.IsLocal() &&
+		(a.Settings.ReviewLocalFollows == nil || !*a.Settings.ReviewLocalFollows) {
+		// Local accounts are trusted.
+		return false
+	}
+
+	// Established once known for long enough. Note
+	// that for remote accounts this is the time the
+	// account was first seen, not created on origin.
+	return time.Since(requester.CreatedAt) < a.Settings.AutoApproveFollowsAfter
+}
+
 // AccountToEmoji is an intermediate struct to facilitate the many2many relationship between an account and one or more emojis.
 type AccountToEmoji struct {
 	AccountID string   `bun:"type:CHAR(26),unique:accountemoji,nullzero,notnull"`
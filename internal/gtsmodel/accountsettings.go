@@ -21,15 +21,63 @@ import "time"
 
 // AccountSettings models settings / preferences for a local, non-instance account.
 type AccountSettings struct {
-	AccountID         string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // AccountID that owns this settings.
-	CreatedAt         time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created.
-	UpdatedAt         time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item was last updated.
-	Privacy           Visibility `bun:",nullzero"`                                                   // Default post privacy for this account
-	Sensitive         *bool      `bun:",nullzero,notnull,default:false"`                             // Set posts from this account to sensitive by default?
-	Language          string     `bun:",nullzero,notnull,default:'en'"`                              // What language does this account post in?
-	StatusContentType string     `bun:",nullzero"`                                                   // What is the default format for statuses posted by this account (only for local accounts).
-	Theme             string     `bun:",nullzero"`                                                   // Preset CSS theme filename selected by this Account (empty string if nothing set).
-	CustomCSS         string     `bun:",nullzero"`                                                   // Custom CSS that should be displayed for this Account's profile and statuses.
-	EnableRSS         *bool      `bun:",nullzero,notnull,default:false"`                             // enable RSS feed subscription for this account's public posts at [URL]/feed
-	HideCollections   *bool      `bun:",nullzero,notnull,default:false"`                             // Hide this account's followers/following collections.
+	AccountID                string        `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // AccountID that owns this settings.
+	CreatedAt                time.Time     `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created.
+	UpdatedAt                time.Time     `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item was last updated.
+	Privacy                  Visibility    `bun:",nullzero"`                                                   // Default post privacy for this account
+	ReplyPrivacy             Visibility    `bun:",nullzero"`                                                   // Default privacy of replies: that of the replied-to post, but never wider than this (empty string to use Privacy).
+	Sensitive                *bool         `bun:",nullzero,notnull,default:false"`                             // Set posts from this account to sensitive by default?
+	DefaultMediaSensitive    *bool         `bun:",nullzero,notnull,default:false"`                             // Mark posts from this account with media attached as sensitive, if not set explicitly.
+	Language                 string        `bun:",nullzero,notnull,default:'en'"`                              // What language does this account post in?
+	LocalOnly                *bool         `bun:",nullzero,notnull,default:false"`                             // Make posts from this account local-only (not federated) by default, if not set explicitly.
+	DetectLanguage           *bool         `bun:",nullzero,notnull,default:false"`                             // Detect the language of new statuses posted without one, instead of using Language straight away.
+	ContinueThread           *bool         `bun:",nullzero,notnull,default:false"`                             // Make new statuses posted without in-reply-to reply to this account's most recent status, if not set explicitly.
+	StatusContentType        string        `bun:",nullzero"`                                                   // What is the default format for statuses posted by this account (only for local accounts).
+	BioContentType           string        `bun:",nullzero"`                                                   // Format of this account's bio / note (empty string to use StatusContentType).
+	Theme                    string        `bun:",nullzero"`                                                   // Preset CSS theme filename selected by this Account (empty string if nothing set).
+	CustomCSS                string        `bun:",nullzero"`                                                   // Custom CSS that should be displayed for this Account's profile and statuses.
+	EnableRSS                *bool         `bun:",nullzero,notnull,default:false"`                             // enable RSS feed subscription for this account's public posts at [URL]/feed
+	HideCollections          *bool         `bun:",nullzero,notnull,default:false"`                             // Hide this account's followers/following collections.
+	DefaultContentWarning    string        `bun:",nullzero"`                                                   // Content warning / spoiler text to pre-populate new statuses with (empty string if nothing set).
+	DefaultPostExpiry        time.Duration `bun:",nullzero"`                                                   // Automatically delete new statuses after this duration (0 if nothing set).
+	AutoApproveFollowsAfter  time.Duration `bun:",nullzero"`                                                   // If locked, automatically approve follow requests from accounts known for longer than this (0 to review all).
+	ReviewLocalFollows       *bool         `bun:",nullzero,notnull,default:false"`                             // If AutoApproveFollowsAfter is set, review follow requests from local accounts as well, instead of trusting them.
+	HideWebProfile           *bool         `bun:",nullzero,notnull,default:false"`                             // Only show this account's web profile, statuses and RSS feed to itself and followers who are logged in.
+	AwayEnabled              *bool         `bun:",nullzero,notnull,default:false"`                             // Is away mode turned on for this account?
+	AwayMessage              string        `bun:",nullzero"`                                                   // Message shown on the web profile (and sent as auto-reply, if enabled) while away.
+	AwayStartsAt             time.Time     `bun:"type:timestamptz,nullzero"`                                   // When away mode starts. If zero, starts immediately.
+	AwayEndsAt               time.Time     `bun:"type:timestamptz,nullzero"`                                   // When away mode ends. If zero, lasts until turned off.
+	AwayAutoReply            *bool         `bun:",nullzero,notnull,default:false"`                             // Automatically reply to mentions with AwayMessage while away.
+	RequireMediaDescriptions *bool         `bun:",nullzero,notnull,default:false"`                             // Reject statuses from this account that contain media without a description, even if the instance doesn't require them.
+	RotatingPinStatusIDs     []string      `bun:"rotating_pin_status_ids,array"`                               // IDs of statuses to take turns being pinned to this account's profile, in order.
+	RotatingPinInterval      time.Duration `bun:",nullzero"`                                                   // How long each status in RotatingPinStatusIDs stays pinned before the next one takes over (0 if rotation is off).
+}
+
+// NoteContentType returns the format of the account's
+// bio / note: BioContentType if set, else StatusContentType.
+// May be empty, in which case the default format is used.
+func (s *AccountSettings) NoteContentType() string {
+	if s.BioContentType != "" {
+		return s.BioContentType
+	}
+	return s.StatusContentType
+}
+
+// Away returns whether the account is in away mode at
+// the given time, ie., away mode is enabled with a message,
+// and the time falls within the (optional) away window.
+func (s *AccountSettings) Away(now time.Time) bool {
+	if s.AwayEnabled == nil || !*s.AwayEnabled || s.AwayMessage == "" {
+		return false
+	}
+
+	if !s.AwayStartsAt.IsZero() && now.Before(s.AwayStartsAt) {
+		return false
+	}
+
+	if !s.AwayEndsAt.IsZero() && !now.Before(s.AwayEndsAt) {
+		return false
+	}
+
+	return true
 }
@@ -25,6 +25,22 @@ type Client struct {
 	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
 	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
 	Secret    string    `bun:",nullzero,notnull"`                                           // secret generated when client was created
-	Domain    string    `bun:",nullzero,notnull"`                                           // domain requested for client
 	UserID    string    `bun:"type:CHAR(26),nullzero"`                                      // id of the user that this client acts on behalf of
+
+	// RedirectURIs is the set of redirect uris registered
+	// for this client. Authorization requests must use a
+	// redirect uri that matches one of these exactly (see
+	// oauth.RedirectURIAllowed for the loopback exception).
+	RedirectURIs []string `bun:"redirect_uris,array"`
+
+	// ClientCredentialsScopes is a space separated list of the scopes that
+	// may be granted to app-only tokens issued to this client via the
+	// client_credentials grant. If not set, defaults to oauth.ScopeRead.
+	ClientCredentialsScopes string `bun:",nullzero"`
+
+	// AccessTokenTTL and RefreshTokenTTL are the lifetimes of
+	// access and refresh tokens issued to this client. If not
+	// set, the instance-wide defaults are used instead.
+	AccessTokenTTL  time.Duration `bun:",nullzero"`
+	RefreshTokenTTL time.Duration `bun:",nullzero"`
 }
@@ -0,0 +1,33 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Consent is a user's remembered approval of an OAuth client acting on their
+// behalf with the given scope, so that they needn't approve it again every time
+// the client asks for authorization. It's superseded if the client asks for
+// a broader scope, and can be revoked by the user.
+type Consent struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                              // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`           // when was item created
+	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`           // when was item last updated
+	UserID    string    `bun:"type:CHAR(26),unique:consents_user_id_client_id_uniq,nullzero,notnull"` // ID of the user who gave consent
+	ClientID  string    `bun:"type:CHAR(26),unique:consents_user_id_client_id_uniq,nullzero,notnull"` // ID of the client consented to
+	Scope     string    `bun:",nullzero,notnull"`                                                     // Oauth scope consented to
+}
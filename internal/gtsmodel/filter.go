@@ -19,6 +19,9 @@ package gtsmodel
 
 import (
 	"regexp"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -31,13 +34,21 @@ type Filter struct {
 	AccountID            string           `bun:"type:CHAR(26),notnull,nullzero"`                              // ID of the local account that created the filter.
 	Title                string           `bun:",nullzero,notnull,unique"`                                    // The name of the filter.
 	Action               FilterAction     `bun:",nullzero,notnull"`                                           // The action to take.
+	Mode                 FilterMode       `bun:",nullzero,notnull,default:'block'"`                           // Whether the filter blocks or allows matching statuses.
 	Keywords             []*FilterKeyword `bun:"-"`                                                           // Keywords for this filter.
 	Statuses             []*FilterStatus  `bun:"-"`                                                           // Statuses for this filter.
+	TargetAccountIDs     []string         `bun:"target_account_ids,array"`                                    // IDs of accounts whose statuses this filter matches.
+	TargetDomains        []string         `bun:"target_domains,array"`                                        // Domains of accounts whose statuses this filter matches.
+	TargetSubdomains     *bool            `bun:",nullzero,notnull,default:false"`                             // Whether TargetDomains also match their subdomains.
 	ContextHome          *bool            `bun:",nullzero,notnull,default:false"`                             // Apply filter to home timeline and lists.
 	ContextNotifications *bool            `bun:",nullzero,notnull,default:false"`                             // Apply filter to notifications.
 	ContextPublic        *bool            `bun:",nullzero,notnull,default:false"`                             // Apply filter to home timeline and lists.
 	ContextThread        *bool            `bun:",nullzero,notnull,default:false"`                             // Apply filter when viewing a status's associated thread.
 	ContextAccount       *bool            `bun:",nullzero,notnull,default:false"`                             // Apply filter when viewing an account profile.
+	ActiveDays           []int            `bun:"active_days,array"`                                           // Days of the week (0 = Sunday) on which the filter applies. If empty, applies every day.
+	ActiveFrom           int              `bun:",notnull,default:0"`                                          // Minutes after midnight from which the filter applies each active day.
+	ActiveUntil          int              `bun:",notnull,default:0"`                                          // Minutes after midnight until which the filter applies. If equal to ActiveFrom, applies all day.
+	ActiveTimezone       string           `bun:",nullzero"`                                                   // IANA time zone in which ActiveDays, ActiveFrom and ActiveUntil are interpreted. If empty, UTC.
 }
 
 // Expired returns whether the filter has expired at a given time.
@@ -46,6 +57,102 @@ func (f *Filter) Expired(now time.Time) bool {
 	return !f.ExpiresAt.IsZero() && !f.ExpiresAt.After(now)
 }
 
+// Active returns whether the filter applies at a given time,
+// ie., it has not expired, and the time falls within its
+// recurring active window (if any) in the filter's time zone.
+func (f *Filter) Active(now time.Time) bool {
+	if f.Expired(now) {
+		return false
+	}
+
+	if len(f.ActiveDays) == 0 && f.ActiveFrom == f.ActiveUntil {
+		// No window set,
+		// always active.
+		return true
+	}
+
+	now = now.In(filterLocation(f.ActiveTimezone))
+	day := now.Weekday()
+	mins := now.Hour()*60 + now.Minute()
+
+	switch {
+	case f.ActiveFrom == f.ActiveUntil:
+		// Active all day.
+
+	case f.ActiveFrom < f.ActiveUntil:
+		// Window within a single day.
+		if mins < f.ActiveFrom || mins >= f.ActiveUntil {
+			return false
+		}
+
+	case mins >= f.ActiveFrom:
+		// Window wraps past midnight,
+		// and we're in the starting day.
+
+	case mins < f.ActiveUntil:
+		// Window wraps past midnight, and we're
+		// in the early hours of the next day, so
+		// the window belongs to the previous day.
+		day = (day + 6) % 7
+
+	default:
+		return false
+	}
+
+	if len(f.ActiveDays) == 0 {
+		return true
+	}
+
+	return slices.Contains(f.ActiveDays, int(day))
+}
+
+// filterLocations caches loaded filter time zones.
+var filterLocations sync.Map
+
+// filterLocation returns the location with the given IANA
+// name, falling back to UTC if empty or unable to be loaded.
+func filterLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+
+	if loc, ok := filterLocations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	filterLocations.Store(name, loc)
+	return loc
+}
+
+// TargetsAccount returns whether the filter matches statuses
+// authored by the account with the given ID and domain, either
+// by account ID, or by domain (and subdomains, if enabled).
+// Local accounts, with an empty domain, only match by ID.
+func (f *Filter) TargetsAccount(accountID string, domain string) bool {
+	if slices.Contains(f.TargetAccountIDs, accountID) {
+		return true
+	}
+
+	if domain == "" {
+		return false
+	}
+
+	subdomains := f.TargetSubdomains != nil && *f.TargetSubdomains
+	for _, target := range f.TargetDomains {
+		if domain == target ||
+			(subdomains && strings.HasSuffix(domain, "."+target)) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // FilterKeyword stores a single keyword to filter statuses against.
 type FilterKeyword struct {
 	ID        string         `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                     // id of this item in the database
@@ -56,6 +163,7 @@ type FilterKeyword struct {
 	Filter    *Filter        `bun:"-"`                                                                            // Filter corresponding to FilterID
 	Keyword   string         `bun:",nullzero,notnull,unique:filter_keywords_filter_id_keyword_uniq"`              // The keyword or phrase to filter against.
 	WholeWord *bool          `bun:",nullzero,notnull,default:false"`                                              // Should the filter consider word boundaries?
+	Regex     *bool          `bun:",nullzero,notnull,default:false"`                                              // Should Keyword be treated as a regular expression instead of a literal phrase?
 	Regexp    *regexp.Regexp `bun:"-"`                                                                            // pre-prepared regular expression
 }
 
@@ -66,9 +174,16 @@ func (k *FilterKeyword) Compile() (err error) {
 		wordBreak = `\b`
 	}
 
+	// Regex keywords are used as-is (grouped, so
+	// alternations stay within the word breaks),
+	// anything else is matched as a literal phrase.
+	expr := regexp.QuoteMeta(k.Keyword)
+	if k.Regex != nil && *k.Regex {
+		expr = `(?:` + k.Keyword + `)`
+	}
+
 	// Compile keyword filter regexp.
-	quoted := regexp.QuoteMeta(k.Keyword)
-	k.Regexp, err = regexp.Compile(`(?i)` + wordBreak + quoted + wordBreak)
+	k.Regexp, err = regexp.Compile(`(?i)` + wordBreak + expr + wordBreak)
 	return // caller is expected to wrap this error
 }
 
@@ -93,4 +208,28 @@ const (
 	FilterActionWarn FilterAction = "warn"
 	// FilterActionHide means that the status should be removed from timeline results entirely.
 	FilterActionHide FilterAction = "hide"
+	// FilterActionBlur means that the status's media should be collapsed behind a reveal,
+	// while the rest of the status remains visible.
+	FilterActionBlur FilterAction = "blur"
+	// FilterActionNotify means that the user should be notified when the status reaches
+	// their home timeline, while the status itself remains visible as normal.
+	FilterActionNotify FilterAction = "notify"
+)
+
+// FilterMode represents whether a filter acts on the statuses that match
+// it (block), or on the statuses that don't match it (allow).
+type FilterMode string
+
+const (
+	// FilterModeNone is treated the same as FilterModeBlock,
+	// for filters created before filter modes were introduced.
+	FilterModeNone FilterMode = ""
+	// FilterModeBlock means that the filter action
+	// is applied to statuses that match the filter.
+	FilterModeBlock FilterMode = "block"
+	// FilterModeAllow means that, in each context the filter applies to,
+	// only statuses that match the filter (or another allow filter in the
+	// same context) should be shown, and all other statuses are hidden.
+	// The filter action is not used.
+	FilterModeAllow FilterMode = "allow"
 )
@@ -47,4 +47,5 @@ const (
 	NotificationPoll          NotificationType = "poll"           // NotificationPoll -- a poll you voted in or created has ended
 	NotificationStatus        NotificationType = "status"         // NotificationStatus -- someone you enabled notifications for has posted a status.
 	NotificationSignup        NotificationType = "admin.sign_up"  // NotificationSignup -- someone has submitted a new account sign-up to the instance.
+	NotificationFilterMatch   NotificationType = "filter_match"   // NotificationFilterMatch -- a status matching one of your filters with the notify action has reached your home timeline.
 )
@@ -0,0 +1,35 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ScheduledStatus represents a status created by a local account,
+// which will be published at a later time. Until then, the status
+// only exists as the parameters it will be created with.
+type ScheduledStatus struct {
+	ID            string       `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt     time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt     time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID     string       `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local account that scheduled the status
+	Account       *Account     `bun:"-"`                                                           // Account corresponding to AccountID
+	ApplicationID string       `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the application used to schedule the status
+	Application   *Application `bun:"-"`                                                           // Application corresponding to ApplicationID
+	ScheduledAt   time.Time    `bun:"type:timestamptz,nullzero,notnull"`                           // when should the status be published
+	Params        string       `bun:",nullzero,notnull"`                                           // JSON encoded parameters to create the status with when it's published
+}
@@ -29,6 +29,7 @@ type Status struct {
 	UpdatedAt                time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
 	FetchedAt                time.Time          `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
 	PinnedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status was pinned by owning account at this time.
+	ExpiresAt                time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status will be automatically deleted at this time (local statuses only).
 	URI                      string             `bun:",unique,nullzero,notnull"`                                    // activitypub URI of this status
 	URL                      string             `bun:",nullzero"`                                                   // web url for viewing this status
 	Content                  string             `bun:""`                                                            // content of this status; likely html-formatted but not guaranteed
@@ -38,6 +39,8 @@ type Status struct {
 	Tags                     []*Tag             `bun:"attached_tags,m2m:status_to_tags"`                            // Tags corresponding to tagIDs. https://bun.uptrace.dev/guide/relations.html#many-to-many-relation
 	MentionIDs               []string           `bun:"mentions,array"`                                              // Database IDs of any mentions in this status
 	Mentions                 []*Mention         `bun:"attached_mentions,rel:has-many"`                              // Mentions corresponding to mentionIDs
+	AudienceIDs              []string           `bun:"audience,array"`                                              // Database IDs of accounts a circle status is addressed to
+	Audience                 []*Account         `bun:"-"`                                                           // Accounts corresponding to audienceIDs
 	EmojiIDs                 []string           `bun:"emojis,array"`                                                // Database IDs of any emojis used in this status
 	Emojis                   []*Emoji           `bun:"attached_emojis,m2m:status_to_emojis"`                        // Emojis corresponding to emojiIDs. https://bun.uptrace.dev/guide/relations.html#many-to-many-relation
 	Local                    *bool              `bun:",nullzero,notnull,default:false"`                             // is this status from a local account?
@@ -133,6 +136,20 @@ func (s *Status) MentionsPopulated() bool {
 	return true
 }
 
+// AudiencePopulated returns whether audience accounts are populated according to current AudienceIDs.
+func (s *Status) AudiencePopulated() bool {
+	if len(s.AudienceIDs) != len(s.Audience) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range s.AudienceIDs {
+		if s.Audience[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
+
 // EmojisPopulated returns whether emojis are populated according to current EmojiIDs.
 func (s *Status) EmojisPopulated() bool {
 	if len(s.EmojiIDs) != len(s.Emojis) {
@@ -200,6 +217,12 @@ func (s *Status) MentionsAccount(accountID string) bool {
 	})
 }
 
+// InAudience returns whether the given account ID is in
+// the chosen audience of the status (circle statuses only).
+func (s *Status) InAudience(accountID string) bool {
+	return slices.Contains(s.AudienceIDs, accountID)
+}
+
 // BelongsToAccount returns whether status belongs to the given account ID.
 func (s *Status) BelongsToAccount(accountID string) bool {
 	return s.AccountID == accountID
@@ -239,6 +262,8 @@ const (
 	VisibilityFollowersOnly Visibility = "followers_only"
 	// VisibilityMutualsOnly means this status is visible to mutual followers only.
 	VisibilityMutualsOnly Visibility = "mutuals_only"
+	// VisibilityCircle means this status is visible only to a chosen audience of followers.
+	VisibilityCircle Visibility = "circle"
 	// VisibilityDirect means this status is visible only to mentioned recipients.
 	VisibilityDirect Visibility = "direct"
 	// VisibilityDefault is used when no other setting can be found.
@@ -39,4 +39,9 @@ type Token struct {
 	Refresh             string    `bun:",pk,nullzero,notnull,default:''"`                             // Refresh token, if present
 	RefreshCreateAt     time.Time `bun:"type:timestamptz,nullzero"`                                   // Refresh created at, if refresh present
 	RefreshExpiresAt    time.Time `bun:"type:timestamptz,nullzero"`                                   // Refresh expires at -- null means the refresh token never expires
+	RefreshConsumedAt   time.Time `bun:"type:timestamptz,nullzero"`                                   // Refresh token was exchanged for a new token at this time -- null means not yet used
+	FamilyID            string    `bun:"type:CHAR(26),nullzero"`                                      // ID of the first token in this token's line of refreshed tokens
+	ReadOnly            *bool     `bun:",nullzero,notnull,default:false"`                             // Token can't be used for any write requests, regardless of scope (eg., for demos)
+	DeviceName          string    `bun:",nullzero"`                                                   // Human-readable name of the device / app this token was issued to, if the client provided one
+	LastUsedAt          time.Time `bun:"type:timestamptz,nullzero"`                                   // Token was last used to authenticate a request around this time -- null means never used
 }
@@ -0,0 +1,204 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package language
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DetectThreshold is the confidence, from 0 to 1, that
+// Detect requires before it will identify a language.
+const DetectThreshold = 0.6
+
+// Detect attempts to identify the language of the given
+// status text, returning its ISO 639-1 code, or an empty
+// string if it can't be identified with at least the
+// confidence of DetectThreshold.
+func Detect(text string) string {
+	lang, confidence := DetectConfidence(text)
+	if confidence < DetectThreshold {
+		return ""
+	}
+	return lang
+}
+
+// DetectConfidence attempts to identify the language of the
+// given status text, returning its ISO 639-1 code, and how
+// confident it is of that, from 0 to 1. An empty code (with
+// confidence 0) means there was nothing to go on at all.
+//
+// This is a deliberately cheap heuristic, not a statistical
+// model: text mostly in a script that's used by only one
+// language is identified by its script, with the share of
+// letters in that script as confidence, and text in Latin
+// script by counting common short words of a handful of
+// languages, with the share of hits for the best language
+// as confidence, halved if there's only a single hit. Links,
+// mentions and hashtags are ignored.
+func DetectConfidence(text string) (string, float64) {
+	var (
+		words   []string
+		letters int
+		scripts = make(map[string]int)
+	)
+
+	for _, field := range strings.Fields(text) {
+		if strings.HasPrefix(field, "@") ||
+			strings.HasPrefix(field, "#") ||
+			strings.Contains(field, "://") {
+			// Not part of the prose.
+			continue
+		}
+
+		for _, r := range field {
+			if !unicode.IsLetter(r) {
+				continue
+			}
+
+			letters++
+			for _, s := range detectScripts {
+				if unicode.Is(s.table, r) {
+					scripts[s.name]++
+					break
+				}
+			}
+		}
+
+		// Split into lowercase words, keeping
+		// apostrophes within words (eg., "c'est").
+		for _, word := range strings.FieldsFunc(
+			strings.ToLower(strings.ReplaceAll(field, "’", "'")),
+			func(r rune) bool { return !unicode.IsLetter(r) && r != '\'' },
+		) {
+			if word = strings.Trim(word, "'"); word != "" {
+				words = append(words, word)
+			}
+		}
+	}
+
+	if letters == 0 {
+		return "", 0
+	}
+
+	// Japanese mixes kana with kanji,
+	// so count those together, but only
+	// if there's any kana at all, since
+	// kanji alone could well be Chinese.
+	if kana := scripts["kana"]; kana > 0 && 2*(kana+scripts["han"]) > letters {
+		return "ja", float64(kana+scripts["han"]) / float64(letters)
+	}
+
+	for script, lang := range scriptLanguages {
+		if 2*scripts[script] > letters {
+			return lang, float64(scripts[script]) / float64(letters)
+		}
+	}
+
+	if 2*scripts["latin"] <= letters {
+		// Some other script.
+		return "", 0
+	}
+
+	// Count common words of each
+	// language found in the text.
+	var (
+		best      string
+		bestHits  int
+		otherHits int
+	)
+
+	for lang, common := range commonWords {
+		var hits int
+		for _, word := range words {
+			if _, ok := common[word]; ok {
+				hits++
+			}
+		}
+
+		switch {
+		case hits > bestHits:
+			otherHits = bestHits
+			best, bestHits = lang, hits
+		case hits > otherHits:
+			otherHits = hits
+		}
+	}
+
+	if bestHits == 0 {
+		return "", 0
+	}
+
+	// A clear lead on the runner up, with a
+	// couple of hits, reaches the threshold.
+	confidence := float64(bestHits) / float64(bestHits+otherHits)
+	if bestHits < 2 {
+		confidence /= 2
+	}
+
+	return best, confidence
+}
+
+// detectScripts are the scripts
+// counted by Detect, with names.
+var detectScripts = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{"latin", unicode.Latin},
+	{"kana", unicode.Hiragana},
+	{"kana", unicode.Katakana},
+	{"han", unicode.Han},
+	{"hangul", unicode.Hangul},
+	{"greek", unicode.Greek},
+	{"hebrew", unicode.Hebrew},
+	{"thai", unicode.Thai},
+}
+
+// scriptLanguages maps scripts that are (for our purposes)
+// used by only one language to the code of that language.
+var scriptLanguages = map[string]string{
+	"hangul": "ko",
+	"greek":  "el",
+	"hebrew": "he",
+	"thai":   "th",
+}
+
+// commonWords contains, per language, a set of
+// short words that are very common in that language.
+var commonWords = func() map[string]map[string]struct{} {
+	lists := map[string]string{
+		"en": "the and is are was were you that this with have has for not but what it's i'm of to be just they will would there their from about",
+		"de": "der die das und ist nicht ich du wir ihr sie ein eine einen mit auf auch für aber sich dem den des noch wie schon oder bin sind war habe hat zu",
+		"fr": "le les et est une des du je tu nous vous il elle ils pas qui pour dans avec sur mais ce cette c'est au aux très",
+		"es": "el los las y es una un que no por para con pero muy está estoy yo tú del al lo como más también",
+		"it": "il lo gli e è un una che non per con sono ma anche della del questo questa molto io noi voi ho hai nel",
+		"nl": "de het een en is niet ik je jij wij we zijn van op met voor maar ook dat dit wat heb heeft naar nog er",
+		"pt": "o os as e é um uma que não por para com mas muito eu você nós do da dos das no na também isso",
+	}
+
+	m := make(map[string]map[string]struct{}, len(lists))
+	for lang, list := range lists {
+		set := make(map[string]struct{})
+		for _, word := range strings.Fields(list) {
+			set[word] = struct{}{}
+		}
+		m[lang] = set
+	}
+	return m
+}()
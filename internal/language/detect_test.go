@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package language_test
+
+import (
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/language"
+)
+
+func TestDetect(t *testing.T) {
+	for _, test := range []struct {
+		text     string
+		expected string
+	}{
+		{"Just finished reading the book and I think it's one of the best I have read this year", "en"},
+		{"Ich habe heute keine Zeit, aber wir sehen uns morgen auf der Party!", "de"},
+		{"Je ne sais pas si c'est une bonne idée, mais nous verrons bien", "fr"},
+		{"No sé si es una buena idea, pero lo vamos a intentar también", "es"},
+		{"Questo è molto bello, non vedo l'ora di tornare con gli amici", "it"},
+		{"Ik heb het niet gezien, maar dat is ook niet zo erg", "nl"},
+		{"Eu não sei se isso é uma boa ideia, mas vamos ver", "pt"},
+		{"今日はとても良い天気ですね", "ja"},
+		{"오늘 날씨가 정말 좋네요", "ko"},
+		{"Καλημέρα σε όλους", "el"},
+
+		// Links, mentions and hashtags ignored.
+		{"@someone@example.org #das #ist #nicht https://example.org/die/der/und", ""},
+
+		// Not enough to go on.
+		{"lol", ""},
+		{"", ""},
+		{"🎉🎉🎉", ""},
+
+		// Chinese or Japanese?
+		{"中文", ""},
+	} {
+		if detected := language.Detect(test.text); detected != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.text, test.expected, detected)
+		}
+	}
+}
+
+func TestDetectConfidence(t *testing.T) {
+	for _, test := range []struct {
+		text       string
+		expected   string
+		confidence float64
+	}{
+		// Script alone.
+		{"오늘 날씨가 정말 좋네요", "ko", 1},
+
+		// Common words, no competition.
+		{"Ik heb het niet gezien", "nl", 1},
+
+		// A single hit: below threshold.
+		{"Le chat", "fr", 0.5},
+
+		// Nothing to go on.
+		{"🎉🎉🎉", "", 0},
+	} {
+		lang, confidence := language.DetectConfidence(test.text)
+		if lang != test.expected || confidence != test.confidence {
+			t.Errorf("%q: expected %q (%v), got %q (%v)", test.text, test.expected, test.confidence, lang, confidence)
+		}
+	}
+}
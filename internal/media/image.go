@@ -102,22 +102,15 @@ func (m *gtsImage) AspectRatio() float32 {
 		float64(m.image.Bounds().Size().Y))
 }
 
-// Thumbnail returns a small sized copy of gtsImage{}, limited to 512x512 if not small enough.
-func (m *gtsImage) Thumbnail() *gtsImage {
-	const (
-		// max thumb
-		// dimensions.
-		maxWidth  = 512
-		maxHeight = 512
-	)
-
+// Thumbnail returns a small sized copy of gtsImage{}, limited to maxSize x maxSize if not small enough.
+func (m *gtsImage) Thumbnail(maxSize int) *gtsImage {
 	// Check the receiving image is within max thumnail bounds.
-	if m.Width() <= maxWidth && m.Height() <= maxHeight {
+	if m.Width() <= maxSize && m.Height() <= maxSize {
 		return &gtsImage{image: imaging.Clone(m.image)}
 	}
 
 	// Image is too large, needs to be resized to thumbnail max.
-	img := imaging.Fit(m.image, maxWidth, maxHeight, imaging.Linear)
+	img := imaging.Fit(m.image, maxSize, maxSize, imaging.Linear)
 	return &gtsImage{image: img}
 }
 
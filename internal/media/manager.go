@@ -187,6 +187,59 @@ func (m *Manager) CreateMedia(
 	return m.RecacheMedia(attachment, data), nil
 }
 
+// CreateMediaFromUpload works like CreateMedia, but reads
+// the media data from a file that was uploaded directly to
+// storage by the owning account, at the given key (see
+// storage.Driver{}.PresignedUpload()). The uploaded file
+// is removed from storage once it has been read.
+func (m *Manager) CreateMediaFromUpload(
+	ctx context.Context,
+	accountID string,
+	key string,
+	info AdditionalMediaInfo,
+) (
+	*ProcessingMedia,
+	error,
+) {
+	if !storage.IsUploadKey(key, accountID) {
+		return nil, gtserror.Newf("key %s is not an upload by account %s", key, accountID)
+	}
+
+	data := func(ctx context.Context) (io.ReadCloser, int64, error) {
+		sz, err := m.state.Storage.Size(ctx, key)
+		if err != nil {
+			return nil, 0, gtserror.Newf("error getting size of upload %s: %w", key, err)
+		}
+
+		rc, err := m.state.Storage.GetStream(ctx, key)
+		if err != nil {
+			return nil, 0, gtserror.Newf("error opening upload %s: %w", key, err)
+		}
+
+		// Wrap closer to cleanup uploaded data.
+		c := iotools.CloserFunc(func() error {
+
+			// First try close original.
+			if err := rc.Close(); err != nil {
+				return err
+			}
+
+			// Remove the upload now stream is closed.
+			if err := m.state.Storage.Delete(ctx, key); err != nil &&
+				!storage.IsNotFound(err) {
+				log.Errorf(ctx, "error deleting upload %s from storage: %v", key, err)
+			}
+
+			return nil
+		})
+
+		// Return newly wrapped readcloser and size.
+		return iotools.ReadCloser(rc, c), sz, nil
+	}
+
+	return m.CreateMedia(ctx, accountID, data, info)
+}
+
 // RecacheMedia wraps a media model (assumed already
 // inserted in the database!) with given data function
 // to perform a blocking dereference / decode operation
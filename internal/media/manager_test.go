@@ -28,6 +28,7 @@ import (
 
 	"codeberg.org/gruf/go-storage/disk"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -441,6 +442,138 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcess() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
+func (suite *ManagerTestSuite) TestSimpleJpegProcessThumbnailSize() {
+	ctx := context.Background()
+
+	// Use smaller thumbnails than the default.
+	config.SetMediaThumbnailMaxSize(128)
+	config.SetMediaThumbnailQuality(50)
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	processing, err := suite.manager.CreateMedia(ctx,
+		accountID,
+		data,
+		media.AdditionalMediaInfo{},
+	)
+	suite.NoError(err)
+	suite.NotNil(processing)
+
+	attachment, err := processing.Load(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// Thumbnail should be limited to
+	// 128px, with aspect ratio preserved.
+	suite.EqualValues(gtsmodel.Small{
+		Width: 128, Height: 72, Size: 9216, Aspect: 1.7777777777777777,
+	}, attachment.FileMeta.Small)
+
+	// Thumbnail should be smaller than the default one.
+	defaultThumbnailBytes, err := os.ReadFile("./test/test-jpeg-thumbnail.jpg")
+	suite.NoError(err)
+	suite.Less(attachment.Thumbnail.FileSize, len(defaultThumbnailBytes))
+}
+
+func (suite *ManagerTestSuite) TestSimpleJpegProcessFromUpload() {
+	ctx := context.Background()
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+	key := storage.UploadKeyPrefix(accountID) + "01J2A8TMQ1TVJ2VQZ7WSQ0R7X6"
+
+	// put a test image in storage at the
+	// upload key, as if the client did so
+	b, err := os.ReadFile("./test/test-jpeg.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := suite.storage.Put(ctx, key, b); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// process the media from the upload
+	processing, err := suite.manager.CreateMediaFromUpload(ctx,
+		accountID,
+		key,
+		media.AdditionalMediaInfo{},
+	)
+	suite.NoError(err)
+	suite.NotNil(processing)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processing.Load(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	suite.Equal(accountID, attachment.AccountID)
+	suite.Equal("image/jpeg", attachment.File.ContentType)
+	suite.Equal(269739, attachment.File.FileSize)
+
+	// make sure the processed file is in storage
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedFullBytes)
+
+	// the upload itself should have been removed
+	hasUpload, err := suite.storage.Has(ctx, key)
+	suite.NoError(err)
+	suite.False(hasUpload)
+}
+
+func (suite *ManagerTestSuite) TestCreateMediaFromUploadWrongAccount() {
+	ctx := context.Background()
+
+	// upload key belonging to a different account
+	key := storage.UploadKeyPrefix("01F8MH1H7YV1Z7D2C8K2730QBF") + "01J2A8TMQ1TVJ2VQZ7WSQ0R7X6"
+
+	processing, err := suite.manager.CreateMediaFromUpload(ctx,
+		"01FS1X72SK9ZPW0J1QQ68BD264",
+		key,
+		media.AdditionalMediaInfo{},
+	)
+	suite.EqualError(err, "CreateMediaFromUpload: key uploads/01F8MH1H7YV1Z7D2C8K2730QBF/01J2A8TMQ1TVJ2VQZ7WSQ0R7X6 is not an upload by account 01FS1X72SK9ZPW0J1QQ68BD264")
+	suite.Nil(processing)
+}
+
+func (suite *ManagerTestSuite) TestCreateMediaFromUploadTraversal() {
+	ctx := context.Background()
+
+	// put another account's media in storage
+	victimKey := "01F8MH1H7YV1Z7D2C8K2730QBF/attachment/original/01J2A8TMQ1TVJ2VQZ7WSQ0R7X6.jpg"
+	b, err := os.ReadFile("./test/test-jpeg.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := suite.storage.Put(ctx, victimKey, b); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// key under own upload prefix, traversing out to the victim's media
+	key := storage.UploadKeyPrefix("01FS1X72SK9ZPW0J1QQ68BD264") + "../../" + victimKey
+
+	processing, err := suite.manager.CreateMediaFromUpload(ctx,
+		"01FS1X72SK9ZPW0J1QQ68BD264",
+		key,
+		media.AdditionalMediaInfo{},
+	)
+	suite.EqualError(err, "CreateMediaFromUpload: key "+key+" is not an upload by account 01FS1X72SK9ZPW0J1QQ68BD264")
+	suite.Nil(processing)
+
+	// the victim's media should be untouched
+	has, err := suite.storage.Has(ctx, victimKey)
+	suite.NoError(err)
+	suite.True(has)
+}
+
 func (suite *ManagerTestSuite) TestSimpleJpegProcessPartial() {
 	ctx := context.Background()
 
@@ -21,7 +21,6 @@ import (
 	"bytes"
 	"cmp"
 	"context"
-	"image/jpeg"
 	"io"
 	"time"
 
@@ -30,6 +29,7 @@ import (
 	terminator "codeberg.org/superseriousbusiness/exif-terminator"
 	"github.com/disintegration/imaging"
 	"github.com/h2non/filetype"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -392,7 +392,7 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 	p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
 
 	// Get smaller thumbnail image
-	thumbImg := fullImg.Thumbnail()
+	thumbImg := fullImg.Thumbnail(config.GetMediaThumbnailMaxSize())
 
 	// Garbage collector, you may
 	// now take our large son.
@@ -421,29 +421,9 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		}
 	}
 
-	// Create a thumbnail JPEG encoder stream.
-	enc := thumbImg.ToJPEG(&jpeg.Options{
-
-		// Good enough for
-		// a thumbnail.
-		Quality: 70,
-	})
-
-	// Stream-encode the JPEG thumbnail image into our storage driver.
-	sz, err := p.mgr.state.Storage.PutStream(ctx, p.media.Thumbnail.Path, enc)
-	if err != nil {
-		return gtserror.Newf("error stream-encoding thumbnail to storage: %w", err)
-	}
-
-	// Set final written thumb size.
-	p.media.Thumbnail.FileSize = int(sz)
-
-	// Set thumbnail dimensions in attachment info.
-	p.media.FileMeta.Small = gtsmodel.Small{
-		Width:  thumbImg.Width(),
-		Height: thumbImg.Height(),
-		Size:   thumbImg.Size(),
-		Aspect: thumbImg.AspectRatio(),
+	// Encode the thumbnail into storage.
+	if err := p.mgr.putThumbnail(ctx, p.media, thumbImg); err != nil {
+		return err
 	}
 
 	// Finally set the attachment as processed.
@@ -0,0 +1,182 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"image/jpeg"
+	"io"
+
+	"github.com/disintegration/imaging"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// putThumbnail stream-encodes thumbImg as JPEG, at the configured
+// thumbnail quality, into storage at the attachment's thumbnail
+// path, and sets the attachment's thumbnail size and dimensions.
+func (m *Manager) putThumbnail(
+	ctx context.Context,
+	attachment *gtsmodel.MediaAttachment,
+	thumbImg *gtsImage,
+) error {
+	// Create a thumbnail JPEG encoder stream.
+	enc := thumbImg.ToJPEG(&jpeg.Options{
+		Quality: config.GetMediaThumbnailQuality(),
+	})
+
+	// Stream-encode the JPEG thumbnail image into our storage driver.
+	sz, err := m.state.Storage.PutStream(ctx, attachment.Thumbnail.Path, enc)
+	if err != nil {
+		return gtserror.Newf("error stream-encoding thumbnail to storage: %w", err)
+	}
+
+	// Set final written thumb size.
+	attachment.Thumbnail.FileSize = int(sz)
+
+	// Set thumbnail dimensions in attachment info.
+	attachment.FileMeta.Small = gtsmodel.Small{
+		Width:  thumbImg.Width(),
+		Height: thumbImg.Height(),
+		Size:   thumbImg.Size(),
+		Aspect: thumbImg.AspectRatio(),
+	}
+
+	return nil
+}
+
+// RegenerateThumbnails regenerates the thumbnails of all cached
+// image and video attachments (local and remote) from their
+// original files, with the current media-thumbnail-* settings.
+// Attachments that fail are logged and skipped.
+//
+// Returns the number of thumbnails regenerated.
+func (m *Manager) RegenerateThumbnails(ctx context.Context) (int, error) {
+	var (
+		page          = &paging.Page{Limit: 50}
+		totalRegen    int
+		totalAttempts int
+	)
+
+	for {
+		// Fetch next page of attachments from the database.
+		attachments, err := m.state.DB.GetAttachments(ctx, page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return totalRegen, gtserror.Newf("error getting attachments: %w", err)
+		}
+
+		if len(attachments) == 0 {
+			// Reached the end.
+			break
+		}
+
+		// Use last ID as the next max ID.
+		page.Max = paging.MaxID(attachments[len(attachments)-1].ID)
+
+		for _, attachment := range attachments {
+			if !*attachment.Cached ||
+				attachment.Thumbnail.Path == "" ||
+				(attachment.Type != gtsmodel.FileTypeImage &&
+					attachment.Type != gtsmodel.FileTypeVideo) {
+				// Nothing to
+				// regenerate from.
+				continue
+			}
+
+			totalAttempts++
+			if err := m.regenerateThumbnail(ctx, attachment); err != nil {
+				log.Errorf(ctx, "error regenerating thumbnail of attachment %s: %v", attachment.ID, err)
+				continue
+			}
+			totalRegen++
+		}
+	}
+
+	log.Infof(ctx, "regenerated %d of %d thumbnail(s)", totalRegen, totalAttempts)
+	return totalRegen, nil
+}
+
+// regenerateThumbnail regenerates the thumbnail of the
+// given cached attachment from its original file.
+func (m *Manager) regenerateThumbnail(ctx context.Context, attachment *gtsmodel.MediaAttachment) error {
+	// Get a stream to the original file.
+	rc, err := m.state.Storage.GetStream(ctx, attachment.File.Path)
+	if err != nil {
+		return gtserror.Newf("error loading file from storage: %w", err)
+	}
+	defer rc.Close()
+
+	fullImg, err := decodeThumbnailSource(attachment.File.ContentType, rc)
+	if err != nil {
+		return err
+	}
+
+	// Thumbnail is overwritten in
+	// place, at its existing path.
+	thumbImg := fullImg.Thumbnail(config.GetMediaThumbnailMaxSize())
+	if err := m.state.Storage.Delete(ctx, attachment.Thumbnail.Path); err != nil {
+		return gtserror.Newf("error removing old thumbnail from storage: %w", err)
+	}
+
+	if err := m.putThumbnail(ctx, attachment, thumbImg); err != nil {
+		return err
+	}
+
+	if err := m.state.DB.UpdateAttachment(ctx, attachment,
+		"thumbnail_file_size",
+		"small_width",
+		"small_height",
+		"small_size",
+		"small_aspect",
+	); err != nil {
+		return gtserror.Newf("error updating attachment: %w", err)
+	}
+
+	return nil
+}
+
+// decodeThumbnailSource decodes the image (or first video frame)
+// of an original file of given content type to make a thumbnail
+// of, in the same way as when the attachment was processed.
+func decodeThumbnailSource(contentType string, r io.Reader) (*gtsImage, error) {
+	switch contentType {
+	case mimeImageJpeg, mimeImageGif, mimeImageWebp:
+		return decodeImage(r, imaging.AutoOrientation(true))
+
+	case mimeImagePng:
+		return decodeImage(
+			&pngAncillaryChunkStripper{Reader: r},
+			imaging.AutoOrientation(true),
+		)
+
+	case mimeVideoMp4:
+		video, err := decodeVideoFrame(r)
+		if err != nil {
+			return nil, gtserror.Newf("error decoding video: %w", err)
+		}
+		return video.frame, nil
+
+	default:
+		return nil, gtserror.Newf("unsupported content type %s", contentType)
+	}
+}
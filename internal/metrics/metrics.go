@@ -22,10 +22,11 @@ package metrics
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
-	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/technologize/otel-go-contrib/otelginmetrics"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/extra/bunotel"
@@ -41,7 +42,40 @@ const (
 	serviceName = "GoToSocial"
 )
 
-func Initialize(db db.DB) error {
+// webfingerThrottled counts webfinger
+// requests rejected by rate limiting.
+var webfingerThrottled atomic.Uint64
+
+// WebfingerThrottled records that a webfinger
+// request was rejected by rate limiting.
+func WebfingerThrottled() {
+	webfingerThrottled.Add(1)
+}
+
+// streamEventsDropped counts streaming events
+// dropped because the client's queue was full.
+var streamEventsDropped atomic.Uint64
+
+// StreamEventDropped records that a streaming
+// event was dropped because the client's queue
+// was full and its slow consumer policy is to
+// drop the oldest events.
+func StreamEventDropped() {
+	streamEventsDropped.Add(1)
+}
+
+// streamSlowConsumers counts streaming connections
+// closed because the client wasn't reading fast enough.
+var streamSlowConsumers atomic.Uint64
+
+// StreamSlowConsumerDisconnected records that
+// a streaming connection was closed because
+// the client wasn't reading fast enough.
+func StreamSlowConsumerDisconnected() {
+	streamSlowConsumers.Add(1)
+}
+
+func Initialize(state *state.State) error {
 	if !config.GetMetricsEnabled() {
 		return nil
 	}
@@ -75,6 +109,7 @@ func Initialize(db db.DB) error {
 	meter := meterProvider.Meter(serviceName)
 
 	thisInstance := config.GetHost()
+	db := state.DB
 
 	_, err = meter.Int64ObservableGauge(
 		"gotosocial.instance.total_users",
@@ -124,6 +159,68 @@ func Initialize(db db.DB) error {
 		return err
 	}
 
+	webfingerResponses := &state.Caches.WebfingerResponse
+
+	_, err = meter.Int64ObservableCounter(
+		"gotosocial.cache.webfinger_response.hits",
+		metric.WithDescription("Number of webfinger requests served from cache"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(webfingerResponses.Hits()))
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Int64ObservableCounter(
+		"gotosocial.cache.webfinger_response.misses",
+		metric.WithDescription("Number of webfinger requests not served from cache"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(webfingerResponses.Misses()))
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Int64ObservableCounter(
+		"gotosocial.webfinger.throttled_requests",
+		metric.WithDescription("Number of webfinger requests rejected by rate limiting"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(webfingerThrottled.Load()))
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Int64ObservableCounter(
+		"gotosocial.streaming.dropped_events",
+		metric.WithDescription("Number of streaming events dropped because the client was not reading fast enough"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(streamEventsDropped.Load()))
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Int64ObservableCounter(
+		"gotosocial.streaming.slow_consumer_disconnects",
+		metric.WithDescription("Number of streaming connections closed because the client was not reading fast enough"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(streamSlowConsumers.Load()))
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
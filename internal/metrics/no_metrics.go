@@ -24,17 +24,23 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
-	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/uptrace/bun"
 )
 
-func Initialize(db db.DB) error {
+func Initialize(state *state.State) error {
 	if config.GetMetricsEnabled() {
 		return errors.New("metrics was disabled at build time")
 	}
 	return nil
 }
 
+func WebfingerThrottled() {}
+
+func StreamEventDropped() {}
+
+func StreamSlowConsumerDisconnected() {}
+
 func InstrumentGin() gin.HandlerFunc {
 	return func(c *gin.Context) {}
 }
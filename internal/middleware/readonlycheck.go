@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/oauth2/v4"
+)
+
+// ReadOnlyCheck returns a new gin middleware for rejecting write requests
+// made with read-only oauth tokens, such as those of demo clients. It
+// should be used after TokenCheck.
+//
+// Unlike ScopeCheck, this doesn't depend on the token's scope: any request
+// with a method other than GET, HEAD or OPTIONS made with a read-only token
+// is aborted with 403, even if the token was somehow granted a write scope.
+//
+// The given function is used to look up the stored token by its access
+// token, to check whether it's read-only.
+func ReadOnlyCheck(
+	getTokenByAccess func(ctx context.Context, access string) (*gtsmodel.Token, error),
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			// Reads are fine.
+			return
+		}
+
+		i, ok := c.Get(oauth.SessionAuthorizedToken)
+		if !ok {
+			// No token, nothing to check.
+			return
+		}
+
+		ti, ok := i.(oauth2.TokenInfo)
+		if !ok {
+			err := gtserror.Newf("could not parse token from session context")
+			respondInternalServerError(c, err)
+			return
+		}
+
+		token, err := getTokenByAccess(c.Request.Context(), ti.GetAccess())
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting token: %w", err)
+			respondInternalServerError(c, err)
+			return
+		}
+
+		if token == nil || !util.PtrValueOr(token.ReadOnly, false) {
+			// Token may write
+			// (scope permitting).
+			return
+		}
+
+		const text = "This token is read-only"
+		errWithCode := gtserror.NewErrorForbidden(errors.New(text), text)
+
+		// Set error on gin context so it'll
+		// be picked up by logging middleware.
+		c.Error(errWithCode) //nolint:errcheck
+
+		c.AbortWithStatusJSON(
+			errWithCode.Code(),
+			gin.H{"error": errWithCode.Safe()},
+		)
+	}
+}
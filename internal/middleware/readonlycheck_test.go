@@ -0,0 +1,93 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/oauth2/v4/models"
+)
+
+func TestReadOnlyCheck(t *testing.T) {
+	// Suppress warnings about debug mode.
+	gin.SetMode(gin.ReleaseMode)
+
+	tokens := map[string]*gtsmodel.Token{
+		"demo":   {Access: "demo", Scope: "read write", ReadOnly: util.Ptr(true)},
+		"normal": {Access: "normal", Scope: "read write", ReadOnly: util.Ptr(false)},
+		"legacy": {Access: "legacy", Scope: "read write"},
+	}
+
+	getTokenByAccess := func(_ context.Context, access string) (*gtsmodel.Token, error) {
+		token, ok := tokens[access]
+		if !ok {
+			return nil, db.ErrNoEntries
+		}
+		return token, nil
+	}
+
+	for _, test := range []struct {
+		method string
+		access string
+		expect int
+	}{
+		{http.MethodGet, "demo", http.StatusOK},
+		{http.MethodHead, "demo", http.StatusOK},
+		{http.MethodPost, "demo", http.StatusForbidden},
+		{http.MethodPut, "demo", http.StatusForbidden},
+		{http.MethodPatch, "demo", http.StatusForbidden},
+		{http.MethodDelete, "demo", http.StatusForbidden},
+		{http.MethodPost, "normal", http.StatusOK},
+		{http.MethodDelete, "normal", http.StatusOK},
+		{http.MethodPost, "legacy", http.StatusOK},
+		{http.MethodPost, "unknown", http.StatusOK},
+		{http.MethodPost, "", http.StatusOK},
+	} {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			// Stand-in for TokenCheck.
+			if test.access != "" {
+				c.Set(oauth.SessionAuthorizedToken, &models.Token{
+					Access: test.access,
+					Scope:  "read write",
+				})
+			}
+		})
+		r.Use(middleware.ReadOnlyCheck(getTokenByAccess))
+		r.Handle(test.method, "/api/v1/statuses", func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(test.method, "/api/v1/statuses", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != test.expect {
+			t.Errorf("%s with token '%s': expected status code %d, got %d", test.method, test.access, test.expect, rec.Code)
+		}
+	}
+}
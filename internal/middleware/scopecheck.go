@@ -0,0 +1,212 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/oauth2/v4"
+)
+
+// ScopeCheck returns a new gin middleware for enforcing the scopes of
+// oauth tokens in client API requests. It should be used after TokenCheck.
+//
+// If a token has been set on the gin context, the scope required for the
+// requested route is determined from the request method and route path,
+// (eg., GET /api/v1/statuses/:id requires "read:statuses"), and if the
+// token's scope doesn't permit it, the request is aborted with 403.
+//
+// Requests without a token are passed through, as it's up to the handlers
+// to decide whether a token is required for them.
+func ScopeCheck() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		i, ok := c.Get(oauth.SessionAuthorizedToken)
+		if !ok {
+			// No token, nothing to check.
+			return
+		}
+
+		ti, ok := i.(oauth2.TokenInfo)
+		if !ok {
+			err := gtserror.Newf("could not parse token from session context")
+			respondInternalServerError(c, err)
+			return
+		}
+
+		path := c.FullPath()
+		required := RequiredScope(c.Request.Method, path)
+		if required == "" {
+			// No scope required.
+			return
+		}
+
+		granted := ti.GetScope()
+		if oauth.ScopesAllow(granted, required) {
+			// Permitted.
+			return
+		}
+
+		if strings.HasSuffix(path, "/verify_credentials") &&
+			oauth.ScopesAllow(granted, oauth.ScopeProfile) {
+			// The profile scope permits
+			// only verifying credentials.
+			return
+		}
+
+		const text = "This action is outside the authorized scopes"
+		err := fmt.Errorf("token scope %q does not permit %q", granted, required)
+		errWithCode := gtserror.NewErrorForbidden(err, text)
+
+		// Set error on gin context so it'll
+		// be picked up by logging middleware.
+		c.Error(errWithCode) //nolint:errcheck
+
+		c.AbortWithStatusJSON(
+			errWithCode.Code(),
+			gin.H{"error": errWithCode.Safe()},
+		)
+	}
+}
+
+// RequiredScope returns the oauth scope required to make a client API
+// request with the given method to the given route path, or an empty
+// string if no scope is required (eg., for public endpoints).
+func RequiredScope(method string, path string) string {
+	// Trim the API version prefix from the path.
+	rest, ok := strings.CutPrefix(path, "/api/v1/")
+	if !ok {
+		rest, ok = strings.CutPrefix(path, "/api/v2/")
+		if !ok {
+			return ""
+		}
+	}
+
+	// Separate the endpoint group
+	// from the rest of the path.
+	group, rest, _ := strings.Cut(rest, "/")
+
+	// Reading or writing.
+	action := oauth.ScopeWrite
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		action = oauth.ScopeRead
+	}
+
+	switch group {
+
+	// Public, or handled elsewhere.
+	case "apps", "custom_emojis":
+		return ""
+
+	// Instance info is public,
+	// but updating it is not.
+	case "instance":
+		if action == oauth.ScopeRead {
+			return ""
+		}
+		return oauth.ScopeAdminWrite
+
+	case "admin":
+		action = "admin:" + action
+		category, _, _ := strings.Cut(rest, "/")
+		switch category {
+		case "accounts", "reports", "domain_allows", "domain_blocks":
+			return action + ":" + category
+		default:
+			return action
+		}
+
+	case "accounts":
+		switch {
+		case strings.HasSuffix(rest, "/follow"),
+			strings.HasSuffix(rest, "/unfollow"):
+			return oauth.ScopeWrite + ":follows"
+		case strings.HasSuffix(rest, "/block"),
+			strings.HasSuffix(rest, "/unblock"):
+			return oauth.ScopeWrite + ":blocks"
+		case strings.HasSuffix(rest, "/mute"),
+			strings.HasSuffix(rest, "/unmute"):
+			return oauth.ScopeWrite + ":mutes"
+		case rest == "relationships":
+			return oauth.ScopeRead + ":follows"
+		default:
+			return action + ":accounts"
+		}
+
+	case "statuses":
+		switch {
+		case strings.HasSuffix(rest, "/favourite"),
+			strings.HasSuffix(rest, "/unfavourite"):
+			return oauth.ScopeWrite + ":favourites"
+		case strings.HasSuffix(rest, "/bookmark"),
+			strings.HasSuffix(rest, "/unbookmark"):
+			return oauth.ScopeWrite + ":bookmarks"
+		default:
+			return action + ":statuses"
+		}
+
+	case "conversations":
+		if action == oauth.ScopeRead {
+			return oauth.ScopeRead + ":statuses"
+		}
+		return oauth.ScopeWrite + ":conversations"
+
+	case "polls", "timelines", "markers", "streaming":
+		if group == "streaming" {
+			// Websocket upgrades
+			// are always reads.
+			action = oauth.ScopeRead
+		}
+		return action + ":statuses"
+
+	// Media can only be
+	// handled when writing.
+	case "media":
+		return oauth.ScopeWrite + ":media"
+
+	case "search":
+		return oauth.ScopeRead + ":search"
+
+	case "reports":
+		if action == oauth.ScopeRead {
+			return oauth.ScopeRead
+		}
+		return oauth.ScopeWrite + ":reports"
+
+	case "blocks", "bookmarks", "favourites", "filters",
+		"lists", "mutes", "notifications":
+		return action + ":" + group
+
+	case "follow_requests":
+		return action + ":follows"
+
+	case "featured_tags", "preferences", "user":
+		return action + ":accounts"
+
+	case "push":
+		return oauth.ScopePush
+
+	default:
+		return action
+	}
+}
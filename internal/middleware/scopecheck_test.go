@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+)
+
+func TestRequiredScope(t *testing.T) {
+	type scopeTest struct {
+		method   string
+		path     string
+		expected string
+	}
+
+	for _, test := range []scopeTest{
+		{http.MethodGet, "/api/v1/statuses/:id", "read:statuses"},
+		{http.MethodPost, "/api/v1/statuses", "write:statuses"},
+		{http.MethodPost, "/api/v1/statuses/:id/favourite", "write:favourites"},
+		{http.MethodPost, "/api/v1/statuses/:id/bookmark", "write:bookmarks"},
+		{http.MethodGet, "/api/v1/accounts/verify_credentials", "read:accounts"},
+		{http.MethodPost, "/api/v1/accounts/:id/follow", "write:follows"},
+		{http.MethodPost, "/api/v1/accounts/:id/block", "write:blocks"},
+		{http.MethodGet, "/api/v1/accounts/relationships", "read:follows"},
+		{http.MethodGet, "/api/v1/timelines/home", "read:statuses"},
+		{http.MethodGet, "/api/v1/notifications", "read:notifications"},
+		{http.MethodPost, "/api/v2/media", "write:media"},
+		{http.MethodGet, "/api/v2/search", "read:search"},
+		{http.MethodPost, "/api/v2/filters", "write:filters"},
+		{http.MethodGet, "/api/v1/admin/accounts/:id", "admin:read:accounts"},
+		{http.MethodPost, "/api/v1/admin/domain_blocks", "admin:write:domain_blocks"},
+		{http.MethodPost, "/api/v1/admin/custom_emojis", "admin:write"},
+		{http.MethodGet, "/api/v1/instance", ""},
+		{http.MethodPatch, "/api/v1/instance", "admin:write"},
+		{http.MethodPost, "/api/v1/apps", ""},
+		{http.MethodGet, "/api/v1/streaming", "read:statuses"},
+		{http.MethodGet, "/api/v1/some_new_thing", "read"},
+		{http.MethodDelete, "/api/v1/some_new_thing", "write"},
+		{http.MethodGet, "/users/:username", ""},
+	} {
+		if actual := middleware.RequiredScope(test.method, test.path); actual != test.expected {
+			t.Errorf("%s %s: expected %q, got %q", test.method, test.path, test.expected, actual)
+		}
+	}
+}
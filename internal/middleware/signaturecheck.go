@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 
@@ -46,8 +47,16 @@ const (
 // blocked, the handler will set the key verifier and the signature in the
 // context for use down the line.
 //
+// If the incoming request is an unsigned GET, and the caller matches
+// one of the configured AdvancedSignedFetchExempt domains or CIDRs,
+// then the matching exemption will be set in the context, so that
+// the request can be authenticated further down the line without
+// a signature. Exempted domains are still subject to domain blocks.
+//
 // In case of an error, the request will be aborted with http code 500.
 func SignatureCheck(uriBlocked func(context.Context, *url.URL) (bool, error)) func(*gin.Context) {
+	exempt := newSignedFetchExempt(config.GetAdvancedSignedFetchExempt())
+
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 
@@ -62,6 +71,33 @@ func SignatureCheck(uriBlocked func(context.Context, *url.URL) (bool, error)) fu
 			if err.Error() != noSigError {
 				log.Debugf(ctx, "http signature was present but invalid: %s", err)
 				c.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+
+			// Only GET requests, ie., fetches,
+			// can ever be exempt from signatures.
+			if c.Request.Method != http.MethodGet || exempt.empty() {
+				return
+			}
+
+			entry, err := exempt.match(ctx, c.ClientIP(), uriBlocked)
+			if err != nil {
+				log.Errorf(ctx, "error checking signed fetch exemption: %v", err)
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+
+			if entry != "" {
+				log.Infof(ctx,
+					"allowing unsigned fetch of %s from %s, exempted by %s",
+					c.Request.URL.Path, c.ClientIP(), entry,
+				)
+
+				// Mark the request exempt and replace
+				// request with a shallow copy with the
+				// new context, same as below.
+				ctx = gtscontext.SetSignedFetchExempt(ctx, entry)
+				c.Request = c.Request.WithContext(ctx)
 			}
 
 			return
@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+)
+
+func TestSignatureCheckSignedFetchExempt(t *testing.T) {
+	// Suppress warnings about debug mode.
+	gin.SetMode(gin.ReleaseMode)
+
+	config.SetAdvancedSignedFetchExempt([]string{
+		"192.0.2.0/24",
+		"0.0.0.0/0", // should be ignored
+		"*",         // should be ignored
+	})
+	defer config.SetAdvancedSignedFetchExempt(nil)
+
+	noBlocks := func(context.Context, *url.URL) (bool, error) {
+		return false, nil
+	}
+
+	for _, test := range []struct {
+		method     string
+		remoteAddr string
+		expect     string
+	}{
+		{http.MethodGet, "192.0.2.69:1234", "192.0.2.0/24"},
+		{http.MethodGet, "198.51.100.1:1234", ""},
+		{http.MethodPost, "192.0.2.69:1234", ""},
+	} {
+		var exempt string
+
+		r := gin.New()
+		r.Use(middleware.SignatureCheck(noBlocks))
+		r.Handle(test.method, "/users/someone", func(c *gin.Context) {
+			exempt = gtscontext.SignedFetchExempt(c.Request.Context())
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(test.method, "/users/someone", nil)
+		req.RemoteAddr = test.remoteAddr
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s from %s: unexpected status code %d", test.method, test.remoteAddr, rec.Code)
+		}
+
+		if exempt != test.expect {
+			t.Errorf("%s from %s: expected exemption '%s', got '%s'", test.method, test.remoteAddr, test.expect, exempt)
+		}
+	}
+}
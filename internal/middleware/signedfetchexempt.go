@@ -0,0 +1,188 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// signedFetchExemptTTL is how long the resolved
+// addresses of exempt domains are cached for.
+const signedFetchExemptTTL = 5 * time.Minute
+
+// signedFetchExempt wraps the configured list
+// of domains and CIDRs which are permitted to
+// fetch ActivityPub objects without a signature.
+type signedFetchExempt struct {
+	prefixes []netip.Prefix
+	domains  []string
+
+	// lookup resolves the addresses of
+	// an exempt domain, by default via
+	// net.DefaultResolver.LookupNetIP.
+	lookup func(context.Context, string) ([]netip.Addr, error)
+
+	// resolved caches the looked up
+	// addresses of each exempt domain,
+	// so that unsigned requests don't
+	// each trigger their own lookups.
+	resolved map[string]resolvedDomain
+	mu       sync.Mutex
+}
+
+// resolvedDomain holds the looked up addresses
+// of an exempt domain, along with their expiry.
+type resolvedDomain struct {
+	addrs   []netip.Addr
+	expires time.Time
+}
+
+// newSignedFetchExempt parses the given exemption entries into
+// a signedFetchExempt. Wildcard entries are always skipped, as
+// are any entries that can't be parsed; config.Validate() will
+// already have warned about or rejected these at startup.
+func newSignedFetchExempt(entries []string) *signedFetchExempt {
+	exempt := &signedFetchExempt{
+		lookup: func(ctx context.Context, domain string) ([]netip.Addr, error) {
+			return net.DefaultResolver.LookupNetIP(ctx, "ip", domain)
+		},
+		resolved: make(map[string]resolvedDomain),
+	}
+
+	for _, entry := range entries {
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			if prefix.Bits() == 0 {
+				// Never exempt everything.
+				continue
+			}
+
+			exempt.prefixes = append(exempt.prefixes, prefix.Masked())
+			continue
+		}
+
+		if strings.ContainsAny(entry, "*/:") {
+			// Wildcard or junk.
+			continue
+		}
+
+		exempt.domains = append(exempt.domains, strings.ToLower(entry))
+	}
+
+	return exempt
+}
+
+// empty returns whether no exemptions are configured.
+func (e *signedFetchExempt) empty() bool {
+	return len(e.prefixes) == 0 && len(e.domains) == 0
+}
+
+// addrs returns the addresses of the given exempt domain,
+// only looking these up again once the cached ones expire.
+// Failed lookups are cached too, so a domain that doesn't
+// resolve isn't retried on every unsigned request.
+func (e *signedFetchExempt) addrs(ctx context.Context, domain string) []netip.Addr {
+	now := time.Now()
+
+	e.mu.Lock()
+	cached, ok := e.resolved[domain]
+	e.mu.Unlock()
+
+	if ok && now.Before(cached.expires) {
+		return cached.addrs
+	}
+
+	addrs, err := e.lookup(ctx, domain)
+	if err != nil {
+		log.Warnf(ctx, "could not resolve signed fetch exempt domain %s: %v", domain, err)
+		addrs = nil
+	}
+
+	e.mu.Lock()
+	e.resolved[domain] = resolvedDomain{
+		addrs:   addrs,
+		expires: now.Add(signedFetchExemptTTL),
+	}
+	e.mu.Unlock()
+
+	return addrs
+}
+
+// match checks whether the given client IP is covered by
+// one of the exempt CIDRs, or by the resolved addresses of
+// one of the exempt domains, returning the matching entry.
+//
+// Exempt domains which are blocked according to uriBlocked
+// are skipped, so that domain blocks are always respected.
+func (e *signedFetchExempt) match(
+	ctx context.Context,
+	clientIPStr string,
+	uriBlocked func(context.Context, *url.URL) (bool, error),
+) (string, error) {
+	clientIP, err := netip.ParseAddr(clientIPStr)
+	if err != nil {
+		// Can't match
+		// without an IP.
+		return "", nil
+	}
+	clientIP = clientIP.Unmap()
+
+	for _, prefix := range e.prefixes {
+		if prefix.Contains(clientIP) {
+			return prefix.String(), nil
+		}
+	}
+
+	for _, domain := range e.domains {
+		var matched bool
+		for _, addr := range e.addrs(ctx, domain) {
+			if addr.Unmap() == clientIP {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			continue
+		}
+
+		// Client resolves to this exempt domain,
+		// make sure the domain isn't blocked.
+		blocked, err := uriBlocked(ctx, &url.URL{Host: domain})
+		if err != nil {
+			return "", gtserror.Newf("error checking block for domain %s: %w", domain, err)
+		}
+
+		if blocked {
+			log.Infof(ctx, "signed fetch exempt domain %s is blocked", domain)
+			continue
+		}
+
+		return domain, nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSignedFetchExemptDomain(t *testing.T) {
+	ctx := context.Background()
+
+	exempt := newSignedFetchExempt([]string{
+		"Relay.example.org",
+		"unresolvable.example.org",
+		"blocked.example.org",
+	})
+
+	// Resolve without live DNS,
+	// counting lookups per domain.
+	lookups := make(map[string]int)
+	exempt.lookup = func(_ context.Context, domain string) ([]netip.Addr, error) {
+		lookups[domain]++
+		switch domain {
+		case "relay.example.org":
+			return []netip.Addr{netip.MustParseAddr("203.0.113.1")}, nil
+		case "blocked.example.org":
+			return []netip.Addr{netip.MustParseAddr("203.0.113.2")}, nil
+		default:
+			return nil, errors.New("no such host")
+		}
+	}
+
+	uriBlocked := func(_ context.Context, uri *url.URL) (bool, error) {
+		return uri.Host == "blocked.example.org", nil
+	}
+
+	for _, test := range []struct {
+		clientIP string
+		expect   string
+	}{
+		{"203.0.113.1", "relay.example.org"},
+		{"::ffff:203.0.113.1", "relay.example.org"},
+		{"203.0.113.2", ""}, // blocked
+		{"198.51.100.1", ""},
+		{"not an ip", ""},
+	} {
+		entry, err := exempt.match(ctx, test.clientIP, uriBlocked)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.clientIP, err)
+		}
+
+		if entry != test.expect {
+			t.Errorf("%s: expected exemption '%s', got '%s'", test.clientIP, test.expect, entry)
+		}
+	}
+
+	// Each domain, including the one that
+	// failed to resolve, should only have
+	// been looked up once across requests.
+	for _, domain := range exempt.domains {
+		if lookups[domain] != 1 {
+			t.Errorf("%s: expected 1 lookup, got %d", domain, lookups[domain])
+		}
+	}
+
+	// Expire the cached addresses,
+	// which should be looked up again.
+	for domain, resolved := range exempt.resolved {
+		resolved.expires = time.Now().Add(-time.Second)
+		exempt.resolved[domain] = resolved
+	}
+
+	if _, err := exempt.match(ctx, "203.0.113.1", uriBlocked); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if lookups["relay.example.org"] != 2 {
+		t.Errorf("expected relay.example.org to be looked up again, got %d lookups", lookups["relay.example.org"])
+	}
+}
@@ -0,0 +1,191 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+)
+
+// tokenBucketSweepInterval is how often idle
+// (ie., full) buckets are dropped from memory.
+const tokenBucketSweepInterval = 5 * time.Minute
+
+// TokenBucketRateLimit returns a gin middleware that will rate limit
+// callers (by IP address) using a token bucket. Each caller may make up
+// to burst requests at once, after which requests are permitted at the
+// sustained rate of perMinute requests per minute.
+//
+// Unlike RateLimit, which uses a fixed window, this allows legitimate
+// bursts of requests (eg., from large instances) without permitting a
+// caller to sustain a high request rate.
+//
+// If a caller exceeds the limit, the request is aborted, an HTTP 429
+// TooManyRequests status is returned, and the Retry-After header is
+// set to the number of seconds until the caller may try again. If
+// throttled is not nil, it's called for each request aborted this way.
+//
+// If perMinute or burst is <= 0, then a noop handler will
+// be returned, which performs no rate limiting.
+func TokenBucketRateLimit(
+	perMinute int,
+	burst int,
+	exceptions []string,
+	throttled func(),
+) gin.HandlerFunc {
+	if perMinute <= 0 || burst <= 0 {
+		// Rate limiting is disabled.
+		// Return noop middleware.
+		return func(ctx *gin.Context) {}
+	}
+
+	buckets := &tokenBuckets{
+		rate:    float64(perMinute) / 60,
+		burst:   float64(burst),
+		buckets: make(map[netip.Addr]*tokenBucket),
+	}
+
+	// Convert exceptions IP ranges into prefixes.
+	exceptPrefs := make([]netip.Prefix, len(exceptions))
+	for i, str := range exceptions {
+		exceptPrefs[i] = netip.MustParsePrefix(str)
+	}
+
+	// Mask IPv6 addresses to a /64,
+	// for the same reasons as RateLimit.
+	ipv6Mask := net.CIDRMask(64, 128)
+
+	return func(c *gin.Context) {
+		clientIP := netip.MustParseAddr(c.ClientIP())
+
+		// Check if this IP is exempt from rate
+		// limits and skip further checks if so.
+		for _, prefix := range exceptPrefs {
+			if prefix.Contains(clientIP) {
+				c.Next()
+				return
+			}
+		}
+
+		if clientIP.Is6() {
+			asIP := net.IP(clientIP.AsSlice()).Mask(ipv6Mask)
+			clientIP, _ = netip.AddrFromSlice(asIP)
+		}
+
+		wait := buckets.take(clientIP, time.Now())
+		if wait > 0 {
+			if throttled != nil {
+				throttled()
+			}
+
+			// Round up to whole seconds, so callers
+			// that wait as instructed are permitted.
+			retryAfter := int(math.Ceil(wait.Seconds()))
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+
+			// Return JSON error message for
+			// consistency with other endpoints.
+			apiutil.Data(c,
+				http.StatusTooManyRequests,
+				apiutil.AppJSON,
+				apiutil.ErrorRateLimited,
+			)
+			c.Abort()
+			return
+		}
+
+		// Allow the request
+		// to continue.
+		c.Next()
+	}
+}
+
+// tokenBucket holds the tokens
+// available to a single caller.
+type tokenBucket struct {
+	tokens float64   // tokens at last refill
+	last   time.Time // time of last refill
+}
+
+// tokenBuckets is a collection of
+// token buckets, keyed by caller IP.
+type tokenBuckets struct {
+	mu        sync.Mutex
+	rate      float64 // tokens refilled per second
+	burst     float64 // max tokens per bucket
+	buckets   map[netip.Addr]*tokenBucket
+	lastSweep time.Time
+}
+
+// take attempts to take a token from the bucket of the given
+// caller at the given time. If successful it returns zero,
+// else the duration until a token will next be available.
+func (t *tokenBuckets) take(key netip.Addr, now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if now.Sub(t.lastSweep) >= tokenBucketSweepInterval {
+		t.sweep(now)
+	}
+
+	bucket, ok := t.buckets[key]
+	if !ok {
+		// New callers start
+		// with a full bucket.
+		bucket = &tokenBucket{tokens: t.burst}
+		t.buckets[key] = bucket
+	} else {
+		bucket.tokens = t.refilled(bucket, now)
+	}
+	bucket.last = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0
+	}
+
+	missing := (1 - bucket.tokens) / t.rate
+	return time.Duration(missing * float64(time.Second))
+}
+
+// refilled returns the tokens in
+// the given bucket at the given time.
+func (t *tokenBuckets) refilled(bucket *tokenBucket, now time.Time) float64 {
+	elapsed := now.Sub(bucket.last).Seconds()
+	return min(t.burst, bucket.tokens+elapsed*t.rate)
+}
+
+// sweep drops buckets which have refilled
+// entirely, as these are equivalent to new.
+func (t *tokenBuckets) sweep(now time.Time) {
+	for key, bucket := range t.buckets {
+		if t.refilled(bucket, now) >= t.burst {
+			delete(t.buckets, key)
+		}
+	}
+	t.lastSweep = now
+}
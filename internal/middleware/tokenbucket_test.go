@@ -0,0 +1,119 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+)
+
+type TokenBucketRateLimitTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TokenBucketRateLimitTestSuite) request(handler gin.HandlerFunc, clientIP string) *httptest.ResponseRecorder {
+	const trustedPlatform = "X-Test-IP"
+
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, e   = gin.CreateTestContext(recorder)
+	)
+
+	// Instruct engine to derive
+	// clientIP from test header.
+	e.TrustedPlatform = trustedPlatform
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/.well-known/webfinger", nil)
+	ctx.Request.Header.Add(trustedPlatform, clientIP)
+
+	handler(ctx)
+	return recorder
+}
+
+func (suite *TokenBucketRateLimitTestSuite) TestTokenBucketRateLimit() {
+	// Suppress warnings about debug mode.
+	gin.SetMode(gin.ReleaseMode)
+
+	const (
+		perMinute = 6 // 1 every 10 seconds
+		burst     = 5
+	)
+
+	var throttled int
+	handler := middleware.TokenBucketRateLimit(
+		perMinute,
+		burst,
+		[]string{"198.51.100.0/24"},
+		func() { throttled++ },
+	)
+
+	// The whole burst should be allowed through.
+	for i := 0; i < burst; i++ {
+		recorder := suite.request(handler, "192.0.2.1")
+		suite.Equal(http.StatusOK, recorder.Code)
+		suite.Empty(recorder.Header().Get("Retry-After"))
+	}
+
+	// Next request should be denied, and told
+	// to retry once a token has been refilled.
+	recorder := suite.request(handler, "192.0.2.1")
+	suite.Equal(http.StatusTooManyRequests, recorder.Code)
+	retryAfter, err := strconv.Atoi(recorder.Header().Get("Retry-After"))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.InDelta(10, retryAfter, 1)
+	suite.Equal(1, throttled)
+
+	// Other IPs have their own bucket.
+	recorder = suite.request(handler, "192.0.2.2")
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	// IPv6 addresses in the same /64 share a bucket.
+	for i := 0; i < burst; i++ {
+		recorder = suite.request(handler, "2001:db8::1")
+		suite.Equal(http.StatusOK, recorder.Code)
+	}
+	recorder = suite.request(handler, "2001:db8::2")
+	suite.Equal(http.StatusTooManyRequests, recorder.Code)
+	suite.Equal(2, throttled)
+
+	// Excepted IPs are never limited.
+	for i := 0; i < burst*2; i++ {
+		recorder = suite.request(handler, "198.51.100.1")
+		suite.Equal(http.StatusOK, recorder.Code)
+	}
+	suite.Equal(2, throttled)
+}
+
+func (suite *TokenBucketRateLimitTestSuite) TestTokenBucketRateLimitDisabled() {
+	handler := middleware.TokenBucketRateLimit(0, 5, nil, nil)
+	for i := 0; i < 10; i++ {
+		recorder := suite.request(handler, "192.0.2.1")
+		suite.Equal(http.StatusOK, recorder.Code)
+	}
+}
+
+func TestTokenBucketRateLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(TokenBucketRateLimitTestSuite))
+}
@@ -18,7 +18,9 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -65,6 +67,10 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 		}
 		c.Set(oauth.SessionAuthorizedToken, ti)
 
+		// Note that the token is in use,
+		// so the user can see it was used.
+		touchToken(ctx, dbConn, ti.GetAccess())
+
 		// check for user-level token
 		if userID := ti.GetUserID(); userID != "" {
 			log.Tracef(ctx, "authenticated user %s with bearer token, scope is %s", userID, ti.GetScope())
@@ -138,3 +144,30 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 		}
 	}
 }
+
+// tokenLastUsedInterval is how often at most the last used
+// time of a token is updated, to spare the db a write on
+// every single authenticated request.
+const tokenLastUsedInterval = 5 * time.Minute
+
+// touchToken updates the last used time of the token
+// with the given access token, if it's not been updated
+// within the last tokenLastUsedInterval.
+func touchToken(ctx context.Context, dbConn db.DB, access string) {
+	token, err := dbConn.GetTokenByAccess(ctx, access)
+	if err != nil {
+		log.Errorf(ctx, "database error looking for token: %s", err)
+		return
+	}
+
+	now := time.Now()
+	if now.Sub(token.LastUsedAt) < tokenLastUsedInterval {
+		// Updated recently enough.
+		return
+	}
+
+	token.LastUsedAt = now
+	if err := dbConn.UpdateToken(ctx, token, "last_used_at"); err != nil {
+		log.Errorf(ctx, "database error updating token %s: %s", token.ID, err)
+	}
+}
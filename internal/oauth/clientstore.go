@@ -19,6 +19,7 @@ package oauth
 
 import (
 	"context"
+	"strings"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -46,17 +47,20 @@ func (cs *clientStore) GetByID(ctx context.Context, clientID string) (oauth2.Cli
 	return models.New(
 		client.ID,
 		client.Secret,
-		client.Domain,
+		// The oauth2 library only knows about a single
+		// domain per client, so pass all redirect uris
+		// newline-separated; see validateURIHandler.
+		strings.Join(client.RedirectURIs, "\n"),
 		client.UserID,
 	), nil
 }
 
 func (cs *clientStore) Set(ctx context.Context, id string, cli oauth2.ClientInfo) error {
 	return cs.db.PutClient(ctx, &gtsmodel.Client{
-		ID:     cli.GetID(),
-		Secret: cli.GetSecret(),
-		Domain: cli.GetDomain(),
-		UserID: cli.GetUserID(),
+		ID:           cli.GetID(),
+		Secret:       cli.GetSecret(),
+		RedirectURIs: ParseRedirectURIs(cli.GetDomain()),
+		UserID:       cli.GetUserID(),
 	})
 }
 
@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// DeviceNameKey is the form key under which clients may give a
+// human-readable name for the device or app that a token is issued
+// to, either when requesting authorization or when requesting a token.
+// The name is shown to the user when they review their sessions.
+const DeviceNameKey = "device_name"
+
+// maxDeviceNameLength is the maximum length of a
+// device name in characters; longer names are cut.
+const maxDeviceNameLength = 64
+
+type deviceNameCtxKey struct{}
+
+// withDeviceName returns a copy of ctx carrying the given device name,
+// cleaned up for storage. Tokens created by the token store using
+// the returned context get the device name set on them.
+func withDeviceName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, deviceNameCtxKey{}, cleanDeviceName(name))
+}
+
+// deviceName returns the device name set on ctx by
+// withDeviceName, or an empty string if none was set.
+func deviceName(ctx context.Context) string {
+	name, _ := ctx.Value(deviceNameCtxKey{}).(string)
+	return name
+}
+
+// cleanDeviceName strips control characters and surrounding
+// whitespace from the given client-provided device name, and
+// cuts it to maxDeviceNameLength characters.
+func cleanDeviceName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+
+	if runes := []rune(name); len(runes) > maxDeviceNameLength {
+		name = strings.TrimSpace(string(runes[:maxDeviceNameLength]))
+	}
+
+	return name
+}
@@ -21,3 +21,18 @@ import "github.com/superseriousbusiness/oauth2/v4/errors"
 
 // ErrInvalidRequest is an oauth spec compliant 'invalid_request' error.
 var ErrInvalidRequest = errors.New("invalid_request")
+
+// ErrInvalidClient is an oauth spec compliant 'invalid_client' error.
+var ErrInvalidClient = errors.New("invalid_client")
+
+// ErrUnsupportedTokenType is an oauth spec compliant 'unsupported_token_type' error.
+// See https://datatracker.ietf.org/doc/html/rfc7009#section-2.2.1
+var ErrUnsupportedTokenType = errors.New("unsupported_token_type")
+
+// ErrInvalidRedirectURI is an oauth spec compliant 'invalid_redirect_uri' error.
+// See https://datatracker.ietf.org/doc/html/rfc7591#section-3.2.2
+var ErrInvalidRedirectURI = errors.New("invalid_redirect_uri")
+
+// ErrInvalidClientMetadata is an oauth spec compliant 'invalid_client_metadata' error.
+// See https://datatracker.ietf.org/doc/html/rfc7591#section-3.2.2
+var ErrInvalidClientMetadata = errors.New("invalid_client_metadata")
@@ -0,0 +1,284 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/oauth2/v4"
+	"github.com/superseriousbusiness/oauth2/v4/generates"
+)
+
+// JWTAccessClaims are the claims carried by JWT access
+// tokens, as loosely per RFC 9068. Subject is the ID of
+// the user the token was issued for, or the client ID
+// for app-only tokens issued via client credentials.
+type JWTAccessClaims struct {
+	jwt.StandardClaims
+	Scope    string `json:"scope"`
+	ClientID string `json:"client_id"`
+}
+
+// JWTAccessGenerate generates signed JWT access tokens, in
+// place of the default opaque access token generator. The
+// tokens are still stored and looked up in the database as
+// usual; being JWTs just lets other services validate them.
+//
+// Refresh tokens remain opaque.
+type JWTAccessGenerate struct {
+	db       db.DB
+	key      crypto.Signer
+	method   jwt.SigningMethod
+	jwk      apimodel.JWK
+	issuer   string
+	lifetime time.Duration
+	opaque   *generates.AccessGenerate
+}
+
+// NewJWTAccessGenerate returns a new JWT access token generator
+// signing tokens with the given key, with the given issuer (iss)
+// claim, and expiring after the given lifetime unless the token
+// or its client has a lifetime of its own.
+func NewJWTAccessGenerate(
+	database db.DB,
+	key crypto.Signer,
+	issuer string,
+	lifetime time.Duration,
+) (*JWTAccessGenerate, error) {
+	method, jwk, err := keyToJWK(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWTAccessGenerate{
+		db:       database,
+		key:      key,
+		method:   method,
+		jwk:      jwk,
+		issuer:   issuer,
+		lifetime: lifetime,
+		opaque:   generates.NewAccessGenerate(),
+	}, nil
+}
+
+// Token implements oauth2.AccessGenerate.
+func (g *JWTAccessGenerate) Token(
+	ctx context.Context,
+	data *oauth2.GenerateBasic,
+	isGenRefresh bool,
+) (string, string, error) {
+	var (
+		clientID = data.Client.GetID()
+		subject  = data.UserID
+		scope    string
+		ttl      time.Duration
+	)
+
+	if subject == "" {
+		// App-only token.
+		subject = clientID
+	}
+
+	if ti := data.TokenInfo; ti != nil {
+		scope = ti.GetScope()
+		ttl = ti.GetAccessExpiresIn()
+	}
+
+	if ttl <= 0 {
+		// The client's token lifetime is only applied
+		// when tokens issued by the oauth2 library are
+		// stored, so it needs to be checked here too.
+		client, err := g.db.GetClientByID(ctx, clientID)
+		if err != nil {
+			return "", "", gtserror.Newf("db error getting client %s: %w", clientID, err)
+		}
+		ttl = accessTokenTTL(client, g.lifetime)
+	}
+
+	jti, err := id.NewRandomULID()
+	if err != nil {
+		return "", "", gtserror.Newf("error generating jti: %w", err)
+	}
+
+	token := jwt.NewWithClaims(g.method, &JWTAccessClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        jti,
+			Issuer:    g.issuer,
+			Subject:   subject,
+			IssuedAt:  data.CreateAt.Unix(),
+			ExpiresAt: data.CreateAt.Add(ttl).Unix(),
+		},
+		Scope:    scope,
+		ClientID: clientID,
+	})
+	token.Header["kid"] = g.jwk.Kid
+
+	access, err := token.SignedString(g.key)
+	if err != nil {
+		return "", "", gtserror.Newf("error signing token: %w", err)
+	}
+
+	var refresh string
+	if isGenRefresh {
+		_, refresh, err = g.opaque.Token(ctx, data, true)
+		if err != nil {
+			return "", "", gtserror.Newf("error generating refresh token: %w", err)
+		}
+	}
+
+	return access, refresh, nil
+}
+
+// JWKS returns the JSON Web Key Set containing
+// the public key that tokens are signed with.
+func (g *JWTAccessGenerate) JWKS() *apimodel.JWKS {
+	return &apimodel.JWKS{Keys: []apimodel.JWK{g.jwk}}
+}
+
+// LoadJWTSigningKey loads a PEM-encoded RSA, ECDSA or Ed25519
+// private key, in PKCS #8, PKCS #1 (RSA) or SEC 1 (ECDSA) form,
+// from the file at the given path.
+func LoadJWTSigningKey(path string) (crypto.Signer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading jwt signing key: %w", err)
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("jwt signing key %s is not PEM-encoded", path)
+	}
+
+	var key any
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		err = fmt.Errorf("unsupported PEM block type %s", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing jwt signing key %s: %w", path, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("jwt signing key %s is of unsupported type %T", path, key)
+	}
+
+	// Make sure we can actually sign with this.
+	if _, _, err := keyToJWK(signer.Public()); err != nil {
+		return nil, fmt.Errorf("jwt signing key %s: %w", path, err)
+	}
+
+	return signer, nil
+}
+
+// keyToJWK returns the jwt signing method to use with the
+// private key of the given public key, and its public JWK.
+func keyToJWK(pub crypto.PublicKey) (jwt.SigningMethod, apimodel.JWK, error) {
+	var (
+		method jwt.SigningMethod
+		jwk    = apimodel.JWK{Use: "sig"}
+		b64    = base64.RawURLEncoding.EncodeToString
+	)
+
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		method = jwt.SigningMethodRS256
+		jwk.Kty = "RSA"
+		jwk.N = b64(pub.N.Bytes())
+		jwk.E = b64(big.NewInt(int64(pub.E)).Bytes())
+
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256():
+			method = jwt.SigningMethodES256
+		case elliptic.P384():
+			method = jwt.SigningMethodES384
+		case elliptic.P521():
+			method = jwt.SigningMethodES512
+		default:
+			return nil, jwk, errors.New("unsupported ecdsa curve, must be P-256, P-384 or P-521")
+		}
+
+		// Coordinates are padded to the curve size.
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = b64(pub.X.FillBytes(make([]byte, size)))
+		jwk.Y = b64(pub.Y.FillBytes(make([]byte, size)))
+
+	case ed25519.PublicKey:
+		method = jwt.SigningMethodEdDSA
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = b64(pub)
+
+	default:
+		return nil, jwk, fmt.Errorf("unsupported key type %T, must be RSA, ECDSA or Ed25519", pub)
+	}
+
+	jwk.Alg = method.Alg()
+	jwk.Kid = jwkThumbprint(jwk)
+	return method, jwk, nil
+}
+
+// jwkThumbprint returns the RFC 7638 thumbprint of the given
+// JWK: the SHA-256 of its required members, lexically ordered.
+func jwkThumbprint(jwk apimodel.JWK) string {
+	var members map[string]string
+	switch jwk.Kty {
+	case "RSA":
+		members = map[string]string{"e": jwk.E, "kty": jwk.Kty, "n": jwk.N}
+	case "EC":
+		members = map[string]string{"crv": jwk.Crv, "kty": jwk.Kty, "x": jwk.X, "y": jwk.Y}
+	default:
+		members = map[string]string{"crv": jwk.Crv, "kty": jwk.Kty, "x": jwk.X}
+	}
+
+	// encoding/json sorts map keys, and none
+	// of the values need escaping, so this
+	// is the canonical form of the members.
+	b, _ := json.Marshal(members)
+	sum := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,392 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+	"github.com/superseriousbusiness/oauth2/v4"
+	"github.com/superseriousbusiness/oauth2/v4/models"
+)
+
+// writePEMKey writes the given private key
+// to a PEM file in dir, and returns its path.
+func writePEMKey(t *testing.T, dir string, key any) string {
+	var block *pem.Block
+	if ecKey, ok := key.(*ecdsa.PrivateKey); ok {
+		// Use SEC 1 for ecdsa keys, as
+		// generated by openssl ecparam.
+		b, err := x509.MarshalECPrivateKey(ecKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block = &pem.Block{Type: "EC PRIVATE KEY", Bytes: b}
+	} else {
+		b, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: b}
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%T.pem", key))
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// jwkKeyfunc returns a jwt.Keyfunc which picks the public key
+// to validate a token with from the given JWKS, by token kid.
+func jwkKeyfunc(jwks *apimodel.JWKS) jwt.Keyfunc {
+	b64 := func(s string) *big.Int {
+		b, _ := base64.RawURLEncoding.DecodeString(s)
+		return new(big.Int).SetBytes(b)
+	}
+
+	return func(token *jwt.Token) (any, error) {
+		for _, jwk := range jwks.Keys {
+			if jwk.Kid != token.Header["kid"] {
+				continue
+			}
+
+			if jwk.Alg != token.Method.Alg() {
+				return nil, fmt.Errorf("alg %s does not match jwk alg %s", token.Method.Alg(), jwk.Alg)
+			}
+
+			switch jwk.Kty {
+			case "RSA":
+				return &rsa.PublicKey{N: b64(jwk.N), E: int(b64(jwk.E).Int64())}, nil
+			case "EC":
+				return &ecdsa.PublicKey{Curve: elliptic.P256(), X: b64(jwk.X), Y: b64(jwk.Y)}, nil
+			case "OKP":
+				x, _ := base64.RawURLEncoding.DecodeString(jwk.X)
+				return ed25519.PublicKey(x), nil
+			}
+		}
+		return nil, fmt.Errorf("no jwk with kid %v", token.Header["kid"])
+	}
+}
+
+func testKeys(t *testing.T) map[string]crypto.Signer {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string]crypto.Signer{
+		"RS256": rsaKey,
+		"ES256": ecKey,
+		"EdDSA": edKey,
+	}
+}
+
+func TestLoadJWTSigningKey(t *testing.T) {
+	dir := t.TempDir()
+
+	for alg, key := range testKeys(t) {
+		loaded, err := oauth.LoadJWTSigningKey(writePEMKey(t, dir, key))
+		if err != nil {
+			t.Fatalf("%s: %v", alg, err)
+		}
+
+		gen, err := oauth.NewJWTAccessGenerate(nil, loaded, "http://localhost:8080", time.Hour)
+		if err != nil {
+			t.Fatalf("%s: %v", alg, err)
+		}
+
+		keys := gen.JWKS().Keys
+		if len(keys) != 1 || keys[0].Alg != alg || keys[0].Use != "sig" || keys[0].Kid == "" {
+			t.Errorf("%s: unexpected jwks %+v", alg, keys)
+		}
+	}
+
+	// Curves other than the NIST P-* ones aren't supported.
+	p224Key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := oauth.LoadJWTSigningKey(writePEMKey(t, dir, p224Key)); err == nil {
+		t.Error("expected error loading P-224 key")
+	}
+
+	// Nor are non-PEM files.
+	notPEM := filepath.Join(dir, "not.pem")
+	if err := os.WriteFile(notPEM, []byte("not a key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := oauth.LoadJWTSigningKey(notPEM); err == nil {
+		t.Error("expected error loading non-PEM key")
+	}
+}
+
+func TestJWTAccessGenerateToken(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		createdAt = time.Now().Truncate(time.Second)
+		clientID  = "01F8MGV8AC3NGSJW0FE8W1BV70"
+		client    = models.New(clientID, "secret", "http://localhost:8080", "")
+	)
+
+	for alg, key := range testKeys(t) {
+		// Token expiry is given, so the
+		// database is never consulted.
+		gen, err := oauth.NewJWTAccessGenerate(nil, key, "http://localhost:8080", 24*time.Hour)
+		if err != nil {
+			t.Fatalf("%s: %v", alg, err)
+		}
+
+		for _, userID := range []string{"01F8MGVGPHQ2D3P3X0454H54Z5", ""} {
+			access, refresh, err := gen.Token(ctx, &oauth2.GenerateBasic{
+				Client:   client,
+				UserID:   userID,
+				CreateAt: createdAt,
+				TokenInfo: &models.Token{
+					Scope:           "read write",
+					AccessCreateAt:  createdAt,
+					AccessExpiresIn: time.Hour,
+				},
+			}, true)
+			if err != nil {
+				t.Fatalf("%s: %v", alg, err)
+			}
+
+			if refresh == "" {
+				t.Errorf("%s: expected refresh token", alg)
+			}
+
+			claims := &oauth.JWTAccessClaims{}
+			token, err := jwt.ParseWithClaims(access, claims, jwkKeyfunc(gen.JWKS()))
+			if err != nil {
+				t.Fatalf("%s: token did not validate: %v", alg, err)
+			}
+
+			if token.Method.Alg() != alg {
+				t.Errorf("%s: unexpected alg %s", alg, token.Method.Alg())
+			}
+
+			// App-only tokens have the client as subject.
+			expectSubject := userID
+			if expectSubject == "" {
+				expectSubject = clientID
+			}
+
+			if claims.Subject != expectSubject ||
+				claims.ClientID != clientID ||
+				claims.Scope != "read write" ||
+				claims.Issuer != "http://localhost:8080" ||
+				claims.IssuedAt != createdAt.Unix() ||
+				claims.ExpiresAt != createdAt.Add(time.Hour).Unix() ||
+				claims.Id == "" {
+				t.Errorf("%s: unexpected claims %+v", alg, claims)
+			}
+		}
+	}
+}
+
+// newJWTServer returns a new oauth server
+// issuing access tokens as JWTs.
+func (suite *TokenStoreTestSuite) newJWTServer(ctx context.Context) oauth.Server {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	config.SetAdvancedOAuthAccessTokenFormat(config.OAuthAccessTokenFormatJWT)
+	config.SetAdvancedOAuthJWTSigningKeyPath(writePEMKey(suite.T(), suite.T().TempDir(), key))
+
+	server, err := oauth.New(ctx, suite.db)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return server
+}
+
+// checkJWTExpiry checks that the given JWT access token
+// expires when its stored token does, and is introspected
+// as expiring then, returning its claims.
+func (suite *TokenStoreTestSuite) checkJWTExpiry(
+	ctx context.Context,
+	server oauth.Server,
+	client *gtsmodel.Client,
+	access string,
+) *oauth.JWTAccessClaims {
+	jwks, errWithCode := server.JWKS()
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	claims := &oauth.JWTAccessClaims{}
+	if _, err := jwt.ParseWithClaims(access, claims, jwkKeyfunc(jwks)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbToken, err := suite.db.GetTokenByAccess(ctx, access)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(claims.ExpiresAt, dbToken.AccessExpiresAt.Unix())
+
+	introspection, errWithCode := server.IntrospectToken(ctx, client.ID, client.Secret, access, "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(introspection.Active)
+	suite.Equal(claims.ExpiresAt, introspection.Exp)
+
+	return claims
+}
+
+func (suite *TokenStoreTestSuite) TestJWTUserAccessToken() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := suite.newJWTServer(ctx)
+
+	var (
+		existing = suite.testTokens["local_account_1"]
+		client   = suite.testClients["local_account_1"]
+	)
+
+	ti, err := server.GenerateUserAccessToken(ctx,
+		oauth.DBTokenToToken(existing),
+		client.Secret,
+		existing.UserID,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	access := ti.GetAccess()
+
+	// Token should validate against the served
+	// JWKS, and be stored with the same expiry.
+	claims := suite.checkJWTExpiry(ctx, server, client, access)
+
+	suite.Equal(existing.UserID, claims.Subject)
+	suite.Equal(existing.ClientID, claims.ClientID)
+	suite.Equal(existing.Scope, claims.Scope)
+	suite.Equal("http://localhost:8080", claims.Issuer)
+
+	// Token doesn't otherwise expire, so
+	// the configured JWT lifetime applies.
+	suite.Equal(claims.IssuedAt+int64((24*time.Hour).Seconds()), claims.ExpiresAt)
+
+	// Token should still be usable as usual.
+	loaded, err := server.LoadAccessToken(ctx, access)
+	suite.NoError(err)
+	suite.Equal(existing.UserID, loaded.GetUserID())
+}
+
+func (suite *TokenStoreTestSuite) TestJWTRefreshedAccessToken() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		server = suite.newJWTServer(ctx)
+		client = suite.testClients["local_account_1"]
+		now    = time.Now()
+	)
+
+	// Token issued an hour ago, with a refresh token.
+	token := &gtsmodel.Token{
+		ID:              "01J2N3KBN4HZHT4X2VNRSQG1F3",
+		ClientID:        client.ID,
+		UserID:          suite.testTokens["local_account_1"].UserID,
+		RedirectURI:     "http://localhost:8080",
+		Scope:           "read write",
+		Access:          "NTHKODE1OWETMZQ5MY0ZMDAWLTG5NZATOGRLMDYXYJGYZDM2",
+		AccessCreateAt:  now.Add(-time.Hour),
+		AccessExpiresAt: now.Add(time.Hour),
+		Refresh:         "MZM3NDA0MJETYZC4ZS0ZNDDILTK4OTITYJEZYTI0YTEYNWVK",
+		RefreshCreateAt: now.Add(-time.Hour),
+		FamilyID:        "01J2N3KBN4HZHT4X2VNRSQG1F3",
+	}
+	if err := suite.db.PutToken(ctx, token); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Token is only halfway through its
+	// lifetime, so should still be usable.
+	_, err := server.LoadAccessToken(ctx, token.Access)
+	suite.NoError(err)
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {client.ID},
+		"client_secret": {client.Secret},
+		"refresh_token": {token.Refresh},
+	}
+	r := httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	data, errWithCode := server.HandleTokenRequest(r)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// The refreshed token gets the configured JWT
+	// lifetime, both in its claims and when stored.
+	access, _ := data["access_token"].(string)
+	claims := suite.checkJWTExpiry(ctx, server, client, access)
+	suite.Equal(claims.IssuedAt+int64((24*time.Hour).Seconds()), claims.ExpiresAt)
+	suite.EqualValues((24 * time.Hour).Seconds(), data["expires_in"])
+}
+
+func (suite *TokenStoreTestSuite) TestJWKSOpaqueTokens() {
+	server := testrig.NewTestOauthServer(suite.db)
+
+	_, errWithCode := server.JWKS()
+	suite.NotNil(errWithCode)
+	suite.Equal(404, errWithCode.Code())
+}
@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// ValidateTokenLifetimes checks that the given access and refresh
+// token lifetimes are suitable to be set on a client, where zero
+// means the instance default (tokens don't expire). A refresh
+// token must not expire before the access token it refreshes.
+func ValidateTokenLifetimes(access time.Duration, refresh time.Duration) error {
+	if access < 0 || refresh < 0 {
+		return errors.New("token lifetimes must not be negative")
+	}
+
+	if refresh != 0 && (access == 0 || refresh < access) {
+		return errors.New("refresh token lifetime must be at least as long as access token lifetime")
+	}
+
+	return nil
+}
+
+// tokenExpiry returns the time at which a token created at the
+// given time, with the given lifetime, expires. Zero lifetimes
+// mean the token never expires, for which zero time is returned.
+func tokenExpiry(createdAt time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return createdAt.Add(ttl)
+}
+
+// accessTokenTTL returns the lifetime of access tokens issued to
+// the given client, or the given default if the client has none.
+func accessTokenTTL(client *gtsmodel.Client, def time.Duration) time.Duration {
+	if client.AccessTokenTTL > 0 {
+		return client.AccessTokenTTL
+	}
+	return def
+}
+
+// refreshTokenTTL returns the lifetime of refresh tokens issued to
+// the given client, or the given default if the client has none.
+func refreshTokenTTL(client *gtsmodel.Client, def time.Duration) time.Duration {
+	if client.RefreshTokenTTL > 0 {
+		return client.RefreshTokenTTL
+	}
+	return def
+}
@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func TestValidateTokenLifetimes(t *testing.T) {
+	type lifetimeTest struct {
+		access   time.Duration
+		refresh  time.Duration
+		expectOK bool
+	}
+
+	for _, test := range []lifetimeTest{
+		{0, 0, true},
+		{time.Hour, 0, true},
+		{time.Hour, time.Hour, true},
+		{time.Hour, 24 * time.Hour, true},
+		{0, time.Hour, false},
+		{24 * time.Hour, time.Hour, false},
+		{-time.Hour, 0, false},
+		{time.Hour, -time.Hour, false},
+	} {
+		err := oauth.ValidateTokenLifetimes(test.access, test.refresh)
+		if ok := err == nil; ok != test.expectOK {
+			t.Errorf("access %s, refresh %s: expected ok %t, got error %v", test.access, test.refresh, test.expectOK, err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3/ttl"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+const (
+	// RequestURIPrefix is the prefix of request
+	// URIs returned for pushed authorization requests.
+	// See https://datatracker.ietf.org/doc/html/rfc9126#section-2.2
+	RequestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+
+	// PushedAuthRequestTTL is how long a pushed authorization
+	// request may be used for at the authorize endpoint. The
+	// spec recommends a short lifetime, between 5 and 600 seconds.
+	PushedAuthRequestTTL = time.Minute
+
+	// pushedAuthRequestsMax is the max
+	// number of pushed authorization
+	// requests to keep around at once.
+	pushedAuthRequestsMax = 10000
+)
+
+// PushedAuthRequests stores the parameters of authorization requests
+// pushed by clients to the pushed authorization request (PAR) endpoint,
+// as per https://datatracker.ietf.org/doc/html/rfc9126, keyed by the
+// request URI returned to the client. Each request URI can only be
+// used once, and only until it expires.
+type PushedAuthRequests struct {
+	cache *ttl.Cache[string, pushedAuthRequest]
+}
+
+type pushedAuthRequest struct {
+	form      apimodel.OAuthAuthorize
+	expiresAt time.Time
+}
+
+// NewPushedAuthRequests returns a new, empty PushedAuthRequests.
+func NewPushedAuthRequests() *PushedAuthRequests {
+	cache := new(ttl.Cache[string, pushedAuthRequest])
+
+	// Expiry is checked against each request's own
+	// expiresAt, since the cache TTL is refreshed on
+	// access; the TTL just stops stale requests from
+	// hanging around until pushed out by newer ones.
+	cache.Init(0, pushedAuthRequestsMax, PushedAuthRequestTTL)
+
+	return &PushedAuthRequests{cache: cache}
+}
+
+// Push stores the given authorization request parameters at the
+// given time, returning the request URI that the client should
+// use to refer to them at the authorize endpoint.
+func (p *PushedAuthRequests) Push(form *apimodel.OAuthAuthorize, now time.Time) (string, error) {
+	b := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	requestURI := RequestURIPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	p.cache.Set(requestURI, pushedAuthRequest{
+		form:      *form,
+		expiresAt: now.Add(PushedAuthRequestTTL),
+	})
+
+	return requestURI, nil
+}
+
+// Pop returns the authorization request parameters stored under
+// the given request URI for the given client, removing them so that
+// the request URI can't be used again. An error is returned if the
+// request URI is unknown, was already used, was pushed by a different
+// client, or had expired by the given time.
+func (p *PushedAuthRequests) Pop(requestURI string, clientID string, now time.Time) (*apimodel.OAuthAuthorize, error) {
+	par, ok := p.cache.Get(requestURI)
+
+	// Only the caller that actually removes the
+	// request gets to use it, so concurrent uses
+	// of the same request URI can't both succeed.
+	if !ok || !p.cache.Invalidate(requestURI) {
+		return nil, errors.New("request_uri is unknown, or has already been used")
+	}
+
+	if par.form.ClientID != clientID {
+		return nil, errors.New("request_uri was not pushed by this client")
+	}
+
+	if !now.Before(par.expiresAt) {
+		return nil, errors.New("request_uri has expired")
+	}
+
+	form := par.form
+	return &form, nil
+}
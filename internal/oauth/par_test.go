@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func TestPushedAuthRequests(t *testing.T) {
+	var (
+		par  = oauth.NewPushedAuthRequests()
+		now  = time.Now()
+		form = &apimodel.OAuthAuthorize{
+			ResponseType: "code",
+			ClientID:     "01F8MGY43H3N2C8EWPR2FPYEXG",
+			RedirectURI:  "https://example.org/callback",
+			Scope:        "read write",
+			State:        "some-state",
+		}
+	)
+
+	push := func() string {
+		requestURI, err := par.Push(form, now)
+		if err != nil {
+			t.Fatalf("error pushing: %v", err)
+		}
+		if !strings.HasPrefix(requestURI, oauth.RequestURIPrefix) {
+			t.Fatalf("request uri %q missing prefix", requestURI)
+		}
+		return requestURI
+	}
+
+	expectErr := func(requestURI string, clientID string, at time.Time, expect string) {
+		t.Helper()
+		if _, err := par.Pop(requestURI, clientID, at); err == nil || err.Error() != expect {
+			t.Errorf("expected error %q, got %v", expect, err)
+		}
+	}
+
+	// Used once within its lifetime, the
+	// request should come back unchanged.
+	requestURI := push()
+	popped, err := par.Pop(requestURI, form.ClientID, now.Add(oauth.PushedAuthRequestTTL-time.Second))
+	if err != nil {
+		t.Fatalf("error popping: %v", err)
+	}
+	if *popped != *form {
+		t.Errorf("expected %+v, got %+v", *form, *popped)
+	}
+
+	// Replaying the request should fail.
+	expectErr(requestURI, form.ClientID, now, "request_uri is unknown, or has already been used")
+
+	// So should using it once it's expired, even
+	// if it's still cached, and then it's gone.
+	requestURI = push()
+	expectErr(requestURI, form.ClientID, now.Add(oauth.PushedAuthRequestTTL), "request_uri has expired")
+	expectErr(requestURI, form.ClientID, now, "request_uri is unknown, or has already been used")
+
+	// As should using it from a different client.
+	requestURI = push()
+	expectErr(requestURI, "01F8MGV8AC3NGSJW0FE8W1BV70", now, "request_uri was not pushed by this client")
+
+	// And of course, an unknown request uri.
+	expectErr(oauth.RequestURIPrefix+"nope", form.ClientID, now, "request_uri is unknown, or has already been used")
+
+	// Request uris must not be guessable.
+	if push() == push() {
+		t.Error("expected distinct request uris")
+	}
+}
@@ -0,0 +1,201 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	oautherr "github.com/superseriousbusiness/oauth2/v4/errors"
+)
+
+// ValidateRedirectURI checks that the given redirect URI is suitable to
+// be registered for an OAuth client, ie., that it's either the out-of-band
+// URI, or an absolute URL without a fragment (RFC 6749 section 3.1.2).
+// Wildcards are rejected too, since redirect URIs are matched exactly.
+//
+// If allowLocalhost is false, http(s) URLs pointing to localhost or
+// a loopback address will also be rejected.
+func ValidateRedirectURI(redirectURI string, allowLocalhost bool) error {
+	if redirectURI == OOBURI {
+		// Always fine.
+		return nil
+	}
+
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return fmt.Errorf("redirect uri %s could not be parsed: %w", redirectURI, err)
+	}
+
+	if !u.IsAbs() {
+		return fmt.Errorf("redirect uri %s is not an absolute url", redirectURI)
+	}
+
+	if u.Fragment != "" || strings.Contains(redirectURI, "#") {
+		return fmt.Errorf("redirect uri %s must not contain a fragment", redirectURI)
+	}
+
+	if strings.Contains(redirectURI, "*") {
+		return fmt.Errorf("redirect uri %s must not contain wildcards", redirectURI)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		// Custom scheme, eg. for native
+		// apps, no host to check further.
+		return nil
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Errorf("redirect uri %s has no host", redirectURI)
+	}
+
+	if !allowLocalhost && isLocalhost(host) {
+		return fmt.Errorf("redirect uri %s points to localhost", redirectURI)
+	}
+
+	return nil
+}
+
+// isLocalhost returns whether the given
+// hostname is localhost or a loopback ip.
+func isLocalhost(host string) bool {
+	host = strings.ToLower(host)
+	if host == "localhost" ||
+		strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ParseRedirectURIs splits the given newline-separated
+// redirect uris, as submitted by clients on app creation,
+// into a slice of redirect uris, dropping empty lines.
+func ParseRedirectURIs(redirectURIs string) []string {
+	var uris []string
+	for _, uri := range strings.Split(redirectURIs, "\n") {
+		uri = strings.TrimSpace(uri)
+		if uri != "" {
+			uris = append(uris, uri)
+		}
+	}
+	return uris
+}
+
+// defaultRedirectURI returns the redirect uri to use when
+// a request doesn't specify one, ie., the first redirect
+// uri registered for the client with the given domain.
+func defaultRedirectURI(domain string) string {
+	if uris := ParseRedirectURIs(domain); len(uris) > 0 {
+		return uris[0]
+	}
+	return ""
+}
+
+// RedirectURIAllowed returns whether the given redirect uri, from an
+// authorization request, matches one of the given registered redirect
+// uris, according to MatchRedirectURI. The port of loopback redirect
+// uris is only ignored if advanced-oauth-loopback-redirect-any-port
+// is set.
+func RedirectURIAllowed(registered []string, redirectURI string) bool {
+	loopbackAnyPort := config.GetAdvancedOAuthLoopbackRedirectAnyPort()
+	return slices.ContainsFunc(registered, func(uri string) bool {
+		return MatchRedirectURI(uri, redirectURI, loopbackAnyPort)
+	})
+}
+
+// MatchRedirectURI returns whether the given redirect uri exactly
+// matches the given registered redirect uri, with no normalization.
+//
+// If loopbackAnyPort is true, an http redirect uri to a loopback ip
+// address may also differ from the registered one in its port, since
+// native apps listen on whichever port the os gives them at runtime
+// (RFC 8252 section 7.3). The rest of the uri must still match exactly.
+func MatchRedirectURI(registered string, redirectURI string, loopbackAnyPort bool) bool {
+	if redirectURI == registered {
+		return true
+	}
+
+	if !loopbackAnyPort {
+		return false
+	}
+
+	registeredStripped, ok := stripLoopbackPort(registered)
+	if !ok {
+		return false
+	}
+
+	redirectStripped, ok := stripLoopbackPort(redirectURI)
+	if !ok {
+		return false
+	}
+
+	return redirectStripped == registeredStripped
+}
+
+// stripLoopbackPort returns the given uri with its port removed,
+// if it's an http uri to a loopback ip address, or false if not.
+func stripLoopbackPort(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "http" || u.User != nil {
+		return "", false
+	}
+
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		// Only ip literals; "localhost" may
+		// resolve elsewhere (RFC 8252 section 8.3).
+		return "", false
+	}
+
+	// Cut the host from the uri as given,
+	// rather than re-serializing the parsed
+	// uri, so that the rest stays exactly
+	// as given.
+	rest, ok := strings.CutPrefix(uri, "http://"+u.Host)
+	if !ok {
+		return "", false
+	}
+
+	if strings.Contains(host, ":") {
+		// IPv6 literal.
+		host = "[" + host + "]"
+	}
+
+	return "http://" + host + rest, true
+}
+
+// validateURIHandler is a manage.ValidateURIHandler which, unlike
+// the default handler, supports clients that have multiple redirect
+// URIs registered, separated by newlines. The given redirect URI is
+// only valid if it matches one of the client's redirect URIs, see
+// RedirectURIAllowed.
+func validateURIHandler(baseURI string, redirectURI string) error {
+	if RedirectURIAllowed(ParseRedirectURIs(baseURI), redirectURI) {
+		// Permitted.
+		return nil
+	}
+
+	return oautherr.ErrInvalidRedirectURI
+}
@@ -0,0 +1,154 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+func TestValidateRedirectURI(t *testing.T) {
+	type redirectTest struct {
+		redirectURI    string
+		allowLocalhost bool
+		valid          bool
+	}
+
+	for _, test := range []redirectTest{
+		{"https://example.org/callback", false, true},
+		{"https://example.org/callback?foo=bar", false, true},
+		{"urn:ietf:wg:oauth:2.0:oob", false, true},
+		{"org.example.app:/callback", false, true},
+		{"https://example.org/callback#fragment", false, false},
+		{"https://example.org/callback#", false, false},
+		{"https://*.example.org/callback", false, false},
+		{"https://example.org/*", false, false},
+		{"/callback", false, false},
+		{"example.org/callback", false, false},
+		{"https:///callback", false, false},
+		{"http://localhost:8080/callback", false, false},
+		{"http://app.localhost/callback", false, false},
+		{"http://127.0.0.1/callback", false, false},
+		{"http://[::1]:8080/callback", false, false},
+		{"http://localhost:8080/callback", true, true},
+		{"http://127.0.0.1/callback", true, true},
+		{"", false, false},
+	} {
+		err := oauth.ValidateRedirectURI(test.redirectURI, test.allowLocalhost)
+		if valid := (err == nil); valid != test.valid {
+			t.Errorf("redirect uri %q, allow localhost %t: expected valid %t, got error %v", test.redirectURI, test.allowLocalhost, test.valid, err)
+		}
+	}
+}
+
+func TestMatchRedirectURI(t *testing.T) {
+	type matchTest struct {
+		registered      string
+		redirectURI     string
+		loopbackAnyPort bool
+		match           bool
+	}
+
+	for _, test := range []matchTest{
+		// Exact matches.
+		{"https://example.org/callback", "https://example.org/callback", false, true},
+		{"https://example.org/callback?foo=bar", "https://example.org/callback?foo=bar", false, true},
+		{"urn:ietf:wg:oauth:2.0:oob", "urn:ietf:wg:oauth:2.0:oob", false, true},
+		{"org.example.app:/callback", "org.example.app:/callback", true, true},
+		{"http://127.0.0.1:1234/callback", "http://127.0.0.1:1234/callback", false, true},
+
+		// Loopback with varying port.
+		{"http://127.0.0.1/callback", "http://127.0.0.1:51004/callback", true, true},
+		{"http://127.0.0.1:1234/callback", "http://127.0.0.1:5678/callback", true, true},
+		{"http://127.0.0.1:1234/callback", "http://127.0.0.1/callback", true, true},
+		{"http://[::1]/callback", "http://[::1]:51004/callback", true, true},
+		{"http://127.0.0.1/callback?app=1", "http://127.0.0.1:51004/callback?app=1", true, true},
+		{"http://127.0.0.1/callback", "http://127.0.0.1:51004/callback", false, false},
+		{"http://localhost/callback", "http://localhost:51004/callback", true, false},
+		{"https://127.0.0.1/callback", "https://127.0.0.1:51004/callback", true, false},
+		{"http://127.0.0.1/callback", "http://127.0.0.2:51004/callback", true, false},
+		{"http://127.0.0.1/callback", "http://[::1]:51004/callback", true, false},
+		{"http://127.0.0.1/callback", "http://127.0.0.1:51004/callback/", true, false},
+		{"http://127.0.0.1/callback", "http://127.0.0.1:51004/callback?evil=1", true, false},
+		{"http://127.0.0.1/callback", "http://user@127.0.0.1:51004/callback", true, false},
+
+		// Mismatches.
+		{"https://example.org/callback", "https://example.org/callback/", true, false},
+		{"https://example.org/callback", "https://example.org/Callback", true, false},
+		{"https://example.org/callback", "https://EXAMPLE.org/callback", true, false},
+		{"https://example.org/callback", "https://example.org:443/callback", true, false},
+		{"https://example.org/callback", "https://example.org.evil.com/callback", true, false},
+		{"https://example.org/callback", "https://example.org/callback?next=https://evil.com", true, false},
+		{"https://example.org/callback", "https://example.org/callback/../evil", true, false},
+		{"https://example.org/callback", "http://example.org/callback", true, false},
+		{"https://example.org/callback", "", true, false},
+	} {
+		if match := oauth.MatchRedirectURI(test.registered, test.redirectURI, test.loopbackAnyPort); match != test.match {
+			t.Errorf("registered %q, redirect uri %q, loopback any port %t: expected match %t, got %t", test.registered, test.redirectURI, test.loopbackAnyPort, test.match, match)
+		}
+	}
+}
+
+func TestRedirectURIAllowed(t *testing.T) {
+	testrig.InitTestConfig()
+
+	registered := []string{
+		"https://example.org/callback",
+		"http://127.0.0.1/callback",
+	}
+
+	if !oauth.RedirectURIAllowed(registered, "https://example.org/callback") {
+		t.Error("expected exact redirect uri to be allowed")
+	}
+
+	if !oauth.RedirectURIAllowed(registered, "http://127.0.0.1:51004/callback") {
+		t.Error("expected loopback redirect uri with any port to be allowed by default")
+	}
+
+	if oauth.RedirectURIAllowed(registered, "https://example.org:8443/callback") {
+		t.Error("expected non-loopback redirect uri with different port to be rejected")
+	}
+
+	config.SetAdvancedOAuthLoopbackRedirectAnyPort(false)
+	if oauth.RedirectURIAllowed(registered, "http://127.0.0.1:51004/callback") {
+		t.Error("expected loopback redirect uri with different port to be rejected when configured")
+	}
+	if !oauth.RedirectURIAllowed(registered, "http://127.0.0.1/callback") {
+		t.Error("expected exact loopback redirect uri to be allowed when configured")
+	}
+}
+
+func TestParseRedirectURIs(t *testing.T) {
+	for _, test := range []struct {
+		redirectURIs string
+		expected     []string
+	}{
+		{"https://example.org/callback", []string{"https://example.org/callback"}},
+		{"https://example.org/callback\norg.example.app:/callback\n", []string{"https://example.org/callback", "org.example.app:/callback"}},
+		{" https://example.org/callback \r\n\nhttp://localhost:8080/callback", []string{"https://example.org/callback", "http://localhost:8080/callback"}},
+		{"", nil},
+	} {
+		if uris := oauth.ParseRedirectURIs(test.redirectURIs); !slices.Equal(uris, test.expected) {
+			t.Errorf("redirect uris %q: expected %q, got %q", test.redirectURIs, test.expected, uris)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Top-level OAuth scopes, as per Mastodon:
+// https://docs.joinmastodon.org/api/oauth-scopes/
+const (
+	ScopeRead       = "read"
+	ScopeWrite      = "write"
+	ScopeFollow     = "follow"
+	ScopePush       = "push"
+	ScopeProfile    = "profile"
+	ScopeAdmin      = "admin"
+	ScopeAdminRead  = "admin:read"
+	ScopeAdminWrite = "admin:write"
+
+	// ScopeUser is a legacy GoToSocial
+	// scope, used by the settings panel,
+	// giving full access to user endpoints.
+	ScopeUser = "user"
+
+	// ScopeDefault is the scope granted
+	// when none is explicitly requested.
+	ScopeDefault = ScopeRead
+)
+
+var (
+	// readSubScopes are the sub-scopes of ScopeRead.
+	readSubScopes = []string{
+		"accounts",
+		"blocks",
+		"bookmarks",
+		"favourites",
+		"filters",
+		"follows",
+		"lists",
+		"mutes",
+		"notifications",
+		"search",
+		"statuses",
+	}
+
+	// writeSubScopes are the sub-scopes of ScopeWrite.
+	writeSubScopes = []string{
+		"accounts",
+		"blocks",
+		"bookmarks",
+		"conversations",
+		"favourites",
+		"filters",
+		"follows",
+		"lists",
+		"media",
+		"mutes",
+		"notifications",
+		"reports",
+		"statuses",
+	}
+
+	// adminSubScopes are the sub-scopes
+	// of ScopeAdminRead and ScopeAdminWrite.
+	adminSubScopes = []string{
+		"accounts",
+		"reports",
+		"domain_allows",
+		"domain_blocks",
+		"ip_blocks",
+		"email_domain_blocks",
+		"canonical_email_blocks",
+	}
+
+	// impliedScopes maps scopes to other scopes that they
+	// imply, in addition to any of their own sub-scopes.
+	impliedScopes = map[string][]string{
+		ScopeFollow: {
+			"read:blocks",
+			"read:follows",
+			"read:mutes",
+			"write:blocks",
+			"write:follows",
+			"write:mutes",
+		},
+		ScopeUser: {
+			ScopeRead,
+			ScopeWrite,
+			ScopeFollow,
+			ScopePush,
+		},
+	}
+
+	// validScopes contains all recognized scopes.
+	validScopes = func() map[string]struct{} {
+		m := map[string]struct{}{
+			ScopeRead:       {},
+			ScopeWrite:      {},
+			ScopeFollow:     {},
+			ScopePush:       {},
+			ScopeProfile:    {},
+			ScopeAdmin:      {},
+			ScopeAdminRead:  {},
+			ScopeAdminWrite: {},
+			ScopeUser:       {},
+		}
+		for _, sub := range readSubScopes {
+			m[ScopeRead+":"+sub] = struct{}{}
+		}
+		for _, sub := range writeSubScopes {
+			m[ScopeWrite+":"+sub] = struct{}{}
+		}
+		for _, sub := range adminSubScopes {
+			m[ScopeAdminRead+":"+sub] = struct{}{}
+			m[ScopeAdminWrite+":"+sub] = struct{}{}
+		}
+		return m
+	}()
+)
+
+// ValidateScopes checks that each of the given space
+// separated scopes is a recognized OAuth scope.
+func ValidateScopes(scopes string) error {
+	fields := strings.Fields(scopes)
+	if len(fields) == 0 {
+		return errors.New("no scopes provided")
+	}
+
+	for _, scope := range fields {
+		if _, ok := validScopes[scope]; !ok {
+			return fmt.Errorf("scope %q not recognized", scope)
+		}
+	}
+
+	return nil
+}
+
+// ValidateClientCredentialsScopes checks that each of the given space
+// separated scopes is a recognized OAuth scope that may be granted to an
+// app-only token via the client_credentials grant. Such tokens don't act on
+// behalf of any user, so only reading (ie., public information) is allowed.
+func ValidateClientCredentialsScopes(scopes string) error {
+	if err := ValidateScopes(scopes); err != nil {
+		return err
+	}
+
+	for _, scope := range strings.Fields(scopes) {
+		if !scopeAllows(ScopeRead, scope) {
+			return fmt.Errorf("scope %q not permitted for client credentials", scope)
+		}
+	}
+
+	return nil
+}
+
+// ScopesAllow returns whether the given space separated
+// granted scopes permit the given required scope. This
+// takes account of the hierarchy of scopes, eg. "write"
+// permits "write:statuses", and "admin" permits "admin:read".
+func ScopesAllow(granted string, required string) bool {
+	for _, scope := range strings.Fields(granted) {
+		if scopeAllows(scope, required) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopesSubset returns whether each of the given space
+// separated requested scopes are permitted by the given
+// space separated granted scopes, ie. whether requested
+// is the same as, or a narrower version of, granted.
+func ScopesSubset(requested string, granted string) bool {
+	for _, scope := range strings.Fields(requested) {
+		if !ScopesAllow(granted, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// scopeAllows returns whether single granted scope permits required scope.
+func scopeAllows(granted string, required string) bool {
+	if granted == required ||
+		strings.HasPrefix(required, granted+":") {
+		// Exact match, or required
+		// is a sub-scope of granted.
+		return true
+	}
+
+	for _, implied := range impliedScopes[granted] {
+		if scopeAllows(implied, required) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth_test
+
+import (
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func TestScopesAllow(t *testing.T) {
+	type scopeTest struct {
+		granted  string
+		required string
+		expected bool
+	}
+
+	for _, test := range []scopeTest{
+		{"read", "read", true},
+		{"read", "read:statuses", true},
+		{"read:statuses", "read", false},
+		{"read:statuses", "read:statuses", true},
+		{"read:statuses", "read:accounts", false},
+		{"read", "write:statuses", false},
+		{"read write", "write:statuses", true},
+		{"write", "write:statuses", true},
+		{"follow", "write:follows", true},
+		{"follow", "read:mutes", true},
+		{"follow", "write:statuses", false},
+		{"admin", "admin:read:accounts", true},
+		{"admin:read", "admin:read:accounts", true},
+		{"admin:read", "admin:write:accounts", false},
+		{"user", "write:media", true},
+		{"user", "admin:read", false},
+		{"readwrite", "read:statuses", false},
+		{"", "read", false},
+	} {
+		if actual := oauth.ScopesAllow(test.granted, test.required); actual != test.expected {
+			t.Errorf("granted %q, required %q: expected %t, got %t", test.granted, test.required, test.expected, actual)
+		}
+	}
+}
+
+func TestScopesSubset(t *testing.T) {
+	type scopeTest struct {
+		requested string
+		granted   string
+		expected  bool
+	}
+
+	for _, test := range []scopeTest{
+		{"read", "read write follow push", true},
+		{"read:statuses write:media", "read write", true},
+		{"read write", "read", false},
+		{"write", "write:statuses", false},
+		{"admin:read", "read write admin", true},
+		{"", "read", true},
+	} {
+		if actual := oauth.ScopesSubset(test.requested, test.granted); actual != test.expected {
+			t.Errorf("requested %q, granted %q: expected %t, got %t", test.requested, test.granted, test.expected, actual)
+		}
+	}
+}
+
+func TestValidateScopes(t *testing.T) {
+	for _, valid := range []string{
+		"read",
+		"read write follow push",
+		"read:statuses write:media",
+		"admin:read:accounts admin:write",
+		"user admin",
+	} {
+		if err := oauth.ValidateScopes(valid); err != nil {
+			t.Errorf("expected %q to be valid, got %v", valid, err)
+		}
+	}
+
+	for _, invalid := range []string{
+		"",
+		"read:nothing",
+		"everything",
+		"read,write",
+	} {
+		if err := oauth.ValidateScopes(invalid); err == nil {
+			t.Errorf("expected %q to be invalid", invalid)
+		}
+	}
+}
+
+func TestValidateClientCredentialsScopes(t *testing.T) {
+	for scopes, valid := range map[string]bool{
+		"read":                        true,
+		"read:statuses read:accounts": true,
+		"write":                       false,
+		"read write:statuses":         false,
+		"follow":                      false,
+		"user":                        false,
+		"admin:read":                  false,
+		"profile":                     false,
+		"read:nonsense":               false,
+		"":                            false,
+	} {
+		err := oauth.ValidateClientCredentialsScopes(scopes)
+		if valid && err != nil {
+			t.Errorf("scopes %q: expected valid, got error: %v", scopes, err)
+		} else if !valid && err == nil {
+			t.Errorf("scopes %q: expected error, got none", scopes)
+		}
+	}
+}
@@ -23,12 +23,19 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/oauth2/v4"
 	oautherr "github.com/superseriousbusiness/oauth2/v4/errors"
+	"github.com/superseriousbusiness/oauth2/v4/generates"
 	"github.com/superseriousbusiness/oauth2/v4/manage"
 	"github.com/superseriousbusiness/oauth2/v4/server"
 )
@@ -57,7 +64,7 @@ const (
 	// HelpfulAdvice is a handy hint to users;
 	// particularly important during the login flow
 	HelpfulAdvice      = "If you arrived at this error during a sign in/oauth flow, please try clearing your session cookies and signing in again; if problems persist, make sure you're using the correct credentials"
-	HelpfulAdviceGrant = "If you arrived at this error during a sign in/oauth flow, your client is trying to use an unsupported OAuth grant type. Supported grant types are: authorization_code, client_credentials; please reach out to developer of your client"
+	HelpfulAdviceGrant = "If you arrived at this error during a sign in/oauth flow, your client is trying to use an unsupported OAuth grant type. Supported grant types are: authorization_code, client_credentials, refresh_token; please reach out to developer of your client"
 )
 
 // Server wraps some oauth2 server functions in an interface, exposing only what is needed
@@ -67,24 +74,73 @@ type Server interface {
 	ValidationBearerToken(r *http.Request) (oauth2.TokenInfo, error)
 	GenerateUserAccessToken(ctx context.Context, ti oauth2.TokenInfo, clientSecret string, userID string) (accessToken oauth2.TokenInfo, err error)
 	LoadAccessToken(ctx context.Context, access string) (accessToken oauth2.TokenInfo, err error)
+	RevokeToken(ctx context.Context, clientID string, clientSecret string, token string, tokenTypeHint string) gtserror.WithCode
+	IntrospectToken(ctx context.Context, clientID string, clientSecret string, token string, tokenTypeHint string) (*apimodel.OAuthTokenIntrospection, gtserror.WithCode)
+	PushAuthorizeRequest(ctx context.Context, clientID string, clientSecret string, form *apimodel.OAuthAuthorize) (*apimodel.OAuthPushedAuthorization, gtserror.WithCode)
+	LoadPushedAuthorizeRequest(ctx context.Context, clientID string, requestURI string) (*apimodel.OAuthAuthorize, gtserror.WithCode)
+	JWKS() (*apimodel.JWKS, gtserror.WithCode)
 }
 
 // s fulfils the Server interface using the underlying oauth2 server
 type s struct {
-	server *server.Server
+	server    *server.Server
+	generator oauth2.AccessGenerate
+	jwt       *JWTAccessGenerate
+	db        db.DB
+	par       *PushedAuthRequests
+
+	// accessTTL is the default lifetime of user
+	// access tokens, where zero means they never
+	// expire (they must be revoked instead).
+	accessTTL time.Duration
 }
 
-// New returns a new oauth server that implements the Server interface
-func New(ctx context.Context, database db.DB) Server {
-	ts := newTokenStore(ctx, database)
+// New returns a new oauth server that implements the Server interface.
+//
+// If advanced-oauth-access-token-format is jwt, then access tokens
+// are issued as JWTs signed with the configured signing key, which
+// is loaded here, else they're issued as opaque random strings.
+func New(ctx context.Context, database db.DB) (Server, error) {
+	var (
+		generator oauth2.AccessGenerate = generates.NewAccessGenerate()
+		jwtGen    *JWTAccessGenerate
+		accessTTL time.Duration
+	)
+
+	if config.GetAdvancedOAuthAccessTokenFormat() == config.OAuthAccessTokenFormatJWT {
+		key, err := LoadJWTSigningKey(config.GetAdvancedOAuthJWTSigningKeyPath())
+		if err != nil {
+			return nil, err
+		}
+
+		// JWT access tokens expire after the JWT lifetime,
+		// which is also stored as the token's expiry in the
+		// database, so that they're validated against it.
+		accessTTL = config.GetAdvancedOAuthJWTLifetime()
+
+		jwtGen, err = NewJWTAccessGenerate(
+			database,
+			key,
+			config.GetProtocol()+"://"+config.GetHost(),
+			accessTTL,
+		)
+		if err != nil {
+			return nil, err
+		}
+		generator = jwtGen
+	}
+
+	ts := newTokenStore(ctx, database, accessTTL)
 	cs := NewClientStore(database)
 
 	manager := manage.NewDefaultManager()
+	manager.MapAccessGenerate(generator)
 	manager.MapTokenStorage(ts)
 	manager.MapClientStorage(cs)
+	manager.SetValidateURIHandler(validateURIHandler)
 	manager.SetAuthorizeCodeTokenCfg(&manage.Config{
-		AccessTokenExp:    0,     // access tokens don't expire -- they must be revoked
-		IsGenerateRefresh: false, // don't use refresh tokens
+		AccessTokenExp:    0,    // access tokens don't expire by default, see tokenStore.Create
+		IsGenerateRefresh: true, // refresh tokens are rotated on use, see refreshToken
 	})
 	sc := &server.Config{
 		TokenType: "Bearer",
@@ -95,9 +151,11 @@ func New(ctx context.Context, database db.DB) Server {
 		// Allow:
 		// - Authorization Code (for first & third parties)
 		// - Client Credentials (for applications)
+		// - Refresh Token (for rotating user tokens)
 		AllowedGrantTypes: []oauth2.GrantType{
 			oauth2.AuthorizationCode,
 			oauth2.ClientCredentials,
+			oauth2.Refreshing,
 		},
 		AllowedCodeChallengeMethods: []oauth2.CodeChallengeMethod{
 			oauth2.CodeChallengePlain,
@@ -123,14 +181,62 @@ func New(ctx context.Context, database db.DB) Server {
 		return userID, nil
 	})
 	srv.SetClientInfoHandler(server.ClientFormHandler)
+	srv.SetClientScopeHandler(clientScopeHandler(database))
+	srv.SetRefreshingScopeHandler(func(tgr *oauth2.TokenGenerateRequest, oldScope string) (bool, error) {
+		// Allow narrowing the scope
+		// of a refreshed token, but
+		// never widening it.
+		return ScopesSubset(tgr.Scope, oldScope), nil
+	})
 	return &s{
-		server: srv,
+		server:    srv,
+		generator: generator,
+		jwt:       jwtGen,
+		db:        database,
+		par:       NewPushedAuthRequests(),
+		accessTTL: accessTTL,
+	}, nil
+}
+
+// clientScopeHandler returns a handler to check the scope requested when
+// generating a token for a client, either at authorize time or when using
+// client credentials. The scope must be valid, and within the scopes that
+// were registered for the client's application, and only read scopes for
+// demo clients. If no scope is requested, the default scope will be
+// requested instead.
+func clientScopeHandler(database db.DB) server.ClientScopeHandler {
+	return func(tgr *oauth2.TokenGenerateRequest) (bool, error) {
+		ctx := context.Background()
+		if tgr.Request != nil {
+			ctx = tgr.Request.Context()
+		}
+
+		if tgr.Scope == "" {
+			tgr.Scope = ScopeDefault
+		}
+
+		if err := ValidateScopes(tgr.Scope); err != nil {
+			log.Debugf(ctx, "client %s requested invalid scope: %v", tgr.ClientID, err)
+			return false, nil
+		}
+
+		if IsDemoClient(tgr.ClientID) && !ScopesSubset(tgr.Scope, ScopeRead) {
+			log.Debugf(ctx, "demo client %s requested non-read scope %s", tgr.ClientID, tgr.Scope)
+			return false, nil
+		}
+
+		app, err := database.GetApplicationByClientID(ctx, tgr.ClientID)
+		if err != nil {
+			return false, gtserror.Newf("db error getting application for client %s: %w", tgr.ClientID, err)
+		}
+
+		return ScopesSubset(tgr.Scope, app.Scopes), nil
 	}
 }
 
 // HandleTokenRequest wraps the oauth2 library's HandleTokenRequest function
 func (s *s) HandleTokenRequest(r *http.Request) (map[string]interface{}, gtserror.WithCode) {
-	ctx := r.Context()
+	ctx := withDeviceName(r.Context(), r.FormValue(DeviceNameKey))
 
 	gt, tgr, err := s.server.ValidationTokenRequest(r)
 	if err != nil {
@@ -142,12 +248,299 @@ func (s *s) HandleTokenRequest(r *http.Request) (map[string]interface{}, gtserro
 		return nil, gtserror.NewErrorBadRequest(err, help, adv)
 	}
 
+	if gt == oauth2.Refreshing {
+		// Refresh tokens are rotated
+		// by us, not the oauth2 library.
+		return s.refreshToken(ctx, tgr)
+	}
+
+	if gt == oauth2.ClientCredentials {
+		// App-only tokens are issued by
+		// us, not the oauth2 library.
+		return s.clientCredentialsToken(ctx, tgr)
+	}
+
+	if gt == oauth2.AuthorizationCode &&
+		tgr.ClientSecret == "" && tgr.CodeVerifier != "" {
+		// Public client using PKCE without a client
+		// secret. The code verifier stands in for the
+		// secret here: it's checked against the stored
+		// code challenge when the auth code is exchanged,
+		// and the exchange fails if no challenge was set.
+		client, err := s.server.Manager.GetClient(ctx, tgr.ClientID)
+		if err != nil {
+			help := fmt.Sprintf("could not get client: %s", err)
+			return nil, gtserror.NewErrorBadRequest(err, help, HelpfulAdvice)
+		}
+		tgr.ClientSecret = client.GetSecret()
+	}
+
+	if gt == oauth2.AuthorizationCode && deviceName(ctx) == "" {
+		// No device name given now, so carry over the one
+		// given when requesting authorization, if any. The
+		// auth code token is gone by the time the access
+		// token is created, so it has to be fetched first.
+		//
+		// If this fails, then so will the exchange below.
+		if codeToken, err := s.db.GetTokenByCode(ctx, tgr.Code); err == nil {
+			ctx = withDeviceName(ctx, codeToken.DeviceName)
+		}
+	}
+
 	ti, err := s.server.GetAccessToken(ctx, gt, tgr)
 	if err != nil {
 		help := fmt.Sprintf("could not get access token: %s", err)
 		return nil, gtserror.NewErrorBadRequest(err, help, HelpfulAdvice)
 	}
 
+	return s.tokenData(ti)
+}
+
+// refreshToken exchanges the refresh token in the given request for
+// a new access + refresh token pair, consuming the old refresh token.
+//
+// Each token issued this way shares a family ID with the token it was
+// refreshed from. If a refresh token is presented again after it was
+// consumed, then either the client or an attacker is replaying a token
+// that may have been stolen, so the whole family of tokens is revoked.
+func (s *s) refreshToken(
+	ctx context.Context,
+	tgr *oauth2.TokenGenerateRequest,
+) (map[string]interface{}, gtserror.WithCode) {
+	client, errWithCode := s.authenticateClient(ctx, tgr.ClientID, tgr.ClientSecret)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	const invalid = "refresh token is invalid, expired, revoked, or was issued to another client"
+
+	dbToken, err := s.db.GetTokenByRefresh(ctx, tgr.Refresh)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if dbToken == nil || dbToken.ClientID != client.GetID() {
+		return nil, gtserror.NewErrorBadRequest(oautherr.ErrInvalidGrant, invalid)
+	}
+
+	// Tokens created before refresh token
+	// rotation may not have a family ID set.
+	familyID := dbToken.FamilyID
+	if familyID == "" {
+		familyID = dbToken.ID
+	}
+
+	revokeFamily := func() gtserror.WithCode {
+		log.Warnf(ctx,
+			"consumed refresh token of token %s was presented again, revoking token family %s",
+			dbToken.ID, familyID,
+		)
+
+		if err := s.db.DeleteTokensByFamilyID(ctx, familyID); err != nil {
+			err := gtserror.Newf("db error deleting token family: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		return gtserror.NewErrorBadRequest(oautherr.ErrInvalidGrant, invalid)
+	}
+
+	if !dbToken.RefreshConsumedAt.IsZero() {
+		// Refresh token was already used.
+		return nil, revokeFamily()
+	}
+
+	if !dbToken.RefreshExpiresAt.IsZero() && !time.Now().Before(dbToken.RefreshExpiresAt) {
+		return nil, gtserror.NewErrorBadRequest(oautherr.ErrInvalidGrant, invalid)
+	}
+
+	scope := dbToken.Scope
+	if tgr.Scope != "" {
+		// Make sure the requested scope is allowed.
+		if fn := s.server.RefreshingScopeHandler; fn != nil {
+			allowed, err := fn(tgr, dbToken.Scope)
+			if err != nil {
+				err := gtserror.Newf("error checking refresh scope: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			if !allowed {
+				const help = "requested scope is wider than the scope of the refresh token"
+				return nil, gtserror.NewErrorBadRequest(oautherr.ErrInvalidScope, help)
+			}
+		}
+		scope = tgr.Scope
+	}
+
+	// Get the client's token lifetimes.
+	dbClient, err := s.db.GetClientByID(ctx, client.GetID())
+	if err != nil {
+		err := gtserror.Newf("db error getting client: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Consume the old refresh token; this fails
+	// if it was consumed in the meantime by a
+	// concurrent request, which we treat as reuse.
+	consumed, err := s.db.ConsumeTokenRefresh(ctx, dbToken.ID)
+	if err != nil {
+		err := gtserror.Newf("db error consuming refresh token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !consumed {
+		return nil, revokeFamily()
+	}
+
+	now := time.Now()
+	tokenID, err := id.NewRandomULID()
+	if err != nil {
+		err := gtserror.Newf("error generating token id: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Keep the device name of the refreshed
+	// token, unless the client gave a new one.
+	newDeviceName := deviceName(ctx)
+	if newDeviceName == "" {
+		newDeviceName = dbToken.DeviceName
+	}
+
+	newToken := &gtsmodel.Token{
+		ID:               tokenID,
+		ClientID:         dbToken.ClientID,
+		UserID:           dbToken.UserID,
+		RedirectURI:      dbToken.RedirectURI,
+		Scope:            scope,
+		AccessCreateAt:   now,
+		AccessExpiresAt:  tokenExpiry(now, accessTokenTTL(dbClient, s.accessTTL)),
+		RefreshCreateAt:  now,
+		RefreshExpiresAt: tokenExpiry(now, refreshTokenTTL(dbClient, 0)),
+		FamilyID:         familyID,
+		ReadOnly:         util.Ptr(util.PtrValueOr(dbToken.ReadOnly, false) || IsDemoClient(dbToken.ClientID)),
+		DeviceName:       newDeviceName,
+		LastUsedAt:       dbToken.LastUsedAt,
+	}
+
+	// Generate the new token pair with the new token's
+	// info, so that JWT access tokens carry its scope.
+	newToken.Access, newToken.Refresh, err = s.generator.Token(ctx, &oauth2.GenerateBasic{
+		Client:    client,
+		UserID:    dbToken.UserID,
+		CreateAt:  now,
+		TokenInfo: DBTokenToToken(newToken),
+		Request:   tgr.Request,
+	}, true)
+	if err != nil {
+		err := gtserror.Newf("error generating tokens: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := s.db.PutToken(ctx, newToken); err != nil {
+		err := gtserror.Newf("db error putting token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return s.tokenData(DBTokenToToken(newToken))
+}
+
+// clientCredentialsToken issues a new app-only access token to the client
+// in the given request, authenticated by its client secret.
+//
+// The token is bound to the client only, not to any user, so it can't be
+// used to access user-specific endpoints. Its scope must be permitted both
+// by the client's application, and by the client's client credentials
+// scopes, which in turn may only contain app-level (ie., read) scopes.
+func (s *s) clientCredentialsToken(
+	ctx context.Context,
+	tgr *oauth2.TokenGenerateRequest,
+) (map[string]interface{}, gtserror.WithCode) {
+	client, errWithCode := s.authenticateClient(ctx, tgr.ClientID, tgr.ClientSecret)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	dbClient, err := s.db.GetClientByID(ctx, client.GetID())
+	if err != nil {
+		err := gtserror.Newf("db error getting client: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	allowed := dbClient.ClientCredentialsScopes
+	if allowed == "" {
+		allowed = ScopeRead
+	}
+
+	scope := tgr.Scope
+	if scope == "" {
+		scope = ScopeDefault
+	}
+
+	if err := ValidateClientCredentialsScopes(scope); err != nil {
+		return nil, gtserror.NewErrorBadRequest(oautherr.ErrInvalidScope, err.Error())
+	}
+
+	if !ScopesSubset(scope, allowed) {
+		const help = "requested scope is not permitted for this client's client credentials"
+		return nil, gtserror.NewErrorBadRequest(oautherr.ErrInvalidScope, help)
+	}
+
+	app, err := s.db.GetApplicationByClientID(ctx, client.GetID())
+	if err != nil {
+		err := gtserror.Newf("db error getting application: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !ScopesSubset(scope, app.Scopes) {
+		const help = "requested scope is not permitted for this client's application"
+		return nil, gtserror.NewErrorBadRequest(oautherr.ErrInvalidScope, help)
+	}
+
+	now := time.Now()
+	tokenID, err := id.NewRandomULID()
+	if err != nil {
+		err := gtserror.Newf("error generating token id: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	redirectURI := tgr.RedirectURI
+	if redirectURI == "" {
+		redirectURI = defaultRedirectURI(client.GetDomain())
+	}
+
+	// Note: UserID is deliberately
+	// left unset on app-only tokens.
+	token := &gtsmodel.Token{
+		ID:              tokenID,
+		ClientID:        client.GetID(),
+		RedirectURI:     redirectURI,
+		Scope:           scope,
+		AccessCreateAt:  now,
+		AccessExpiresAt: tokenExpiry(now, accessTokenTTL(dbClient, manage.DefaultClientTokenCfg.AccessTokenExp)),
+		ReadOnly:        util.Ptr(IsDemoClient(client.GetID())),
+	}
+
+	token.Access, _, err = s.generator.Token(ctx, &oauth2.GenerateBasic{
+		Client:    client,
+		CreateAt:  now,
+		TokenInfo: DBTokenToToken(token),
+		Request:   tgr.Request,
+	}, false)
+	if err != nil {
+		err := gtserror.Newf("error generating token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := s.db.PutToken(ctx, token); err != nil {
+		err := gtserror.Newf("db error putting token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return s.tokenData(DBTokenToToken(token))
+}
+
+// tokenData returns the token endpoint response for the given token.
+func (s *s) tokenData(ti oauth2.TokenInfo) (map[string]interface{}, gtserror.WithCode) {
 	data := s.server.GetTokenData(ti)
 
 	if expiresInI, ok := data["expires_in"]; ok {
@@ -217,6 +610,11 @@ func (s *s) HandleAuthorizeRequest(w http.ResponseWriter, r *http.Request) gtser
 		}
 	}
 
+	// Demo clients may only be authorized to read.
+	if IsDemoClient(req.ClientID) && !ScopesSubset(req.Scope, ScopeRead) {
+		return s.errorOrRedirect(oautherr.ErrInvalidScope, w, req)
+	}
+
 	// specify the expiration time of access token
 	if fn := s.server.AccessTokenExpHandler; fn != nil {
 		exp, err := fn(w, r)
@@ -226,18 +624,23 @@ func (s *s) HandleAuthorizeRequest(w http.ResponseWriter, r *http.Request) gtser
 		req.AccessTokenExp = exp
 	}
 
+	// Store the device name given when requesting authorization
+	// with the auth code, so that it's carried over to the token.
+	ctx = withDeviceName(ctx, r.FormValue(DeviceNameKey))
+
 	ti, err := s.server.GetAuthorizeToken(ctx, req)
 	if err != nil {
 		return s.errorOrRedirect(err, w, req)
 	}
 
-	// If the redirect URI is empty, the default domain provided by the client is used.
+	// If the redirect URI is empty, the first
+	// redirect URI registered for the client is used.
 	if req.RedirectURI == "" {
 		client, err := s.server.Manager.GetClient(ctx, req.ClientID)
 		if err != nil {
 			return gtserror.NewErrorUnauthorized(err, HelpfulAdvice)
 		}
-		req.RedirectURI = client.GetDomain()
+		req.RedirectURI = defaultRedirectURI(client.GetDomain())
 	}
 
 	uri, err := s.server.GetRedirectURI(req, s.server.GetAuthorizeData(req.ResponseType, ti))
@@ -302,3 +705,314 @@ func (s *s) GenerateUserAccessToken(ctx context.Context, ti oauth2.TokenInfo, cl
 func (s *s) LoadAccessToken(ctx context.Context, access string) (accessToken oauth2.TokenInfo, err error) {
 	return s.server.Manager.LoadAccessToken(ctx, access)
 }
+
+// JWKS returns the JSON Web Key Set to validate JWT access
+// tokens with, or 404 if access tokens aren't issued as JWTs.
+func (s *s) JWKS() (*apimodel.JWKS, gtserror.WithCode) {
+	if s.jwt == nil {
+		const text = "this instance does not issue JWT access tokens"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+	return s.jwt.JWKS(), nil
+}
+
+// RevokeToken revokes the given access or refresh token on behalf of the
+// given client, as per https://datatracker.ietf.org/doc/html/rfc7009.
+//
+// The token type hint, if set, should be one of "access_token" or
+// "refresh_token", and is used to decide which lookup to try first.
+//
+// Since access and refresh tokens are stored together, revoking one
+// will always also revoke the other, along with any other tokens in
+// the same family of refreshed tokens.
+//
+// In line with the spec, no error is returned if the token was not found
+// (or was already revoked), so that callers can't use this function to
+// probe for valid tokens.
+func (s *s) RevokeToken(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+	token string,
+	tokenTypeHint string,
+) gtserror.WithCode {
+	// Authenticate the client first, so that
+	// only the client a token was issued to
+	// (and not just anyone with its ID) can
+	// revoke the token.
+	client, errWithCode := s.authenticateClient(ctx, clientID, clientSecret)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	dbToken, errWithCode := s.getTokenByHint(ctx, token, tokenTypeHint)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if dbToken == nil {
+		// Unknown or already revoked
+		// token, nothing to do.
+		return nil
+	}
+
+	if dbToken.ClientID != client.GetID() {
+		// Token was issued to a different client,
+		// this client is not allowed to revoke it.
+		const help = "token was not issued to this client"
+		return gtserror.NewErrorForbidden(ErrInvalidClient, help)
+	}
+
+	// Deleting the token by its ID removes both
+	// the access and the refresh token (if set).
+	if err := s.db.DeleteTokenByID(ctx, dbToken.ID); err != nil {
+		err := gtserror.Newf("db error deleting token: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if dbToken.FamilyID != "" {
+		// Also remove any tokens it was
+		// refreshed from, or refreshed to.
+		if err := s.db.DeleteTokensByFamilyID(ctx, dbToken.FamilyID); err != nil {
+			err := gtserror.Newf("db error deleting token family: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// IntrospectToken returns metadata about the given access or refresh
+// token on behalf of the given client, as per
+// https://datatracker.ietf.org/doc/html/rfc7662.
+//
+// Unlike RevokeToken, the client must authenticate with its secret.
+// The token type hint is handled in the same way as for RevokeToken.
+//
+// Tokens that are unknown, revoked, expired, or that were issued to a
+// different client are all reported the same way: as not active, with
+// no further metadata, so as not to leak anything about them.
+func (s *s) IntrospectToken(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+	token string,
+	tokenTypeHint string,
+) (*apimodel.OAuthTokenIntrospection, gtserror.WithCode) {
+	client, errWithCode := s.authenticateClient(ctx, clientID, clientSecret)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	inactive := &apimodel.OAuthTokenIntrospection{Active: false}
+
+	dbToken, errWithCode := s.getTokenByHint(ctx, token, tokenTypeHint)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if dbToken == nil {
+		// Unknown or revoked token.
+		return inactive, nil
+	}
+
+	if dbToken.ClientID != client.GetID() {
+		// Token was issued to a different
+		// client, don't reveal anything.
+		return inactive, nil
+	}
+
+	// Get creation + expiry times for whichever
+	// kind of token this is. Zero expiry means
+	// that the token never expires.
+	var createdAt, expiresAt time.Time
+	if dbToken.Access == token {
+		createdAt, expiresAt = dbToken.AccessCreateAt, dbToken.AccessExpiresAt
+	} else {
+		createdAt, expiresAt = dbToken.RefreshCreateAt, dbToken.RefreshExpiresAt
+		if !dbToken.RefreshConsumedAt.IsZero() {
+			// Refresh token has
+			// already been used.
+			return inactive, nil
+		}
+	}
+
+	if !expiresAt.IsZero() && !time.Now().Before(expiresAt) {
+		// Token has expired.
+		return inactive, nil
+	}
+
+	introspection := &apimodel.OAuthTokenIntrospection{
+		Active:    true,
+		Scope:     dbToken.Scope,
+		ClientID:  dbToken.ClientID,
+		TokenType: "Bearer",
+	}
+
+	if !createdAt.IsZero() {
+		introspection.Iat = createdAt.Unix()
+	}
+
+	if !expiresAt.IsZero() {
+		introspection.Exp = expiresAt.Unix()
+	}
+
+	if dbToken.UserID != "" {
+		// Token was issued on behalf of a user,
+		// ensure they're still allowed to use it.
+		user, err := s.db.GetUserByID(ctx, dbToken.UserID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting user: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if user == nil || *user.Disabled || user.Account == nil || user.Account.IsSuspended() {
+			return inactive, nil
+		}
+
+		introspection.Username = user.Account.Username
+	}
+
+	return introspection, nil
+}
+
+// PushAuthorizeRequest checks and stores the parameters of an authorization
+// request pushed by the given client, as per
+// https://datatracker.ietf.org/doc/html/rfc9126, returning the request URI
+// the client can then use at the authorize endpoint in place of the
+// parameters themselves.
+//
+// The client must authenticate with its secret, as otherwise anyone
+// knowing a client ID could push requests on that client's behalf.
+func (s *s) PushAuthorizeRequest(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+	form *apimodel.OAuthAuthorize,
+) (*apimodel.OAuthPushedAuthorization, gtserror.WithCode) {
+	client, errWithCode := s.authenticateClient(ctx, clientID, clientSecret)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.RequestURI != "" {
+		const help = "request_uri must not be included in a pushed authorization request"
+		return nil, gtserror.NewErrorBadRequest(ErrInvalidRequest, help)
+	}
+
+	if form.ClientID != "" && form.ClientID != client.GetID() {
+		const help = "client_id does not match authenticated client"
+		return nil, gtserror.NewErrorBadRequest(ErrInvalidRequest, help)
+	}
+	form.ClientID = client.GetID()
+
+	if form.ResponseType != string(oauth2.Code) {
+		const help = "response_type must be code"
+		return nil, gtserror.NewErrorBadRequest(ErrInvalidRequest, help)
+	}
+
+	dbClient, err := s.db.GetClientByID(ctx, client.GetID())
+	if err != nil {
+		err := gtserror.Newf("db error getting client: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !RedirectURIAllowed(dbClient.RedirectURIs, form.RedirectURI) {
+		help := fmt.Sprintf("redirect_uri %s is not registered for this client", form.RedirectURI)
+		return nil, gtserror.NewErrorBadRequest(ErrInvalidRequest, help)
+	}
+
+	if form.Scope == "" {
+		form.Scope = ScopeRead
+	}
+
+	if err := ValidateScopes(form.Scope); err != nil {
+		return nil, gtserror.NewErrorBadRequest(ErrInvalidRequest, err.Error())
+	}
+
+	requestURI, err := s.par.Push(form, time.Now())
+	if err != nil {
+		err := gtserror.Newf("error pushing authorization request: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.OAuthPushedAuthorization{
+		RequestURI: requestURI,
+		ExpiresIn:  int64(PushedAuthRequestTTL / time.Second),
+	}, nil
+}
+
+// LoadPushedAuthorizeRequest returns the parameters of the authorization
+// request pushed by the given client under the given request URI. The
+// request URI can only be used once: after this, it's gone.
+func (s *s) LoadPushedAuthorizeRequest(
+	ctx context.Context,
+	clientID string,
+	requestURI string,
+) (*apimodel.OAuthAuthorize, gtserror.WithCode) {
+	form, err := s.par.Pop(requestURI, clientID, time.Now())
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error(), HelpfulAdvice)
+	}
+
+	return form, nil
+}
+
+// authenticateClient gets the client with
+// the given ID, checking the given secret
+// against it.
+func (s *s) authenticateClient(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+) (oauth2.ClientInfo, gtserror.WithCode) {
+	const help = "client could not be authenticated"
+
+	client, err := s.server.Manager.GetClient(ctx, clientID)
+	if err != nil {
+		return nil, gtserror.NewErrorUnauthorized(ErrInvalidClient, help)
+	}
+
+	if clientSecret == "" || clientSecret != client.GetSecret() {
+		return nil, gtserror.NewErrorUnauthorized(ErrInvalidClient, help)
+	}
+
+	return client, nil
+}
+
+// getTokenByHint looks up the given access or refresh token, using
+// the token type hint (one of "access_token" or "refresh_token", or
+// empty) to decide which lookup to try first. Returns nil token
+// if no token was found.
+func (s *s) getTokenByHint(
+	ctx context.Context,
+	token string,
+	tokenTypeHint string,
+) (*gtsmodel.Token, gtserror.WithCode) {
+	// Figure out which lookups to
+	// do, and in which order.
+	var lookups []func(context.Context, string) (*gtsmodel.Token, error)
+	switch tokenTypeHint {
+	case "", "access_token":
+		lookups = append(lookups, s.db.GetTokenByAccess, s.db.GetTokenByRefresh)
+	case "refresh_token":
+		lookups = append(lookups, s.db.GetTokenByRefresh, s.db.GetTokenByAccess)
+	default:
+		help := fmt.Sprintf("token_type_hint %s not supported, must be one of access_token, refresh_token", tokenTypeHint)
+		return nil, gtserror.NewErrorBadRequest(ErrUnsupportedTokenType, help)
+	}
+
+	for _, lookup := range lookups {
+		dbToken, err := lookup(ctx, token)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting token: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if dbToken != nil {
+			return dbToken, nil
+		}
+	}
+
+	return nil, nil
+}
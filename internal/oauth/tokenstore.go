@@ -22,10 +22,13 @@ import (
 	"errors"
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/oauth2/v4"
 	"github.com/superseriousbusiness/oauth2/v4/models"
 )
@@ -34,15 +37,23 @@ import (
 type tokenStore struct {
 	oauth2.TokenStore
 	db db.DB
+
+	// accessTTL is the default lifetime of
+	// access tokens, zero meaning no expiry.
+	accessTTL time.Duration
 }
 
 // newTokenStore returns a token store that satisfies the oauth2.TokenStore interface.
 //
 // In order to allow tokens to 'expire', it will also set off a goroutine that iterates through
 // the tokens in the DB once per minute and deletes any that have expired.
-func newTokenStore(ctx context.Context, db db.DB) oauth2.TokenStore {
+//
+// Access tokens created via the token store expire after accessTTL, unless
+// their client has a lifetime of its own; zero means they don't expire.
+func newTokenStore(ctx context.Context, db db.DB, accessTTL time.Duration) oauth2.TokenStore {
 	ts := &tokenStore{
-		db: db,
+		db:        db,
+		accessTTL: accessTTL,
 	}
 
 	// set the token store to clean out expired tokens once per minute, or return if we're done
@@ -66,24 +77,34 @@ func newTokenStore(ctx context.Context, db db.DB) oauth2.TokenStore {
 
 // sweep clears out old tokens that have expired; it should be run on a loop about once per minute or so.
 func (ts *tokenStore) sweep(ctx context.Context) error {
-	// select *all* tokens from the db
-	// todo: if this becomes expensive (ie., there are fucking LOADS of tokens) then figure out a better way.
-	tokens, err := ts.db.GetAllTokens(ctx)
+	// Tokens with no expiry times set (null in
+	// the db) never expire, so aren't selected.
+	return ts.db.DeleteExpiredTokens(ctx, time.Now())
+}
+
+// pruneTokens revokes the oldest active tokens that the given
+// user holds for the given client, so that there's room for one
+// more token within the configured per-client token limit.
+func (ts *tokenStore) pruneTokens(ctx context.Context, userID string, clientID string) error {
+	limit := config.GetAdvancedOAuthMaxTokensPerClient()
+	if limit <= 0 {
+		// No limit.
+		return nil
+	}
+
+	tokens, err := ts.db.GetActiveTokens(ctx, userID, clientID)
 	if err != nil {
-		return err
+		return gtserror.Newf("error getting tokens for user %s client %s: %w", userID, clientID, err)
 	}
 
-	// iterate through and remove expired tokens
-	now := time.Now()
-	for _, dbt := range tokens {
-		// The zero value of a time.Time is 00:00 january 1 1970, which will always be before now. So:
-		// we only want to check if a token expired before now if the expiry time is *not zero*;
-		// ie., if it's been explicity set.
-		if !dbt.CodeExpiresAt.IsZero() && dbt.CodeExpiresAt.Before(now) || !dbt.RefreshExpiresAt.IsZero() && dbt.RefreshExpiresAt.Before(now) || !dbt.AccessExpiresAt.IsZero() && dbt.AccessExpiresAt.Before(now) {
-			if err := ts.db.DeleteTokenByID(ctx, dbt.ID); err != nil {
-				return err
-			}
+	// Tokens are returned oldest
+	// first, so prune from the start.
+	for len(tokens) >= limit {
+		log.Debugf(ctx, "revoking oldest token %s of user %s for client %s", tokens[0].ID, userID, clientID)
+		if err := ts.db.DeleteTokenByID(ctx, tokens[0].ID); err != nil {
+			return gtserror.Newf("error deleting token %s: %w", tokens[0].ID, err)
 		}
+		tokens = tokens[1:]
 	}
 
 	return nil
@@ -97,6 +118,30 @@ func (ts *tokenStore) Create(ctx context.Context, info oauth2.TokenInfo) error {
 		return errors.New("info param was not a models.Token")
 	}
 
+	if t.Access != "" {
+		// Tokens created via the oauth2 library get
+		// the instance-wide lifetimes, so apply the
+		// client's own lifetimes on top, if it has any.
+		// Access tokens otherwise get the default of
+		// the token store, which for JWTs matches the
+		// lifetime their exp claim was generated with.
+		//
+		// This updates t in place, so the token
+		// response also shows the right expiry.
+		client, err := ts.db.GetClientByID(ctx, t.ClientID)
+		if err != nil {
+			return err
+		}
+
+		if ttl := accessTokenTTL(client, ts.accessTTL); ttl > 0 {
+			t.SetAccessExpiresIn(ttl)
+		}
+
+		if client.RefreshTokenTTL > 0 && t.Refresh != "" {
+			t.SetRefreshExpiresIn(client.RefreshTokenTTL)
+		}
+	}
+
 	dbt := TokenToDBToken(t)
 	if dbt.ID == "" {
 		dbtID, err := id.NewRandomULID()
@@ -106,6 +151,29 @@ func (ts *tokenStore) Create(ctx context.Context, info oauth2.TokenInfo) error {
 		dbt.ID = dbtID
 	}
 
+	// Tokens created via the oauth2 library each
+	// start a new family. Tokens issued by refreshing
+	// are created by the server within an existing one.
+	dbt.FamilyID = dbt.ID
+
+	// Tokens of demo clients can never be
+	// used for writes, whatever their scope.
+	dbt.ReadOnly = util.Ptr(IsDemoClient(dbt.ClientID))
+
+	// Bind the token to the device
+	// name given by the client, if any.
+	dbt.DeviceName = deviceName(ctx)
+
+	if dbt.Access != "" && dbt.UserID != "" {
+		// Make room for this new user access token.
+		//
+		// Refreshing doesn't need this, as it
+		// swaps one active token for another.
+		if err := ts.pruneTokens(ctx, dbt.UserID, dbt.ClientID); err != nil {
+			return err
+		}
+	}
+
 	return ts.db.PutToken(ctx, dbt)
 }
 
@@ -159,25 +227,6 @@ func (ts *tokenStore) GetByRefresh(ctx context.Context, refresh string) (oauth2.
 func TokenToDBToken(tkn *models.Token) *gtsmodel.Token {
 	now := time.Now()
 
-	// For the following, we want to make sure we're not adding a time.Now() to an *empty* ExpiresIn, otherwise that's
-	// going to cause all sorts of interesting problems. So check first to make sure that the ExpiresIn is not equal
-	// to the zero value of a time.Duration, which is 0s. If it *is* empty/nil, just leave the ExpiresAt at nil as well.
-
-	cea := time.Time{}
-	if tkn.CodeExpiresIn != 0*time.Second {
-		cea = now.Add(tkn.CodeExpiresIn)
-	}
-
-	aea := time.Time{}
-	if tkn.AccessExpiresIn != 0*time.Second {
-		aea = now.Add(tkn.AccessExpiresIn)
-	}
-
-	rea := time.Time{}
-	if tkn.RefreshExpiresIn != 0*time.Second {
-		rea = now.Add(tkn.RefreshExpiresIn)
-	}
-
 	return &gtsmodel.Token{
 		ClientID:            tkn.ClientID,
 		UserID:              tkn.UserID,
@@ -187,13 +236,13 @@ func TokenToDBToken(tkn *models.Token) *gtsmodel.Token {
 		CodeChallenge:       tkn.CodeChallenge,
 		CodeChallengeMethod: tkn.CodeChallengeMethod,
 		CodeCreateAt:        tkn.CodeCreateAt,
-		CodeExpiresAt:       cea,
+		CodeExpiresAt:       expiresAt(now, tkn.CodeCreateAt, tkn.CodeExpiresIn),
 		Access:              tkn.Access,
 		AccessCreateAt:      tkn.AccessCreateAt,
-		AccessExpiresAt:     aea,
+		AccessExpiresAt:     expiresAt(now, tkn.AccessCreateAt, tkn.AccessExpiresIn),
 		Refresh:             tkn.Refresh,
 		RefreshCreateAt:     tkn.RefreshCreateAt,
-		RefreshExpiresAt:    rea,
+		RefreshExpiresAt:    expiresAt(now, tkn.RefreshCreateAt, tkn.RefreshExpiresIn),
 	}
 }
 
@@ -201,21 +250,6 @@ func TokenToDBToken(tkn *models.Token) *gtsmodel.Token {
 func DBTokenToToken(dbt *gtsmodel.Token) *models.Token {
 	now := time.Now()
 
-	var codeExpiresIn time.Duration
-	if !dbt.CodeExpiresAt.IsZero() {
-		codeExpiresIn = dbt.CodeExpiresAt.Sub(now)
-	}
-
-	var accessExpiresIn time.Duration
-	if !dbt.AccessExpiresAt.IsZero() {
-		accessExpiresIn = dbt.AccessExpiresAt.Sub(now)
-	}
-
-	var refreshExpiresIn time.Duration
-	if !dbt.RefreshExpiresAt.IsZero() {
-		refreshExpiresIn = dbt.RefreshExpiresAt.Sub(now)
-	}
-
 	return &models.Token{
 		ClientID:            dbt.ClientID,
 		UserID:              dbt.UserID,
@@ -225,12 +259,40 @@ func DBTokenToToken(dbt *gtsmodel.Token) *models.Token {
 		CodeChallenge:       dbt.CodeChallenge,
 		CodeChallengeMethod: dbt.CodeChallengeMethod,
 		CodeCreateAt:        dbt.CodeCreateAt,
-		CodeExpiresIn:       codeExpiresIn,
+		CodeExpiresIn:       expiresIn(now, dbt.CodeCreateAt, dbt.CodeExpiresAt),
 		Access:              dbt.Access,
 		AccessCreateAt:      dbt.AccessCreateAt,
-		AccessExpiresIn:     accessExpiresIn,
+		AccessExpiresIn:     expiresIn(now, dbt.AccessCreateAt, dbt.AccessExpiresAt),
 		Refresh:             dbt.Refresh,
 		RefreshCreateAt:     dbt.RefreshCreateAt,
-		RefreshExpiresIn:    refreshExpiresIn,
+		RefreshExpiresIn:    expiresIn(now, dbt.RefreshCreateAt, dbt.RefreshExpiresAt),
+	}
+}
+
+// expiresAt returns the expiry time of a token with the given
+// lifetime, counted from its creation time if set, else from
+// now. Zero lifetimes mean the token never expires, for which
+// zero time is returned.
+func expiresAt(now time.Time, createdAt time.Time, ttl time.Duration) time.Time {
+	if ttl == 0 {
+		return time.Time{}
+	}
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+	return createdAt.Add(ttl)
+}
+
+// expiresIn returns the lifetime of a token with the given expiry
+// time, counted from its creation time if set, else from now, as
+// the oauth2 library checks expiry against the creation time. Zero
+// expiry means the token never expires, for which zero is returned.
+func expiresIn(now time.Time, createdAt time.Time, expiresAt time.Time) time.Duration {
+	if expiresAt.IsZero() {
+		return 0
+	}
+	if createdAt.IsZero() {
+		createdAt = now
 	}
+	return expiresAt.Sub(createdAt)
 }
@@ -17,4 +17,155 @@
 
 package oauth_test
 
-// TODO: write tests
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+	"github.com/superseriousbusiness/oauth2/v4/models"
+)
+
+type TokenStoreTestSuite struct {
+	suite.Suite
+	db    db.DB
+	state state.State
+
+	testTokens  map[string]*gtsmodel.Token
+	testClients map[string]*gtsmodel.Client
+}
+
+func (suite *TokenStoreTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+	testrig.InitTestLog()
+	testrig.InitTestConfig()
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+	testrig.StandardDBSetup(suite.db, nil)
+
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+}
+
+func (suite *TokenStoreTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *TokenStoreTestSuite) TestOldestTokenEvicted() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config.SetAdvancedOAuthMaxTokensPerClient(2)
+
+	var (
+		server   = testrig.NewTestOauthServer(suite.db)
+		existing = suite.testTokens["local_account_1"]
+		client   = suite.testClients["local_account_1"]
+	)
+
+	// User already holds one token for this client, so
+	// the first new one fits, and the second evicts it.
+	var accesses []string
+	for i := 0; i < 2; i++ {
+		ti, err := server.GenerateUserAccessToken(ctx,
+			oauth.DBTokenToToken(existing),
+			client.Secret,
+			existing.UserID,
+		)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		accesses = append(accesses, ti.(*models.Token).Access)
+	}
+
+	tokens, err := suite.db.GetActiveTokens(ctx, existing.UserID, existing.ClientID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(tokens, 2)
+
+	// Oldest token is gone...
+	_, err = suite.db.GetTokenByAccess(ctx, existing.Access)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// ...and the new ones remain.
+	for _, access := range accesses {
+		_, err := suite.db.GetTokenByAccess(ctx, access)
+		suite.NoError(err)
+	}
+}
+
+func (suite *TokenStoreTestSuite) TestNoTokenLimit() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config.SetAdvancedOAuthMaxTokensPerClient(0)
+
+	var (
+		server   = testrig.NewTestOauthServer(suite.db)
+		existing = suite.testTokens["local_account_1"]
+		client   = suite.testClients["local_account_1"]
+	)
+
+	for i := 0; i < 3; i++ {
+		if _, err := server.GenerateUserAccessToken(ctx,
+			oauth.DBTokenToToken(existing),
+			client.Secret,
+			existing.UserID,
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	tokens, err := suite.db.GetActiveTokens(ctx, existing.UserID, existing.ClientID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(tokens, 4)
+}
+
+func (suite *TokenStoreTestSuite) TestDemoClientTokenReadOnly() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		server   = testrig.NewTestOauthServer(suite.db)
+		existing = suite.testTokens["local_account_1"]
+		client   = suite.testClients["local_account_1"]
+	)
+
+	for _, demo := range []bool{false, true} {
+		if demo {
+			config.SetAdvancedOAuthDemoClientIDs([]string{client.ID})
+		} else {
+			config.SetAdvancedOAuthDemoClientIDs(nil)
+		}
+
+		// Token is created with the existing
+		// token's scope, which includes write.
+		ti, err := server.GenerateUserAccessToken(ctx,
+			oauth.DBTokenToToken(existing),
+			client.Secret,
+			existing.UserID,
+		)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		token, err := suite.db.GetTokenByAccess(ctx, ti.GetAccess())
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(demo, *token.ReadOnly)
+	}
+	config.SetAdvancedOAuthDemoClientIDs(nil)
+}
+
+func TestTokenStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(TokenStoreTestSuite))
+}
@@ -18,7 +18,10 @@
 package oauth
 
 import (
+	"slices"
+
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/oauth2/v4"
 	"github.com/superseriousbusiness/oauth2/v4/errors"
@@ -105,3 +108,12 @@ func Authed(c *gin.Context, requireToken bool, requireApp bool, requireUser bool
 
 	return a, nil
 }
+
+// IsDemoClient returns whether the given client ID is one of the
+// configured demo clients, whose tokens are read-only. Such clients
+// may only request read scopes, and their tokens are marked read-only
+// so that they can't be used for writes even if a write scope slips
+// through; see the ReadOnlyCheck middleware.
+func IsDemoClient(clientID string) bool {
+	return slices.Contains(config.GetAdvancedOAuthDemoClientIDs(), clientID)
+}
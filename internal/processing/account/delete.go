@@ -51,6 +51,13 @@ func (p *Processor) Delete(
 	}...)
 	l.Trace("beginning account delete process")
 
+	if origin != account.ID {
+		// Account is being deleted by an admin, or as
+		// a side effect of a domain block, rather than
+		// by itself, so mark the context accordingly.
+		ctx = gtscontext.SetModeration(ctx)
+	}
+
 	// Delete statuses *before* follows to ensure correct addressing
 	// of any outgoing fedi messages generated by deleting statuses.
 	if err := p.deleteAccountStatuses(ctx, account); err != nil {
@@ -95,8 +102,8 @@ func (p *Processor) Delete(
 	return nil
 }
 
-// deleteUserAndTokensForAccount deletes the gtsmodel.User and
-// any OAuth tokens and applications for the given account.
+// deleteUserAndTokensForAccount deletes the gtsmodel.User and any
+// OAuth tokens, consents and applications for the given account.
 //
 // Callers to this function should already have checked that
 // this is a local account, or else it won't have a user associated
@@ -129,6 +136,10 @@ func (p *Processor) deleteUserAndTokensForAccount(ctx context.Context, account *
 		}
 	}
 
+	if err := p.state.DB.DeleteConsentsByUserID(ctx, user.ID); err != nil {
+		return gtserror.Newf("db error deleting consents: %w", err)
+	}
+
 	columns, err := stubbifyUser(user)
 	if err != nil {
 		return gtserror.Newf("error stubbifying user: %w", err)
@@ -502,7 +513,10 @@ func stubbifyAccount(account *gtsmodel.Account, origin string) []string {
 	account.Note = ""
 	account.NoteRaw = ""
 	account.Memorial = util.Ptr(false)
+	account.FeaturedTagIDs = nil
+	account.FeaturedTags = nil
 	account.AlsoKnownAsURIs = nil
+	account.PreviousUsernames = nil
 	account.MovedToURI = ""
 	account.Discoverable = util.Ptr(false)
 	account.SuspendedAt = now
@@ -520,7 +534,9 @@ func stubbifyAccount(account *gtsmodel.Account, origin string) []string {
 		"note",
 		"note_raw",
 		"memorial",
+		"featured_tags",
 		"also_known_as_uris",
+		"previous_usernames",
 		"moved_to_uri",
 		"discoverable",
 		"suspended_at",
@@ -0,0 +1,201 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// FeaturedTagsGet returns the hashtags featured on the profile of
+// the target account, in the order they were featured. Requesting
+// account may be nil, for unauthenticated (eg., web) requests.
+func (p *Processor) FeaturedTagsGet(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	targetAccountID string,
+) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(errors.New("account not found"))
+		}
+		err := gtserror.Newf("db error getting account %s: %w", targetAccountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	visible, err := p.filter.AccountVisible(ctx, requestingAccount, targetAccount)
+	if err != nil {
+		err := gtserror.Newf("error checking account visibility: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !visible {
+		return nil, gtserror.NewErrorNotFound(errors.New("account not found"))
+	}
+
+	return p.featuredTagsToAPI(ctx, targetAccount), nil
+}
+
+// FeaturedTagCreate features the hashtag with the given
+// name on the profile of the requesting account, creating
+// the hashtag if it doesn't exist yet.
+func (p *Processor) FeaturedTagCreate(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	name string,
+) (*apimodel.FeaturedTag, gtserror.WithCode) {
+	// Normalize + validate tag name
+	// the same way as in statuses.
+	nameNormal, ok := text.NormalizeHashtag(name)
+	if !ok {
+		const text = "name must be a valid hashtag"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	tag, err := p.state.DB.GetTagByName(ctx, nameNormal)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tag %s: %w", nameNormal, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if tag == nil {
+		// We don't have this
+		// tag yet, create it.
+		tag = &gtsmodel.Tag{
+			ID:   id.NewULID(),
+			Name: nameNormal,
+		}
+
+		if err := p.state.DB.PutTag(ctx, tag); err != nil {
+			err := gtserror.Newf("db error putting tag %s: %w", nameNormal, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if tag.Useable != nil && !*tag.Useable {
+		const text = "this hashtag cannot be used on this instance"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if slices.Contains(requestingAccount.FeaturedTagIDs, tag.ID) {
+		const text = "this hashtag is already featured"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	featuredTagIDs := append(slices.Clone(requestingAccount.FeaturedTagIDs), tag.ID)
+	if err := validate.FeaturedTags(featuredTagIDs); err != nil {
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if errWithCode := p.updateFeaturedTags(ctx, requestingAccount, featuredTagIDs); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiFeaturedTag, err := p.converter.FeaturedTagToAPIFeaturedTag(ctx, requestingAccount, tag)
+	if err != nil {
+		err := gtserror.Newf("error converting featured tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiFeaturedTag, nil
+}
+
+// FeaturedTagDelete stops featuring the hashtag with
+// the given ID on the requesting account's profile.
+func (p *Processor) FeaturedTagDelete(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	tagID string,
+) gtserror.WithCode {
+	if !slices.Contains(requestingAccount.FeaturedTagIDs, tagID) {
+		err := gtserror.Newf("tag %s not featured by account %s", tagID, requestingAccount.ID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	featuredTagIDs := slices.DeleteFunc(
+		slices.Clone(requestingAccount.FeaturedTagIDs),
+		func(id string) bool { return id == tagID },
+	)
+
+	return p.updateFeaturedTags(ctx, requestingAccount, featuredTagIDs)
+}
+
+// updateFeaturedTags stores the given featured tag IDs on the
+// account, and federates the change out to the account's
+// followers so they know to refetch its featured tags.
+func (p *Processor) updateFeaturedTags(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	featuredTagIDs []string,
+) gtserror.WithCode {
+	account.FeaturedTagIDs = featuredTagIDs
+	account.FeaturedTags = nil
+
+	if err := p.state.DB.UpdateAccount(ctx, account, "featured_tags"); err != nil {
+		err := gtserror.Newf("db error updating account %s: %w", account.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Repopulate the featured tags.
+	if err := p.state.DB.PopulateAccount(ctx, account); err != nil {
+		log.Errorf(ctx, "error repopulating account %s: %v", account.ID, err)
+	}
+
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       account,
+		Origin:         account,
+	})
+
+	return nil
+}
+
+// featuredTagsToAPI converts the featured tags of the
+// given account to their API representation, skipping
+// (and logging) any tags that can't be converted.
+func (p *Processor) featuredTagsToAPI(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) []*apimodel.FeaturedTag {
+	apiFeaturedTags := make([]*apimodel.FeaturedTag, 0, len(account.FeaturedTags))
+	for _, tag := range account.FeaturedTags {
+		apiFeaturedTag, err := p.converter.FeaturedTagToAPIFeaturedTag(ctx, account, tag)
+		if err != nil {
+			log.Errorf(ctx, "error converting featured tag %s: %v", tag.ID, err)
+			continue
+		}
+
+		apiFeaturedTags = append(apiFeaturedTags, apiFeaturedTag)
+	}
+
+	return apiFeaturedTags
+}
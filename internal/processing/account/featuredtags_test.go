@@ -0,0 +1,169 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type FeaturedTagsTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *FeaturedTagsTestSuite) TestFeaturedTagCreate() {
+	var (
+		ctx            = context.Background()
+		testAccount    = &gtsmodel.Account{}
+		testAccountCpy = suite.testAccounts["admin_account"]
+	)
+	*testAccount = *testAccountCpy
+
+	// Feature a hashtag the account has used.
+	featuredTag, errWithCode := suite.accountProcessor.FeaturedTagCreate(ctx, testAccount, "#Welcome")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal("01F8MHA1A2NF9MJ3WCCQ3K8BSZ", featuredTag.ID)
+	suite.Equal("welcome", featuredTag.Name)
+	suite.Equal("http://localhost:8080/tags/welcome", featuredTag.URL)
+	suite.Equal(1, featuredTag.StatusesCount)
+	suite.NotNil(featuredTag.LastStatusAt)
+
+	// Feature a hashtag that doesn't exist yet.
+	featuredTag, errWithCode = suite.accountProcessor.FeaturedTagCreate(ctx, testAccount, "gardening")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal("gardening", featuredTag.Name)
+	suite.Zero(featuredTag.StatusesCount)
+	suite.Nil(featuredTag.LastStatusAt)
+
+	// Both should now be featured, in order.
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{"01F8MHA1A2NF9MJ3WCCQ3K8BSZ", featuredTag.ID}, dbAccount.FeaturedTagIDs)
+
+	featuredTags, errWithCode := suite.accountProcessor.FeaturedTagsGet(ctx, nil, testAccount.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(featuredTags, 2)
+	suite.Equal("welcome", featuredTags[0].Name)
+	suite.Equal("gardening", featuredTags[1].Name)
+}
+
+func (suite *FeaturedTagsTestSuite) TestFeaturedTagCreateInvalid() {
+	var (
+		ctx            = context.Background()
+		testAccount    = &gtsmodel.Account{}
+		testAccountCpy = suite.testAccounts["local_account_1"]
+	)
+	*testAccount = *testAccountCpy
+
+	for _, name := range []string{
+		"",
+		"#",
+		"not a hashtag",
+		"#hash#tag",
+	} {
+		_, errWithCode := suite.accountProcessor.FeaturedTagCreate(ctx, testAccount, name)
+		if suite.NotNil(errWithCode, name) {
+			suite.Equal(http.StatusBadRequest, errWithCode.Code(), name)
+		}
+	}
+}
+
+func (suite *FeaturedTagsTestSuite) TestFeaturedTagCreateDuplicate() {
+	var (
+		ctx            = context.Background()
+		testAccount    = &gtsmodel.Account{}
+		testAccountCpy = suite.testAccounts["local_account_1"]
+	)
+	*testAccount = *testAccountCpy
+
+	if _, errWithCode := suite.accountProcessor.FeaturedTagCreate(ctx, testAccount, "welcome"); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode := suite.accountProcessor.FeaturedTagCreate(ctx, testAccount, "#WELCOME")
+	suite.EqualError(errWithCode, "this hashtag is already featured")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *FeaturedTagsTestSuite) TestFeaturedTagCreateTooMany() {
+	var (
+		ctx            = context.Background()
+		testAccount    = &gtsmodel.Account{}
+		testAccountCpy = suite.testAccounts["local_account_1"]
+	)
+	*testAccount = *testAccountCpy
+
+	for _, name := range []string{
+		"one", "two", "three", "four", "five",
+		"six", "seven", "eight", "nine", "ten",
+	} {
+		if _, errWithCode := suite.accountProcessor.FeaturedTagCreate(ctx, testAccount, name); errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+	}
+
+	_, errWithCode := suite.accountProcessor.FeaturedTagCreate(ctx, testAccount, "eleven")
+	suite.EqualError(errWithCode, "cannot have more than 10 featured tags")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *FeaturedTagsTestSuite) TestFeaturedTagDelete() {
+	var (
+		ctx            = context.Background()
+		testAccount    = &gtsmodel.Account{}
+		testAccountCpy = suite.testAccounts["local_account_1"]
+	)
+	*testAccount = *testAccountCpy
+
+	featuredTag, errWithCode := suite.accountProcessor.FeaturedTagCreate(ctx, testAccount, "welcome")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if errWithCode := suite.accountProcessor.FeaturedTagDelete(ctx, testAccount, featuredTag.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbAccount.FeaturedTagIDs)
+
+	// Deleting again should 404.
+	errWithCode = suite.accountProcessor.FeaturedTagDelete(ctx, testAccount, featuredTag.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestFeaturedTagsTestSuite(t *testing.T) {
+	suite.Run(t, new(FeaturedTagsTestSuite))
+}
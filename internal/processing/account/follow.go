@@ -111,10 +111,11 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 		return nil, errWithCode
 	}
 
-	// For unlocked accounts on the same instance,
-	// we can already optimistically show the follow
-	// request as accepted in the returned relationship.
-	if targetAccount.IsLocal() && !*targetAccount.Locked {
+	// For accounts on the same instance that don't need
+	// to approve this follow request, we can already
+	// optimistically show the follow request as accepted
+	// in the returned relationship.
+	if targetAccount.IsLocal() && !targetAccount.FollowNeedsApproval(requestingAccount) {
 		rel.Requested = false
 		rel.Following = true
 		rel.ShowingReblogs = util.PtrValueOr(fr.ShowReblogs, true)
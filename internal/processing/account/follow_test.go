@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -158,6 +159,68 @@ func (suite *FollowTestSuite) TestFollowRequestLocal() {
 	suite.Equal(targetAccount.ID, cMsg.Target.ID)
 }
 
+func (suite *FollowTestSuite) TestFollowRequestLocalAutoApprove() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	// Have turtle auto-approve established accounts,
+	// which local accounts are trusted to be by default.
+	settings := new(gtsmodel.AccountSettings)
+	*settings = *targetAccount.Settings
+	settings.AutoApproveFollowsAfter = 100 * 365 * 24 * time.Hour
+	if err := suite.state.DB.UpdateAccountSettings(ctx, settings, "auto_approve_follows_after"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	rel, errWithCode := suite.accountProcessor.FollowCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountFollowRequest{ID: targetAccount.ID},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(rel.Following)
+	suite.False(rel.Requested)
+}
+
+func (suite *FollowTestSuite) TestFollowRequestLocalReviewLocal() {
+	ctx := context.Background()
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	// Make admin a brand new account, so that
+	// it's not established like it is by default.
+	requestingAccount := new(gtsmodel.Account)
+	*requestingAccount = *suite.testAccounts["admin_account"]
+	requestingAccount.CreatedAt = time.Now()
+	if err := suite.state.DB.UpdateAccount(ctx, requestingAccount, "created_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Have turtle auto-approve established
+	// accounts, but review local accounts
+	// by age too instead of trusting them.
+	settings := new(gtsmodel.AccountSettings)
+	*settings = *targetAccount.Settings
+	settings.AutoApproveFollowsAfter = time.Hour
+	settings.ReviewLocalFollows = util.Ptr(true)
+	if err := suite.state.DB.UpdateAccountSettings(ctx, settings, "auto_approve_follows_after", "review_local_follows"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	rel, errWithCode := suite.accountProcessor.FollowCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountFollowRequest{ID: targetAccount.ID},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.False(rel.Following)
+	suite.True(rel.Requested)
+}
+
 func TestFollowTestS(t *testing.T) {
 	suite.Run(t, new(FollowTestSuite))
 }
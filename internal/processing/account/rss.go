@@ -19,8 +19,10 @@ package account
 
 import (
 	"context"
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gorilla/feeds"
@@ -28,6 +30,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 const (
@@ -50,23 +54,9 @@ func (p *Processor) GetRSSFeedForUsername(ctx context.Context, username string)
 		never = time.Time{}
 	)
 
-	account, err := p.state.DB.GetAccountByUsernameDomain(ctx, username, "")
-	if err != nil {
-		if errors.Is(err, db.ErrNoEntries) {
-			// Simply no account with this username.
-			err = gtserror.New("account not found")
-			return nil, never, gtserror.NewErrorNotFound(err)
-		}
-
-		// Real db error.
-		err = gtserror.Newf("db error getting account %s: %w", username, err)
-		return nil, never, gtserror.NewErrorInternalError(err)
-	}
-
-	// Ensure account has rss feed enabled.
-	if !*account.Settings.EnableRSS {
-		err = gtserror.New("account RSS feed not enabled")
-		return nil, never, gtserror.NewErrorNotFound(err)
+	account, errWithCode := p.getRSSAccount(ctx, username)
+	if errWithCode != nil {
+		return nil, never, errWithCode
 	}
 
 	// Ensure account stats populated.
@@ -139,6 +129,143 @@ func (p *Processor) GetRSSFeedForUsername(ctx context.Context, username string)
 	}, lastPostAt, nil
 }
 
+// GetRSSFeedForUsernameTag is like GetRSSFeedForUsername, but returns a function
+// to return an RSS feed of only those posts of the local account with the given
+// username which use the given hashtag, and a last-modified time of the latest
+// such post.
+//
+// Tag feeds are not available for accounts that hide their collections.
+func (p *Processor) GetRSSFeedForUsernameTag(ctx context.Context, username string, tagName string) (GetRSSFeed, time.Time, gtserror.WithCode) {
+	var (
+		never = time.Time{}
+	)
+
+	account, errWithCode := p.getRSSAccount(ctx, username)
+	if errWithCode != nil {
+		return nil, never, errWithCode
+	}
+
+	// Tag feeds expose a collection of the account's
+	// posts, so respect account's choice to hide those.
+	if *account.Settings.HideCollections {
+		err := gtserror.New("account collections hidden")
+		return nil, never, gtserror.NewErrorNotFound(err)
+	}
+
+	// Normalize + validate tag name.
+	tagNameNormal, ok := text.NormalizeHashtag(tagName)
+	if !ok {
+		err := gtserror.Newf("string '%s' could not be normalized to a valid hashtag", tagName)
+		return nil, never, gtserror.NewErrorNotFound(err)
+	}
+
+	// Tags are stored lowercase, so use the same
+	// for feed title + self link, whatever the
+	// case of the tag as given in the request.
+	tagName = strings.ToLower(tagNameNormal)
+
+	tag, err := p.state.DB.GetTagByName(ctx, tagName)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting tag %s: %w", tagName, err)
+		return nil, never, gtserror.NewErrorInternalError(err)
+	}
+
+	var statuses []*gtsmodel.Status
+
+	if tag != nil {
+		// Retrieve latest web-visible statuses of the account using this tag.
+		statuses, err = p.state.DB.GetAccountWebStatusesByTag(ctx, account.ID, tag.ID, rssFeedLength, "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting account web statuses by tag: %w", err)
+			return nil, never, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// LastModified time is the time of the latest post using this tag.
+	// Like with account feeds, this may be zero if there's no such post.
+	var lastPostAt time.Time
+	if len(statuses) > 0 {
+		lastPostAt = statuses[0].CreatedAt
+	}
+
+	return func() (string, gtserror.WithCode) {
+		// Assemble author namestring once only.
+		author := "@" + account.Username + "@" + config.GetAccountDomain()
+
+		// Derive image/thumbnail for this account (may be nil).
+		image, errWithCode := p.rssImageForAccount(ctx, account, author)
+		if errWithCode != nil {
+			return "", errWithCode
+		}
+
+		title := "Posts from " + author + " tagged #" + tagName
+		feed := &feeds.Feed{
+			Title:       title,
+			Description: title,
+			Link:        &feeds.Link{Href: account.URL},
+			Image:       image,
+		}
+
+		// As with account feeds, use account creation
+		// time as Updated value for an empty feed, to
+		// keep the output determinate for cacheing.
+		if lastPostAt.IsZero() {
+			feed.Updated = account.CreatedAt
+		} else {
+			feed.Updated = lastPostAt
+		}
+
+		// Add each status to the rss feed.
+		for _, status := range statuses {
+			item, err := p.converter.StatusToRSSItem(ctx, status)
+			if err != nil {
+				err = gtserror.Newf("error converting status to feed item: %w", err)
+				return "", gtserror.NewErrorInternalError(err)
+			}
+
+			feed.Add(item)
+		}
+
+		// Self link is the URL of this tag feed.
+		self := account.URL + "/tags/" + tagName + "/feed.rss"
+
+		return stringifyFeedWithSelf(feed, self)
+	}, lastPostAt, nil
+}
+
+// getRSSAccount gets the local account with the given
+// username, ensuring that it has its RSS feed enabled.
+func (p *Processor) getRSSAccount(ctx context.Context, username string) (*gtsmodel.Account, gtserror.WithCode) {
+	account, err := p.state.DB.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Simply no account with this username.
+			err = gtserror.New("account not found")
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+
+		// Real db error.
+		err = gtserror.Newf("db error getting account %s: %w", username, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Ensure account has rss feed enabled.
+	if !*account.Settings.EnableRSS {
+		err = gtserror.New("account RSS feed not enabled")
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	// Feeds can't be restricted to followers,
+	// so they're off while the web profile is
+	// hidden, regardless of the setting above.
+	if util.PtrValueOr(account.Settings.HideWebProfile, false) {
+		err = gtserror.New("account web profile hidden")
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return account, nil
+}
+
 func (p *Processor) rssImageForAccount(ctx context.Context, account *gtsmodel.Account, author string) (*feeds.Image, gtserror.WithCode) {
 	if account.AvatarMediaAttachmentID == "" {
 		// No image, no problem!
@@ -179,3 +306,56 @@ func stringifyFeed(feed *feeds.Feed) (string, gtserror.WithCode) {
 
 	return rss, nil
 }
+
+func stringifyFeedWithSelf(feed *feeds.Feed, self string) (string, gtserror.WithCode) {
+	// gorilla/feeds doesn't support including an atom
+	// self link in rss feeds, so wrap the rss channel
+	// ourselves in order to include one.
+	rss, err := feeds.ToXML(&rssFeedXML{
+		Version:          "2.0",
+		ContentNamespace: "http://purl.org/rss/1.0/modules/content/",
+		AtomNamespace:    "http://www.w3.org/2005/Atom",
+		Channel: &rssChannel{
+			AtomLink: &rssAtomLink{
+				Href: self,
+				Rel:  "self",
+				Type: "application/rss+xml",
+			},
+			RssFeed: (&feeds.Rss{Feed: feed}).RssFeed(),
+		},
+	})
+	if err != nil {
+		err := gtserror.Newf("error converting feed to rss string: %w", err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	return rss, nil
+}
+
+// rssFeedXML is equivalent to feeds.RssFeedXml,
+// but with the atom namespace, and a channel
+// that can include an atom self link.
+type rssFeedXML struct {
+	XMLName          xml.Name `xml:"rss"`
+	Version          string   `xml:"version,attr"`
+	ContentNamespace string   `xml:"xmlns:content,attr"`
+	AtomNamespace    string   `xml:"xmlns:atom,attr"`
+	Channel          *rssChannel
+}
+
+// FeedXml implements feeds.XmlFeed.
+func (r *rssFeedXML) FeedXml() interface{} {
+	return r
+}
+
+type rssChannel struct {
+	AtomLink *rssAtomLink
+	*feeds.RssFeed
+}
+
+type rssAtomLink struct {
+	XMLName xml.Name `xml:"atom:link"`
+	Href    string   `xml:"href,attr"`
+	Rel     string   `xml:"rel,attr"`
+	Type    string   `xml:"type,attr"`
+}
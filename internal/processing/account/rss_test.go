@@ -19,9 +19,11 @@ package account_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type GetRSSTestSuite struct {
@@ -48,6 +50,63 @@ func (suite *GetRSSTestSuite) TestGetAccountRSSZork() {
 	suite.Equal("<?xml version=\"1.0\" encoding=\"UTF-8\"?><rss version=\"2.0\" xmlns:content=\"http://purl.org/rss/1.0/modules/content/\">\n  <channel>\n    <title>Posts from @the_mighty_zork@localhost:8080</title>\n    <link>http://localhost:8080/@the_mighty_zork</link>\n    <description>Posts from @the_mighty_zork@localhost:8080</description>\n    <pubDate>Sun, 10 Dec 2023 09:24:00 +0000</pubDate>\n    <lastBuildDate>Sun, 10 Dec 2023 09:24:00 +0000</lastBuildDate>\n    <image>\n      <url>http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/small/01F8MH58A357CV5K7R7TJMSH6S.jpg</url>\n      <title>Avatar for @the_mighty_zork@localhost:8080</title>\n      <link>http://localhost:8080/@the_mighty_zork</link>\n    </image>\n    <item>\n      <title>HTML in post</title>\n      <link>http://localhost:8080/@the_mighty_zork/statuses/01HH9KYNQPA416TNJ53NSATP40</link>\n      <description>@the_mighty_zork@localhost:8080 made a new post: &#34;Here&#39;s a bunch of HTML, read it and weep, weep then!&#xA;&#xA;```html&#xA;&lt;section class=&#34;about-user&#34;&gt;&#xA; &lt;div class=&#34;col-header&#34;&gt;&#xA; &lt;h2&gt;About&lt;/h2&gt;&#xA; &lt;/div&gt; &#xA; &lt;div class=&#34;fields&#34;&gt;&#xA; &lt;h3 class=&#34;sr-only&#34;&gt;Fields&lt;/h3&gt;&#xA; &lt;dl&gt;&#xA;...</description>\n      <content:encoded><![CDATA[<p>Here's a bunch of HTML, read it and weep, weep then!</p><pre><code class=\"language-html\">&lt;section class=&#34;about-user&#34;&gt;\n    &lt;div class=&#34;col-header&#34;&gt;\n        &lt;h2&gt;About&lt;/h2&gt;\n    &lt;/div&gt;            \n    &lt;div class=&#34;fields&#34;&gt;\n        &lt;h3 class=&#34;sr-only&#34;&gt;Fields&lt;/h3&gt;\n        &lt;dl&gt;\n            &lt;div class=&#34;field&#34;&gt;\n                &lt;dt&gt;should you follow me?&lt;/dt&gt;\n                &lt;dd&gt;maybe!&lt;/dd&gt;\n            &lt;/div&gt;\n            &lt;div class=&#34;field&#34;&gt;\n                &lt;dt&gt;age&lt;/dt&gt;\n                &lt;dd&gt;120&lt;/dd&gt;\n            &lt;/div&gt;\n        &lt;/dl&gt;\n    &lt;/div&gt;\n    &lt;div class=&#34;bio&#34;&gt;\n        &lt;h3 class=&#34;sr-only&#34;&gt;Bio&lt;/h3&gt;\n        &lt;p&gt;i post about things that concern me&lt;/p&gt;\n    &lt;/div&gt;\n    &lt;div class=&#34;sr-only&#34; role=&#34;group&#34;&gt;\n        &lt;h3 class=&#34;sr-only&#34;&gt;Stats&lt;/h3&gt;\n        &lt;span&gt;Joined in Jun, 2022.&lt;/span&gt;\n        &lt;span&gt;8 posts.&lt;/span&gt;\n        &lt;span&gt;Followed by 1.&lt;/span&gt;\n        &lt;span&gt;Following 1.&lt;/span&gt;\n    &lt;/div&gt;\n    &lt;div class=&#34;accountstats&#34; aria-hidden=&#34;true&#34;&gt;\n        &lt;b&gt;Joined&lt;/b&gt;&lt;time datetime=&#34;2022-06-04T13:12:00.000Z&#34;&gt;Jun, 2022&lt;/time&gt;\n        &lt;b&gt;Posts&lt;/b&gt;&lt;span&gt;8&lt;/span&gt;\n        &lt;b&gt;Followed by&lt;/b&gt;&lt;span&gt;1&lt;/span&gt;\n        &lt;b&gt;Following&lt;/b&gt;&lt;span&gt;1&lt;/span&gt;\n    &lt;/div&gt;\n&lt;/section&gt;\n</code></pre><p>There, hope you liked that!</p>]]></content:encoded>\n      <author>@the_mighty_zork@localhost:8080</author>\n      <guid isPermaLink=\"true\">http://localhost:8080/@the_mighty_zork/statuses/01HH9KYNQPA416TNJ53NSATP40</guid>\n      <pubDate>Sun, 10 Dec 2023 09:24:00 +0000</pubDate>\n      <source>http://localhost:8080/@the_mighty_zork/feed.rss</source>\n    </item>\n    <item>\n      <title>introduction post</title>\n      <link>http://localhost:8080/@the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY</link>\n      <description>@the_mighty_zork@localhost:8080 made a new post: &#34;hello everyone!&#34;</description>\n      <content:encoded><![CDATA[hello everyone!]]></content:encoded>\n      <author>@the_mighty_zork@localhost:8080</author>\n      <guid isPermaLink=\"true\">http://localhost:8080/@the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY</guid>\n      <pubDate>Wed, 20 Oct 2021 10:40:37 +0000</pubDate>\n      <source>http://localhost:8080/@the_mighty_zork/feed.rss</source>\n    </item>\n  </channel>\n</rss>", feed)
 }
 
+func (suite *GetRSSTestSuite) TestGetAccountRSSAdminTag() {
+	getFeed, lastModified, err := suite.accountProcessor.GetRSSFeedForUsernameTag(context.Background(), "admin", "Welcome")
+	suite.NoError(err)
+	suite.EqualValues(1634729805, lastModified.Unix())
+
+	feed, err := getFeed()
+	suite.NoError(err)
+	suite.Contains(feed, `xmlns:atom="http://www.w3.org/2005/Atom"`)
+	suite.Contains(feed, `<atom:link href="http://localhost:8080/@admin/tags/welcome/feed.rss" rel="self" type="application/rss+xml"></atom:link>`)
+	suite.Contains(feed, `<title>Posts from @admin@localhost:8080 tagged #welcome</title>`)
+	suite.Contains(feed, `<link>http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R</link>`)
+
+	// Untagged post should not be included.
+	suite.NotContains(feed, "01F8MHAAY43M6RJ473VQFCVH37")
+}
+
+func (suite *GetRSSTestSuite) TestGetAccountRSSAdminTagNoPosts() {
+	getFeed, lastModified, err := suite.accountProcessor.GetRSSFeedForUsernameTag(context.Background(), "admin", "hashtag")
+	suite.NoError(err)
+	suite.Empty(lastModified)
+
+	feed, err := getFeed()
+	suite.NoError(err)
+	suite.Contains(feed, `<title>Posts from @admin@localhost:8080 tagged #hashtag</title>`)
+	suite.NotContains(feed, "<item>")
+}
+
+func (suite *GetRSSTestSuite) TestGetAccountRSSAdminTagHideCollections() {
+	ctx := context.Background()
+
+	// Hide admin's collections.
+	settings := suite.testAccounts["admin_account"].Settings
+	settings.HideCollections = util.Ptr(true)
+	if err := suite.db.UpdateAccountSettings(ctx, settings, "hide_collections"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, _, errWithCode := suite.accountProcessor.GetRSSFeedForUsernameTag(ctx, "admin", "welcome")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *GetRSSTestSuite) TestGetAccountRSSAdminHideWebProfile() {
+	ctx := context.Background()
+
+	// Hide admin's web profile.
+	settings := suite.testAccounts["admin_account"].Settings
+	settings.HideWebProfile = util.Ptr(true)
+	if err := suite.db.UpdateAccountSettings(ctx, settings, "hide_web_profile"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// RSS is still enabled,
+	// but feed is gone anyway.
+	_, _, errWithCode := suite.accountProcessor.GetRSSFeedForUsername(ctx, "admin")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
 func (suite *GetRSSTestSuite) TestGetAccountRSSZorkNoPosts() {
 	ctx := context.Background()
 
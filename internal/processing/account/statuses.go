@@ -137,6 +137,59 @@ func (p *Processor) StatusesGet(
 	})
 }
 
+// WebProfileCheck checks whether the web profile (and web statuses)
+// of the given local account may be shown to the requesting account,
+// which is nil for visitors who aren't logged in. Accounts that hide
+// their web profile are only shown to themselves and their followers.
+//
+// Returns nil if the profile may be shown, else an error with code 401
+// for visitors who aren't logged in, or 403 for logged in non-followers.
+func (p *Processor) WebProfileCheck(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	targetAccountID string,
+) gtserror.WithCode {
+	account, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.New("account not found")
+			return gtserror.NewErrorNotFound(err)
+		}
+		err := gtserror.Newf("db error getting account %s: %w", targetAccountID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if !util.PtrValueOr(account.Settings.HideWebProfile, false) {
+		// Shown to everyone.
+		return nil
+	}
+
+	const text = "this profile is only visible to followers who are logged in"
+
+	if requestingAccount == nil {
+		err := gtserror.Newf("web profile of %s hidden from visitors", account.Username)
+		return gtserror.NewErrorUnauthorized(err, text)
+	}
+
+	if requestingAccount.ID == account.ID {
+		// Always shown to self.
+		return nil
+	}
+
+	following, err := p.state.DB.IsFollowing(ctx, requestingAccount.ID, account.ID)
+	if err != nil {
+		err := gtserror.Newf("db error checking follow: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if !following {
+		err := gtserror.Newf("web profile of %s hidden from non-followers", account.Username)
+		return gtserror.NewErrorForbidden(err, text)
+	}
+
+	return nil
+}
+
 // WebStatusesGet fetches a number of statuses (in descending order)
 // from the given account. It selects only statuses which are suitable
 // for showing on the public web profile of an account.
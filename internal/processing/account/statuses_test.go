@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type WebProfileTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *WebProfileTestSuite) TestWebProfileCheck() {
+	ctx := context.Background()
+	admin := suite.testAccounts["admin_account"]
+
+	// Shown to everyone by default.
+	suite.Nil(suite.accountProcessor.WebProfileCheck(ctx, nil, admin.ID))
+
+	// Hide admin's web profile.
+	admin.Settings.HideWebProfile = util.Ptr(true)
+	if err := suite.db.UpdateAccountSettings(ctx, admin.Settings, "hide_web_profile"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Visitors who aren't logged in are asked to.
+	errWithCode := suite.accountProcessor.WebProfileCheck(ctx, nil, admin.ID)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	// Logged in non-followers are turned away.
+	errWithCode = suite.accountProcessor.WebProfileCheck(ctx, suite.testAccounts["local_account_2"], admin.ID)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// Admin themself, and zork
+	// who follows admin, can see.
+	suite.Nil(suite.accountProcessor.WebProfileCheck(ctx, admin, admin.ID))
+	suite.Nil(suite.accountProcessor.WebProfileCheck(ctx, suite.testAccounts["local_account_1"], admin.ID))
+}
+
+func TestWebProfileTestSuite(t *testing.T) {
+	suite.Run(t, new(WebProfileTestSuite))
+}
@@ -23,11 +23,14 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"time"
 
 	"codeberg.org/gruf/go-bytesize"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -95,6 +98,17 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		emojisChanged = true
 	}
 
+	if form.Pronouns != nil {
+		// Parse new pronouns (always from plaintext).
+		// Empty string unsets.
+		pronouns := text.SanitizeToPlaintext(*form.Pronouns)
+		if err := validate.Pronouns(pronouns); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		account.Pronouns = pronouns
+	}
+
 	if form.FieldsAttributes != nil {
 		var (
 			fieldsAttributes = *form.FieldsAttributes
@@ -137,6 +151,36 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		emojisChanged = true
 	}
 
+	if form.Source != nil {
+		// The note is formatted according to the note content
+		// type, so if that changes, it needs formatting again.
+		noteContentType := account.Settings.NoteContentType()
+
+		if form.Source.StatusContentType != nil {
+			if err := validate.StatusContentType(*form.Source.StatusContentType); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			account.Settings.StatusContentType = *form.Source.StatusContentType
+		}
+
+		if form.Source.BioContentType != nil {
+			// Empty string unsets.
+			if *form.Source.BioContentType != "" {
+				if err := validate.StatusContentType(*form.Source.BioContentType); err != nil {
+					return nil, gtserror.NewErrorBadRequest(err, err.Error())
+				}
+			}
+
+			account.Settings.BioContentType = *form.Source.BioContentType
+		}
+
+		if account.Settings.NoteContentType() != noteContentType {
+			// Formatting the note may also change its emojis.
+			emojisChanged = true
+		}
+	}
+
 	if emojisChanged {
 		// Use map to deduplicate emojis by their ID.
 		emojis := make(map[string]*gtsmodel.Emoji)
@@ -153,7 +197,7 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		}
 
 		// Format + set note according to user prefs.
-		f := p.selectNoteFormatter(account.Settings.StatusContentType)
+		f := p.selectNoteFormatter(account.Settings.NoteContentType())
 		formatNoteResult := f(ctx, p.parseMention, account.ID, "", account.NoteRaw)
 		account.Note = formatNoteResult.HTML
 
@@ -249,6 +293,10 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 			account.Settings.Sensitive = form.Source.Sensitive
 		}
 
+		if form.Source.DefaultMediaSensitive != nil {
+			account.Settings.DefaultMediaSensitive = form.Source.DefaultMediaSensitive
+		}
+
 		if form.Source.Privacy != nil {
 			if err := validate.Privacy(*form.Source.Privacy); err != nil {
 				return nil, gtserror.NewErrorBadRequest(err)
@@ -257,12 +305,115 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 			account.Settings.Privacy = privacy
 		}
 
-		if form.Source.StatusContentType != nil {
-			if err := validate.StatusContentType(*form.Source.StatusContentType); err != nil {
+		if form.Source.ReplyPrivacy != nil {
+			// Empty string unsets.
+			var replyPrivacy gtsmodel.Visibility
+			if *form.Source.ReplyPrivacy != "" {
+				if err := validate.Privacy(*form.Source.ReplyPrivacy); err != nil {
+					return nil, gtserror.NewErrorBadRequest(err, err.Error())
+				}
+				replyPrivacy = typeutils.APIVisToVis(apimodel.Visibility(*form.Source.ReplyPrivacy))
+			}
+			account.Settings.ReplyPrivacy = replyPrivacy
+		}
+
+		if form.Source.DefaultContentWarning != nil {
+			if err := validate.DefaultContentWarning(*form.Source.DefaultContentWarning); err != nil {
 				return nil, gtserror.NewErrorBadRequest(err, err.Error())
 			}
 
-			account.Settings.StatusContentType = *form.Source.StatusContentType
+			account.Settings.DefaultContentWarning = *form.Source.DefaultContentWarning
+		}
+
+		if form.Source.DefaultPostExpiry != nil {
+			expiry := *form.Source.DefaultPostExpiry
+			if expiry != 0 {
+				if err := validate.StatusExpiry("default_post_expiry", expiry); err != nil {
+					return nil, gtserror.NewErrorBadRequest(err, err.Error())
+				}
+			}
+
+			account.Settings.DefaultPostExpiry = time.Duration(expiry) * time.Second
+		}
+
+		if form.Source.AutoApproveFollowsAfter != nil {
+			after := *form.Source.AutoApproveFollowsAfter
+			if err := validate.AutoApproveFollowsAfter(after); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			account.Settings.AutoApproveFollowsAfter = time.Duration(after) * time.Second
+		}
+
+		if form.Source.ReviewLocalFollows != nil {
+			account.Settings.ReviewLocalFollows = form.Source.ReviewLocalFollows
+		}
+
+		if form.Source.AwayEnabled != nil {
+			account.Settings.AwayEnabled = form.Source.AwayEnabled
+		}
+
+		if form.Source.AwayMessage != nil {
+			if err := validate.AwayMessage(*form.Source.AwayMessage); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			account.Settings.AwayMessage = *form.Source.AwayMessage
+		}
+
+		if form.Source.AwayStartsAt != nil {
+			startsAt, err := parseAwayTime("away_starts_at", *form.Source.AwayStartsAt)
+			if err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			account.Settings.AwayStartsAt = startsAt
+		}
+
+		if form.Source.AwayEndsAt != nil {
+			endsAt, err := parseAwayTime("away_ends_at", *form.Source.AwayEndsAt)
+			if err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			account.Settings.AwayEndsAt = endsAt
+		}
+
+		if err := validate.AwayWindow(
+			account.Settings.AwayStartsAt,
+			account.Settings.AwayEndsAt,
+		); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		if form.Source.AwayAutoReply != nil {
+			account.Settings.AwayAutoReply = form.Source.AwayAutoReply
+		}
+
+		if form.Source.RequireMediaDescriptions != nil {
+			account.Settings.RequireMediaDescriptions = form.Source.RequireMediaDescriptions
+		}
+
+		if form.Source.DetectLanguage != nil {
+			account.Settings.DetectLanguage = form.Source.DetectLanguage
+		}
+
+		if form.Source.LocalOnly != nil {
+			account.Settings.LocalOnly = form.Source.LocalOnly
+		}
+
+		if form.Source.ContinueThread != nil {
+			account.Settings.ContinueThread = form.Source.ContinueThread
+		}
+
+		if form.Source.RotatingPinStatusIDs != nil || form.Source.RotatingPinInterval != nil {
+			if errWithCode := p.updateRotatingPin(ctx,
+				account,
+				form.Source.RotatingPinStatusIDs,
+				form.Source.RotatingPinInterval,
+			); errWithCode != nil {
+				return nil, errWithCode
+			}
 		}
 	}
 
@@ -298,6 +449,10 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		account.Settings.HideCollections = form.HideCollections
 	}
 
+	if form.HideWebProfile != nil {
+		account.Settings.HideWebProfile = form.HideWebProfile
+	}
+
 	if err := p.state.DB.UpdateAccount(ctx, account); err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("could not update account %s: %s", account.ID, err))
 	}
@@ -320,6 +475,73 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 	return acctSensitive, nil
 }
 
+// updateRotatingPin validates and sets the rotating pin settings of
+// the given account, from the status IDs and interval (in seconds)
+// in an update form, either of which may be nil if unchanged. Every
+// status must be one of the account's own that can be pinned.
+func (p *Processor) updateRotatingPin(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	statusIDs *[]string,
+	interval *int,
+) gtserror.WithCode {
+	ids := account.Settings.RotatingPinStatusIDs
+	if statusIDs != nil {
+		ids = *statusIDs
+	}
+
+	seconds := int(account.Settings.RotatingPinInterval / time.Second)
+	if interval != nil {
+		seconds = *interval
+	}
+
+	if err := validate.RotatingPin(ids, seconds); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if statusIDs != nil {
+		for _, id := range ids {
+			status, err := p.state.DB.GetStatusByID(gtscontext.SetBarebones(ctx), id)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				err := gtserror.Newf("db error getting status %s: %w", id, err)
+				return gtserror.NewErrorInternalError(err)
+			}
+
+			if status == nil ||
+				status.AccountID != account.ID ||
+				status.Visibility == gtsmodel.VisibilityDirect ||
+				status.Visibility == gtsmodel.VisibilityCircle ||
+				status.BoostOfID != "" {
+				err := fmt.Errorf("status %s in rotating_pin_status_ids is not one of your statuses that can be pinned", id)
+				return gtserror.NewErrorBadRequest(err, err.Error())
+			}
+		}
+	}
+
+	account.Settings.RotatingPinStatusIDs = ids
+	account.Settings.RotatingPinInterval = time.Duration(seconds) * time.Second
+	return nil
+}
+
+// parseAwayTime parses the given ISO 8601 / RFC 3339 away
+// mode timestamp, where an empty string unsets the time.
+func parseAwayTime(field string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := util.ParseISO8601(value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an ISO 8601 timestamp: %w", field, err)
+	}
+
+	return t.UTC(), nil
+}
+
 // UpdateAvatar does the dirty work of checking the avatar
 // part of an account update form, parsing and checking the
 // media, and doing the necessary updates in the database
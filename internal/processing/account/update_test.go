@@ -19,13 +19,16 @@ package account_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type AccountUpdateTestSuite struct {
@@ -331,6 +334,251 @@ func (suite *AccountUpdateTestSuite) TestAccountUpdateNoteNotFields() {
 	suite.Equal(fieldsBefore, len(dbAccount.Fields))
 }
 
+func (suite *AccountUpdateTestSuite) TestAccountUpdateDefaultContentWarning() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	// Copy zork's settings.
+	settings := &gtsmodel.AccountSettings{}
+	*settings = *suite.testAccounts["local_account_1"].Settings
+	testAccount.Settings = settings
+
+	var (
+		ctx            = context.Background()
+		contentWarning = "long post"
+	)
+
+	// Call update function.
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			DefaultContentWarning: &contentWarning,
+		},
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Returned source should be updated.
+	suite.Equal(contentWarning, apiAccount.Source.DefaultContentWarning)
+
+	// Check database model of account settings as well.
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(contentWarning, dbAccount.Settings.DefaultContentWarning)
+
+	// Unset it again with an empty string.
+	contentWarning = ""
+	apiAccount, errWithCode = suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			DefaultContentWarning: &contentWarning,
+		},
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiAccount.Source.DefaultContentWarning)
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateDefaultPostExpiry() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	// Copy zork's settings.
+	settings := &gtsmodel.AccountSettings{}
+	*settings = *suite.testAccounts["local_account_1"].Settings
+	testAccount.Settings = settings
+
+	ctx := context.Background()
+
+	// Call update function.
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			DefaultPostExpiry: util.Ptr(86400),
+		},
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Returned source should be updated.
+	suite.Equal(86400, apiAccount.Source.DefaultPostExpiry)
+
+	// Check database model of account settings as well.
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(24*time.Hour, dbAccount.Settings.DefaultPostExpiry)
+
+	// Expiry below the configured minimum should be rejected.
+	_, errWithCode = suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			DefaultPostExpiry: util.Ptr(60),
+		},
+	})
+	suite.EqualError(errWithCode, "default_post_expiry must be between 300 and 31536000 seconds, provided value was 60")
+
+	// Unset it again with 0.
+	apiAccount, errWithCode = suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			DefaultPostExpiry: util.Ptr(0),
+		},
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Zero(apiAccount.Source.DefaultPostExpiry)
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateBioContentType() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	// Copy zork's settings.
+	settings := &gtsmodel.AccountSettings{}
+	*settings = *suite.testAccounts["local_account_1"].Settings
+	testAccount.Settings = settings
+
+	var (
+		ctx  = context.Background()
+		note = "*hello* here i am!"
+	)
+
+	// Write the note while it still follows
+	// the (plain) status content type.
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		Note: &note,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(`<p>*hello* here i am!</p>`, apiAccount.Note)
+	suite.Empty(apiAccount.Source.BioContentType)
+
+	// Switching just the bio content type
+	// should format the existing note again.
+	apiAccount, errWithCode = suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			BioContentType: util.Ptr("text/markdown"),
+		},
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(`<p><em>hello</em> here i am!</p>`, apiAccount.Note)
+	suite.Equal("text/markdown", apiAccount.Source.BioContentType)
+	suite.Equal("text/plain", apiAccount.Source.StatusContentType)
+
+	// Check database model of account as well.
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("text/markdown", dbAccount.Settings.BioContentType)
+	suite.Equal(`<p><em>hello</em> here i am!</p>`, dbAccount.Note)
+
+	// Unset it again with an empty string,
+	// so the note follows the status content
+	// type, changed to markdown at the same time.
+	apiAccount, errWithCode = suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			BioContentType:    util.Ptr(""),
+			StatusContentType: util.Ptr("text/markdown"),
+		},
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(`<p><em>hello</em> here i am!</p>`, apiAccount.Note)
+	suite.Empty(apiAccount.Source.BioContentType)
+
+	// A plain bio overrides the markdown
+	// status content type, and formats the
+	// note again as plain.
+	apiAccount, errWithCode = suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			BioContentType: util.Ptr("text/plain"),
+		},
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(`<p>*hello* here i am!</p>`, apiAccount.Note)
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateBioContentTypeNotAllowed() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	// Copy zork's settings.
+	settings := &gtsmodel.AccountSettings{}
+	*settings = *suite.testAccounts["local_account_1"].Settings
+	testAccount.Settings = settings
+
+	// Only allow plain statuses on this instance.
+	config.SetStatusesContentTypes([]string{"text/plain"})
+
+	_, errWithCode := suite.accountProcessor.Update(context.Background(), testAccount, &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			BioContentType: util.Ptr("text/markdown"),
+		},
+	})
+	suite.EqualError(errWithCode, "status content type 'text/markdown' is not allowed on this instance, allowed options are 'text/plain'")
+
+	_, errWithCode = suite.accountProcessor.Update(context.Background(), testAccount, &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			BioContentType: util.Ptr("text/html"),
+		},
+	})
+	suite.EqualError(errWithCode, "status content type 'text/html' was not recognized, valid options are 'text/plain', 'text/markdown'")
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdatePronouns() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	ctx := context.Background()
+
+	// Pronouns should be sanitized to plaintext.
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		Pronouns: util.Ptr(" <strong>he/they</strong> "),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("he/they", apiAccount.Pronouns)
+
+	// Check database model of account as well.
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("he/they", dbAccount.Pronouns)
+
+	// Too long pronouns should be rejected.
+	_, errWithCode = suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		Pronouns: util.Ptr(strings.Repeat("they/them", 10)),
+	})
+	suite.EqualError(errWithCode, "pronouns must be less than 64 characters, but submitted pronouns were 90 characters")
+
+	// Unset pronouns with an empty string.
+	apiAccount, errWithCode = suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		Pronouns: util.Ptr(""),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiAccount.Pronouns)
+
+	dbAccount, err = suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbAccount.Pronouns)
+}
+
 func TestAccountUpdateTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountUpdateTestSuite))
 }
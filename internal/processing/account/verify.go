@@ -0,0 +1,289 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"golang.org/x/net/html"
+)
+
+const (
+	// fieldVerifyEvery is how often the
+	// profile fields of all local accounts
+	// are verified again, in case the linked
+	// pages have gained or lost a link back.
+	fieldVerifyEvery = 24 * time.Hour
+
+	// fieldVerifyTimeout is the maximum time
+	// to spend fetching a single field's page,
+	// including following any redirects.
+	fieldVerifyTimeout = 30 * time.Second
+
+	// fieldVerifyMaxBody is the maximum amount
+	// of a field's page that will be parsed
+	// when looking for a rel=me link back.
+	fieldVerifyMaxBody = 1024 * 1024
+)
+
+// ScheduleFieldVerification schedules the profile fields
+// of all local accounts to be verified again regularly.
+func (p *Processor) ScheduleFieldVerification() error {
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@fieldverification",
+		time.Now().Add(fieldVerifyEvery),
+		fieldVerifyEvery,
+		p.verifyAllFields,
+	) {
+		return gtserror.New("failed to schedule @fieldverification")
+	}
+	return nil
+}
+
+// verifyAllFields verifies the profile fields of all local accounts.
+func (p *Processor) verifyAllFields(ctx context.Context, start time.Time) {
+	log.Info(ctx, "starting profile field verification")
+
+	users, err := p.state.DB.GetAllUsers(ctx)
+	if err != nil {
+		log.Errorf(ctx, "error getting users: %v", err)
+		return
+	}
+
+	for _, user := range users {
+		if user.Account == nil {
+			continue
+		}
+
+		if err := p.VerifyFields(ctx, user.Account); err != nil {
+			log.Errorf(ctx, "error verifying fields of account %s: %v", user.AccountID, err)
+		}
+	}
+
+	log.Infof(ctx, "finished profile field verification after %s", time.Since(start))
+}
+
+// VerifyFields checks each profile field of the given local account
+// that contains a link, by fetching the linked page and looking for
+// a rel="me" link back to the account's profile, as Mastodon does.
+// The VerifiedAt time of each field is updated accordingly, and the
+// account's fields are stored if any of these have changed.
+func (p *Processor) VerifyFields(ctx context.Context, account *gtsmodel.Account) error {
+	if account.IsRemote() || len(account.Fields) == 0 {
+		// Only local accounts with
+		// fields need verifying.
+		return nil
+	}
+
+	// Linked pages are fetched
+	// as the instance account.
+	tsport, err := p.federator.TransportController().NewTransportForUsername(ctx, "")
+	if err != nil {
+		return gtserror.Newf("error getting instance transport: %w", err)
+	}
+
+	var changed bool
+	for i, field := range account.Fields {
+		// Fields are formatted from the raw
+		// fields, which contain the plain link.
+		if i >= len(account.FieldsRaw) {
+			break
+		}
+
+		verified := false
+		if link := fieldLink(account.FieldsRaw[i].Value); link != nil {
+			verified, err = p.verifyFieldLink(ctx, tsport, account, link)
+			if err != nil {
+				log.Debugf(ctx, "error verifying field link %s: %v", link, err)
+			}
+		}
+
+		switch {
+		case verified && field.VerifiedAt.IsZero():
+			// Newly verified.
+			field.VerifiedAt = time.Now()
+			changed = true
+
+		case !verified && !field.VerifiedAt.IsZero():
+			// No longer verified.
+			field.VerifiedAt = time.Time{}
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := p.state.DB.UpdateAccount(ctx, account, "fields"); err != nil {
+		return gtserror.Newf("error updating account fields: %w", err)
+	}
+
+	return nil
+}
+
+// verifyFieldLink fetches the page at the given link,
+// and returns whether it links back to the account.
+func (p *Processor) verifyFieldLink(
+	ctx context.Context,
+	tsport transport.Transport,
+	account *gtsmodel.Account,
+	link *url.URL,
+) (bool, error) {
+	ctx, cncl := context.WithTimeout(gtscontext.SetFastFail(ctx), fieldVerifyTimeout)
+	defer cncl()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.String(), nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "text/html")
+
+	// Redirects are followed by the
+	// transport's underlying client.
+	rsp, err := tsport.GET(req)
+	if err != nil {
+		return false, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return false, gtserror.Newf("unexpected status %s", rsp.Status)
+	}
+
+	if ct := rsp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		return false, gtserror.Newf("unexpected content type %s", ct)
+	}
+
+	// Relative links are relative to the
+	// final page fetched, after redirects.
+	base := link
+	if rsp.Request != nil {
+		base = rsp.Request.URL
+	}
+
+	return linksBackTo(
+		io.LimitReader(rsp.Body, fieldVerifyMaxBody),
+		base,
+		account.URL,
+		account.URI,
+	), nil
+}
+
+// fieldLink returns the given raw profile field
+// value as a URL if it's an http(s) link, else nil.
+func fieldLink(value string) *url.URL {
+	value = strings.TrimSpace(value)
+	if strings.ContainsAny(value, " \t\n") {
+		// Not just a link.
+		return nil
+	}
+
+	link, err := url.Parse(value)
+	if err != nil || link.Host == "" {
+		return nil
+	}
+
+	if link.Scheme != "https" && link.Scheme != "http" {
+		return nil
+	}
+
+	return link
+}
+
+// linksBackTo returns whether the HTML document read from
+// r contains an <a> or <link> element with rel="me", and an
+// href (resolved against base) matching one of the targets.
+func linksBackTo(r io.Reader, base *url.URL, targets ...string) bool {
+	tokenizer := html.NewTokenizer(r)
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			// Reached end of
+			// document (or limit).
+			return false
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if token.Data != "a" && token.Data != "link" {
+				continue
+			}
+
+			var rel, href string
+			for _, attr := range token.Attr {
+				switch attr.Key {
+				case "rel":
+					rel = attr.Val
+				case "href":
+					href = attr.Val
+				}
+			}
+
+			if !relMe(rel) || href == "" {
+				continue
+			}
+
+			link, err := base.Parse(href)
+			if err != nil {
+				continue
+			}
+
+			for _, target := range targets {
+				if sameLink(link, target) {
+					return true
+				}
+			}
+		}
+	}
+}
+
+// relMe returns whether the given
+// rel attribute value includes "me".
+func relMe(rel string) bool {
+	for _, value := range strings.Fields(rel) {
+		if strings.EqualFold(value, "me") {
+			return true
+		}
+	}
+	return false
+}
+
+// sameLink returns whether the given link points to the
+// target URL, ignoring case of scheme and host, any
+// trailing slash, and any query or fragment.
+func sameLink(link *url.URL, target string) bool {
+	t, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(link.Scheme, t.Scheme) &&
+		strings.EqualFold(link.Host, t.Host) &&
+		strings.TrimSuffix(link.Path, "/") == strings.TrimSuffix(t.Path, "/")
+}
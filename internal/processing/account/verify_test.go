@@ -0,0 +1,134 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type VerifyTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// processorWithPages returns an account processor which
+// fetches pages from the given map of URL -> HTML content.
+func (suite *VerifyTestSuite) processorWithPages(pages map[string]string) *account.Processor {
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		page, ok := pages[req.URL.String()]
+		if !ok {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Status:     http.StatusText(http.StatusNotFound),
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+				Request:    req,
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Header:     http.Header{"Content-Type": {"text/html; charset=utf-8"}},
+			Body:       io.NopCloser(bytes.NewReader([]byte(page))),
+			Request:    req,
+		}, nil
+	}, "")
+
+	transportController := testrig.NewTestTransportController(&suite.state, httpClient)
+	federator := testrig.NewTestFederator(&suite.state, transportController, suite.mediaManager)
+	filter := visibility.NewFilter(&suite.state)
+	common := common.New(&suite.state, suite.mediaManager, suite.tc, federator, filter)
+
+	processor := account.New(&common, &suite.state, suite.tc, suite.mediaManager, federator, filter, processing.GetParseMentionFunc(&suite.state, federator))
+	return &processor
+}
+
+func (suite *VerifyTestSuite) TestVerifyFields() {
+	ctx := context.Background()
+
+	testAccount := new(gtsmodel.Account)
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	testAccount.FieldsRaw = []*gtsmodel.Field{
+		{Name: "links back", Value: "https://example.org/about"},
+		{Name: "links elsewhere", Value: "https://example.org/other"},
+		{Name: "not found", Value: "https://example.org/missing"},
+		{Name: "not a link", Value: "she/her"},
+	}
+	testAccount.Fields = []*gtsmodel.Field{
+		{Name: "links back", Value: "<a>https://example.org/about</a>"},
+		{Name: "links elsewhere", Value: "<a>https://example.org/other</a>"},
+		{Name: "not found", Value: "<a>https://example.org/missing</a>"},
+		{Name: "not a link", Value: "she/her"},
+	}
+
+	processor := suite.processorWithPages(map[string]string{
+		"https://example.org/about": `<html><body>` +
+			`<a href="https://example.org/">home</a>` +
+			`<a rel="noopener me" href="` + testAccount.URL + `/">me on fedi</a>` +
+			`</body></html>`,
+		"https://example.org/other": `<html><head>` +
+			`<link rel="me" href="https://elsewhere.example.org/@someone">` +
+			`</head></html>`,
+	})
+
+	if err := processor.VerifyFields(ctx, testAccount); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.Len(dbAccount.Fields, 4) {
+		suite.False(dbAccount.Fields[0].VerifiedAt.IsZero())
+		suite.True(dbAccount.Fields[1].VerifiedAt.IsZero())
+		suite.True(dbAccount.Fields[2].VerifiedAt.IsZero())
+		suite.True(dbAccount.Fields[3].VerifiedAt.IsZero())
+	}
+
+	// Page no longer links back,
+	// so verification is removed.
+	processor = suite.processorWithPages(map[string]string{})
+
+	if err := processor.VerifyFields(ctx, testAccount); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbAccount, err = suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbAccount.Fields[0].VerifiedAt.IsZero())
+}
+
+func TestVerifyTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifyTestSuite))
+}
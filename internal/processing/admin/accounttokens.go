@@ -0,0 +1,134 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// AccountTokensGet returns the sessions (ie., active access
+// tokens) of the user of the given local account, newest first.
+// This works for suspended accounts too, as their user remains.
+func (p *Processor) AccountTokensGet(
+	ctx context.Context,
+	accountID string,
+) ([]*apimodel.Session, gtserror.WithCode) {
+	user, errWithCode := p.accountUser(ctx, accountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	tokens, err := p.state.DB.GetTokensByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tokens: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSessions := make([]*apimodel.Session, 0, len(tokens))
+	for _, token := range tokens {
+		app, err := p.state.DB.GetApplicationByClientID(ctx, token.ClientID)
+		if err != nil {
+			log.Errorf(ctx, "error getting application for token %s: %v", token.ID, err)
+			continue
+		}
+
+		apiSession, err := p.converter.TokenToAPISession(ctx, token, app, "")
+		if err != nil {
+			log.Errorf(ctx, "error converting token %s: %v", token.ID, err)
+			continue
+		}
+
+		apiSessions = append(apiSessions, apiSession)
+	}
+
+	return apiSessions, nil
+}
+
+// AccountTokensRevoke revokes all tokens (access and refresh
+// tokens, and authorization codes) of the user of the given
+// local account at once, logging them out everywhere.
+//
+// Unlike most account actions this is done straight away,
+// rather than in the background, and can't conflict with
+// another action running for the account (eg., a suspension).
+// It's recorded as a completed admin action, whose ID is returned.
+func (p *Processor) AccountTokensRevoke(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	accountID string,
+) (string, gtserror.WithCode) {
+	user, errWithCode := p.accountUser(ctx, accountID)
+	if errWithCode != nil {
+		return "", errWithCode
+	}
+
+	action := &gtsmodel.AdminAction{
+		ID:             id.NewULID(),
+		TargetCategory: gtsmodel.AdminActionCategoryAccount,
+		TargetID:       accountID,
+		Type:           gtsmodel.AdminActionRevokeTokens,
+		AccountID:      adminAcct.ID,
+	}
+
+	revoked, err := p.state.DB.DeleteTokensByUserID(ctx, user.ID)
+	if err != nil {
+		// Record the failure, as
+		// other actions would do.
+		action.Errors = []string{err.Error()}
+	}
+	action.Text = fmt.Sprintf("revoked %d session(s)", revoked)
+	action.CompletedAt = time.Now()
+
+	if err := p.state.DB.PutAdminAction(ctx, action); err != nil {
+		log.Errorf(ctx, "db error putting admin action %s: %v", action.Key(), err)
+	}
+
+	if len(action.Errors) != 0 {
+		err := gtserror.Newf("db error deleting tokens: %s", action.Errors[0])
+		return action.ID, gtserror.NewErrorInternalError(err)
+	}
+
+	return action.ID, nil
+}
+
+// accountUser returns the user of the given
+// local account, or 404 if there isn't one.
+func (p *Processor) accountUser(ctx context.Context, accountID string) (*gtsmodel.User, gtserror.WithCode) {
+	user, err := p.state.DB.GetUserByAccountID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting user for account id %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if user == nil {
+		err := fmt.Errorf("user for account %s not found", accountID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return user, nil
+}
@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type AccountTokensTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountTokensTestSuite) TestAccountTokensGet() {
+	var (
+		ctx        = context.Background()
+		targetAcct = suite.testAccounts["local_account_1"]
+	)
+
+	sessions, errWithCode := suite.adminProcessor.AccountTokensGet(ctx, targetAcct.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Only the access token is a session,
+	// not the authorization code token.
+	suite.Len(sessions, 1)
+	suite.Equal(suite.testTokens["local_account_1"].ID, sessions[0].ID)
+
+	// Nothing is current from an admin's view.
+	suite.False(sessions[0].Current)
+}
+
+func (suite *AccountTokensTestSuite) TestAccountTokensRevoke() {
+	var (
+		ctx        = context.Background()
+		adminAcct  = suite.testAccounts["admin_account"]
+		targetAcct = suite.testAccounts["local_account_1"]
+		targetUser = suite.testUsers["local_account_1"]
+		token      = suite.testTokens["local_account_1"]
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountTokensRevoke(ctx, adminAcct, targetAcct.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// All of the user's tokens should be gone.
+	tokens, _ := suite.state.DB.GetTokensByUserID(ctx, targetUser.ID)
+	suite.Empty(tokens)
+
+	// The old access token should no longer work.
+	_, err := suite.state.DB.GetTokenByAccess(ctx, token.Access)
+	suite.Error(err)
+
+	// Other users' tokens should be untouched.
+	_, err = suite.state.DB.GetTokenByAccess(ctx, suite.testTokens["local_account_2"].Access)
+	suite.NoError(err)
+
+	// The revocation should have been recorded.
+	action, err := suite.state.DB.GetAdminAction(ctx, actionID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.AdminActionRevokeTokens, action.Type)
+	suite.Equal(targetAcct.ID, action.TargetID)
+	suite.Equal(adminAcct.ID, action.AccountID)
+	suite.False(action.CompletedAt.IsZero())
+	suite.Empty(action.Errors)
+}
+
+func (suite *AccountTokensTestSuite) TestAccountTokensRevokeRemoteAccount() {
+	var (
+		ctx        = context.Background()
+		adminAcct  = suite.testAccounts["admin_account"]
+		targetAcct = suite.testAccounts["remote_account_1"]
+	)
+
+	_, errWithCode := suite.adminProcessor.AccountTokensRevoke(ctx, adminAcct, targetAcct.ID)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestAccountTokensTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountTokensTestSuite))
+}
@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DomainSensitivesGet returns all domain sensitive entries stored on this instance.
+func (p *Processor) DomainSensitivesGet(ctx context.Context) ([]*apimodel.DomainSensitive, gtserror.WithCode) {
+	sensitives, err := p.state.DB.GetDomainSensitives(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting domain sensitives: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSensitives := make([]*apimodel.DomainSensitive, len(sensitives))
+	for i := range sensitives {
+		apiSensitives[i] = toAPIDomainSensitive(sensitives[i])
+	}
+
+	return apiSensitives, nil
+}
+
+// DomainSensitiveGet returns one domain sensitive entry, with the given ID.
+func (p *Processor) DomainSensitiveGet(ctx context.Context, id string) (*apimodel.DomainSensitive, gtserror.WithCode) {
+	sensitive, errWithCode := p.getDomainSensitive(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return toAPIDomainSensitive(sensitive), nil
+}
+
+// DomainSensitiveCreate marks media from the requested domain as sensitive,
+// authored by the given admin account. If an entry already exists for the
+// domain, the existing entry is returned.
+func (p *Processor) DomainSensitiveCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.DomainSensitiveRequest,
+) (*apimodel.DomainSensitive, gtserror.WithCode) {
+	if form.Domain == "" {
+		const text = "domain must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	domain, err := util.Punify(form.Domain)
+	if err != nil {
+		const text = "domain is not valid"
+		return nil, gtserror.NewErrorBadRequest(err, text)
+	}
+
+	if domain == config.GetHost() || domain == config.GetAccountDomain() {
+		const text = "cannot mark media from this instance as sensitive"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Check if an entry already exists for this domain.
+	sensitive, err := p.state.DB.GetDomainSensitive(ctx, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting domain sensitive %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if sensitive != nil {
+		// Nothing to do.
+		return toAPIDomainSensitive(sensitive), nil
+	}
+
+	sensitive = &gtsmodel.DomainSensitive{
+		ID:                 id.NewULID(),
+		Domain:             domain,
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+		PrivateComment:     text.SanitizeToPlaintext(form.PrivateComment),
+	}
+
+	if err := p.state.DB.CreateDomainSensitive(ctx, sensitive); err != nil {
+		err := gtserror.Newf("db error putting domain sensitive %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPIDomainSensitive(sensitive), nil
+}
+
+// DomainSensitiveDelete removes the domain sensitive
+// entry with the given ID, returning the removed entry.
+func (p *Processor) DomainSensitiveDelete(ctx context.Context, id string) (*apimodel.DomainSensitive, gtserror.WithCode) {
+	sensitive, errWithCode := p.getDomainSensitive(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteDomainSensitive(ctx, sensitive.Domain); err != nil {
+		err := gtserror.Newf("db error deleting domain sensitive %s: %w", sensitive.Domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPIDomainSensitive(sensitive), nil
+}
+
+// getDomainSensitive fetches the domain sensitive
+// entry with the given ID, returning 404 if not found.
+func (p *Processor) getDomainSensitive(ctx context.Context, id string) (*gtsmodel.DomainSensitive, gtserror.WithCode) {
+	sensitive, err := p.state.DB.GetDomainSensitiveByID(ctx, id)
+	switch {
+	case err == nil:
+		return sensitive, nil
+
+	case errors.Is(err, db.ErrNoEntries):
+		const text = "domain sensitive entry not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+
+	default:
+		err := gtserror.Newf("db error getting domain sensitive %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+}
+
+// toAPIDomainSensitive performs a simple conversion of database model DomainSensitive to API model.
+func toAPIDomainSensitive(sensitive *gtsmodel.DomainSensitive) *apimodel.DomainSensitive {
+	return &apimodel.DomainSensitive{
+		ID:             sensitive.ID,
+		Domain:         sensitive.Domain,
+		PrivateComment: sensitive.PrivateComment,
+		CreatedBy:      sensitive.CreatedByAccountID,
+		CreatedAt:      util.FormatISO8601(sensitive.CreatedAt),
+	}
+}
@@ -19,24 +19,62 @@ package processing
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"codeberg.org/gruf/go-debug"
 	"github.com/google/uuid"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
+const (
+	// Max length of dynamic client
+	// registration metadata fields.
+	registerFieldLen    = 1024
+	registerRedirectLen = 2056
+
+	grantTypeAuthorizationCode = "authorization_code"
+	grantTypeClientCredentials = "client_credentials"
+	responseTypeCode           = "code"
+	authMethodClientSecretPost = "client_secret_post"
+	authMethodNone             = "none"
+)
+
 func (p *Processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ApplicationCreateRequest) (*apimodel.Application, gtserror.WithCode) {
 	// set default 'read' for scopes if it's not set
 	var scopes string
 	if form.Scopes == "" {
-		scopes = "read"
+		scopes = oauth.ScopeDefault
 	} else {
 		scopes = form.Scopes
 	}
 
+	// App-only tokens can't be
+	// wider than the app itself.
+	if form.ClientCredentialsScopes != "" &&
+		!oauth.ScopesSubset(form.ClientCredentialsScopes, scopes) {
+		const text = "client_credentials_scopes must be within the scopes of the application"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Redirect uris are matched exactly on authorization,
+	// so reject any that could never match sensibly. Unlike
+	// dynamic client registration, localhost has always been
+	// allowed here, since it's how native apps tend to work.
+	for _, redirectURI := range oauth.ParseRedirectURIs(form.RedirectURIs) {
+		if err := oauth.ValidateRedirectURI(redirectURI, true); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
 	// generate new IDs for this application and its associated client
 	clientID, err := id.NewRandomULID()
 	if err != nil {
@@ -67,11 +105,14 @@ func (p *Processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *api
 
 	// now we need to model an oauth client from the application that the oauth library can use
 	oc := &gtsmodel.Client{
-		ID:     clientID,
-		Secret: clientSecret,
-		Domain: form.RedirectURIs,
+		ID:           clientID,
+		Secret:       clientSecret,
+		RedirectURIs: oauth.ParseRedirectURIs(form.RedirectURIs),
 		// This client isn't yet associated with a specific user,  it's just an app client right now
-		UserID: "",
+		UserID:                  "",
+		ClientCredentialsScopes: form.ClientCredentialsScopes,
+		AccessTokenTTL:          time.Duration(form.AccessTokenLifetime) * time.Second,
+		RefreshTokenTTL:         time.Duration(form.RefreshTokenLifetime) * time.Second,
 	}
 
 	// chuck it in the db
@@ -86,3 +127,174 @@ func (p *Processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *api
 
 	return apiApp, nil
 }
+
+// AppRegister handles a dynamic client registration request as per
+// RFC 7591, creating a new application + oauth client from the given
+// client metadata, and returning the registered client's information.
+//
+// Errors are returned with the relevant RFC 7591 error codes, ready
+// to be passed to apiutil.OAuthErrorHandler.
+func (p *Processor) AppRegister(ctx context.Context, form *apimodel.OAuthClientRegistrationRequest) (*apimodel.OAuthClientRegistration, gtserror.WithCode) {
+	if errWithCode := validateClientRegistration(form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// generate new IDs + secrets for this application and its associated client
+	clientID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	clientSecret := uuid.NewString()
+
+	appID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Multiple redirect URIs are stored
+	// newline-separated on the application.
+	redirectURIs := strings.Join(form.RedirectURIs, "\n")
+
+	now := time.Now()
+	app := &gtsmodel.Application{
+		ID:           appID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Name:         form.ClientName,
+		Website:      form.ClientURI,
+		RedirectURI:  redirectURIs,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       form.Scope,
+	}
+
+	if err := p.state.DB.PutApplication(ctx, app); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	oc := &gtsmodel.Client{
+		ID:           clientID,
+		Secret:       clientSecret,
+		RedirectURIs: form.RedirectURIs,
+		// This client isn't yet associated with a specific user,  it's just an app client right now
+		UserID: "",
+	}
+
+	if err := p.state.DB.PutClient(ctx, oc); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.OAuthClientRegistration{
+		ClientID:              clientID,
+		ClientSecret:          clientSecret,
+		ClientIDIssuedAt:      now.Unix(),
+		ClientSecretExpiresAt: 0,
+		RedirectURIs:          form.RedirectURIs,
+		ResponseTypes:         form.ResponseTypes,
+		ClientName:            form.ClientName,
+		ClientURI:             form.ClientURI,
+		Scope:                 form.Scope,
+	}, nil
+}
+
+// validateClientRegistration validates the given dynamic
+// client registration request, setting defaults on it for
+// any optional metadata that wasn't provided.
+func validateClientRegistration(form *apimodel.OAuthClientRegistrationRequest) gtserror.WithCode {
+	invalidMetadata := func(text string) gtserror.WithCode {
+		return gtserror.NewErrorBadRequest(oauth.ErrInvalidClientMetadata, text)
+	}
+
+	invalidRedirect := func(text string) gtserror.WithCode {
+		return gtserror.NewErrorBadRequest(oauth.ErrInvalidRedirectURI, text)
+	}
+
+	if len(form.RedirectURIs) == 0 {
+		return invalidRedirect("at least one redirect uri must be provided")
+	}
+
+	// Localhost redirects are only permitted
+	// in debug builds, or if explicitly allowed.
+	allowLocalhost := debug.DEBUG ||
+		config.GetAdvancedOAuthRegistrationAllowLocalhost()
+
+	for _, redirectURI := range form.RedirectURIs {
+		if len([]rune(redirectURI)) > registerRedirectLen {
+			text := fmt.Sprintf("redirect uris must be less than %d characters", registerRedirectLen)
+			return invalidRedirect(text)
+		}
+
+		if err := oauth.ValidateRedirectURI(redirectURI, allowLocalhost); err != nil {
+			return invalidRedirect(err.Error())
+		}
+	}
+
+	if len(form.GrantTypes) == 0 {
+		form.GrantTypes = []string{grantTypeAuthorizationCode}
+	}
+
+	for _, grantType := range form.GrantTypes {
+		switch grantType {
+		case grantTypeAuthorizationCode, grantTypeClientCredentials:
+			// Supported.
+		default:
+			text := fmt.Sprintf("grant type %s not supported", grantType)
+			return invalidMetadata(text)
+		}
+	}
+
+	if len(form.ResponseTypes) == 0 {
+		form.ResponseTypes = []string{responseTypeCode}
+	}
+
+	for _, responseType := range form.ResponseTypes {
+		if responseType != responseTypeCode {
+			text := fmt.Sprintf("response type %s not supported", responseType)
+			return invalidMetadata(text)
+		}
+	}
+
+	if slices.Contains(form.GrantTypes, grantTypeAuthorizationCode) !=
+		slices.Contains(form.ResponseTypes, responseTypeCode) {
+		// See https://datatracker.ietf.org/doc/html/rfc7591#section-2.1
+		return invalidMetadata("authorization_code grant type and code response type must be used together")
+	}
+
+	// Grant types and token endpoint auth method are only
+	// validated, not stored: every client may use each of
+	// the supported grant types, and authenticates at the
+	// token endpoint the same way, so they're not echoed
+	// back as if they'd been registered for this client.
+	switch form.TokenEndpointAuthMethod {
+	case "", authMethodClientSecretPost, authMethodNone:
+		// Supported.
+	default:
+		text := fmt.Sprintf("token endpoint auth method %s not supported", form.TokenEndpointAuthMethod)
+		return invalidMetadata(text)
+	}
+
+	if form.Scope == "" {
+		form.Scope = oauth.ScopeDefault
+	}
+
+	if len([]rune(form.Scope)) > registerFieldLen {
+		text := fmt.Sprintf("scope must be less than %d characters", registerFieldLen)
+		return invalidMetadata(text)
+	}
+
+	if err := oauth.ValidateScopes(form.Scope); err != nil {
+		return invalidMetadata(err.Error())
+	}
+
+	if len([]rune(form.ClientName)) > registerFieldLen {
+		text := fmt.Sprintf("client_name must be less than %d characters", registerFieldLen)
+		return invalidMetadata(text)
+	}
+
+	if len([]rune(form.ClientURI)) > registerFieldLen {
+		text := fmt.Sprintf("client_uri must be less than %d characters", registerFieldLen)
+		return invalidMetadata(text)
+	}
+
+	return nil
+}
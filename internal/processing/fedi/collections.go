@@ -22,14 +22,17 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"slices"
 
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -131,6 +134,13 @@ func (p *Processor) OutboxGet(
 			hi = statuses[0].ID
 		}
 
+		// Drop local-only statuses, which are never
+		// served to remotes. This is done after getting
+		// the page ID values, so paging isn't affected.
+		statuses = slices.DeleteFunc(statuses, func(status *gtsmodel.Status) bool {
+			return !*status.Federated
+		})
+
 		// Start building AS collection page params.
 		params.Total = util.Ptr(*receivingAcct.Stats.StatusesCount)
 		var pageParams ap.CollectionPageParams
@@ -425,6 +435,12 @@ func (p *Processor) FeaturedCollectionGet(ctx context.Context, requestedUser str
 		}
 	}
 
+	// Drop local-only statuses, which
+	// are never served to remotes.
+	statuses = slices.DeleteFunc(statuses, func(status *gtsmodel.Status) bool {
+		return !*status.Federated
+	})
+
 	collection, err := p.converter.StatusesToASFeaturedCollection(ctx, receivingAcct.FeaturedCollectionURI, statuses)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
@@ -437,3 +453,33 @@ func (p *Processor) FeaturedCollectionGet(ctx context.Context, requestedUser str
 
 	return data, nil
 }
+
+// FeaturedTagsCollectionGet returns an ordered collection of the requested username's featured hashtags.
+// The returned collection has an `orderedItems` property which contains an ordered list of Hashtag objects.
+func (p *Processor) FeaturedTagsCollectionGet(ctx context.Context, requestedUser string) (interface{}, gtserror.WithCode) {
+	// Authenticate incoming request, getting related accounts.
+	auth, errWithCode := p.authenticate(ctx, requestedUser)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	receivingAcct := auth.receivingAcct
+
+	tags, err := p.state.DB.GetTags(ctx, receivingAcct.FeaturedTagIDs)
+	if err != nil {
+		err := gtserror.Newf("db error getting featured tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	collectionID := uris.GenerateURIForFeaturedTags(receivingAcct.Username)
+	collection, err := p.converter.TagsToASFeaturedTagsCollection(ctx, collectionID, tags)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	data, err := ap.Serialize(collection)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return data, nil
+}
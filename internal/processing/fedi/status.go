@@ -68,6 +68,11 @@ func (p *Processor) StatusGet(ctx context.Context, requestedUser string, statusI
 		return nil, gtserror.NewErrorNotFound(errors.New(text))
 	}
 
+	if !*status.Federated {
+		const text = "status is local-only"
+		return nil, gtserror.NewErrorNotFound(errors.New(text))
+	}
+
 	visible, err := p.filter.StatusVisible(ctx, requestingAcct, status)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
@@ -140,6 +145,11 @@ func (p *Processor) StatusRepliesGet(
 		return nil, gtserror.NewErrorNotFound(errors.New(text))
 	}
 
+	if !*status.Federated {
+		const text = "status is local-only"
+		return nil, gtserror.NewErrorNotFound(errors.New(text))
+	}
+
 	// Parse replies collection ID from status' URI with onlyOtherAccounts param.
 	onlyOtherAccStr := "only_other_accounts=" + strconv.FormatBool(onlyOtherAccounts)
 	collectionID, err := url.Parse(status.URI + "/replies?" + onlyOtherAccStr)
@@ -155,6 +165,12 @@ func (p *Processor) StatusRepliesGet(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Drop local-only replies, which
+	// are never served to remotes.
+	replies = slices.DeleteFunc(replies, func(reply *gtsmodel.Status) bool {
+		return !*reply.Federated
+	})
+
 	if onlyOtherAccounts {
 		// If 'onlyOtherAccounts' is set, drop all by original status author.
 		replies = slices.DeleteFunc(replies, func(reply *gtsmodel.Status) bool {
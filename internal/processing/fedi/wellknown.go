@@ -20,6 +20,9 @@ package fedi
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -116,19 +119,46 @@ func (p *Processor) HostMetaGet() *apimodel.HostMeta {
 }
 
 // WebfingerGet handles the GET for a webfinger resource. Most commonly, it will be used for returning account lookups.
-func (p *Processor) WebfingerGet(ctx context.Context, requestedUsername string) (*apimodel.WellKnownResponse, gtserror.WithCode) {
+//
+// If any rels are given, only links with a matching rel are included in the response,
+// as per https://www.rfc-editor.org/rfc/rfc7033#section-4.3. The subject and aliases
+// are always included, and if no links match the response will contain no links.
+//
+// Responses are cached by normalized acct: URI. Local usernames are always lowercase,
+// and the requested host has already been checked by the caller, so the key only needs
+// the lowercased username and account domain to cover case and host variants.
+//
+// Also returned is the time the response last changed, for conditional requests.
+func (p *Processor) WebfingerGet(ctx context.Context, requestedUsername string, rels []string) (*apimodel.WellKnownResponse, time.Time, gtserror.WithCode) {
+	key := webfingerAccount + ":" + strings.ToLower(requestedUsername) + "@" + config.GetAccountDomain()
+	if resp, lastModified, ok := p.state.Caches.WebfingerResponse.Get(key); ok {
+		return filterWebfingerLinks(resp, rels), lastModified, nil
+	}
+
 	// Get the local account the request is referring to.
 	requestedAccount, err := p.state.DB.GetAccountByUsernameDomain(ctx, requestedUsername, "")
 	if err != nil {
-		return nil, gtserror.NewErrorNotFound(fmt.Errorf("database error getting account with username %s: %s", requestedUsername, err))
+		return nil, time.Time{}, gtserror.NewErrorNotFound(fmt.Errorf("database error getting account with username %s: %s", requestedUsername, err))
 	}
 
-	return &apimodel.WellKnownResponse{
+	// Include any previous usernames of the account as acct:
+	// aliases, so that remotes which cached an old handle can
+	// still match it up with the account. The subject is always
+	// the canonical, current handle.
+	aliases := []string{
+		requestedAccount.URI,
+		requestedAccount.URL,
+	}
+	for _, username := range requestedAccount.PreviousUsernames {
+		if username == "" || username == requestedAccount.Username {
+			continue
+		}
+		aliases = append(aliases, webfingerAccount+":"+username+"@"+config.GetAccountDomain())
+	}
+
+	resp := &apimodel.WellKnownResponse{
 		Subject: webfingerAccount + ":" + requestedAccount.Username + "@" + config.GetAccountDomain(),
-		Aliases: []string{
-			requestedAccount.URI,
-			requestedAccount.URL,
-		},
+		Aliases: aliases,
 		Links: []apimodel.Link{
 			{
 				Rel:  webfingerProfilePage,
@@ -141,5 +171,37 @@ func (p *Processor) WebfingerGet(ctx context.Context, requestedUsername string)
 				Href: requestedAccount.URI,
 			},
 		},
-	}, nil
+	}
+
+	// The response only depends on the account
+	// and instance config, so the account's last
+	// update time is also when the response was.
+	lastModified := requestedAccount.UpdatedAt
+
+	p.state.Caches.WebfingerResponse.Set(key, requestedAccount.ID, resp, lastModified)
+	return filterWebfingerLinks(resp, rels), lastModified, nil
+}
+
+// filterWebfingerLinks returns a copy of the given
+// webfinger response containing only links with one
+// of the given rels, or the response itself if no rels
+// are given. The response is not modified, as it may
+// be shared with the webfinger response cache.
+func filterWebfingerLinks(resp *apimodel.WellKnownResponse, rels []string) *apimodel.WellKnownResponse {
+	if len(rels) == 0 {
+		return resp
+	}
+
+	links := make([]apimodel.Link, 0, len(resp.Links))
+	for _, link := range resp.Links {
+		if slices.Contains(rels, link.Rel) {
+			links = append(links, link)
+		}
+	}
+
+	return &apimodel.WellKnownResponse{
+		Subject: resp.Subject,
+		Aliases: resp.Aliases,
+		Links:   links,
+	}
 }
@@ -34,6 +34,19 @@ import (
 // Create a new filter and filter keyword for the given account, using the provided parameters.
 // These params should have already been validated by the time they reach this function.
 func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form *apimodel.FilterCreateUpdateRequestV1) (*apimodel.FilterV1, gtserror.WithCode) {
+	apiFilter, errWithCode := p.create(ctx, account, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Send a filters changed event.
+	p.stream.FiltersChanged(ctx, account)
+
+	return apiFilter, nil
+}
+
+// create is like Create, but without sending a filters changed event.
+func (p *Processor) create(ctx context.Context, account *gtsmodel.Account, form *apimodel.FilterCreateUpdateRequestV1) (*apimodel.FilterV1, gtserror.WithCode) {
 	filter := &gtsmodel.Filter{
 		ID:        id.NewULID(),
 		AccountID: account.ID,
@@ -83,13 +96,5 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	apiFilter, errWithCode := p.apiFilter(ctx, filterKeyword)
-	if errWithCode != nil {
-		return nil, errWithCode
-	}
-
-	// Send a filters changed event.
-	p.stream.FiltersChanged(ctx, account)
-
-	return apiFilter, nil
+	return p.apiFilter(ctx, filterKeyword)
 }
@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Import creates v1 filters for the given account in bulk, reporting which
+// were imported, which were skipped as duplicates of an existing filter with
+// the same phrase and context, and which were rejected as invalid. The form
+// filters should have already been validated by the time they reach this
+// function, with any that failed validation carrying the reason why.
+func (p *Processor) Import(ctx context.Context, account *gtsmodel.Account, form *apimodel.FilterImportRequestV1) (*apimodel.FilterImportResultV1, gtserror.WithCode) {
+	existing, errWithCode := p.GetAll(ctx, account)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	seen := make(map[string]struct{}, len(existing)+len(form.Filters))
+	for _, filter := range existing {
+		seen[importKey(filter.Phrase, filter.Context)] = struct{}{}
+	}
+
+	result := &apimodel.FilterImportResultV1{
+		Imported: []*apimodel.FilterV1{},
+		Skipped:  []apimodel.FilterImportEntryResultV1{},
+		Rejected: []apimodel.FilterImportEntryResultV1{},
+	}
+
+	for _, entry := range form.Filters {
+		entryResult := apimodel.FilterImportEntryResultV1{
+			Index:  entry.Index,
+			Phrase: entry.Phrase,
+		}
+
+		if entry.Filter == nil {
+			entryResult.Error = entry.Error
+			result.Rejected = append(result.Rejected, entryResult)
+			continue
+		}
+
+		key := importKey(entry.Filter.Phrase, entry.Filter.Context)
+		if _, ok := seen[key]; ok {
+			entryResult.Error = "filter with this phrase and context already exists"
+			result.Skipped = append(result.Skipped, entryResult)
+			continue
+		}
+
+		apiFilter, errWithCode := p.create(ctx, account, entry.Filter)
+		if errWithCode != nil {
+			if errWithCode.Code() == http.StatusInternalServerError {
+				log.Errorf(ctx, "error importing filter: %v", errWithCode)
+			}
+			entryResult.Error = errWithCode.Safe()
+			result.Rejected = append(result.Rejected, entryResult)
+			continue
+		}
+
+		seen[key] = struct{}{}
+		result.Imported = append(result.Imported, apiFilter)
+	}
+
+	if len(result.Imported) > 0 {
+		// Send a filters changed event.
+		p.stream.FiltersChanged(ctx, account)
+	}
+
+	return result, nil
+}
+
+// importKey returns the key by which imported
+// filters are deduplicated: the phrase, plus
+// the contexts in which it's applied.
+func importKey(phrase string, contexts []apimodel.FilterContext) string {
+	sorted := make([]string, len(contexts))
+	for i, context := range contexts {
+		sorted[i] = string(context)
+	}
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+	return phrase + "\x00" + strings.Join(sorted, ",")
+}
@@ -65,6 +65,11 @@ func (p *Processor) Update(
 	action := gtsmodel.FilterActionWarn
 	if *form.Irreversible {
 		action = gtsmodel.FilterActionHide
+	} else if filter.Action == gtsmodel.FilterActionBlur ||
+		filter.Action == gtsmodel.FilterActionNotify {
+		// v1 filters can't express blur or notify,
+		// so keep them if not irreversible.
+		action = filter.Action
 	}
 	expiresAt := time.Time{}
 	if form.ExpiresIn != nil {
@@ -133,6 +138,8 @@ func (p *Processor) Update(
 	filter.ContextAccount = &contextAccount
 	filterKeyword.Keyword = form.Phrase
 	filterKeyword.WholeWord = util.Ptr(util.PtrValueOr(form.WholeWord, false))
+	// v1 filters only know about literal phrases.
+	filterKeyword.Regex = util.Ptr(false)
 
 	// We only want to update the relevant filter keyword.
 	filter.Keywords = []*gtsmodel.FilterKeyword{filterKeyword}
@@ -153,6 +160,7 @@ func (p *Processor) Update(
 		{
 			"keyword",
 			"whole_word",
+			"regex",
 		},
 	}
 	if err := p.state.DB.UpdateFilter(ctx, filter, filterColumns, filterKeywordColumns, nil, nil); err != nil {
@@ -25,21 +25,40 @@ import (
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // Create a new filter for the given account, using the provided parameters.
 // These params should have already been validated by the time they reach this function.
 func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form *apimodel.FilterCreateRequestV2) (*apimodel.FilterV2, gtserror.WithCode) {
+	if errWithCode := p.checkTargetAccounts(ctx, form.Accounts); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	filter := &gtsmodel.Filter{
-		ID:        id.NewULID(),
-		AccountID: account.ID,
-		Title:     form.Title,
-		Action:    typeutils.APIFilterActionToFilterAction(*form.FilterAction),
+		ID:               id.NewULID(),
+		AccountID:        account.ID,
+		Title:            form.Title,
+		Action:           typeutils.APIFilterActionToFilterAction(*form.FilterAction),
+		Mode:             typeutils.APIFilterModeToFilterMode(*form.FilterMode),
+		TargetAccountIDs: form.Accounts,
+		TargetDomains:    form.Domains,
+		TargetSubdomains: form.IncludeSubdomains,
+	}
+	if errWithCode := setActiveWindow(
+		filter,
+		form.ActiveDays,
+		form.ActiveFrom,
+		form.ActiveUntil,
+		form.ActiveTimezone,
+	); errWithCode != nil {
+		return nil, errWithCode
 	}
 	if form.ExpiresIn != nil {
 		filter.ExpiresAt = time.Now().Add(time.Second * time.Duration(*form.ExpiresIn))
@@ -71,6 +90,7 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 			Filter:    filter,
 			Keyword:   formKeyword.Keyword,
 			WholeWord: formKeyword.WholeWord,
+			Regex:     formKeyword.Regex,
 		}
 		filter.Keywords = append(filter.Keywords, filterKeyword)
 	}
@@ -104,3 +124,45 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 
 	return apiFilter, nil
 }
+
+// checkTargetAccounts checks that each of the given
+// accounts to be targeted by a filter exists.
+func (p *Processor) checkTargetAccounts(ctx context.Context, accountIDs []string) gtserror.WithCode {
+	for _, accountID := range accountIDs {
+		_, err := p.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			accountID,
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				err := fmt.Errorf("account %s not found", accountID)
+				return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+			}
+			err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+	return nil
+}
+
+// setActiveWindow sets the recurring active window of
+// the given filter, converting times of day (HH:MM)
+// to minutes after midnight in the filter's time zone.
+func setActiveWindow(
+	filter *gtsmodel.Filter,
+	days []int,
+	from string,
+	until string,
+	timezone string,
+) gtserror.WithCode {
+	fromMins, untilMins, err := validate.FilterActiveWindow(days, from, until, timezone)
+	if err != nil {
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	filter.ActiveDays = util.Deduplicate(days)
+	filter.ActiveFrom = fromMins
+	filter.ActiveUntil = untilMins
+	filter.ActiveTimezone = timezone
+	return nil
+}
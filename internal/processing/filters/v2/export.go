@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Export returns all filters of the given account as a portable
+// document, which can be imported into another account with Import.
+func (p *Processor) Export(ctx context.Context, account *gtsmodel.Account) (*apimodel.FilterExport, gtserror.WithCode) {
+	apiFilters, errWithCode := p.GetAll(ctx, account)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	export := &apimodel.FilterExport{
+		ExportedAt: util.FormatISO8601(time.Now()),
+		Filters:    make([]apimodel.FilterExportEntry, 0, len(apiFilters)),
+	}
+
+	for _, apiFilter := range apiFilters {
+		keywords := make([]apimodel.FilterExportKeyword, 0, len(apiFilter.Keywords))
+		for _, keyword := range apiFilter.Keywords {
+			keywords = append(keywords, apimodel.FilterExportKeyword{
+				Keyword:   keyword.Keyword,
+				WholeWord: keyword.WholeWord,
+				Regex:     keyword.Regex,
+			})
+		}
+
+		export.Filters = append(export.Filters, apimodel.FilterExportEntry{
+			Title:        apiFilter.Title,
+			Context:      apiFilter.Context,
+			ExpiresAt:    apiFilter.ExpiresAt,
+			FilterAction: apiFilter.FilterAction,
+			FilterMode:   apiFilter.FilterMode,
+			Keywords:     keywords,
+
+			Domains:           apiFilter.Domains,
+			IncludeSubdomains: apiFilter.IncludeSubdomains,
+
+			ActiveDays:     apiFilter.ActiveDays,
+			ActiveFrom:     apiFilter.ActiveFrom,
+			ActiveUntil:    apiFilter.ActiveUntil,
+			ActiveTimezone: apiFilter.ActiveTimezone,
+		})
+	}
+
+	return export, nil
+}
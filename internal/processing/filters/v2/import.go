@@ -0,0 +1,307 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// Import creates filters for the given account from a document
+// previously created with Export, reporting the outcome for each
+// filter. Malformed or invalid filters are skipped, with the reason
+// reported, rather than aborting the whole import.
+//
+// In replace mode, the account's existing filters are deleted
+// first, but only if at least one filter in the document is valid.
+func (p *Processor) Import(ctx context.Context, account *gtsmodel.Account, form *apimodel.FilterImportRequest) (*apimodel.FilterImportResult, gtserror.WithCode) {
+	if errWithCode := checkImportMode(form.Mode); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	switch form.Expiry {
+	case apimodel.FilterImportExpiryRelative,
+		apimodel.FilterImportExpiryAbsolute:
+	default:
+		err := fmt.Errorf("filter import expiry '%s' was not recognized, valid options are '%s', '%s'",
+			form.Expiry, apimodel.FilterImportExpiryRelative, apimodel.FilterImportExpiryAbsolute)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	var exportedAt time.Time
+	if form.ExportedAt != "" {
+		var err error
+		exportedAt, err = util.ParseISO8601(form.ExportedAt)
+		if err != nil {
+			err := fmt.Errorf("could not parse exported_at: %w", err)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+	}
+
+	now := time.Now()
+	result := &apimodel.FilterImportResult{
+		Filters: make([]apimodel.FilterImportEntryResult, len(form.Filters)),
+	}
+
+	// Parse + validate all filters before
+	// touching the db, so that existing
+	// filters are only replaced by valid ones.
+	filters := make([]*gtsmodel.Filter, len(form.Filters))
+	for i, raw := range form.Filters {
+		result.Filters[i].Index = i
+
+		var entry apimodel.FilterExportEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			result.Filters[i].Error = "malformed filter: " + err.Error()
+			continue
+		}
+		result.Filters[i].Title = entry.Title
+
+		filter, err := importFilter(account, &entry, form.Expiry, exportedAt, now)
+		if err != nil {
+			result.Filters[i].Error = err.Error()
+			continue
+		}
+
+		filters[i] = filter
+	}
+
+	if errWithCode := p.storeImported(ctx, account, form.Mode, filters, result); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return result, nil
+}
+
+// checkImportMode checks that the given filter import mode is valid.
+func checkImportMode(mode string) gtserror.WithCode {
+	switch mode {
+	case apimodel.FilterImportModeMerge,
+		apimodel.FilterImportModeReplace:
+		return nil
+	default:
+		err := fmt.Errorf("filter import mode '%s' was not recognized, valid options are '%s', '%s'",
+			mode, apimodel.FilterImportModeMerge, apimodel.FilterImportModeReplace)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+}
+
+// storeImported stores the given imported filters for the given account,
+// recording the outcome in the entry of result at the same index. Nil
+// filters failed to parse or validate, and are only counted as failed.
+//
+// In replace mode, the account's existing filters are deleted
+// first, but only if at least one of the filters is non-nil.
+func (p *Processor) storeImported(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	mode string,
+	filters []*gtsmodel.Filter,
+	result *apimodel.FilterImportResult,
+) gtserror.WithCode {
+	valid := 0
+	for _, filter := range filters {
+		if filter != nil {
+			valid++
+		}
+	}
+
+	if mode == apimodel.FilterImportModeReplace && valid > 0 {
+		if errWithCode := p.deleteAll(ctx, account); errWithCode != nil {
+			return errWithCode
+		}
+	}
+
+	for i, filter := range filters {
+		if filter == nil {
+			result.Failed++
+			continue
+		}
+
+		if err := p.state.DB.PutFilter(ctx, filter); err != nil {
+			if errors.Is(err, db.ErrAlreadyExists) {
+				result.Filters[i].Error = "duplicate title or keyword"
+			} else {
+				log.Errorf(ctx, "db error putting filter: %v", err)
+				result.Filters[i].Error = "internal error storing filter"
+			}
+			result.Failed++
+			continue
+		}
+
+		result.Filters[i].ID = filter.ID
+		result.Imported++
+	}
+
+	if valid > 0 {
+		// Send a filters changed event.
+		p.stream.FiltersChanged(ctx, account)
+	}
+
+	return nil
+}
+
+// deleteAll deletes all existing filters of the given account.
+func (p *Processor) deleteAll(ctx context.Context, account *gtsmodel.Account) gtserror.WithCode {
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	for _, filter := range filters {
+		if err := p.state.DB.DeleteFilterByID(ctx, filter.ID); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// importFilter validates the given filter export entry,
+// and converts it to a new filter for the given account.
+func importFilter(
+	account *gtsmodel.Account,
+	entry *apimodel.FilterExportEntry,
+	expiry string,
+	exportedAt time.Time,
+	now time.Time,
+) (*gtsmodel.Filter, error) {
+	if err := validate.FilterTitle(entry.Title); err != nil {
+		return nil, err
+	}
+
+	action := entry.FilterAction
+	if action == apimodel.FilterActionNone {
+		action = apimodel.FilterActionWarn
+	}
+	if err := validate.FilterAction(action); err != nil {
+		return nil, err
+	}
+
+	// Exports from before filter
+	// modes won't include a mode.
+	mode := entry.FilterMode
+	if mode == apimodel.FilterModeNone {
+		mode = apimodel.FilterModeBlock
+	}
+	if err := validate.FilterMode(mode); err != nil {
+		return nil, err
+	}
+
+	if err := validate.FilterContexts(entry.Context); err != nil {
+		return nil, err
+	}
+
+	domains, err := validate.FilterDomains(entry.Domains)
+	if err != nil {
+		return nil, err
+	}
+
+	activeFrom, activeUntil, err := validate.FilterActiveWindow(
+		entry.ActiveDays,
+		entry.ActiveFrom,
+		entry.ActiveUntil,
+		entry.ActiveTimezone,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &gtsmodel.Filter{
+		ID:               id.NewULID(),
+		AccountID:        account.ID,
+		Title:            entry.Title,
+		Action:           typeutils.APIFilterActionToFilterAction(action),
+		Mode:             typeutils.APIFilterModeToFilterMode(mode),
+		TargetDomains:    domains,
+		TargetSubdomains: util.Ptr(entry.IncludeSubdomains),
+		ActiveDays:       util.Deduplicate(entry.ActiveDays),
+		ActiveFrom:       activeFrom,
+		ActiveUntil:      activeUntil,
+		ActiveTimezone:   entry.ActiveTimezone,
+	}
+
+	for _, context := range entry.Context {
+		switch context {
+		case apimodel.FilterContextHome:
+			filter.ContextHome = util.Ptr(true)
+		case apimodel.FilterContextNotifications:
+			filter.ContextNotifications = util.Ptr(true)
+		case apimodel.FilterContextPublic:
+			filter.ContextPublic = util.Ptr(true)
+		case apimodel.FilterContextThread:
+			filter.ContextThread = util.Ptr(true)
+		case apimodel.FilterContextAccount:
+			filter.ContextAccount = util.Ptr(true)
+		}
+	}
+
+	if entry.ExpiresAt != nil {
+		expiresAt, err := util.ParseISO8601(*entry.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse expires_at: %w", err)
+		}
+
+		if expiry == apimodel.FilterImportExpiryRelative {
+			if exportedAt.IsZero() {
+				return nil, errors.New("exported_at is required to compute relative expiry")
+			}
+
+			// Keep the same remaining time
+			// as the filter had at export.
+			expiresAt = now.Add(expiresAt.Sub(exportedAt))
+		}
+
+		if !expiresAt.After(now) {
+			return nil, errors.New("filter has already expired")
+		}
+
+		filter.ExpiresAt = expiresAt
+	}
+
+	for _, entryKeyword := range entry.Keywords {
+		if err := validate.FilterKeywordOrRegex(entryKeyword.Keyword, entryKeyword.Regex); err != nil {
+			return nil, err
+		}
+
+		filter.Keywords = append(filter.Keywords, &gtsmodel.FilterKeyword{
+			ID:        id.NewULID(),
+			AccountID: account.ID,
+			FilterID:  filter.ID,
+			Filter:    filter,
+			Keyword:   entryKeyword.Keyword,
+			WholeWord: util.Ptr(entryKeyword.WholeWord),
+			Regex:     util.Ptr(entryKeyword.Regex),
+		})
+	}
+
+	return filter, nil
+}
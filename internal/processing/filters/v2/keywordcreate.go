@@ -53,6 +53,7 @@ func (p *Processor) KeywordCreate(ctx context.Context, account *gtsmodel.Account
 		FilterID:  filter.ID,
 		Keyword:   form.Keyword,
 		WholeWord: form.WholeWord,
+		Regex:     form.Regex,
 	}
 
 	if err := p.state.DB.PutFilterKeyword(ctx, filterKeyword); err != nil {
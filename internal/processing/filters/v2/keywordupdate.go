@@ -53,8 +53,9 @@ func (p *Processor) KeywordUpdate(
 
 	filterKeyword.Keyword = form.Keyword
 	filterKeyword.WholeWord = form.WholeWord
+	filterKeyword.Regex = form.Regex
 
-	if err := p.state.DB.UpdateFilterKeyword(ctx, filterKeyword, "keyword", "whole_word"); err != nil {
+	if err := p.state.DB.UpdateFilterKeyword(ctx, filterKeyword, "keyword", "whole_word", "regex"); err != nil {
 		if errors.Is(err, db.ErrAlreadyExists) {
 			err = errors.New("duplicate keyword")
 			return nil, gtserror.NewErrorConflict(err, err.Error())
@@ -0,0 +1,365 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+var (
+	// mastodonFilterKeys are the keys of a filter in Mastodon
+	// representation which are understood by ImportMastodon.
+	mastodonFilterKeys = []string{
+		"id",
+		"title",
+		"context",
+		"expires_at",
+		"filter_action",
+		"keywords",
+		"statuses",
+		"gotosocial",
+	}
+
+	// mastodonKeywordKeys are the keys of a filter keyword
+	// in Mastodon representation understood by ImportMastodon.
+	mastodonKeywordKeys = []string{
+		"id",
+		"keyword",
+		"whole_word",
+		"regex",
+	}
+
+	// mastodonStatusKeys are the keys of a filter status in
+	// Mastodon representation understood by ImportMastodon.
+	mastodonStatusKeys = []string{
+		"id",
+		"status_id",
+	}
+
+	// mastodonExtensionKeys are the keys of the gotosocial
+	// extension object understood by ImportMastodon.
+	mastodonExtensionKeys = []string{
+		"filter_action",
+		"filter_mode",
+		"accounts",
+		"domains",
+		"include_subdomains",
+		"active_days",
+		"active_from",
+		"active_until",
+		"active_timezone",
+	}
+)
+
+// ExportMastodon returns all filters of the given account in the
+// representation used by Mastodon's v2 filters API, which can be
+// imported into another account with ImportMastodon.
+func (p *Processor) ExportMastodon(ctx context.Context, account *gtsmodel.Account) ([]apimodel.FilterMastodon, gtserror.WithCode) {
+	apiFilters, errWithCode := p.GetAll(ctx, account)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filters := make([]apimodel.FilterMastodon, 0, len(apiFilters))
+	for _, apiFilter := range apiFilters {
+		filters = append(filters, filterToMastodon(apiFilter))
+	}
+
+	return filters, nil
+}
+
+// ImportMastodon creates filters for the given account from filters
+// in the representation used by Mastodon's v2 filters API, reporting
+// the outcome for each filter, and any fields that were dropped.
+//
+// Expiry times are always treated as absolute, since
+// Mastodon filters carry no record of when they were exported.
+func (p *Processor) ImportMastodon(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	mode string,
+	raws []json.RawMessage,
+) (*apimodel.FilterImportResult, gtserror.WithCode) {
+	if errWithCode := checkImportMode(mode); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	now := time.Now()
+	result := &apimodel.FilterImportResult{
+		Filters: make([]apimodel.FilterImportEntryResult, len(raws)),
+	}
+
+	// Parse + validate all filters before
+	// touching the db, so that existing
+	// filters are only replaced by valid ones.
+	filters := make([]*gtsmodel.Filter, len(raws))
+	for i, raw := range raws {
+		entryResult := &result.Filters[i]
+		entryResult.Index = i
+
+		var mastoFilter apimodel.FilterMastodon
+		if err := json.Unmarshal(raw, &mastoFilter); err != nil {
+			entryResult.Error = "malformed filter: " + err.Error()
+			continue
+		}
+		entryResult.Title = mastoFilter.Title
+		entryResult.Unmapped = unmappedMastodonKeys(raw)
+
+		entry := mastodonToExportEntry(&mastoFilter)
+		filter, err := importFilter(account, entry, apimodel.FilterImportExpiryAbsolute, time.Time{}, now)
+		if err != nil {
+			entryResult.Error = err.Error()
+			continue
+		}
+
+		unmapped, err := p.importMastodonTargets(ctx, account, filter, &mastoFilter)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		entryResult.Unmapped = append(entryResult.Unmapped, unmapped...)
+
+		filters[i] = filter
+	}
+
+	if errWithCode := p.storeImported(ctx, account, mode, filters, result); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return result, nil
+}
+
+// importMastodonTargets adds the accounts and statuses targeted by the
+// given Mastodon filter to the given filter, returning descriptions of
+// those that were dropped because they don't exist on this instance.
+func (p *Processor) importMastodonTargets(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filter *gtsmodel.Filter,
+	mastoFilter *apimodel.FilterMastodon,
+) ([]string, error) {
+	var unmapped []string
+
+	for i, mastoStatus := range mastoFilter.Statuses {
+		_, err := p.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			mastoStatus.StatusID,
+		)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				return nil, gtserror.Newf("db error getting status %s: %w", mastoStatus.StatusID, err)
+			}
+			unmapped = append(unmapped, fmt.Sprintf("statuses[%d]: status %s not found", i, mastoStatus.StatusID))
+			continue
+		}
+
+		filter.Statuses = append(filter.Statuses, &gtsmodel.FilterStatus{
+			ID:        id.NewULID(),
+			AccountID: account.ID,
+			FilterID:  filter.ID,
+			Filter:    filter,
+			StatusID:  mastoStatus.StatusID,
+		})
+	}
+
+	if mastoFilter.GoToSocial == nil {
+		return unmapped, nil
+	}
+
+	for i, accountID := range mastoFilter.GoToSocial.Accounts {
+		_, err := p.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			accountID,
+		)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				return nil, gtserror.Newf("db error getting account %s: %w", accountID, err)
+			}
+			unmapped = append(unmapped, fmt.Sprintf("gotosocial.accounts[%d]: account %s not found", i, accountID))
+			continue
+		}
+
+		filter.TargetAccountIDs = append(filter.TargetAccountIDs, accountID)
+	}
+
+	return unmapped, nil
+}
+
+// filterToMastodon converts the given API filter to Mastodon representation,
+// moving fields that Mastodon doesn't support into the gotosocial extension.
+func filterToMastodon(apiFilter *apimodel.FilterV2) apimodel.FilterMastodon {
+	mastoFilter := apimodel.FilterMastodon{
+		ID:           apiFilter.ID,
+		Title:        apiFilter.Title,
+		Context:      apiFilter.Context,
+		ExpiresAt:    apiFilter.ExpiresAt,
+		FilterAction: apiFilter.FilterAction,
+		Keywords:     make([]apimodel.FilterMastodonKeyword, 0, len(apiFilter.Keywords)),
+		Statuses:     make([]apimodel.FilterMastodonStatus, 0, len(apiFilter.Statuses)),
+	}
+
+	for _, keyword := range apiFilter.Keywords {
+		mastoFilter.Keywords = append(mastoFilter.Keywords, apimodel.FilterMastodonKeyword{
+			ID:        keyword.ID,
+			Keyword:   keyword.Keyword,
+			WholeWord: keyword.WholeWord,
+			Regex:     keyword.Regex,
+		})
+	}
+
+	for _, status := range apiFilter.Statuses {
+		mastoFilter.Statuses = append(mastoFilter.Statuses, apimodel.FilterMastodonStatus{
+			ID:       status.ID,
+			StatusID: status.StatusID,
+		})
+	}
+
+	ext := &apimodel.FilterMastodonExtensions{
+		Accounts:          apiFilter.Accounts,
+		Domains:           apiFilter.Domains,
+		IncludeSubdomains: apiFilter.IncludeSubdomains,
+		ActiveDays:        apiFilter.ActiveDays,
+		ActiveFrom:        apiFilter.ActiveFrom,
+		ActiveUntil:       apiFilter.ActiveUntil,
+		ActiveTimezone:    apiFilter.ActiveTimezone,
+	}
+
+	// Mastodon has no notify action, so fall
+	// back to the next best thing, a warning.
+	if apiFilter.FilterAction == apimodel.FilterActionNotify {
+		mastoFilter.FilterAction = apimodel.FilterActionWarn
+		ext.FilterAction = apimodel.FilterActionNotify
+	}
+
+	// Block is the default, and the
+	// only mode Mastodon knows about.
+	if apiFilter.FilterMode != apimodel.FilterModeBlock {
+		ext.FilterMode = apiFilter.FilterMode
+	}
+
+	if ext.FilterAction != apimodel.FilterActionNone ||
+		ext.FilterMode != apimodel.FilterModeNone ||
+		len(ext.Accounts) != 0 ||
+		len(ext.Domains) != 0 ||
+		ext.IncludeSubdomains ||
+		len(ext.ActiveDays) != 0 ||
+		ext.ActiveFrom != "" ||
+		ext.ActiveUntil != "" ||
+		ext.ActiveTimezone != "" {
+		mastoFilter.GoToSocial = ext
+	}
+
+	return mastoFilter
+}
+
+// mastodonToExportEntry converts the given filter in Mastodon representation
+// to a filter export entry, so that it can be validated with importFilter.
+// Accounts and statuses are not included, and must be handled separately.
+func mastodonToExportEntry(mastoFilter *apimodel.FilterMastodon) *apimodel.FilterExportEntry {
+	entry := &apimodel.FilterExportEntry{
+		Title:        mastoFilter.Title,
+		Context:      mastoFilter.Context,
+		ExpiresAt:    mastoFilter.ExpiresAt,
+		FilterAction: mastoFilter.FilterAction,
+		Keywords:     make([]apimodel.FilterExportKeyword, 0, len(mastoFilter.Keywords)),
+	}
+
+	for _, keyword := range mastoFilter.Keywords {
+		entry.Keywords = append(entry.Keywords, apimodel.FilterExportKeyword{
+			Keyword:   keyword.Keyword,
+			WholeWord: keyword.WholeWord,
+			Regex:     keyword.Regex,
+		})
+	}
+
+	if ext := mastoFilter.GoToSocial; ext != nil {
+		if ext.FilterAction != apimodel.FilterActionNone {
+			entry.FilterAction = ext.FilterAction
+		}
+		entry.FilterMode = ext.FilterMode
+		entry.Domains = ext.Domains
+		entry.IncludeSubdomains = ext.IncludeSubdomains
+		entry.ActiveDays = ext.ActiveDays
+		entry.ActiveFrom = ext.ActiveFrom
+		entry.ActiveUntil = ext.ActiveUntil
+		entry.ActiveTimezone = ext.ActiveTimezone
+	}
+
+	return entry
+}
+
+// unmappedMastodonKeys returns the paths of any keys in the given
+// raw filter in Mastodon representation which ImportMastodon does
+// not understand, and so will be dropped, in a stable order.
+func unmappedMastodonKeys(raw json.RawMessage) []string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		// Already known to be
+		// valid by this point.
+		return nil
+	}
+
+	unmapped := unknownKeys("", fields, mastodonFilterKeys)
+
+	var keywords []map[string]json.RawMessage
+	if err := json.Unmarshal(fields["keywords"], &keywords); err == nil {
+		for i, keyword := range keywords {
+			prefix := fmt.Sprintf("keywords[%d].", i)
+			unmapped = append(unmapped, unknownKeys(prefix, keyword, mastodonKeywordKeys)...)
+		}
+	}
+
+	var statuses []map[string]json.RawMessage
+	if err := json.Unmarshal(fields["statuses"], &statuses); err == nil {
+		for i, status := range statuses {
+			prefix := fmt.Sprintf("statuses[%d].", i)
+			unmapped = append(unmapped, unknownKeys(prefix, status, mastodonStatusKeys)...)
+		}
+	}
+
+	var ext map[string]json.RawMessage
+	if err := json.Unmarshal(fields["gotosocial"], &ext); err == nil {
+		unmapped = append(unmapped, unknownKeys("gotosocial.", ext, mastodonExtensionKeys)...)
+	}
+
+	return unmapped
+}
+
+// unknownKeys returns the sorted keys of fields
+// that are not in known, each prefixed with prefix.
+func unknownKeys(prefix string, fields map[string]json.RawMessage, known []string) []string {
+	var unknown []string
+	for key := range fields {
+		if !slices.Contains(known, key) {
+			unknown = append(unknown, prefix+key)
+		}
+	}
+	slices.Sort(unknown)
+	return unknown
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // Update an existing filter for the given account, using the provided parameters.
@@ -68,6 +69,10 @@ func (p *Processor) Update(
 		filterColumns = append(filterColumns, "action")
 		filter.Action = typeutils.APIFilterActionToFilterAction(*form.FilterAction)
 	}
+	if form.FilterMode != nil {
+		filterColumns = append(filterColumns, "mode")
+		filter.Mode = typeutils.APIFilterModeToFilterMode(*form.FilterMode)
+	}
 	// TODO: (Vyr) is it possible to unset a filter expiration with this API?
 	if form.ExpiresIn != nil {
 		filterColumns = append(filterColumns, "expires_at")
@@ -106,6 +111,43 @@ func (p *Processor) Update(
 		}
 	}
 
+	if form.Accounts != nil {
+		if errWithCode := p.checkTargetAccounts(ctx, *form.Accounts); errWithCode != nil {
+			return nil, errWithCode
+		}
+		filterColumns = append(filterColumns, "target_account_ids")
+		filter.TargetAccountIDs = *form.Accounts
+	}
+	if form.Domains != nil {
+		filterColumns = append(filterColumns, "target_domains")
+		filter.TargetDomains = *form.Domains
+	}
+	if form.IncludeSubdomains != nil {
+		filterColumns = append(filterColumns, "target_subdomains")
+		filter.TargetSubdomains = form.IncludeSubdomains
+	}
+	if form.ActiveDays != nil ||
+		form.ActiveFrom != nil ||
+		form.ActiveUntil != nil ||
+		form.ActiveTimezone != nil {
+		// Replace the whole active window.
+		if errWithCode := setActiveWindow(
+			filter,
+			util.PtrValueOr(form.ActiveDays, nil),
+			util.PtrValueOr(form.ActiveFrom, ""),
+			util.PtrValueOr(form.ActiveUntil, ""),
+			util.PtrValueOr(form.ActiveTimezone, ""),
+		); errWithCode != nil {
+			return nil, errWithCode
+		}
+		filterColumns = append(filterColumns,
+			"active_days",
+			"active_from",
+			"active_until",
+			"active_timezone",
+		)
+	}
+
 	filterKeywordColumns, deleteFilterKeywordIDs, errWithCode := applyKeywordChanges(filter, form.Keywords)
 	if err != nil {
 		return nil, errWithCode
@@ -169,7 +211,7 @@ func applyKeywordChanges(filter *gtsmodel.Filter, formKeywords []apimodel.Filter
 			}
 
 			// Process updates.
-			columns := make([]string, 0, 2)
+			columns := make([]string, 0, 3)
 			if formKeyword.Keyword != nil {
 				columns = append(columns, "keyword")
 				filterKeyword.Keyword = *formKeyword.Keyword
@@ -178,6 +220,18 @@ func applyKeywordChanges(filter *gtsmodel.Filter, formKeywords []apimodel.Filter
 				columns = append(columns, "whole_word")
 				filterKeyword.WholeWord = formKeyword.WholeWord
 			}
+			if formKeyword.Regex != nil {
+				columns = append(columns, "regex")
+				filterKeyword.Regex = formKeyword.Regex
+			}
+			if formKeyword.Keyword != nil || formKeyword.Regex != nil {
+				// Whether the keyword is a regex may come from the
+				// stored keyword, so validate the combined result.
+				regex := util.PtrValueOr(filterKeyword.Regex, false)
+				if err := validate.FilterKeywordOrRegex(filterKeyword.Keyword, regex); err != nil {
+					return nil, nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+				}
+			}
 			filterKeywordColumnsByID[id] = columns
 			continue
 		}
@@ -190,6 +244,7 @@ func applyKeywordChanges(filter *gtsmodel.Filter, formKeywords []apimodel.Filter
 			Filter:    filter,
 			Keyword:   *formKeyword.Keyword,
 			WholeWord: util.Ptr(util.PtrValueOr(formKeyword.WholeWord, false)),
+			Regex:     util.Ptr(util.PtrValueOr(formKeyword.Regex, false)),
 		}
 		filterKeywordsByID[filterKeyword.ID] = filterKeyword
 		// Don't need to set columns, as we're using all of them.
@@ -0,0 +1,135 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// CreateUpload returns a presigned URL at which the given
+// account can upload a media file directly to storage, to
+// be turned into an attachment afterwards via FinalizeUpload.
+func (p *Processor) CreateUpload(ctx context.Context, account *gtsmodel.Account) (*apimodel.AttachmentUpload, gtserror.WithCode) {
+	key, u, err := p.state.Storage.PresignedUpload(ctx, account.ID)
+	if err != nil {
+		if errors.Is(err, storage.ErrPresignUnsupported) {
+			const text = "direct uploads are not supported by this instance"
+			return nil, gtserror.NewErrorNotFound(err, text)
+		}
+
+		err := gtserror.Newf("error creating presigned upload: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.AttachmentUpload{
+		Key:       key,
+		URL:       u.String(),
+		ExpiresAt: util.FormatISO8601(u.Expiry),
+	}, nil
+}
+
+// FinalizeUpload creates a new media attachment belonging to the given account,
+// from a file the account uploaded directly to storage (see CreateUpload).
+func (p *Processor) FinalizeUpload(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentUploadFinalizeRequest) (*apimodel.Attachment, gtserror.WithCode) {
+	if !p.state.Storage.SupportsPresignedUploads() {
+		// Uploads can only have been made via
+		// CreateUpload, which isn't supported.
+		const text = "direct uploads are not supported by this instance"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	if !storage.IsUploadKey(form.Key, account.ID) {
+		// Don't reveal whether someone
+		// else's upload exists or not.
+		const text = "upload not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	focusX, focusY, err := parseFocus(form.Focus)
+	if err != nil {
+		err := fmt.Errorf("could not parse focus value %s: %s", form.Focus, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	size, err := p.state.Storage.Size(ctx, form.Key)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			const text = "upload not found"
+			return nil, gtserror.NewErrorNotFound(err, text)
+		}
+
+		err := gtserror.Newf("error getting size of upload %s: %w", form.Key, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// The file didn't go through the instance, so
+	// check size limits as for regular uploads now.
+	maxSize := max(config.GetMediaVideoMaxSize(), config.GetMediaImageMaxSize())
+	if size > int64(maxSize) {
+		if err := p.state.Storage.Delete(ctx, form.Key); err != nil && !storage.IsNotFound(err) {
+			err := gtserror.Newf("error deleting oversized upload %s: %w", form.Key, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		text := fmt.Sprintf("file size limit exceeded: limit is %d bytes but upload was %d bytes", maxSize, size)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	processing, err := p.mediaManager.CreateMediaFromUpload(ctx,
+		account.ID,
+		form.Key,
+		media.AdditionalMediaInfo{
+			Description: &form.Description,
+			FocusX:      &focusX,
+			FocusY:      &focusY,
+		},
+	)
+	if err != nil {
+		err := gtserror.Newf("error creating media: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Immediately trigger write to storage.
+	attachment, err := processing.Load(ctx)
+	if err != nil {
+		const text = "error processing media"
+		err := gtserror.Newf("error processing media: %w", err)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, text)
+	} else if attachment.Type == gtsmodel.FileTypeUnknown {
+		text := fmt.Sprintf("could not process %s type media", attachment.File.ContentType)
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	apiAttachment, err := p.converter.AttachmentToAPIAttachment(ctx, attachment)
+	if err != nil {
+		err := fmt.Errorf("error parsing media attachment to frontend type: %s", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apiAttachment, nil
+}
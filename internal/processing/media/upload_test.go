@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+type UploadTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *UploadTestSuite) TestFinalizeUploadUnsupported() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Test storage is in-memory, so direct
+	// uploads should never be finalized,
+	// even with an otherwise valid key.
+	attachment, errWithCode := suite.mediaProcessor.FinalizeUpload(ctx, testAccount, &apimodel.AttachmentUploadFinalizeRequest{
+		Key: storage.UploadKeyPrefix(testAccount.ID) + "01J2A8TMQ1TVJ2VQZ7WSQ0R7X6",
+	})
+	suite.Nil(attachment)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestUploadTestSuite(t *testing.T) {
+	suite.Run(t, &UploadTestSuite{})
+}
@@ -18,8 +18,10 @@
 package processing
 
 import (
+	"context"
 	"net/http"
 
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/oauth2/v4"
 )
@@ -34,6 +36,30 @@ func (p *Processor) OAuthHandleTokenRequest(r *http.Request) (map[string]interfa
 	return p.oauthServer.HandleTokenRequest(r)
 }
 
+func (p *Processor) OAuthRevokeToken(ctx context.Context, clientID string, clientSecret string, token string, tokenTypeHint string) gtserror.WithCode {
+	// todo: some kind of metrics stuff here
+	return p.oauthServer.RevokeToken(ctx, clientID, clientSecret, token, tokenTypeHint)
+}
+
+func (p *Processor) OAuthIntrospectToken(ctx context.Context, clientID string, clientSecret string, token string, tokenTypeHint string) (*apimodel.OAuthTokenIntrospection, gtserror.WithCode) {
+	// todo: some kind of metrics stuff here
+	return p.oauthServer.IntrospectToken(ctx, clientID, clientSecret, token, tokenTypeHint)
+}
+
+func (p *Processor) OAuthPushAuthorizeRequest(ctx context.Context, clientID string, clientSecret string, form *apimodel.OAuthAuthorize) (*apimodel.OAuthPushedAuthorization, gtserror.WithCode) {
+	// todo: some kind of metrics stuff here
+	return p.oauthServer.PushAuthorizeRequest(ctx, clientID, clientSecret, form)
+}
+
+func (p *Processor) OAuthLoadPushedAuthorizeRequest(ctx context.Context, clientID string, requestURI string) (*apimodel.OAuthAuthorize, gtserror.WithCode) {
+	// todo: some kind of metrics stuff here
+	return p.oauthServer.LoadPushedAuthorizeRequest(ctx, clientID, requestURI)
+}
+
+func (p *Processor) OAuthJWKS() (*apimodel.JWKS, gtserror.WithCode) {
+	return p.oauthServer.JWKS()
+}
+
 func (p *Processor) OAuthValidateBearerToken(r *http.Request) (oauth2.TokenInfo, error) {
 	// todo: some kind of metrics stuff here
 	return p.oauthServer.ValidationBearerToken(r)
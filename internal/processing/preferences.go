@@ -32,9 +32,10 @@ func (p *Processor) PreferencesGet(ctx context.Context, accountID string) (*apim
 	}
 
 	return &apimodel.Preferences{
-		PostingDefaultVisibility: mastoPrefVisibility(act.Settings.Privacy),
-		PostingDefaultSensitive:  *act.Settings.Sensitive,
-		PostingDefaultLanguage:   act.Settings.Language,
+		PostingDefaultVisibility:     mastoPrefVisibility(act.Settings.Privacy),
+		PostingDefaultSensitive:      *act.Settings.Sensitive,
+		PostingDefaultLanguage:       act.Settings.Language,
+		PostingDefaultContentWarning: act.Settings.DefaultContentWarning,
 		// The Reading* preferences don't appear to actually be settable by the
 		// client, so forcing some sensible defaults here
 		ReadingExpandMedia:    "default",
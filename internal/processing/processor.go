@@ -211,6 +211,7 @@ func NewProcessor(
 		emailSender,
 		&processor.account,
 		&processor.media,
+		&processor.status,
 		&processor.stream,
 	)
 
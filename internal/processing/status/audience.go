@@ -0,0 +1,267 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// audienceSampleMax is the maximum number of inboxes
+// and domains to include in a status audience preview.
+const audienceSampleMax = 20
+
+// AudiencePreview resolves the remote delivery audience of a draft status
+// from the given form, without creating or federating anything. Only the
+// visibility, federated, in-reply-to and status (for mentions) fields of
+// the form are taken into account.
+//
+// Addressing follows that of StatusToAS: followers (or, for mutuals-only,
+// mutuals) plus mentioned accounts, only mentioned accounts for direct,
+// or only the chosen audience for circle.
+func (p *Processor) AudiencePreview(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	form *apimodel.AdvancedStatusCreateForm,
+) (
+	*apimodel.StatusAudience,
+	gtserror.WithCode,
+) {
+	// Ensure account populated; we'll need settings.
+	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
+		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
+	}
+
+	// Draft status model, which is
+	// never stored in the database.
+	status := &gtsmodel.Status{
+		ID:        id.NewULID(),
+		CreatedAt: time.Now(),
+		Local:     util.Ptr(true),
+		Account:   requester,
+		AccountID: requester.ID,
+	}
+
+	// Check in-reply-to, so that the preview
+	// fails in the same way posting would.
+	if errWithCode := p.processInReplyTo(ctx,
+		requester,
+		status,
+		form.InReplyToID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.processContinueThread(ctx,
+		requester,
+		form,
+		status,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := processVisibility(form, requester.Settings, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if errWithCode := p.processAudience(ctx, form, requester.ID, status); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	audience := &apimodel.StatusAudience{
+		Visibility:    p.converter.VisToAPIVis(ctx, status.Visibility),
+		Federated:     *status.Federated,
+		InboxesSample: []string{},
+		DomainsSample: []string{},
+	}
+
+	if !*status.Federated {
+		// Local-only, nothing
+		// would be delivered.
+		return audience, nil
+	}
+
+	// Mentioned accounts are
+	// addressed for all visibilities.
+	targets := p.draftMentionTargets(ctx, requester, status.ID, form.Status)
+
+	switch status.Visibility {
+	case gtsmodel.VisibilityDirect:
+		// Mentions only.
+
+	case gtsmodel.VisibilityCircle:
+		// Chosen audience only; mentions
+		// outside of it aren't addressed.
+		targets = slices.DeleteFunc(targets, func(target *gtsmodel.Account) bool {
+			return !status.InAudience(target.ID)
+		})
+		targets = append(targets, status.Audience...)
+
+	case gtsmodel.VisibilityMutualsOnly:
+		mutuals, err := p.filter.StatusMutuals(ctx, status)
+		if err != nil {
+			err := gtserror.Newf("error getting mutuals: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		targets = append(targets, mutuals...)
+
+	default:
+		follows, err := p.state.DB.GetAccountFollowers(ctx, requester.ID, nil)
+		if err != nil {
+			err := gtserror.Newf("error getting followers: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		for _, follow := range follows {
+			targets = append(targets, follow.Account)
+		}
+	}
+
+	var (
+		seen    = make(map[string]struct{}, len(targets))
+		inboxes = make(map[string]struct{})
+		domains = make(map[string]struct{})
+	)
+
+	for _, target := range targets {
+		if target == nil || target.IsLocal() {
+			// Local accounts receive
+			// statuses without delivery.
+			continue
+		}
+
+		if _, ok := seen[target.ID]; ok {
+			// Already counted.
+			continue
+		}
+		seen[target.ID] = struct{}{}
+
+		excluded, err := p.excludedRecipient(ctx, requester, target)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if excluded {
+			audience.Excluded++
+			continue
+		}
+
+		// Deliver to a shared inbox if we have that option.
+		inbox := target.InboxURI
+		if config.GetInstanceDeliverToSharedInboxes() &&
+			target.SharedInboxURI != nil && *target.SharedInboxURI != "" {
+			inbox = *target.SharedInboxURI
+		}
+
+		audience.Accounts++
+		inboxes[inbox] = struct{}{}
+		domains[target.Domain] = struct{}{}
+	}
+
+	audience.Inboxes = len(inboxes)
+	audience.Domains = len(domains)
+	audience.InboxesSample = audienceSample(inboxes)
+	audience.DomainsSample = audienceSample(domains)
+
+	return audience, nil
+}
+
+// draftMentionTargets returns the target accounts of mentions in the
+// given draft status text, resolved in the same way as when posting.
+// Mentions that can't be resolved are skipped, as they would be then.
+func (p *Processor) draftMentionTargets(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statusID string,
+	text string,
+) []*gtsmodel.Account {
+	var (
+		namestrings = make(map[string]struct{})
+		targets     []*gtsmodel.Account
+	)
+
+	for _, match := range regexes.MentionFinder.FindAllStringSubmatch(text, -1) {
+		namestring := match[1]
+		if _, ok := namestrings[namestring]; ok {
+			continue
+		}
+		namestrings[namestring] = struct{}{}
+
+		mention, err := p.parseMention(ctx, namestring, requester.ID, statusID)
+		if err != nil {
+			log.Debugf(ctx, "error parsing mention %s, skipping: %v", namestring, err)
+			continue
+		}
+
+		targets = append(targets, mention.TargetAccount)
+	}
+
+	return targets
+}
+
+// excludedRecipient returns whether the given target account would
+// be excluded from delivery of a status authored by requester, due to
+// a block in either direction, a domain block, or a suspension.
+func (p *Processor) excludedRecipient(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	target *gtsmodel.Account,
+) (bool, error) {
+	if target.IsSuspended() {
+		return true, nil
+	}
+
+	blocked, err := p.state.DB.IsEitherBlocked(ctx, requester.ID, target.ID)
+	if err != nil {
+		return false, gtserror.Newf("error checking block with %s: %w", target.ID, err)
+	}
+
+	if blocked {
+		return true, nil
+	}
+
+	blocked, err = p.state.DB.IsDomainBlocked(ctx, target.Domain)
+	if err != nil {
+		return false, gtserror.Newf("error checking domain block for %s: %w", target.Domain, err)
+	}
+
+	return blocked, nil
+}
+
+// audienceSample returns up to audienceSampleMax
+// of the given set's values, sorted alphabetically.
+func audienceSample(set map[string]struct{}) []string {
+	sample := make([]string, 0, len(set))
+	for value := range set {
+		sample = append(sample, value)
+	}
+	slices.Sort(sample)
+	if len(sample) > audienceSampleMax {
+		sample = sample[:audienceSampleMax]
+	}
+	return sample
+}
@@ -21,25 +21,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"slices"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/language"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // Create processes the given form to create a new status, returning the api model representation of that status if it's OK.
 //
+// If the form has an IdempotencyKey set, then retries of the same request (same key + payload) by the
+// requester will return the originally created status instead of creating a duplicate. See createIdempotent().
+//
 // Precondition: the form's fields should have already been validated and normalized by the caller.
 func (p *Processor) Create(
 	ctx context.Context,
@@ -49,6 +57,22 @@ func (p *Processor) Create(
 ) (
 	*apimodel.Status,
 	gtserror.WithCode,
+) {
+	if form.IdempotencyKey != "" {
+		return p.createIdempotent(ctx, requester, application, form)
+	}
+	return p.create(ctx, requester, application, form)
+}
+
+// create performs the actual status creation for Create().
+func (p *Processor) create(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	application *gtsmodel.Application,
+	form *apimodel.AdvancedStatusCreateForm,
+) (
+	*apimodel.Status,
+	gtserror.WithCode,
 ) {
 	// Ensure account populated; we'll need settings.
 	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
@@ -75,7 +99,6 @@ func (p *Processor) Create(
 		AccountID:                requester.ID,
 		AccountURI:               requester.URI,
 		ActivityStreamsType:      ap.ObjectNote,
-		Sensitive:                &form.Sensitive,
 		CreatedWithApplicationID: application.ID,
 		Text:                     form.Status,
 	}
@@ -109,26 +132,48 @@ func (p *Processor) Create(
 		return nil, errWithCode
 	}
 
+	if errWithCode := p.processContinueThread(ctx,
+		requester,
+		form,
+		status,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	if errWithCode := p.processThreadID(ctx, status); errWithCode != nil {
 		return nil, errWithCode
 	}
 
-	if errWithCode := p.processMediaIDs(ctx, form, requester.ID, status); errWithCode != nil {
+	if errWithCode := p.processMediaIDs(ctx, form, requester, status); errWithCode != nil {
 		return nil, errWithCode
 	}
 
-	if err := processVisibility(form, requester.Settings.Privacy, status); err != nil {
+	processSensitive(form, requester.Settings.DefaultMediaSensitive, status)
+
+	if err := processVisibility(form, requester.Settings, status); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	if err := processLanguage(form, requester.Settings.Language, status); err != nil {
+	if errWithCode := p.processAudience(ctx, form, requester.ID, status); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	detectLanguage := config.GetStatusesLanguageDetection() ||
+		util.PtrValueOr(requester.Settings.DetectLanguage, false)
+	if err := processLanguage(form, requester.Settings.Language, detectLanguage, status); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if errWithCode := processContentType(form, requester.Settings.StatusContentType); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	if err := p.processContent(ctx, p.parseMention, form, status); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	processExpiry(form, requester.Settings.DefaultPostExpiry, status)
+
 	if status.Poll != nil {
 		// Try to insert the new status poll in the database.
 		if err := p.state.DB.PutPoll(ctx, status.Poll); err != nil {
@@ -158,6 +203,14 @@ func (p *Processor) Create(
 		}
 	}
 
+	if !status.ExpiresAt.IsZero() {
+		// Likewise, schedule automatic
+		// deletion of the status itself.
+		if err := p.ScheduleExpiry(ctx, status); err != nil {
+			log.Errorf(ctx, "error scheduling status expiry: %v", err)
+		}
+	}
+
 	return p.c.GetAPIStatus(ctx, requester, status)
 }
 
@@ -199,6 +252,80 @@ func (p *Processor) processInReplyTo(ctx context.Context, requester *gtsmodel.Ac
 	return nil
 }
 
+// processContinueThread makes the given status a reply to the
+// requester's most recent status, if the form asks to continue
+// the requester's thread (or the requester's continue_thread
+// setting does, and the form doesn't say), and the form doesn't
+// set in_reply_to_id itself.
+//
+// Direct statuses and boosts are skipped when looking for the
+// most recent status, and deleted statuses are of course gone,
+// so the thread continues from the latest status still around.
+// If there's no such status, or it can't be replied to, then
+// the status starts a new thread instead.
+func (p *Processor) processContinueThread(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	form *apimodel.AdvancedStatusCreateForm,
+	status *gtsmodel.Status,
+) gtserror.WithCode {
+	if form.InReplyToID != "" {
+		// Explicit reply.
+		return nil
+	}
+
+	continueThread := util.PtrValueOr(form.ContinueThread,
+		util.PtrValueOr(requester.Settings.ContinueThread, false))
+	if !continueThread {
+		return nil
+	}
+
+	statuses, err := p.state.DB.GetAccountStatuses(ctx,
+		requester.ID,
+		20,    // limit
+		false, // excludeReplies
+		true,  // excludeReblogs
+		"",    // maxID
+		"",    // minID
+		false, // mediaOnly
+		false, // publicOnly
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting latest statuses: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	var latest *gtsmodel.Status
+	for _, s := range statuses {
+		if s.Visibility != gtsmodel.VisibilityDirect {
+			latest = s
+			break
+		}
+	}
+
+	if latest == nil {
+		// Nothing to continue,
+		// start a new thread.
+		return nil
+	}
+
+	errWithCode := p.processInReplyTo(ctx, requester, status, latest.ID)
+	if errWithCode == nil {
+		return nil
+	}
+
+	switch errWithCode.Code() {
+	case http.StatusNotFound, http.StatusForbidden:
+		// Deleted in the meantime,
+		// or marked not replyable.
+		log.Debugf(ctx, "not continuing thread from status %s: %v", latest.ID, errWithCode)
+		return nil
+
+	default:
+		return errWithCode
+	}
+}
+
 func (p *Processor) processThreadID(ctx context.Context, status *gtsmodel.Status) gtserror.WithCode {
 	// Status takes the thread ID of
 	// whatever it replies to, if set.
@@ -239,18 +366,20 @@ func (p *Processor) processThreadID(ctx context.Context, status *gtsmodel.Status
 	return nil
 }
 
-func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) gtserror.WithCode {
+func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, requester *gtsmodel.Account, status *gtsmodel.Status) gtserror.WithCode {
 	if form.MediaIDs == nil {
 		return nil
 	}
 
-	// Get minimum allowed char descriptions.
-	minChars := config.GetMediaDescriptionMinChars()
+	// Descriptions are required if either the
+	// instance or the posting account says so.
+	descriptionRequired := config.GetMediaDescriptionRequired() ||
+		util.PtrValueOr(requester.Settings.RequireMediaDescriptions, false)
 
 	attachments := []*gtsmodel.MediaAttachment{}
 	attachmentIDs := []string{}
 
-	for _, mediaID := range form.MediaIDs {
+	for i, mediaID := range form.MediaIDs {
 		attachment, err := p.state.DB.GetAttachmentByID(ctx, mediaID)
 		if err != nil && !errors.Is(err, db.ErrNoEntries) {
 			err := gtserror.Newf("error fetching media from db: %w", err)
@@ -262,7 +391,7 @@ func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.Advanced
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
 
-		if attachment.AccountID != thisAccountID {
+		if attachment.AccountID != requester.ID {
 			text := fmt.Sprintf("media %s does not belong to account", mediaID)
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
@@ -272,43 +401,89 @@ func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.Advanced
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
 
-		if length := len([]rune(attachment.Description)); length < minChars {
-			text := fmt.Sprintf("media %s description too short, at least %d required", mediaID, minChars)
-			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		if err := validate.MediaDescription(i, mediaID, attachment.Description, descriptionRequired); err != nil {
+			return gtserror.NewErrorBadRequest(err, err.Error())
 		}
 
 		attachments = append(attachments, attachment)
 		attachmentIDs = append(attachmentIDs, attachment.ID)
 	}
 
+	if len(attachments) > 1 && !config.GetStatusesMediaAllowMixed() {
+		// Ensure all attachments are of the same
+		// type, as mixing is disabled on this instance.
+		for _, attachment := range attachments[1:] {
+			if attachment.Type != attachments[0].Type {
+				const text = "media of different types cannot be attached to the same status on this instance"
+				return gtserror.NewErrorBadRequest(errors.New(text), text)
+			}
+		}
+	}
+
 	status.Attachments = attachments
 	status.AttachmentIDs = attachmentIDs
 	return nil
 }
 
-func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVis gtsmodel.Visibility, status *gtsmodel.Status) error {
-	// by default all flags are set to true
-	federated := true
+// processExpiry sets the time at which the status will be
+// automatically deleted, if at all. The form's expires_in
+// takes precedence if set, with 0 meaning never; otherwise
+// the account's default post expiry is used.
+func processExpiry(form *apimodel.AdvancedStatusCreateForm, accountDefaultExpiry time.Duration, status *gtsmodel.Status) {
+	expiry := accountDefaultExpiry
+	if form.ExpiresIn != nil {
+		expiry = time.Duration(*form.ExpiresIn) * time.Second
+	}
+
+	if expiry > 0 {
+		status.ExpiresAt = status.CreatedAt.Add(expiry)
+	}
+}
+
+func processSensitive(form *apimodel.AdvancedStatusCreateForm, accountDefaultMediaSensitive *bool, status *gtsmodel.Status) {
+	// If sensitive isn't set on the form, then only
+	// mark the status sensitive if it has media, and
+	// the account wants media hidden by default.
+	sensitive := len(status.AttachmentIDs) != 0 &&
+		util.PtrValueOr(accountDefaultMediaSensitive, false)
+	if form.Sensitive != nil {
+		sensitive = *form.Sensitive
+	}
+
+	status.Sensitive = &sensitive
+}
+
+func processVisibility(form *apimodel.AdvancedStatusCreateForm, settings *gtsmodel.AccountSettings, status *gtsmodel.Status) error {
+	// by default all flags are set to true,
+	// except federated if the account posts
+	// local-only by default
+	federated := !util.PtrValueOr(settings.LocalOnly, false)
 	boostable := true
 	replyable := true
 	likeable := true
 
-	// If visibility isn't set on the form, then just take the account default.
+	// If visibility isn't set on the form, then take the account's reply
+	// default for replies (if set), or just the account default otherwise.
 	// If that's also not set, take the default for the whole instance.
 	var vis gtsmodel.Visibility
 	switch {
 	case form.Visibility != "":
 		vis = typeutils.APIVisToVis(form.Visibility)
-	case accountDefaultVis != "":
-		vis = accountDefaultVis
+	case status.InReplyTo != nil && settings.ReplyPrivacy != "":
+		vis = replyVisibility(settings.ReplyPrivacy, status.InReplyTo.Visibility)
+	case settings.Privacy != "":
+		vis = settings.Privacy
 	default:
 		vis = gtsmodel.VisibilityDefault
 	}
 
 	switch vis {
 	case gtsmodel.VisibilityPublic:
-		// for public, there's no need to change any of the advanced flags from true regardless of what the user filled out
-		break
+		// for public, the only flag the user can change is federated, to make the status local-only
+		if form.Federated != nil {
+			federated = *form.Federated
+		}
+
 	case gtsmodel.VisibilityUnlocked:
 		// for unlocked the user can set any combination of flags they like so look at them all to see if they're set and then apply them
 		if form.Federated != nil {
@@ -343,14 +518,24 @@ func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVi
 			likeable = *form.Likeable
 		}
 
-	case gtsmodel.VisibilityDirect:
-		// direct is pretty easy: there's only one possible setting so return it
+	case gtsmodel.VisibilityDirect, gtsmodel.VisibilityCircle:
+		// direct and circle are pretty easy: there's only one possible setting so return it
 		federated = true
 		boostable = false
 		replyable = true
 		likeable = true
 	}
 
+	if status.InReplyTo != nil &&
+		!util.PtrValueOr(status.InReplyTo.Federated, true) &&
+		vis != gtsmodel.VisibilityDirect {
+		// Replies to local-only statuses are local-only
+		// too, as other instances can't see the status
+		// that's being replied to. Direct replies are
+		// federated so mentions still get delivered.
+		federated = false
+	}
+
 	status.Visibility = vis
 	status.Federated = &federated
 	status.Boostable = &boostable
@@ -359,10 +544,105 @@ func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVi
 	return nil
 }
 
-func processLanguage(form *apimodel.AdvancedStatusCreateForm, accountDefaultLanguage string, status *gtsmodel.Status) error {
-	if form.Language != "" {
+// visibilityWidths orders visibilities from
+// narrowest to widest, for replyVisibility().
+var visibilityWidths = map[gtsmodel.Visibility]int{
+	gtsmodel.VisibilityDirect:        0,
+	gtsmodel.VisibilityCircle:        0,
+	gtsmodel.VisibilityMutualsOnly:   1,
+	gtsmodel.VisibilityFollowersOnly: 2,
+	gtsmodel.VisibilityUnlocked:      3,
+	gtsmodel.VisibilityPublic:        4,
+}
+
+// replyVisibility returns the default visibility for a reply to a status
+// with the given visibility, for an account with the given reply privacy
+// setting: the same visibility as the replied-to status, but never wider
+// than the reply privacy. Replies to circle statuses are direct, as their
+// audience isn't known to whoever is replying.
+func replyVisibility(replyPrivacy gtsmodel.Visibility, parentVis gtsmodel.Visibility) gtsmodel.Visibility {
+	vis := parentVis
+	if visibilityWidths[replyPrivacy] < visibilityWidths[vis] {
+		vis = replyPrivacy
+	}
+
+	if vis == gtsmodel.VisibilityCircle {
+		vis = gtsmodel.VisibilityDirect
+	}
+
+	return vis
+}
+
+// processAudience sets the chosen audience of a circle status from
+// the form's audience IDs, checking that each of them is an existing
+// follower of the author. Does nothing for other visibilities.
+func (p *Processor) processAudience(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) gtserror.WithCode {
+	if status.Visibility != gtsmodel.VisibilityCircle {
+		return nil
+	}
+
+	audience := make([]*gtsmodel.Account, 0, len(form.AudienceIDs))
+	audienceIDs := make([]string, 0, len(form.AudienceIDs))
+
+	for _, accountID := range form.AudienceIDs {
+		if slices.Contains(audienceIDs, accountID) {
+			// Already included.
+			continue
+		}
+
+		account, err := p.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			accountID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error getting audience account %s: %w", accountID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if account == nil {
+			text := fmt.Sprintf("audience account %s not found", accountID)
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		follows, err := p.state.DB.IsFollowing(ctx, accountID, thisAccountID)
+		if err != nil {
+			err := gtserror.Newf("error checking follow %s->%s: %w", accountID, thisAccountID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if !follows {
+			text := fmt.Sprintf("audience account %s does not follow you", accountID)
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		audience = append(audience, account)
+		audienceIDs = append(audienceIDs, accountID)
+	}
+
+	if len(audience) == 0 {
+		const text = "circle visibility requires at least one audience account"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	status.Audience = audience
+	status.AudienceIDs = audienceIDs
+	return nil
+}
+
+// processLanguage sets the language of the status to the one given in the
+// form, or if none was given, the language detected from the status text (if
+// detection is enabled and the language could be detected), or failing that,
+// the account's default language.
+func processLanguage(form *apimodel.AdvancedStatusCreateForm, accountDefaultLanguage string, detect bool, status *gtsmodel.Status) error {
+	switch {
+	case form.Language != "":
 		status.Language = form.Language
-	} else {
+	case detect:
+		// Only used if detected
+		// with enough confidence.
+		status.Language = language.Detect(form.Status)
+	}
+	if status.Language == "" {
 		status.Language = accountDefaultLanguage
 	}
 	if status.Language == "" {
@@ -371,13 +651,31 @@ func processLanguage(form *apimodel.AdvancedStatusCreateForm, accountDefaultLang
 	return nil
 }
 
-func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.ParseMentionFunc, form *apimodel.AdvancedStatusCreateForm, status *gtsmodel.Status) error {
-	if form.ContentType == "" {
-		// If content type wasn't specified, use the author's preferred content-type.
-		contentType := apimodel.StatusContentType(status.Account.Settings.StatusContentType)
-		form.ContentType = contentType
+// processContentType checks that the content type given in the form is
+// allowed on this instance, or if none was given, sets it to the account's
+// default content type, or the instance's first allowed content type if
+// the account doesn't have one set (or it's no longer allowed).
+func processContentType(form *apimodel.AdvancedStatusCreateForm, accountDefaultContentType string) gtserror.WithCode {
+	if form.ContentType != "" {
+		if err := validate.StatusContentType(string(form.ContentType)); err != nil {
+			return gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		return nil
 	}
 
+	if accountDefaultContentType != "" &&
+		validate.StatusContentType(accountDefaultContentType) == nil {
+		form.ContentType = apimodel.StatusContentType(accountDefaultContentType)
+		return nil
+	}
+
+	// Config validation ensures
+	// there's at least one of these.
+	form.ContentType = apimodel.StatusContentType(config.GetStatusesContentTypes()[0])
+	return nil
+}
+
+func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.ParseMentionFunc, form *apimodel.AdvancedStatusCreateForm, status *gtsmodel.Status) error {
 	// format is the currently set text formatting
 	// function, according to the provided content-type.
 	var format text.FormatFunc
@@ -20,12 +20,15 @@ package status_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type StatusCreateTestSuite struct {
@@ -44,7 +47,7 @@ func (suite *StatusCreateTestSuite) TestProcessContentWarningWithQuotationMarks(
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
+			Sensitive:   util.Ptr(false),
 			SpoilerText: "\"test\"", // these should not be html-escaped when the final text is rendered
 			Visibility:  apimodel.VisibilityPublic,
 			ScheduledAt: "",
@@ -78,7 +81,7 @@ func (suite *StatusCreateTestSuite) TestProcessContentWarningWithHTMLEscapedQuot
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
+			Sensitive:   util.Ptr(false),
 			SpoilerText: "&#34test&#34", // the html-escaped quotation marks should appear as normal quotation marks in the finished text
 			Visibility:  apimodel.VisibilityPublic,
 			ScheduledAt: "",
@@ -117,7 +120,7 @@ func (suite *StatusCreateTestSuite) TestProcessStatusMarkdownWithUnderscoreEmoji
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
+			Sensitive:   util.Ptr(false),
 			Visibility:  apimodel.VisibilityPublic,
 			ScheduledAt: "",
 			Language:    "en",
@@ -151,7 +154,7 @@ func (suite *StatusCreateTestSuite) TestProcessStatusMarkdownWithSpoilerTextEmoj
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
+			Sensitive:   util.Ptr(false),
 			Visibility:  apimodel.VisibilityPublic,
 			ScheduledAt: "",
 			Language:    "en",
@@ -188,7 +191,7 @@ func (suite *StatusCreateTestSuite) TestProcessMediaDescriptionTooShort() {
 			MediaIDs:    []string{suite.testAttachments["local_account_1_unattached_1"].ID},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
+			Sensitive:   util.Ptr(false),
 			SpoilerText: "",
 			Visibility:  apimodel.VisibilityPublic,
 			ScheduledAt: "",
@@ -208,6 +211,203 @@ func (suite *StatusCreateTestSuite) TestProcessMediaDescriptionTooShort() {
 	suite.Nil(apiStatus)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessMediaMixedTypesDisallowed() {
+	ctx := context.Background()
+
+	config.SetStatusesMediaAllowMixed(false)
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Insert a video attachment alongside the
+	// account's existing unattached image.
+	image := suite.testAttachments["local_account_1_unattached_1"]
+	video := new(gtsmodel.MediaAttachment)
+	*video = *image
+	video.ID = "01J0TB1GSGS8FB5EWT5F9W0SBV"
+	video.Type = gtsmodel.FileTypeVideo
+	if err := suite.db.PutAttachment(ctx, video); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "look at my media",
+			MediaIDs:    []string{image.ID, video.ID},
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.EqualError(err, "media of different types cannot be attached to the same status on this instance")
+	suite.Nil(apiStatus)
+
+	// With mixing allowed the same media should be fine.
+	config.SetStatusesMediaAllowMixed(true)
+
+	apiStatus, err = suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+	suite.Len(apiStatus.MediaAttachments, 2)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessEmptyMediaMixedTypesDisallowed() {
+	ctx := context.Background()
+
+	config.SetStatusesMediaAllowMixed(false)
+	defer config.SetStatusesMediaAllowMixed(true)
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Empty but non-nil media IDs, as
+	// sent by `"media_ids": []` in JSON.
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "no media here",
+			MediaIDs:    []string{},
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+	suite.Empty(apiStatus.MediaAttachments)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessMediaDescriptionRequired() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Insert an undescribed attachment after
+	// the account's existing described one.
+	described := suite.testAttachments["local_account_1_unattached_1"]
+	undescribed := new(gtsmodel.MediaAttachment)
+	*undescribed = *described
+	undescribed.ID = "01J3WZJ8KQ6SP7M2A1ZB0R9XVE"
+	undescribed.Description = ""
+	if err := suite.db.PutAttachment(ctx, undescribed); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "look at my media",
+			MediaIDs:    []string{described.ID, undescribed.ID},
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	const expectErr = "media attachment 2 (01J3WZJ8KQ6SP7M2A1ZB0R9XVE) has no description: alt text is required for all media attached to statuses"
+
+	// Required by the instance.
+	config.SetMediaDescriptionRequired(true)
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.EqualError(err, expectErr)
+	suite.Nil(apiStatus)
+	config.SetMediaDescriptionRequired(false)
+
+	// Required by the account only.
+	settings, dbErr := suite.db.GetAccountSettings(ctx, creatingAccount.ID)
+	if dbErr != nil {
+		suite.FailNow(dbErr.Error())
+	}
+	settings.RequireMediaDescriptions = util.Ptr(true)
+	creatingAccount.Settings = settings
+
+	apiStatus, err = suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.EqualError(err, expectErr)
+	suite.Nil(apiStatus)
+	settings.RequireMediaDescriptions = util.Ptr(false)
+
+	// Not required by either, so fine.
+	apiStatus, err = suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+	suite.Len(apiStatus.MediaAttachments, 2)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessDefaultMediaSensitive() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	settings, err := suite.db.GetAccountSettings(ctx, creatingAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	settings.DefaultMediaSensitive = util.Ptr(true)
+	creatingAccount.Settings = settings
+
+	// Each status needs its own unattached media.
+	mediaIDs := func(id string) []string {
+		attachment := new(gtsmodel.MediaAttachment)
+		*attachment = *suite.testAttachments["local_account_1_unattached_1"]
+		attachment.ID = id
+		if err := suite.db.PutAttachment(ctx, attachment); err != nil {
+			suite.FailNow(err.Error())
+		}
+		return []string{attachment.ID}
+	}
+
+	for _, test := range []struct {
+		description string
+		mediaIDs    []string
+		sensitive   *bool
+		expected    bool
+	}{
+		{
+			description: "media, sensitive not set",
+			mediaIDs:    mediaIDs("01J3ZB0Q4ZK4Y6V7D4W8S5T1AA"),
+			expected:    true,
+		},
+		{
+			description: "media, explicitly not sensitive",
+			mediaIDs:    mediaIDs("01J3ZB0Q4ZK4Y6V7D4W8S5T1AB"),
+			sensitive:   util.Ptr(false),
+			expected:    false,
+		},
+		{
+			description: "no media, sensitive not set",
+			expected:    false,
+		},
+		{
+			description: "no media, explicitly sensitive",
+			sensitive:   util.Ptr(true),
+			expected:    true,
+		},
+	} {
+		statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+			StatusCreateRequest: apimodel.StatusCreateRequest{
+				Status:      "some art i made",
+				MediaIDs:    test.mediaIDs,
+				Sensitive:   test.sensitive,
+				Visibility:  apimodel.VisibilityPublic,
+				Language:    "en",
+				ContentType: apimodel.StatusContentTypePlain,
+			},
+		}
+
+		apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+		if errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+		suite.Equal(test.expected, apiStatus.Sensitive, test.description)
+	}
+
+	settings.DefaultMediaSensitive = util.Ptr(false)
+}
+
 func (suite *StatusCreateTestSuite) TestProcessLanguageWithScriptPart() {
 	ctx := context.Background()
 
@@ -220,7 +420,7 @@ func (suite *StatusCreateTestSuite) TestProcessLanguageWithScriptPart() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
+			Sensitive:   util.Ptr(false),
 			SpoilerText: "",
 			Visibility:  apimodel.VisibilityPublic,
 			ScheduledAt: "",
@@ -242,6 +442,173 @@ func (suite *StatusCreateTestSuite) TestProcessLanguageWithScriptPart() {
 	suite.Equal("zh-Hans", *apiStatus.Language)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessLanguageDetected() {
+	ctx := context.Background()
+
+	config.SetStatusesLanguageDetection(true)
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "Ich habe heute keine Zeit, aber wir sehen uns morgen!",
+			Visibility:  apimodel.VisibilityPublic,
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	// Detected, rather than
+	// the account default.
+	suite.Equal("de", *apiStatus.Language)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessLanguageNotDetected() {
+	ctx := context.Background()
+
+	config.SetStatusesLanguageDetection(true)
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "lol",
+			Visibility:  apimodel.VisibilityPublic,
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	// Falls back to account default.
+	suite.Equal("en", *apiStatus.Language)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessLocalOnlyAccountSetting() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	replyingAccount := suite.testAccounts["local_account_2"]
+
+	settings, err := suite.db.GetAccountSettings(ctx, creatingAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	settings.LocalOnly = util.Ptr(true)
+	creatingAccount.Settings = settings
+
+	create := func(account *gtsmodel.Account, form *apimodel.AdvancedStatusCreateForm) *gtsmodel.Status {
+		form.ContentType = apimodel.StatusContentTypePlain
+		apiStatus, errWithCode := suite.status.Create(ctx, account, creatingApplication, form)
+		if errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+		status, err := suite.db.GetStatusByID(ctx, apiStatus.ID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		return status
+	}
+
+	// Local-only by default.
+	localOnly := create(creatingAccount, &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:     "just between us",
+			Visibility: apimodel.VisibilityPublic,
+		},
+	})
+	suite.False(*localOnly.Federated)
+
+	// Unless federated is set on the status.
+	federated := create(creatingAccount, &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:     "hello fediverse",
+			Visibility: apimodel.VisibilityPublic,
+		},
+		AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+			Federated: util.Ptr(true),
+		},
+	})
+	suite.True(*federated.Federated)
+
+	// Direct statuses are always federated.
+	direct := create(creatingAccount, &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:     "hey @1happyturtle",
+			Visibility: apimodel.VisibilityDirect,
+		},
+	})
+	suite.True(*direct.Federated)
+
+	// Replies to a local-only status are
+	// local-only, even from other accounts.
+	reply := create(replyingAccount, &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "just between us too",
+			Visibility:  apimodel.VisibilityPublic,
+			InReplyToID: localOnly.ID,
+		},
+		AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+			Federated: util.Ptr(true),
+		},
+	})
+	suite.False(*reply.Federated)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessLanguageDetectedAccountSetting() {
+	ctx := context.Background()
+
+	// Off for the instance,
+	// but on for the account.
+	config.SetStatusesLanguageDetection(false)
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	settings, err := suite.db.GetAccountSettings(ctx, creatingAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	settings.DetectLanguage = util.Ptr(true)
+	creatingAccount.Settings = settings
+
+	for _, test := range []struct {
+		text     string
+		language string
+	}{
+		// Confident enough.
+		{"Je ne sais pas si c'est une bonne idée, mais nous verrons bien", "fr"},
+		{"Eu não sei se isso é uma boa ideia, mas vamos ver", "pt"},
+
+		// Too uncertain, so falls
+		// back to account default.
+		{"Le chat", "en"},
+		{"lol", "en"},
+	} {
+		statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+			StatusCreateRequest: apimodel.StatusCreateRequest{
+				Status:      test.text,
+				Visibility:  apimodel.VisibilityPublic,
+				ContentType: apimodel.StatusContentTypePlain,
+			},
+		}
+
+		apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+		if errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+		suite.Equal(test.language, *apiStatus.Language, test.text)
+	}
+}
+
 func (suite *StatusCreateTestSuite) TestProcessReplyToUnthreadedRemoteStatus() {
 	ctx := context.Background()
 
@@ -257,7 +624,7 @@ func (suite *StatusCreateTestSuite) TestProcessReplyToUnthreadedRemoteStatus() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: inReplyTo.ID,
-			Sensitive:   false,
+			Sensitive:   util.Ptr(false),
 			SpoilerText: "this is a reply",
 			Visibility:  apimodel.VisibilityPublic,
 			ScheduledAt: "",
@@ -286,6 +653,333 @@ func (suite *StatusCreateTestSuite) TestProcessReplyToUnthreadedRemoteStatus() {
 	suite.NotEmpty(dbStatus.ThreadID)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessStatusDefaultPostExpiry() {
+	ctx := context.Background()
+
+	creatingAccount := &gtsmodel.Account{}
+	*creatingAccount = *suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Give the account a default post expiry.
+	settings, err := suite.db.GetAccountSettings(ctx, creatingAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	settings.DefaultPostExpiry = 24 * time.Hour
+	if err := suite.db.UpdateAccountSettings(ctx, settings, "default_post_expiry"); err != nil {
+		suite.FailNow(err.Error())
+	}
+	creatingAccount.Settings = settings
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "this post will self destruct in 24 hours",
+			Visibility:  apimodel.VisibilityPublic,
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(errWithCode)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, apiStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(dbStatus.CreatedAt.Add(24*time.Hour), dbStatus.ExpiresAt)
+
+	// Override the default to not expire at all.
+	statusCreateForm.ExpiresIn = util.Ptr(0)
+
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(errWithCode)
+
+	dbStatus, err = suite.db.GetStatusByID(ctx, apiStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(dbStatus.ExpiresAt)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessReplyPrivacy() {
+	ctx := context.Background()
+
+	creatingAccount := &gtsmodel.Account{}
+	*creatingAccount = *suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Default replies to unlisted.
+	settings, err := suite.db.GetAccountSettings(ctx, creatingAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	settings.Privacy = gtsmodel.VisibilityPublic
+	settings.ReplyPrivacy = gtsmodel.VisibilityUnlocked
+	if err := suite.db.UpdateAccountSettings(ctx, settings, "privacy", "reply_privacy"); err != nil {
+		suite.FailNow(err.Error())
+	}
+	creatingAccount.Settings = settings
+
+	for _, test := range []struct {
+		inReplyTo  string
+		visibility apimodel.Visibility
+		expected   gtsmodel.Visibility
+	}{
+		// Narrowed from public.
+		{"local_account_2_status_1", "", gtsmodel.VisibilityUnlocked},
+		// Never wider than the parent.
+		{"local_account_2_status_7", "", gtsmodel.VisibilityFollowersOnly},
+		// Explicit visibility wins.
+		{"local_account_2_status_1", apimodel.VisibilityPublic, gtsmodel.VisibilityPublic},
+		// Not a reply, so account default.
+		{"", "", gtsmodel.VisibilityPublic},
+	} {
+		var inReplyToID string
+		if test.inReplyTo != "" {
+			inReplyToID = suite.testStatuses[test.inReplyTo].ID
+		}
+
+		apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, &apimodel.AdvancedStatusCreateForm{
+			StatusCreateRequest: apimodel.StatusCreateRequest{
+				Status:      "@1happyturtle hello",
+				InReplyToID: inReplyToID,
+				Visibility:  test.visibility,
+				ContentType: apimodel.StatusContentTypePlain,
+			},
+		})
+		if errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+
+		dbStatus, err := suite.db.GetStatusByID(ctx, apiStatus.ID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(test.expected, dbStatus.Visibility, "in reply to %s", test.inReplyTo)
+	}
+}
+
+func (suite *StatusCreateTestSuite) TestProcessContentTypeAllowlist() {
+	ctx := context.Background()
+
+	config.SetStatusesContentTypes([]string{"text/plain"})
+
+	creatingAccount := &gtsmodel.Account{}
+	*creatingAccount = *suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Markdown isn't allowed.
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "**not allowed**",
+			Visibility:  apimodel.VisibilityPublic,
+			ContentType: apimodel.StatusContentTypeMarkdown,
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.Nil(apiStatus)
+	suite.EqualError(errWithCode, "status content type 'text/markdown' is not allowed on this instance, allowed options are 'text/plain'")
+
+	// Plain is fine.
+	statusCreateForm.ContentType = apimodel.StatusContentTypePlain
+	_, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(errWithCode)
+
+	// An account default that's no longer
+	// allowed falls back to the instance's.
+	settings := &gtsmodel.AccountSettings{}
+	*settings = *creatingAccount.Settings
+	settings.StatusContentType = string(apimodel.StatusContentTypeMarkdown)
+	creatingAccount.Settings = settings
+	statusCreateForm.ContentType = ""
+
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(errWithCode)
+	suite.Equal("<p>**not allowed**</p>", apiStatus.Content)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessStatusExpiresIn() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "this post will self destruct in 1 hour",
+			Visibility:  apimodel.VisibilityPublic,
+			ContentType: apimodel.StatusContentTypePlain,
+			ExpiresIn:   util.Ptr(3600),
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(errWithCode)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, apiStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(dbStatus.CreatedAt.Add(time.Hour), dbStatus.ExpiresAt)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessCircleStatus() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	member := suite.testAccounts["local_account_2"]
+	nonMember := suite.testAccounts["admin_account"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "just for you, @1happyturtle (sorry @admin)",
+			Visibility:  apimodel.VisibilityCircle,
+			ContentType: apimodel.StatusContentTypePlain,
+			AudienceIDs: []string{member.ID, member.ID},
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Shown to clients as private, with
+	// the audience only shown to the author.
+	suite.Equal(apimodel.VisibilityPrivate, apiStatus.Visibility)
+	suite.Equal([]string{member.ID}, apiStatus.AudienceIDs)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, apiStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.VisibilityCircle, dbStatus.Visibility)
+	suite.Equal([]string{member.ID}, dbStatus.AudienceIDs)
+	suite.False(*dbStatus.Boostable)
+
+	filter := visibility.NewFilter(&suite.state)
+
+	visible, err := filter.StatusVisible(ctx, member, dbStatus)
+	suite.NoError(err)
+	suite.True(visible)
+
+	// Mentioned, but not in the audience.
+	visible, err = filter.StatusVisible(ctx, nonMember, dbStatus)
+	suite.NoError(err)
+	suite.False(visible)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessCircleStatusAudienceNotFollower() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "hello there",
+			Visibility:  apimodel.VisibilityCircle,
+			ContentType: apimodel.StatusContentTypePlain,
+			AudienceIDs: []string{suite.testAccounts["remote_account_2"].ID},
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.Nil(apiStatus)
+	suite.EqualError(errWithCode, "audience account "+suite.testAccounts["remote_account_2"].ID+" does not follow you")
+}
+
+func (suite *StatusCreateTestSuite) TestProcessContinueThread() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	settings, err := suite.db.GetAccountSettings(ctx, creatingAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	settings.ContinueThread = util.Ptr(true)
+	creatingAccount.Settings = settings
+
+	create := func(form *apimodel.AdvancedStatusCreateForm) *gtsmodel.Status {
+		form.ContentType = apimodel.StatusContentTypePlain
+		if form.Visibility == "" {
+			form.Visibility = apimodel.VisibilityPublic
+		}
+
+		// Make sure status IDs (ULIDs) sort
+		// in the order statuses are created.
+		time.Sleep(2 * time.Millisecond)
+
+		apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, form)
+		if errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+		status, err := suite.db.GetStatusByID(ctx, apiStatus.ID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		return status
+	}
+
+	// Each new status replies
+	// to the previous one.
+	first := create(&apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{Status: "a thread 1/3"},
+	})
+	second := create(&apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{Status: "a thread 2/3"},
+	})
+	suite.Equal(first.ID, second.InReplyToID)
+	suite.Equal(creatingAccount.ID, second.InReplyToAccountID)
+	suite.Equal(first.ThreadID, second.ThreadID)
+
+	// Direct statuses are skipped over.
+	create(&apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:         "hey @1happyturtle",
+			Visibility:     apimodel.VisibilityDirect,
+			ContinueThread: util.Ptr(false),
+		},
+	})
+	third := create(&apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{Status: "a thread 3/3"},
+	})
+	suite.Equal(second.ID, third.InReplyToID)
+
+	// If the previous status is deleted, the
+	// thread continues from the one before.
+	if err := suite.db.DeleteStatusByID(ctx, third.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+	replacement := create(&apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{Status: "a thread 3/3 (fixed typo)"},
+	})
+	suite.Equal(second.ID, replacement.InReplyToID)
+
+	// An explicit in_reply_to_id wins.
+	explicit := create(&apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "back to the start",
+			InReplyToID: first.ID,
+		},
+	})
+	suite.Equal(first.ID, explicit.InReplyToID)
+
+	// And the setting can be turned
+	// off for a single status.
+	standalone := create(&apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:         "something else entirely",
+			ContinueThread: util.Ptr(false),
+		},
+	})
+	suite.Empty(standalone.InReplyToID)
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
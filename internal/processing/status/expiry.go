@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// expiryRetryInterval is how long to wait before trying
+// again to delete an expired status, if it failed.
+const expiryRetryInterval = 5 * time.Minute
+
+// ScheduleExpiryAll schedules deletion of all expiring
+// local statuses, for use at startup. Any whose expiry
+// has already passed (eg., because the instance was
+// down) are deleted immediately.
+func (p *Processor) ScheduleExpiryAll(ctx context.Context) error {
+	statuses, err := p.state.DB.GetExpiringStatuses(gtscontext.SetBarebones(ctx))
+	if err != nil {
+		return gtserror.Newf("error getting expiring statuses from db: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, status := range statuses {
+		// Schedule each of the statuses and catch any errors.
+		if err := p.ScheduleExpiry(ctx, status); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// ScheduleExpiry adds the given status to the scheduler, to be
+// deleted at its ExpiresAt time. Any previously scheduled
+// deletion of the status is replaced.
+func (p *Processor) ScheduleExpiry(ctx context.Context, status *gtsmodel.Status) error {
+	return p.scheduleExpiry(ctx, status.ID, status.ExpiresAt)
+}
+
+func (p *Processor) scheduleExpiry(ctx context.Context, statusID string, at time.Time) error {
+	if at.IsZero() {
+		return gtserror.Newf("status %s has no expiry", statusID)
+	}
+
+	// Tasks stay registered with the scheduler
+	// after they've run, so clear out any old
+	// one for this status before adding anew.
+	p.state.Workers.Scheduler.Cancel(statusID)
+
+	ok := p.state.Workers.Scheduler.AddOnce(
+		statusID,
+		at,
+		p.onExpiry(statusID),
+	)
+
+	if !ok {
+		// Failed to add the status to the scheduler,
+		// it was either starting or stopping.
+		return gtserror.Newf("failed adding status %s expiry to scheduler", statusID)
+	}
+
+	atStr := at.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled status %s to be deleted at '%s'", statusID, atStr)
+	return nil
+}
+
+// onExpiry returns a callback function to be used by the
+// scheduler when the given status expires. Pinned statuses
+// are exempt from expiry; they're instead deleted when
+// unpinned, if they've expired by then (see PinRemove).
+func (p *Processor) onExpiry(statusID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		status, err := p.state.DB.GetStatusByID(ctx, statusID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// Already deleted.
+				return
+			}
+
+			log.Errorf(ctx, "error getting status %s from db, will retry: %v", statusID, err)
+			p.retryExpiry(ctx, statusID, now)
+			return
+		}
+
+		if status.ExpiresAt.IsZero() || now.Before(status.ExpiresAt) {
+			// Expiry was unset or moved
+			// since this was scheduled.
+			return
+		}
+
+		if !status.PinnedAt.IsZero() {
+			log.Debugf(ctx, "status %s is pinned, not deleting", statusID)
+			return
+		}
+
+		if status.Account == nil {
+			log.Errorf(ctx, "status %s has no account, will retry", statusID)
+			p.retryExpiry(ctx, statusID, now)
+			return
+		}
+
+		// Delete the status via the usual client API
+		// worker, which also federates the Delete;
+		// deliveries of this are retried as usual.
+		if _, errWithCode := p.Delete(ctx, status.Account, statusID); errWithCode != nil {
+			log.Errorf(ctx, "error deleting expired status %s, will retry: %v", statusID, errWithCode)
+			p.retryExpiry(ctx, statusID, now)
+		}
+	}
+}
+
+// retryExpiry reschedules expiry of the given status
+// after expiryRetryInterval, logging any error.
+func (p *Processor) retryExpiry(ctx context.Context, statusID string, now time.Time) {
+	if err := p.scheduleExpiry(ctx, statusID, now.Add(expiryRetryInterval)); err != nil {
+		log.Errorf(ctx, "error rescheduling status %s expiry: %v", statusID, err)
+	}
+}
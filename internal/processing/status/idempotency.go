@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// createIdempotent wraps create() for forms with an IdempotencyKey set.
+// The first request with a key creates the status and caches the result
+// for the requester + key; retries with the same key and payload return
+// the already created status. Reusing a key with a different payload
+// is an error, as this most likely indicates a client bug. Retrying
+// once the created status has been deleted is a conflict, rather than
+// a new request, so that a late retry can't post the status again.
+func (p *Processor) createIdempotent(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	application *gtsmodel.Application,
+	form *apimodel.AdvancedStatusCreateForm,
+) (
+	*apimodel.Status,
+	gtserror.WithCode,
+) {
+	// Idempotency keys are scoped per account.
+	key := requester.ID + "/" + form.IdempotencyKey
+
+	hash, err := hashStatusCreateForm(form)
+	if err != nil {
+		err := gtserror.Newf("error hashing form: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Lock on the key for the whole check + create, so
+	// that concurrent retries of an in-flight request
+	// wait for it to finish and then get its result,
+	// instead of racing to create their own status.
+	unlock := p.state.ProcessingLocks.Lock("idempotency:" + key)
+	defer unlock()
+
+	if cached, ok := p.state.Caches.StatusIdempotency.Get(key); ok {
+		if cached.PayloadHash != hash {
+			const text = "Idempotency-Key has already been used for a different request"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
+		// Fetch the originally created status.
+		status, err := p.state.DB.GetStatusByID(ctx, cached.ResultID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting status %s: %w", cached.ResultID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if status == nil {
+			// The original status has since been deleted.
+			// The key stays cached, so that this is the
+			// response to any further retries too.
+			const text = "Idempotency-Key has already been used for a status which has since been deleted"
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+
+		return p.c.GetAPIStatus(ctx, requester, status)
+	}
+
+	apiStatus, errWithCode := p.create(ctx, requester, application, form)
+	if errWithCode != nil {
+		// Don't cache failures, a
+		// retry may well succeed.
+		return nil, errWithCode
+	}
+
+	p.state.Caches.StatusIdempotency.Set(key, cache.Idempotency{
+		ResultID:    apiStatus.ID,
+		PayloadHash: hash,
+	})
+
+	return apiStatus, nil
+}
+
+// hashStatusCreateForm returns a hex encoded hash of the
+// given form's fields, (excluding the idempotency key).
+func hashStatusCreateForm(form *apimodel.AdvancedStatusCreateForm) (string, error) {
+	b, err := json.Marshal(form)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -26,6 +26,7 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
 const allowedPinnedCount = 10
@@ -58,6 +59,11 @@ func (p *Processor) getPinnableStatus(ctx context.Context, requestingAccount *gt
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	}
 
+	if targetStatus.Visibility == gtsmodel.VisibilityCircle {
+		err := errors.New("cannot pin circle statuses")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
 	if targetStatus.BoostOfID != "" {
 		err := errors.New("cannot pin boosts")
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
@@ -191,5 +197,14 @@ func (p *Processor) PinRemove(ctx context.Context, requestingAccount *gtsmodel.A
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if !targetStatus.ExpiresAt.IsZero() {
+		// Status is no longer exempt from expiry, so
+		// reschedule it; if its expiry passed while it
+		// was pinned, it will be deleted straight away.
+		if err := p.ScheduleExpiry(ctx, targetStatus); err != nil {
+			log.Errorf(ctx, "error scheduling status expiry: %v", err)
+		}
+	}
+
 	return p.c.GetAPIStatus(ctx, requestingAccount, targetStatus)
 }
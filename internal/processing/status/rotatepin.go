@@ -0,0 +1,218 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// rotatingPinCheckEvery is how often the rotating
+// pins of all local accounts are checked, so a pin
+// may stay up to this much longer than its interval.
+const rotatingPinCheckEvery = 10 * time.Minute
+
+// ScheduleRotatingPins schedules the rotating pins
+// of all local accounts to be checked regularly.
+func (p *Processor) ScheduleRotatingPins() error {
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@rotatingpins",
+		time.Now().Add(rotatingPinCheckEvery),
+		rotatingPinCheckEvery,
+		p.rotateAllPins,
+	) {
+		return gtserror.New("failed to schedule @rotatingpins")
+	}
+	return nil
+}
+
+// rotateAllPins rotates the pins of all
+// local accounts with rotation turned on.
+func (p *Processor) rotateAllPins(ctx context.Context, now time.Time) {
+	users, err := p.state.DB.GetAllUsers(ctx)
+	if err != nil {
+		log.Errorf(ctx, "error getting users: %v", err)
+		return
+	}
+
+	for _, user := range users {
+		account := user.Account
+		if account == nil ||
+			account.Settings == nil ||
+			account.Settings.RotatingPinInterval <= 0 {
+			continue
+		}
+
+		if err := p.RotatePin(ctx, account, now); err != nil {
+			log.Errorf(ctx, "error rotating pin of account %s: %v", account.ID, err)
+		}
+	}
+}
+
+// RotatePin checks the rotating pin of the given local account at the
+// given time. If none of the statuses in the rotation is pinned, or the
+// one that is has been pinned for at least the rotation interval, the
+// next status in the rotation is pinned in its place. Statuses in the
+// rotation that have since been deleted, or can't be pinned, are skipped.
+func (p *Processor) RotatePin(ctx context.Context, account *gtsmodel.Account, now time.Time) error {
+	settings := account.Settings
+	if settings == nil ||
+		settings.RotatingPinInterval <= 0 ||
+		len(settings.RotatingPinStatusIDs) == 0 {
+		// Rotation is off.
+		return nil
+	}
+
+	// Get a lock on this account.
+	unlock := p.state.ProcessingLocks.Lock(account.URI)
+	defer unlock()
+
+	// Fetch statuses in the rotation, leaving
+	// nil in the place of any that are gone or
+	// can't be pinned. The current pin is the
+	// most recently pinned of the rest, if any.
+	var (
+		ids      = settings.RotatingPinStatusIDs
+		statuses = make([]*gtsmodel.Status, len(ids))
+		current  = -1
+	)
+	for i, id := range ids {
+		status, err := p.state.DB.GetStatusByID(gtscontext.SetBarebones(ctx), id)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting status %s: %w", id, err)
+		}
+
+		if status == nil || !rotatable(account, status) {
+			continue
+		}
+		statuses[i] = status
+
+		if !status.PinnedAt.IsZero() &&
+			(current == -1 || status.PinnedAt.After(statuses[current].PinnedAt)) {
+			current = i
+		}
+	}
+
+	if current != -1 && now.Sub(statuses[current].PinnedAt) < settings.RotatingPinInterval {
+		// Current pin isn't due
+		// to be rotated yet.
+		return nil
+	}
+
+	// Find the next status in the rotation after
+	// the current one (or the first, if none is
+	// pinned), wrapping around to the start.
+	next := -1
+	for n := 1; n <= len(ids); n++ {
+		i := (current + n) % len(ids)
+		if statuses[i] != nil {
+			next = i
+			break
+		}
+	}
+
+	if next == -1 || next == current {
+		// Nothing else to pin.
+		return nil
+	}
+
+	// Ensure account stats populated.
+	if account.Stats == nil {
+		if err := p.state.DB.PopulateAccountStats(ctx, account); err != nil {
+			return gtserror.Newf("db error getting account stats: %w", err)
+		}
+	}
+
+	pinnedCount := *account.Stats.StatusesPinnedCount
+	if current == -1 && pinnedCount >= allowedPinnedCount {
+		// No room for another pin; try
+		// again next time, in case the
+		// account unpins something.
+		log.Debugf(ctx, "account %s has no room to pin rotating status", account.ID)
+		return nil
+	}
+
+	if current != -1 {
+		if err := p.setPinnedAt(ctx, account, statuses[current], time.Time{}); err != nil {
+			return err
+		}
+		pinnedCount--
+	}
+
+	if statuses[next].PinnedAt.IsZero() {
+		pinnedCount++
+	}
+
+	if err := p.setPinnedAt(ctx, account, statuses[next], now); err != nil {
+		return err
+	}
+
+	// Update account stats,
+	// clamping to 0 as in unpin.
+	*account.Stats.StatusesPinnedCount = max(pinnedCount, 0)
+	if err := p.state.DB.UpdateAccountStats(
+		ctx,
+		account.Stats,
+		"statuses_pinned_count",
+	); err != nil {
+		return gtserror.Newf("db error updating stats: %w", err)
+	}
+
+	return nil
+}
+
+// setPinnedAt pins the given status of the given account at
+// the given time, or unpins it if the time is zero, then
+// invalidates it in timelines so the change shows up there.
+func (p *Processor) setPinnedAt(ctx context.Context, account *gtsmodel.Account, status *gtsmodel.Status, pinnedAt time.Time) error {
+	status.PinnedAt = pinnedAt
+	if err := p.state.DB.UpdateStatus(ctx, status, "pinned_at"); err != nil {
+		return gtserror.Newf("db error updating pin of status %s: %w", status.ID, err)
+	}
+
+	if err := p.c.InvalidateTimelinedStatus(ctx, account.ID, status.ID); err != nil {
+		return gtserror.Newf("error invalidating status %s from timelines: %w", status.ID, err)
+	}
+
+	if pinnedAt.IsZero() && !status.ExpiresAt.IsZero() {
+		// Status is no longer exempt from
+		// expiry, so reschedule it, as in unpin.
+		if err := p.ScheduleExpiry(ctx, status); err != nil {
+			log.Errorf(ctx, "error scheduling status expiry: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// rotatable returns whether the given status of
+// the given account can be pinned in rotation,
+// following the same rules as getPinnableStatus.
+func rotatable(account *gtsmodel.Account, status *gtsmodel.Status) bool {
+	return status.AccountID == account.ID &&
+		status.Visibility != gtsmodel.VisibilityDirect &&
+		status.Visibility != gtsmodel.VisibilityCircle &&
+		status.BoostOfID == ""
+}
@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RotatePinTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *RotatePinTestSuite) pinnedIn(ctx context.Context, statusIDs []string) []string {
+	pinned := []string{}
+	for _, id := range statusIDs {
+		status, err := suite.db.GetStatusByID(ctx, id)
+		if err != nil {
+			continue
+		}
+
+		if !status.PinnedAt.IsZero() {
+			pinned = append(pinned, id)
+		}
+	}
+	return pinned
+}
+
+func (suite *RotatePinTestSuite) TestRotatePin() {
+	var (
+		ctx       = context.Background()
+		now       = time.Now()
+		deletedID = "01J3ZQ8V7R2E5WJ9M0XKQ4A1BC"
+		status1   = suite.testStatuses["local_account_1_status_1"]
+		status2   = suite.testStatuses["local_account_1_status_2"]
+		status5   = suite.testStatuses["local_account_1_status_5"]
+		rotation  = []string{status1.ID, deletedID, status2.ID, status5.ID}
+	)
+
+	account, err := suite.db.GetAccountByID(ctx, suite.testAccounts["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	account.Settings.RotatingPinStatusIDs = rotation
+	account.Settings.RotatingPinInterval = time.Hour
+
+	for _, step := range []struct {
+		at     time.Duration
+		pinned string
+	}{
+		// Nothing pinned yet,
+		// so first gets pinned.
+		{0, status1.ID},
+
+		// Not due yet.
+		{30 * time.Minute, status1.ID},
+
+		// Due, deleted status skipped.
+		{time.Hour, status2.ID},
+		{2 * time.Hour, status5.ID},
+
+		// Wraps around to the start.
+		{3 * time.Hour, status1.ID},
+	} {
+		if err := suite.status.RotatePin(ctx, account, now.Add(step.at)); err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal([]string{step.pinned}, suite.pinnedIn(ctx, rotation), "after %s", step.at)
+	}
+}
+
+func (suite *RotatePinTestSuite) TestRotatePinOff() {
+	var (
+		ctx     = context.Background()
+		status1 = suite.testStatuses["local_account_1_status_1"]
+	)
+
+	account, err := suite.db.GetAccountByID(ctx, suite.testAccounts["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Statuses set but
+	// no interval: off.
+	account.Settings.RotatingPinStatusIDs = []string{status1.ID}
+	account.Settings.RotatingPinInterval = 0
+
+	if err := suite.status.RotatePin(ctx, account, time.Now()); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(suite.pinnedIn(ctx, []string{status1.ID}))
+
+	// Statuses of other accounts are skipped.
+	account.Settings.RotatingPinStatusIDs = []string{suite.testStatuses["local_account_2_status_1"].ID}
+	account.Settings.RotatingPinInterval = time.Hour
+
+	if err := suite.status.RotatePin(ctx, account, time.Now()); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(suite.pinnedIn(ctx, account.Settings.RotatingPinStatusIDs))
+}
+
+func TestRotatePinTestSuite(t *testing.T) {
+	suite.Run(t, new(RotatePinTestSuite))
+}
@@ -0,0 +1,256 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// ScheduledCreate stores the given form as a status to be created on
+// behalf of requester at the given time, returning the api model of the
+// scheduled status. When the time comes, the status is created via the
+// same path as Create(), so the form is checked again at that point.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) ScheduledCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	application *gtsmodel.Application,
+	form *apimodel.AdvancedStatusCreateForm,
+	scheduledAt time.Time,
+) (
+	*apimodel.ScheduledStatus,
+	gtserror.WithCode,
+) {
+	now := time.Now()
+
+	if !scheduledAt.After(now) {
+		const text = "scheduled_at must be in the future"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	horizon := config.GetStatusesScheduledMaxHorizon()
+	if scheduledAt.After(now.Add(horizon)) {
+		text := "scheduled_at must be no more than " + horizon.String() + " in the future"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Draft status model, which is never stored
+	// in the database, used to check the form's
+	// in-reply-to and media now rather than only
+	// finding out about problems at publish time.
+	status := &gtsmodel.Status{
+		ID:        id.NewULID(),
+		CreatedAt: now,
+		Local:     util.Ptr(true),
+		Account:   requester,
+		AccountID: requester.ID,
+	}
+
+	if errWithCode := p.processInReplyTo(ctx,
+		requester,
+		status,
+		form.InReplyToID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.processMediaIDs(ctx, form, requester, status); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Store the form without its scheduling
+	// fields, since they don't apply to the
+	// status that will eventually be created.
+	params := *form
+	params.ScheduledAt = ""
+	params.IdempotencyKey = ""
+
+	b, err := json.Marshal(&params)
+	if err != nil {
+		err := gtserror.Newf("error encoding form: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	scheduled := &gtsmodel.ScheduledStatus{
+		ID:            id.NewULID(),
+		AccountID:     requester.ID,
+		Account:       requester,
+		ApplicationID: application.ID,
+		Application:   application,
+		ScheduledAt:   scheduledAt,
+		Params:        string(b),
+	}
+
+	if err := p.state.DB.PutScheduledStatus(ctx, scheduled); err != nil {
+		err := gtserror.Newf("db error putting scheduled status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Mark the attachments as belonging to the
+	// scheduled status, so they can't be used
+	// by any other status in the meantime.
+	for _, attachment := range status.Attachments {
+		attachment.ScheduledStatusID = scheduled.ID
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+			err := gtserror.Newf("db error updating attachment %s: %w", attachment.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if err := p.SchedulePublish(ctx, scheduled); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiScheduled, err := p.converter.ScheduledStatusToAPIScheduledStatus(ctx, scheduled)
+	if err != nil {
+		err := gtserror.Newf("error converting scheduled status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiScheduled, nil
+}
+
+// SchedulePublishAll schedules publishing of all stored scheduled
+// statuses, for use at startup. Any whose time has already passed
+// (eg., because the instance was down) are published immediately.
+func (p *Processor) SchedulePublishAll(ctx context.Context) error {
+	scheduled, err := p.state.DB.GetAllScheduledStatuses(ctx)
+	if err != nil {
+		return gtserror.Newf("error getting scheduled statuses from db: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, s := range scheduled {
+		// Schedule each of the statuses and catch any errors.
+		if err := p.SchedulePublish(ctx, s); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// SchedulePublish adds the given scheduled status to the
+// scheduler, to be published at its ScheduledAt time.
+func (p *Processor) SchedulePublish(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) error {
+	ok := p.state.Workers.Scheduler.AddOnce(
+		scheduled.ID,
+		scheduled.ScheduledAt,
+		p.onPublish(scheduled.ID),
+	)
+
+	if !ok {
+		// Failed to add the status to the scheduler, either it was
+		// starting / stopping or there already exists a task for it.
+		return gtserror.Newf("failed adding scheduled status %s to scheduler", scheduled.ID)
+	}
+
+	atStr := scheduled.ScheduledAt.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled status %s to be published at '%s'", scheduled.ID, atStr)
+	return nil
+}
+
+// onPublish returns a callback function to be used by the
+// scheduler when the given scheduled status is due. The
+// scheduled status is removed once handled, whether or
+// not it could actually be published.
+func (p *Processor) onPublish(scheduledID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		scheduled, err := p.state.DB.GetScheduledStatusByID(ctx, scheduledID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "error getting scheduled status %s from db: %v", scheduledID, err)
+			}
+			return
+		}
+
+		defer func() {
+			if err := p.state.DB.DeleteScheduledStatusByID(ctx, scheduledID); err != nil {
+				log.Errorf(ctx, "error deleting scheduled status %s: %v", scheduledID, err)
+			}
+		}()
+
+		if err := p.publish(ctx, scheduled); err != nil {
+			log.Errorf(ctx, "error publishing scheduled status %s: %v", scheduledID, err)
+		}
+	}
+}
+
+// publish creates a status from the given scheduled status.
+func (p *Processor) publish(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) error {
+	account, err := p.state.DB.GetAccountByID(ctx, scheduled.AccountID)
+	if err != nil {
+		return gtserror.Newf("error getting account %s: %w", scheduled.AccountID, err)
+	}
+
+	if account.IsSuspended() {
+		// Account has been suspended or
+		// deleted since scheduling, drop it.
+		return gtserror.Newf("account %s is suspended", account.ID)
+	}
+
+	application, err := p.state.DB.GetApplicationByID(ctx, scheduled.ApplicationID)
+	if err != nil {
+		return gtserror.Newf("error getting application %s: %w", scheduled.ApplicationID, err)
+	}
+
+	form := new(apimodel.AdvancedStatusCreateForm)
+	if err := json.Unmarshal([]byte(scheduled.Params), form); err != nil {
+		return gtserror.Newf("error decoding params: %w", err)
+	}
+
+	// Release the attachments from the scheduled
+	// status, so they can be attached to the new one.
+	for _, mediaID := range form.MediaIDs {
+		attachment, err := p.state.DB.GetAttachmentByID(ctx, mediaID)
+		if err != nil {
+			// Status creation will
+			// report missing media.
+			continue
+		}
+
+		if attachment.ScheduledStatusID != scheduled.ID {
+			continue
+		}
+
+		attachment.ScheduledStatusID = ""
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+			return gtserror.Newf("db error updating attachment %s: %w", attachment.ID, err)
+		}
+	}
+
+	if _, errWithCode := p.create(ctx, account, application, form); errWithCode != nil {
+		return errWithCode
+	}
+
+	return nil
+}
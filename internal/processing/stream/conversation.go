@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+// ConversationSubscribers returns the IDs of accounts
+// with an open conversation stream for the given thread.
+func (p *Processor) ConversationSubscribers(threadID string) []string {
+	return p.streams.Subscribers(stream.TimelineConversation + ":" + threadID)
+}
+
+// conversationStreamType returns the stream type of the conversation
+// stream for the thread of the given status, if it's visible to account.
+func (p *Processor) conversationStreamType(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	statusID string,
+) (string, gtserror.WithCode) {
+	status, err := p.state.DB.GetStatusByID(ctx, statusID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting status %s: %w", statusID, err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	if status != nil {
+		visible, err := p.visFilter.StatusVisible(ctx, account, status)
+		if err != nil {
+			err := gtserror.Newf("error checking visibility of status %s: %w", statusID, err)
+			return "", gtserror.NewErrorInternalError(err)
+		}
+
+		if !visible {
+			// Don't leak its existence.
+			status = nil
+		}
+	}
+
+	if status == nil {
+		const text = "status not found"
+		return "", gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	if status.ThreadID == "" {
+		// Only threads local accounts are involved
+		// in are tracked, so we can't stream others.
+		const text = "conversation of this status can't be streamed"
+		return "", gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return stream.TimelineConversation + ":" + status.ThreadID, nil
+}
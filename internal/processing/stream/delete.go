@@ -20,14 +20,30 @@ package stream
 import (
 	"context"
 
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
 )
 
 // Delete streams the delete of the given statusID to *ALL* open streams.
-func (p *Processor) Delete(ctx context.Context, statusID string) {
+// The reason, one of the stream.DeleteReason consts, is only included
+// on streams that opted in to detailed delete events.
+func (p *Processor) Delete(ctx context.Context, statusID string, reason string) {
 	p.streams.PostAll(ctx, stream.Message{
-		Payload: statusID,
-		Event:   stream.EventTypeDelete,
-		Stream:  stream.AllStatusTimelines,
+		Payload:      statusID,
+		Event:        stream.EventTypeDelete,
+		Stream:       stream.AllStatusTimelines,
+		DeleteReason: reason,
+	})
+}
+
+// DeleteForAccount streams the delete of the given statusID to
+// open streams of only the given account, for statuses that were
+// removed from view of that account, but not deleted outright.
+func (p *Processor) DeleteForAccount(ctx context.Context, account *gtsmodel.Account, statusID string, reason string) {
+	p.streams.Post(ctx, account.ID, stream.Message{
+		Payload:      statusID,
+		Event:        stream.EventTypeDelete,
+		Stream:       stream.AllStatusTimelines,
+		DeleteReason: reason,
 	})
 }
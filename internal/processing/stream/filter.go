@@ -0,0 +1,159 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+// listFilter drops statuses from the list streams of one connection
+// according to a selected set of an account's filters, as applied by
+// typeutils.StatusKeptByFilters.
+//
+// Selected filters apply regardless of their configured context,
+// and statuses matching block filters are always dropped rather than
+// sent with a warning, so that a noisy list can be quieted server side.
+// Selected allow filters narrow the list to statuses matching them.
+type listFilter struct {
+	p         *Processor
+	accountID string
+	filterIDs []string
+
+	// resolved filters, and the account
+	// filters version they were resolved at.
+	mu      sync.Mutex
+	filters []*gtsmodel.Filter
+	version int64
+}
+
+// newListFilter returns a new listFilter for the given
+// account, resolving the filters with the given IDs.
+func (p *Processor) newListFilter(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterIDs []string,
+) (*listFilter, gtserror.WithCode) {
+	f := &listFilter{
+		p:         p,
+		accountID: account.ID,
+		filterIDs: filterIDs,
+	}
+
+	if err := f.resolve(ctx); err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			const text = "filter not found"
+			return nil, gtserror.NewErrorNotFound(err, text)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return f, nil
+}
+
+// resolve fetches the latest versions of the
+// selected filters, checking account owns them.
+func (f *listFilter) resolve(ctx context.Context) error {
+	// Note version before fetching, so changes
+	// made while fetching trigger a new resolve.
+	version := f.p.filtersVersion(f.accountID).Load()
+
+	filters := make([]*gtsmodel.Filter, 0, len(f.filterIDs))
+	for _, id := range f.filterIDs {
+		filter, err := f.p.state.DB.GetFilterByID(ctx, id)
+		if err != nil {
+			return gtserror.Newf("error getting filter %s: %w", id, err)
+		}
+
+		if filter.AccountID != f.accountID {
+			// Not ours, treat as not found.
+			return gtserror.Newf("filter %s: %w", id, db.ErrNoEntries)
+		}
+
+		filters = append(filters, filter)
+	}
+
+	f.filters = filters
+	f.version = version
+	return nil
+}
+
+// Keep implements stream.Filter.
+func (f *listFilter) Keep(msg stream.Message) bool {
+	if msg.Status == nil {
+		// Not a status.
+		return true
+	}
+
+	if len(msg.Stream) == 0 ||
+		!strings.HasPrefix(msg.Stream[0], stream.TimelineList+":") {
+		// Only applies to lists.
+		return true
+	}
+
+	if msg.Status.GetAccountID() == f.accountID {
+		// Never drop own statuses, since
+		// not seeing them is confusing.
+		return true
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.version != f.p.filtersVersion(f.accountID).Load() {
+		// Filters have changed since
+		// resolving, get latest versions.
+		// On error, the previous filters
+		// remain in use until next change.
+		if err := f.resolve(context.Background()); err != nil {
+			log.Errorf(nil, "error resolving stream filters: %v", err)
+		}
+	}
+
+	// Match filters against the stored status, so
+	// it's done just as when assembling timelines.
+	ctx := context.Background()
+	status, err := f.p.state.DB.GetStatusByID(ctx, msg.Status.ID)
+	if err != nil {
+		// Can't check, so keep. If the status
+		// is gone, a delete will follow anyway.
+		log.Errorf(ctx, "error getting status %s to filter: %v", msg.Status.ID, err)
+		return true
+	}
+
+	return typeutils.StatusKeptByFilters(status, f.filters, time.Now())
+}
+
+// filtersVersion returns the version counter of
+// filters for account with the given ID, which is
+// incremented whenever the account's filters change.
+func (p *Processor) filtersVersion(accountID string) *atomic.Int64 {
+	v, _ := p.filterVersions.LoadOrStore(accountID, new(atomic.Int64))
+	return v.(*atomic.Int64)
+}
@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+type FilterTestSuite struct {
+	StreamTestSuite
+}
+
+// putStatus stores a new status by local_account_2 with the
+// given ID and content, and returns it converted for streaming.
+func (suite *FilterTestSuite) putStatus(id string, content string) *apimodel.Status {
+	status := new(gtsmodel.Status)
+	*status = *suite.testStatuses["local_account_2_status_1"]
+	status.ID = id
+	status.URI = "http://localhost:8080/users/1happyturtle/statuses/" + id
+	status.URL = "http://localhost:8080/@1happyturtle/statuses/" + id
+	status.Content = content
+	status.Text = content
+	status.ContentWarning = ""
+	if err := suite.db.PutStatus(context.Background(), status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return &apimodel.Status{
+		ID:      status.ID,
+		Account: &apimodel.Account{ID: status.AccountID},
+		Content: status.Content,
+	}
+}
+
+func (suite *FilterTestSuite) TestListStreamFilterHomeContext() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+
+		// Filter with keyword "fnord",
+		// configured for home context.
+		filterID   = "01HN26VM6KZTW1ANNRVSBMA461"
+		streamType = stream.TimelineList + ":01HHJ7AGNKAFKXTWCX6NC3DREF"
+	)
+
+	matching := suite.putStatus("01J1ZJ3ZNJ6WB1QSXG2Z2H0B6E", "<p>all hail the fnord</p>")
+	nonMatching := suite.putStatus("01J1ZJ4B5X7QWQ3C2B1J1D5XHM", "<p>hello world</p>")
+
+	openStream, errWithCode := suite.streamProcessor.Open(ctx, account, streamType, filterID)
+	suite.NoError(errWithCode)
+
+	// Matching status should be dropped,
+	// non-matching status received.
+	suite.streamProcessor.Update(ctx, account, matching, streamType)
+	suite.streamProcessor.Update(ctx, account, nonMatching, streamType)
+
+	recvCtx, cncl := context.WithTimeout(ctx, time.Second)
+	defer cncl()
+
+	msg, ok := openStream.Recv(recvCtx)
+	suite.True(ok)
+	suite.Equal(stream.EventTypeUpdate, msg.Event)
+	suite.Contains(msg.Payload, nonMatching.ID)
+	suite.NotContains(msg.Payload, "fnord")
+}
+
+func (suite *FilterTestSuite) TestListStreamFilterAllowMode() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+
+		// Filter with keyword "fnord".
+		filterID   = "01HN26VM6KZTW1ANNRVSBMA461"
+		streamType = stream.TimelineList + ":01HHJ7AGNKAFKXTWCX6NC3DREF"
+	)
+
+	// Turn the filter into an allow filter.
+	filter, err := suite.db.GetFilterByID(ctx, filterID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	filter.Mode = gtsmodel.FilterModeAllow
+	if err := suite.db.UpdateFilter(ctx, filter, []string{"mode"}, make([][]string, len(filter.Keywords)), nil, nil); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	matching := suite.putStatus("01J1ZJ3ZNJ6WB1QSXG2Z2H0B6E", "<p>all hail the fnord</p>")
+	nonMatching := suite.putStatus("01J1ZJ4B5X7QWQ3C2B1J1D5XHM", "<p>hello world</p>")
+
+	openStream, errWithCode := suite.streamProcessor.Open(ctx, account, streamType, filterID)
+	suite.NoError(errWithCode)
+
+	// Now only the matching status
+	// should be let through.
+	suite.streamProcessor.Update(ctx, account, nonMatching, streamType)
+	suite.streamProcessor.Update(ctx, account, matching, streamType)
+
+	recvCtx, cncl := context.WithTimeout(ctx, time.Second)
+	defer cncl()
+
+	msg, ok := openStream.Recv(recvCtx)
+	suite.True(ok)
+	suite.Equal(stream.EventTypeUpdate, msg.Event)
+	suite.Contains(msg.Payload, matching.ID)
+	suite.NotContains(msg.Payload, "hello world")
+}
+
+func (suite *FilterTestSuite) TestListStreamFilterNotFound() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_2"]
+
+		// Filter owned by local_account_1.
+		filterID = "01HN26VM6KZTW1ANNRVSBMA461"
+	)
+
+	_, errWithCode := suite.streamProcessor.Open(ctx, account, stream.TimelineList+":01HHJ7AGNKAFKXTWCX6NC3DREF", filterID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestFilterTestSuite(t *testing.T) {
+	suite.Run(t, &FilterTestSuite{})
+}
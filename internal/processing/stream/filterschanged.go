@@ -26,11 +26,20 @@ import (
 
 // FiltersChanged streams a filters changed event to any open, appropriate streams belonging to the given account.
 // Filter changes have no payload.
+//
+// Streams that opted in to retroactive filtering are also sent delete events
+// for recently sent statuses that their filters now drop. See stream.Refilter.
 func (p *Processor) FiltersChanged(ctx context.Context, account *gtsmodel.Account) {
+	// Ensure any filters selected for
+	// list streams are re-resolved.
+	p.filtersVersion(account.ID).Add(1)
+
 	p.streams.Post(ctx, account.ID, stream.Message{
 		Event: stream.EventTypeFiltersChanged,
 		Stream: []string{
 			stream.TimelineHome,
 		},
 	})
+
+	p.streams.Refilter(ctx, account.ID)
 }
@@ -42,5 +42,7 @@ func (p *Processor) Notify(ctx context.Context, account *gtsmodel.Account, notif
 			stream.TimelineNotifications,
 			stream.TimelineHome,
 		},
+		Notification:     notif,
+		NotificationType: notif.Type,
 	})
 }
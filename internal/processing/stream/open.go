@@ -19,6 +19,7 @@ package stream
 
 import (
 	"context"
+	"strings"
 
 	"codeberg.org/gruf/go-kv"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -28,11 +29,51 @@ import (
 )
 
 // Open returns a new Stream for the given account, which will contain a channel for passing messages back to the caller.
-func (p *Processor) Open(ctx context.Context, account *gtsmodel.Account, streamType string) (*stream.Stream, gtserror.WithCode) {
+//
+// If any filter IDs are given, statuses matching those filters of the account will be dropped from list streams,
+// regardless of the filters' context and action. The filters are re-resolved whenever the account's filters change.
+func (p *Processor) Open(ctx context.Context, account *gtsmodel.Account, streamType string, filterIDs ...string) (*stream.Stream, gtserror.WithCode) {
+	return p.Resume(ctx, account, streamType, "", filterIDs...)
+}
+
+// Resume is like Open, but if lastEventID is set, the returned Stream will first
+// replay messages missed since the message with that ID, or a reset event if these
+// are no longer available.
+func (p *Processor) Resume(ctx context.Context, account *gtsmodel.Account, streamType string, lastEventID string, filterIDs ...string) (*stream.Stream, gtserror.WithCode) {
 	l := log.WithContext(ctx).WithFields(kv.Fields{
 		{"account", account.ID},
 		{"streamType", streamType},
+		{"lastEventID", lastEventID},
+		{"filterIDs", filterIDs},
 	}...)
 	l.Debug("received open stream request")
-	return p.streams.Open(account.ID, streamType), nil
+
+	if statusID, ok := strings.CutPrefix(streamType, stream.TimelineConversation+":"); ok {
+		// Conversation streams are requested by the ID
+		// of any status in the thread, but keyed by the
+		// thread ID, so they get replies to any of them.
+		var errWithCode gtserror.WithCode
+		streamType, errWithCode = p.conversationStreamType(ctx, account, statusID)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	var filter *listFilter
+	if len(filterIDs) > 0 {
+		// Resolve filters before opening,
+		// so errors can be returned early.
+		var errWithCode gtserror.WithCode
+		filter, errWithCode = p.newListFilter(ctx, account, filterIDs)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	str := p.streams.Resume(account.ID, lastEventID, streamType)
+	if filter != nil {
+		str.SetFilter(filter.Keep)
+	}
+
+	return str, nil
 }
@@ -19,9 +19,11 @@ package stream_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
 )
 
 type OpenStreamTestSuite struct {
@@ -35,6 +37,49 @@ func (suite *OpenStreamTestSuite) TestOpenStream() {
 	suite.NoError(errWithCode)
 }
 
+func (suite *OpenStreamTestSuite) TestOpenConversationStream() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_2"]
+		status  = suite.testStatuses["local_account_1_status_1"]
+	)
+
+	str, errWithCode := suite.streamProcessor.Open(ctx, account, stream.TimelineConversation+":"+status.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Stream should be keyed by thread ID.
+	suite.Equal([]string{account.ID}, suite.streamProcessor.ConversationSubscribers(status.ThreadID))
+
+	// Subscription should be gone once closed.
+	str.Close()
+	suite.Empty(suite.streamProcessor.ConversationSubscribers(status.ThreadID))
+}
+
+func (suite *OpenStreamTestSuite) TestOpenConversationStreamNoThread() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_2"]
+		status  = suite.testStatuses["admin_account_status_4"]
+	)
+
+	_, errWithCode := suite.streamProcessor.Open(ctx, account, stream.TimelineConversation+":"+status.ID)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *OpenStreamTestSuite) TestOpenConversationStreamNotFound() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_2"]
+	)
+
+	_, errWithCode := suite.streamProcessor.Open(ctx, account, stream.TimelineConversation+":01HCWDKKBWECZJQ93E262N36VN")
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
 func TestOpenStreamTestSuite(t *testing.T) {
 	suite.Run(t, &OpenStreamTestSuite{})
 }
@@ -39,5 +39,6 @@ func (p *Processor) StatusUpdate(ctx context.Context, account *gtsmodel.Account,
 		Payload: byteutil.B2S(b),
 		Event:   stream.EventTypeStatusUpdate,
 		Stream:  []string{streamType},
+		Status:  status,
 	})
 }
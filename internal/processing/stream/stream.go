@@ -18,6 +18,10 @@
 package stream
 
 import (
+	"sync"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
@@ -27,12 +31,22 @@ type Processor struct {
 	state       *state.State
 	oauthServer oauth.Server
 	streams     stream.Streams
+	visFilter   *visibility.Filter
+
+	// account ID -> *atomic.Int64
+	// version of account's filters,
+	// for re-resolving list filters.
+	filterVersions sync.Map
 }
 
 func New(state *state.State, oauthServer oauth.Server) Processor {
 	return Processor{
 		state:       state,
 		oauthServer: oauthServer,
-		streams:     stream.Streams{},
+		visFilter:   visibility.NewFilter(state),
+		streams: stream.Streams{
+			QueueSize:    config.GetAdvancedStreamingQueueSize(),
+			SlowConsumer: config.GetAdvancedStreamingSlowConsumer(),
+		},
 	}
 }
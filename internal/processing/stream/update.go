@@ -39,5 +39,6 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, statu
 		Payload: byteutil.B2S(b),
 		Event:   stream.EventTypeUpdate,
 		Stream:  []string{streamType},
+		Status:  status,
 	})
 }
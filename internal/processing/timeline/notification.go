@@ -43,6 +43,8 @@ func (p *Processor) NotificationsGet(
 	limit int,
 	types []string,
 	excludeTypes []string,
+	accountID string,
+	excludeAccountIDs []string,
 ) (*apimodel.PageableResponse, gtserror.WithCode) {
 	notifs, err := p.state.DB.GetAccountNotifications(
 		ctx,
@@ -53,6 +55,8 @@ func (p *Processor) NotificationsGet(
 		limit,
 		types,
 		excludeTypes,
+		accountID,
+		excludeAccountIDs,
 	)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		err = fmt.Errorf("NotificationsGet: db error getting notifications: %w", err)
@@ -0,0 +1,93 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// ConsentsGet returns the applications that the given
+// user approved and asked to remember approval for.
+func (p *Processor) ConsentsGet(ctx context.Context, user *gtsmodel.User) ([]*apimodel.Consent, gtserror.WithCode) {
+	consents, err := p.state.DB.GetConsentsByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting consents: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiConsents := make([]*apimodel.Consent, 0, len(consents))
+	for _, consent := range consents {
+		app, err := p.state.DB.GetApplicationByClientID(ctx, consent.ClientID)
+		if err != nil {
+			// Application may have been deleted
+			// since; consent is useless then anyway.
+			log.Errorf(ctx, "error getting application for consent %s: %v", consent.ID, err)
+			continue
+		}
+
+		apiApp, err := p.converter.AppToAPIAppPublic(ctx, app)
+		if err != nil {
+			log.Errorf(ctx, "error converting application for consent %s: %v", consent.ID, err)
+			continue
+		}
+
+		apiConsents = append(apiConsents, &apimodel.Consent{
+			ID:          consent.ID,
+			Application: apiApp,
+			Scope:       consent.Scope,
+			CreatedAt:   util.FormatISO8601(consent.CreatedAt),
+			UpdatedAt:   util.FormatISO8601(consent.UpdatedAt),
+		})
+	}
+
+	return apiConsents, nil
+}
+
+// ConsentDelete revokes the given user's consent with the
+// given ID, so that the application it was for has to ask the
+// user for approval again the next time it wants authorizing.
+//
+// Tokens already issued to the application are not affected.
+func (p *Processor) ConsentDelete(ctx context.Context, user *gtsmodel.User, id string) gtserror.WithCode {
+	consent, err := p.state.DB.GetConsentByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting consent: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if consent == nil || consent.UserID != user.ID {
+		// Don't reveal other users' consents.
+		const text = "consent not found"
+		return gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	if err := p.state.DB.DeleteConsentByID(ctx, consent.ID); err != nil {
+		err := gtserror.Newf("db error deleting consent: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ConsentTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *ConsentTestSuite) TestConsentsGetAndDelete() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	app := testrig.NewTestApplications()["application_1"]
+
+	consent := &gtsmodel.Consent{
+		ID:       "01J3K2DQTJ4NR1FJ0P6RPSXD9Q",
+		UserID:   user.ID,
+		ClientID: app.ClientID,
+		Scope:    "read",
+	}
+	suite.NoError(suite.db.PutConsent(ctx, consent))
+
+	// Remembering again for the same client
+	// replaces the scope, not the consent.
+	suite.NoError(suite.db.PutConsent(ctx, &gtsmodel.Consent{
+		ID:       "01J3K2F1VQ3M6CG1QRMQ5T0V8S",
+		UserID:   user.ID,
+		ClientID: app.ClientID,
+		Scope:    "read write",
+	}))
+
+	consents, errWithCode := suite.user.ConsentsGet(ctx, user)
+	suite.NoError(errWithCode)
+	suite.Len(consents, 1)
+	suite.Equal(consent.ID, consents[0].ID)
+	suite.Equal("read write", consents[0].Scope)
+	suite.Equal(app.Name, consents[0].Application.Name)
+
+	// Other users can't revoke it.
+	errWithCode = suite.user.ConsentDelete(ctx, suite.testUsers["local_account_2"], consent.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	errWithCode = suite.user.ConsentDelete(ctx, user, consent.ID)
+	suite.NoError(errWithCode)
+
+	consents, errWithCode = suite.user.ConsentsGet(ctx, user)
+	suite.NoError(errWithCode)
+	suite.Empty(consents)
+}
+
+func TestConsentTestSuite(t *testing.T) {
+	suite.Run(t, new(ConsentTestSuite))
+}
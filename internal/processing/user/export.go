@@ -0,0 +1,456 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sync"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// exportPageSize is the number of
+	// statuses to export in each page.
+	exportPageSize = 50
+
+	// exportPageInterval is the minimum time spent on
+	// each page of statuses (and their media), so that
+	// exporting a large account doesn't starve the
+	// instance of database and storage capacity.
+	exportPageInterval = time.Second
+
+	// exportMinInterval is the minimum time between
+	// successful exports of the same account.
+	exportMinInterval = 24 * time.Hour
+
+	exportStatePending = "pending"
+	exportStateRunning = "running"
+	exportStateDone    = "done"
+	exportStateFailed  = "failed"
+)
+
+// exportJobs tracks the most
+// recent export of each account.
+type exportJobs struct {
+	mu   sync.Mutex
+	jobs map[string]*exportJob // by account ID
+}
+
+// exportJob tracks the
+// progress of an export.
+type exportJob struct {
+	mu            sync.Mutex
+	id            string
+	state         string
+	createdAt     time.Time
+	completedAt   time.Time
+	statusesTotal int
+	statusesDone  int
+	mediaDone     int
+	size          int64
+}
+
+// update calls fn to update
+// the job with its mutex held.
+func (j *exportJob) update(fn func(j *exportJob)) {
+	j.mu.Lock()
+	fn(j)
+	j.mu.Unlock()
+}
+
+// toAPI returns the API model of the job.
+func (j *exportJob) toAPI() *apimodel.AccountExport {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	apiExport := &apimodel.AccountExport{
+		ID:            j.id,
+		State:         j.state,
+		CreatedAt:     util.FormatISO8601(j.createdAt),
+		StatusesTotal: j.statusesTotal,
+		StatusesDone:  j.statusesDone,
+		MediaDone:     j.mediaDone,
+		Size:          j.size,
+	}
+
+	switch j.state {
+	case exportStateDone:
+		apiExport.CompletedAt = util.Ptr(util.FormatISO8601(j.completedAt))
+		apiExport.ExpiresAt = util.Ptr(util.FormatISO8601(j.createdAt.Add(storage.ExportRetention)))
+		apiExport.ArchiveURL = config.GetProtocol() + "://" + config.GetHost() + "/api/v1/user/export/archive"
+
+	case exportStateFailed:
+		apiExport.CompletedAt = util.Ptr(util.FormatISO8601(j.completedAt))
+		apiExport.Error = "error building export archive, please try again later"
+	}
+
+	return apiExport
+}
+
+// ExportCreate starts a new export of the given account's profile,
+// statuses, and media, replacing any previous export. Exports are
+// built in the background; use ExportGet to check on progress.
+func (p *Processor) ExportCreate(ctx context.Context, account *gtsmodel.Account) (*apimodel.AccountExport, gtserror.WithCode) {
+	p.exports.mu.Lock()
+	defer p.exports.mu.Unlock()
+
+	var prevID string
+	if prev := p.exports.jobs[account.ID]; prev != nil {
+		prev.mu.Lock()
+		prevID = prev.id
+		state, createdAt := prev.state, prev.createdAt
+		prev.mu.Unlock()
+
+		switch {
+		case state == exportStatePending || state == exportStateRunning:
+			const text = "an export is already in progress"
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+
+		case state == exportStateDone && time.Since(createdAt) < exportMinInterval:
+			text := fmt.Sprintf("an export was already made in the last %s, please use that one", exportMinInterval)
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	job := &exportJob{
+		id:        id.NewULID(),
+		state:     exportStatePending,
+		createdAt: time.Now(),
+	}
+	p.exports.jobs[account.ID] = job
+
+	p.state.Workers.Export.Queue.Push(func(ctx context.Context) {
+		p.export(ctx, account, job, prevID)
+	})
+
+	return job.toAPI(), nil
+}
+
+// ExportGet returns the progress of the given account's most recent export.
+func (p *Processor) ExportGet(ctx context.Context, account *gtsmodel.Account) (*apimodel.AccountExport, gtserror.WithCode) {
+	p.exports.mu.Lock()
+	job := p.exports.jobs[account.ID]
+	p.exports.mu.Unlock()
+
+	if job == nil {
+		const text = "no export found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return job.toAPI(), nil
+}
+
+// ExportArchive returns the archive of the given account's most recent
+// export, either as a presigned URL (if using S3 storage without proxying),
+// or as content to stream to the caller, if it has finished successfully.
+func (p *Processor) ExportArchive(ctx context.Context, account *gtsmodel.Account) (*apimodel.Content, gtserror.WithCode) {
+	p.exports.mu.Lock()
+	job := p.exports.jobs[account.ID]
+	p.exports.mu.Unlock()
+
+	if job == nil {
+		const text = "no export found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	job.mu.Lock()
+	exportID, state := job.id, job.state
+	size, completedAt := job.size, job.completedAt
+	job.mu.Unlock()
+
+	if state != exportStateDone {
+		const text = "export has not finished successfully"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	key := storage.ExportKey(account.ID, exportID)
+
+	if url := p.state.Storage.URL(ctx, key); url != nil {
+		// Caller can fetch
+		// from S3 directly.
+		return &apimodel.Content{URL: url}, nil
+	}
+
+	rc, err := p.state.Storage.GetStream(ctx, key)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			const text = "export archive has expired"
+			return nil, gtserror.NewErrorNotFound(err, text)
+		}
+		err := gtserror.Newf("error getting export archive %s: %w", key, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.Content{
+		ContentType:    "application/zip",
+		ContentLength:  size,
+		ContentUpdated: completedAt,
+		Content:        rc,
+	}, nil
+}
+
+// export builds the archive for the given export job,
+// streaming it to storage, and removes the archive of
+// the previous export (with given ID) if any.
+func (p *Processor) export(ctx context.Context, account *gtsmodel.Account, job *exportJob, prevID string) {
+	job.update(func(j *exportJob) { j.state = exportStateRunning })
+
+	if prevID != "" {
+		// The previous export is replaced
+		// by this one, so remove its archive.
+		prevKey := storage.ExportKey(account.ID, prevID)
+		if err := p.state.Storage.Delete(ctx, prevKey); err != nil && !storage.IsNotFound(err) {
+			log.Warnf(ctx, "error removing previous export archive %s: %v", prevKey, err)
+		}
+	}
+
+	key := storage.ExportKey(account.ID, job.id)
+
+	// Write the archive into a pipe as it's
+	// uploaded, rather than building it all
+	// in memory first. Closing the writer
+	// with an error aborts the upload.
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(p.writeExport(ctx, account, job, pw))
+	}()
+
+	size, err := p.state.Storage.PutStream(ctx, key, pr)
+
+	// Ensure the writer stops
+	// if the upload failed.
+	pr.CloseWithError(err)
+
+	if err != nil {
+		log.Errorf(ctx, "error exporting account %s: %v", account.ID, err)
+
+		// Remove any partial archive.
+		if err := p.state.Storage.Delete(ctx, key); err != nil && !storage.IsNotFound(err) {
+			log.Warnf(ctx, "error removing partial export archive %s: %v", key, err)
+		}
+
+		job.update(func(j *exportJob) {
+			j.state = exportStateFailed
+			j.completedAt = time.Now()
+		})
+		return
+	}
+
+	job.update(func(j *exportJob) {
+		j.state = exportStateDone
+		j.completedAt = time.Now()
+		j.size = size
+	})
+}
+
+// writeExport writes a zip archive of the given account's
+// profile, statuses, and media to w, updating the progress
+// of the given export job as it goes. The archive contains:
+//
+//   - account.json: the account's profile.
+//   - statuses/[page].json: the account's statuses, newest first.
+//   - media/[id].[ext]: the account's avatar, header, and the
+//     media attached to its statuses, by attachment ID.
+func (p *Processor) writeExport(ctx context.Context, account *gtsmodel.Account, job *exportJob, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	apiAccount, err := p.converter.AccountToAPIAccountSensitive(ctx, account)
+	if err != nil {
+		return gtserror.Newf("error converting account: %w", err)
+	}
+
+	if err := writeExportJSON(zw, "account.json", apiAccount); err != nil {
+		return err
+	}
+
+	for _, attachmentID := range []string{
+		account.AvatarMediaAttachmentID,
+		account.HeaderMediaAttachmentID,
+	} {
+		if attachmentID == "" {
+			continue
+		}
+
+		attachment, err := p.state.DB.GetAttachmentByID(ctx, attachmentID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				continue
+			}
+			return gtserror.Newf("error getting attachment %s: %w", attachmentID, err)
+		}
+
+		if err := p.writeExportMedia(ctx, zw, job, attachment); err != nil {
+			return err
+		}
+	}
+
+	if err := p.state.DB.PopulateAccountStats(ctx, account); err != nil {
+		return gtserror.Newf("error getting account stats: %w", err)
+	}
+
+	job.update(func(j *exportJob) {
+		j.statusesTotal = util.PtrValueOr(account.Stats.StatusesCount, 0)
+	})
+
+	var maxID string
+	for page := 1; ; page++ {
+		start := time.Now()
+
+		statuses, err := p.state.DB.GetAccountStatuses(ctx,
+			account.ID,
+			exportPageSize,
+			false, // include replies
+			false, // include boosts
+			maxID,
+			"",
+			false, // all statuses
+			false, // of any visibility
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("error getting statuses: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			// Done.
+			break
+		}
+
+		maxID = statuses[len(statuses)-1].ID
+
+		apiStatuses := make([]*apimodel.Status, 0, len(statuses))
+		for _, status := range statuses {
+			apiStatus, err := p.converter.StatusToAPIStatus(ctx,
+				status,
+				account,
+				statusfilter.FilterContextNone,
+				nil, // no filters
+				nil, // no mutes
+			)
+			if err != nil {
+				log.Errorf(ctx, "error converting status %s: %v", status.ID, err)
+				continue
+			}
+			apiStatuses = append(apiStatuses, apiStatus)
+		}
+
+		name := fmt.Sprintf("statuses/%05d.json", page)
+		if err := writeExportJSON(zw, name, apiStatuses); err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			for _, attachment := range status.Attachments {
+				if attachment.AccountID != account.ID {
+					// Not ours,
+					// eg., a boost.
+					continue
+				}
+
+				if err := p.writeExportMedia(ctx, zw, job, attachment); err != nil {
+					return err
+				}
+			}
+
+			job.update(func(j *exportJob) { j.statusesDone++ })
+		}
+
+		// Pace pages so as not to starve the instance.
+		if wait := exportPageInterval - time.Since(start); wait > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+	}
+
+	return zw.Close()
+}
+
+// writeExportMedia copies the file of the given attachment from
+// storage into the given zip archive, if it's cached in storage.
+func (p *Processor) writeExportMedia(
+	ctx context.Context,
+	zw *zip.Writer,
+	job *exportJob,
+	attachment *gtsmodel.MediaAttachment,
+) error {
+	if !util.PtrValueOr(attachment.Cached, false) || attachment.File.Path == "" {
+		return nil
+	}
+
+	rc, err := p.state.Storage.GetStream(ctx, attachment.File.Path)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			log.Warnf(ctx, "file for attachment %s missing from storage", attachment.ID)
+			return nil
+		}
+		return gtserror.Newf("error getting file for attachment %s: %w", attachment.ID, err)
+	}
+	defer rc.Close()
+
+	// Media is already compressed,
+	// so store it in the archive as-is.
+	fw, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     "media/" + attachment.ID + path.Ext(attachment.File.Path),
+		Method:   zip.Store,
+		Modified: attachment.CreatedAt,
+	})
+	if err != nil {
+		return gtserror.Newf("error creating archive entry for attachment %s: %w", attachment.ID, err)
+	}
+
+	if _, err := io.Copy(fw, rc); err != nil {
+		return gtserror.Newf("error copying file for attachment %s: %w", attachment.ID, err)
+	}
+
+	job.update(func(j *exportJob) { j.mediaDone++ })
+	return nil
+}
+
+// writeExportJSON writes v as JSON into
+// the given zip archive, at given name.
+func writeExportJSON(zw *zip.Writer, name string, v any) error {
+	fw, err := zw.Create(name)
+	if err != nil {
+		return gtserror.Newf("error creating archive entry %s: %w", name, err)
+	}
+
+	if err := json.NewEncoder(fw).Encode(v); err != nil {
+		return gtserror.Newf("error encoding archive entry %s: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ExportTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *ExportTestSuite) TestExport() {
+	ctx := context.Background()
+
+	account, err := suite.db.GetAccountByID(ctx, suite.testUsers["local_account_1"].AccountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Start an export.
+	export, errWithCode := suite.user.ExportCreate(ctx, account)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("pending", export.State)
+
+	// Can't start another while this one is pending.
+	_, errWithCode = suite.user.ExportCreate(ctx, account)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Nothing to download yet.
+	_, errWithCode = suite.user.ExportArchive(ctx, account)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Run the queued export.
+	fn, ok := suite.state.Workers.Export.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected export to be queued")
+	}
+	fn(ctx)
+
+	export, errWithCode = suite.user.ExportGet(ctx, account)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("done", export.State)
+	suite.NotNil(export.CompletedAt)
+	suite.NotZero(export.StatusesTotal)
+	suite.Equal(export.StatusesTotal, export.StatusesDone)
+	suite.NotZero(export.Size)
+	suite.Equal("http://localhost:8080/api/v1/user/export/archive", export.ArchiveURL)
+
+	// Can't start another so soon after.
+	_, errWithCode = suite.user.ExportCreate(ctx, account)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Download the archive.
+	content, errWithCode := suite.user.ExportArchive(ctx, account)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer content.Content.Close()
+	suite.Equal("application/zip", content.ContentType)
+
+	b, err := io.ReadAll(content.Content)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(export.Size, int64(len(b)))
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var (
+		hasAccount bool
+		pages      int
+		media      int
+	)
+	for _, f := range zr.File {
+		switch {
+		case f.Name == "account.json":
+			hasAccount = true
+		case strings.HasPrefix(f.Name, "statuses/"):
+			pages++
+		case strings.HasPrefix(f.Name, "media/"):
+			media++
+		}
+	}
+	suite.True(hasAccount)
+	suite.NotZero(pages)
+	suite.Equal(export.MediaDone, media)
+}
+
+func TestExportTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
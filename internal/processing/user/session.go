@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// SessionsGet returns the sessions (ie., active access tokens)
+// of the given user, newest first. The session with the given
+// current access token is marked as the current session.
+func (p *Processor) SessionsGet(ctx context.Context, user *gtsmodel.User, currentAccess string) ([]*apimodel.Session, gtserror.WithCode) {
+	tokens, err := p.state.DB.GetTokensByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tokens: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSessions := make([]*apimodel.Session, 0, len(tokens))
+	for _, token := range tokens {
+		app, err := p.state.DB.GetApplicationByClientID(ctx, token.ClientID)
+		if err != nil {
+			// Application may have been deleted
+			// since; token is useless then anyway.
+			log.Errorf(ctx, "error getting application for token %s: %v", token.ID, err)
+			continue
+		}
+
+		apiSession, err := p.converter.TokenToAPISession(ctx, token, app, currentAccess)
+		if err != nil {
+			log.Errorf(ctx, "error converting token %s: %v", token.ID, err)
+			continue
+		}
+
+		apiSessions = append(apiSessions, apiSession)
+	}
+
+	return apiSessions, nil
+}
+
+// SessionDelete revokes the given user's session with the
+// given ID, logging the device or app it belongs to out.
+//
+// Tokens previously refreshed into or out of the session's
+// token are revoked too, so it can't be revived by refreshing.
+func (p *Processor) SessionDelete(ctx context.Context, user *gtsmodel.User, id string) gtserror.WithCode {
+	token, err := p.state.DB.GetTokenByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting token: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if token == nil || token.UserID != user.ID {
+		// Don't reveal other users' sessions.
+		const text = "session not found"
+		return gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	if err := p.state.DB.DeleteTokenByID(ctx, token.ID); err != nil {
+		err := gtserror.Newf("db error deleting token: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if token.FamilyID != "" {
+		if err := p.state.DB.DeleteTokensByFamilyID(ctx, token.FamilyID); err != nil {
+			err := gtserror.Newf("db error deleting token family: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
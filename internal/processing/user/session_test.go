@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SessionTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *SessionTestSuite) TestSessionsGetAndDelete() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	token := testrig.NewTestTokens()["local_account_1"]
+
+	sessions, errWithCode := suite.user.SessionsGet(ctx, user, token.Access)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(sessions)
+
+	var found bool
+	for _, session := range sessions {
+		if session.ID == token.ID {
+			found = true
+			suite.True(session.Current)
+			suite.Equal(token.Scope, session.Scope)
+		} else {
+			suite.False(session.Current)
+		}
+	}
+	suite.True(found)
+
+	// Other users can't revoke it.
+	errWithCode = suite.user.SessionDelete(ctx, suite.testUsers["local_account_2"], token.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	errWithCode = suite.user.SessionDelete(ctx, user, token.ID)
+	suite.NoError(errWithCode)
+
+	// Token should be gone now.
+	_, err := suite.db.GetTokenByAccess(ctx, token.Access)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	sessions, errWithCode = suite.user.SessionsGet(ctx, user, token.Access)
+	suite.NoError(errWithCode)
+	for _, session := range sessions {
+		suite.NotEqual(token.ID, session.ID)
+	}
+}
+
+func TestSessionTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionTestSuite))
+}
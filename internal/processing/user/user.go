@@ -29,6 +29,10 @@ type Processor struct {
 	converter   *typeutils.Converter
 	oauthServer oauth.Server
 	emailSender email.Sender
+
+	// exports tracks the most
+	// recent export of each account.
+	exports *exportJobs
 }
 
 // New returns a new user processor.
@@ -42,5 +46,8 @@ func New(
 		state:       state,
 		converter:   converter,
 		emailSender: emailSender,
+		exports: &exportJobs{
+			jobs: make(map[string]*exportJob),
+		},
 	}
 }
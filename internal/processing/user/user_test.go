@@ -49,6 +49,7 @@ func (suite *UserStandardTestSuite) SetupTest() {
 
 	suite.db = testrig.NewTestDB(&suite.state)
 	suite.state.DB = suite.db
+	suite.state.Storage = testrig.NewInMemoryStorage()
 
 	suite.sentEmails = make(map[string]string)
 	suite.emailSender = testrig.NewEmailSender("../../../web/template/", suite.sentEmails)
@@ -57,8 +58,10 @@ func (suite *UserStandardTestSuite) SetupTest() {
 	suite.user = user.New(&suite.state, typeutils.NewConverter(&suite.state), testrig.NewTestOauthServer(suite.db), suite.emailSender)
 
 	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.state.Storage, "../../../testrig/media")
 }
 
 func (suite *UserStandardTestSuite) TearDownTest() {
 	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.state.Storage)
 }
@@ -0,0 +1,166 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// awayReplyInterval is the minimum time between
+// automatic away replies sent by one account to
+// the same sender, which also stops two accounts
+// that are both away from replying to each other
+// forever.
+const awayReplyInterval = 24 * time.Hour
+
+// awayReplies tracks when accounts last sent
+// an automatic away reply to each sender.
+type awayReplies struct {
+	mu   sync.Mutex
+	sent map[string]time.Time
+}
+
+// allow returns whether the account with the given ID may send
+// an away reply to the given sender now, and if so, records it.
+func (a *awayReplies) allow(accountID string, senderID string, now time.Time) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.sent == nil {
+		a.sent = make(map[string]time.Time)
+	}
+
+	// Drop expired entries as we
+	// go, so the map stays small.
+	for key, sentAt := range a.sent {
+		if now.Sub(sentAt) >= awayReplyInterval {
+			delete(a.sent, key)
+		}
+	}
+
+	key := accountID + " " + senderID
+	if _, ok := a.sent[key]; ok {
+		return false
+	}
+
+	a.sent[key] = now
+	return true
+}
+
+// replyAway sends an automatic reply, with their away message,
+// from each local account mentioned in the given status that is
+// away with auto-replies turned on. Bots (which may well be away
+// repliers themselves) and blocked accounts never get a reply, and
+// each sender gets at most one reply per awayReplyInterval.
+//
+// The reply is unlisted if the status was public or unlisted, and
+// direct otherwise, so it's never more visible than the status.
+func (u *utils) replyAway(ctx context.Context, status *gtsmodel.Status) {
+	if status.BoostOfID != "" || status.Account == nil {
+		return
+	}
+
+	author := status.Account
+	if util.PtrValueOr(author.Bot, false) ||
+		author.ActorType == ap.ActorService ||
+		author.ActorType == ap.ActorApplication ||
+		author.IsInstance() {
+		return
+	}
+
+	now := time.Now()
+	for _, mention := range status.Mentions {
+		target := mention.TargetAccount
+		if target == nil || target.IsRemote() || target.ID == author.ID {
+			continue
+		}
+
+		settings, err := u.state.DB.GetAccountSettings(ctx, target.ID)
+		if err != nil {
+			log.Errorf(ctx, "error getting settings for account %s: %v", target.ID, err)
+			continue
+		}
+
+		if !util.PtrValueOr(settings.AwayAutoReply, false) ||
+			!settings.Away(now) || !settings.Away(status.CreatedAt) {
+			// Not away, or status was sent before
+			// going away (eg., it was fetched late).
+			continue
+		}
+
+		if status.Visibility == gtsmodel.VisibilityCircle &&
+			!status.InAudience(target.ID) {
+			// Target can't see the status.
+			continue
+		}
+
+		blocked, err := u.state.DB.IsEitherBlocked(ctx, target.ID, author.ID)
+		if err != nil {
+			log.Errorf(ctx, "error checking blocks: %v", err)
+			continue
+		}
+
+		if blocked {
+			continue
+		}
+
+		if !u.awayReplies.allow(target.ID, author.ID, now) {
+			// Already replied recently.
+			continue
+		}
+
+		app, err := u.state.DB.GetInstanceApplication(ctx)
+		if err != nil {
+			log.Errorf(ctx, "error getting instance application: %v", err)
+			return
+		}
+
+		visibility := apimodel.VisibilityDirect
+		switch status.Visibility {
+		case gtsmodel.VisibilityPublic, gtsmodel.VisibilityUnlocked:
+			visibility = apimodel.VisibilityUnlisted
+		}
+
+		handle := "@" + author.Username
+		if author.IsRemote() {
+			handle += "@" + author.Domain
+		}
+
+		form := &apimodel.AdvancedStatusCreateForm{
+			StatusCreateRequest: apimodel.StatusCreateRequest{
+				Status:      handle + " " + settings.AwayMessage,
+				InReplyToID: status.ID,
+				Visibility:  visibility,
+			},
+		}
+
+		if _, errWithCode := u.status.Create(ctx, target, app, form); errWithCode != nil {
+			// May happen if target isn't allowed to
+			// reply to the status, nothing we can do.
+			log.Debugf(ctx, "couldn't send away reply from %s: %v", target.ID, errWithCode)
+		}
+	}
+}
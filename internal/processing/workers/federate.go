@@ -19,14 +19,20 @@ package workers
 
 import (
 	"context"
+	"errors"
 	"net/url"
+	"time"
 
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
@@ -148,6 +154,102 @@ func (f *federate) CreateStatus(ctx context.Context, status *gtsmodel.Status) er
 		return nil
 	}
 
+	if delay := config.GetInstanceFederationDelay(); delay > 0 {
+		// Hold back the Create until the delay has
+		// elapsed, so that edits in the meantime are
+		// delivered as part of it, and a deletion in
+		// the meantime means it's never delivered.
+		f.createStatusDelayed(status.ID, status.CreatedAt.Add(delay))
+		return nil
+	}
+
+	return f.createStatus(ctx, status)
+}
+
+// createStatusTaskID returns the scheduler task ID
+// for the held back Create of status with given ID.
+func createStatusTaskID(statusID string) string {
+	return "federate-create-status:" + statusID
+}
+
+// createStatusDelayed schedules the Create of the status
+// with given ID to be sent at the given time, fetching
+// the latest version of the status when it's sent.
+func (f *federate) createStatusDelayed(statusID string, at time.Time) {
+	taskID := createStatusTaskID(statusID)
+
+	// Returns false if a Create is
+	// already scheduled, nothing to do.
+	_ = f.state.Workers.Scheduler.AddOnce(
+		taskID,
+		at,
+		func(ctx context.Context, _ time.Time) {
+			// Untrack this task, so that later updates
+			// and deletes of the status federate as usual.
+			_ = f.state.Workers.Scheduler.Cancel(taskID)
+
+			// Get the latest version of status from database.
+			status, err := f.state.DB.GetStatusByID(ctx, statusID)
+			if err != nil {
+				if !errors.Is(err, db.ErrNoEntries) {
+					log.Errorf(ctx, "error getting status %s: %v", statusID, err)
+				}
+
+				// Status was deleted in the
+				// meantime, nothing to send.
+				return
+			}
+
+			if err := f.createStatus(ctx, status); err != nil {
+				log.Errorf(ctx, "error federating status %s: %v", statusID, err)
+			}
+		},
+	)
+}
+
+// pendingCreateStatus returns whether the Create of the given
+// status is still being held back, ie., it hasn't been sent yet.
+// If so, and cancel is true, the Create will never be sent.
+//
+// With cancel false the scheduled Create is left untouched; as
+// it fetches the latest version of the status when it's sent,
+// any changes made in the meantime will be included anyway.
+func (f *federate) pendingCreateStatus(status *gtsmodel.Status, cancel bool) bool {
+	taskID := createStatusTaskID(status.ID)
+	if cancel {
+		return f.state.Workers.Scheduler.Cancel(taskID)
+	}
+	return f.state.Workers.Scheduler.Has(taskID)
+}
+
+// schedulePendingCreates schedules the held back Create of
+// all statuses still within the federation delay, for use
+// at startup, as scheduled tasks don't survive a restart.
+func (f *federate) schedulePendingCreates(ctx context.Context) error {
+	delay := config.GetInstanceFederationDelay()
+	if delay <= 0 {
+		return nil
+	}
+
+	// Any status created within the delay can't have had its
+	// Create sent yet, as that only happens once it's elapsed.
+	statuses, err := f.state.DB.GetLocalFederatedStatusesSince(
+		gtscontext.SetBarebones(ctx),
+		time.Now().Add(-delay),
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting recent statuses from db: %w", err)
+	}
+
+	for _, status := range statuses {
+		f.createStatusDelayed(status.ID, status.CreatedAt.Add(delay))
+	}
+
+	return nil
+}
+
+// createStatus sends a Create of the given status.
+func (f *federate) createStatus(ctx context.Context, status *gtsmodel.Status) error {
 	// Ensure the status model is fully populated.
 	if err := f.state.DB.PopulateStatus(ctx, status); err != nil {
 		return gtserror.Newf("error populating status: %w", err)
@@ -222,6 +324,12 @@ func (f *federate) DeleteStatus(ctx context.Context, status *gtsmodel.Status) er
 		return nil
 	}
 
+	// Do nothing if the status was
+	// never sent out in the first place.
+	if f.pendingCreateStatus(status, true) {
+		return nil
+	}
+
 	// Parse the outbox URI of the status author.
 	outboxIRI, err := parseURI(status.Account.OutboxURI)
 	if err != nil {
@@ -260,6 +368,13 @@ func (f *federate) UpdateStatus(ctx context.Context, status *gtsmodel.Status) er
 		return nil
 	}
 
+	// Do nothing if the status hasn't been
+	// sent out yet, as its Create will then
+	// include these changes when it's sent.
+	if f.pendingCreateStatus(status, false) {
+		return nil
+	}
+
 	// Ensure the status model is fully populated.
 	if err := f.state.DB.PopulateStatus(ctx, status); err != nil {
 		return gtserror.Newf("error populating status: %w", err)
@@ -463,6 +578,12 @@ func (f *federate) UndoAnnounce(ctx context.Context, boost *gtsmodel.Status) err
 		return nil
 	}
 
+	// Do nothing if the boosted
+	// status is local-only.
+	if !*boost.Federated {
+		return nil
+	}
+
 	// Parse relevant URI(s).
 	outboxIRI, err := parseURI(boost.Account.OutboxURI)
 	if err != nil {
@@ -721,6 +842,12 @@ func (f *federate) Announce(ctx context.Context, boost *gtsmodel.Status) error {
 		return nil
 	}
 
+	// Do nothing if the boosted
+	// status is local-only.
+	if !*boost.Federated {
+		return nil
+	}
+
 	// Parse relevant URI(s).
 	outboxIRI, err := parseURI(boost.Account.OutboxURI)
 	if err != nil {
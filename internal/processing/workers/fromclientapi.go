@@ -31,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -245,6 +246,9 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
 
+	// Auto-reply for any mentioned accounts that are away.
+	p.utils.replyAway(ctx, status)
+
 	if status.InReplyToID != "" {
 		// Interaction counts changed on the replied status;
 		// uncache the prepared version from all timelines.
@@ -283,6 +287,9 @@ func (p *clientAPI) CreatePollVote(ctx context.Context, cMsg *messages.FromClien
 	// Interaction counts changed on the source status, uncache from timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, vote.Poll.StatusID)
 
+	// Stream the updated vote counts.
+	p.surface.timelinePollUpdate(vote.PollID)
+
 	if *status.Local {
 		// These are poll votes in a local status, we only need to
 		// federate the updated status model with latest vote counts.
@@ -305,10 +312,11 @@ func (p *clientAPI) CreateFollowReq(ctx context.Context, cMsg *messages.FromClie
 		return gtserror.Newf("%T not parseable as *gtsmodel.FollowRequest", cMsg.GTSModel)
 	}
 
-	// If target is a local, unlocked account,
-	// we can skip side effects for the follow
-	// request and accept the follow immediately.
-	if cMsg.Target.IsLocal() && !*cMsg.Target.Locked {
+	// If target is a local account which doesn't
+	// need to approve this follow request, we can
+	// skip side effects for the follow request
+	// and accept the follow immediately.
+	if cMsg.Target.IsLocal() && !cMsg.Target.FollowNeedsApproval(cMsg.Origin) {
 		// Accept the FR first to get the Follow.
 		follow, err := p.state.DB.AcceptFollowRequest(
 			ctx,
@@ -416,16 +424,28 @@ func (p *clientAPI) CreateBlock(ctx context.Context, cMsg *messages.FromClientAP
 	}
 
 	// Remove blockee's statuses from blocker's timeline.
-	if err := p.state.Timelines.Home.WipeItemsFromAccountID(
+	removedIDs, err := p.state.Timelines.Home.WipeItemsFromAccountID(
 		ctx,
 		block.AccountID,
 		block.TargetAccountID,
-	); err != nil {
+	)
+	if err != nil {
 		return gtserror.Newf("error wiping timeline items for block: %w", err)
 	}
 
+	// Stream removal of the blockee's statuses to
+	// the blocker. Nothing is streamed to the blockee,
+	// as that would tell them they've been blocked.
+	for _, statusID := range removedIDs {
+		p.surface.Stream.DeleteForAccount(ctx,
+			cMsg.Origin,
+			statusID,
+			stream.DeleteReasonBlock,
+		)
+	}
+
 	// Remove blocker's statuses from blockee's timeline.
-	if err := p.state.Timelines.Home.WipeItemsFromAccountID(
+	if _, err := p.state.Timelines.Home.WipeItemsFromAccountID(
 		ctx,
 		block.TargetAccountID,
 		block.AccountID,
@@ -485,6 +505,16 @@ func (p *clientAPI) UpdateAccount(ctx context.Context, cMsg *messages.FromClient
 		log.Errorf(ctx, "error federating account update: %v", err)
 	}
 
+	// Fields may have changed, so check their links
+	// (again). This fetches each linked page, which
+	// can be slow, so rather than hold up the client
+	// API worker, enqueue it with the dereferencers.
+	p.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		if err := p.account.VerifyFields(ctx, account); err != nil {
+			log.Errorf(ctx, "error verifying account fields: %v", err)
+		}
+	})
+
 	return nil
 }
 
@@ -643,7 +673,7 @@ func (p *clientAPI) UndoAnnounce(ctx context.Context, cMsg *messages.FromClientA
 		log.Errorf(ctx, "error updating account stats: %v", err)
 	}
 
-	if err := p.surface.deleteStatusFromTimelines(ctx, status.ID); err != nil {
+	if err := p.surface.deleteStatusFromTimelines(ctx, status.ID, deleteReason(ctx)); err != nil {
 		log.Errorf(ctx, "error removing timelined status: %v", err)
 	}
 
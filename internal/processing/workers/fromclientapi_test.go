@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
@@ -281,6 +282,78 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusWithNotification() {
 	)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusFilterNotify() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+		filterID         = id.NewULID()
+
+		// Admin account posts a new top-level status.
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+		)
+	)
+
+	// Give the receiving account a notify
+	// filter that matches the new status.
+	filter := &gtsmodel.Filter{
+		ID:          filterID,
+		AccountID:   receivingAccount.ID,
+		Title:       "poo alert",
+		Action:      gtsmodel.FilterActionNotify,
+		ContextHome: util.Ptr(true),
+		Keywords: []*gtsmodel.FilterKeyword{
+			{
+				ID:        id.NewULID(),
+				AccountID: receivingAccount.ID,
+				FilterID:  filterID,
+				Keyword:   "poo",
+				WholeWord: util.Ptr(true),
+			},
+		},
+	}
+	if err := testStructs.State.DB.PutFilter(ctx, filter); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Wait for a filter match notification
+	// to appear for the status.
+	if !testrig.WaitFor(func() bool {
+		_, err := testStructs.State.DB.GetNotification(
+			ctx,
+			gtsmodel.NotificationFilterMatch,
+			receivingAccount.ID,
+			postingAccount.ID,
+			status.ID,
+		)
+		return err == nil
+	}) {
+		suite.FailNow("timed out waiting for filter match notification")
+	}
+}
+
 func (suite *FromClientAPITestSuite) TestProcessCreateStatusReply() {
 	testStructs := suite.SetupTestStructs()
 	defer suite.TearDownTestStructs(testStructs)
@@ -346,6 +419,84 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusReply() {
 	)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusReplyConversationStream() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		threadStatus     = suite.testStatuses["local_account_1_status_1"]
+	)
+
+	// Receiving account follows the thread
+	// of a status by another account.
+	conversationStream, errWithCode := testStructs.Processor.Stream().Open(
+		ctx,
+		receivingAccount,
+		stream.TimelineConversation+":"+threadStatus.ID,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer conversationStream.Close()
+
+	// Admin account posts a direct reply, which
+	// the receiving account isn't mentioned in,
+	// and then a public reply in the same thread.
+	directReply := suite.newStatus(
+		ctx,
+		testStructs.State,
+		postingAccount,
+		gtsmodel.VisibilityDirect,
+		threadStatus,
+		nil,
+	)
+	publicReply := suite.newStatus(
+		ctx,
+		testStructs.State,
+		postingAccount,
+		gtsmodel.VisibilityPublic,
+		threadStatus,
+		nil,
+	)
+
+	for _, status := range []*gtsmodel.Status{directReply, publicReply} {
+		if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+			ctx,
+			&messages.FromClientAPI{
+				APObjectType:   ap.ObjectNote,
+				APActivityType: ap.ActivityCreate,
+				GTSModel:       status,
+				Origin:         postingAccount,
+			},
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Only the public reply should be
+	// streamed to the receiving account.
+	suite.checkStreamed(
+		conversationStream,
+		true,
+		suite.statusJSON(
+			ctx,
+			testStructs.TypeConverter,
+			publicReply,
+			receivingAccount,
+		),
+		stream.EventTypeUpdate,
+	)
+	suite.checkStreamed(
+		conversationStream,
+		false,
+		"",
+		"",
+	)
+}
+
 func (suite *FromClientAPITestSuite) TestProcessCreateStatusReplyMuted() {
 	testStructs := suite.SetupTestStructs()
 	defer suite.TearDownTestStructs(testStructs)
@@ -870,6 +1021,413 @@ func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	}
 }
 
+func (suite *FromClientAPITestSuite) TestProcessStatusDeleteDetailed() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		deletingAccount  = suite.testAccounts["local_account_1"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		deletedStatus    = suite.testStatuses["local_account_1_status_1"]
+		streams          = suite.openStreams(ctx, testStructs.Processor, receivingAccount, nil)
+		homeStream       = streams[stream.TimelineHome]
+	)
+	homeStream.SetDetailedDeletes(true)
+
+	if err := testStructs.State.DB.DeleteStatusByID(ctx, deletedStatus.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the status delete
+	// by the author themself.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityDelete,
+			GTSModel:       deletedStatus,
+			Origin:         deletingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Skip the delete of admin's boost.
+	suite.checkStreamed(homeStream, true, "", stream.EventTypeDelete)
+
+	suite.checkStreamed(
+		homeStream,
+		true,
+		`{"id":"`+deletedStatus.ID+`","reason":"author"}`,
+		stream.EventTypeDelete,
+	)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessStatusDeleteModeration() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		deletingAccount  = suite.testAccounts["local_account_1"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		deletedStatus    = suite.testStatuses["local_account_1_status_1"]
+		streams          = suite.openStreams(ctx, testStructs.Processor, receivingAccount, nil)
+		homeStream       = streams[stream.TimelineHome]
+	)
+	homeStream.SetDetailedDeletes(true)
+
+	if err := testStructs.State.DB.DeleteStatusByID(ctx, deletedStatus.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the status delete as part of
+	// an account delete done by an admin.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		gtscontext.SetModeration(ctx),
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityDelete,
+			GTSModel:       deletedStatus,
+			Origin:         deletingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Skip the delete of admin's boost.
+	suite.checkStreamed(homeStream, true, "", stream.EventTypeDelete)
+
+	// Reason should be generic, not
+	// mentioning who removed the status.
+	suite.checkStreamed(
+		homeStream,
+		true,
+		`{"id":"`+deletedStatus.ID+`","reason":"moderation"}`,
+		stream.EventTypeDelete,
+	)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessCreateBlockDetailedDelete() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx             = context.Background()
+		blockingAccount = suite.testAccounts["local_account_2"]
+		blockedAccount  = suite.testAccounts["local_account_1"]
+		blockedStatus   = suite.testStatuses["local_account_1_status_1"]
+		blockerStreams  = suite.openStreams(ctx, testStructs.Processor, blockingAccount, nil)
+		blockeeStreams  = suite.openStreams(ctx, testStructs.Processor, blockedAccount, nil)
+		blockerStream   = blockerStreams[stream.TimelineHome]
+		blockeeStream   = blockeeStreams[stream.TimelineHome]
+	)
+	blockerStream.SetDetailedDeletes(true)
+	blockeeStream.SetDetailedDeletes(true)
+
+	// Make sure the blockee's status
+	// is in the blocker's home timeline.
+	if _, err := testStructs.State.Timelines.Home.IngestOne(
+		ctx,
+		blockingAccount.ID,
+		blockedStatus,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	block := &gtsmodel.Block{
+		ID:              id.NewULID(),
+		URI:             "http://localhost:8080/users/1happyturtle/blocks/" + id.NewULID(),
+		AccountID:       blockingAccount.ID,
+		Account:         blockingAccount,
+		TargetAccountID: blockedAccount.ID,
+		TargetAccount:   blockedAccount,
+	}
+
+	// Put the block in the db, to mimic what would
+	// have already happened earlier up the flow.
+	if err := testStructs.State.DB.PutBlock(ctx, block); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ActivityBlock,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       block,
+			Origin:         blockingAccount,
+			Target:         blockedAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Blocker should be told the
+	// status was removed for a block.
+	suite.checkStreamed(
+		blockerStream,
+		true,
+		`{"id":"`+blockedStatus.ID+`","reason":"block"}`,
+		stream.EventTypeDelete,
+	)
+
+	// Blockee shouldn't be told anything.
+	suite.checkStreamed(blockeeStream, false, "", "")
+}
+
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusLocalOnly() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx           = context.Background()
+		account       = suite.testAccounts["local_account_1"]
+		remoteStatus  = suite.testStatuses["remote_account_1_status_1"]
+		deliveryQueue = &testStructs.State.Workers.Delivery.Queue
+		processCreate = func(status *gtsmodel.Status) {
+			if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+				ctx,
+				&messages.FromClientAPI{
+					APObjectType:   ap.ObjectNote,
+					APActivityType: ap.ActivityCreate,
+					GTSModel:       status,
+					Origin:         account,
+				},
+			); err != nil {
+				suite.FailNow(err.Error())
+			}
+		}
+	)
+
+	// A federated reply to a remote status
+	// should be delivered to the remote.
+	federated := suite.newStatus(ctx, testStructs.State, account, gtsmodel.VisibilityPublic, remoteStatus, nil)
+	processCreate(federated)
+	if !testrig.WaitFor(func() bool {
+		return deliveryQueue.Len() > 0
+	}) {
+		suite.FailNow("timed out waiting for delivery")
+	}
+
+	// Drain deliveries.
+	for {
+		if _, ok := deliveryQueue.Pop(); !ok {
+			break
+		}
+	}
+
+	// But a local-only one should
+	// not be delivered anywhere.
+	localOnly := suite.newStatus(ctx, testStructs.State, account, gtsmodel.VisibilityPublic, remoteStatus, nil)
+	localOnly.Federated = util.Ptr(false)
+	if err := testStructs.State.DB.UpdateStatus(ctx, localOnly, "federated"); err != nil {
+		suite.FailNow(err.Error())
+	}
+	processCreate(localOnly)
+	suite.Zero(deliveryQueue.Len())
+}
+
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusFederationDelay() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	config.SetInstanceFederationDelay(time.Minute)
+	defer config.SetInstanceFederationDelay(0)
+
+	var (
+		ctx        = context.Background()
+		account    = suite.testAccounts["local_account_1"]
+		status     = suite.newStatus(ctx, testStructs.State, account, gtsmodel.VisibilityPublic, nil, nil)
+		taskID     = "federate-create-status:" + status.ID
+		processMsg = func(activityType string) {
+			if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+				ctx,
+				&messages.FromClientAPI{
+					APObjectType:   ap.ObjectNote,
+					APActivityType: activityType,
+					GTSModel:       status,
+					Origin:         account,
+				},
+			); err != nil {
+				suite.FailNow(err.Error())
+			}
+		}
+	)
+	status.CreatedAt = time.Now()
+
+	// Create the status, the Create
+	// should be held back for now.
+	processMsg(ap.ActivityCreate)
+
+	// Update the status, the
+	// Create should still be held.
+	processMsg(ap.ActivityUpdate)
+	suite.True(testStructs.State.Workers.Scheduler.Has(taskID))
+
+	// Boost the status, boosts are Announced
+	// rather than Created, so should never be
+	// picked up as pending Creates at startup.
+	boost := suite.newStatus(ctx, testStructs.State, account, gtsmodel.VisibilityPublic, nil, status)
+
+	// Drop the held Create, as a restart would,
+	// then reschedule pending Creates at startup.
+	suite.True(testStructs.State.Workers.Scheduler.Cancel(taskID))
+	if err := testStructs.Processor.Workers().SchedulePendingCreates(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(testStructs.State.Workers.Scheduler.Has(taskID))
+	suite.False(testStructs.State.Workers.Scheduler.Has("federate-create-status:" + boost.ID))
+
+	// Delete the status, the Create
+	// should now never be sent at all.
+	if err := testStructs.State.DB.DeleteStatusByID(ctx, status.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+	processMsg(ap.ActivityDelete)
+
+	// Nothing left to cancel.
+	suite.False(testStructs.State.Workers.Scheduler.Cancel(taskID))
+}
+
+func (suite *FromClientAPITestSuite) TestProcessUpdateStatusBoosted() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		authorAccount    = suite.testAccounts["local_account_1"]
+		boostingAccount  = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		editedStatus     = suite.testStatuses["local_account_1_status_1"]
+		boost            = suite.testStatuses["admin_account_status_4"]
+	)
+
+	// Make receiving account follow the
+	// booster, and not the status author.
+	if err := testStructs.State.DB.DeleteFollowByID(
+		ctx, suite.testFollows["local_account_2_local_account_1"].ID,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := testStructs.State.DB.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              id.NewULID(),
+		URI:             receivingAccount.URI + "/follow/" + id.NewULID(),
+		AccountID:       receivingAccount.ID,
+		TargetAccountID: boostingAccount.ID,
+		ShowReblogs:     util.Ptr(true),
+		Notify:          util.Ptr(false),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var (
+		streams    = suite.openStreams(ctx, testStructs.Processor, receivingAccount, nil)
+		homeStream = streams[stream.TimelineHome]
+	)
+
+	// Edit the status, to mimic what would
+	// have already happened earlier up the flow.
+	editedStatus.Content = "hello everyone! (edited)"
+	if err := testStructs.State.DB.UpdateStatus(ctx, editedStatus, "content"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the status update.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel:       editedStatus,
+			Origin:         authorAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Receiving account should be streamed an
+	// update of the boost, wrapping the edit.
+	recvCtx, cncl := context.WithTimeout(ctx, 5*time.Second)
+	defer cncl()
+
+	msg, ok := homeStream.Recv(recvCtx)
+	if !ok {
+		suite.FailNow("expected a message but message was not received")
+	}
+	suite.Equal(stream.EventTypeStatusUpdate, msg.Event)
+	suite.Contains(msg.Payload, `"id":"`+boost.ID+`"`)
+	suite.Contains(msg.Payload, `hello everyone! (edited)`)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessCreatePollVoteHiddenCounts() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		votingAccount    = suite.testAccounts["admin_account"]
+		authorAccount    = suite.testAccounts["local_account_1"]
+		followerAccount  = suite.testAccounts["local_account_2"]
+		poll             = testrig.NewTestPolls()["local_account_1_status_6_poll"]
+		authorStreams    = suite.openStreams(ctx, testStructs.Processor, authorAccount, nil)
+		authorHomeStream = authorStreams[stream.TimelineHome]
+		followerStreams  = suite.openStreams(ctx, testStructs.Processor, followerAccount, nil)
+		followerStream   = followerStreams[stream.TimelineHome]
+	)
+
+	// Put the vote in the db first, to mimic what
+	// would have already happened earlier up the flow.
+	vote := &gtsmodel.PollVote{
+		ID:        id.NewULID(),
+		Choices:   []int{1},
+		AccountID: votingAccount.ID,
+		PollID:    poll.ID,
+	}
+	if err := testStructs.State.DB.PutPollVote(ctx, vote); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the poll vote.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ActivityQuestion,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       vote,
+			Origin:         votingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Poll updates are throttled, so
+	// allow extra time for this one.
+	recvCtx, cncl := context.WithTimeout(ctx, 10*time.Second)
+	defer cncl()
+
+	// The poll author should be streamed
+	// an update with the new vote counts.
+	msg, ok := authorHomeStream.Recv(recvCtx)
+	if !ok {
+		suite.FailNow("expected a message but message was not received")
+	}
+	suite.Equal(stream.EventTypeStatusUpdate, msg.Event)
+	suite.Contains(msg.Payload, `"votes_count":1`)
+
+	// Counts are hidden from the follower
+	// until the poll closes, so they
+	// shouldn't be streamed anything.
+	suite.checkStreamed(
+		followerStream,
+		false,
+		"",
+		"",
+	)
+}
+
 func TestFromClientAPITestSuite(t *testing.T) {
 	suite.Run(t, &FromClientAPITestSuite{})
 }
@@ -20,6 +20,7 @@ package workers
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"codeberg.org/gruf/go-kv"
 	"codeberg.org/gruf/go-logger/v2/level"
@@ -232,6 +233,9 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
 
+	// Auto-reply for any mentioned accounts that are away.
+	p.utils.replyAway(ctx, status)
+
 	return nil
 }
 
@@ -265,6 +269,9 @@ func (p *fediAPI) CreatePollVote(ctx context.Context, fMsg *messages.FromFediAPI
 	// Interaction counts changed on the source status, uncache from timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, vote.Poll.StatusID)
 
+	// Stream the updated vote counts.
+	p.surface.timelinePollUpdate(vote.PollID)
+
 	if *status.Local {
 		// Before federating it, increment the
 		// poll vote counts on our local copy.
@@ -290,8 +297,9 @@ func (p *fediAPI) CreateFollowReq(ctx context.Context, fMsg *messages.FromFediAP
 		return gtserror.Newf("error populating follow request: %w", err)
 	}
 
-	if *followRequest.TargetAccount.Locked {
-		// Local account is locked: just notify the follow request.
+	if followRequest.TargetAccount.FollowNeedsApproval(followRequest.Account) {
+		// Local account is locked, and the requester isn't
+		// auto-approved: just notify the follow request.
 		if err := p.surface.notifyFollowRequest(ctx, followRequest); err != nil {
 			log.Errorf(ctx, "error notifying follow request: %v", err)
 		}
@@ -304,8 +312,8 @@ func (p *fediAPI) CreateFollowReq(ctx context.Context, fMsg *messages.FromFediAP
 		return nil
 	}
 
-	// Local account is not locked:
-	// Automatically accept the follow request
+	// Local account is not locked, or auto-approves
+	// the requester: automatically accept the follow request
 	// and notify about the new follower.
 	follow, err := p.state.DB.AcceptFollowRequest(
 		ctx,
@@ -416,7 +424,7 @@ func (p *fediAPI) CreateBlock(ctx context.Context, fMsg *messages.FromFediAPI) e
 	// Remove each account's posts from the other's timelines.
 	//
 	// First home timelines.
-	if err := p.state.Timelines.Home.WipeItemsFromAccountID(
+	if _, err := p.state.Timelines.Home.WipeItemsFromAccountID(
 		ctx,
 		block.AccountID,
 		block.TargetAccountID,
@@ -424,7 +432,7 @@ func (p *fediAPI) CreateBlock(ctx context.Context, fMsg *messages.FromFediAPI) e
 		log.Errorf(ctx, "error wiping items from block -> target's home timeline: %v", err)
 	}
 
-	if err := p.state.Timelines.Home.WipeItemsFromAccountID(
+	if _, err := p.state.Timelines.Home.WipeItemsFromAccountID(
 		ctx,
 		block.TargetAccountID,
 		block.AccountID,
@@ -433,7 +441,7 @@ func (p *fediAPI) CreateBlock(ctx context.Context, fMsg *messages.FromFediAPI) e
 	}
 
 	// Now list timelines.
-	if err := p.state.Timelines.List.WipeItemsFromAccountID(
+	if _, err := p.state.Timelines.List.WipeItemsFromAccountID(
 		ctx,
 		block.AccountID,
 		block.TargetAccountID,
@@ -441,7 +449,7 @@ func (p *fediAPI) CreateBlock(ctx context.Context, fMsg *messages.FromFediAPI) e
 		log.Errorf(ctx, "error wiping items from block -> target's list timeline(s): %v", err)
 	}
 
-	if err := p.state.Timelines.List.WipeItemsFromAccountID(
+	if _, err := p.state.Timelines.List.WipeItemsFromAccountID(
 		ctx,
 		block.TargetAccountID,
 		block.AccountID,
@@ -584,6 +592,13 @@ func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		}
 	}
 
+	if pollVotesOnlyUpdate(existing, status) {
+		// Remote polls get updated each time vote counts
+		// change, so these updates are throttled per poll.
+		p.surface.timelinePollUpdate(status.PollID)
+		return nil
+	}
+
 	// Push message that the status has been edited to streams.
 	if err := p.surface.timelineStatusUpdate(ctx, status); err != nil {
 		log.Errorf(ctx, "error streaming status edit: %v", err)
@@ -592,6 +607,18 @@ func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 	return nil
 }
 
+// pollVotesOnlyUpdate returns whether the given updated status
+// appears to differ from its existing version only in poll vote
+// counts, i.e. it has the same poll and has not otherwise been edited.
+func pollVotesOnlyUpdate(existing, updated *gtsmodel.Status) bool {
+	return updated.PollID != "" &&
+		updated.PollID == existing.PollID &&
+		updated.Content == existing.Content &&
+		updated.ContentWarning == existing.ContentWarning &&
+		util.EqualPtrs(updated.Sensitive, existing.Sensitive) &&
+		slices.Equal(updated.AttachmentIDs, existing.AttachmentIDs)
+}
+
 func (p *fediAPI) DeleteStatus(ctx context.Context, fMsg *messages.FromFediAPI) error {
 	// Delete attachments from this status, since this request
 	// comes from the federating API, and there's no way the
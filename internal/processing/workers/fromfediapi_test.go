@@ -446,6 +446,82 @@ func (suite *FromFediAPITestSuite) TestProcessFollowRequestLocked() {
 	suite.Empty(testStructs.HTTPClient.SentMessages)
 }
 
+func (suite *FromFediAPITestSuite) TestProcessFollowRequestAutoApprove() {
+	ctx := context.Background()
+
+	for _, test := range []struct {
+		name          string
+		after         time.Duration
+		expectFollows bool
+	}{
+		{
+			// remote_account_1 is known for
+			// longer than a week: approve.
+			name:          "established",
+			after:         7 * 24 * time.Hour,
+			expectFollows: true,
+		},
+		{
+			// but not for this long: queue.
+			name:          "new",
+			after:         100 * 365 * 24 * time.Hour,
+			expectFollows: false,
+		},
+	} {
+		suite.Run(test.name, func() {
+			testStructs := suite.SetupTestStructs()
+			defer suite.TearDownTestStructs(testStructs)
+
+			originAccount := suite.testAccounts["remote_account_1"]
+
+			// target is a locked account,
+			// with auto-approval set up.
+			targetAccount := new(gtsmodel.Account)
+			*targetAccount = *suite.testAccounts["local_account_2"]
+			targetAccount.Settings = new(gtsmodel.AccountSettings)
+			*targetAccount.Settings = *suite.testAccounts["local_account_2"].Settings
+			targetAccount.Settings.AutoApproveFollowsAfter = test.after
+			if err := testStructs.State.DB.UpdateAccountSettings(ctx, targetAccount.Settings, "auto_approve_follows_after"); err != nil {
+				suite.FailNow(err.Error())
+			}
+
+			followRequest := &gtsmodel.FollowRequest{
+				ID:              "01FGRYAVAWWPP926J175QGM0WV",
+				CreatedAt:       time.Now(),
+				UpdatedAt:       time.Now(),
+				AccountID:       originAccount.ID,
+				Account:         originAccount,
+				TargetAccountID: targetAccount.ID,
+				TargetAccount:   targetAccount,
+				ShowReblogs:     util.Ptr(true),
+				URI:             fmt.Sprintf("%s/follows/01FGRYAVAWWPP926J175QGM0WV", originAccount.URI),
+				Notify:          util.Ptr(false),
+			}
+			if err := testStructs.State.DB.Put(ctx, followRequest); err != nil {
+				suite.FailNow(err.Error())
+			}
+
+			if err := testStructs.Processor.Workers().ProcessFromFediAPI(ctx, &messages.FromFediAPI{
+				APObjectType:   ap.ActivityFollow,
+				APActivityType: ap.ActivityCreate,
+				GTSModel:       followRequest,
+				Receiving:      targetAccount,
+				Requesting:     originAccount,
+			}); err != nil {
+				suite.FailNow(err.Error())
+			}
+
+			follows, err := testStructs.State.DB.IsFollowing(ctx, originAccount.ID, targetAccount.ID)
+			suite.NoError(err)
+			suite.Equal(test.expectFollows, follows)
+
+			requested, err := testStructs.State.DB.IsFollowRequested(ctx, originAccount.ID, targetAccount.ID)
+			suite.NoError(err)
+			suite.Equal(!test.expectFollows, requested)
+		})
+	}
+}
+
 func (suite *FromFediAPITestSuite) TestProcessFollowRequestUnlocked() {
 	testStructs := suite.SetupTestStructs()
 	defer suite.TearDownTestStructs(testStructs)
@@ -649,6 +725,79 @@ func (suite *FromFediAPITestSuite) TestMoveAccount() {
 	suite.WithinDuration(time.Now(), move.SucceededAt, 1*time.Minute)
 }
 
+func (suite *FromFediAPITestSuite) TestMoveAccountNotAliased() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	// foss_satan claims to be migrating to our local
+	// admin account, but admin account doesn't list
+	// foss_satan in alsoKnownAs, so it's spoofed.
+	ctx := context.Background()
+	receivingAcct := suite.testAccounts["local_account_1"]
+
+	// Copy requesting account
+	// since we'll be changing it.
+	requestingAcct := &gtsmodel.Account{}
+	*requestingAcct = *suite.testAccounts["remote_account_1"]
+	targetAcct := suite.testAccounts["admin_account"]
+
+	// Remove existing follow from zork to admin account.
+	if err := testStructs.State.DB.DeleteFollowByID(
+		ctx,
+		suite.testFollows["local_account_1_admin_account"].ID,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Have Zork follow foss_satan instead.
+	if err := testStructs.State.DB.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              "01HRA0XZYFZC5MNWTKEBR58SSE",
+		URI:             "http://localhost:8080/users/the_mighty_zork/follows/01HRA0XZYFZC5MNWTKEBR58SSE",
+		AccountID:       receivingAcct.ID,
+		TargetAccountID: requestingAcct.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the Move.
+	err := testStructs.Processor.Workers().ProcessFromFediAPI(ctx, &messages.FromFediAPI{
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityMove,
+		GTSModel: &gtsmodel.Move{
+			OriginURI: requestingAcct.URI,
+			Origin:    testrig.URLMustParse(requestingAcct.URI),
+			TargetURI: targetAcct.URI,
+			Target:    testrig.URLMustParse(targetAcct.URI),
+			URI:       "https://fossbros-anonymous.io/users/foss_satan/moves/01HRA064871MR8HGVSAFJ333GM",
+		},
+		Receiving:  receivingAcct,
+		Requesting: requestingAcct,
+	})
+	suite.NoError(err)
+
+	// Zork should still follow foss_satan...
+	follows, err := testStructs.State.DB.IsFollowing(ctx, receivingAcct.ID, requestingAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(follows)
+
+	// ...and not have been moved to admin account.
+	follows, err = testStructs.State.DB.IsFollowing(ctx, receivingAcct.ID, targetAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(follows)
+
+	// Move attempt should still be in the
+	// DB for audit, but not marked succeeded.
+	move, err := testStructs.State.DB.GetMoveByURI(ctx, "https://fossbros-anonymous.io/users/foss_satan/moves/01HRA064871MR8HGVSAFJ333GM")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(move.SucceededAt.IsZero())
+}
+
 func TestFromFederatorTestSuite(t *testing.T) {
 	suite.Run(t, &FromFediAPITestSuite{})
 }
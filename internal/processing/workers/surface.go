@@ -37,4 +37,8 @@ type Surface struct {
 	Stream      *stream.Processor
 	Filter      *visibility.Filter
 	EmailSender email.Sender
+
+	// filterNotifies rate limits notifications
+	// sent by filters with the notify action.
+	filterNotifies filterNotifies
 }
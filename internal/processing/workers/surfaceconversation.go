@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"errors"
+
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+// streamConversationStatus streams the given new or edited
+// status to open conversation streams for its thread, of any
+// local accounts that the status is visible to. Visibility is
+// checked for each status, as participants of a thread can
+// see different parts of it.
+func (s *Surface) streamConversationStatus(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	edit bool,
+) error {
+	if status.ThreadID == "" || status.BoostOfID != "" {
+		// Not part of a thread
+		// that can be streamed.
+		return nil
+	}
+
+	accountIDs := s.Stream.ConversationSubscribers(status.ThreadID)
+	if len(accountIDs) == 0 {
+		// Nobody's listening.
+		return nil
+	}
+
+	var (
+		errs       gtserror.MultiError
+		streamType = stream.TimelineConversation + ":" + status.ThreadID
+	)
+
+	for _, accountID := range accountIDs {
+		account, err := s.State.DB.GetAccountByID(ctx, accountID)
+		if err != nil {
+			errs.Appendf("error getting account %s: %w", accountID, err)
+			continue
+		}
+
+		visible, err := s.Filter.StatusVisible(ctx, account, status)
+		if err != nil {
+			errs.Appendf("error checking visibility of status %s: %w", status.ID, err)
+			continue
+		}
+
+		if !visible {
+			// Nothing to do.
+			continue
+		}
+
+		filters, err := s.State.DB.GetFiltersForAccountID(ctx, accountID)
+		if err != nil {
+			errs.Appendf("couldn't retrieve filters for account %s: %w", accountID, err)
+			continue
+		}
+
+		mutes, err := s.State.DB.GetAccountMutes(gtscontext.SetBarebones(ctx), accountID, nil)
+		if err != nil {
+			errs.Appendf("couldn't retrieve mutes for account %s: %w", accountID, err)
+			continue
+		}
+
+		apiStatus, err := s.Converter.StatusToAPIStatus(ctx,
+			status,
+			account,
+			statusfilter.FilterContextThread,
+			filters,
+			usermute.NewCompiledUserMuteList(mutes),
+		)
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			// Don't put this status in the stream.
+			continue
+		}
+		if err != nil {
+			errs.Appendf("error converting status %s to frontend representation: %w", status.ID, err)
+			continue
+		}
+
+		if edit {
+			s.Stream.StatusUpdate(ctx, account, apiStatus, streamType)
+		} else {
+			s.Stream.Update(ctx, account, apiStatus, streamType)
+		}
+	}
+
+	return errs.Combine()
+}
@@ -0,0 +1,141 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+const (
+	// filterNotifyWindow and filterNotifyMax limit
+	// notify filters to sending at most filterNotifyMax
+	// notifications per filterNotifyWindow each, so that
+	// a filter on a popular keyword can't flood the
+	// notifications of the account that owns it.
+	filterNotifyWindow = time.Hour
+	filterNotifyMax    = 5
+)
+
+// filterNotifies tracks when each notify
+// filter recently sent a notification.
+type filterNotifies struct {
+	mu   sync.Mutex
+	sent map[string][]time.Time
+}
+
+// allow returns whether the filter with the given ID may send
+// a notification at the given time, and if so, records it.
+func (f *filterNotifies) allow(filterID string, now time.Time) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.sent == nil {
+		f.sent = make(map[string][]time.Time)
+	}
+
+	// Drop expired entries as we
+	// go, so the map stays small.
+	for key, times := range f.sent {
+		recent := times[:0]
+		for _, sentAt := range times {
+			if now.Sub(sentAt) < filterNotifyWindow {
+				recent = append(recent, sentAt)
+			}
+		}
+
+		if len(recent) == 0 {
+			delete(f.sent, key)
+		} else {
+			f.sent[key] = recent
+		}
+	}
+
+	if len(f.sent[filterID]) >= filterNotifyMax {
+		return false
+	}
+
+	f.sent[filterID] = append(f.sent[filterID], now)
+	return true
+}
+
+// notifyFilterMatches notifies the given account about the given
+// status, which has passed through their home timeline, if it matches
+// any of their filters with the notify action. This is done whether or
+// not the status was hidden from the timeline by another filter, so
+// that notify filters can be combined with hide filters, but not if the
+// author is muted. Boosts are notified as the status they boost.
+func (s *Surface) notifyFilterMatches(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+	filters []*gtsmodel.Filter,
+	mutes *usermute.CompiledUserMuteList,
+) error {
+	if status.AccountID == account.ID {
+		// Don't notify about own statuses.
+		return nil
+	}
+
+	now := time.Now()
+	if mutes.Matches(status.AccountID, statusfilter.FilterContextHome, now) {
+		return nil
+	}
+
+	target := status
+	if status.BoostOf != nil {
+		target = status.BoostOf
+		if target.AccountID == account.ID ||
+			mutes.Matches(target.AccountID, statusfilter.FilterContextHome, now) {
+			return nil
+		}
+	}
+
+	for _, filter := range filters {
+		if filter.Action != gtsmodel.FilterActionNotify {
+			continue
+		}
+
+		if !typeutils.StatusMatchesFilter(status, filter, statusfilter.FilterContextHome, now) {
+			continue
+		}
+
+		if !s.filterNotifies.allow(filter.ID, now) {
+			// This filter has sent too many
+			// notifications lately, try others.
+			continue
+		}
+
+		// One notification is enough,
+		// however many filters matched.
+		return s.Notify(ctx,
+			gtsmodel.NotificationFilterMatch,
+			account,
+			target.Account,
+			target.ID,
+		)
+	}
+
+	return nil
+}
@@ -58,6 +58,13 @@ func (s *Surface) notifyMentions(
 			continue
 		}
 
+		if status.Visibility == gtsmodel.VisibilityCircle &&
+			!status.InAudience(mention.TargetAccountID) {
+			// Mentioned account isn't in the
+			// circle, so can't see the status.
+			continue
+		}
+
 		// Ensure thread not muted
 		// by mentioned account.
 		muted, err := s.State.DB.IsThreadMutedByAccount(
@@ -87,7 +87,7 @@ func (suite *SurfaceNotifyTestSuite) TestSpamNotifs() {
 	notifs, err := testStructs.State.DB.GetAccountNotifications(
 		gtscontext.SetBarebones(ctx),
 		targetAccount.ID,
-		"", "", "", 0, nil, nil,
+		"", "", "", 0, nil, nil, "", nil,
 	)
 	if err != nil {
 		suite.FailNow(err.Error())
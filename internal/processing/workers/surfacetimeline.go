@@ -20,6 +20,7 @@ package workers
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
@@ -37,7 +38,8 @@ import (
 //
 // It will also handle notifications for any mentions attached to
 // the account, and notifications for any local accounts that want
-// to know when this account posts.
+// to know when this account posts, and streaming it to any open
+// conversation streams for its thread.
 func (s *Surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.Status) error {
 	// Ensure status fully populated; including account, mentions, etc.
 	if err := s.State.DB.PopulateStatus(ctx, status); err != nil {
@@ -53,12 +55,7 @@ func (s *Surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.
 	// If the poster is also local, add a fake entry for them
 	// so they can see their own status in their timeline.
 	if status.Account.IsLocal() {
-		follows = append(follows, &gtsmodel.Follow{
-			AccountID:   status.AccountID,
-			Account:     status.Account,
-			Notify:      func() *bool { b := false; return &b }(), // Account shouldn't notify itself.
-			ShowReblogs: func() *bool { b := true; return &b }(),  // Account should show own reblogs.
-		})
+		follows = append(follows, selfFollow(status.Account))
 	}
 
 	// Timeline the status for each local follower of this account.
@@ -73,6 +70,11 @@ func (s *Surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.
 		return gtserror.Newf("error notifying status mentions for status %s: %w", status.ID, err)
 	}
 
+	// Stream the status to open conversation streams for its thread.
+	if err := s.streamConversationStatus(ctx, status, false); err != nil {
+		return gtserror.Newf("error streaming status %s to conversations: %w", status.ID, err)
+	}
+
 	return nil
 }
 
@@ -147,6 +149,19 @@ func (s *Surface) timelineAndNotifyStatusForFollowers(
 			filters,
 			compiledMutes,
 		)
+		// Notify the follower if this status matches
+		// any of their notify filters. This happens even
+		// if the status was hidden by a filter, so that
+		// "notify" and "hide" filters can be combined.
+		if nErr := s.notifyFilterMatches(ctx,
+			follow.Account,
+			status,
+			filters,
+			compiledMutes,
+		); nErr != nil {
+			errs.Appendf("error notifying account %s about filter match: %w", follow.AccountID, nErr)
+		}
+
 		if err != nil {
 			errs.Appendf("error home timelining status: %w", err)
 			continue
@@ -383,15 +398,15 @@ func (s *Surface) timelineStatus(
 }
 
 // deleteStatusFromTimelines completely removes the given status from all timelines.
-// It will also stream deletion of the status to all open streams.
-func (s *Surface) deleteStatusFromTimelines(ctx context.Context, statusID string) error {
+// It will also stream deletion of the status to all open streams, with the given reason.
+func (s *Surface) deleteStatusFromTimelines(ctx context.Context, statusID string, reason string) error {
 	if err := s.State.Timelines.Home.WipeItemFromAllTimelines(ctx, statusID); err != nil {
 		return err
 	}
 	if err := s.State.Timelines.List.WipeItemFromAllTimelines(ctx, statusID); err != nil {
 		return err
 	}
-	s.Stream.Delete(ctx, statusID)
+	s.Stream.Delete(ctx, statusID, reason)
 	return nil
 }
 
@@ -436,12 +451,7 @@ func (s *Surface) timelineStatusUpdate(ctx context.Context, status *gtsmodel.Sta
 	// If the poster is also local, add a fake entry for them
 	// so they can see their own status in their timeline.
 	if status.Account.IsLocal() {
-		follows = append(follows, &gtsmodel.Follow{
-			AccountID:   status.AccountID,
-			Account:     status.Account,
-			Notify:      func() *bool { b := false; return &b }(), // Account shouldn't notify itself.
-			ShowReblogs: func() *bool { b := true; return &b }(),  // Account should show own reblogs.
-		})
+		follows = append(follows, selfFollow(status.Account))
 	}
 
 	// Push to streams for each local follower of this account.
@@ -449,9 +459,140 @@ func (s *Surface) timelineStatusUpdate(ctx context.Context, status *gtsmodel.Sta
 		return gtserror.Newf("error timelining status %s for followers: %w", status.ID, err)
 	}
 
+	// Push to streams of followers of any accounts that boosted
+	// this status, as it appears wrapped in their boosts there.
+	if err := s.timelineStatusUpdateForBoosts(ctx, status); err != nil {
+		return gtserror.Newf("error timelining status %s for boosts: %w", status.ID, err)
+	}
+
+	// Push to open conversation streams for the status' thread.
+	if err := s.streamConversationStatus(ctx, status, true); err != nil {
+		return gtserror.Newf("error streaming status %s to conversations: %w", status.ID, err)
+	}
+
+	return nil
+}
+
+// timelineStatusUpdateForBoosts looks up boosts of the given edited
+// status, and pushes edit messages of those boosts, wrapping the
+// edited status, into active streams of local followers of boosters.
+func (s *Surface) timelineStatusUpdateForBoosts(ctx context.Context, status *gtsmodel.Status) error {
+	// Get all boosts of this status.
+	boosts, err := s.State.DB.GetStatusBoosts(ctx, status.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting boosts of status %s: %w", status.ID, err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, boost := range boosts {
+		// Wrap the edited
+		// version of status.
+		boost.BoostOf = status
+
+		// Get all local followers of the account that boosted the status.
+		follows, err := s.State.DB.GetAccountLocalFollowers(ctx, boost.AccountID)
+		if err != nil {
+			errs.Appendf("error getting local followers of account %s: %w", boost.AccountID, err)
+			continue
+		}
+
+		// If the booster is also local, add a fake entry
+		// for them so they can see their own boost updated.
+		if boost.Account.IsLocal() {
+			follows = append(follows, selfFollow(boost.Account))
+		}
+
+		// Push to streams for each local follower of the booster.
+		if err := s.timelineStatusUpdateForFollowers(ctx, boost, follows); err != nil {
+			errs.Appendf("error timelining boost %s for followers: %w", boost.ID, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// pollUpdateStreamDelay is the minimum time between
+// streamed status updates for changes in vote counts
+// of a poll, so fast-voting polls don't spam streams.
+const pollUpdateStreamDelay = 5 * time.Second
+
+// timelinePollUpdate schedules the status of the poll with the
+// given ID to be pushed as an update into active streams, in order
+// to reflect changed vote counts. Only one update is scheduled per
+// poll at a time, and it fetches the latest vote counts when run,
+// so votes in the meantime are coalesced into a single update.
+func (s *Surface) timelinePollUpdate(pollID string) {
+	taskID := "poll-update:" + pollID
+
+	// Returns false if an update is
+	// already scheduled, nothing to do.
+	_ = s.State.Workers.Scheduler.AddOnce(
+		taskID,
+		time.Now().Add(pollUpdateStreamDelay),
+		func(ctx context.Context, _ time.Time) {
+			// Untrack this task so that later
+			// votes can schedule a new update.
+			_ = s.State.Workers.Scheduler.Cancel(taskID)
+
+			if err := s.timelineStreamPollUpdate(ctx, pollID); err != nil {
+				log.Errorf(ctx, "error streaming poll update: %v", err)
+			}
+		},
+	)
+}
+
+// timelineStreamPollUpdate pushes the status of the poll
+// with given ID, with its latest vote counts, into active
+// streams of the accounts that are able to see those counts.
+func (s *Surface) timelineStreamPollUpdate(ctx context.Context, pollID string) error {
+	// Get the latest version of poll from database.
+	poll, err := s.State.DB.GetPollByID(ctx, pollID)
+	if err != nil {
+		return gtserror.Newf("error getting poll %s: %w", pollID, err)
+	}
+
+	// Extract status and
+	// set its Poll field.
+	status := poll.Status
+	status.Poll = poll
+
+	if !*poll.HideCounts || poll.Closed() {
+		// Vote counts are visible to all,
+		// push update as for any status edit.
+		return s.timelineStatusUpdate(ctx, status)
+	}
+
+	// Vote counts are hidden from everyone but the author
+	// until the poll closes, so only the author's streams
+	// would see any change. Nothing to do for remote authors.
+	if err := s.State.DB.PopulateStatus(ctx, status); err != nil {
+		return gtserror.Newf("error populating status with id %s: %w", status.ID, err)
+	}
+
+	if !status.Account.IsLocal() {
+		return nil
+	}
+
+	follows := []*gtsmodel.Follow{selfFollow(status.Account)}
+	if err := s.timelineStatusUpdateForFollowers(ctx, status, follows); err != nil {
+		return gtserror.Newf("error timelining status %s for author: %w", status.ID, err)
+	}
+
 	return nil
 }
 
+// selfFollow returns a fake follow of the given local account
+// by itself, used to timeline an account's own statuses for it.
+func selfFollow(account *gtsmodel.Account) *gtsmodel.Follow {
+	return &gtsmodel.Follow{
+		AccountID:   account.ID,
+		Account:     account,
+		Notify:      func() *bool { b := false; return &b }(), // Account shouldn't notify itself.
+		ShowReblogs: func() *bool { b := true; return &b }(),  // Account should show own reblogs.
+	}
+}
+
 // timelineStatusUpdateForFollowers iterates through the given
 // slice of followers of the account that posted the given status,
 // pushing update messages into open list/home streams of each
@@ -29,7 +29,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
 )
 
 // util provides util functions used by both
@@ -38,7 +40,10 @@ type utils struct {
 	state   *state.State
 	media   *media.Processor
 	account *account.Processor
+	status  *status.Processor
 	surface *Surface
+
+	awayReplies awayReplies
 }
 
 // wipeStatus encapsulates common logic
@@ -124,7 +129,7 @@ func (u *utils) wipeStatus(
 	}
 
 	for _, boost := range boosts {
-		if err := u.surface.deleteStatusFromTimelines(ctx, boost.ID); err != nil {
+		if err := u.surface.deleteStatusFromTimelines(ctx, boost.ID, deleteReason(ctx)); err != nil {
 			errs.Appendf("error deleting boost from timelines: %w", err)
 		}
 		if err := u.state.DB.DeleteStatusByID(ctx, boost.ID); err != nil {
@@ -133,7 +138,7 @@ func (u *utils) wipeStatus(
 	}
 
 	// delete this status from any and all timelines
-	if err := u.surface.deleteStatusFromTimelines(ctx, statusToDelete.ID); err != nil {
+	if err := u.surface.deleteStatusFromTimelines(ctx, statusToDelete.ID, deleteReason(ctx)); err != nil {
 		errs.Appendf("error deleting status from timelines: %w", err)
 	}
 
@@ -145,6 +150,17 @@ func (u *utils) wipeStatus(
 	return errs.Combine()
 }
 
+// deleteReason returns the reason to stream for deletes
+// of statuses made with the given context: moderation if
+// the context is marked as a moderation action, else the
+// status is assumed to have been deleted by its author.
+func deleteReason(ctx context.Context) string {
+	if gtscontext.Moderation(ctx) {
+		return stream.DeleteReasonModeration
+	}
+	return stream.DeleteReasonAuthor
+}
+
 // redirectFollowers redirects all local
 // followers of originAcct to targetAcct.
 //
@@ -18,11 +18,14 @@
 package workers
 
 import (
+	"context"
+
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
@@ -43,6 +46,7 @@ func New(
 	emailSender email.Sender,
 	account *account.Processor,
 	media *media.Processor,
+	status *status.Processor,
 	stream *stream.Processor,
 ) Processor {
 	// Init federate logic
@@ -68,6 +72,7 @@ func New(
 		state:   state,
 		media:   media,
 		account: account,
+		status:  status,
 		surface: surface,
 	}
 
@@ -90,3 +95,10 @@ func New(
 		},
 	}
 }
+
+// SchedulePendingCreates schedules federating the Create of all
+// local statuses still held back by the federation delay, for
+// use at startup, so that a restart doesn't mean they're lost.
+func (p *Processor) SchedulePendingCreates(ctx context.Context) error {
+	return p.clientAPI.federate.schedulePendingCreates(ctx)
+}
@@ -234,6 +234,9 @@ func visibilityIcon(visibility apimodel.Visibility) template.HTML {
 	case apimodel.VisibilityMutualsOnly:
 		label = "mutuals-only"
 		icon = "handshake-o"
+	case apimodel.VisibilityCircle:
+		label = "circle"
+		icon = "users"
 	case apimodel.VisibilityDirect:
 		label = "direct"
 		icon = "envelope"
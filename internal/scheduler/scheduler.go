@@ -64,6 +64,14 @@ func (sch *Scheduler) AddRecurring(id string, start time.Time, freq time.Duratio
 	return sch.schedule(id, fn, &sched.PeriodicAt{Once: sched.Once(start), Period: sched.Periodic(freq)})
 }
 
+// Has returns whether a task is currently registered under id.
+func (sch *Scheduler) Has(id string) bool {
+	sch.mu.Lock()
+	_, ok := sch.ts[id]
+	sch.mu.Unlock()
+	return ok
+}
+
 // Cancel attempts to cancel a scheduled task with id, returns false if no task found.
 func (sch *Scheduler) Cancel(id string) bool {
 	// Attempt to acquire and
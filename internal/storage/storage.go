@@ -18,13 +18,17 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
 	"mime"
 	"net/url"
 	"path"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -35,16 +39,51 @@ import (
 	"codeberg.org/gruf/go-storage/s3"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/oklog/ulid"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
 const (
 	urlCacheTTL             = time.Hour * 24
 	urlCacheExpiryFrequency = time.Minute * 5
+
+	// ExportRetention is how long account
+	// export archives are kept in storage.
+	ExportRetention = 7 * 24 * time.Hour
+
+	// UploadsPrefix is the key prefix under which
+	// media uploaded directly to storage by clients
+	// (see PresignedUpload) is placed, until it has
+	// been processed by the instance.
+	UploadsPrefix = "uploads/"
+
+	// ExportsPrefix is the key prefix under which
+	// account export archives are placed, until
+	// they're replaced by a newer export, or pruned.
+	ExportsPrefix = "exports/"
+
+	// healthCacheTTL is how long the result of a
+	// health check is reused for, so that frequent
+	// health probes don't hammer the backend.
+	healthCacheTTL = 10 * time.Second
+
+	// healthTimeout is how long a health
+	// check waits for the backend to answer.
+	healthTimeout = 5 * time.Second
+
+	// healthProbeKey is the key checked
+	// for on storage other than S3.
+	healthProbeKey = "gotosocial-health-probe"
 )
 
+// ErrPresignUnsupported is returned by PresignedUpload
+// when not running on S3 storage with proxying disabled.
+var ErrPresignUnsupported = errors.New("presigned uploads require s3 storage without proxying")
+
 // PresignedURL represents a pre signed S3 URL with
 // an expiry time.
 type PresignedURL struct {
@@ -64,6 +103,14 @@ func IsNotFound(err error) bool {
 	return errors.Is(err, storage.ErrNotFound)
 }
 
+// ErrUnreachable is returned in a storage health
+// check when the storage backend can't be reached.
+var ErrUnreachable = errors.New("storage unreachable")
+
+// ErrBucketMissing is returned in a storage health check
+// when S3 can be reached, but the bucket doesn't exist.
+var ErrBucketMissing = errors.New("storage bucket does not exist")
+
 // Driver wraps a kv.KVStore to also provide S3 presigned GET URLs.
 type Driver struct {
 	// Underlying storage
@@ -72,7 +119,131 @@ type Driver struct {
 	// S3-only parameters
 	Proxy          bool
 	Bucket         string
+	Class          string
 	PresignedCache *ttl.Cache[string, PresignedURL]
+
+	// S3-only storage to write keys of
+	// certain media types (eg., "emoji")
+	// to, when these should be written
+	// with a different storage class.
+	Classed map[string]ClassedStorage
+
+	// S3-only put options to use for streams of
+	// unknown size, when these should be uploaded
+	// in concurrent parts. Nil if not enabled.
+	StreamPutOpts *minio.PutObjectOptions
+
+	// S3-only tags to apply to written objects,
+	// and the put options to write them with.
+	// Nil if object tagging is not enabled.
+	Tags       []ObjectTag
+	TagPutOpts *minio.PutObjectOptions
+
+	// healthMu protects health, the
+	// result of the last health check.
+	healthMu sync.Mutex
+	health   Health
+}
+
+// Health is the result of a storage health check.
+type Health struct {
+	// Reachable is whether the
+	// storage backend answered.
+	Reachable bool
+
+	// Latency is how long the
+	// backend took to answer.
+	Latency time.Duration
+
+	// CheckedAt is when the check was done.
+	CheckedAt time.Time
+
+	// Err is why storage isn't healthy, wrapping
+	// ErrUnreachable or ErrBucketMissing, or
+	// nil if it is healthy.
+	Err error
+}
+
+// ObjectTag is a tag to apply to
+// objects written to S3 storage.
+type ObjectTag struct {
+	Key string
+
+	// Value is the fixed value
+	// of the tag, if Part < 0.
+	Value string
+
+	// Part is the index of the part of a
+	// media key to use as the tag value.
+	Part int
+}
+
+// Parts of media keys available as tag values.
+// Keys are in the form:
+// [account]/[type]/[size]/[id].[ext]
+var objectTagParts = map[string]int{
+	"account-id": 0,
+	"media-type": 1,
+	"media-size": 2,
+}
+
+// parseObjectTags parses the given (validated) object tags
+// config, with each entry either the name of a media key
+// part, or a fixed tag in the form "key=value".
+func parseObjectTags(entries []string) []ObjectTag {
+	tags := make([]ObjectTag, 0, len(entries))
+	for _, entry := range entries {
+		if key, value, ok := strings.Cut(entry, "="); ok {
+			tags = append(tags, ObjectTag{Key: key, Value: value, Part: -1})
+		} else if part, ok := objectTagParts[entry]; ok {
+			tags = append(tags, ObjectTag{Key: entry, Part: part})
+		}
+	}
+	return tags
+}
+
+// objectTags returns the tags to apply to the object
+// at the given key. Tags taking their value from part
+// of a media key are skipped for non-media keys.
+func (d *Driver) objectTags(key string) map[string]string {
+	parts := strings.Split(key, "/")
+	if len(parts) != 4 {
+		parts = nil
+	}
+
+	tags := make(map[string]string, len(d.Tags))
+	for _, tag := range d.Tags {
+		switch {
+		case tag.Part < 0:
+			tags[tag.Key] = tag.Value
+		case parts != nil:
+			tags[tag.Key] = parts[tag.Part]
+		}
+	}
+	return tags
+}
+
+// ClassedStorage wraps storage that
+// writes with a specific S3 storage class.
+type ClassedStorage struct {
+	storage.Storage
+	Class string
+}
+
+// writer returns the storage to write the given key to,
+// and the S3 storage class it will be written with, if any.
+func (d *Driver) writer(key string) (storage.Storage, string) {
+	if len(d.Classed) > 0 {
+		// Keys are in the form:
+		// [account]/[type]/[size]/[id].[ext]
+		parts := strings.SplitN(key, "/", 3)
+		if len(parts) == 3 {
+			if cs, ok := d.Classed[parts[1]]; ok {
+				return cs.Storage, cs.Class
+			}
+		}
+	}
+	return d.Storage, d.Class
 }
 
 // Get returns the byte value for key in storage.
@@ -87,12 +258,168 @@ func (d *Driver) GetStream(ctx context.Context, key string) (io.ReadCloser, erro
 
 // Put writes the supplied value bytes at key in the storage
 func (d *Driver) Put(ctx context.Context, key string, value []byte) (int, error) {
-	return d.Storage.WriteBytes(ctx, key, value)
+	st, class := d.writer(key)
+
+	if s3st, ok := st.(*s3.S3Storage); ok && d.TagPutOpts != nil {
+		// Write object with tags.
+		r := bytes.NewReader(value)
+		n, err := d.putTagged(ctx, s3st, class, key, r, r.Size())
+		return int(n), classError(err, class)
+	}
+
+	n, err := st.WriteBytes(ctx, key, value)
+	return n, classError(err, class)
 }
 
 // PutStream writes the bytes from supplied reader at key in the storage
 func (d *Driver) PutStream(ctx context.Context, key string, r io.Reader) (int64, error) {
-	return d.Storage.WriteStream(ctx, key, r)
+	st, class := d.writer(key)
+	s3st, ok := st.(*s3.S3Storage)
+	rs, sized := r.(s3.ReaderSize)
+
+	if ok && !sized && d.StreamPutOpts != nil {
+		// Unknown size, upload in concurrent parts.
+		n, err := d.putConcurrentParts(ctx, s3st, class, key, r)
+		return n, classError(err, class)
+	}
+
+	if ok && d.TagPutOpts != nil {
+		// Write object with tags,
+		// in parts if size unknown.
+		size := int64(-1)
+		if sized {
+			size = rs.Size()
+		}
+		n, err := d.putTagged(ctx, s3st, class, key, r, size)
+		return n, classError(err, class)
+	}
+
+	n, err := st.WriteStream(ctx, key, r)
+	return n, classError(err, class)
+}
+
+// putTagged writes the bytes from supplied reader at key in the
+// given S3 storage, tagged with the configured object tags. If
+// size is -1, the object is uploaded in parts as it's read.
+func (d *Driver) putTagged(ctx context.Context, st *s3.S3Storage, class string, key string, r io.Reader, size int64) (int64, error) {
+	opts := *d.TagPutOpts
+	opts.StorageClass = class
+	opts.UserTags = d.objectTags(key)
+
+	info, err := st.Client().Client.PutObject(ctx, d.Bucket, key, r, size, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	return info.Size, nil
+}
+
+// putConcurrentParts writes the bytes from supplied reader of unknown size
+// at key in the given S3 storage, uploading parts of it concurrently. On
+// error the S3 client aborts the multipart upload, so no parts are left
+// dangling in the bucket. Each part is checksummed by the S3 client, and
+// once complete the size stored is checked against the size read from r.
+func (d *Driver) putConcurrentParts(ctx context.Context, st *s3.S3Storage, class string, key string, r io.Reader) (int64, error) {
+	opts := *d.StreamPutOpts
+	opts.StorageClass = class
+	if d.TagPutOpts != nil {
+		opts.UserTags = d.objectTags(key)
+	}
+
+	cr := &countReader{r: r}
+	info, err := st.Client().Client.PutObject(ctx, d.Bucket, key, cr, -1, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	if info.Size != cr.n {
+		// Something went wrong in transit; remove
+		// the incomplete object rather than keep it.
+		if err := st.Remove(ctx, key); err != nil {
+			log.Errorf(ctx, "error removing incomplete object %s: %v", key, err)
+		}
+		return 0, fmt.Errorf("stored size %d does not match read size %d", info.Size, cr.n)
+	}
+
+	return info.Size, nil
+}
+
+// countReader wraps an io.Reader
+// to count the bytes read from it.
+type countReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countReader) Read(b []byte) (int, error) {
+	n, err := cr.r.Read(b)
+	cr.n += int64(n)
+	return n, err
+}
+
+// classError wraps a write error with the S3 storage class used
+// for the write, if any, since an unsupported storage class is
+// otherwise easy to miss as the cause of an upload failing.
+func classError(err error, class string) error {
+	if err == nil || class == "" || IsAlreadyExist(err) {
+		return err
+	}
+	return fmt.Errorf("error writing with s3 storage class %s: %w", class, err)
+}
+
+// Health checks whether the storage backend can be reached, and for
+// S3 whether the bucket exists, with a cheap bucket-exists call (or a
+// stat on other storage). Results are reused for a few seconds, so
+// this can be called as often as needed by health endpoints.
+func (d *Driver) Health(ctx context.Context) Health {
+	d.healthMu.Lock()
+	defer d.healthMu.Unlock()
+
+	now := time.Now()
+	if !d.health.CheckedAt.IsZero() && now.Sub(d.health.CheckedAt) < healthCacheTTL {
+		return d.health
+	}
+
+	ctx, cncl := context.WithTimeout(ctx, healthTimeout)
+	defer cncl()
+
+	var (
+		reachable = true
+		err       error
+	)
+
+	if s3st, ok := d.Storage.(*s3.S3Storage); ok {
+		var exists bool
+		exists, err = s3st.Client().BucketExists(ctx, d.Bucket)
+		switch {
+		case err != nil:
+			reachable = false
+			err = fmt.Errorf("%w: %w", ErrUnreachable, err)
+		case !exists:
+			err = fmt.Errorf("%w: %s", ErrBucketMissing, d.Bucket)
+		}
+	} else if _, err = d.Storage.Stat(ctx, healthProbeKey); err != nil {
+		reachable = false
+		err = fmt.Errorf("%w: %w", ErrUnreachable, err)
+	}
+
+	d.health = Health{
+		Reachable: reachable,
+		Latency:   time.Since(now),
+		CheckedAt: now,
+		Err:       err,
+	}
+	return d.health
+}
+
+// Ready returns an error if the storage backend is
+// not healthy, for use in readiness health checks.
+func (d *Driver) Ready(ctx context.Context) error {
+	health := d.Health(ctx)
+	if health.Err != nil {
+		return fmt.Errorf("storage not ready after %s: %w", health.Latency, health.Err)
+	}
+	return nil
 }
 
 // Delete attempts to remove the supplied key (and corresponding value) from storage.
@@ -115,6 +442,76 @@ func (d *Driver) WalkKeys(ctx context.Context, walk func(string) error) error {
 	})
 }
 
+// Size returns the size of the value at key in storage.
+func (d *Driver) Size(ctx context.Context, key string) (int64, error) {
+	stat, err := d.Storage.Stat(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	if stat == nil {
+		return 0, storage.ErrNotFound
+	}
+	return stat.Size, nil
+}
+
+// UploadKeyPrefix returns the key prefix of media
+// uploaded directly to storage by the given account.
+func UploadKeyPrefix(accountID string) string {
+	return UploadsPrefix + accountID + "/"
+}
+
+// IsUploadKey returns whether the given key is that of media
+// uploaded directly to storage by the given account, ie., the
+// account's upload key prefix followed by a single ULID. Keys
+// are checked exactly, as client-supplied keys could otherwise
+// reach outside of the account's uploads, eg. via "..".
+func IsUploadKey(key string, accountID string) bool {
+	uploadID, ok := strings.CutPrefix(key, UploadKeyPrefix(accountID))
+	if !ok {
+		return false
+	}
+	_, err := ulid.ParseStrict(uploadID)
+	return err == nil
+}
+
+// ExportKey returns the key of the
+// given account's export archive.
+func ExportKey(accountID string, exportID string) string {
+	return ExportsPrefix + accountID + "/" + exportID + ".zip"
+}
+
+// PresignedUpload will return a presigned PUT object URL at which the given account
+// can upload a file directly, along with the key the file will be stored at. This
+// is only supported when running on S3 storage with proxying disabled; otherwise
+// ErrPresignUnsupported is returned.
+func (d *Driver) PresignedUpload(ctx context.Context, accountID string) (string, *PresignedURL, error) {
+	if !d.SupportsPresignedUploads() {
+		return "", nil, ErrPresignUnsupported
+	}
+	s3 := d.Storage.(*s3.S3Storage)
+
+	key := UploadKeyPrefix(accountID) + id.NewULID()
+	expiry := config.GetStorageS3PresignedUploadExpiry()
+
+	u, err := s3.Client().PresignedPutObject(ctx, d.Bucket, key, expiry)
+	if err != nil {
+		return "", nil, gtserror.Newf("error presigning upload for key %s: %w", key, err)
+	}
+
+	return key, &PresignedURL{
+		URL:    u,
+		Expiry: time.Now().Add(expiry),
+	}, nil
+}
+
+// SupportsPresignedUploads returns whether accounts can upload
+// files directly to storage, ie., whether running on S3 storage
+// with proxying disabled (see PresignedUpload).
+func (d *Driver) SupportsPresignedUploads() bool {
+	_, ok := d.Storage.(*s3.S3Storage)
+	return ok && !d.Proxy
+}
+
 // URL will return a presigned GET object URL, but only if running on S3 storage with proxying disabled.
 func (d *Driver) URL(ctx context.Context, key string) *PresignedURL {
 	// Check whether S3 *without* proxying is enabled
@@ -235,6 +632,13 @@ func NewFileStorage() (*Driver, error) {
 		return nil, fmt.Errorf("error opening disk storage: %w", err)
 	}
 
+	if len(config.GetStorageS3ObjectTags()) > 0 {
+		// Tags only apply to objects
+		// in S3, so nothing to do.
+		log.Warnf(nil, "%s is set, but will be ignored as storage backend is local",
+			config.StorageS3ObjectTagsFlag())
+	}
+
 	return &Driver{
 		Storage: disk,
 	}, nil
@@ -247,24 +651,121 @@ func NewS3Storage() (*Driver, error) {
 	secret := config.GetStorageS3SecretKey()
 	secure := config.GetStorageS3UseSSL()
 	bucket := config.GetStorageS3BucketName()
+	class := config.GetStorageS3StorageClass()
+	partSize := uint64(config.GetStorageS3PartSize())
+	numThreads := uint(config.GetStorageS3NumThreads()) // #nosec G115 -- Checked during validation
+
+	// Server-side encryption to store
+	// media with; nil for bucket default.
+	sse, err := serverSideEncryption()
+	if err != nil {
+		return nil, fmt.Errorf("error preparing s3 server-side encryption: %w", err)
+	}
+
+	// Chunk size for uploads of unknown
+	// size, which we upload part-by-part
+	// ourselves; use part size if set.
+	chunkSize := int64(5 * 1024 * 1024) // 5MiB
+	if partSize != 0 {
+		chunkSize = int64(partSize) // #nosec G115 -- Checked during validation
+	}
 
 	// Open the s3 storage implementation
-	s3, err := s3.Open(endpoint, bucket, &s3.Config{
-		CoreOpts: minio.Options{
-			Creds:  credentials.NewStaticV4(access, secret, ""),
-			Secure: secure,
-		},
-		GetOpts:      minio.GetObjectOptions{},
-		PutOpts:      minio.PutObjectOptions{},
-		PutChunkSize: 5 * 1024 * 1024, // 5MiB
-		StatOpts:     minio.StatObjectOptions{},
-		RemoveOpts:   minio.RemoveObjectOptions{},
-		ListSize:     200,
-	})
+	open := func(class string) (*s3.S3Storage, error) {
+		return s3.Open(endpoint, bucket, &s3.Config{
+			CoreOpts: minio.Options{
+				Creds:  credentials.NewStaticV4(access, secret, ""),
+				Secure: secure,
+			},
+			GetOpts: minio.GetObjectOptions{
+				// Only sent if SSE-C, as
+				// the key is needed to read.
+				ServerSideEncryption: sse,
+			},
+			PutOpts: minio.PutObjectOptions{
+				StorageClass:         class,
+				PartSize:             partSize,
+				NumThreads:           numThreads,
+				ServerSideEncryption: sse,
+			},
+			PutChunkOpts: minio.PutObjectPartOptions{
+				// Only sent if SSE-C.
+				SSE: sse,
+			},
+			PutChunkSize: chunkSize,
+			StatOpts: minio.StatObjectOptions{
+				ServerSideEncryption: sse,
+			},
+			RemoveOpts: minio.RemoveObjectOptions{},
+			ListSize:   200,
+		})
+	}
+
+	s3, err := open(class)
 	if err != nil {
 		return nil, fmt.Errorf("error opening s3 storage: %w", err)
 	}
 
+	if sse != nil {
+		// Fail fast if the provider can't encrypt as requested,
+		// rather than find out after storing media unencrypted.
+		if err := probeSSE(context.Background(), s3, bucket, sse); err != nil {
+			return nil, fmt.Errorf("error checking s3 server-side encryption: %w", err)
+		}
+	}
+
+	// Prepare options for uploading streams of
+	// unknown size in concurrent parts, if enabled.
+	var streamPutOpts *minio.PutObjectOptions
+	if config.GetStorageS3ConcurrentStreamParts() {
+		if numThreads == 0 {
+			// Match S3 client's
+			// default thread count.
+			numThreads = 4
+		}
+
+		streamPutOpts = &minio.PutObjectOptions{
+			// Always set a part size, as for unknown
+			// sizes the S3 client otherwise picks one
+			// big enough for a 5TiB object, per thread.
+			PartSize:              uint64(chunkSize), // #nosec G115 -- Always positive
+			NumThreads:            numThreads,
+			ConcurrentStreamParts: true,
+			ServerSideEncryption:  sse,
+		}
+	}
+
+	// Prepare options for writing objects
+	// with tags, if any are configured.
+	var (
+		tags       []ObjectTag
+		tagPutOpts *minio.PutObjectOptions
+	)
+	if tagsConfig := config.GetStorageS3ObjectTags(); len(tagsConfig) > 0 {
+		tags = parseObjectTags(tagsConfig)
+		tagPutOpts = &minio.PutObjectOptions{
+			// Always set a part size,
+			// as for stream put opts.
+			PartSize:             uint64(chunkSize), // #nosec G115 -- Always positive
+			NumThreads:           numThreads,
+			ServerSideEncryption: sse,
+		}
+	}
+
+	// Open separate storage for emojis
+	// if they use a different class.
+	var classed map[string]ClassedStorage
+	if emojiClass := config.GetStorageS3StorageClassEmojis(); emojiClass != "" && emojiClass != class {
+		emojiS3, err := open(emojiClass)
+		if err != nil {
+			return nil, fmt.Errorf("error opening s3 storage for emojis: %w", err)
+		}
+
+		classed = map[string]ClassedStorage{
+			"emoji": {Storage: emojiS3, Class: emojiClass},
+		}
+	}
+
 	// ttl should be lower than the expiry used by S3 to avoid serving invalid URLs
 	presignedCache := ttl.New[string, PresignedURL](0, 1000, urlCacheTTL-urlCacheExpiryFrequency)
 	presignedCache.Start(urlCacheExpiryFrequency)
@@ -272,7 +773,77 @@ func NewS3Storage() (*Driver, error) {
 	return &Driver{
 		Proxy:          config.GetStorageS3Proxy(),
 		Bucket:         config.GetStorageS3BucketName(),
+		Class:          class,
+		Classed:        classed,
+		StreamPutOpts:  streamPutOpts,
+		Tags:           tags,
+		TagPutOpts:     tagPutOpts,
 		Storage:        s3,
 		PresignedCache: presignedCache,
 	}, nil
 }
+
+// serverSideEncryption returns the server-side encryption
+// configured to store media in S3 with, or nil if none.
+func serverSideEncryption() (encrypt.ServerSide, error) {
+	switch config.GetStorageS3SSE() {
+	case "sse-s3":
+		return encrypt.NewSSE(), nil
+	case "sse-kms":
+		return encrypt.NewSSEKMS(config.GetStorageS3SSEKMSKeyID(), nil)
+	case "sse-c":
+		key, err := base64.StdEncoding.DecodeString(config.GetStorageS3SSECKey())
+		if err != nil {
+			return nil, err
+		}
+		return encrypt.NewSSEC(key)
+	default:
+		return nil, nil
+	}
+}
+
+// probeSSE checks that the S3 provider supports the given
+// server-side encryption, by storing a small file with it,
+// and checking that the provider reports it as encrypted
+// accordingly. Some S3-compatible providers ignore the
+// encryption headers, and would store media unencrypted.
+func probeSSE(ctx context.Context, st *s3.S3Storage, bucket string, sse encrypt.ServerSide) error {
+	const sseKey = "gotosocial-sse-probe"
+
+	// Create a small file in S3 storage, using
+	// the configured put options (incl. sse).
+	if _, err := st.WriteBytes(ctx, sseKey, []byte(sseKey)); err != nil {
+		return gtserror.Newf("error putting file in bucket at key %s: %w", sseKey, err)
+	}
+
+	// Try to clean up file whatever happens.
+	defer func() {
+		if err := st.Remove(ctx, sseKey); err != nil {
+			log.Warnf(ctx, "error deleting file from bucket at key %s (%v); "+
+				"you may want to remove this file manually from your S3 bucket", sseKey, err)
+		}
+	}()
+
+	info, err := st.Client().StatObject(ctx, bucket, sseKey, minio.StatObjectOptions{
+		ServerSideEncryption: sse,
+	})
+	if err != nil {
+		return gtserror.Newf("error getting info of file in bucket at key %s: %w", sseKey, err)
+	}
+
+	var ok bool
+	switch sse.Type() {
+	case encrypt.S3:
+		ok = info.Metadata.Get(encrypt.SseGenericHeader) == "AES256"
+	case encrypt.KMS:
+		ok = strings.HasPrefix(info.Metadata.Get(encrypt.SseGenericHeader), "aws:kms")
+	case encrypt.SSEC:
+		ok = info.Metadata.Get(encrypt.SseCustomerAlgorithm) != ""
+	}
+
+	if !ok {
+		return gtserror.Newf("provider did not store file with %s encryption; it may not support it", sse.Type())
+	}
+
+	return nil
+}
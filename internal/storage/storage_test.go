@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StorageTestSuite struct {
+	suite.Suite
+}
+
+func (suite *StorageTestSuite) TestIsUploadKey() {
+	const (
+		accountID = "01FS1X72SK9ZPW0J1QQ68BD264"
+		victimID  = "01F8MH1H7YV1Z7D2C8K2730QBF"
+		uploadID  = "01J2A8TMQ1TVJ2VQZ7WSQ0R7X6"
+	)
+
+	for _, test := range []struct {
+		key   string
+		valid bool
+	}{
+		{storage.UploadKeyPrefix(accountID) + uploadID, true},
+		{storage.UploadKeyPrefix(victimID) + uploadID, false},
+		{storage.UploadKeyPrefix(accountID) + "../../" + victimID + "/attachment/original/" + uploadID + ".jpg", false},
+		{storage.UploadKeyPrefix(accountID) + uploadID + ".jpg", false},
+		{storage.UploadKeyPrefix(accountID) + uploadID + "/" + uploadID, false},
+		{storage.UploadKeyPrefix(accountID), false},
+		{uploadID, false},
+	} {
+		suite.Equal(test.valid, storage.IsUploadKey(test.key, accountID), test.key)
+	}
+}
+
+func (suite *StorageTestSuite) TestHealth() {
+	var (
+		ctx    = context.Background()
+		driver = testrig.NewInMemoryStorage()
+	)
+
+	health := driver.Health(ctx)
+	suite.True(health.Reachable)
+	suite.NoError(health.Err)
+	suite.False(health.CheckedAt.IsZero())
+	suite.NoError(driver.Ready(ctx))
+
+	// A second check straight away
+	// should reuse the first result.
+	again := driver.Health(ctx)
+	suite.Equal(health.CheckedAt, again.CheckedAt)
+}
+
+func TestStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(StorageTestSuite))
+}
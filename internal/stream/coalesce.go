@@ -0,0 +1,190 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream
+
+import (
+	"encoding/json"
+	"time"
+
+	"codeberg.org/gruf/go-byteutil"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+// MaxNotificationBatchWindow is the longest
+// window that notifications may be batched
+// within, so that they aren't held for long.
+const MaxNotificationBatchWindow = 10 * time.Second
+
+// maxSampleAccounts is the maximum number
+// of accounts included in a notification group.
+const maxSampleAccounts = 8
+
+// batch is a group of notification msgs of the same
+// type about the same status, held back to be sent
+// together as one msg once the batch window is up.
+type batch struct {
+	count  int
+	last   Message
+	recent []*apimodel.Notification
+}
+
+// SetNotificationBatchWindow sets the window within which favourite
+// and boost notifications of the same status are batched on the stream.
+//
+// The first such notification is held back for the window, and any more
+// of the same type about the same status received in the meantime are
+// added to it. Once the window is up, a single notification is sent
+// as-is, while several are sent together as one EventTypeNotificationGroup
+// msg with a count. Zero, the default, turns batching off.
+func (s *Stream) SetNotificationBatchWindow(window time.Duration) {
+	s.batchWindow.Store(int64(min(window, MaxNotificationBatchWindow)))
+}
+
+// batchKey returns the key that the given msg is batched
+// under, or an empty string if it can't be batched.
+func batchKey(msg Message) string {
+	if msg.Event != EventTypeNotification ||
+		msg.Notification == nil ||
+		msg.Notification.Status == nil ||
+		len(msg.Stream) == 0 {
+		return ""
+	}
+
+	switch msg.NotificationType {
+	case "favourite", "reblog":
+		return msg.Stream[0] + " " + msg.NotificationType + "-" + msg.Notification.Status.ID
+	default:
+		return ""
+	}
+}
+
+// hold adds the given msg to its batch, starting
+// a new batch if there isn't one yet, returning
+// false if the msg isn't batched, and should be
+// sent right away instead.
+func (s *Stream) hold(msg Message) bool {
+	window := time.Duration(s.batchWindow.Load())
+	if window <= 0 {
+		// Batching off.
+		return false
+	}
+
+	key := batchKey(msg)
+	if key == "" {
+		// Can't be batched.
+		return false
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	b := s.batches[key]
+	if b == nil {
+		// Start a new batch, to
+		// send once window is up.
+		if s.batches == nil {
+			s.batches = make(map[string]*batch)
+		}
+		b = new(batch)
+		s.batches[key] = b
+		time.AfterFunc(window, func() { s.flush(key) })
+	}
+
+	b.count++
+	b.last = msg
+	b.recent = append(b.recent, msg.Notification)
+	if len(b.recent) > maxSampleAccounts {
+		// Only the most recent are
+		// needed for sample accounts.
+		b.recent = b.recent[1:]
+	}
+
+	return true
+}
+
+// flush sends the batch with the given key
+// as one msg, unless the stream was closed.
+func (s *Stream) flush(key string) {
+	s.mutex.Lock()
+	b := s.batches[key]
+	delete(s.batches, key)
+	s.mutex.Unlock()
+
+	if b == nil {
+		return
+	}
+
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+
+	s.push(b.message(key))
+}
+
+// message returns the msg to send for the batch
+// with the given key: the batched msg if there's
+// only one, or otherwise a notification group msg.
+func (b *batch) message(key string) Message {
+	if b.count == 1 {
+		return b.last
+	}
+
+	latest := b.last.Notification
+	group := &apimodel.NotificationGroup{
+		GroupKey:                 latest.Type + "-" + latest.Status.ID,
+		NotificationsCount:       b.count,
+		Type:                     latest.Type,
+		MostRecentNotificationID: latest.ID,
+		LatestNotificationAt:     latest.CreatedAt,
+		SampleAccounts:           make([]*apimodel.Account, 0, len(b.recent)),
+		Status:                   latest.Status,
+	}
+
+	// Add accounts most recent first,
+	// skipping any already added.
+	seen := make(map[string]struct{}, len(b.recent))
+	for i := len(b.recent) - 1; i >= 0; i-- {
+		account := b.recent[i].Account
+		if account == nil {
+			continue
+		}
+		if _, ok := seen[account.ID]; ok {
+			continue
+		}
+		seen[account.ID] = struct{}{}
+		group.SampleAccounts = append(group.SampleAccounts, account)
+	}
+
+	payload, err := json.Marshal(group)
+	if err != nil {
+		// Shouldn't happen, but at
+		// least send the latest one.
+		return b.last
+	}
+
+	return Message{
+		ID:      b.last.ID,
+		Stream:  b.last.Stream,
+		Event:   EventTypeNotificationGroup,
+		Payload: byteutil.B2S(payload),
+
+		NotificationType: b.last.NotificationType,
+	}
+}
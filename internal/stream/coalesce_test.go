@@ -0,0 +1,147 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+type CoalesceTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CoalesceTestSuite) notify(streams *stream.Streams, notifType string, statusID string, accountID string) {
+	streams.Post(context.Background(), "account", stream.Message{
+		Stream:  []string{stream.TimelineNotifications},
+		Event:   stream.EventTypeNotification,
+		Payload: notifType + ":" + statusID + ":" + accountID,
+		Notification: &apimodel.Notification{
+			ID:      notifType + statusID + accountID,
+			Type:    notifType,
+			Account: &apimodel.Account{ID: accountID},
+			Status:  &apimodel.Status{ID: statusID},
+		},
+		NotificationType: notifType,
+	})
+}
+
+func (suite *CoalesceTestSuite) recvAll(str *stream.Stream) []stream.Message {
+	var msgs []stream.Message
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		msg, ok := str.Recv(ctx)
+		cancel()
+		if !ok {
+			return msgs
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+func (suite *CoalesceTestSuite) TestBatchSameStatus() {
+	streams := new(stream.Streams)
+
+	str := streams.Open("account", stream.TimelineNotifications)
+	defer str.Close()
+	str.SetNotificationBatchWindow(50 * time.Millisecond)
+
+	suite.notify(streams, "favourite", "status1", "alice")
+	suite.notify(streams, "favourite", "status1", "bob")
+	suite.notify(streams, "favourite", "status1", "alice")
+	suite.notify(streams, "mention", "status1", "carol")
+
+	msgs := suite.recvAll(str)
+	if !suite.Len(msgs, 2) {
+		return
+	}
+
+	// Mentions aren't batched,
+	// so it's sent right away.
+	suite.Equal(stream.EventTypeNotification, msgs[0].Event)
+	suite.Equal("mention:status1:carol", msgs[0].Payload)
+
+	// The favourites come
+	// together afterwards.
+	suite.Equal(stream.EventTypeNotificationGroup, msgs[1].Event)
+
+	var group apimodel.NotificationGroup
+	if err := json.Unmarshal([]byte(msgs[1].Payload), &group); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("favourite-status1", group.GroupKey)
+	suite.Equal("favourite", group.Type)
+	suite.Equal(3, group.NotificationsCount)
+	suite.Equal("favouritestatus1alice", group.MostRecentNotificationID)
+	suite.Equal("status1", group.Status.ID)
+	if suite.Len(group.SampleAccounts, 2) {
+		suite.Equal("alice", group.SampleAccounts[0].ID)
+		suite.Equal("bob", group.SampleAccounts[1].ID)
+	}
+}
+
+func (suite *CoalesceTestSuite) TestNoBatchDistinctStatuses() {
+	streams := new(stream.Streams)
+
+	str := streams.Open("account", stream.TimelineNotifications)
+	defer str.Close()
+	str.SetNotificationBatchWindow(50 * time.Millisecond)
+
+	suite.notify(streams, "favourite", "status1", "alice")
+	suite.notify(streams, "favourite", "status2", "bob")
+	suite.notify(streams, "reblog", "status1", "carol")
+
+	// Each is alone in its batch,
+	// so they're sent as they were.
+	var payloads []string
+	for _, msg := range suite.recvAll(str) {
+		suite.Equal(stream.EventTypeNotification, msg.Event)
+		payloads = append(payloads, msg.Payload)
+	}
+	suite.ElementsMatch([]string{
+		"favourite:status1:alice",
+		"favourite:status2:bob",
+		"reblog:status1:carol",
+	}, payloads)
+}
+
+func (suite *CoalesceTestSuite) TestNoBatchByDefault() {
+	streams := new(stream.Streams)
+
+	str := streams.Open("account", stream.TimelineNotifications)
+	defer str.Close()
+
+	suite.notify(streams, "favourite", "status1", "alice")
+	suite.notify(streams, "favourite", "status1", "bob")
+
+	var events []string
+	for _, msg := range suite.recvAll(str) {
+		events = append(events, msg.Event)
+	}
+	suite.Equal([]string{"notification", "notification"}, events)
+}
+
+func TestCoalesceTestSuite(t *testing.T) {
+	suite.Run(t, new(CoalesceTestSuite))
+}
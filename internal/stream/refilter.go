@@ -0,0 +1,137 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream
+
+import (
+	"context"
+	"time"
+)
+
+// MaxRefilterDeletes is the max number of delete events
+// sent to one stream when its filter is re-applied to
+// recently posted statuses, so that a filter change
+// can't flood a connection with a busy timeline open.
+const MaxRefilterDeletes = 20
+
+// SetRetroactiveFilter sets whether the stream's filter should be
+// re-applied to recently sent statuses when Refilter() is called,
+// sending a delete event for each status that's now filtered out.
+// The default is to only apply the filter to new messages.
+func (s *Stream) SetRetroactiveFilter(retroactive bool) {
+	s.retroactive.Store(retroactive)
+}
+
+// Refilter re-applies the filters of the given account's streams that have
+// retroactive filtering enabled to the statuses still buffered for the
+// account, newest first. For each status now dropped by a stream's filter,
+// a delete event is sent on that stream, up to MaxRefilterDeletes per stream.
+func (s *Streams) Refilter(ctx context.Context, accountID string) {
+	// Acquire lock.
+	s.mutex.Lock()
+
+	var strs []*Stream
+	for _, str := range s.streams[accountID] {
+		if str.retroactive.Load() &&
+			str.filter.Load() != nil {
+			strs = append(strs, str)
+		}
+	}
+
+	b := s.buffers[accountID]
+	if b == nil || len(strs) == 0 {
+		// Nothing to do.
+		s.mutex.Unlock()
+		return
+	}
+
+	// Gather status msgs, newest first.
+	now := time.Now()
+	b.prune(now)
+	statusMsgs := make([]Message, 0, len(b.msgs))
+	for i := len(b.msgs) - 1; i >= 0; i-- {
+		msg := b.msgs[i].msg
+		if msg.Status != nil {
+			statusMsgs = append(statusMsgs, msg)
+		}
+	}
+
+	// Done with lock. Filters are checked outside
+	// of it, as they may need to hit the database.
+	s.mutex.Unlock()
+
+	for _, str := range strs {
+		var deletes []Message
+
+		// Statuses already checked, as a
+		// status may have been edited.
+		seen := make(map[string]struct{})
+
+		for _, msg := range statusMsgs {
+			if len(deletes) >= MaxRefilterDeletes {
+				break
+			}
+
+			statusID := msg.Status.ID
+			if _, ok := seen[statusID]; ok {
+				continue
+			}
+			seen[statusID] = struct{}{}
+
+			stype := str.getStreamType(msg.Stream...)
+			if stype == "" {
+				// Never sent here.
+				continue
+			}
+
+			if str.keep(Message{
+				Stream: []string{stype},
+				Event:  msg.Event,
+				Status: msg.Status,
+			}) {
+				// Still fine.
+				continue
+			}
+
+			deletes = append(deletes, Message{
+				Stream:       []string{stype},
+				Event:        EventTypeDelete,
+				Payload:      statusID,
+				DeleteReason: DeleteReasonFiltered,
+			})
+		}
+
+		if len(deletes) == 0 {
+			continue
+		}
+
+		// Give deletes IDs so clients can
+		// keep tracking the last event ID.
+		s.mutex.Lock()
+		for i := range deletes {
+			deletes[i].ID = s.nextID(time.Now())
+		}
+		s.mutex.Unlock()
+
+		for _, msg := range deletes {
+			if !str.send(ctx, msg) {
+				// Closed.
+				break
+			}
+		}
+	}
+}
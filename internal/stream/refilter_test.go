@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+type RefilterTestSuite struct {
+	suite.Suite
+}
+
+func (suite *RefilterTestSuite) post(streams *stream.Streams, accountID string, statusID string) {
+	streams.Post(context.Background(), accountID, stream.Message{
+		Stream:  []string{stream.TimelineHome},
+		Event:   stream.EventTypeUpdate,
+		Payload: statusID,
+		Status:  &apimodel.Status{ID: statusID},
+	})
+}
+
+func (suite *RefilterTestSuite) recvAll(str *stream.Stream) []stream.Message {
+	var msgs []stream.Message
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		msg, ok := str.Recv(ctx)
+		cancel()
+		if !ok {
+			return msgs
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+func (suite *RefilterTestSuite) TestRefilter() {
+	streams := new(stream.Streams)
+
+	// Filter starts out letting everything through.
+	hidden := map[string]bool{}
+	filter := func(msg stream.Message) bool {
+		return msg.Status == nil || !hidden[msg.Status.ID]
+	}
+
+	retroactive := streams.Open("account", stream.TimelineHome)
+	defer retroactive.Close()
+	retroactive.SetFilter(filter)
+	retroactive.SetRetroactiveFilter(true)
+
+	plain := streams.Open("account", stream.TimelineHome)
+	defer plain.Close()
+	plain.SetFilter(filter)
+
+	suite.post(streams, "account", "1")
+	suite.post(streams, "account", "2")
+	suite.post(streams, "account", "3")
+	suite.Len(suite.recvAll(retroactive), 3)
+	suite.Len(suite.recvAll(plain), 3)
+
+	// Filters change to hide some.
+	hidden["1"] = true
+	hidden["3"] = true
+	streams.Refilter(context.Background(), "account")
+
+	var payloads []string
+	for _, msg := range suite.recvAll(retroactive) {
+		suite.Equal(stream.EventTypeDelete, msg.Event)
+		suite.Equal([]string{stream.TimelineHome}, msg.Stream)
+		suite.NotEmpty(msg.ID)
+		payloads = append(payloads, msg.Payload)
+	}
+	suite.Equal([]string{"3", "1"}, payloads)
+
+	// Not opted in.
+	suite.Empty(suite.recvAll(plain))
+}
+
+func (suite *RefilterTestSuite) TestRefilterLimit() {
+	streams := new(stream.Streams)
+
+	str := streams.Open("account", stream.TimelineHome)
+	defer str.Close()
+	str.SetRetroactiveFilter(true)
+
+	for i := 0; i < 2*stream.MaxRefilterDeletes; i++ {
+		suite.post(streams, "account", strconv.Itoa(i))
+	}
+	suite.Len(suite.recvAll(str), 2*stream.MaxRefilterDeletes)
+
+	// Now filter everything.
+	str.SetFilter(func(stream.Message) bool { return false })
+	streams.Refilter(context.Background(), "account")
+
+	msgs := suite.recvAll(str)
+	suite.Len(msgs, stream.MaxRefilterDeletes)
+
+	// Newest first.
+	suite.Equal(strconv.Itoa(2*stream.MaxRefilterDeletes-1), msgs[0].Payload)
+}
+
+func TestRefilterTestSuite(t *testing.T) {
+	suite.Run(t, new(RefilterTestSuite))
+}
@@ -0,0 +1,204 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream
+
+import (
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	// BufferSize is the max number of recently posted
+	// messages kept per account (and for PostAll()),
+	// for replaying to resuming streams.
+	BufferSize = 256
+
+	// BufferMaxAge is the max age of buffered messages.
+	// It's also how long an account's buffer is kept
+	// after its last stream closes, for it to resume.
+	BufferMaxAge = 5 * time.Minute
+)
+
+// buffer is a bounded buffer of recently posted messages.
+type buffer struct {
+	// buffered msgs, oldest first.
+	msgs []bufferedMsg
+
+	// ID of the newest msg that was
+	// dropped from the buffer, any
+	// stream resuming from before
+	// this can't replay everything.
+	dropped uint64
+
+	// time the account's last stream
+	// was closed, zero if still open.
+	closedAt time.Time
+}
+
+type bufferedMsg struct {
+	id  uint64
+	at  time.Time
+	msg Message
+}
+
+// add will add msg to the buffer, dropping any too old or over size.
+func (b *buffer) add(msg Message, now time.Time) {
+	id, _ := strconv.ParseUint(msg.ID, 10, 64)
+	b.prune(now)
+	if len(b.msgs) >= BufferSize {
+		b.dropped = b.msgs[0].id
+		b.msgs = slices.Delete(b.msgs, 0, 1)
+	}
+	b.msgs = append(b.msgs, bufferedMsg{id: id, at: now, msg: msg})
+}
+
+// prune will drop any buffered msgs older than BufferMaxAge.
+func (b *buffer) prune(now time.Time) {
+	var i int
+	for i < len(b.msgs) && now.Sub(b.msgs[i].at) > BufferMaxAge {
+		b.dropped = b.msgs[i].id
+		i++
+	}
+	if i > 0 {
+		b.msgs = slices.Delete(b.msgs, 0, i)
+	}
+}
+
+// nextID returns a new message ID. These are seeded from the
+// clock so they keep increasing across restarts, meaning any
+// ID from before a restart is recognized as unavailable.
+// Must be called under lock.
+func (s *Streams) nextID(now time.Time) string {
+	id := uint64(now.UnixNano()) // #nosec G115 -- Unix time is positive.
+	if id <= s.lastID {
+		id = s.lastID + 1
+	}
+	s.lastID = id
+	return strconv.FormatUint(id, 10)
+}
+
+// buffer starts buffering msgs for account, if not already.
+// Must be called under lock.
+func (s *Streams) buffer(accountID string) {
+	if s.buffers == nil {
+		s.buffers = make(map[string]*buffer)
+	}
+	b := s.buffers[accountID]
+	if b == nil {
+		b = new(buffer)
+
+		// Anything before now
+		// was never buffered.
+		b.dropped = s.lastID
+		s.buffers[accountID] = b
+	}
+	b.closedAt = time.Time{}
+}
+
+// sweep drops buffers of accounts with no streams open
+// for longer than BufferMaxAge. Must be called under lock.
+func (s *Streams) sweep(now time.Time) {
+	for accountID, b := range s.buffers {
+		if !b.closedAt.IsZero() && now.Sub(b.closedAt) > BufferMaxAge {
+			delete(s.buffers, accountID)
+		}
+	}
+}
+
+// replay returns the buffered msgs for account posted after lastEventID,
+// matching str's stream types, oldest first. If not all of these msgs
+// are available, a single reset msg is returned. Must be called under lock.
+func (s *Streams) replay(str *Stream, accountID string, lastEventID string, now time.Time) []Message {
+	reset := []Message{{
+		Stream: str.streamTypes(),
+		Event:  EventTypeReset,
+	}}
+
+	lastID, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil || lastID > s.lastID {
+		// Not an ID we gave out.
+		return reset
+	}
+
+	b := s.buffers[accountID]
+	if b == nil {
+		// Nothing buffered for
+		// account since then.
+		return reset
+	}
+
+	var msgs []bufferedMsg
+	for _, b := range []*buffer{b, &s.public} {
+		b.prune(now)
+		if lastID < b.dropped {
+			// Msgs since then
+			// no longer available.
+			return reset
+		}
+		for _, m := range b.msgs {
+			if m.id > lastID {
+				msgs = append(msgs, m)
+			}
+		}
+	}
+
+	// Merge account and PostAll() msgs in posted order.
+	slices.SortFunc(msgs, func(a, b bufferedMsg) int {
+		switch {
+		case a.id < b.id:
+			return -1
+		case a.id > b.id:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	var replay []Message
+	for _, m := range msgs {
+		// Only include the supported stream, as Post() does.
+		if stype := str.getStreamType(m.msg.Stream...); stype != "" {
+			replay = append(replay, Message{
+				ID:      m.msg.ID,
+				Stream:  []string{stype},
+				Event:   m.msg.Event,
+				Payload: m.msg.Payload,
+				Status:  m.msg.Status,
+
+				Notification:     m.msg.Notification,
+				NotificationType: m.msg.NotificationType,
+				DeleteReason:     m.msg.DeleteReason,
+			})
+		}
+	}
+
+	return replay
+}
+
+// streamTypes returns the stream types subscribed to, sorted.
+func (s *Stream) streamTypes() []string {
+	var types []string
+	if ptr := s.types.Load(); ptr != nil {
+		for streamType := range *ptr {
+			types = append(types, streamType)
+		}
+	}
+	slices.Sort(types)
+	return types
+}
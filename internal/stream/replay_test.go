@@ -0,0 +1,129 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+type ReplayTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ReplayTestSuite) post(streams *stream.Streams, accountID string, payload string) {
+	streams.Post(context.Background(), accountID, stream.Message{
+		Stream:  []string{stream.TimelineHome},
+		Event:   stream.EventTypeUpdate,
+		Payload: payload,
+	})
+}
+
+func (suite *ReplayTestSuite) recv(str *stream.Stream) (stream.Message, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	return str.Recv(ctx)
+}
+
+func (suite *ReplayTestSuite) TestResume() {
+	streams := new(stream.Streams)
+
+	str := streams.Open("account", stream.TimelineHome)
+	suite.post(streams, "account", "1")
+	msg, ok := suite.recv(str)
+	suite.True(ok)
+	suite.Equal("1", msg.Payload)
+	suite.NotEmpty(msg.ID)
+	lastEventID := msg.ID
+
+	// Connection drops, and more msgs
+	// are posted, including public ones.
+	str.Close()
+	suite.post(streams, "account", "2")
+	suite.post(streams, "other_account", "nope")
+	streams.PostAll(context.Background(), stream.Message{
+		Stream:  []string{stream.TimelinePublic, stream.TimelineHome},
+		Event:   stream.EventTypeDelete,
+		Payload: "3",
+	})
+	suite.post(streams, "account", "4")
+
+	str = streams.Resume("account", lastEventID, stream.TimelineHome)
+	defer str.Close()
+	suite.post(streams, "account", "5")
+
+	var payloads []string
+	for {
+		msg, ok := suite.recv(str)
+		if !ok {
+			break
+		}
+		suite.Equal([]string{stream.TimelineHome}, msg.Stream)
+		suite.Greater(msg.ID, lastEventID)
+		lastEventID = msg.ID
+		payloads = append(payloads, msg.Payload)
+	}
+	suite.Equal([]string{"2", "3", "4", "5"}, payloads)
+}
+
+func (suite *ReplayTestSuite) TestResumeDropped() {
+	streams := new(stream.Streams)
+
+	str := streams.Open("account", stream.TimelineHome)
+	suite.post(streams, "account", "0")
+	msg, _ := suite.recv(str)
+	str.Close()
+
+	// Overflow the buffer.
+	for i := 0; i <= stream.BufferSize; i++ {
+		suite.post(streams, "account", "")
+	}
+
+	str = streams.Resume("account", msg.ID, stream.TimelineHome)
+	defer str.Close()
+
+	msg, ok := suite.recv(str)
+	suite.True(ok)
+	suite.Equal(stream.EventTypeReset, msg.Event)
+	suite.Equal([]string{stream.TimelineHome}, msg.Stream)
+	_, ok = suite.recv(str)
+	suite.False(ok)
+}
+
+func (suite *ReplayTestSuite) TestResumeUnknownID() {
+	streams := new(stream.Streams)
+
+	for _, lastEventID := range []string{
+		"not an ID",
+		"99999999999999999999",
+	} {
+		str := streams.Resume("account", lastEventID, stream.TimelineHome)
+		msg, ok := suite.recv(str)
+		suite.True(ok)
+		suite.Equal(stream.EventTypeReset, msg.Event)
+		str.Close()
+	}
+}
+
+func TestReplayTestSuite(t *testing.T) {
+	suite.Run(t, new(ReplayTestSuite))
+}
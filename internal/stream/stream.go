@@ -19,15 +19,41 @@ package stream
 
 import (
 	"context"
+	"encoding/json"
 	"maps"
 	"slices"
 	"sync"
 	"sync/atomic"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
+)
+
+const (
+	// DefaultQueueSize is the number of messages
+	// that can be queued on a stream before its
+	// slow consumer policy kicks in, if unset.
+	DefaultQueueSize = 50
+
+	// SlowConsumerDisconnect closes streams
+	// whose queue is full. This is the default.
+	SlowConsumerDisconnect = "disconnect"
+
+	// SlowConsumerDropOldest drops the oldest
+	// droppable message on streams whose queue
+	// is full, to make room for the new one.
+	// See droppable() for what can be dropped.
+	SlowConsumerDropOldest = "drop-oldest"
 )
 
 const (
 	// EventTypeNotification -- a user
 	// should be shown a notification.
+	//
+	// On streams with granular notification
+	// events, the notification type is appended
+	// to this, eg., "notification.mention".
 	EventTypeNotification = "notification"
 
 	// EventTypeUpdate -- a user should
@@ -46,6 +72,42 @@ const (
 	// EventTypeFiltersChanged -- the user's filters
 	// (including keywords and statuses) have changed.
 	EventTypeFiltersChanged = "filters_changed"
+
+	// EventTypeNotificationGroup -- a user should be
+	// shown several notifications of the same type
+	// about the same status at once. Only sent on
+	// streams with notification batching turned on.
+	EventTypeNotificationGroup = "notification_group"
+
+	// EventTypeReset -- the stream could not be resumed
+	// from the requested event ID, as events since then
+	// are no longer available, so the client should
+	// refetch whatever it's displaying.
+	EventTypeReset = "reset"
+)
+
+const (
+	// DeleteReasonAuthor -- the status
+	// was deleted by its author, or its
+	// author's account was deleted.
+	DeleteReasonAuthor = "author"
+
+	// DeleteReasonModeration -- the status was
+	// removed by moderation, eg., its author was
+	// suspended, or their domain was blocked.
+	// This is deliberately not any more specific.
+	DeleteReasonModeration = "moderation"
+
+	// DeleteReasonBlock -- the status was
+	// removed because the receiving account
+	// blocked its author. Only sent to the
+	// blocking account, never to the blocked.
+	DeleteReasonBlock = "block"
+
+	// DeleteReasonFiltered -- the status was
+	// removed because it's now filtered out,
+	// on streams with retroactive filters.
+	DeleteReasonFiltered = "filtered"
 )
 
 const (
@@ -75,6 +137,11 @@ const (
 	// TimelineList:
 	// Updates to a specific list.
 	TimelineList = "list"
+
+	// TimelineConversation:
+	// New replies and edits within a
+	// specific thread, keyed by thread ID.
+	TimelineConversation = "conversation"
 )
 
 // AllStatusTimelines contains all Timelines
@@ -89,12 +156,40 @@ var AllStatusTimelines = []string{
 }
 
 type Streams struct {
+
+	// QueueSize is the max number of messages
+	// queued on each stream, waiting for the
+	// client to receive them. Zero or less
+	// means DefaultQueueSize.
+	QueueSize int
+
+	// SlowConsumer is the policy for streams
+	// whose queue is full; one of the
+	// SlowConsumer{Disconnect,DropOldest}
+	// consts. Empty means disconnect.
+	SlowConsumer string
+
 	streams map[string][]*Stream
 	mutex   sync.Mutex
+
+	// recently posted messages per
+	// account ID, for resuming streams.
+	// See replay.go for details.
+	buffers map[string]*buffer
+	public  buffer
+	lastID  uint64
 }
 
 // Open will open open a new Stream for given account ID and stream types, the given context will be passed to Stream.
 func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
+	return s.Resume(accountID, "", streamTypes...)
+}
+
+// Resume is like Open, but if lastEventID is set, the returned Stream will
+// first replay any buffered messages posted after the message with that ID,
+// before any new messages. If messages since then are no longer buffered, or
+// the ID isn't recognized, a single message of EventTypeReset is sent instead.
+func (s *Streams) Resume(accountID string, lastEventID string, streamTypes ...string) *Stream {
 	if len(streamTypes) == 0 {
 		panic("no stream types given")
 	}
@@ -102,7 +197,12 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 	// Prep new Stream.
 	str := new(Stream)
 	str.done = make(chan struct{})
-	str.msgCh = make(chan Message, 50) // TODO: make configurable
+	str.wake = make(chan struct{}, 1)
+	str.size = s.QueueSize
+	if str.size <= 0 {
+		str.size = DefaultQueueSize
+	}
+	str.dropOldest = (s.SlowConsumer == SlowConsumerDropOldest)
 	for _, streamType := range streamTypes {
 		str.Subscribe(streamType)
 	}
@@ -123,6 +223,17 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 	strs = append(strs, str)
 	s.streams[accountID] = strs
 
+	// Start buffering messages for this account
+	// (if not already), and replay any requested.
+	// Doing this under lock ensures that messages
+	// are either replayed, or sent live; not both.
+	now := time.Now()
+	s.sweep(now)
+	if lastEventID != "" {
+		str.replay = s.replay(str, accountID, lastEventID, now)
+	}
+	s.buffer(accountID)
+
 	// Register close callback
 	// to remove stream from our
 	// internal map for this account.
@@ -133,6 +244,13 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 			return s == str // remove 'str' ptr
 		})
 		s.streams[accountID] = strs
+		if len(strs) == 0 {
+			// Keep buffering messages for
+			// a while, in case it resumes.
+			if b := s.buffers[accountID]; b != nil {
+				b.closedAt = time.Now()
+			}
+		}
 		s.mutex.Unlock()
 	}
 
@@ -149,6 +267,14 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 	// Acquire lock.
 	s.mutex.Lock()
 
+	// Give the message an ID and
+	// buffer it for resuming streams.
+	now := time.Now()
+	msg.ID = s.nextID(now)
+	if b := s.buffers[accountID]; b != nil {
+		b.add(msg, now)
+	}
+
 	// Iterate all streams stored for account.
 	for _, str := range s.streams[accountID] {
 
@@ -163,9 +289,15 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 			// Use a message copy to *only*
 			// include the supported stream.
 			msgCopy := Message{
+				ID:      msg.ID,
 				Stream:  []string{stype},
 				Event:   msg.Event,
 				Payload: msg.Payload,
+				Status:  msg.Status,
+
+				Notification:     msg.Notification,
+				NotificationType: msg.NotificationType,
+				DeleteReason:     msg.DeleteReason,
 			}
 
 			// Send message to supported stream
@@ -173,6 +305,10 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 			// This prevents deadlocks between each
 			// msg channel and main Streams{} mutex.
 			deferred = append(deferred, func() bool {
+				if !stream.keep(msgCopy) {
+					// Filtered out.
+					return true
+				}
 				return stream.send(ctx, msgCopy)
 			})
 		}
@@ -199,6 +335,12 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 	// Acquire lock.
 	s.mutex.Lock()
 
+	// Give the message an ID and
+	// buffer it for resuming streams.
+	now := time.Now()
+	msg.ID = s.nextID(now)
+	s.public.add(msg, now)
+
 	// Iterate ALL stored streams.
 	for _, strs := range s.streams {
 		for _, str := range strs {
@@ -214,9 +356,15 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 				// Use a message copy to *only*
 				// include the supported stream.
 				msgCopy := Message{
+					ID:      msg.ID,
 					Stream:  []string{stype},
 					Event:   msg.Event,
 					Payload: msg.Payload,
+					Status:  msg.Status,
+
+					Notification:     msg.Notification,
+					NotificationType: msg.NotificationType,
+					DeleteReason:     msg.DeleteReason,
 				}
 
 				// Send message to supported stream
@@ -224,6 +372,10 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 				// This prevents deadlocks between each
 				// msg channel and main Streams{} mutex.
 				deferred = append(deferred, func() bool {
+					if !stream.keep(msgCopy) {
+						// Filtered out.
+						return true
+					}
 					return stream.send(ctx, msgCopy)
 				})
 			}
@@ -244,6 +396,29 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 	return ok
 }
 
+// Subscribers returns the IDs of accounts with at least
+// one open stream subscribed to the given stream type.
+func (s *Streams) Subscribers(streamType string) []string {
+	var accountIDs []string
+
+	// Acquire lock.
+	s.mutex.Lock()
+
+	for accountID, strs := range s.streams {
+		for _, str := range strs {
+			if str.getStreamType(streamType) != "" {
+				accountIDs = append(accountIDs, accountID)
+				break
+			}
+		}
+	}
+
+	// Done with lock.
+	s.mutex.Unlock()
+
+	return accountIDs
+}
+
 // Stream represents one
 // open stream for a client.
 type Stream struct {
@@ -256,12 +431,100 @@ type Stream struct {
 	// protects stream close.
 	done chan struct{}
 
-	// inbound msg ch.
-	msgCh chan Message
+	// inbound msg queue, bounded by
+	// size, and protected by mutex.
+	// wake is signalled on each push.
+	queue []Message
+	size  int
+	mutex sync.Mutex
+	wake  chan struct{}
+
+	// whether to drop old msgs, rather
+	// than closing the stream, when the
+	// queue is full. See send().
+	dropOldest bool
+
+	// set when the stream was
+	// closed for being too slow.
+	tooSlow atomic.Bool
 
 	// close hook to remove
 	// stream from Streams{}.
 	close func()
+
+	// optional filter to check
+	// msgs against before sending.
+	filter atomic.Pointer[Filter]
+
+	// msgs to replay before any
+	// from queue, when resuming.
+	// only accessed by Recv().
+	replay []Message
+
+	// whether notification events
+	// are sent with their type in
+	// the event name. See Recv().
+	granular atomic.Bool
+
+	// whether delete events are sent
+	// with their reason in the payload,
+	// rather than a bare status ID.
+	// See Recv().
+	detailedDeletes atomic.Bool
+
+	// whether the filter is re-applied
+	// to recently sent statuses when
+	// filters change. See Refilter().
+	retroactive atomic.Bool
+
+	// window within which notifications
+	// are batched, 0 for off, and batches
+	// being held, protected by mutex.
+	// See coalesce.go.
+	batchWindow atomic.Int64
+	batches     map[string]*batch
+}
+
+// Filter is a function used to check whether a
+// message should be sent on a stream. Returning
+// false means the message will be dropped.
+type Filter func(Message) bool
+
+// SetFilter sets the given filter on the stream,
+// which all messages will be checked against before
+// sending. Passing nil removes any previous filter.
+func (s *Stream) SetFilter(filter Filter) {
+	if filter == nil {
+		s.filter.Store(nil)
+		return
+	}
+	s.filter.Store(&filter)
+}
+
+// keep returns whether the given message passes
+// the stream's filter, if any, and should be sent.
+func (s *Stream) keep(msg Message) bool {
+	if ptr := s.filter.Load(); ptr != nil {
+		return (*ptr)(msg)
+	}
+	return true
+}
+
+// SetGranularNotifications sets whether notification events on
+// the stream are sent with the type of notification appended to
+// the event name, eg., "notification.follow", so that clients can
+// tell them apart without parsing the payload. The default is to
+// send all notification events as plain "notification" events.
+func (s *Stream) SetGranularNotifications(granular bool) {
+	s.granular.Store(granular)
+}
+
+// SetDetailedDeletes sets whether delete events on the stream
+// are sent with a JSON payload containing the status ID and the
+// reason it was deleted, eg., {"id":"...","reason":"author"}.
+// The default is to send just the status ID as the payload.
+func (s *Stream) SetDetailedDeletes(detailed bool) {
+	s.detailedDeletes.Store(detailed)
 }
 
 // Subscribe will add given type to given types this stream supports.
@@ -298,32 +561,189 @@ func (s *Stream) getStreamType(streamTypes ...string) string {
 	return ""
 }
 
-// send will block on posting a new Message{}, returning early with
-// a false value if provided context is canceled, or stream closed.
+// send will queue a new Message{} without blocking, returning
+// false if provided context is canceled, or stream closed.
+//
+// If the queue is full, ie., the client isn't receiving fast
+// enough, the stream is closed (and marked as too slow), or if
+// set to drop oldest, the oldest droppable message is dropped.
 func (s *Stream) send(ctx context.Context, msg Message) bool {
 	select {
 	case <-s.done:
 		return false
 	case <-ctx.Done():
 		return false
-	case s.msgCh <- msg:
+	default:
+	}
+
+	if s.hold(msg) {
+		// Batched with other
+		// msgs, to send later.
 		return true
 	}
+
+	return s.push(msg)
+}
+
+// push adds the given msg to the queue, applying the
+// slow consumer policy if it's full; see send().
+func (s *Stream) push(msg Message) bool {
+	s.mutex.Lock()
+
+	if len(s.queue) >= s.size {
+		if !s.dropOldest {
+			// Client isn't keeping
+			// up, disconnect them.
+			s.mutex.Unlock()
+			s.closeSlow()
+			return false
+		}
+
+		// Make room by dropping the oldest
+		// queued msg that's safe to drop.
+		i := slices.IndexFunc(s.queue, droppable)
+		switch {
+		case i >= 0:
+			s.queue = slices.Delete(s.queue, i, i+1)
+			metrics.StreamEventDropped()
+
+		case droppable(msg):
+			// Queue is all critical
+			// msgs, drop the new one.
+			s.mutex.Unlock()
+			metrics.StreamEventDropped()
+			return true
+
+		default:
+			// Can't drop anything,
+			// disconnect instead.
+			s.mutex.Unlock()
+			s.closeSlow()
+			return false
+		}
+	}
+
+	s.queue = append(s.queue, msg)
+	s.mutex.Unlock()
+
+	// Wake up Recv(),
+	// if not already.
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return true
+}
+
+// droppable returns whether msg can be dropped from
+// a full queue. Status updates and notifications can
+// be, while the client can refetch them; but dropping
+// deletes, filter changes or resets would leave the
+// client displaying stale content.
+func droppable(msg Message) bool {
+	switch msg.Event {
+	case EventTypeUpdate,
+		EventTypeStatusUpdate,
+		EventTypeNotification,
+		EventTypeNotificationGroup:
+		return true
+	default:
+		return false
+	}
+}
+
+// closeSlow closes the stream, marking it
+// as closed for the client being too slow.
+func (s *Stream) closeSlow() {
+	if s.tooSlow.CompareAndSwap(false, true) {
+		metrics.StreamSlowConsumerDisconnected()
+	}
+	s.Close()
+}
+
+// TooSlow returns whether the stream was closed
+// because the client didn't receive messages fast
+// enough, and its queue filled up.
+func (s *Stream) TooSlow() bool {
+	return s.tooSlow.Load()
 }
 
 // Recv will block on receiving Message{}, returning early with a
 // false value if provided context is canceled, or stream closed.
 func (s *Stream) Recv(ctx context.Context) (Message, bool) {
-	select {
-	case <-s.done:
-		return Message{}, false
-	case <-ctx.Done():
-		return Message{}, false
-	case msg := <-s.msgCh:
-		return msg, true
+	for len(s.replay) > 0 {
+		// Replay msgs first.
+		msg := s.replay[0]
+		s.replay = s.replay[1:]
+		if s.keep(msg) {
+			return s.event(msg), true
+		}
+	}
+
+	for {
+		select {
+		case <-s.done:
+			return Message{}, false
+		case <-ctx.Done():
+			return Message{}, false
+		default:
+		}
+
+		s.mutex.Lock()
+		if len(s.queue) > 0 {
+			// Pop next queued msg.
+			msg := s.queue[0]
+			s.queue[0] = Message{}
+			s.queue = s.queue[1:]
+			s.mutex.Unlock()
+			return s.event(msg), true
+		}
+		s.mutex.Unlock()
+
+		// Wait for a msg to be
+		// queued, or close / cancel.
+		select {
+		case <-s.done:
+			return Message{}, false
+		case <-ctx.Done():
+			return Message{}, false
+		case <-s.wake:
+		}
 	}
 }
 
+// event returns msg with its event name adjusted
+// for the stream, ie., with the notification type
+// appended for streams with granular notifications,
+// and the delete reason in the payload for streams
+// with detailed deletes.
+func (s *Stream) event(msg Message) Message {
+	if msg.Event == EventTypeNotification &&
+		msg.NotificationType != "" &&
+		s.granular.Load() {
+		msg.Event += "." + msg.NotificationType
+	}
+	if msg.Event == EventTypeDelete &&
+		s.detailedDeletes.Load() {
+		msg.Payload = deletePayload(msg.Payload, msg.DeleteReason)
+	}
+	return msg
+}
+
+// deletePayload returns the payload of a detailed
+// delete event of the given status ID and reason.
+func deletePayload(statusID string, reason string) string {
+	b, _ := json.Marshal(struct {
+		ID     string `json:"id"`
+		Reason string `json:"reason,omitempty"`
+	}{
+		ID:     statusID,
+		Reason: reason,
+	})
+	return string(b)
+}
+
 // Close will close the underlying context, finally
 // removing it from the parent Streams per-account-map.
 func (s *Stream) Close() {
@@ -371,6 +791,12 @@ func (s *Stream) cas(fn func(map[string]struct{}) bool) {
 // one streamed message.
 type Message struct {
 
+	// ID of the message, which is
+	// always greater than that of
+	// the previously posted message.
+	// Set when posting the message.
+	ID string `json:"id,omitempty"`
+
 	// All the stream types this
 	// message should be delivered to.
 	Stream []string `json:"stream"`
@@ -382,4 +808,26 @@ type Message struct {
 	// The actual payload of the message. In case of an
 	// update or notification, this will be a JSON string.
 	Payload string `json:"payload"`
+
+	// The status in the payload of the message, in case
+	// of an update or status update, for checking against
+	// stream filters. This isn't sent to the client.
+	Status *apimodel.Status `json:"-"`
+
+	// The notification in the payload of the message, in
+	// case of a notification, for batching notifications.
+	// This isn't sent to the client as a separate field.
+	Notification *apimodel.Notification `json:"-"`
+
+	// The type of notification in the payload of the
+	// message, in case of a notification, for streams
+	// with granular notification events. This isn't
+	// sent to the client as a separate field.
+	NotificationType string `json:"-"`
+
+	// The reason a status was deleted, in case of a
+	// delete, for streams with detailed deletes; one
+	// of the DeleteReason consts. This isn't sent to
+	// the client as a separate field.
+	DeleteReason string `json:"-"`
 }
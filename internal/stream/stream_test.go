@@ -0,0 +1,273 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+type StreamTestSuite struct {
+	suite.Suite
+}
+
+func (suite *StreamTestSuite) notify(streams *stream.Streams, accountID string, notifType string) {
+	streams.Post(context.Background(), accountID, stream.Message{
+		Stream:           []string{stream.TimelineNotifications},
+		Event:            stream.EventTypeNotification,
+		NotificationType: notifType,
+	})
+}
+
+func (suite *StreamTestSuite) events(str *stream.Stream) []string {
+	var events []string
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		msg, ok := str.Recv(ctx)
+		cancel()
+		if !ok {
+			return events
+		}
+		events = append(events, msg.Event)
+	}
+}
+
+func (suite *StreamTestSuite) TestCombinedNotifications() {
+	streams := new(stream.Streams)
+
+	str := streams.Open("account", stream.TimelineNotifications)
+	defer str.Close()
+
+	suite.notify(streams, "account", "mention")
+	suite.notify(streams, "account", "follow")
+	suite.Equal([]string{"notification", "notification"}, suite.events(str))
+}
+
+func (suite *StreamTestSuite) TestGranularNotifications() {
+	streams := new(stream.Streams)
+
+	str := streams.Open("account", stream.TimelineNotifications)
+	str.SetGranularNotifications(true)
+
+	suite.notify(streams, "account", "mention")
+	suite.notify(streams, "account", "admin.sign_up")
+	suite.notify(streams, "account", "")
+	suite.Equal([]string{
+		"notification.mention",
+		"notification.admin.sign_up",
+		"notification",
+	}, suite.events(str))
+
+	// Replayed msgs get
+	// granular events too.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	suite.notify(streams, "account", "follow")
+	msg, ok := str.Recv(ctx)
+	suite.True(ok)
+	str.Close()
+
+	suite.notify(streams, "account", "favourite")
+	str = streams.Resume("account", msg.ID, stream.TimelineNotifications)
+	defer str.Close()
+	str.SetGranularNotifications(true)
+	suite.Equal([]string{"notification.favourite"}, suite.events(str))
+}
+
+func (suite *StreamTestSuite) delete(streams *stream.Streams, statusID string, reason string) {
+	streams.PostAll(context.Background(), stream.Message{
+		Stream:       []string{stream.TimelineHome},
+		Event:        stream.EventTypeDelete,
+		Payload:      statusID,
+		DeleteReason: reason,
+	})
+}
+
+func (suite *StreamTestSuite) payloads(str *stream.Stream) []string {
+	var payloads []string
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		msg, ok := str.Recv(ctx)
+		cancel()
+		if !ok {
+			return payloads
+		}
+		payloads = append(payloads, msg.Payload)
+	}
+}
+
+func (suite *StreamTestSuite) TestPlainDeletes() {
+	streams := new(stream.Streams)
+
+	str := streams.Open("account", stream.TimelineHome)
+	defer str.Close()
+
+	suite.delete(streams, "01", stream.DeleteReasonAuthor)
+	suite.delete(streams, "02", stream.DeleteReasonModeration)
+	suite.delete(streams, "03", stream.DeleteReasonBlock)
+	suite.delete(streams, "04", stream.DeleteReasonFiltered)
+	suite.delete(streams, "05", "")
+	suite.Equal([]string{"01", "02", "03", "04", "05"}, suite.payloads(str))
+}
+
+func (suite *StreamTestSuite) TestDetailedDeletes() {
+	streams := new(stream.Streams)
+
+	str := streams.Open("account", stream.TimelineHome)
+	str.SetDetailedDeletes(true)
+
+	suite.delete(streams, "01", stream.DeleteReasonAuthor)
+	suite.delete(streams, "02", stream.DeleteReasonModeration)
+	suite.delete(streams, "03", stream.DeleteReasonBlock)
+	suite.delete(streams, "04", stream.DeleteReasonFiltered)
+	suite.delete(streams, "05", "")
+	suite.Equal([]string{
+		`{"id":"01","reason":"author"}`,
+		`{"id":"02","reason":"moderation"}`,
+		`{"id":"03","reason":"block"}`,
+		`{"id":"04","reason":"filtered"}`,
+		`{"id":"05"}`,
+	}, suite.payloads(str))
+
+	// Replayed msgs get
+	// detailed deletes too.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	suite.delete(streams, "06", stream.DeleteReasonAuthor)
+	msg, ok := str.Recv(ctx)
+	suite.True(ok)
+	str.Close()
+
+	suite.delete(streams, "07", stream.DeleteReasonModeration)
+	str = streams.Resume("account", msg.ID, stream.TimelineHome)
+	defer str.Close()
+	str.SetDetailedDeletes(true)
+	suite.Equal([]string{`{"id":"07","reason":"moderation"}`}, suite.payloads(str))
+}
+
+// post posts n home timeline msgs of
+// given event type, failing the test
+// if posting blocks, which would hold
+// up the processor for everyone else.
+func (suite *StreamTestSuite) post(streams *stream.Streams, event string, n int) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < n; i++ {
+			streams.Post(context.Background(), "account", stream.Message{
+				Stream:  []string{stream.TimelineHome},
+				Event:   event,
+				Payload: strconv.Itoa(i),
+			})
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		suite.FailNow("posting to stream blocked")
+	}
+}
+
+func (suite *StreamTestSuite) TestSlowConsumerDisconnect() {
+	streams := &stream.Streams{QueueSize: 5}
+
+	// Open a stream but
+	// never read from it.
+	str := streams.Open("account", stream.TimelineHome)
+	defer str.Close()
+
+	suite.post(streams, stream.EventTypeUpdate, 5)
+	suite.False(str.TooSlow())
+
+	// One more than
+	// fits in queue.
+	suite.post(streams, stream.EventTypeUpdate, 1)
+	suite.True(str.TooSlow())
+
+	// Stream is closed.
+	_, ok := str.Recv(context.Background())
+	suite.False(ok)
+}
+
+func (suite *StreamTestSuite) TestSlowConsumerDropOldest() {
+	streams := &stream.Streams{
+		QueueSize:    3,
+		SlowConsumer: stream.SlowConsumerDropOldest,
+	}
+
+	// Open a stream but
+	// never read from it.
+	str := streams.Open("account", stream.TimelineHome)
+	defer str.Close()
+
+	suite.post(streams, stream.EventTypeDelete, 1)
+	suite.post(streams, stream.EventTypeUpdate, 10)
+	suite.False(str.TooSlow())
+
+	// The delete is kept, along
+	// with the newest updates.
+	var payloads []string
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		msg, ok := str.Recv(ctx)
+		cancel()
+		if !ok {
+			break
+		}
+		payloads = append(payloads, msg.Event+":"+msg.Payload)
+	}
+	suite.Equal([]string{"delete:0", "update:8", "update:9"}, payloads)
+
+	// A queue full of deletes
+	// can't be made room in.
+	suite.post(streams, stream.EventTypeDelete, 4)
+	suite.True(str.TooSlow())
+}
+
+func (suite *StreamTestSuite) TestSubscribers() {
+	streams := new(stream.Streams)
+	conversation := stream.TimelineConversation + ":thread"
+
+	str1 := streams.Open("account1", stream.TimelineHome, conversation)
+	str2 := streams.Open("account2", stream.TimelineHome)
+	defer str2.Close()
+
+	// Only account1 is subscribed.
+	suite.Equal([]string{"account1"}, streams.Subscribers(conversation))
+
+	// Subscribing later counts too, and a second
+	// stream doesn't list the account twice.
+	str2.Subscribe(conversation)
+	str3 := streams.Open("account1", conversation)
+	suite.ElementsMatch([]string{"account1", "account2"}, streams.Subscribers(conversation))
+
+	// Closed streams are no longer subscribed.
+	str1.Close()
+	str3.Close()
+	suite.Equal([]string{"account2"}, streams.Subscribers(conversation))
+}
+
+func TestStreamTestSuite(t *testing.T) {
+	suite.Run(t, new(StreamTestSuite))
+}
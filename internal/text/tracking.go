@@ -0,0 +1,132 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+)
+
+// StripTrackingParams removes tracking query parameters, as
+// configured by statuses-tracking-params, from all links in
+// the given text, returning the cleaned text. If stripping
+// is disabled on this instance, text is returned unchanged.
+//
+// Other query parameters are left as-is, as are links to any
+// domain in statuses-tracking-params-skip-domains (or their
+// subdomains), where such parameters may be load-bearing.
+func StripTrackingParams(text string) string {
+	if !config.GetStatusesStripTrackingParams() {
+		return text
+	}
+
+	params := config.GetStatusesTrackingParams()
+	if len(params) == 0 {
+		return text
+	}
+
+	skipDomains := config.GetStatusesTrackingParamsSkipDomains()
+
+	return regexes.LinkScheme.ReplaceAllStringFunc(text, func(link string) string {
+		return stripLinkTrackingParams(link, params, skipDomains)
+	})
+}
+
+// stripLinkTrackingParams removes the given tracking params
+// from the given link's query. The link is otherwise left
+// byte-for-byte identical, rather than being re-encoded.
+func stripLinkTrackingParams(link string, params []string, skipDomains []string) string {
+	// Split off fragment, which may
+	// itself contain a question mark.
+	rest, fragment, hasFragment := strings.Cut(link, "#")
+
+	base, query, hasQuery := strings.Cut(rest, "?")
+	if !hasQuery || query == "" {
+		return link
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+
+	if domainMatches(u.Hostname(), skipDomains) {
+		return link
+	}
+
+	pairs := strings.Split(query, "&")
+	kept := pairs[:0]
+	for _, pair := range pairs {
+		key, _, _ := strings.Cut(pair, "=")
+		if k, err := url.QueryUnescape(key); err == nil {
+			key = k
+		}
+
+		if !isTrackingParam(key, params) {
+			kept = append(kept, pair)
+		}
+	}
+
+	if len(kept) == len(pairs) {
+		// Nothing stripped.
+		return link
+	}
+
+	cleaned := base
+	if len(kept) > 0 {
+		cleaned += "?" + strings.Join(kept, "&")
+	}
+	if hasFragment {
+		cleaned += "#" + fragment
+	}
+
+	return cleaned
+}
+
+// isTrackingParam returns whether the given query key matches one
+// of the given params, where a trailing '*' matches any suffix.
+func isTrackingParam(key string, params []string) bool {
+	key = strings.ToLower(key)
+	for _, param := range params {
+		param = strings.ToLower(param)
+		if prefix, ok := strings.CutSuffix(param, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == param {
+			return true
+		}
+	}
+	return false
+}
+
+// domainMatches returns whether host is
+// any of the given domains or a subdomain.
+func domainMatches(host string, domains []string) bool {
+	host = strings.ToLower(host)
+	for _, domain := range domains {
+		domain = strings.ToLower(domain)
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TrackingTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TrackingTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	config.SetStatusesStripTrackingParams(true)
+	config.SetStatusesTrackingParams([]string{"utm_*", "fbclid"})
+	config.SetStatusesTrackingParamsSkipDomains([]string{"example.net"})
+}
+
+func (suite *TrackingTestSuite) TestStripTrackingParams() {
+	for _, test := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			// Only tracking params.
+			in:       "check this out https://example.org/article?utm_source=rss&utm_medium=feed!",
+			expected: "check this out https://example.org/article!",
+		},
+		{
+			// Functional params are kept, in order.
+			in:       "https://example.org/search?q=gts&fbclid=abc123&page=2",
+			expected: "https://example.org/search?q=gts&page=2",
+		},
+		{
+			// Fragment is kept.
+			in:       "https://example.org/page?UTM_Campaign=x#section-2",
+			expected: "https://example.org/page#section-2",
+		},
+		{
+			// Encoding of kept params is untouched.
+			in:       "https://example.org/?q=hello%20world&utm_term=x",
+			expected: "https://example.org/?q=hello%20world",
+		},
+		{
+			// Skipped domain (and subdomains).
+			in:       "https://shop.example.net/item?utm_source=x",
+			expected: "https://shop.example.net/item?utm_source=x",
+		},
+		{
+			// Multiple links.
+			in:       "https://a.example.org/?fbclid=1 and https://b.example.org/?id=1",
+			expected: "https://a.example.org/ and https://b.example.org/?id=1",
+		},
+		{
+			// No query at all.
+			in:       "https://example.org/utm_source",
+			expected: "https://example.org/utm_source",
+		},
+	} {
+		suite.Equal(test.expected, text.StripTrackingParams(test.in))
+	}
+}
+
+func (suite *TrackingTestSuite) TestStripTrackingParamsDisabled() {
+	config.SetStatusesStripTrackingParams(false)
+
+	in := "https://example.org/article?utm_source=rss"
+	suite.Equal(in, text.StripTrackingParams(in))
+}
+
+func TestTrackingTestSuite(t *testing.T) {
+	suite.Run(t, new(TrackingTestSuite))
+}
@@ -72,8 +72,9 @@ type Manager interface {
 	// WipeItemFromAllTimelines removes one item from the index and prepared items of all timelines
 	WipeItemFromAllTimelines(ctx context.Context, itemID string) error
 
-	// WipeStatusesFromAccountID removes all items by the given accountID from the given timeline.
-	WipeItemsFromAccountID(ctx context.Context, timelineID string, accountID string) error
+	// WipeStatusesFromAccountID removes all items by the given accountID from the given timeline,
+	// returning the IDs of the removed items.
+	WipeItemsFromAccountID(ctx context.Context, timelineID string, accountID string) ([]string, error)
 
 	// UnprepareItem unprepares/uncaches the prepared version fo the given itemID from the given timelineID.
 	// Use this for cache invalidation when the prepared representation of an item has changed.
@@ -208,9 +209,8 @@ func (m *manager) WipeItemFromAllTimelines(ctx context.Context, itemID string) e
 	return nil
 }
 
-func (m *manager) WipeItemsFromAccountID(ctx context.Context, timelineID string, accountID string) error {
-	_, err := m.getOrCreateTimeline(ctx, timelineID).RemoveAllByOrBoosting(ctx, accountID)
-	return err
+func (m *manager) WipeItemsFromAccountID(ctx context.Context, timelineID string, accountID string) ([]string, error) {
+	return m.getOrCreateTimeline(ctx, timelineID).RemoveAllByOrBoosting(ctx, accountID)
 }
 
 func (m *manager) UnprepareItemFromAllTimelines(ctx context.Context, itemID string) error {
@@ -60,7 +60,7 @@ func (t *timeline) Remove(ctx context.Context, statusID string) (int, error) {
 	return len(toRemove), nil
 }
 
-func (t *timeline) RemoveAllByOrBoosting(ctx context.Context, accountID string) (int, error) {
+func (t *timeline) RemoveAllByOrBoosting(ctx context.Context, accountID string) ([]string, error) {
 	l := log.
 		WithContext(ctx).
 		WithFields(kv.Fields{
@@ -73,7 +73,7 @@ func (t *timeline) RemoveAllByOrBoosting(ctx context.Context, accountID string)
 
 	if t.items == nil || t.items.data == nil {
 		// Nothing to do.
-		return 0, nil
+		return nil, nil
 	}
 
 	var toRemove []*list.Element
@@ -89,9 +89,11 @@ func (t *timeline) RemoveAllByOrBoosting(ctx context.Context, accountID string)
 		toRemove = append(toRemove, e)
 	}
 
+	removed := make([]string, 0, len(toRemove))
 	for _, e := range toRemove {
+		removed = append(removed, e.Value.(*indexedItemsEntry).itemID)
 		t.items.data.Remove(e)
 	}
 
-	return len(toRemove), nil
+	return removed, nil
 }
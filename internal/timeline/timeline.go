@@ -130,8 +130,8 @@ type Timeline interface {
 
 	// RemoveAllByOrBoosting removes all items created by or boosting the given accountID.
 	//
-	// The returned int indicates the amount of entries that were removed.
-	RemoveAllByOrBoosting(ctx context.Context, accountID string) (int, error)
+	// The returned slice contains the IDs of the entries that were removed.
+	RemoveAllByOrBoosting(ctx context.Context, accountID string) ([]string, error)
 }
 
 // timeline fulfils the Timeline interface
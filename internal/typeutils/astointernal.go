@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"net/url"
+	"slices"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -29,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -127,6 +129,17 @@ func (c *Converter) ASRepresentationToAccount(ctx context.Context, accountable a
 	// Extract account attachments (key-value fields).
 	acct.Fields = ap.ExtractFields(accountable)
 
+	// Extract account pronouns. These are also sent as a
+	// profile field for software that doesn't know the
+	// pronouns property, so drop that field if present.
+	acct.Pronouns = ap.ExtractPronouns(accountable)
+	if acct.Pronouns != "" {
+		acct.Fields = slices.DeleteFunc(acct.Fields, func(f *gtsmodel.Field) bool {
+			return isPronounsField(f) &&
+				text.SanitizeToPlaintext(f.Value) == acct.Pronouns
+		})
+	}
+
 	// Extract account note (bio / summary).
 	acct.Note = ap.ExtractSummary(accountable)
 
@@ -654,6 +654,90 @@ func (suite *ASToInternalTestSuite) TestParseHonkAccount() {
 	suite.False(*dbAcct.Discoverable)
 }
 
+func (suite *ASToInternalTestSuite) TestParseUnmentionedAddresseeStatus() {
+	// Status addressed to an account
+	// without mentioning it, as sent
+	// for mutuals-only visibility. We
+	// can't tell who the mutuals are,
+	// so this should remain direct.
+	t := suite.jsonToType(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/statuses/01J1KSQ4Z1V7ZW0Y8NGPZW4V8F",
+  "type": "Note",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "to": "http://localhost:8080/users/the_mighty_zork",
+  "content": "just between us mutuals",
+  "published": "2024-06-28T10:00:00Z"
+}`)
+	statusable, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), statusable)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(gtsmodel.VisibilityDirect, status.Visibility)
+}
+
+func (suite *ASToInternalTestSuite) TestParsePersonWithPronouns() {
+	const person = `{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    "http://schema.org",
+    {
+      "pronouns": "https://gotosocial.org/ns#pronouns"
+    }
+  ],
+  "attachment": [
+    {
+      "name": "Pronouns",
+      "type": "PropertyValue",
+      "value": "they/them"
+    },
+    {
+      "name": "pronouns (in german)",
+      "type": "PropertyValue",
+      "value": "they/them"
+    },
+    {
+      "name": "hello",
+      "type": "PropertyValue",
+      "value": "world"
+    }
+  ],
+  "id": "https://example.org/users/someone",
+  "inbox": "https://example.org/users/someone/inbox",
+  "outbox": "https://example.org/users/someone/outbox",
+  "preferredUsername": "someone",
+  "pronouns": "<strong>they/them</strong>",
+  "publicKey": {
+    "id": "https://example.org/users/someone#main-key",
+    "owner": "https://example.org/users/someone",
+    "publicKeyPem": "-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA593GZ9TYrvWgMaMKQ6k6\ngkItUapUgNnNXzU9J63GRtYZ7CE/Zi39Kgpsxu77hHBj34vwjr1Oc9AMrVDIMfu9\nEirW1RWxPvrjThBU56VgkpkAXVsieaffJo80BA00QzV4x69Jgat6OT7ox/HMvMxR\nyZ6CXNCPKQALYqQF6v1fX1kO9lhIA+mPd0JN/qMKvZfd1NXABEk9nORUneH7Audt\nIHNdJzKMHC6wPSQWC7SmXT0/nq6o5mR2SgvwTI/JUx6T5r8NDrwSaqB69e+EMJqR\nxKOh9N4A1ba/AQOQZbO/YkFyYY2VE4HWbvS9XpYL74yT9D6Fp4cUovJiXC+ziam0\nNwIDAQAB\n-----END PUBLIC KEY-----\n"
+  },
+  "type": "Person"
+}`
+
+	t := suite.jsonToType(person)
+	rep, ok := t.(ap.Accountable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	acct, err := suite.typeconverter.ASRepresentationToAccount(context.Background(), rep, "")
+	suite.NoError(err)
+
+	// Pronouns should be sanitized, and the
+	// duplicate pronouns field should be dropped.
+	suite.Equal("they/them", acct.Pronouns)
+	suite.Len(acct.Fields, 2)
+	suite.Equal("pronouns (in german)", acct.Fields[0].Name)
+	suite.Equal("hello", acct.Fields[1].Name)
+}
+
 func TestASToInternalTestSuite(t *testing.T) {
 	suite.Run(t, new(ASToInternalTestSuite))
 }
@@ -32,6 +32,8 @@ func APIVisToVis(m apimodel.Visibility) gtsmodel.Visibility {
 		return gtsmodel.VisibilityFollowersOnly
 	case apimodel.VisibilityMutualsOnly:
 		return gtsmodel.VisibilityMutualsOnly
+	case apimodel.VisibilityCircle:
+		return gtsmodel.VisibilityCircle
 	case apimodel.VisibilityDirect:
 		return gtsmodel.VisibilityDirect
 	}
@@ -54,6 +56,20 @@ func APIFilterActionToFilterAction(m apimodel.FilterAction) gtsmodel.FilterActio
 		return gtsmodel.FilterActionWarn
 	case apimodel.FilterActionHide:
 		return gtsmodel.FilterActionHide
+	case apimodel.FilterActionBlur:
+		return gtsmodel.FilterActionBlur
+	case apimodel.FilterActionNotify:
+		return gtsmodel.FilterActionNotify
 	}
 	return gtsmodel.FilterActionNone
 }
+
+func APIFilterModeToFilterMode(m apimodel.FilterMode) gtsmodel.FilterMode {
+	switch m {
+	case apimodel.FilterModeBlock:
+		return gtsmodel.FilterModeBlock
+	case apimodel.FilterModeAllow:
+		return gtsmodel.FilterModeAllow
+	}
+	return gtsmodel.FilterModeNone
+}
@@ -23,7 +23,9 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"html"
 	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/superseriousbusiness/activity/pub"
@@ -118,7 +120,20 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 	person.SetTootFeatured(featuredProp)
 
 	// featuredTags
-	// NOT IMPLEMENTED
+	// Hashtags featured on the profile. There's no
+	// property for this in our vocab, so set it as
+	// an unknown property, which is serialized as-is.
+	if a.IsLocal() {
+		person.GetUnknownProperties()["featuredTags"] = uris.GenerateURIForFeaturedTags(a.Username)
+	}
+
+	// pronouns
+	// Plaintext pronouns shown on the profile. There's
+	// no property for this in our vocab either, so set
+	// it as an unknown property, which is serialized as-is.
+	if a.Pronouns != "" {
+		person.GetUnknownProperties()["pronouns"] = a.Pronouns
+	}
 
 	// preferredUsername
 	// Used for Webfinger lookup. Must be unique on the domain, and must correspond to a Webfinger acct: URI.
@@ -266,10 +281,22 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 
 	// attachment
 	// Used for profile fields.
-	if len(a.Fields) != 0 {
+	//
+	// Pronouns are also included as a field, so they're
+	// shown by software that doesn't know the pronouns
+	// property, unless there's a pronouns field already.
+	fields := a.Fields
+	if a.Pronouns != "" && !slices.ContainsFunc(fields, isPronounsField) {
+		fields = append(slices.Clip(fields), &gtsmodel.Field{
+			Name:  pronounsFieldName,
+			Value: html.EscapeString(a.Pronouns),
+		})
+	}
+
+	if len(fields) != 0 {
 		attachmentProp := streams.NewActivityStreamsAttachmentProperty()
 
-		for _, field := range a.Fields {
+		for _, field := range fields {
 			propertyValue := streams.NewSchemaPropertyValue()
 
 			nameProp := streams.NewActivityStreamsNameProperty()
@@ -520,6 +547,14 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 			return nil, gtserror.Newf("error getting mentions: %w", err)
 		}
 	}
+	if s.Visibility == gtsmodel.VisibilityCircle {
+		// Circle statuses are federated as direct to the
+		// audience, so that receivers only show them to it.
+		mentions, err = c.circleMentions(ctx, s, mentions)
+		if err != nil {
+			return nil, err
+		}
+	}
 	for _, m := range mentions {
 		asMention, err := c.MentionToAS(ctx, m)
 		if err != nil {
@@ -576,8 +611,9 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 	toProp := streams.NewActivityStreamsToProperty()
 	ccProp := streams.NewActivityStreamsCcProperty()
 	switch s.Visibility {
-	case gtsmodel.VisibilityDirect:
-		// if DIRECT, then only mentioned users should be added to TO, and nothing to CC
+	case gtsmodel.VisibilityDirect, gtsmodel.VisibilityCircle:
+		// if DIRECT, then only mentioned users should be added to TO, and nothing to CC;
+		// for CIRCLE, mentions have been replaced with the audience (see circleMentions)
 		for _, m := range mentions {
 			iri, err := url.Parse(m.TargetAccount.URI)
 			if err != nil {
@@ -586,7 +622,26 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 			toProp.AppendIRI(iri)
 		}
 	case gtsmodel.VisibilityMutualsOnly:
-		// TODO
+		// if MUTUALS ONLY then there's no collection we can
+		// address, so add each mutual to TO, and mentions to CC
+		mutuals, err := c.filter.StatusMutuals(ctx, s)
+		if err != nil {
+			return nil, gtserror.Newf("error getting mutuals: %w", err)
+		}
+		for _, mutual := range mutuals {
+			iri, err := url.Parse(mutual.URI)
+			if err != nil {
+				return nil, gtserror.Newf("error parsing uri %s: %w", mutual.URI, err)
+			}
+			toProp.AppendIRI(iri)
+		}
+		for _, m := range mentions {
+			iri, err := url.Parse(m.TargetAccount.URI)
+			if err != nil {
+				return nil, gtserror.Newf("error parsing uri %s: %w", m.TargetAccount.URI, err)
+			}
+			ccProp.AppendIRI(iri)
+		}
 	case gtsmodel.VisibilityFollowersOnly:
 		// if FOLLOWERS ONLY then we want to add followers to TO, and mentions to CC
 		toProp.AppendIRI(authorFollowersURI)
@@ -932,6 +987,45 @@ func (c *Converter) FollowToAS(ctx context.Context, f *gtsmodel.Follow) (vocab.A
 	return follow, nil
 }
 
+// circleMentions returns the mentions to federate a circle status
+// with, which is a mention of each account in its audience, reusing
+// any of the given mentions of them, and dropping mentions of any
+// accounts outside of the audience. Receivers show direct statuses
+// to the accounts they mention, so this ensures the status is shown
+// to exactly the audience, and not to anyone merely mentioned.
+func (c *Converter) circleMentions(ctx context.Context, s *gtsmodel.Status, mentions []*gtsmodel.Mention) ([]*gtsmodel.Mention, error) {
+	audience := s.Audience
+	if !s.AudiencePopulated() {
+		var err error
+		audience, err = c.state.DB.GetAccountsByIDs(ctx, s.AudienceIDs)
+		if err != nil {
+			return nil, gtserror.Newf("error getting audience: %w", err)
+		}
+	}
+
+	circleMentions := make([]*gtsmodel.Mention, 0, len(audience))
+	for _, account := range audience {
+		i := slices.IndexFunc(mentions, func(m *gtsmodel.Mention) bool {
+			return m.TargetAccountID == account.ID
+		})
+		if i >= 0 {
+			circleMentions = append(circleMentions, mentions[i])
+			continue
+		}
+
+		circleMentions = append(circleMentions, &gtsmodel.Mention{
+			StatusID:         s.ID,
+			OriginAccountID:  s.AccountID,
+			OriginAccountURI: s.AccountURI,
+			TargetAccountID:  account.ID,
+			TargetAccount:    account,
+			TargetAccountURI: account.URI,
+		})
+	}
+
+	return circleMentions, nil
+}
+
 // MentionToAS converts a gts model mention into an activity streams Mention, suitable for federation
 func (c *Converter) MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab.ActivityStreamsMention, error) {
 	if m.TargetAccount == nil {
@@ -1590,6 +1684,37 @@ func (c *Converter) StatusesToASFeaturedCollection(ctx context.Context, featured
 	return collection, nil
 }
 
+// TagsToASFeaturedTagsCollection converts a slice of featured tags
+// into an ordered collection of Hashtag objects, suitable for
+// serving at the featuredTags collection URI of an account.
+func (c *Converter) TagsToASFeaturedTagsCollection(ctx context.Context, featuredTagsID string, tags []*gtsmodel.Tag) (vocab.ActivityStreamsOrderedCollection, error) {
+	collection := streams.NewActivityStreamsOrderedCollection()
+
+	collectionIDProp := streams.NewJSONLDIdProperty()
+	featuredTagsIDURI, err := url.Parse(featuredTagsID)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing url %s: %w", featuredTagsID, err)
+	}
+	collectionIDProp.SetIRI(featuredTagsIDURI)
+	collection.SetJSONLDId(collectionIDProp)
+
+	itemsProp := streams.NewActivityStreamsOrderedItemsProperty()
+	for _, t := range tags {
+		hashtag, err := c.TagToAS(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		itemsProp.AppendTootHashtag(hashtag)
+	}
+	collection.SetActivityStreamsOrderedItems(itemsProp)
+
+	totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProp.Set(len(tags))
+	collection.SetActivityStreamsTotalItems(totalItemsProp)
+
+	return collection, nil
+}
+
 // ReportToASFlag converts a gts model report into an activitystreams FLAG, suitable for federation.
 func (c *Converter) ReportToASFlag(ctx context.Context, r *gtsmodel.Report) (vocab.ActivityStreamsFlag, error) {
 	flag := streams.NewActivityStreamsFlag()
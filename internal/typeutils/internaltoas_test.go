@@ -22,8 +22,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -55,6 +57,7 @@ func (suite *InternalToASTestSuite) TestAccountToAS() {
 
 	suite.Equal(`: true,
   "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+  "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
   "followers": "http://localhost:8080/users/the_mighty_zork/followers",
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "icon": {
@@ -118,6 +121,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithFields() {
   ],
   "discoverable": false,
   "featured": "http://localhost:8080/users/1happyturtle/collections/featured",
+  "featuredTags": "http://localhost:8080/users/1happyturtle/collections/tags",
   "followers": "http://localhost:8080/users/1happyturtle/followers",
   "following": "http://localhost:8080/users/1happyturtle/following",
   "id": "http://localhost:8080/users/1happyturtle",
@@ -138,6 +142,74 @@ func (suite *InternalToASTestSuite) TestAccountToASWithFields() {
 }`, trimmed)
 }
 
+func (suite *InternalToASTestSuite) TestAccountToASWithPronouns() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_2"]
+	testAccount.Pronouns = "she/her & they/them"
+
+	asPerson, err := suite.typeconverter.AccountToAS(context.Background(), testAccount)
+	suite.NoError(err)
+
+	ser, err := ap.Serialize(asPerson)
+	suite.NoError(err)
+
+	// Pronouns should be defined in the context.
+	suite.Contains(ser["@context"], map[string]interface{}{
+		"pronouns": "https://gotosocial.org/ns#pronouns",
+	})
+
+	bytes, err := json.MarshalIndent(ser, "", "  ")
+	suite.NoError(err)
+
+	// trim off everything up to 'attachment';
+	// this is necessary because the order of multiple 'context' entries is not determinate
+	trimmed := strings.Split(string(bytes), "\"attachment\"")[1]
+
+	// Pronouns should be appended to fields, escaped, and set as a property.
+	suite.Equal(`: [
+    {
+      "name": "should you follow me?",
+      "type": "PropertyValue",
+      "value": "maybe!"
+    },
+    {
+      "name": "age",
+      "type": "PropertyValue",
+      "value": "120"
+    },
+    {
+      "name": "Pronouns",
+      "type": "PropertyValue",
+      "value": "she/her \u0026amp; they/them"
+    }
+  ],
+  "discoverable": false,
+  "featured": "http://localhost:8080/users/1happyturtle/collections/featured",
+  "featuredTags": "http://localhost:8080/users/1happyturtle/collections/tags",
+  "followers": "http://localhost:8080/users/1happyturtle/followers",
+  "following": "http://localhost:8080/users/1happyturtle/following",
+  "id": "http://localhost:8080/users/1happyturtle",
+  "inbox": "http://localhost:8080/users/1happyturtle/inbox",
+  "manuallyApprovesFollowers": true,
+  "name": "happy little turtle :3",
+  "outbox": "http://localhost:8080/users/1happyturtle/outbox",
+  "preferredUsername": "1happyturtle",
+  "pronouns": "she/her \u0026 they/them",
+  "publicKey": {
+    "id": "http://localhost:8080/users/1happyturtle#main-key",
+    "owner": "http://localhost:8080/users/1happyturtle",
+    "publicKeyPem": "-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAtTc6Jpg6LrRPhVQG4KLz\n2+YqEUUtZPd4YR+TKXuCnwEG9ZNGhgP046xa9h3EWzrZXaOhXvkUQgJuRqPrAcfN\nvc8jBHV2xrUeD8pu/MWKEabAsA/tgCv3nUC47HQ3/c12aHfYoPz3ufWsGGnrkhci\nv8PaveJ3LohO5vjCn1yZ00v6osMJMViEZvZQaazyE9A8FwraIexXabDpoy7tkHRg\nA1fvSkg4FeSG1XMcIz2NN7xyUuFACD+XkuOk7UqzRd4cjPUPLxiDwIsTlcgGOd3E\nUFMWVlPxSGjY2hIKa3lEHytaYK9IMYdSuyCsJshd3/yYC9LqxZY2KdlKJ80VOVyh\nyQIDAQAB\n-----END PUBLIC KEY-----\n"
+  },
+  "summary": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
+  "tag": [],
+  "type": "Person",
+  "url": "http://localhost:8080/@1happyturtle"
+}`, trimmed)
+
+	// The original fields should be untouched.
+	suite.Len(testAccount.Fields, 2)
+}
+
 func (suite *InternalToASTestSuite) TestAccountToASAliasedAndMoved() {
 	testAccount := &gtsmodel.Account{}
 	*testAccount = *suite.testAccounts["local_account_1"] // take zork for this test
@@ -173,6 +245,7 @@ func (suite *InternalToASTestSuite) TestAccountToASAliasedAndMoved() {
   ],
   "discoverable": true,
   "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+  "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
   "followers": "http://localhost:8080/users/the_mighty_zork/followers",
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "icon": {
@@ -232,6 +305,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithOneField() {
   ],
   "discoverable": false,
   "featured": "http://localhost:8080/users/1happyturtle/collections/featured",
+  "featuredTags": "http://localhost:8080/users/1happyturtle/collections/tags",
   "followers": "http://localhost:8080/users/1happyturtle/followers",
   "following": "http://localhost:8080/users/1happyturtle/following",
   "id": "http://localhost:8080/users/1happyturtle",
@@ -272,6 +346,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithEmoji() {
 
 	suite.Equal(`: true,
   "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+  "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
   "followers": "http://localhost:8080/users/the_mighty_zork/followers",
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "icon": {
@@ -336,6 +411,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithSharedInbox() {
     "sharedInbox": "http://localhost:8080/sharedInbox"
   },
   "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+  "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
   "followers": "http://localhost:8080/users/the_mighty_zork/followers",
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "icon": {
@@ -606,6 +682,82 @@ func (suite *InternalToASTestSuite) TestStatusToASWithMentions() {
 }`, string(bytes))
 }
 
+func (suite *InternalToASTestSuite) TestStatusToASMutualsOnly() {
+	// Copy the mutuals-only status as if created
+	// now, after the author and their mutual had
+	// followed each other.
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["local_account_1_status_4"]
+	testStatus.CreatedAt = time.Now()
+	ctx := context.Background()
+
+	asStatus, err := suite.typeconverter.StatusToAS(ctx, testStatus)
+	suite.NoError(err)
+
+	// Should be addressed only to the
+	// author's mutuals, individually.
+	suite.ElementsMatch([]string{
+		"http://localhost:8080/users/1happyturtle",
+		"http://localhost:8080/users/admin",
+	}, urisToStrings(ap.ExtractToURIs(asStatus)))
+	suite.Empty(ap.ExtractCcURIs(asStatus))
+}
+
+func (suite *InternalToASTestSuite) TestStatusToASCircleRoundTrip() {
+	ctx := context.Background()
+
+	var (
+		member       = suite.testAccounts["local_account_2"]
+		remoteMember = suite.testAccounts["remote_account_1"]
+		nonMember    = suite.testAccounts["admin_account"]
+	)
+
+	// Circle status by zork to turtle and foss_satan,
+	// mentioning turtle and (outside the circle) admin.
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["local_account_1_status_1"]
+	testStatus.Visibility = gtsmodel.VisibilityCircle
+	testStatus.AudienceIDs = []string{member.ID, remoteMember.ID}
+	testStatus.Audience = nil
+	testStatus.Mentions = []*gtsmodel.Mention{
+		{ID: "01J2MBW1B0YTJQ5QZJ3TBZ4VXM", TargetAccountID: member.ID, TargetAccount: member},
+		{ID: "01J2MBW6ZP6N0J1Z8J2NWR2CQ5", TargetAccountID: nonMember.ID, TargetAccount: nonMember},
+	}
+	testStatus.MentionIDs = []string{testStatus.Mentions[0].ID, testStatus.Mentions[1].ID}
+
+	asStatus, err := suite.typeconverter.StatusToAS(ctx, testStatus)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Should be addressed to the audience only.
+	suite.ElementsMatch([]string{member.URI, remoteMember.URI}, urisToStrings(ap.ExtractToURIs(asStatus)))
+	suite.Empty(ap.ExtractCcURIs(asStatus))
+
+	// A receiver should see it as a direct status,
+	// mentioning (so shown to) only the audience.
+	status, err := suite.typeconverter.ASStatusToStatus(ctx, asStatus)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.VisibilityDirect, status.Visibility)
+
+	mentioned := make([]string, 0, len(status.Mentions))
+	for _, m := range status.Mentions {
+		mentioned = append(mentioned, m.TargetAccountURI)
+	}
+	suite.ElementsMatch([]string{member.URI, remoteMember.URI}, mentioned)
+}
+
+// urisToStrings returns the string forms of the given URIs.
+func urisToStrings(uris []*url.URL) []string {
+	strs := make([]string, 0, len(uris))
+	for _, uri := range uris {
+		strs = append(strs, uri.String())
+	}
+	return strs
+}
+
 func (suite *InternalToASTestSuite) TestStatusToASDeletePublicReply() {
 	testStatus := suite.testStatuses["admin_account_status_3"]
 	ctx := context.Background()
@@ -925,6 +1077,53 @@ func (suite *InternalToASTestSuite) TestPinnedStatusesToASOneItem() {
 }`, string(bytes))
 }
 
+func (suite *InternalToASTestSuite) TestFeaturedTagsToAS() {
+	ctx := context.Background()
+
+	testTags := testrig.NewTestTags()
+	tags := []*gtsmodel.Tag{
+		testTags["welcome"],
+		testTags["Hashtag"],
+	}
+
+	collection, err := suite.typeconverter.TagsToASFeaturedTagsCollection(
+		ctx,
+		"http://localhost:8080/users/1happyturtle/collections/tags",
+		tags,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ser, err := ap.Serialize(collection)
+	suite.NoError(err)
+
+	bytes, err := json.MarshalIndent(ser, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    "http://joinmastodon.org/ns"
+  ],
+  "id": "http://localhost:8080/users/1happyturtle/collections/tags",
+  "orderedItems": [
+    {
+      "href": "http://localhost:8080/tags/welcome",
+      "name": "#welcome",
+      "type": "Hashtag"
+    },
+    {
+      "href": "http://localhost:8080/tags/hashtag",
+      "name": "#hashtag",
+      "type": "Hashtag"
+    }
+  ],
+  "totalItems": 2,
+  "type": "OrderedCollection"
+}`, string(bytes))
+}
+
 func (suite *InternalToASTestSuite) TestPollVoteToASCreate() {
 	vote := suite.testPollVotes["remote_account_1_status_2_poll_vote_local_account_1"]
 
@@ -19,6 +19,7 @@ package typeutils
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -32,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
@@ -55,9 +57,13 @@ const (
 	instanceMastodonVersion                     = "3.5.3"
 )
 
-var instanceStatusesSupportedMimeTypes = []string{
-	string(apimodel.StatusContentTypePlain),
-	string(apimodel.StatusContentTypeMarkdown),
+var instanceStatusesSupportedVisibilities = []string{
+	string(apimodel.VisibilityPublic),
+	string(apimodel.VisibilityUnlisted),
+	string(apimodel.VisibilityPrivate),
+	string(apimodel.VisibilityMutualsOnly),
+	string(apimodel.VisibilityCircle),
+	string(apimodel.VisibilityDirect),
 }
 
 func toMastodonVersion(in string) string {
@@ -130,14 +136,38 @@ func (c *Converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmode
 	}
 
 	apiAccount.Source = &apimodel.Source{
-		Privacy:             c.VisToAPIVis(ctx, a.Settings.Privacy),
-		Sensitive:           *a.Settings.Sensitive,
-		Language:            a.Settings.Language,
-		StatusContentType:   statusContentType,
-		Note:                a.NoteRaw,
-		Fields:              c.fieldsToAPIFields(a.FieldsRaw),
-		FollowRequestsCount: *a.Stats.FollowRequestsCount,
-		AlsoKnownAsURIs:     a.AlsoKnownAsURIs,
+		Privacy:                  c.VisToAPIVis(ctx, a.Settings.Privacy),
+		ReplyPrivacy:             c.VisToAPIVis(ctx, a.Settings.ReplyPrivacy),
+		Sensitive:                *a.Settings.Sensitive,
+		DefaultMediaSensitive:    util.PtrValueOr(a.Settings.DefaultMediaSensitive, false),
+		Language:                 a.Settings.Language,
+		StatusContentType:        statusContentType,
+		BioContentType:           a.Settings.BioContentType,
+		DefaultContentWarning:    a.Settings.DefaultContentWarning,
+		DefaultPostExpiry:        int(a.Settings.DefaultPostExpiry / time.Second),
+		AutoApproveFollowsAfter:  int(a.Settings.AutoApproveFollowsAfter / time.Second),
+		ReviewLocalFollows:       util.PtrValueOr(a.Settings.ReviewLocalFollows, false),
+		AwayEnabled:              util.PtrValueOr(a.Settings.AwayEnabled, false),
+		AwayMessage:              a.Settings.AwayMessage,
+		AwayAutoReply:            util.PtrValueOr(a.Settings.AwayAutoReply, false),
+		RequireMediaDescriptions: util.PtrValueOr(a.Settings.RequireMediaDescriptions, false),
+		DetectLanguage:           util.PtrValueOr(a.Settings.DetectLanguage, false),
+		LocalOnly:                util.PtrValueOr(a.Settings.LocalOnly, false),
+		ContinueThread:           util.PtrValueOr(a.Settings.ContinueThread, false),
+		RotatingPinStatusIDs:     a.Settings.RotatingPinStatusIDs,
+		RotatingPinInterval:      int(a.Settings.RotatingPinInterval / time.Second),
+		Note:                     a.NoteRaw,
+		Fields:                   c.fieldsToAPIFields(a.FieldsRaw),
+		FollowRequestsCount:      *a.Stats.FollowRequestsCount,
+		AlsoKnownAsURIs:          a.AlsoKnownAsURIs,
+	}
+
+	if !a.Settings.AwayStartsAt.IsZero() {
+		apiAccount.Source.AwayStartsAt = util.FormatISO8601(a.Settings.AwayStartsAt)
+	}
+
+	if !a.Settings.AwayEndsAt.IsZero() {
+		apiAccount.Source.AwayEndsAt = util.FormatISO8601(a.Settings.AwayEndsAt)
 	}
 
 	return apiAccount, nil
@@ -236,7 +266,7 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 	// Bits that vary between remote + local accounts:
 	//   - Account (acct) string.
 	//   - Role.
-	//   - Settings things (enableRSS, theme, customCSS, hideCollections).
+	//   - Settings things (enableRSS, theme, customCSS, hideCollections, hideWebProfile, awayMessage).
 
 	var (
 		acct            string
@@ -245,6 +275,8 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		theme           string
 		customCSS       string
 		hideCollections bool
+		hideWebProfile  bool
+		awayMessage     string
 	)
 
 	if a.IsRemote() {
@@ -279,6 +311,10 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 			theme = a.Settings.Theme
 			customCSS = a.Settings.CustomCSS
 			hideCollections = *a.Settings.HideCollections
+			hideWebProfile = util.PtrValueOr(a.Settings.HideWebProfile, false)
+			if a.Settings.Away(time.Now()) {
+				awayMessage = a.Settings.AwayMessage
+			}
 		}
 
 		acct = a.Username // omit domain
@@ -303,6 +339,7 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		Bot:             bot,
 		CreatedAt:       util.FormatISO8601(a.CreatedAt),
 		Note:            a.Note,
+		Pronouns:        a.Pronouns,
 		URL:             a.URL,
 		Avatar:          aviURL,
 		AvatarStatic:    aviURLStatic,
@@ -319,6 +356,8 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		CustomCSS:       customCSS,
 		EnableRSS:       enableRSS,
 		HideCollections: hideCollections,
+		HideWebProfile:  hideWebProfile,
+		AwayMessage:     awayMessage,
 		Role:            role,
 	}
 
@@ -526,6 +565,36 @@ func (c *Converter) AppToAPIAppPublic(ctx context.Context, a *gtsmodel.Applicati
 	}, nil
 }
 
+// TokenToAPISession converts the given token, with its application,
+// into an api session. The session is marked as the current one if
+// the token's access token is the given current access token.
+func (c *Converter) TokenToAPISession(
+	ctx context.Context,
+	token *gtsmodel.Token,
+	app *gtsmodel.Application,
+	currentAccess string,
+) (*apimodel.Session, error) {
+	apiApp, err := c.AppToAPIAppPublic(ctx, app)
+	if err != nil {
+		return nil, gtserror.Newf("error converting application: %w", err)
+	}
+
+	apiSession := &apimodel.Session{
+		ID:          token.ID,
+		Application: apiApp,
+		DeviceName:  token.DeviceName,
+		Scope:       token.Scope,
+		CreatedAt:   util.FormatISO8601(token.CreatedAt),
+		Current:     currentAccess != "" && token.Access == currentAccess,
+	}
+
+	if !token.LastUsedAt.IsZero() {
+		apiSession.LastUsedAt = util.FormatISO8601(token.LastUsedAt)
+	}
+
+	return apiSession, nil
+}
+
 // AttachmentToAPIAttachment converts a gts model media attacahment into its api representation for serialization on the API.
 func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.MediaAttachment) (apimodel.Attachment, error) {
 	apiAttachment := apimodel.Attachment{
@@ -718,6 +787,41 @@ func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, stubHistor
 	}, nil
 }
 
+// FeaturedTagToAPIFeaturedTag converts a tag featured by the given
+// account into its api representation, including stats on the
+// account's public use of the tag.
+func (c *Converter) FeaturedTagToAPIFeaturedTag(ctx context.Context, account *gtsmodel.Account, t *gtsmodel.Tag) (*apimodel.FeaturedTag, error) {
+	statusesCount, err := c.state.DB.CountAccountWebStatusesByTag(ctx, account.ID, t.ID)
+	if err != nil {
+		return nil, gtserror.Newf("error counting statuses with tag %s: %w", t.Name, err)
+	}
+
+	var lastStatusAt *string
+	if statusesCount != 0 {
+		statuses, err := c.state.DB.GetAccountWebStatusesByTag(
+			gtscontext.SetBarebones(ctx),
+			account.ID,
+			t.ID,
+			1, "",
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("error getting latest status with tag %s: %w", t.Name, err)
+		}
+
+		if len(statuses) != 0 {
+			lastStatusAt = util.Ptr(util.FormatISO8601(statuses[0].CreatedAt))
+		}
+	}
+
+	return &apimodel.FeaturedTag{
+		ID:            t.ID,
+		Name:          strings.ToLower(t.Name),
+		URL:           uris.URIForTag(t.Name),
+		StatusesCount: statusesCount,
+		LastStatusAt:  lastStatusAt,
+	}, nil
+}
+
 // StatusToAPIStatus converts a gts model status into its api
 // (frontend) representation for serialization on the API.
 //
@@ -826,45 +930,48 @@ func (c *Converter) statusToAPIFilterResults(
 	}
 
 	// At this point, the status isn't muted, but might still be filtered.
-	// Record all matching warn filters and the reasons they matched.
+	// Block filters and allow filters are applied together, as follows:
+	//
+	//   1. If any block filter with the hide action matches,
+	//      the status is hidden, whatever any allow filters say.
+	//   2. If any allow filters apply in this context, the status
+	//      is hidden unless it matches at least one of them. So the
+	//      statuses shown are the intersection of those let through
+	//      by the block filters, and those matched by allow filters.
+	//   3. Otherwise, the status is shown, and all matching warn
+	//      and blur block filters are recorded with the reasons they
+	//      matched. Matching allow filters are not recorded, since
+	//      clients would show them as if they were warnings.
+	var (
+		allowFiltersApply bool
+		allowFilterMatch  bool
+	)
+
 	filterResults := make([]apimodel.FilterResult, 0, len(filters))
 	for _, filter := range filters {
 		if !filterAppliesInContext(filter, filterContext) {
 			// Filter doesn't apply to this context.
 			continue
 		}
-		if filter.Expired(now) {
+		if !filter.Active(now) {
 			continue
 		}
 
-		// List all matching keywords.
-		keywordMatches := make([]string, 0, len(filter.Keywords))
-		fields := filterableTextFields(s)
-		for _, filterKeyword := range filter.Keywords {
-			var isMatch bool
-			for _, field := range fields {
-				if filterKeyword.Regexp.MatchString(field) {
-					isMatch = true
-					break
-				}
-			}
-			if isMatch {
-				keywordMatches = append(keywordMatches, filterKeyword.Keyword)
-			}
-		}
+		keywordMatches, statusMatches := statusFilterMatches(s, filter)
+		isMatch := len(keywordMatches) > 0 || len(statusMatches) > 0 ||
+			statusTargetedByFilter(s, filter)
 
-		// A status has only one ID. Not clear why this is a list in the Mastodon API.
-		statusMatches := make([]string, 0, 1)
-		for _, filterStatus := range filter.Statuses {
-			if s.ID == filterStatus.StatusID {
-				statusMatches = append(statusMatches, filterStatus.StatusID)
-				break
-			}
+		if filter.Mode == gtsmodel.FilterModeAllow {
+			// Allow filters don't have an action,
+			// just keep track of whether one matched.
+			allowFiltersApply = true
+			allowFilterMatch = allowFilterMatch || isMatch
+			continue
 		}
 
-		if len(keywordMatches) > 0 || len(statusMatches) > 0 {
+		if isMatch {
 			switch filter.Action {
-			case gtsmodel.FilterActionWarn:
+			case gtsmodel.FilterActionWarn, gtsmodel.FilterActionBlur:
 				// Record what matched.
 				apiFilter, err := c.FilterToAPIFilterV2(ctx, filter)
 				if err != nil {
@@ -883,9 +990,146 @@ func (c *Converter) statusToAPIFilterResults(
 		}
 	}
 
+	if allowFiltersApply && !allowFilterMatch {
+		// Allow filters are in effect in this
+		// context, and none of them matched.
+		return nil, statusfilter.ErrHideStatus
+	}
+
 	return filterResults, nil
 }
 
+// statusFilterMatches returns the keywords and
+// statuses of the given filter matched by the status.
+func statusFilterMatches(s *gtsmodel.Status, filter *gtsmodel.Filter) (keywordMatches []string, statusMatches []string) {
+	// List all matching keywords.
+	keywordMatches = make([]string, 0, len(filter.Keywords))
+	fields := filterableTextFields(s)
+	for _, filterKeyword := range filter.Keywords {
+		var isMatch bool
+		for _, field := range fields {
+			if filterKeyword.Regexp.MatchString(field) {
+				isMatch = true
+				break
+			}
+		}
+		if isMatch {
+			keywordMatches = append(keywordMatches, filterKeyword.Keyword)
+		}
+	}
+
+	// A status has only one ID. Not clear why this is a list in the Mastodon API.
+	statusMatches = make([]string, 0, 1)
+	for _, filterStatus := range filter.Statuses {
+		if s.ID == filterStatus.StatusID {
+			statusMatches = append(statusMatches, filterStatus.StatusID)
+			break
+		}
+	}
+
+	return keywordMatches, statusMatches
+}
+
+// StatusMatchesFilter returns whether the given status matches the given
+// block mode filter, ie., the filter applies in the given context and is
+// active at the given time, and the status matches one of its keywords or
+// statuses, or is by an account it targets. Allow mode filters never match.
+func StatusMatchesFilter(
+	s *gtsmodel.Status,
+	filter *gtsmodel.Filter,
+	filterContext statusfilter.FilterContext,
+	now time.Time,
+) bool {
+	if filter.Mode == gtsmodel.FilterModeAllow ||
+		!filterAppliesInContext(filter, filterContext) ||
+		!filter.Active(now) {
+		return false
+	}
+
+	return statusMatchedByFilter(s, filter)
+}
+
+// StatusKeptByFilters returns whether the given status is kept, rather
+// than dropped, by the given filters, regardless of the contexts they're
+// configured for. Block and allow filters are applied together as in
+// statusToAPIFilterResults, except that a matching block filter always
+// drops the status, whatever its action:
+//
+//  1. If any active block filter matches, the status is dropped.
+//  2. If any allow filters are active, the status is dropped
+//     unless it matches at least one of them.
+//  3. Otherwise, the status is kept.
+//
+// A boost matches a filter if either it or the boosted status does.
+func StatusKeptByFilters(
+	s *gtsmodel.Status,
+	filters []*gtsmodel.Filter,
+	now time.Time,
+) bool {
+	var (
+		allowFiltersApply bool
+		allowFilterMatch  bool
+	)
+
+	for _, filter := range filters {
+		if !filter.Active(now) {
+			continue
+		}
+
+		isMatch := statusMatchedByFilter(s, filter) ||
+			(s.BoostOf != nil && statusMatchedByFilter(s.BoostOf, filter))
+
+		if filter.Mode == gtsmodel.FilterModeAllow {
+			allowFiltersApply = true
+			allowFilterMatch = allowFilterMatch || isMatch
+			continue
+		}
+
+		if isMatch {
+			// Block filter matched.
+			return false
+		}
+	}
+
+	return !allowFiltersApply || allowFilterMatch
+}
+
+// statusMatchedByFilter returns whether the given status
+// matches one of the filter's keywords or statuses, or is
+// by an account it targets, ignoring mode, context + expiry.
+func statusMatchedByFilter(s *gtsmodel.Status, filter *gtsmodel.Filter) bool {
+	keywordMatches, statusMatches := statusFilterMatches(s, filter)
+	return len(keywordMatches) > 0 || len(statusMatches) > 0 ||
+		statusTargetedByFilter(s, filter)
+}
+
+// statusTargetedByFilter returns whether the given status, or
+// the status it boosts, is by an account targeted by the filter.
+func statusTargetedByFilter(s *gtsmodel.Status, filter *gtsmodel.Filter) bool {
+	if len(filter.TargetAccountIDs) == 0 && len(filter.TargetDomains) == 0 {
+		// Nothing to check.
+		return false
+	}
+
+	var domain string
+	if s.Account != nil {
+		domain = s.Account.Domain
+	}
+	if filter.TargetsAccount(s.AccountID, domain) {
+		return true
+	}
+
+	if s.BoostOfAccountID != "" {
+		var boostDomain string
+		if s.BoostOfAccount != nil {
+			boostDomain = s.BoostOfAccount.Domain
+		}
+		return filter.TargetsAccount(s.BoostOfAccountID, boostDomain)
+	}
+
+	return false
+}
+
 // filterableTextFields returns all text from a status that we might want to filter on:
 // - content
 // - content warning
@@ -1123,6 +1367,18 @@ func (c *Converter) baseStatusToFrontend(
 		}
 	}
 
+	// Apply filters before doing any further
+	// conversion work, so that statuses matched
+	// by a "hide" (aka irreversible) filter are
+	// dropped before we bother serializing them.
+	filterResults, err := c.statusToAPIFilterResults(ctx, s, requestingAccount, filterContext, filters, mutes)
+	if err != nil {
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error applying filters: %w", err)
+	}
+
 	apiAuthorAccount, err := c.AccountToAPIAccountPublic(ctx, s.Account)
 	if err != nil {
 		return nil, gtserror.Newf("error converting status author: %w", err)
@@ -1202,6 +1458,14 @@ func (c *Converter) baseStatusToFrontend(
 		apiStatus.Language = util.Ptr(s.Language)
 	}
 
+	if s.Visibility == gtsmodel.VisibilityCircle &&
+		requestingAccount != nil &&
+		requestingAccount.ID == s.AccountID {
+		// Only the author gets to
+		// see who's in the circle.
+		apiStatus.AudienceIDs = s.AudienceIDs
+	}
+
 	if app := s.CreatedWithApplication; app != nil {
 		apiStatus.Application, err = c.AppToAPIAppPublic(ctx, app)
 		if err != nil {
@@ -1253,20 +1517,51 @@ func (c *Converter) baseStatusToFrontend(
 		s.URL = s.URI
 	}
 
-	// Apply filters.
-	filterResults, err := c.statusToAPIFilterResults(ctx, s, requestingAccount, filterContext, filters, mutes)
-	if err != nil {
-		if errors.Is(err, statusfilter.ErrHideStatus) {
-			return nil, err
+	apiStatus.Filtered = filterResults
+
+	if len(apiStatus.MediaAttachments) > 0 &&
+		strongestFilterAction(filterResults) == apimodel.FilterActionBlur {
+		// Only blur filters matched, so collapse media
+		// behind a reveal, but leave the text visible.
+		// Clients unaware of the blur action will still
+		// respect this, as will web templates.
+		apiStatus.Sensitive = true
+	}
+
+	if len(apiStatus.MediaAttachments) > 0 && !apiStatus.Sensitive &&
+		s.Account != nil && s.Account.Domain != "" {
+		// Check if this instance forces
+		// media from the author's domain
+		// to be shown as sensitive.
+		sensitive, err := c.state.DB.IsDomainSensitive(ctx, s.Account.Domain)
+		if err != nil {
+			return nil, gtserror.Newf("error checking domain sensitive for %s: %w", s.Account.Domain, err)
 		}
-		return nil, fmt.Errorf("error applying filters: %w", err)
+		apiStatus.Sensitive = sensitive
 	}
 
-	apiStatus.Filtered = filterResults
-
 	return apiStatus, nil
 }
 
+// strongestFilterAction returns the strongest action
+// of the given filter results, where hide is stronger
+// than warn, which is stronger than blur.
+func strongestFilterAction(filterResults []apimodel.FilterResult) apimodel.FilterAction {
+	strongest := apimodel.FilterActionNone
+	for _, filterResult := range filterResults {
+		switch action := filterResult.Filter.FilterAction; {
+		case action == apimodel.FilterActionHide:
+			return action
+		case action == apimodel.FilterActionWarn:
+			strongest = action
+		case action == apimodel.FilterActionBlur &&
+			strongest == apimodel.FilterActionNone:
+			strongest = action
+		}
+	}
+	return strongest
+}
+
 // VisToAPIVis converts a gts visibility into its api equivalent
 func (c *Converter) VisToAPIVis(ctx context.Context, m gtsmodel.Visibility) apimodel.Visibility {
 	switch m {
@@ -1274,7 +1569,7 @@ func (c *Converter) VisToAPIVis(ctx context.Context, m gtsmodel.Visibility) apim
 		return apimodel.VisibilityPublic
 	case gtsmodel.VisibilityUnlocked:
 		return apimodel.VisibilityUnlisted
-	case gtsmodel.VisibilityFollowersOnly, gtsmodel.VisibilityMutualsOnly:
+	case gtsmodel.VisibilityFollowersOnly, gtsmodel.VisibilityMutualsOnly, gtsmodel.VisibilityCircle:
 		return apimodel.VisibilityPrivate
 	case gtsmodel.VisibilityDirect:
 		return apimodel.VisibilityDirect
@@ -1344,8 +1639,10 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 	// configuration
 	instance.Configuration.Statuses.MaxCharacters = config.GetStatusesMaxChars()
 	instance.Configuration.Statuses.MaxMediaAttachments = config.GetStatusesMediaMaxFiles()
+	instance.Configuration.Statuses.AllowMixedMedia = config.GetStatusesMediaAllowMixed()
 	instance.Configuration.Statuses.CharactersReservedPerURL = instanceStatusesCharactersReservedPerURL
-	instance.Configuration.Statuses.SupportedMimeTypes = instanceStatusesSupportedMimeTypes
+	instance.Configuration.Statuses.SupportedMimeTypes = config.GetStatusesContentTypes()
+	instance.Configuration.Statuses.SupportedVisibilities = instanceStatusesSupportedVisibilities
 	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.SupportedMIMETypes
 	instance.Configuration.MediaAttachments.ImageSizeLimit = int(config.GetMediaImageMaxSize())
 	instance.Configuration.MediaAttachments.ImageMatrixLimit = instanceMediaAttachmentsImageMatrixLimit
@@ -1484,8 +1781,10 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.URLs.Streaming = "wss://" + i.Domain
 	instance.Configuration.Statuses.MaxCharacters = config.GetStatusesMaxChars()
 	instance.Configuration.Statuses.MaxMediaAttachments = config.GetStatusesMediaMaxFiles()
+	instance.Configuration.Statuses.AllowMixedMedia = config.GetStatusesMediaAllowMixed()
 	instance.Configuration.Statuses.CharactersReservedPerURL = instanceStatusesCharactersReservedPerURL
-	instance.Configuration.Statuses.SupportedMimeTypes = instanceStatusesSupportedMimeTypes
+	instance.Configuration.Statuses.SupportedMimeTypes = config.GetStatusesContentTypes()
+	instance.Configuration.Statuses.SupportedVisibilities = instanceStatusesSupportedVisibilities
 	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.SupportedMIMETypes
 	instance.Configuration.MediaAttachments.ImageSizeLimit = int(config.GetMediaImageMaxSize())
 	instance.Configuration.MediaAttachments.ImageMatrixLimit = instanceMediaAttachmentsImageMatrixLimit
@@ -1825,6 +2124,47 @@ func (c *Converter) MarkersToAPIMarker(ctx context.Context, markers []*gtsmodel.
 	return apiMarker, nil
 }
 
+// ScheduledStatusToAPIScheduledStatus converts a gts model scheduled status into an api model scheduled status,
+// for serving to the account that scheduled it.
+func (c *Converter) ScheduledStatusToAPIScheduledStatus(ctx context.Context, s *gtsmodel.ScheduledStatus) (*apimodel.ScheduledStatus, error) {
+	form := new(apimodel.AdvancedStatusCreateForm)
+	if err := json.Unmarshal([]byte(s.Params), form); err != nil {
+		return nil, gtserror.Newf("error decoding params of scheduled status %s: %w", s.ID, err)
+	}
+
+	attachments := make([]apimodel.Attachment, 0, len(form.MediaIDs))
+	for _, mediaID := range form.MediaIDs {
+		attachment, err := c.state.DB.GetAttachmentByID(ctx, mediaID)
+		if err != nil {
+			log.Errorf(ctx, "error getting attachment %s of scheduled status %s: %v", mediaID, s.ID, err)
+			continue
+		}
+
+		apiAttachment, err := c.AttachmentToAPIAttachment(ctx, attachment)
+		if err != nil {
+			log.Errorf(ctx, "error converting attachment %s: %v", mediaID, err)
+			continue
+		}
+
+		attachments = append(attachments, apiAttachment)
+	}
+
+	return &apimodel.ScheduledStatus{
+		ID:          s.ID,
+		ScheduledAt: util.FormatISO8601(s.ScheduledAt),
+		Params: &apimodel.StatusParams{
+			Text:          form.Status,
+			InReplyToID:   form.InReplyToID,
+			MediaIDs:      form.MediaIDs,
+			Sensitive:     util.PtrValueOr(form.Sensitive, false),
+			SpoilerText:   form.SpoilerText,
+			Visibility:    string(form.Visibility),
+			ApplicationID: s.ApplicationID,
+		},
+		MediaAttachments: attachments,
+	}, nil
+}
+
 // PollToAPIPoll converts a database (gtsmodel) Poll into an API model representation appropriate for the given requesting account.
 func (c *Converter) PollToAPIPoll(ctx context.Context, requester *gtsmodel.Account, poll *gtsmodel.Poll) (*apimodel.Poll, error) {
 	// Ensure the poll model is fully populated for src status.
@@ -2021,11 +2361,31 @@ func (c *Converter) FilterToAPIFilterV2(ctx context.Context, filter *gtsmodel.Fi
 		Context:      filterToAPIFilterContexts(filter),
 		ExpiresAt:    filterExpiresAtToAPIFilterExpiresAt(filter.ExpiresAt),
 		FilterAction: filterActionToAPIFilterAction(filter.Action),
+		FilterMode:   filterModeToAPIFilterMode(filter.Mode),
 		Keywords:     apiFilterKeywords,
 		Statuses:     apiFilterStatuses,
+
+		Accounts:          filter.TargetAccountIDs,
+		Domains:           filter.TargetDomains,
+		IncludeSubdomains: util.PtrValueOr(filter.TargetSubdomains, false),
+
+		ActiveDays:     filter.ActiveDays,
+		ActiveFrom:     filterActiveTimeToAPIFilterActiveTime(filter, filter.ActiveFrom),
+		ActiveUntil:    filterActiveTimeToAPIFilterActiveTime(filter, filter.ActiveUntil),
+		ActiveTimezone: filter.ActiveTimezone,
 	}, nil
 }
 
+// filterActiveTimeToAPIFilterActiveTime formats the given
+// minutes after midnight as a time of day (HH:MM), or returns
+// an empty string if the filter is active all day.
+func filterActiveTimeToAPIFilterActiveTime(filter *gtsmodel.Filter, mins int) string {
+	if filter.ActiveFrom == filter.ActiveUntil {
+		return ""
+	}
+	return fmt.Sprintf("%02d:%02d", mins/60, mins%60)
+}
+
 func filterExpiresAtToAPIFilterExpiresAt(expiresAt time.Time) *string {
 	if expiresAt.IsZero() {
 		return nil
@@ -2059,16 +2419,31 @@ func filterActionToAPIFilterAction(m gtsmodel.FilterAction) apimodel.FilterActio
 		return apimodel.FilterActionWarn
 	case gtsmodel.FilterActionHide:
 		return apimodel.FilterActionHide
+	case gtsmodel.FilterActionBlur:
+		return apimodel.FilterActionBlur
+	case gtsmodel.FilterActionNotify:
+		return apimodel.FilterActionNotify
 	}
 	return apimodel.FilterActionNone
 }
 
+func filterModeToAPIFilterMode(m gtsmodel.FilterMode) apimodel.FilterMode {
+	if m == gtsmodel.FilterModeAllow {
+		return apimodel.FilterModeAllow
+	}
+	// Filters without a mode
+	// predate filter modes,
+	// and so are block filters.
+	return apimodel.FilterModeBlock
+}
+
 // FilterKeywordToAPIFilterKeyword converts a GTS model filter status into an API filter status.
 func (c *Converter) FilterKeywordToAPIFilterKeyword(ctx context.Context, filterKeyword *gtsmodel.FilterKeyword) *apimodel.FilterKeyword {
 	return &apimodel.FilterKeyword{
 		ID:        filterKeyword.ID,
 		Keyword:   filterKeyword.Keyword,
 		WholeWord: util.PtrValueOr(filterKeyword.WholeWord, false),
+		Regex:     util.PtrValueOr(filterKeyword.Regex, false),
 	}
 }
 
@@ -20,9 +20,12 @@ package typeutils_test
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
@@ -118,9 +121,24 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
   "fields": [],
   "source": {
     "privacy": "public",
+    "reply_privacy": "",
     "sensitive": false,
+    "default_media_sensitive": false,
     "language": "en",
     "status_content_type": "text/plain",
+    "bio_content_type": "",
+    "default_content_warning": "",
+    "default_post_expiry": 0,
+    "auto_approve_follows_after": 0,
+    "review_local_follows": false,
+    "away_enabled": false,
+    "away_message": "",
+    "away_auto_reply": false,
+    "require_media_descriptions": false,
+    "detect_language": false,
+    "local_only": false,
+    "continue_thread": false,
+    "rotating_pin_interval": 0,
     "note": "hey yo this is my profile!",
     "fields": [],
     "follow_requests_count": 0,
@@ -301,9 +319,24 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitive() {
   "fields": [],
   "source": {
     "privacy": "public",
+    "reply_privacy": "",
     "sensitive": false,
+    "default_media_sensitive": false,
     "language": "en",
     "status_content_type": "text/plain",
+    "bio_content_type": "",
+    "default_content_warning": "",
+    "default_post_expiry": 0,
+    "auto_approve_follows_after": 0,
+    "review_local_follows": false,
+    "away_enabled": false,
+    "away_message": "",
+    "away_auto_reply": false,
+    "require_media_descriptions": false,
+    "detect_language": false,
+    "local_only": false,
+    "continue_thread": false,
+    "rotating_pin_interval": 0,
     "note": "hey yo this is my profile!",
     "fields": [],
     "follow_requests_count": 0
@@ -677,6 +710,7 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
         ],
         "expires_at": null,
         "filter_action": "warn",
+        "filter_mode": "block",
         "keywords": [
           {
             "id": "01HN272TAVWAXX72ZX4M8JZ0PS",
@@ -719,6 +753,411 @@ func (suite *InternalToFrontendTestSuite) TestHideFilteredStatusToFrontend() {
 	suite.ErrorIs(err, statusfilter.ErrHideStatus)
 }
 
+// Test that a status by an account targeted by a hide filter results in the ErrHideStatus error.
+func (suite *InternalToFrontendTestSuite) TestHideAccountFilteredStatusToFrontend() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	requestingAccount := suite.testAccounts["local_account_1"]
+	filter := suite.testFilters["local_account_1_filter_1"]
+	filter.Action = gtsmodel.FilterActionHide
+	filter.TargetAccountIDs = []string{testStatus.AccountID}
+	_, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		[]*gtsmodel.Filter{filter},
+		nil,
+	)
+	suite.ErrorIs(err, statusfilter.ErrHideStatus)
+}
+
+// Test that a status from a domain targeted by a hide filter results in the
+// ErrHideStatus error, but only matches subdomains if the filter says so.
+func (suite *InternalToFrontendTestSuite) TestHideDomainFilteredStatusToFrontend() {
+	// Copy status and account so we can post
+	// from a subdomain of fossbros-anonymous.io.
+	testAccount := new(gtsmodel.Account)
+	*testAccount = *suite.testAccounts["remote_account_1"]
+	testAccount.Domain = "social.fossbros-anonymous.io"
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["remote_account_1_status_1"]
+	testStatus.Account = testAccount
+
+	requestingAccount := suite.testAccounts["local_account_1"]
+	filter := suite.testFilters["local_account_1_filter_1"]
+	filter.Action = gtsmodel.FilterActionHide
+	filter.TargetDomains = []string{"fossbros-anonymous.io"}
+
+	// Parent domain, subdomains not included.
+	_, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		[]*gtsmodel.Filter{filter},
+		nil,
+	)
+	suite.NoError(err)
+
+	// Parent domain, subdomains included.
+	filter.TargetSubdomains = util.Ptr(true)
+	_, err = suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		[]*gtsmodel.Filter{filter},
+		nil,
+	)
+	suite.ErrorIs(err, statusfilter.ErrHideStatus)
+
+	// A domain merely ending in the target
+	// domain is not a subdomain of it, so
+	// fossbros-anonymous.io is not matched
+	// by a filter on anonymous.io.
+	filter.TargetDomains = []string{"anonymous.io"}
+	testAccount.Domain = "fossbros-anonymous.io"
+	_, err = suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		[]*gtsmodel.Filter{filter},
+		nil,
+	)
+	suite.NoError(err)
+}
+
+// Test that a status matching a regex filter keyword results in the ErrHideStatus
+// error, and that patterns which would backtrack catastrophically in other
+// regex engines are still evaluated promptly against long inputs.
+func (suite *InternalToFrontendTestSuite) TestHideRegexFilteredStatusToFrontend() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	requestingAccount := suite.testAccounts["local_account_1"]
+	filter := suite.testFilters["local_account_1_filter_1"]
+	filter.Action = gtsmodel.FilterActionHide
+	filterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	filterKeyword.Keyword = `(a+)+$`
+	filterKeyword.Regex = util.Ptr(true)
+	suite.NoError(filterKeyword.Compile())
+	filterKeyword.Filter = filter
+	filter.Keywords = []*gtsmodel.FilterKeyword{filterKeyword}
+
+	// Long run of 'a' followed by a non-matching
+	// character: exponential for backtracking engines.
+	testStatus.Content = strings.Repeat("a", 100000) + "!"
+	testStatus.Text = testStatus.Content
+
+	start := time.Now()
+	_, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		[]*gtsmodel.Filter{filter},
+		nil,
+	)
+	suite.NoError(err)
+	suite.Less(time.Since(start), 5*time.Second)
+
+	// Now make it match.
+	testStatus.Content = strings.Repeat("a", 100000)
+	testStatus.Text = testStatus.Content
+	_, err = suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		[]*gtsmodel.Filter{filter},
+		nil,
+	)
+	suite.ErrorIs(err, statusfilter.ErrHideStatus)
+}
+
+// Test that a status with media which is filtered with a blur filter
+// is returned with the filter result, and marked as sensitive.
+func (suite *InternalToFrontendTestSuite) TestBlurFilteredStatusToFrontend() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testStatus.Content += " fnord"
+	testStatus.Text += " fnord"
+	requestingAccount := suite.testAccounts["local_account_1"]
+	expectedMatchingFilter := suite.testFilters["local_account_1_filter_1"]
+	expectedMatchingFilter.Action = gtsmodel.FilterActionBlur
+	expectedMatchingFilterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	suite.NoError(expectedMatchingFilterKeyword.Compile())
+	expectedMatchingFilterKeyword.Filter = expectedMatchingFilter
+	expectedMatchingFilter.Keywords = []*gtsmodel.FilterKeyword{expectedMatchingFilterKeyword}
+	requestingAccountFilters := []*gtsmodel.Filter{expectedMatchingFilter}
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		requestingAccountFilters,
+		nil,
+	)
+	suite.NoError(err)
+
+	suite.NotEmpty(apiStatus.MediaAttachments)
+	suite.True(apiStatus.Sensitive)
+	if suite.Len(apiStatus.Filtered, 1) {
+		suite.Equal(apimodel.FilterActionBlur, apiStatus.Filtered[0].Filter.FilterAction)
+		suite.Equal([]string{"fnord"}, apiStatus.Filtered[0].KeywordMatches)
+	}
+}
+
+// Test that when both a blur and a warn filter match a status,
+// the warn filter wins, and the status isn't marked as sensitive,
+// but both filter results are returned.
+func (suite *InternalToFrontendTestSuite) TestBlurAndWarnFilteredStatusToFrontend() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testStatus.Content += " fnord foo"
+	testStatus.Text += " fnord foo"
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	blurFilter := suite.testFilters["local_account_1_filter_1"]
+	blurFilter.Action = gtsmodel.FilterActionBlur
+	blurFilterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	suite.NoError(blurFilterKeyword.Compile())
+	blurFilterKeyword.Filter = blurFilter
+	blurFilter.Keywords = []*gtsmodel.FilterKeyword{blurFilterKeyword}
+
+	warnFilter := suite.testFilters["local_account_1_filter_2"]
+	warnFilterKeyword := suite.testFilterKeywords["local_account_1_filter_2_keyword_1"]
+	suite.NoError(warnFilterKeyword.Compile())
+	warnFilterKeyword.Filter = warnFilter
+	warnFilter.Keywords = []*gtsmodel.FilterKeyword{warnFilterKeyword}
+
+	requestingAccountFilters := []*gtsmodel.Filter{blurFilter, warnFilter}
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		requestingAccountFilters,
+		nil,
+	)
+	suite.NoError(err)
+
+	suite.False(apiStatus.Sensitive)
+	if suite.Len(apiStatus.Filtered, 2) {
+		suite.Equal(apimodel.FilterActionBlur, apiStatus.Filtered[0].Filter.FilterAction)
+		suite.Equal(apimodel.FilterActionWarn, apiStatus.Filtered[1].Filter.FilterAction)
+	}
+}
+
+// Test that media of a remote status is marked as sensitive when
+// this instance has marked media from the author's domain sensitive.
+func (suite *InternalToFrontendTestSuite) TestDomainSensitiveStatusToFrontend() {
+	ctx := context.Background()
+	testStatus := suite.testStatuses["remote_account_1_status_1"]
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(
+		ctx,
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextNone,
+		nil,
+		nil,
+	)
+	suite.NoError(err)
+	suite.NotEmpty(apiStatus.MediaAttachments)
+	suite.False(apiStatus.Sensitive)
+
+	// Mark media from the
+	// author's domain sensitive.
+	if err := suite.db.CreateDomainSensitive(ctx, &gtsmodel.DomainSensitive{
+		ID:                 "01J1Z8YV0QDXWB5RFS0XYC3M4E",
+		Domain:             "fossbros-anonymous.io",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiStatus, err = suite.typeconverter.StatusToAPIStatus(
+		ctx,
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextNone,
+		nil,
+		nil,
+	)
+	suite.NoError(err)
+	suite.True(apiStatus.Sensitive)
+
+	// The status itself should be untouched.
+	suite.False(*testStatus.Sensitive)
+}
+
+// Test that a status matching an allow filter is shown,
+// without the allow filter being recorded in the results.
+func (suite *InternalToFrontendTestSuite) TestAllowFilteredStatusToFrontend() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testStatus.Content += " fnord"
+	testStatus.Text += " fnord"
+	requestingAccount := suite.testAccounts["local_account_1"]
+	allowFilter := suite.testFilters["local_account_1_filter_1"]
+	allowFilter.Mode = gtsmodel.FilterModeAllow
+	allowFilterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	suite.NoError(allowFilterKeyword.Compile())
+	allowFilterKeyword.Filter = allowFilter
+	allowFilter.Keywords = []*gtsmodel.FilterKeyword{allowFilterKeyword}
+	requestingAccountFilters := []*gtsmodel.Filter{allowFilter}
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		requestingAccountFilters,
+		nil,
+	)
+	suite.NoError(err)
+	suite.Empty(apiStatus.Filtered)
+}
+
+// Test that a status not matching any allow filter
+// in the current context results in the ErrHideStatus error.
+func (suite *InternalToFrontendTestSuite) TestAllowFilteredStatusNoMatchToFrontend() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	requestingAccount := suite.testAccounts["local_account_1"]
+	allowFilter := suite.testFilters["local_account_1_filter_1"]
+	allowFilter.Mode = gtsmodel.FilterModeAllow
+	allowFilterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	suite.NoError(allowFilterKeyword.Compile())
+	allowFilterKeyword.Filter = allowFilter
+	allowFilter.Keywords = []*gtsmodel.FilterKeyword{allowFilterKeyword}
+	requestingAccountFilters := []*gtsmodel.Filter{allowFilter}
+	_, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		requestingAccountFilters,
+		nil,
+	)
+	suite.ErrorIs(err, statusfilter.ErrHideStatus)
+
+	// Allow filters don't apply outside their contexts.
+	_, err = suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextAccount,
+		requestingAccountFilters,
+		nil,
+	)
+	suite.NoError(err)
+}
+
+// Test that a status matching both an allow filter and a
+// hide filter results in the ErrHideStatus error, while
+// a warn filter matching too is still recorded as usual.
+func (suite *InternalToFrontendTestSuite) TestAllowAndBlockFilteredStatusToFrontend() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testStatus.Content += " fnord foo"
+	testStatus.Text += " fnord foo"
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	allowFilter := suite.testFilters["local_account_1_filter_1"]
+	allowFilter.Mode = gtsmodel.FilterModeAllow
+	allowFilterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	suite.NoError(allowFilterKeyword.Compile())
+	allowFilterKeyword.Filter = allowFilter
+	allowFilter.Keywords = []*gtsmodel.FilterKeyword{allowFilterKeyword}
+
+	blockFilter := suite.testFilters["local_account_1_filter_2"]
+	blockFilterKeyword := suite.testFilterKeywords["local_account_1_filter_2_keyword_1"]
+	suite.NoError(blockFilterKeyword.Compile())
+	blockFilterKeyword.Filter = blockFilter
+	blockFilter.Keywords = []*gtsmodel.FilterKeyword{blockFilterKeyword}
+
+	requestingAccountFilters := []*gtsmodel.Filter{allowFilter, blockFilter}
+
+	// Warn filter matching: status shown with warning.
+	blockFilter.Action = gtsmodel.FilterActionWarn
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		requestingAccountFilters,
+		nil,
+	)
+	suite.NoError(err)
+	if suite.Len(apiStatus.Filtered, 1) {
+		suite.Equal(blockFilter.ID, apiStatus.Filtered[0].Filter.ID)
+	}
+
+	// Hide filter matching: status hidden.
+	blockFilter.Action = gtsmodel.FilterActionHide
+	_, err = suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		requestingAccountFilters,
+		nil,
+	)
+	suite.ErrorIs(err, statusfilter.ErrHideStatus)
+}
+
+// Test that a boost of a status which is filtered with a hide filter
+// by the requesting user also results in the ErrHideStatus error.
+func (suite *InternalToFrontendTestSuite) TestHideFilteredBoostToFrontend() {
+	boostedStatus := &gtsmodel.Status{}
+	*boostedStatus = *suite.testStatuses["local_account_1_status_1"]
+	boostedStatus.Content += " fnord"
+	boostedStatus.Text += " fnord"
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["admin_account_status_4"]
+	testStatus.BoostOf = boostedStatus
+	requestingAccount := suite.testAccounts["local_account_2"]
+	expectedMatchingFilter := suite.testFilters["local_account_1_filter_1"]
+	expectedMatchingFilter.Action = gtsmodel.FilterActionHide
+	expectedMatchingFilterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	suite.NoError(expectedMatchingFilterKeyword.Compile())
+	expectedMatchingFilterKeyword.Filter = expectedMatchingFilter
+	expectedMatchingFilter.Keywords = []*gtsmodel.FilterKeyword{expectedMatchingFilterKeyword}
+	requestingAccountFilters := []*gtsmodel.Filter{expectedMatchingFilter}
+	_, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		requestingAccountFilters,
+		nil,
+	)
+	suite.ErrorIs(err, statusfilter.ErrHideStatus)
+}
+
+// Test that a status which is filtered with a hide filter by the requesting user
+// is still returned when no filter context applies, eg., when viewed by permalink.
+func (suite *InternalToFrontendTestSuite) TestHideFilteredStatusNoContextToFrontend() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testStatus.Content += " fnord"
+	testStatus.Text += " fnord"
+	requestingAccount := suite.testAccounts["local_account_1"]
+	expectedMatchingFilter := suite.testFilters["local_account_1_filter_1"]
+	expectedMatchingFilter.Action = gtsmodel.FilterActionHide
+	expectedMatchingFilterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	suite.NoError(expectedMatchingFilterKeyword.Compile())
+	expectedMatchingFilterKeyword.Filter = expectedMatchingFilter
+	expectedMatchingFilter.Keywords = []*gtsmodel.FilterKeyword{expectedMatchingFilterKeyword}
+	requestingAccountFilters := []*gtsmodel.Filter{expectedMatchingFilter}
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextNone,
+		requestingAccountFilters,
+		nil,
+	)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+	suite.Empty(apiStatus.Filtered)
+}
+
 // Test that a status from a user muted by the requesting user results in the ErrHideStatus error.
 func (suite *InternalToFrontendTestSuite) TestMutedStatusToFrontend() {
 	testStatus := suite.testStatuses["admin_account_status_1"]
@@ -1193,10 +1632,19 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV1ToFrontend() {
     "statuses": {
       "max_characters": 5000,
       "max_media_attachments": 6,
+      "allow_mixed_media": true,
       "characters_reserved_per_url": 25,
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "circle",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -1318,10 +1766,19 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
     "statuses": {
       "max_characters": 5000,
       "max_media_attachments": 6,
+      "allow_mixed_media": true,
       "characters_reserved_per_url": 25,
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "circle",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -92,6 +92,17 @@ func misskeyReportInlineURLs(content string) []*url.URL {
 	return urls
 }
 
+// pronounsFieldName is the name of the profile
+// field that account pronouns are federated as,
+// alongside the pronouns extension property.
+const pronounsFieldName = "Pronouns"
+
+// isPronounsField returns whether the given
+// profile field is used to show pronouns.
+func isPronounsField(field *gtsmodel.Field) bool {
+	return strings.EqualFold(field.Name, pronounsFieldName)
+}
+
 // placeholdUnknownAttachments separates any attachments with type `unknown`
 // out of the given slice, and returns a piece of text containing links to
 // those attachments, as well as the slice of remaining "known" attachments.
@@ -117,6 +117,14 @@ func GenerateURIForMove(username string, thisMoveID string) string {
 	return fmt.Sprintf("%s://%s/%s/%s/%s/%s", protocol, host, UsersPath, username, MovesPath, thisMoveID)
 }
 
+// GenerateURIForFeaturedTags returns the AP URI for an account's featured tags collection -- something like:
+// https://example.org/users/whatever_user/collections/tags
+func GenerateURIForFeaturedTags(username string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s/%s/%s/%s", protocol, host, UsersPath, username, CollectionsPath, TagsPath)
+}
+
 // GenerateURIForReport returns the API URI for a new Flag activity -- something like:
 // https://example.org/reports/01GP3AWY4CRDVRNZKW0TEAMB5R
 //
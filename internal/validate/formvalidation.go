@@ -21,11 +21,18 @@ import (
 	"errors"
 	"fmt"
 	"net/mail"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/miekg/dns"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	pwv "github.com/wagslane/go-password-validator"
 	"golang.org/x/text/language"
 )
@@ -43,9 +50,16 @@ const (
 	maximumEmojiCategoryLength    = 64
 	maximumProfileFieldLength     = 255
 	maximumProfileFields          = 6
+	maximumFeaturedTags           = 10
 	maximumListTitleLength        = 200
 	maximumFilterKeywordLength    = 40
+	maximumFilterRegexLength      = 200
+	maximumFilterRegexInsts       = 1000 // Maximum size of a compiled filter regex program. Bounds the per-character cost of matching.
 	maximumFilterTitleLength      = 200
+	maximumFilterTargets          = 100
+	maximumContentWarningLength   = 500
+	maximumAwayMessageLength      = 500
+	maximumPronounsLength         = 64
 )
 
 // Password returns a helpful error if the given password
@@ -152,6 +166,18 @@ func Note(note string) error {
 	return nil
 }
 
+// Pronouns checks that the given profile pronouns
+// are not too long, and fit on a single line.
+func Pronouns(pronouns string) error {
+	if length := len([]rune(pronouns)); length > maximumPronounsLength {
+		return fmt.Errorf("pronouns must be less than %d characters, but submitted pronouns were %d characters", maximumPronounsLength, length)
+	}
+	if strings.ContainsAny(pronouns, "\r\n") {
+		return errors.New("pronouns must not contain line breaks")
+	}
+	return nil
+}
+
 // Privacy checks that the desired privacy setting is valid
 func Privacy(privacy string) error {
 	if privacy == "" {
@@ -164,16 +190,115 @@ func Privacy(privacy string) error {
 	return fmt.Errorf("privacy '%s' was not recognized, valid options are 'direct', 'mutuals_only', 'private', 'public', 'unlisted'", privacy)
 }
 
-// StatusContentType checks that the desired status format setting is valid.
+// StatusContentType checks that the desired status format setting
+// is valid, and allowed by the instance's statuses-content-types.
 func StatusContentType(statusContentType string) error {
 	if statusContentType == "" {
 		return fmt.Errorf("empty string for status format not allowed")
 	}
 	switch apimodel.StatusContentType(statusContentType) {
 	case apimodel.StatusContentTypePlain, apimodel.StatusContentTypeMarkdown:
-		return nil
+	default:
+		return fmt.Errorf("status content type '%s' was not recognized, valid options are 'text/plain', 'text/markdown'", statusContentType)
+	}
+	allowed := config.GetStatusesContentTypes()
+	if !slices.Contains(allowed, statusContentType) {
+		return fmt.Errorf("status content type '%s' is not allowed on this instance, allowed options are '%s'", statusContentType, strings.Join(allowed, "', '"))
+	}
+	return nil
+}
+
+// DefaultContentWarning checks that the desired default
+// content warning / spoiler text setting is not too long.
+func DefaultContentWarning(contentWarning string) error {
+	if length := len([]rune(contentWarning)); length > maximumContentWarningLength {
+		return fmt.Errorf("default_content_warning must be less than %d characters, but submitted default_content_warning was %d characters", maximumContentWarningLength, length)
+	}
+	return nil
+}
+
+// AwayMessage checks that the desired away message is not too long.
+func AwayMessage(message string) error {
+	if length := len([]rune(message)); length > maximumAwayMessageLength {
+		return fmt.Errorf("away_message must be less than %d characters, but submitted away_message was %d characters", maximumAwayMessageLength, length)
+	}
+	return nil
+}
+
+// AwayWindow checks that the end of the given away window, if
+// set, is after its start. Either end of the window may be zero.
+func AwayWindow(startsAt time.Time, endsAt time.Time) error {
+	if !startsAt.IsZero() && !endsAt.IsZero() && !endsAt.After(startsAt) {
+		return errors.New("away_ends_at must be after away_starts_at")
+	}
+	return nil
+}
+
+// StatusExpiry checks that the given status expiry, in
+// seconds, is within the bounds configured for automatic
+// status deletion. The field name is used in the error.
+func StatusExpiry(field string, seconds int) error {
+	var (
+		expiry    = time.Duration(seconds) * time.Second
+		expiryMin = config.GetStatusesExpiryMin()
+		expiryMax = config.GetStatusesExpiryMax()
+	)
+
+	if expiry < expiryMin || expiry > expiryMax {
+		return fmt.Errorf("%s must be between %d and %d seconds, provided value was %d", field, int64(expiryMin.Seconds()), int64(expiryMax.Seconds()), seconds)
+	}
+
+	return nil
+}
+
+// AutoApproveFollowsAfter checks that the given account age, in
+// seconds, after which follow requests are automatically approved
+// is not negative, and no more than a year. 0 disables auto-approval.
+func AutoApproveFollowsAfter(seconds int) error {
+	const maxSeconds = 365 * 24 * 60 * 60
+	if seconds < 0 || seconds > maxSeconds {
+		return fmt.Errorf("auto_approve_follows_after must be between 0 and %d seconds, provided value was %d", maxSeconds, seconds)
+	}
+
+	return nil
+}
+
+// RotatingPin checks that a rotating pin has at most 10
+// statuses, and an interval, in seconds, of at least an
+// hour and no more than a year. 0 turns rotation off.
+func RotatingPin(statusIDs []string, seconds int) error {
+	const (
+		maxStatuses = 10
+		minSeconds  = 60 * 60
+		maxSeconds  = 365 * 24 * 60 * 60
+	)
+
+	if len(statusIDs) > maxStatuses {
+		return fmt.Errorf("rotating_pin_status_ids may contain at most %d statuses, provided %d", maxStatuses, len(statusIDs))
+	}
+
+	if seconds != 0 && (seconds < minSeconds || seconds > maxSeconds) {
+		return fmt.Errorf("rotating_pin_interval must be 0, or between %d and %d seconds, provided value was %d", minSeconds, maxSeconds, seconds)
+	}
+
+	return nil
+}
+
+// MediaDescription checks the description of the media attachment
+// with the given ID, at the given (zero-indexed) position in a new
+// status. The description must be at least media-description-min-chars
+// long, and if required, must not be empty or only whitespace.
+func MediaDescription(position int, mediaID string, description string, required bool) error {
+	if required && strings.TrimSpace(description) == "" {
+		return fmt.Errorf("media attachment %d (%s) has no description: alt text is required for all media attached to statuses", position+1, mediaID)
 	}
-	return fmt.Errorf("status content type '%s' was not recognized, valid options are 'text/plain', 'text/markdown'", statusContentType)
+
+	minChars := config.GetMediaDescriptionMinChars()
+	if length := len([]rune(description)); length < minChars {
+		return fmt.Errorf("media %s description too short, at least %d required", mediaID, minChars)
+	}
+
+	return nil
 }
 
 func CustomCSS(customCSS string) error {
@@ -255,6 +380,15 @@ func ULID(i string, name string) error {
 	return nil
 }
 
+// FeaturedTags checks that the given featured
+// tag IDs don't exceed the maximum allowed amount.
+func FeaturedTags(tagIDs []string) error {
+	if len(tagIDs) > maximumFeaturedTags {
+		return fmt.Errorf("cannot have more than %d featured tags", maximumFeaturedTags)
+	}
+	return nil
+}
+
 // ProfileFields validates the length of provided fields slice,
 // and also iterates through the fields and trims each name + value
 // to maximumProfileFieldLength, if they were above.
@@ -329,6 +463,147 @@ func FilterKeyword(keyword string) error {
 	return nil
 }
 
+// FilterKeywordRegex validates a filter keyword that is
+// to be matched as a regular expression, rather than as a
+// literal phrase. Regex keywords must be enabled on the
+// instance, and the pattern must compile, stay within
+// length and complexity limits, and not match empty text
+// (which would otherwise filter every status).
+//
+// Go's RE2 engine matches in time linear to the length of
+// the input, so there's no catastrophic backtracking to
+// guard against; limiting the size of the compiled program
+// bounds the remaining per-character cost of each match.
+func FilterKeywordRegex(pattern string) error {
+	if !config.GetInstanceAllowRegexFilters() {
+		return errors.New("regular expression filter keywords are not enabled on this instance")
+	}
+
+	if pattern == "" {
+		return fmt.Errorf("filter keyword must be provided, and must be no more than %d chars", maximumFilterRegexLength)
+	}
+
+	if length := len([]rune(pattern)); length > maximumFilterRegexLength {
+		return fmt.Errorf("filter keyword regular expression length must be no more than %d chars, provided keyword was %d chars", maximumFilterRegexLength, length)
+	}
+
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return fmt.Errorf("filter keyword is not a valid regular expression: %w", err)
+	}
+
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return fmt.Errorf("filter keyword is not a valid regular expression: %w", err)
+	}
+
+	if size := len(prog.Inst); size > maximumFilterRegexInsts {
+		return fmt.Errorf("filter keyword regular expression is too complex: compiled to %d instructions, maximum is %d", size, maximumFilterRegexInsts)
+	}
+
+	if regexp.MustCompile(pattern).MatchString("") {
+		return errors.New("filter keyword regular expression must not match empty text")
+	}
+
+	return nil
+}
+
+// FilterKeywordOrRegex validates a filter keyword either
+// as a regular expression or as a literal phrase.
+func FilterKeywordOrRegex(keyword string, regex bool) error {
+	if regex {
+		return FilterKeywordRegex(keyword)
+	}
+
+	return FilterKeyword(keyword)
+}
+
+// FilterAccounts validates the IDs of accounts targeted by a filter.
+func FilterAccounts(accountIDs []string) error {
+	if length := len(accountIDs); length > maximumFilterTargets {
+		return fmt.Errorf("filter can target no more than %d accounts, provided %d", maximumFilterTargets, length)
+	}
+
+	for _, accountID := range accountIDs {
+		if err := ULID(accountID, "account_id"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FilterDomains validates the domains targeted by a filter, returning
+// them normalized to lowercase punycode, with any duplicates removed.
+func FilterDomains(domains []string) ([]string, error) {
+	if length := len(domains); length > maximumFilterTargets {
+		return nil, fmt.Errorf("filter can target no more than %d domains, provided %d", maximumFilterTargets, length)
+	}
+
+	normalized := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		punyDomain, err := util.Punify(strings.TrimSuffix(domain, "."))
+		if err != nil {
+			return nil, fmt.Errorf("filter domain %s could not be converted to punycode: %w", domain, err)
+		}
+
+		if _, ok := dns.IsDomainName(punyDomain); !ok ||
+			punyDomain == "" || strings.ContainsAny(punyDomain, "/:@") {
+			return nil, fmt.Errorf("filter domain %s is not a valid domain", domain)
+		}
+
+		normalized = append(normalized, punyDomain)
+	}
+
+	return util.Deduplicate(normalized), nil
+}
+
+// FilterActiveWindow validates the recurring window in which a filter is
+// active: the days of the week on which it's active, from 0 (Sunday) to 6
+// (Saturday), the times of day (HH:MM) from and until which it's active,
+// and the IANA time zone that these are in. The times must be given
+// together, and differ. A window ending earlier in the day than it starts
+// runs past midnight. The times are returned as minutes after midnight,
+// or both 0 if not given, meaning the filter is active all day.
+func FilterActiveWindow(days []int, from string, until string, timezone string) (int, int, error) {
+	for _, day := range days {
+		if day < 0 || day > 6 {
+			return 0, 0, fmt.Errorf("filter active day %d is not a valid day of the week, valid days are 0 (Sunday) to 6 (Saturday)", day)
+		}
+	}
+
+	if (from == "") != (until == "") {
+		return 0, 0, errors.New("filter active_from and active_until must be provided together")
+	}
+
+	var fromMins, untilMins int
+	if from != "" {
+		fromTime, err := time.Parse("15:04", from)
+		if err != nil {
+			return 0, 0, fmt.Errorf("filter active_from %s is not a valid time of day (HH:MM)", from)
+		}
+
+		untilTime, err := time.Parse("15:04", until)
+		if err != nil {
+			return 0, 0, fmt.Errorf("filter active_until %s is not a valid time of day (HH:MM)", until)
+		}
+
+		fromMins = fromTime.Hour()*60 + fromTime.Minute()
+		untilMins = untilTime.Hour()*60 + untilTime.Minute()
+		if fromMins == untilMins {
+			return 0, 0, errors.New("filter active_from and active_until must differ")
+		}
+	}
+
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return 0, 0, fmt.Errorf("filter active_timezone %s is not a valid time zone", timezone)
+		}
+	}
+
+	return fromMins, untilMins, nil
+}
+
 // FilterTitle validates the title of a new or updated filter.
 func FilterTitle(title string) error {
 	if title == "" {
@@ -373,14 +648,32 @@ func FilterContexts(contexts []apimodel.FilterContext) error {
 func FilterAction(action apimodel.FilterAction) error {
 	switch action {
 	case apimodel.FilterActionWarn,
-		apimodel.FilterActionHide:
+		apimodel.FilterActionHide,
+		apimodel.FilterActionBlur,
+		apimodel.FilterActionNotify:
 		return nil
 	}
 	return fmt.Errorf(
-		"filter action '%s' was not recognized, valid options are '%s', '%s'",
+		"filter action '%s' was not recognized, valid options are '%s', '%s', '%s', '%s'",
 		action,
 		apimodel.FilterActionWarn,
 		apimodel.FilterActionHide,
+		apimodel.FilterActionBlur,
+		apimodel.FilterActionNotify,
+	)
+}
+
+func FilterMode(mode apimodel.FilterMode) error {
+	switch mode {
+	case apimodel.FilterModeBlock,
+		apimodel.FilterModeAllow:
+		return nil
+	}
+	return fmt.Errorf(
+		"filter mode '%s' was not recognized, valid options are '%s', '%s'",
+		mode,
+		apimodel.FilterModeBlock,
+		apimodel.FilterModeAllow,
 	)
 }
 
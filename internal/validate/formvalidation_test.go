@@ -20,9 +20,12 @@ package validate_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
@@ -381,6 +384,277 @@ func (suite *ValidationTestSuite) TestValidateEmojiShortcode() {
 	}
 }
 
+func (suite *ValidationTestSuite) TestValidateFilterDomains() {
+	domains, err := validate.FilterDomains([]string{
+		"Example.org",
+		"example.org.",
+		"fossbros-anonymous.io",
+		"pløpp.example.org",
+	})
+	suite.NoError(err)
+	suite.Equal([]string{
+		"example.org",
+		"fossbros-anonymous.io",
+		"xn--plpp-hra.example.org",
+	}, domains)
+
+	for _, domain := range []string{
+		"",
+		"https://example.org",
+		"someone@example.org",
+		"example.org:8080",
+	} {
+		_, err := validate.FilterDomains([]string{domain})
+		suite.Error(err, domain)
+	}
+}
+
+func (suite *ValidationTestSuite) TestValidateFilterAccounts() {
+	suite.NoError(validate.FilterAccounts([]string{"01F8MH1H7YV1Z7D2C8K2730QBF"}))
+	suite.EqualError(
+		validate.FilterAccounts([]string{"the_mighty_zork"}),
+		"account_id didn't match the expected ULID format for an ID (26 characters from the set 0123456789ABCDEFGHJKMNPQRSTVWXYZ)",
+	)
+}
+
+func (suite *ValidationTestSuite) TestValidateStatusExpiry() {
+	config.SetStatusesExpiryMin(5 * time.Minute)
+	config.SetStatusesExpiryMax(365 * 24 * time.Hour)
+
+	for seconds, expected := range map[int]string{
+		300:      "",
+		86400:    "",
+		31536000: "",
+		299:      "expires_in must be between 300 and 31536000 seconds, provided value was 299",
+		31536001: "expires_in must be between 300 and 31536000 seconds, provided value was 31536001",
+		-1:       "expires_in must be between 300 and 31536000 seconds, provided value was -1",
+	} {
+		err := validate.StatusExpiry("expires_in", seconds)
+		if expected == "" {
+			suite.NoError(err)
+		} else {
+			suite.EqualError(err, expected)
+		}
+	}
+}
+
+func (suite *ValidationTestSuite) TestValidateStatusContentType() {
+	config.SetStatusesContentTypes([]string{"text/plain", "text/markdown"})
+	suite.NoError(validate.StatusContentType("text/plain"))
+	suite.NoError(validate.StatusContentType("text/markdown"))
+	suite.EqualError(validate.StatusContentType("text/html"), "status content type 'text/html' was not recognized, valid options are 'text/plain', 'text/markdown'")
+
+	config.SetStatusesContentTypes([]string{"text/plain"})
+	suite.NoError(validate.StatusContentType("text/plain"))
+	suite.EqualError(validate.StatusContentType("text/markdown"), "status content type 'text/markdown' is not allowed on this instance, allowed options are 'text/plain'")
+}
+
+func (suite *ValidationTestSuite) TestValidateFilterActiveWindow() {
+	for _, test := range []struct {
+		days      []int
+		from      string
+		until     string
+		timezone  string
+		fromMins  int
+		untilMins int
+		err       string
+	}{
+		{nil, "", "", "", 0, 0, ""},
+		{[]int{0, 6}, "", "", "", 0, 0, ""},
+		{[]int{1, 2, 3, 4, 5}, "09:00", "17:30", "Europe/Amsterdam", 540, 1050, ""},
+		{nil, "22:00", "06:00", "", 1320, 360, ""},
+		{nil, "00:00", "23:59", "UTC", 0, 1439, ""},
+		{[]int{7}, "", "", "", 0, 0, "filter active day 7 is not a valid day of the week, valid days are 0 (Sunday) to 6 (Saturday)"},
+		{[]int{-1}, "", "", "", 0, 0, "filter active day -1 is not a valid day of the week, valid days are 0 (Sunday) to 6 (Saturday)"},
+		{nil, "09:00", "", "", 0, 0, "filter active_from and active_until must be provided together"},
+		{nil, "9am", "17:00", "", 0, 0, "filter active_from 9am is not a valid time of day (HH:MM)"},
+		{nil, "09:00", "24:00", "", 0, 0, "filter active_until 24:00 is not a valid time of day (HH:MM)"},
+		{nil, "09:00", "09:00", "", 0, 0, "filter active_from and active_until must differ"},
+		{nil, "", "", "Mars/Olympus_Mons", 0, 0, "filter active_timezone Mars/Olympus_Mons is not a valid time zone"},
+	} {
+		fromMins, untilMins, err := validate.FilterActiveWindow(test.days, test.from, test.until, test.timezone)
+		if test.err != "" {
+			suite.EqualError(err, test.err)
+			continue
+		}
+		suite.NoError(err)
+		suite.Equal(test.fromMins, fromMins)
+		suite.Equal(test.untilMins, untilMins)
+	}
+}
+
+func (suite *ValidationTestSuite) TestValidateAutoApproveFollowsAfter() {
+	for seconds, expected := range map[int]string{
+		0:        "",
+		604800:   "",
+		31536000: "",
+		31536001: "auto_approve_follows_after must be between 0 and 31536000 seconds, provided value was 31536001",
+		-1:       "auto_approve_follows_after must be between 0 and 31536000 seconds, provided value was -1",
+	} {
+		err := validate.AutoApproveFollowsAfter(seconds)
+		if expected == "" {
+			suite.NoError(err)
+		} else {
+			suite.EqualError(err, expected)
+		}
+	}
+}
+
+func (suite *ValidationTestSuite) TestValidateFilterKeywordRegexDisabled() {
+	config.SetInstanceAllowRegexFilters(false)
+
+	err := validate.FilterKeywordRegex(`fnord|snorkel`)
+	suite.EqualError(err, "regular expression filter keywords are not enabled on this instance")
+
+	// Literal keywords are unaffected.
+	suite.NoError(validate.FilterKeywordOrRegex(`fnord|snorkel`, false))
+}
+
+func (suite *ValidationTestSuite) TestValidateFilterKeywordRegex() {
+	config.SetInstanceAllowRegexFilters(true)
+	defer config.SetInstanceAllowRegexFilters(false)
+
+	for _, test := range []struct {
+		pattern string
+		err     string
+	}{
+		// Valid patterns.
+		{pattern: `fnord|snorkel`},
+		{pattern: `(?:cat|dog)s?`},
+		{pattern: `\p{Greek}+`},
+		{pattern: `colou?r`},
+		// Pathological for backtracking engines, but
+		// matched in linear time by RE2, so allowed.
+		{pattern: `(a+)+$`},
+		{pattern: `(x|x)*y`},
+		// Invalid patterns.
+		{
+			pattern: ``,
+			err:     "filter keyword must be provided, and must be no more than 200 chars",
+		},
+		{
+			pattern: `(fnord`,
+			err:     "filter keyword is not a valid regular expression: error parsing regexp: missing closing ): `(fnord`",
+		},
+		{
+			// Backreferences aren't supported by RE2.
+			pattern: `(fnord)\1`,
+			err:     "filter keyword is not a valid regular expression: error parsing regexp: invalid escape sequence: `\\1`",
+		},
+		{
+			pattern: `a{1001}`,
+			err:     "filter keyword is not a valid regular expression: error parsing regexp: invalid repeat count: `{1001}`",
+		},
+		{
+			pattern: strings.Repeat("a", 201),
+			err:     "filter keyword regular expression length must be no more than 200 chars, provided keyword was 201 chars",
+		},
+		{
+			pattern: `((a{10}){10}){10}`,
+			err:     "filter keyword regular expression is too complex: compiled to 1222 instructions, maximum is 1000",
+		},
+		{
+			pattern: `.*`,
+			err:     "filter keyword regular expression must not match empty text",
+		},
+		{
+			pattern: `fnord|`,
+			err:     "filter keyword regular expression must not match empty text",
+		},
+	} {
+		err := validate.FilterKeywordRegex(test.pattern)
+		if test.err == "" {
+			suite.NoError(err, test.pattern)
+		} else {
+			suite.EqualError(err, test.err, test.pattern)
+		}
+	}
+}
+
+func (suite *ValidationTestSuite) TestValidateAway() {
+	suite.NoError(validate.AwayMessage(""))
+	suite.NoError(validate.AwayMessage(strings.Repeat("💤", 500)))
+	suite.EqualError(
+		validate.AwayMessage(strings.Repeat("z", 501)),
+		"away_message must be less than 500 characters, but submitted away_message was 501 characters",
+	)
+
+	var (
+		start = time.Date(2024, 8, 1, 9, 0, 0, 0, time.UTC)
+		end   = start.Add(24 * time.Hour)
+	)
+
+	suite.NoError(validate.AwayWindow(time.Time{}, time.Time{}))
+	suite.NoError(validate.AwayWindow(start, time.Time{}))
+	suite.NoError(validate.AwayWindow(time.Time{}, end))
+	suite.NoError(validate.AwayWindow(start, end))
+	suite.EqualError(validate.AwayWindow(start, start), "away_ends_at must be after away_starts_at")
+	suite.EqualError(validate.AwayWindow(end, start), "away_ends_at must be after away_starts_at")
+}
+
+func (suite *ValidationTestSuite) TestValidatePronouns() {
+	suite.NoError(validate.Pronouns(""))
+	suite.NoError(validate.Pronouns("they/them"))
+	suite.NoError(validate.Pronouns(strings.Repeat("🦥", 64)))
+	suite.EqualError(
+		validate.Pronouns(strings.Repeat("a", 65)),
+		"pronouns must be less than 64 characters, but submitted pronouns were 65 characters",
+	)
+	suite.EqualError(validate.Pronouns("she/her\nthey/them"), "pronouns must not contain line breaks")
+}
+
+func (suite *ValidationTestSuite) TestValidateMediaDescription() {
+	const mediaID = "01F8MH8RMYQ6MSNY3JM2XT1CQ5"
+
+	// Not required, so anything goes.
+	suite.NoError(validate.MediaDescription(0, mediaID, "", false))
+	suite.NoError(validate.MediaDescription(0, mediaID, "a cat sitting on a keyboard", false))
+
+	// Required, so only a non-blank description will do.
+	suite.NoError(validate.MediaDescription(0, mediaID, "a cat sitting on a keyboard", true))
+	suite.EqualError(
+		validate.MediaDescription(0, mediaID, "", true),
+		"media attachment 1 (01F8MH8RMYQ6MSNY3JM2XT1CQ5) has no description: alt text is required for all media attached to statuses",
+	)
+	suite.EqualError(
+		validate.MediaDescription(3, mediaID, " \n\t ", true),
+		"media attachment 4 (01F8MH8RMYQ6MSNY3JM2XT1CQ5) has no description: alt text is required for all media attached to statuses",
+	)
+
+	// Min chars still applies on top.
+	config.SetMediaDescriptionMinChars(10)
+	defer config.SetMediaDescriptionMinChars(0)
+
+	suite.NoError(validate.MediaDescription(0, mediaID, "a cat sitting on a keyboard", true))
+	suite.EqualError(
+		validate.MediaDescription(0, mediaID, "a cat", false),
+		"media 01F8MH8RMYQ6MSNY3JM2XT1CQ5 description too short, at least 10 required",
+	)
+}
+
+func (suite *ValidationTestSuite) TestValidateFilterAction() {
+	for _, action := range []apimodel.FilterAction{
+		apimodel.FilterActionWarn,
+		apimodel.FilterActionHide,
+		apimodel.FilterActionBlur,
+		apimodel.FilterActionNotify,
+	} {
+		suite.NoError(validate.FilterAction(action))
+	}
+	suite.EqualError(validate.FilterAction("shout"), "filter action 'shout' was not recognized, valid options are 'warn', 'hide', 'blur', 'notify'")
+}
+
+func (suite *ValidationTestSuite) TestValidateRotatingPin() {
+	ids := []string{"01F8MHAMCHF6Y650WCRSCP4WMY", "01F8MHAYFKS4KMXF8K5Y1C0KRN"}
+
+	suite.NoError(validate.RotatingPin(nil, 0))
+	suite.NoError(validate.RotatingPin(ids, 0))
+	suite.NoError(validate.RotatingPin(ids, 60*60))
+	suite.EqualError(validate.RotatingPin(ids, 60), "rotating_pin_interval must be 0, or between 3600 and 31536000 seconds, provided value was 60")
+	suite.EqualError(validate.RotatingPin(ids, -1), "rotating_pin_interval must be 0, or between 3600 and 31536000 seconds, provided value was -1")
+	suite.EqualError(validate.RotatingPin(make([]string, 11), 60*60), "rotating_pin_status_ids may contain at most 10 statuses, provided 11")
+}
+
 func TestValidationTestSuite(t *testing.T) {
 	suite.Run(t, new(ValidationTestSuite))
 }
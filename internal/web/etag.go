@@ -41,6 +41,11 @@ func newETagCache() cache.TTLCache[string, eTagCacheEntry] {
 type eTagCacheEntry struct {
 	eTag         string
 	lastModified time.Time
+
+	// body is the generated response body
+	// this entry is for, if it's worth caching
+	// (eg., rss feeds), else empty.
+	body string
 }
 
 // generateEtag generates a strong (byte-for-byte) etag using
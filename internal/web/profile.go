@@ -102,16 +102,24 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		return
 	}
 
-	// Only generate RSS link if account has RSS enabled.
+	// If target account hides their web profile,
+	// only show it to them and logged in followers.
+	if errWithCode := m.processor.Account().WebProfileCheck(ctx, authed.Account, targetAccount.ID); errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	// Only generate RSS link if account has RSS
+	// enabled (and it's not off for a hidden profile).
 	var rssFeed string
-	if targetAccount.EnableRSS {
+	if targetAccount.EnableRSS && !targetAccount.HideWebProfile {
 		rssFeed = "/@" + targetAccount.Username + "/feed.rss"
 	}
 
-	// Only allow search engines / robots to
-	// index if account is discoverable.
+	// Only allow search engines / robots to index
+	// if account is discoverable, and not hidden.
 	var robotsMeta string
-	if targetAccount.Discoverable {
+	if targetAccount.Discoverable && !targetAccount.HideWebProfile {
 		robotsMeta = robotsMetaAllowSome
 	}
 
@@ -133,6 +141,13 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		}
 	}
 
+	// Get hashtags featured on the profile.
+	featuredTags, errWithCode := m.processor.Account().FeaturedTagsGet(ctx, authed.Account, targetAccount.ID)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
 	// Get statuses from maxStatusID onwards (or from top if empty string).
 	statusResp, errWithCode := m.processor.Account().WebStatusesGet(ctx, targetAccount.ID, maxStatusID)
 	if errWithCode != nil {
@@ -181,6 +196,7 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 			"statuses":         statusResp.Items,
 			"statuses_next":    statusResp.NextLink,
 			"pinned_statuses":  pinnedStatuses,
+			"featured_tags":    featuredTags,
 			"show_back_to_top": paging,
 		},
 	}
@@ -27,6 +27,7 @@ import (
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	processingaccount "github.com/superseriousbusiness/gotosocial/internal/processing/account"
 )
 
 const appRSSUTF8 = string(apiutil.AppRSSXML) + "; charset=utf-8"
@@ -58,14 +59,63 @@ func (m *Module) rssFeedGETHandler(c *gin.Context) {
 		return
 	}
 
+	m.serveRSSFeed(c, getRSSFeed, lastPostAt)
+}
+
+func (m *Module) rssTagFeedGETHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.AppRSSXML); err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Fetch + normalize username from URL.
+	username, errWithCode := apiutil.ParseUsername(c.Param(apiutil.UsernameKey))
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Usernames on our instance will always be lowercase.
+	//
+	// todo: https://github.com/superseriousbusiness/gotosocial/issues/1813
+	username = strings.ToLower(username)
+
+	// Fetch tag name from URL.
+	tagName, errWithCode := apiutil.ParseTagName(c.Param(apiutil.TagNameKey))
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Retrieve the getRSSFeed function from the processor.
+	// lastPostAt may be a zero time if account has never
+	// posted anything using this tag.
+	getRSSFeed, lastPostAt, errWithCode := m.processor.Account().GetRSSFeedForUsernameTag(c.Request.Context(), username, tagName)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	m.serveRSSFeed(c, getRSSFeed, lastPostAt)
+}
+
+// serveRSSFeed serves the rss feed returned by getRSSFeed, using the
+// ETag cache to respond 304 Not Modified where possible, and to avoid
+// regenerating the feed until something newer than lastPostAt is posted.
+func (m *Module) serveRSSFeed(c *gin.Context, getRSSFeed processingaccount.GetRSSFeed, lastPostAt time.Time) {
 	var (
-		rssFeed string // Stringified rss feed.
+		errWithCode gtserror.WithCode
+		rssFeed     string // Stringified rss feed.
 
 		cacheKey              = c.Request.URL.Path
 		cacheEntry, wasCached = m.eTagCache.Get(cacheKey)
 	)
 
-	if !wasCached || unixAfter(lastPostAt, cacheEntry.lastModified) {
+	if wasCached && !unixAfter(lastPostAt, cacheEntry.lastModified) {
+		// Nothing posted since the cached
+		// feed was generated, so reuse it.
+		rssFeed = cacheEntry.body
+	} else {
 		// We either have no ETag cache entry for this account's feed,
 		// or we have an expired cache entry (account has posted since
 		// the cache entry was last generated).
@@ -98,6 +148,7 @@ func (m *Module) rssFeedGETHandler(c *gin.Context) {
 		cacheEntry = eTagCacheEntry{
 			eTag:         eTag,
 			lastModified: lastModified,
+			body:         rssFeed,
 		}
 		m.eTagCache.Set(cacheKey, cacheEntry)
 	}
@@ -147,8 +198,9 @@ func (m *Module) rssFeedGETHandler(c *gin.Context) {
 	// than the values of the submitted headers would suggest.
 	//
 	// If we had a cache hit earlier, we may not have called the
-	// getRSSFeed function yet; if that's the case then do call it
-	// now because we definitely need it.
+	// getRSSFeed function yet, and the cache entry may not have a
+	// body; if that's the case then do call it now because we
+	// definitely need it.
 	if rssFeed == "" {
 		rssFeed, errWithCode = getRSSFeed()
 		if errWithCode != nil {
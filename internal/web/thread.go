@@ -110,6 +110,13 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		return
 	}
 
+	// If target account hides their web profile,
+	// hide their statuses from the web view too.
+	if errWithCode := m.processor.Account().WebProfileCheck(ctx, authed.Account, targetAccount.ID); errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
 	// Get the status itself from the processor using provided ID and authorization (if any).
 	status, errWithCode := m.processor.Status().WebGet(ctx, targetStatusID)
 	if errWithCode != nil {
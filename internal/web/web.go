@@ -42,6 +42,7 @@ const (
 	tagsPath           = "/tags/:" + apiutil.TagNameKey
 	customCSSPath      = profileGroupPath + "/custom.css"
 	rssFeedPath        = profileGroupPath + "/feed.rss"
+	rssTagFeedPath     = profileGroupPath + tagsPath + "/feed.rss"
 	assetsPathPrefix   = "/assets"
 	distPathPrefix     = assetsPathPrefix + "/dist"
 	themesPathPrefix   = assetsPathPrefix + "/themes"
@@ -115,6 +116,7 @@ func (m *Module) Route(r *router.Router, mi ...gin.HandlerFunc) {
 	r.AttachHandler(http.MethodGet, settingsPanelGlob, m.SettingsPanelHandler)
 	r.AttachHandler(http.MethodGet, customCSSPath, m.customCSSGETHandler)
 	r.AttachHandler(http.MethodGet, rssFeedPath, m.rssFeedGETHandler)
+	r.AttachHandler(http.MethodGet, rssTagFeedPath, m.rssTagFeedGETHandler)
 	r.AttachHandler(http.MethodGet, confirmEmailPath, m.confirmEmailGETHandler)
 	r.AttachHandler(http.MethodPost, confirmEmailPath, m.confirmEmailPOSTHandler)
 	r.AttachHandler(http.MethodGet, robotsPath, m.robotsGETHandler)
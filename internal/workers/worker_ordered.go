@@ -0,0 +1,287 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"sync"
+
+	"codeberg.org/gruf/go-runners"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/queue"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// OrderedFnWorkerPool is a worker pool of function
+// tasks, each queued under a key. Tasks sharing the
+// same key are guaranteed to run one at a time, in
+// the order they were pushed, while tasks under
+// differing keys may run concurrently across workers.
+//
+// Queued tasks are bounded by MaxPerKey and MaxTotal,
+// beyond which Push refuses new tasks so that callers
+// can push back on whoever is producing them. Stop
+// waits for all queued tasks to be run before return.
+type OrderedFnWorkerPool struct {
+
+	// MaxPerKey is the maximum number of tasks
+	// that may be pending under any one key,
+	// including the one being run. 0 = no limit.
+	MaxPerKey int
+
+	// MaxTotal is the maximum number of tasks
+	// that may be pending in total, including
+	// those being run. 0 = no limit.
+	MaxTotal int
+
+	// ready is a queue of keys that
+	// have pending tasks and are not
+	// currently held by any worker.
+	ready queue.SimpleQueue[string]
+
+	// pending maps keys to their queued
+	// tasks, where the first task in each
+	// slice is the next (or current) to run.
+	pending map[string][]func(context.Context)
+	total   int
+
+	// closed is set while stopping, to
+	// refuse new tasks, and drained is
+	// closed once all pending tasks are
+	// done, to notify the waiting Stop.
+	closed  bool
+	drained chan struct{}
+	mutex   sync.Mutex
+
+	// internal fields.
+	workers []*orderedFnWorker
+}
+
+// Push will queue given function task under key, to
+// be run after any tasks already queued under key.
+// Returns false if the task could not be queued, as
+// either the queue for key or the pool as a whole is
+// full, or the pool is stopping.
+func (p *OrderedFnWorkerPool) Push(key string, fn func(context.Context)) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.closed {
+		// Pool is stopping.
+		return false
+	}
+
+	if p.pending == nil {
+		// Lazily allocate tasks map.
+		p.pending = make(map[string][]func(context.Context))
+	}
+
+	tasks, exists := p.pending[key]
+	if (p.MaxPerKey > 0 && len(tasks) >= p.MaxPerKey) ||
+		(p.MaxTotal > 0 && p.total >= p.MaxTotal) {
+		// Queue is full.
+		return false
+	}
+
+	// Append task to those queued for key.
+	p.pending[key] = append(tasks, fn)
+	p.total++
+
+	if !exists {
+		// Key had no pending tasks,
+		// i.e. no worker holds it and
+		// it is not yet marked ready.
+		p.ready.Push(key)
+	}
+
+	return true
+}
+
+// Len returns the total number of pending tasks,
+// including any that are currently being run.
+func (p *OrderedFnWorkerPool) Len() int {
+	p.mutex.Lock()
+	n := p.total
+	p.mutex.Unlock()
+	return n
+}
+
+// next returns the next task to run for given key.
+func (p *OrderedFnWorkerPool) next(key string) func(context.Context) {
+	p.mutex.Lock()
+	fn := p.pending[key][0]
+	p.mutex.Unlock()
+	return fn
+}
+
+// done marks the current task for key as done, and
+// if further tasks remain marks the key ready again.
+func (p *OrderedFnWorkerPool) done(key string) {
+	p.mutex.Lock()
+
+	// Drop the completed task.
+	tasks := p.pending[key]
+	tasks[0] = nil
+	tasks = tasks[1:]
+	p.total--
+
+	if p.total == 0 && p.drained != nil {
+		// Notify waiting Stop
+		// that we're drained.
+		close(p.drained)
+		p.drained = nil
+	}
+
+	if len(tasks) == 0 {
+		// No more tasks.
+		delete(p.pending, key)
+	} else {
+		// Requeue key at the back of the ready
+		// queue so other keys get a fair turn.
+		p.pending[key] = tasks
+		p.ready.Push(key)
+	}
+
+	p.mutex.Unlock()
+}
+
+// Start will attempt to start 'n' workers.
+func (p *OrderedFnWorkerPool) Start(n int) {
+	// Check whether workers are
+	// set (is already running).
+	ok := (len(p.workers) > 0)
+	if ok {
+		return
+	}
+
+	// Allocate new workers slice.
+	p.workers = make([]*orderedFnWorker, n)
+	for i := range p.workers {
+
+		// Allocate new worker.
+		p.workers[i] = new(orderedFnWorker)
+		p.workers[i].pool = p
+
+		// Attempt to start worker.
+		// Return bool not useful
+		// here, as true = started,
+		// false = already running.
+		_ = p.workers[i].Start()
+	}
+}
+
+// Stop will attempt to stop contained workers,
+// refusing new tasks and waiting for those already
+// queued to be run before stopping the workers.
+func (p *OrderedFnWorkerPool) Stop() {
+	// Check whether workers are
+	// set (is currently running).
+	ok := (len(p.workers) == 0)
+	if ok {
+		return
+	}
+
+	p.mutex.Lock()
+	p.closed = true
+	drained := p.drained
+	if p.total > 0 && drained == nil {
+		drained = make(chan struct{})
+		p.drained = drained
+	}
+	p.mutex.Unlock()
+
+	if drained != nil {
+		// Wait for workers
+		// to drain queue.
+		<-drained
+	}
+
+	// Stop all running workers.
+	for i := range p.workers {
+
+		// return bool not useful
+		// here, as true = stopped,
+		// false = never running.
+		_ = p.workers[i].Stop()
+	}
+
+	// Unset workers slice.
+	p.workers = p.workers[:0]
+
+	// Accept tasks again,
+	// ready for a restart.
+	p.mutex.Lock()
+	p.closed = false
+	p.mutex.Unlock()
+}
+
+// orderedFnWorker feeds from the ready keys of an
+// OrderedFnWorkerPool{}, running the next queued task
+// of each key it pops. It does so in a single goroutine
+// with state management utilities.
+type orderedFnWorker struct {
+	pool    *OrderedFnWorkerPool
+	service runners.Service
+}
+
+// Start will attempt to start the worker.
+func (w *orderedFnWorker) Start() bool {
+	return w.service.GoRun(w.run)
+}
+
+// Stop will attempt to stop the worker.
+func (w *orderedFnWorker) Stop() bool {
+	return w.service.Stop()
+}
+
+// run wraps process to restart on any panic.
+func (w *orderedFnWorker) run(ctx context.Context) {
+	if w.pool == nil {
+		panic("not yet initialized")
+	}
+	log.Debugf(ctx, "%p: starting worker", w)
+	defer log.Debugf(ctx, "%p: stopped worker", w)
+	util.Must(func() { w.process(ctx) })
+}
+
+// process is the main ordered worker processing routine.
+func (w *orderedFnWorker) process(ctx context.Context) {
+	if w.pool == nil {
+		// we perform this check here just
+		// to ensure the compiler knows these
+		// variables aren't nil in the loop,
+		// even if already checked by caller.
+		panic("not yet initialized")
+	}
+
+	for {
+		// Block until pop next ready key.
+		key, ok := w.pool.ready.PopCtx(ctx)
+		if !ok {
+			return
+		}
+
+		// Run next task for key, ensuring
+		// the key is released even on panic
+		// so later tasks don't get stuck.
+		func() {
+			defer w.pool.done(key)
+			w.pool.next(key)(ctx)
+		}()
+	}
+}
@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
+)
+
+func TestOrderedFnWorkerPool(t *testing.T) {
+	const (
+		keys  = 8
+		tasks = 100
+	)
+
+	var pool workers.OrderedFnWorkerPool
+	pool.Start(4)
+	defer pool.Stop()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make(map[string][]int)
+		running = make(map[string]*atomic.Int32)
+	)
+
+	for k := 0; k < keys; k++ {
+		running[string(rune('a'+k))] = new(atomic.Int32)
+	}
+
+	wg.Add(keys * tasks)
+	for i := 0; i < tasks; i++ {
+		for k := 0; k < keys; k++ {
+			key, i := string(rune('a'+k)), i
+			pool.Push(key, func(context.Context) {
+				defer wg.Done()
+
+				// Ensure no other task for key is running.
+				if n := running[key].Add(1); n != 1 {
+					t.Errorf("%d concurrent tasks running for key %s", n, key)
+				}
+				defer running[key].Add(-1)
+
+				mu.Lock()
+				results[key] = append(results[key], i)
+				mu.Unlock()
+			})
+		}
+	}
+
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for tasks to complete")
+	}
+
+	// Ensure tasks for each key ran in order.
+	for key, result := range results {
+		if len(result) != tasks {
+			t.Fatalf("expected %d results for key %s, got %d", tasks, key, len(result))
+		}
+		for i := range result {
+			if result[i] != i {
+				t.Fatalf("out of order result for key %s: %v", key, result)
+			}
+		}
+	}
+}
+
+func TestOrderedFnWorkerPoolBounded(t *testing.T) {
+	pool := workers.OrderedFnWorkerPool{
+		MaxPerKey: 2,
+		MaxTotal:  3,
+	}
+
+	// Pool isn't started, so
+	// pushed tasks stay queued.
+	noop := func(context.Context) {}
+
+	if !pool.Push("a", noop) || !pool.Push("a", noop) {
+		t.Fatal("expected tasks within bounds to be queued")
+	}
+
+	if pool.Push("a", noop) {
+		t.Fatal("expected task over per-key bound to be refused")
+	}
+
+	if !pool.Push("b", noop) {
+		t.Fatal("expected task for other key to be queued")
+	}
+
+	if pool.Push("c", noop) {
+		t.Fatal("expected task over total bound to be refused")
+	}
+
+	if n := pool.Len(); n != 3 {
+		t.Fatalf("expected 3 pending tasks, got %d", n)
+	}
+}
+
+func TestOrderedFnWorkerPoolStopDrains(t *testing.T) {
+	var pool workers.OrderedFnWorkerPool
+	pool.Start(2)
+
+	var ran atomic.Int32
+	const tasks = 20
+
+	for i := 0; i < tasks; i++ {
+		pool.Push(string(rune('a'+i%4)), func(context.Context) {
+			time.Sleep(10 * time.Millisecond)
+			ran.Add(1)
+		})
+	}
+
+	// Stop should wait
+	// for all to be run.
+	pool.Stop()
+
+	if n := ran.Load(); n != tasks {
+		t.Fatalf("expected %d tasks run before stop returned, got %d", tasks, n)
+	}
+
+	if n := pool.Len(); n != 0 {
+		t.Fatalf("expected no pending tasks, got %d", n)
+	}
+
+	// Pool should accept tasks
+	// again, ready for a restart.
+	if !pool.Push("a", func(context.Context) {}) {
+		t.Fatal("expected task to be queued after stop")
+	}
+}
@@ -49,6 +49,18 @@ type Workers struct {
 	// for asynchronous dereferencer jobs.
 	Dereference FnWorkerPool
 
+	// Inbox provides a worker pool for asynchronous
+	// processing of activities POSTed to inboxes,
+	// keyed by actor URI so that activities from
+	// any one actor are processed in order.
+	Inbox OrderedFnWorkerPool
+
+	// Export provides a worker pool for building
+	// account export archives. It runs a single
+	// worker, so that exports (which read lots of
+	// media from storage) run one at a time.
+	Export FnWorkerPool
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -80,12 +92,26 @@ func (w *Workers) Start() {
 	n = 4 * maxprocs
 	w.Dereference.Start(n)
 	log.Infof(nil, "started %d dereference workers", n)
+
+	n = 4 * maxprocs
+	w.Inbox.MaxPerKey = inboxMaxPerActor
+	w.Inbox.MaxTotal = inboxMaxTotal
+	w.Inbox.Start(n)
+	log.Infof(nil, "started %d inbox workers", n)
+
+	w.Export.Start(1)
+	log.Info(nil, "started 1 export worker")
 }
 
 // Stop will stop all of the contained worker pools (and global scheduler).
 func (w *Workers) Stop() {
 	_ = w.Scheduler.Stop() // false = not running
 
+	// Stop inbox first, as draining it
+	// may queue work to the other pools.
+	w.Inbox.Stop()
+	log.Info(nil, "stopped inbox workers")
+
 	w.Delivery.Stop()
 	log.Info(nil, "stopped delivery workers")
 
@@ -97,8 +123,20 @@ func (w *Workers) Stop() {
 
 	w.Dereference.Stop()
 	log.Info(nil, "stopped dereference workers")
+
+	w.Export.Stop()
+	log.Info(nil, "stopped export workers")
 }
 
+// inboxMaxPerActor and inboxMaxTotal bound the
+// inbox activities queued for processing, from
+// any one actor and in total respectively, past
+// which remotes are asked to retry delivery later.
+const (
+	inboxMaxPerActor = 100
+	inboxMaxTotal    = 10000
+)
+
 // nocopy when embedded will signal linter to
 // error on pass-by-value of parent struct.
 type nocopy struct{}
@@ -12,14 +12,32 @@ EXPECT=$(cat << "EOF"
     "advanced-cookies-samesite": "strict",
     "advanced-csp-extra-uris": [],
     "advanced-header-filter-mode": "block",
+    "advanced-oauth-access-token-format": "jwt",
+    "advanced-oauth-demo-client-ids": [
+        "01J1CYJ4QRNFZD6WHQMZV7248G"
+    ],
+    "advanced-oauth-jwt-lifetime": 3600000000000,
+    "advanced-oauth-jwt-signing-key-path": "/gotosocial/jwt.pem",
+    "advanced-oauth-loopback-redirect-any-port": false,
+    "advanced-oauth-max-tokens-per-client": 10,
+    "advanced-oauth-registration-allow-localhost": true,
     "advanced-rate-limit-exceptions": [
         "192.0.2.0/24",
         "127.0.0.1/32"
     ],
     "advanced-rate-limit-requests": 6969,
     "advanced-sender-multiplier": -1,
+    "advanced-signed-fetch-exempt": [
+        "cdn.example.org",
+        "192.0.2.0/24"
+    ],
+    "advanced-streaming-ping-interval": 15000000000,
+    "advanced-streaming-queue-size": 100,
+    "advanced-streaming-slow-consumer": "drop-oldest",
     "advanced-throttling-multiplier": -1,
     "advanced-throttling-retry-after": 10000000000,
+    "advanced-webfinger-rate-limit-burst": 500,
+    "advanced-webfinger-rate-limit-requests": 120,
     "application-name": "gts",
     "bind-address": "127.0.0.1",
     "cache": {
@@ -68,7 +86,9 @@ EXPECT=$(cat << "EOF"
         "user-mute-ids-mem-ratio": 3,
         "user-mute-mem-ratio": 2,
         "visibility-mem-ratio": 2,
-        "webfinger-mem-ratio": 0.1
+        "webfinger-mem-ratio": 0.1,
+        "webfinger-response-max": 1000,
+        "webfinger-response-ttl": 600000000000
     },
     "config-path": "internal/config/testdata/test.yaml",
     "db-address": ":memory:",
@@ -93,11 +113,13 @@ EXPECT=$(cat << "EOF"
         "timeout": 10000000000,
         "tls-insecure-skip-verify": false
     },
+    "instance-allow-regex-filters": true,
     "instance-deliver-to-shared-inboxes": false,
     "instance-expose-peers": true,
     "instance-expose-public-timeline": true,
     "instance-expose-suspended": true,
     "instance-expose-suspended-web": true,
+    "instance-federation-delay": 30000000000,
     "instance-federation-mode": "allowlist",
     "instance-federation-spam-filter": true,
     "instance-inject-mastodon-version": true,
@@ -119,15 +141,24 @@ EXPECT=$(cat << "EOF"
     "media-cleanup-from": "00:00",
     "media-description-max-chars": 5000,
     "media-description-min-chars": 69,
+    "media-description-required": true,
     "media-emoji-local-max-size": 420,
     "media-emoji-remote-max-size": 420,
     "media-image-max-size": 420,
     "media-remote-cache-days": 30,
+    "media-thumbnail-max-size": 256,
+    "media-thumbnail-quality": 50,
     "media-video-max-size": 420,
     "metrics-auth-enabled": false,
     "metrics-auth-password": "",
     "metrics-auth-username": "",
     "metrics-enabled": false,
+    "notifications-expiry-exempt-types": [
+        "follow",
+        "follow_request"
+    ],
+    "notifications-read-max-age": 2592000000000000,
+    "notifications-read-max-count": 500,
     "oidc-admin-groups": [
         "steamy"
     ],
@@ -158,17 +189,46 @@ EXPECT=$(cat << "EOF"
     "smtp-port": 4269,
     "smtp-username": "sex-haver",
     "software-version": "",
+    "statuses-content-types": [
+        "text/markdown"
+    ],
+    "statuses-expiry-max": 604800000000000,
+    "statuses-expiry-min": 60000000000,
+    "statuses-language-detection": true,
     "statuses-max-chars": 69,
+    "statuses-media-allow-mixed": false,
     "statuses-media-max-files": 1,
     "statuses-poll-max-options": 1,
     "statuses-poll-option-max-chars": 50,
+    "statuses-scheduled-max-horizon": 2592000000000000,
+    "statuses-strip-tracking-params": true,
+    "statuses-tracking-params": [
+        "utm_*",
+        "fbclid"
+    ],
+    "statuses-tracking-params-skip-domains": [
+        "example.org"
+    ],
     "storage-backend": "local",
     "storage-local-base-path": "/root/store",
     "storage-s3-access-key": "minio",
     "storage-s3-bucket": "gts",
+    "storage-s3-concurrent-stream-parts": true,
     "storage-s3-endpoint": "localhost:9000",
+    "storage-s3-num-threads": 4,
+    "storage-s3-object-tags": [
+        "media-type",
+        "instance=gts.example.org"
+    ],
+    "storage-s3-part-size": 33554432,
+    "storage-s3-presigned-upload-expiry": 300000000000,
     "storage-s3-proxy": true,
     "storage-s3-secret-key": "miniostorage",
+    "storage-s3-sse": "sse-kms",
+    "storage-s3-sse-c-key": "",
+    "storage-s3-sse-kms-key-id": "gts-media",
+    "storage-s3-storage-class": "STANDARD_IA",
+    "storage-s3-storage-class-emojis": "STANDARD",
     "storage-s3-use-ssl": false,
     "syslog-address": "127.0.0.1:6969",
     "syslog-enabled": true,
@@ -225,9 +285,11 @@ GTS_INSTANCE_EXPOSE_SUSPENDED_WEB=true \
 GTS_INSTANCE_EXPOSE_PUBLIC_TIMELINE=true \
 GTS_INSTANCE_FEDERATION_MODE='allowlist' \
 GTS_INSTANCE_FEDERATION_SPAM_FILTER=true \
+GTS_INSTANCE_FEDERATION_DELAY='30s' \
 GTS_INSTANCE_DELIVER_TO_SHARED_INBOXES=false \
 GTS_INSTANCE_INJECT_MASTODON_VERSION=true \
 GTS_INSTANCE_LANGUAGES="nl,en-gb" \
+GTS_INSTANCE_ALLOW_REGEX_FILTERS=true \
 GTS_ACCOUNTS_ALLOW_CUSTOM_CSS=true \
 GTS_ACCOUNTS_CUSTOM_CSS_LENGTH=5000 \
 GTS_ACCOUNTS_REGISTRATION_OPEN=true \
@@ -235,8 +297,11 @@ GTS_ACCOUNTS_REASON_REQUIRED=false \
 GTS_MEDIA_IMAGE_MAX_SIZE=420 \
 GTS_MEDIA_VIDEO_MAX_SIZE=420 \
 GTS_MEDIA_DESCRIPTION_MIN_CHARS=69 \
+GTS_MEDIA_DESCRIPTION_REQUIRED=true \
 GTS_MEDIA_DESCRIPTION_MAX_CHARS=5000 \
 GTS_MEDIA_REMOTE_CACHE_DAYS=30 \
+GTS_MEDIA_THUMBNAIL_MAX_SIZE=256 \
+GTS_MEDIA_THUMBNAIL_QUALITY=50 \
 GTS_MEDIA_EMOJI_LOCAL_MAX_SIZE=420 \
 GTS_MEDIA_EMOJI_REMOTE_MAX_SIZE=420 \
 GTS_METRICS_AUTH_ENABLED=false \
@@ -249,11 +314,32 @@ GTS_STORAGE_S3_ENDPOINT='localhost:9000' \
 GTS_STORAGE_S3_USE_SSL='false' \
 GTS_STORAGE_S3_PROXY='true' \
 GTS_STORAGE_S3_BUCKET='gts' \
+GTS_STORAGE_S3_PRESIGNED_UPLOAD_EXPIRY='5m' \
+GTS_STORAGE_S3_PART_SIZE=33554432 \
+GTS_STORAGE_S3_NUM_THREADS=4 \
+GTS_STORAGE_S3_CONCURRENT_STREAM_PARTS=true \
+GTS_STORAGE_S3_SSE='sse-kms' \
+GTS_STORAGE_S3_SSE_KMS_KEY_ID='gts-media' \
+GTS_STORAGE_S3_OBJECT_TAGS='media-type,instance=gts.example.org' \
+GTS_STORAGE_S3_STORAGE_CLASS='STANDARD_IA' \
+GTS_STORAGE_S3_STORAGE_CLASS_EMOJIS='STANDARD' \
 GTS_STATUSES_MAX_CHARS=69 \
 GTS_STATUSES_CW_MAX_CHARS=420 \
 GTS_STATUSES_POLL_MAX_OPTIONS=1 \
 GTS_STATUSES_POLL_OPTIONS_MAX_CHARS=69 \
 GTS_STATUSES_MEDIA_MAX_FILES=1 \
+GTS_STATUSES_MEDIA_ALLOW_MIXED=false \
+GTS_STATUSES_STRIP_TRACKING_PARAMS=true \
+GTS_STATUSES_TRACKING_PARAMS='utm_*,fbclid' \
+GTS_STATUSES_TRACKING_PARAMS_SKIP_DOMAINS='example.org' \
+GTS_STATUSES_SCHEDULED_MAX_HORIZON='720h' \
+GTS_STATUSES_EXPIRY_MIN='1m' \
+GTS_STATUSES_EXPIRY_MAX='168h' \
+GTS_STATUSES_LANGUAGE_DETECTION=true \
+GTS_STATUSES_CONTENT_TYPES='text/markdown' \
+GTS_NOTIFICATIONS_READ_MAX_AGE='720h' \
+GTS_NOTIFICATIONS_READ_MAX_COUNT=500 \
+GTS_NOTIFICATIONS_EXPIRY_EXEMPT_TYPES='follow,follow_request' \
 GTS_LETS_ENCRYPT_ENABLED=false \
 GTS_LETS_ENCRYPT_PORT=8080 \
 GTS_LETS_ENCRYPT_CERT_DIR='/root/certs' \
@@ -283,8 +369,21 @@ GTS_ADVANCED_COOKIES_SAMESITE='strict' \
 GTS_ADVANCED_RATE_LIMIT_EXCEPTIONS="192.0.2.0/24,127.0.0.1/32" \
 GTS_ADVANCED_RATE_LIMIT_REQUESTS=6969 \
 GTS_ADVANCED_SENDER_MULTIPLIER=-1 \
+GTS_ADVANCED_SIGNED_FETCH_EXEMPT='cdn.example.org,192.0.2.0/24' \
+GTS_ADVANCED_OAUTH_REGISTRATION_ALLOW_LOCALHOST=true \
+GTS_ADVANCED_OAUTH_LOOPBACK_REDIRECT_ANY_PORT=false \
+GTS_ADVANCED_OAUTH_MAX_TOKENS_PER_CLIENT=10 \
+GTS_ADVANCED_OAUTH_DEMO_CLIENT_IDS='01J1CYJ4QRNFZD6WHQMZV7248G' \
+GTS_ADVANCED_OAUTH_ACCESS_TOKEN_FORMAT='jwt' \
+GTS_ADVANCED_OAUTH_JWT_SIGNING_KEY_PATH='/gotosocial/jwt.pem' \
+GTS_ADVANCED_OAUTH_JWT_LIFETIME='1h' \
+GTS_ADVANCED_STREAMING_PING_INTERVAL='15s' \
+GTS_ADVANCED_STREAMING_QUEUE_SIZE=100 \
+GTS_ADVANCED_STREAMING_SLOW_CONSUMER='drop-oldest' \
 GTS_ADVANCED_THROTTLING_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_RETRY_AFTER='10s' \
+GTS_ADVANCED_WEBFINGER_RATE_LIMIT_REQUESTS=120 \
+GTS_ADVANCED_WEBFINGER_RATE_LIMIT_BURST=500 \
 GTS_ADVANCED_HEADER_FILTER_MODE='block' \
 GTS_REQUEST_ID_HEADER='X-Trace-Id' \
 go run ./cmd/gotosocial/... --config-path internal/config/testdata/test.yaml debug config)
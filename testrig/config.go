@@ -80,6 +80,7 @@ func testDefaults() config.Configuration {
 				TagStr: "en-gb",
 			},
 		},
+		InstanceAllowRegexFilters: true,
 
 		AccountsRegistrationOpen: true,
 		AccountsReasonRequired:   true,
@@ -89,12 +90,15 @@ func testDefaults() config.Configuration {
 		MediaImageMaxSize:        10485760, // 10MiB
 		MediaVideoMaxSize:        41943040, // 40MiB
 		MediaDescriptionMinChars: 0,
+		MediaDescriptionRequired: false,
 		MediaDescriptionMaxChars: 500,
 		MediaRemoteCacheDays:     7,
 		MediaEmojiLocalMaxSize:   51200,          // 50KiB
 		MediaEmojiRemoteMaxSize:  102400,         // 100KiB
 		MediaCleanupFrom:         "00:00",        // midnight.
 		MediaCleanupEvery:        24 * time.Hour, // 1/day.
+		MediaThumbnailMaxSize:    512,
+		MediaThumbnailQuality:    70,
 
 		// the testrig only uses in-memory storage, so we can
 		// safely set this value to 'test' to avoid running storage
@@ -102,10 +106,17 @@ func testDefaults() config.Configuration {
 		StorageBackend:       "test",
 		StorageLocalBasePath: "",
 
-		StatusesMaxChars:           5000,
-		StatusesPollMaxOptions:     6,
-		StatusesPollOptionMaxChars: 50,
-		StatusesMediaMaxFiles:      6,
+		StatusesMaxChars:            5000,
+		StatusesPollMaxOptions:      6,
+		StatusesPollOptionMaxChars:  50,
+		StatusesMediaMaxFiles:       6,
+		StatusesMediaAllowMixed:     true,
+		StatusesScheduledMaxHorizon: 90 * 24 * time.Hour,
+
+		StatusesExpiryMin: 5 * time.Minute,
+		StatusesExpiryMax: 365 * 24 * time.Hour,
+
+		StatusesContentTypes: []string{"text/plain", "text/markdown"},
 
 		LetsEncryptEnabled:      false,
 		LetsEncryptPort:         0,
@@ -147,6 +158,13 @@ func testDefaults() config.Configuration {
 		AdvancedThrottlingMultiplier: 0, // disabled
 		AdvancedSenderMultiplier:     0, // 1 sender only, regardless of CPU
 
+		AdvancedStreamingQueueSize:    config.Defaults.AdvancedStreamingQueueSize,
+		AdvancedStreamingSlowConsumer: config.Defaults.AdvancedStreamingSlowConsumer,
+
+		AdvancedOAuthLoopbackRedirectAnyPort: config.Defaults.AdvancedOAuthLoopbackRedirectAnyPort,
+		AdvancedOAuthAccessTokenFormat:       config.Defaults.AdvancedOAuthAccessTokenFormat,
+		AdvancedOAuthJWTLifetime:             config.Defaults.AdvancedOAuthJWTLifetime,
+
 		SoftwareVersion: "0.0.0-testrig",
 
 		// simply use cache defaults.
@@ -61,8 +61,10 @@ var testModels = []interface{}{
 	&gtsmodel.Instance{},
 	&gtsmodel.Notification{},
 	&gtsmodel.RouterSession{},
+	&gtsmodel.ScheduledStatus{},
 	&gtsmodel.Token{},
 	&gtsmodel.Client{},
+	&gtsmodel.Consent{},
 	&gtsmodel.EmojiCategory{},
 	&gtsmodel.Tombstone{},
 	&gtsmodel.Report{},
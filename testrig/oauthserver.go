@@ -26,5 +26,9 @@ import (
 
 // NewTestOauthServer returns an oauth server with the given db
 func NewTestOauthServer(db db.DB) oauth.Server {
-	return oauth.New(context.Background(), db)
+	server, err := oauth.New(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+	return server
 }
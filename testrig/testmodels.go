@@ -101,28 +101,28 @@ func NewTestTokens() map[string]*gtsmodel.Token {
 func NewTestClients() map[string]*gtsmodel.Client {
 	clients := map[string]*gtsmodel.Client{
 		"instance_application": {
-			ID:     "01AY6P665V14JJR0AFVRT7311Y",
-			Secret: "baedee87-6d00-4cf5-87b9-4d78ee58ef01",
-			Domain: "http://localhost:8080",
-			UserID: "",
+			ID:           "01AY6P665V14JJR0AFVRT7311Y",
+			Secret:       "baedee87-6d00-4cf5-87b9-4d78ee58ef01",
+			RedirectURIs: []string{"http://localhost:8080"},
+			UserID:       "",
 		},
 		"admin_account": {
-			ID:     "01F8MGWSJCND9BWBD4WGJXBM93",
-			Secret: "dda8e835-2c9c-4bd2-9b8b-77c2e26d7a7a",
-			Domain: "http://localhost:8080",
-			UserID: "01F8MGWYWKVKS3VS8DV1AMYPGE", // admin_account
+			ID:           "01F8MGWSJCND9BWBD4WGJXBM93",
+			Secret:       "dda8e835-2c9c-4bd2-9b8b-77c2e26d7a7a",
+			RedirectURIs: []string{"http://localhost:8080"},
+			UserID:       "01F8MGWYWKVKS3VS8DV1AMYPGE", // admin_account
 		},
 		"local_account_1": {
-			ID:     "01F8MGV8AC3NGSJW0FE8W1BV70",
-			Secret: "c3724c74-dc3b-41b2-a108-0ea3d8399830",
-			Domain: "http://localhost:8080",
-			UserID: "01F8MGVGPHQ2D3P3X0454H54Z5", // local_account_1
+			ID:           "01F8MGV8AC3NGSJW0FE8W1BV70",
+			Secret:       "c3724c74-dc3b-41b2-a108-0ea3d8399830",
+			RedirectURIs: []string{"http://localhost:8080"},
+			UserID:       "01F8MGVGPHQ2D3P3X0454H54Z5", // local_account_1
 		},
 		"local_account_2": {
-			ID:     "01F8MGW47HN8ZXNHNZ7E47CDMQ",
-			Secret: "8f5603a5-c721-46cd-8f1b-2e368f51379f",
-			Domain: "http://localhost:8080",
-			UserID: "01F8MH1VYJAE00TVVGMM5JNJ8X", // local_account_2
+			ID:           "01F8MGW47HN8ZXNHNZ7E47CDMQ",
+			Secret:       "8f5603a5-c721-46cd-8f1b-2e368f51379f",
+			RedirectURIs: []string{"http://localhost:8080"},
+			UserID:       "01F8MH1VYJAE00TVVGMM5JNJ8X", // local_account_2
 		},
 	}
 	return clients
@@ -650,44 +650,80 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 func NewTestAccountSettings() map[string]*gtsmodel.AccountSettings {
 	return map[string]*gtsmodel.AccountSettings{
 		"unconfirmed_account": {
-			AccountID:       "01F8MH0BBE4FHXPH513MBVFHB0",
-			CreatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			UpdatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(false),
-			HideCollections: util.Ptr(false),
+			AccountID:                "01F8MH0BBE4FHXPH513MBVFHB0",
+			CreatedAt:                TimeMustParse("2022-06-04T13:12:00Z"),
+			UpdatedAt:                TimeMustParse("2022-06-04T13:12:00Z"),
+			Privacy:                  gtsmodel.VisibilityPublic,
+			Sensitive:                util.Ptr(false),
+			Language:                 "en",
+			EnableRSS:                util.Ptr(false),
+			HideCollections:          util.Ptr(false),
+			ReviewLocalFollows:       util.Ptr(false),
+			HideWebProfile:           util.Ptr(false),
+			AwayEnabled:              util.Ptr(false),
+			AwayAutoReply:            util.Ptr(false),
+			RequireMediaDescriptions: util.Ptr(false),
+			DefaultMediaSensitive:    util.Ptr(false),
+			DetectLanguage:           util.Ptr(false),
+			LocalOnly:                util.Ptr(false),
+			ContinueThread:           util.Ptr(false),
 		},
 		"admin_account": {
-			AccountID:       "01F8MH17FWEB39HZJ76B6VXSKF",
-			CreatedAt:       TimeMustParse("2022-05-17T13:10:59Z"),
-			UpdatedAt:       TimeMustParse("2022-05-17T13:10:59Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(true),
-			HideCollections: util.Ptr(false),
+			AccountID:                "01F8MH17FWEB39HZJ76B6VXSKF",
+			CreatedAt:                TimeMustParse("2022-05-17T13:10:59Z"),
+			UpdatedAt:                TimeMustParse("2022-05-17T13:10:59Z"),
+			Privacy:                  gtsmodel.VisibilityPublic,
+			Sensitive:                util.Ptr(false),
+			Language:                 "en",
+			EnableRSS:                util.Ptr(true),
+			HideCollections:          util.Ptr(false),
+			ReviewLocalFollows:       util.Ptr(false),
+			HideWebProfile:           util.Ptr(false),
+			AwayEnabled:              util.Ptr(false),
+			AwayAutoReply:            util.Ptr(false),
+			RequireMediaDescriptions: util.Ptr(false),
+			DefaultMediaSensitive:    util.Ptr(false),
+			DetectLanguage:           util.Ptr(false),
+			LocalOnly:                util.Ptr(false),
+			ContinueThread:           util.Ptr(false),
 		},
 		"local_account_1": {
-			AccountID:       "01F8MH1H7YV1Z7D2C8K2730QBF",
-			CreatedAt:       TimeMustParse("2022-05-20T11:09:18Z"),
-			UpdatedAt:       TimeMustParse("2022-05-20T11:09:18Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(true),
-			HideCollections: util.Ptr(false),
+			AccountID:                "01F8MH1H7YV1Z7D2C8K2730QBF",
+			CreatedAt:                TimeMustParse("2022-05-20T11:09:18Z"),
+			UpdatedAt:                TimeMustParse("2022-05-20T11:09:18Z"),
+			Privacy:                  gtsmodel.VisibilityPublic,
+			Sensitive:                util.Ptr(false),
+			Language:                 "en",
+			EnableRSS:                util.Ptr(true),
+			HideCollections:          util.Ptr(false),
+			ReviewLocalFollows:       util.Ptr(false),
+			HideWebProfile:           util.Ptr(false),
+			AwayEnabled:              util.Ptr(false),
+			AwayAutoReply:            util.Ptr(false),
+			RequireMediaDescriptions: util.Ptr(false),
+			DefaultMediaSensitive:    util.Ptr(false),
+			DetectLanguage:           util.Ptr(false),
+			LocalOnly:                util.Ptr(false),
+			ContinueThread:           util.Ptr(false),
 		},
 		"local_account_2": {
-			AccountID:       "01F8MH5NBDF2MV7CTC4Q5128HF",
-			CreatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			UpdatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			Privacy:         gtsmodel.VisibilityFollowersOnly,
-			Sensitive:       util.Ptr(true),
-			Language:        "fr",
-			EnableRSS:       util.Ptr(false),
-			HideCollections: util.Ptr(true),
+			AccountID:                "01F8MH5NBDF2MV7CTC4Q5128HF",
+			CreatedAt:                TimeMustParse("2022-06-04T13:12:00Z"),
+			UpdatedAt:                TimeMustParse("2022-06-04T13:12:00Z"),
+			Privacy:                  gtsmodel.VisibilityFollowersOnly,
+			Sensitive:                util.Ptr(true),
+			Language:                 "fr",
+			EnableRSS:                util.Ptr(false),
+			HideCollections:          util.Ptr(true),
+			ReviewLocalFollows:       util.Ptr(false),
+			HideWebProfile:           util.Ptr(false),
+			AwayEnabled:              util.Ptr(false),
+			AwayAutoReply:            util.Ptr(false),
+			RequireMediaDescriptions: util.Ptr(false),
+			DefaultMediaSensitive:    util.Ptr(false),
+			DetectLanguage:           util.Ptr(false),
+			LocalOnly:                util.Ptr(false),
+			ContinueThread:           util.Ptr(false),
 		},
 	}
 }
@@ -141,6 +141,8 @@ export const gtsApi = createApi({
 		"InstanceRules",
 		"HTTPHeaderAllows",
 		"HTTPHeaderBlocks",
+		"Consent",
+		"Session",
 	],
 	endpoints: (build) => ({
 		instanceV1: build.query<InstanceV1, void>({
@@ -24,7 +24,7 @@ import type {
 	UpdateAliasesFormData
 } from "../../types/migration";
 import type { Theme } from "../../types/theme";
-import { User } from "../../types/user";
+import type { Consent, Session, User } from "../../types/user";
 
 const extended = gtsApi.injectEndpoints({
 	endpoints: (build) => ({
@@ -84,6 +84,32 @@ const extended = gtsApi.injectEndpoints({
 			query: () => ({
 				url: `/api/v1/accounts/themes`
 			})
+		}),
+		consents: build.query<Consent[], void>({
+			query: () => ({
+				url: `/api/v1/user/consents`
+			}),
+			providesTags: [{ type: "Consent", id: "LIST" }],
+		}),
+		deleteConsent: build.mutation<any, string>({
+			query: (id) => ({
+				method: "DELETE",
+				url: `/api/v1/user/consents/${id}`
+			}),
+			invalidatesTags: [{ type: "Consent", id: "LIST" }],
+		}),
+		sessions: build.query<Session[], void>({
+			query: () => ({
+				url: `/api/v1/user/sessions`
+			}),
+			providesTags: [{ type: "Session", id: "LIST" }],
+		}),
+		deleteSession: build.mutation<any, string>({
+			query: (id) => ({
+				method: "DELETE",
+				url: `/api/v1/user/sessions/${id}`
+			}),
+			invalidatesTags: [{ type: "Session", id: "LIST" }],
 		})
 	})
 });
@@ -96,4 +122,8 @@ export const {
 	useAliasAccountMutation,
 	useMoveAccountMutation,
 	useAccountThemesQuery,
+	useConsentsQuery,
+	useDeleteConsentMutation,
+	useSessionsQuery,
+	useDeleteSessionMutation,
 } = extended;
@@ -50,6 +50,7 @@ export interface Account {
 	bot: boolean,
 	created_at: string,
 	note: string,
+	pronouns?: string,
 	url: string,
 	avatar: string,
 	avatar_static: string,
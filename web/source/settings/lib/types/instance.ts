@@ -83,6 +83,7 @@ export interface InstanceStatuses {
     max_media_attachments:       number;
     characters_reserved_per_url: number;
     supported_mime_types:        string[];
+    supported_visibilities?:     string[];
 }
 
 export interface InstanceStats {
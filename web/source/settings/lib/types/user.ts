@@ -32,3 +32,35 @@ export interface User {
 	approved: boolean;
 	reset_password_sent_at?: string;
 }
+
+/**
+ * An application the user approved, and
+ * asked to remember approval for.
+ */
+export interface Consent {
+	id: string;
+	application: {
+		name: string;
+		website?: string;
+	};
+	scope: string;
+	created_at: string;
+	updated_at: string;
+}
+
+/**
+ * An access token held by the user, ie., a
+ * device or app logged in to their account.
+ */
+export interface Session {
+	id: string;
+	application: {
+		name: string;
+		website?: string;
+	};
+	device_name?: string;
+	scope: string;
+	created_at: string;
+	last_used_at?: string;
+	current: boolean;
+}
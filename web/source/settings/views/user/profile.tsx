@@ -35,7 +35,8 @@ import {
 	TextArea,
 	FileInput,
 	Checkbox,
-	RadioGroup
+	RadioGroup,
+	Select
 } from "../../components/form/inputs";
 
 import FormWithData from "../../lib/form/form-with-data";
@@ -63,10 +64,13 @@ function UserProfileForm({ data: profile }) {
 		- bool locked
 		- string display_name
 		- string note
+		- string pronouns
+		- string source[bio_content_type]
 		- file avatar
 		- file header
 		- bool enable_rss
 		- bool hide_collections
+		- bool hide_web_profile
 		- string custom_css (if enabled)
 		- string theme
 	*/
@@ -96,11 +100,14 @@ function UserProfileForm({ data: profile }) {
 		header: useFileInput("header", { withPreview: true }),
 		displayName: useTextInput("display_name", { source: profile }),
 		note: useTextInput("note", { source: profile, valueSelector: (p) => p.source?.note }),
+		pronouns: useTextInput("pronouns", { source: profile, valueSelector: (p) => p.pronouns ?? "" }),
+		bioContentType: useTextInput("source[bio_content_type]", { source: profile, valueSelector: (p) => p.source?.bio_content_type ?? "" }),
 		bot: useBoolInput("bot", { source: profile }),
 		locked: useBoolInput("locked", { source: profile }),
 		discoverable: useBoolInput("discoverable", { source: profile}),
 		enableRSS: useBoolInput("enable_rss", { source: profile }),
 		hideCollections: useBoolInput("hide_collections", { source: profile }),
+		hideWebProfile: useBoolInput("hide_web_profile", { source: profile }),
 		fields: useFieldArrayInput("fields_attributes", {
 			defaultValue: profile?.source?.fields,
 			length: instanceConfig.maxPinnedFields
@@ -177,12 +184,26 @@ function UserProfileForm({ data: profile }) {
 				label="Display name"
 				placeholder="A GoToSocial user"
 			/>
+			<TextInput
+				field={form.pronouns}
+				label="Pronouns"
+				placeholder="they/them"
+				maxLength={64}
+			/>
 			<TextArea
 				field={form.note}
 				label="Bio"
-				placeholder="Just trying out GoToSocial, my pronouns are they/them and I like sloths."
+				placeholder="Just trying out GoToSocial, and I like sloths."
 				rows={8}
 			/>
+			<Select field={form.bioContentType} label="Bio format" options={
+				<>
+					<option value="">Same as default post format</option>
+					<option value="text/plain">Plain</option>
+					<option value="text/markdown">Markdown</option>
+				</>
+			}>
+			</Select>
 			<b>Profile fields</b>
 			<ProfileFields
 				field={form.fields}
@@ -215,6 +236,10 @@ function UserProfileForm({ data: profile }) {
 				field={form.hideCollections}
 				label="Hide who you follow / are followed by"
 			/>
+			<Checkbox
+				field={form.hideWebProfile}
+				label="Hide web profile from visitors who aren't logged in as a follower (also turns off RSS)"
+			/>
 
 			<div className="form-section-docs">
 				<h3>Advanced</h3>
@@ -20,14 +20,14 @@
 import React from "react";
 import { useTextInput, useBoolInput } from "../../lib/form";
 import useFormSubmit from "../../lib/form/submit";
-import { Select, TextInput, Checkbox } from "../../components/form/inputs";
+import { Select, TextInput, TextArea, Checkbox } from "../../components/form/inputs";
 import FormWithData from "../../lib/form/form-with-data";
 import Languages from "../../components/languages";
 import MutationButton from "../../components/form/mutation-button";
 import { useVerifyCredentialsQuery } from "../../lib/query/oauth";
-import { useEmailChangeMutation, usePasswordChangeMutation, useUpdateCredentialsMutation, useUserQuery } from "../../lib/query/user";
+import { useConsentsQuery, useDeleteConsentMutation, useDeleteSessionMutation, useEmailChangeMutation, usePasswordChangeMutation, useSessionsQuery, useUpdateCredentialsMutation, useUserQuery } from "../../lib/query/user";
 import Loading from "../../components/loading";
-import { User } from "../../lib/types/user";
+import { Consent, Session, User } from "../../lib/types/user";
 import { useInstanceV1Query } from "../../lib/query/gts-api";
 
 export default function UserSettings() {
@@ -43,15 +43,27 @@ function UserSettingsForm({ data }) {
 	/* form keys
 		- string source[privacy]
 		- bool source[sensitive]
+		- bool source[default_media_sensitive]
 		- string source[language]
 		- string source[status_content_type]
+		- string source[default_content_warning]
+		- bool source[require_media_descriptions]
+		- bool source[detect_language]
+		- bool source[local_only]
+		- bool source[continue_thread]
 	 */
 
 	const form = {
 		defaultPrivacy: useTextInput("source[privacy]", { source: data, defaultValue: "unlisted" }),
 		isSensitive: useBoolInput("source[sensitive]", { source: data }),
+		isMediaSensitive: useBoolInput("source[default_media_sensitive]", { source: data }),
 		language: useTextInput("source[language]", { source: data, valueSelector: (s) => s.source.language?.toUpperCase() ?? "EN" }),
 		statusContentType: useTextInput("source[status_content_type]", { source: data, defaultValue: "text/plain" }),
+		defaultContentWarning: useTextInput("source[default_content_warning]", { source: data, defaultValue: "" }),
+		requireMediaDescriptions: useBoolInput("source[require_media_descriptions]", { source: data }),
+		detectLanguage: useBoolInput("source[detect_language]", { source: data }),
+		localOnly: useBoolInput("source[local_only]", { source: data }),
+		continueThread: useBoolInput("source[continue_thread]", { source: data }),
 	};
 
 	const [submitForm, result] = useFormSubmit(form, useUpdateCredentialsMutation());
@@ -75,6 +87,10 @@ function UserSettingsForm({ data }) {
 					<Languages />
 				}>
 				</Select>
+				<Checkbox
+					field={form.detectLanguage}
+					label="Detect the language of my posts, and only use the default post language if unsure"
+				/>
 				<Select field={form.defaultPrivacy} label="Default post privacy" options={
 					<>
 						<option value="private">Private / followers-only</option>
@@ -83,6 +99,14 @@ function UserSettingsForm({ data }) {
 					</>
 				}>
 				</Select>
+				<Checkbox
+					field={form.localOnly}
+					label="Make my posts local-only (not federated) by default"
+				/>
+				<Checkbox
+					field={form.continueThread}
+					label="Make my new posts reply to my previous post by default, continuing a thread"
+				/>
 				<Select field={form.statusContentType} label="Default post (and bio) format" options={
 					<>
 						<option value="text/plain">Plain (default)</option>
@@ -94,18 +118,99 @@ function UserSettingsForm({ data }) {
 					field={form.isSensitive}
 					label="Mark my posts as sensitive by default"
 				/>
+				<Checkbox
+					field={form.isMediaSensitive}
+					label="Mark my posts with media attached as sensitive by default (hides the media)"
+				/>
+				<TextInput
+					field={form.defaultContentWarning}
+					label="Default content warning (leave blank for none)"
+					placeholder="e.g. long post, politics"
+				/>
+				<Checkbox
+					field={form.requireMediaDescriptions}
+					label="Don't let me post media without a description (alt text)"
+				/>
 				<MutationButton
 					disabled={false}
 					label="Save settings"
 					result={result}
 				/>
 			</form>
+			<AwayMode data={data} />
 			<PasswordChange />
 			<EmailChange />
+			<Sessions />
+			<Consents />
 		</>
 	);
 }
 
+function AwayMode({ data }) {
+	/* form keys
+		- bool source[away_enabled]
+		- string source[away_message]
+		- string source[away_starts_at]
+		- string source[away_ends_at]
+		- bool source[away_auto_reply]
+	 */
+
+	const form = {
+		enabled: useBoolInput("source[away_enabled]", { source: data }),
+		message: useTextInput("source[away_message]", { source: data, defaultValue: "" }),
+		startsAt: useTextInput("source[away_starts_at]", { source: data, defaultValue: "" }),
+		endsAt: useTextInput("source[away_ends_at]", { source: data, defaultValue: "" }),
+		autoReply: useBoolInput("source[away_auto_reply]", { source: data }),
+	};
+
+	const [submitForm, result] = useFormSubmit(form, useUpdateCredentialsMutation());
+
+	return (
+		<form className="user-settings" onSubmit={submitForm}>
+			<div className="form-section-docs">
+				<h3>Away Mode</h3>
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/settings/#away-mode"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about these settings (opens in a new tab)
+				</a>
+			</div>
+			<Checkbox
+				field={form.enabled}
+				label="Enable away mode"
+			/>
+			<TextArea
+				field={form.message}
+				label="Away message (shown on your profile while away)"
+				placeholder="e.g. On holiday until the 20th, I'll reply when I'm back!"
+				rows={3}
+			/>
+			<TextInput
+				field={form.startsAt}
+				label="Away from (optional, UTC)"
+				placeholder="e.g. 2024-08-01T09:00:00Z"
+			/>
+			<TextInput
+				field={form.endsAt}
+				label="Away until (optional, UTC)"
+				placeholder="e.g. 2024-08-20T17:00:00Z"
+			/>
+			<Checkbox
+				field={form.autoReply}
+				label="Automatically reply to mentions with my away message (at most once a day per account)"
+			/>
+			<MutationButton
+				disabled={false}
+				label="Save away mode"
+				result={result}
+			/>
+		</form>
+	);
+}
+
 function PasswordChange() {
 	// Load instance data.
 	const {
@@ -331,3 +436,105 @@ function EmailChangeForm({user, oidcEnabled}: { user: User, oidcEnabled?: boolea
 		</form>
 	);
 }
+
+function Consents() {
+	const { data: consents, isLoading } = useConsentsQuery();
+
+	return (
+		<div className="consents">
+			<div className="form-section-docs">
+				<h3>Remembered Applications</h3>
+				<p>
+					These applications were authorized with "remember my approval" ticked,
+					so they can be authorized again without asking you, unless they ask
+					for more access than you approved. Revoking approval for an application
+					means you'll be asked again next time; it doesn't log the application out.
+				</p>
+			</div>
+			{ isLoading
+				? <Loading />
+				: <div className="list">
+					{ consents?.length
+						? consents.map((consent) => <ConsentEntry key={consent.id} consent={consent} />)
+						: <div className="entry">No remembered applications.</div>
+					}
+				</div>
+			}
+		</div>
+	);
+}
+
+function ConsentEntry({ consent }: { consent: Consent }) {
+	const [ deleteConsent, deleteResult ] = useDeleteConsentMutation();
+
+	return (
+		<div className="entry">
+			<span>
+				<b>{consent.application.name}</b> with scope <code>{consent.scope}</code>
+			</span>
+			<MutationButton
+				type="button"
+				onClick={() => deleteConsent(consent.id)}
+				label="Revoke"
+				result={deleteResult}
+				className="button danger"
+				showError={false}
+				disabled={false}
+			/>
+		</div>
+	);
+}
+
+function Sessions() {
+	const { data: sessions, isLoading } = useSessionsQuery();
+
+	return (
+		<div className="consents">
+			<div className="form-section-docs">
+				<h3>Active Sessions</h3>
+				<p>
+					These are the devices and applications that are logged in to your
+					account. If you don't recognize one, revoke it to log it out.
+				</p>
+			</div>
+			{ isLoading
+				? <Loading />
+				: <div className="list">
+					{ sessions?.length
+						? sessions.map((session) => <SessionEntry key={session.id} session={session} />)
+						: <div className="entry">No active sessions.</div>
+					}
+				</div>
+			}
+		</div>
+	);
+}
+
+function SessionEntry({ session }: { session: Session }) {
+	const [ deleteSession, deleteResult ] = useDeleteSessionMutation();
+
+	const lastUsed = session.last_used_at
+		? new Date(session.last_used_at).toLocaleString()
+		: "never";
+
+	return (
+		<div className="entry">
+			<span>
+				<b>{session.application.name}</b>
+				{ session.device_name && <> on <b>{session.device_name}</b></> }
+				{ session.current && <> (this session)</> }
+				<br/>
+				Last used: {lastUsed}, with scope <code>{session.scope}</code>
+			</span>
+			<MutationButton
+				type="button"
+				onClick={() => deleteSession(session.id)}
+				label="Revoke"
+				result={deleteResult}
+				className="button danger"
+				showError={false}
+				disabled={false}
+			/>
+		</div>
+	);
+}